@@ -0,0 +1,290 @@
+package notify
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"html"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ErrAckTimeout signals that a notification was not acknowledged in time.
+var ErrAckTimeout = errors.New("acknowledgement timed out")
+
+// ErrUnknownAckToken signals that an acknowledgement token was never registered or was forgotten.
+var ErrUnknownAckToken = errors.New("unknown acknowledgement token")
+
+// Ack is the acknowledgement of a notification.
+type Ack struct {
+	// Token is the token the notification was sent with.
+	Token string
+	// By identifies who acknowledged the notification, e.g. a user ID or mail address, if known.
+	By string
+	// Time is the time of the acknowledgement.
+	Time time.Time
+}
+
+// ackState is the state of a registered token. done is closed once the token is acknowledged.
+type ackState struct {
+	done chan struct{}
+	ack  Ack
+}
+
+// Acks tracks the acknowledgement of notifications. Notifications are sent with a token, see ContextWithAck, and are
+// acknowledged through a link, see ServeHTTP, by replying to them, see HandleReply, or by calling Acknowledge. Callers
+// can query or wait for the acknowledgement of a token. It is safe for concurrent use.
+type Acks struct {
+	mu       sync.Mutex
+	baseURL  string
+	pending  map[string]*ackState
+	receipts map[string]string
+	now      func() time.Time
+}
+
+// NewAcks returns a new tracker. If baseURL is not empty, notifications sent with a token carry an "Acknowledge" link
+// to baseURL, which must be served by the tracker, see ServeHTTP.
+func NewAcks(baseURL string) *Acks {
+	return &Acks{
+		baseURL:  baseURL,
+		pending:  make(map[string]*ackState),
+		receipts: make(map[string]string),
+		now:      time.Now,
+	}
+}
+
+// Register registers and returns a new random token.
+func (a *Acks) Register() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	token := hex.EncodeToString(b)
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.pending[token] = &ackState{done: make(chan struct{})}
+
+	return token
+}
+
+// Forget removes the token and the receipts of its notifications from the tracker. Trackers keep tokens until they are
+// forgotten, so forget them once they are no longer of interest.
+func (a *Acks) Forget(token string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	delete(a.pending, token)
+	for key, t := range a.receipts {
+		if t == token {
+			delete(a.receipts, key)
+		}
+	}
+}
+
+// URL returns the link that acknowledges the token, or an empty string if the tracker has no base URL.
+func (a *Acks) URL(token string) string {
+	if a.baseURL == "" {
+		return ""
+	}
+
+	separator := "?"
+	if strings.Contains(a.baseURL, "?") {
+		separator = "&"
+	}
+
+	return a.baseURL + separator + "token=" + url.QueryEscape(token)
+}
+
+// Acknowledge acknowledges the token. Acknowledging a token again has no effect, the first acknowledgement is kept.
+func (a *Acks) Acknowledge(token, by string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	state, ok := a.pending[token]
+	if !ok {
+		return ErrUnknownAckToken
+	}
+
+	select {
+	case <-state.done:
+	default:
+		state.ack = Ack{Token: token, By: by, Time: a.now()}
+		close(state.done)
+	}
+
+	return nil
+}
+
+// Acknowledged returns the acknowledgement of the token and whether it was acknowledged.
+func (a *Acks) Acknowledged(token string) (Ack, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	state, ok := a.pending[token]
+	if !ok {
+		return Ack{}, false
+	}
+
+	select {
+	case <-state.done:
+		return state.ack, true
+	default:
+		return Ack{}, false
+	}
+}
+
+// Wait waits for the token to be acknowledged and returns the acknowledgement. It returns ErrAckTimeout if the token
+// is not acknowledged within the timeout, the error of the context if it is done first and ErrUnknownAckToken if the
+// token is unknown. A timeout of zero or less waits until the context is done.
+func (a *Acks) Wait(ctx context.Context, token string, timeout time.Duration) (Ack, error) {
+	a.mu.Lock()
+	state, ok := a.pending[token]
+	a.mu.Unlock()
+	if !ok {
+		return Ack{}, ErrUnknownAckToken
+	}
+
+	var expired <-chan time.Time
+	if timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		expired = timer.C
+	}
+
+	select {
+	case <-state.done:
+		a.mu.Lock()
+		defer a.mu.Unlock()
+		return state.ack, nil
+	case <-expired:
+		return Ack{}, ErrAckTimeout
+	case <-ctx.Done():
+		return Ack{}, ctx.Err()
+	}
+}
+
+// receiptKey identifies a sent message by the receiver it was sent to and its message ID.
+func receiptKey(receipt Receipt) string {
+	return receipt.Receiver + "\x00" + receipt.MessageID
+}
+
+// recordReceipt remembers that the message identified by the receipt was sent with the token.
+func (a *Acks) recordReceipt(token string, receipt Receipt) {
+	if receipt.MessageID == "" {
+		return
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if _, ok := a.pending[token]; ok {
+		a.receipts[receiptKey(receipt)] = token
+	}
+}
+
+// HandleReply is a ReplyHandler, see Receive, that acknowledges notifications replied to with "ACK". Replies name the
+// token, as in "ACK <token>", or reply to the notification directly on services that report which message was replied
+// to. All other replies are ignored.
+func (a *Acks) HandleReply(_ context.Context, reply Reply) error {
+	fields := strings.Fields(reply.Text)
+	if len(fields) == 0 || !strings.EqualFold(fields[0], "ack") {
+		return nil
+	}
+
+	var token string
+	if len(fields) > 1 {
+		token = fields[1]
+	} else if reply.InReplyTo != nil {
+		a.mu.Lock()
+		token = a.receipts[receiptKey(*reply.InReplyTo)]
+		a.mu.Unlock()
+	}
+
+	if err := a.Acknowledge(token, reply.Sender); err != nil && !errors.Is(err, ErrUnknownAckToken) {
+		return err
+	}
+
+	return nil
+}
+
+// ServeHTTP serves the links of the tracker, see URL. GET requests show a confirmation page, so that mail scanners
+// that open links don't acknowledge notifications, POST requests acknowledge the token. The optional by parameter
+// identifies who acknowledged.
+func (a *Acks) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	token := r.FormValue("token")
+
+	switch r.Method {
+	case http.MethodGet:
+		if _, ok := a.Acknowledged(token); ok {
+			_, _ = w.Write([]byte("Acknowledged"))
+			return
+		}
+
+		a.mu.Lock()
+		_, known := a.pending[token]
+		a.mu.Unlock()
+		if !known {
+			http.Error(w, ErrUnknownAckToken.Error(), http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write([]byte(`<form method="post"><input type="hidden" name="token" value="` +
+			html.EscapeString(token) + `"><button type="submit">Acknowledge</button></form>`))
+	case http.MethodPost:
+		if err := a.Acknowledge(token, r.FormValue("by")); err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte("Acknowledged"))
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// ackContext is the acknowledgement a notification is sent with.
+type ackContext struct {
+	acks  *Acks
+	token string
+}
+
+type ackKey struct{}
+
+// ContextWithAck registers a new token with the tracker and returns it along with a copy of ctx that sends
+// notifications with it. Notifications sent with the context carry a link that acknowledges the token if the tracker
+// has a base URL, and the receipts of the sent messages are remembered, so that replies to them acknowledge the token,
+// see HandleReply.
+func (a *Acks) ContextWithAck(ctx context.Context) (context.Context, string) {
+	token := a.Register()
+
+	return context.WithValue(ctx, ackKey{}, ackContext{acks: a, token: token}), token
+}
+
+// AckTokenFromContext returns the acknowledgement token of ctx, see Acks.ContextWithAck. Services that support
+// callbacks, e.g. buttons, can embed it to report the acknowledgement.
+func AckTokenFromContext(ctx context.Context) (string, bool) {
+	if ctx == nil {
+		return "", false
+	}
+	ac, ok := ctx.Value(ackKey{}).(ackContext)
+
+	return ac.token, ok
+}
+
+// ackAction returns the action that acknowledges the token of ctx, if any.
+func ackAction(ctx context.Context) (Action, bool) {
+	ac, ok := ctx.Value(ackKey{}).(ackContext)
+	if !ok {
+		return Action{}, false
+	}
+
+	link := ac.acks.URL(ac.token)
+
+	return Action{Label: "Acknowledge", URL: link}, link != ""
+}
@@ -0,0 +1,164 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAcksAcknowledge(t *testing.T) {
+	t.Parallel()
+
+	acks := NewAcks("")
+	token := acks.Register()
+
+	if _, ok := acks.Acknowledged(token); ok {
+		t.Error("Acknowledged() of new token returned true")
+	}
+	if _, err := acks.Wait(context.Background(), token, time.Millisecond); !errors.Is(err, ErrAckTimeout) {
+		t.Errorf("Wait() of unacknowledged token returned %v, want ErrAckTimeout", err)
+	}
+
+	go func() {
+		time.Sleep(5 * time.Millisecond)
+		_ = acks.Acknowledge(token, "alice")
+	}()
+	ack, err := acks.Wait(context.Background(), token, time.Second)
+	if err != nil {
+		t.Fatalf("Wait() returned error: %v", err)
+	}
+	if ack.Token != token || ack.By != "alice" || ack.Time.IsZero() {
+		t.Errorf("Wait() returned unexpected acknowledgement: %+v", ack)
+	}
+
+	// The first acknowledgement is kept.
+	if err = acks.Acknowledge(token, "bob"); err != nil {
+		t.Errorf("Acknowledge() of acknowledged token returned error: %v", err)
+	}
+	if ack, _ = acks.Acknowledged(token); ack.By != "alice" {
+		t.Errorf("Acknowledged() returned acknowledgement by %q, want alice", ack.By)
+	}
+
+	acks.Forget(token)
+	if err = acks.Acknowledge(token, "alice"); !errors.Is(err, ErrUnknownAckToken) {
+		t.Errorf("Acknowledge() of forgotten token returned %v, want ErrUnknownAckToken", err)
+	}
+	if _, err = acks.Wait(context.Background(), token, 0); !errors.Is(err, ErrUnknownAckToken) {
+		t.Errorf("Wait() of forgotten token returned %v, want ErrUnknownAckToken", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err = acks.Wait(ctx, acks.Register(), 0); !errors.Is(err, context.Canceled) {
+		t.Errorf("Wait() with done context returned %v, want context.Canceled", err)
+	}
+}
+
+// receiptService records a receipt for every message it sends.
+type receiptService struct {
+	nativeService
+}
+
+func (s *receiptService) SendMessage(ctx context.Context, message Message) error {
+	RecordReceipt(ctx, Receipt{Service: s, Receiver: "chat", MessageID: "42"})
+
+	return s.nativeService.SendMessage(ctx, message)
+}
+
+func TestNotifySendMessageWithAck(t *testing.T) {
+	t.Parallel()
+
+	acks := NewAcks("https://example.com/ack")
+	service := &receiptService{}
+	n := NewWithServices(service)
+
+	ctx, token := acks.ContextWithAck(context.Background())
+	if got, _ := AckTokenFromContext(ctx); got != token {
+		t.Errorf("AckTokenFromContext() = %q, want %q", got, token)
+	}
+
+	actions := make([]Action, 1, 2)
+	actions[0] = Action{Label: "Runbook", URL: "https://example.com/runbook"}
+	if err := n.SendMessage(ctx, Message{Subject: "Disk full", Actions: actions}); err != nil {
+		t.Fatalf("SendMessage() returned error: %v", err)
+	}
+
+	want := []Action{actions[0], {Label: "Acknowledge", URL: "https://example.com/ack?token=" + token}}
+	if got := service.messages[0].Actions; len(got) != 2 || got[1] != want[1] {
+		t.Errorf("SendMessage() sent actions %v, want %v", got, want)
+	}
+	if got := actions[:2][1]; got != (Action{}) {
+		t.Errorf("SendMessage() modified the actions of the caller: %v", got)
+	}
+
+	// Replies to other messages and other replies are ignored.
+	for _, reply := range []Reply{
+		{Text: "ACK", InReplyTo: &Receipt{Receiver: "chat", MessageID: "41"}},
+		{Text: "thanks", InReplyTo: &Receipt{Receiver: "chat", MessageID: "42"}},
+		{Text: "ack unknown-token"},
+	} {
+		if err := acks.HandleReply(context.Background(), reply); err != nil {
+			t.Errorf("HandleReply() returned error: %v", err)
+		}
+	}
+	if _, ok := acks.Acknowledged(token); ok {
+		t.Fatal("HandleReply() acknowledged token for unrelated reply")
+	}
+
+	err := acks.HandleReply(context.Background(), Reply{
+		Sender:    "alice",
+		Text:      " ack ",
+		InReplyTo: &Receipt{Receiver: "chat", MessageID: "42"},
+	})
+	if err != nil {
+		t.Errorf("HandleReply() returned error: %v", err)
+	}
+	if ack, ok := acks.Acknowledged(token); !ok || ack.By != "alice" {
+		t.Errorf("HandleReply() didn't acknowledge token, got %+v", ack)
+	}
+}
+
+func TestAcksServeHTTP(t *testing.T) {
+	t.Parallel()
+
+	acks := NewAcks("https://example.com/ack?tenant=1")
+	token := acks.Register()
+
+	link := acks.URL(token)
+	if link != "https://example.com/ack?tenant=1&token="+token {
+		t.Errorf("URL() = %q", link)
+	}
+
+	// Opening the link only shows a confirmation.
+	w := httptest.NewRecorder()
+	acks.ServeHTTP(w, httptest.NewRequest(http.MethodGet, link, nil))
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), "<form") {
+		t.Errorf("GET returned %d %q, want confirmation form", w.Code, w.Body.String())
+	}
+	if _, ok := acks.Acknowledged(token); ok {
+		t.Error("GET acknowledged token")
+	}
+
+	form := url.Values{"token": {token}, "by": {"alice@example.com"}}
+	r := httptest.NewRequest(http.MethodPost, "/ack", strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w = httptest.NewRecorder()
+	acks.ServeHTTP(w, r)
+	if w.Code != http.StatusOK {
+		t.Errorf("POST returned %d, want %d", w.Code, http.StatusOK)
+	}
+	if ack, ok := acks.Acknowledged(token); !ok || ack.By != "alice@example.com" {
+		t.Errorf("POST didn't acknowledge token, got %+v", ack)
+	}
+
+	w = httptest.NewRecorder()
+	acks.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/ack?token=unknown", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("GET of unknown token returned %d, want %d", w.Code, http.StatusNotFound)
+	}
+}
@@ -0,0 +1,114 @@
+package notify
+
+import (
+	"context"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ErrAttachmentTooLarge signals that an attachment exceeds the size limit of a service.
+var ErrAttachmentTooLarge = errors.New("attachment too large")
+
+// Attachment is a file sent along with a Message.
+type Attachment struct {
+	// Name is the file name shown to the receiver.
+	Name string
+	// ContentType is the MIME type of the file. It is detected from name and data if empty.
+	ContentType string
+	Data        []byte
+}
+
+// UploadOptions configures how UploadAttachments handles attachments.
+type UploadOptions struct {
+	// MaxSize is the maximum size of a single attachment in bytes. Zero means no limit.
+	MaxSize int
+	// Retries is the number of times an upload that failed with an ErrTransient is retried. Other errors aren't
+	// retried.
+	Retries int
+	// RetryDelay is the delay before the first retry. It doubles with every further retry. Defaults to
+	// DefaultUploadRetryDelay if zero.
+	RetryDelay time.Duration
+}
+
+// DefaultUploadRetryDelay is the delay before the first retry of an upload if UploadOptions.RetryDelay is zero.
+const DefaultUploadRetryDelay = 500 * time.Millisecond
+
+// UploadFunc uploads a single attachment and returns a reference to the uploaded file, e.g. its ID.
+type UploadFunc func(ctx context.Context, attachment Attachment) (string, error)
+
+// detectContentType returns the MIME type of the attachment, derived from its name or, failing that, from its data.
+func detectContentType(attachment Attachment) string {
+	if attachment.ContentType != "" {
+		return attachment.ContentType
+	}
+	if contentType := mime.TypeByExtension(filepath.Ext(attachment.Name)); contentType != "" {
+		return contentType
+	}
+
+	return http.DetectContentType(attachment.Data)
+}
+
+// upload calls the upload function for the attachment until it succeeds, fails with an error that isn't transient or
+// the retries are exhausted.
+func upload(ctx context.Context, attachment Attachment, options UploadOptions, fn UploadFunc) (string, error) {
+	delay := options.RetryDelay
+	if delay <= 0 {
+		delay = DefaultUploadRetryDelay
+	}
+	for attempt := 0; ; attempt++ {
+		ref, err := fn(ctx, attachment)
+		if err == nil {
+			return ref, nil
+		}
+		if attempt >= options.Retries || !errors.Is(err, ErrTransient) {
+			return "", err
+		}
+
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-time.After(delay):
+			delay *= 2
+		}
+	}
+}
+
+// UploadAttachments is the shared upload pipeline for services that have to upload files before they can reference
+// them in a message. It rejects attachments larger than options.MaxSize, fills in missing content types and passes each
+// attachment to fn, retrying uploads that failed with an ErrTransient, so fn should classify its errors, see
+// ClassifyError. It returns the references returned by fn in the order of the attachments.
+func UploadAttachments(
+	ctx context.Context,
+	attachments []Attachment,
+	options UploadOptions,
+	fn UploadFunc,
+) ([]string, error) {
+	for _, attachment := range attachments {
+		if options.MaxSize > 0 && len(attachment.Data) > options.MaxSize {
+			return nil, errors.Wrapf(ErrAttachmentTooLarge, "%q has %d bytes, the limit is %d bytes",
+				attachment.Name, len(attachment.Data), options.MaxSize)
+		}
+	}
+
+	refs := make([]string, 0, len(attachments))
+	for _, attachment := range attachments {
+		select {
+		case <-ctx.Done():
+			return refs, ctx.Err()
+		default:
+			attachment.ContentType = detectContentType(attachment)
+
+			ref, err := upload(ctx, attachment, options, fn)
+			if err != nil {
+				return refs, errors.Wrapf(err, "upload attachment %q", attachment.Name)
+			}
+			refs = append(refs, ref)
+		}
+	}
+
+	return refs, nil
+}
@@ -0,0 +1,93 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDetectContentType(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		attachment Attachment
+		want       string
+	}{
+		{Attachment{Name: "report.csv", ContentType: "application/octet-stream"}, "application/octet-stream"},
+		{Attachment{Name: "report.pdf"}, "application/pdf"},
+		{Attachment{Name: "image", Data: []byte("\x89PNG\r\n\x1a\n")}, "image/png"},
+		{Attachment{Name: "notes", Data: []byte("plain notes")}, "text/plain; charset=utf-8"},
+	}
+
+	for _, tt := range tests {
+		if got := detectContentType(tt.attachment); got != tt.want {
+			t.Errorf("detectContentType(%q) returned %q, want %q", tt.attachment.Name, got, tt.want)
+		}
+	}
+}
+
+func TestUploadAttachments(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	attachments := []Attachment{
+		{Name: "a.txt", Data: []byte("a")},
+		{Name: "b.txt", Data: []byte("bb")},
+	}
+
+	// Failed uploads are retried.
+	var uploaded []Attachment
+	failures := 2
+	refs, err := UploadAttachments(ctx, attachments, UploadOptions{Retries: 2, RetryDelay: time.Millisecond},
+		func(_ context.Context, attachment Attachment) (string, error) {
+			if failures > 0 {
+				failures--
+				return "", ClassifyError(errors.New("temporary failure"), ErrTransient)
+			}
+			uploaded = append(uploaded, attachment)
+
+			return "ref-" + attachment.Name, nil
+		})
+	if err != nil {
+		t.Fatalf("UploadAttachments() returned error: %v", err)
+	}
+	if diff := cmp.Diff([]string{"ref-a.txt", "ref-b.txt"}, refs); diff != "" {
+		t.Errorf("UploadAttachments() returned unexpected references:\n%s", diff)
+	}
+	if len(uploaded) != 2 || uploaded[0].ContentType != "text/plain; charset=utf-8" {
+		t.Errorf("UploadAttachments() uploaded unexpected attachments: %v", uploaded)
+	}
+
+	// Exhausted retries are reported.
+	_, err = UploadAttachments(ctx, attachments, UploadOptions{Retries: 1, RetryDelay: time.Millisecond},
+		func(context.Context, Attachment) (string, error) {
+			return "", ClassifyError(errors.New("temporary failure"), ErrTransient)
+		})
+	if err == nil {
+		t.Error("UploadAttachments() with failing upload returned no error")
+	}
+
+	// Errors that aren't transient aren't retried.
+	attempts := 0
+	_, err = UploadAttachments(ctx, attachments, UploadOptions{Retries: 2},
+		func(context.Context, Attachment) (string, error) {
+			attempts++
+			return "", ClassifyError(errors.New("forbidden"), ErrPermanent)
+		})
+	if !errors.Is(err, ErrPermanent) || attempts != 1 {
+		t.Errorf("UploadAttachments() with permanent failure returned %v after %d attempts, want 1", err, attempts)
+	}
+
+	// Oversized attachments are rejected before anything is uploaded.
+	_, err = UploadAttachments(ctx, attachments, UploadOptions{MaxSize: 1},
+		func(context.Context, Attachment) (string, error) {
+			t.Error("UploadAttachments() uploaded despite oversized attachment")
+			return "", nil
+		})
+	if !errors.Is(err, ErrAttachmentTooLarge) {
+		t.Errorf("UploadAttachments() with oversized attachment returned %v, want ErrAttachmentTooLarge", err)
+	}
+}
@@ -0,0 +1,174 @@
+package notify
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// DefaultAuditTimeout is the time audit stores get to record a send attempt, see WithAudit.
+const DefaultAuditTimeout = 5 * time.Second
+
+// Outcomes of audited send attempts.
+const (
+	AuditOutcomeSent   = "sent"
+	AuditOutcomeFailed = "failed"
+)
+
+// AuditRecord describes a single attempt to send a notification through a service.
+type AuditRecord struct {
+	// Time is the time the attempt started.
+	Time time.Time `json:"time"`
+	// MessageHash is the hex encoded SHA-256 hash of the notification. Records don't contain the notification itself,
+	// which may be confidential, but the hash allows to find all attempts of the same notification.
+	MessageHash string `json:"message_hash"`
+	// Service is the type of the service, e.g. "*slack.Slack".
+	Service string `json:"service"`
+	// Receivers are the receivers the service reported receipts for, see RecordReceipt, or those of the receivers
+	// override, see WithReceiversOverride. Empty if neither is known.
+	Receivers []string `json:"receivers,omitempty"`
+	// Tags are the tags of the notification, see WithTags.
+	Tags []string `json:"tags,omitempty"`
+	// Outcome is AuditOutcomeSent or AuditOutcomeFailed.
+	Outcome string `json:"outcome"`
+	// Error is the error of a failed attempt.
+	Error string `json:"error,omitempty"`
+	// Latency is how long the attempt took.
+	Latency time.Duration `json:"latency"`
+	// Template and TemplateVersion identify the template the notification was rendered from, see SendTemplate.
+	Template        string `json:"template,omitempty"`
+	TemplateVersion string `json:"template_version,omitempty"`
+}
+
+// AuditStore stores audit records, e.g. in a file, see NewFileAuditStore, or an SQL database, see NewSQLAuditStore.
+type AuditStore interface {
+	Record(ctx context.Context, record AuditRecord) error
+}
+
+// AuditPruner is implemented by audit stores that can delete old records, see PruneAudit.
+type AuditPruner interface {
+	Prune(ctx context.Context, before time.Time) error
+}
+
+// WithAudit returns an Option that records every send attempt of every service in the given store. Attempts are
+// recorded even if the context of the send is canceled, within DefaultAuditTimeout. Failing to record an attempt
+// doesn't fail the send, see WithAuditErrorHandler.
+func WithAudit(store AuditStore) Option {
+	return func(n *Notify) {
+		if n != nil {
+			n.audit = store
+		}
+	}
+}
+
+// WithAuditErrorHandler returns an Option that passes the errors of the audit store to onError, e.g. to log them or
+// count them in a metric. Without it, they are dropped.
+func WithAuditErrorHandler(onError func(error)) Option {
+	return func(n *Notify) {
+		if n != nil {
+			n.auditOnError = onError
+		}
+	}
+}
+
+// detachedContext carries the values of its parent, but is never canceled and has no deadline, like
+// context.WithoutCancel of Go 1.21.
+type detachedContext struct {
+	parent context.Context
+}
+
+func (detachedContext) Deadline() (time.Time, bool) { return time.Time{}, false }
+func (detachedContext) Done() <-chan struct{}       { return nil }
+func (detachedContext) Err() error                  { return nil }
+func (c detachedContext) Value(key any) any         { return c.parent.Value(key) }
+
+// hashMessage returns the hex encoded SHA-256 hash of the given parts of a message.
+func hashMessage(parts ...string) string {
+	h := sha256.New()
+	for _, part := range parts {
+		_, _ = h.Write([]byte(part))
+		_, _ = h.Write([]byte{0})
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// audited calls fn for the service and records the attempt in the audit store.
+func (n *Notify) audited(
+	ctx context.Context,
+	service Notifier,
+	hash string,
+	fn func(ctx context.Context, service Notifier) error,
+) error {
+	sendCtx, receipts := ContextWithReceipts(ctx)
+
+	start := time.Now()
+	err := fn(sendCtx, service)
+
+	options := SendOptionsFromContext(ctx)
+	record := AuditRecord{
+		Time:        start,
+		MessageHash: hash,
+		Service:     fmt.Sprintf("%T", service),
+		Receivers:   options.Receivers,
+		Tags:        options.Tags,
+		Outcome:     AuditOutcomeSent,
+		Latency:     time.Since(start),
+	}
+	if all := receipts.All(); len(all) > 0 {
+		record.Receivers = make([]string, len(all))
+		for i, receipt := range all {
+			record.Receivers[i] = receipt.Receiver
+		}
+	}
+	if ref, ok := TemplateFromContext(ctx); ok {
+		record.Template, record.TemplateVersion = ref.Name, ref.Version
+	}
+	if err != nil {
+		record.Outcome = AuditOutcomeFailed
+		record.Error = err.Error()
+	}
+
+	// Record failed attempts too if they failed because the context was canceled, which is when it matters most.
+	recordCtx, cancel := context.WithTimeout(detachedContext{parent: ctx}, DefaultAuditTimeout)
+	defer cancel()
+
+	if recordErr := n.audit.Record(recordCtx, record); recordErr != nil && n.auditOnError != nil {
+		n.auditOnError(errors.Wrap(recordErr, "record audit record"))
+	}
+
+	return err
+}
+
+// pruneAudit deletes the audit records older than maxAge.
+func (n *Notify) pruneAudit(ctx context.Context, maxAge time.Duration) error {
+	if n.audit == nil {
+		return nil
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	pruner, ok := n.audit.(AuditPruner)
+	if !ok {
+		return errors.Wrap(ErrUnsupported, "prune audit records")
+	}
+
+	return errors.Wrap(pruner.Prune(ctx, time.Now().Add(-maxAge)), "prune audit records")
+}
+
+// PruneAudit deletes the records older than maxAge from the audit store, see WithAudit. Call it periodically to retain
+// records for a limited time only. It returns ErrUnsupported if the store doesn't implement AuditPruner.
+func (n *Notify) PruneAudit(ctx context.Context, maxAge time.Duration) error {
+	return n.pruneAudit(ctx, maxAge)
+}
+
+// PruneAudit deletes the records older than maxAge from the audit store, see WithAudit. Call it periodically to retain
+// records for a limited time only. It returns ErrUnsupported if the store doesn't implement AuditPruner.
+func PruneAudit(ctx context.Context, maxAge time.Duration) error {
+	return std.PruneAudit(ctx, maxAge)
+}
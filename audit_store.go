@@ -0,0 +1,289 @@
+package notify
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Compile-time check to ensure that the audit stores implement the optional AuditPruner interface.
+var (
+	_ AuditPruner = (*FileAuditStore)(nil)
+	_ AuditPruner = (*SQLAuditStore)(nil)
+)
+
+// FileAuditStore stores audit records in a file, one JSON object per line, or one base64 encoded encrypted JSON object
+// per line if an Encrypter is set. It is safe for concurrent use, but not for use by several processes.
+type FileAuditStore struct {
+	mu        sync.Mutex
+	path      string
+	encrypter *Encrypter
+}
+
+// NewFileAuditStore returns a store that appends audit records to the file at the given path. The file is created if
+// it doesn't exist.
+func NewFileAuditStore(path string) *FileAuditStore {
+	return &FileAuditStore{path: path}
+}
+
+// SetEncrypter makes the store encrypt the records it writes with the given Encrypter. Records written before remain
+// readable, unencrypted ones as well as those encrypted with keys the Encrypter still holds. Prune re-encrypts the
+// records it keeps with the primary key, which completes a key rotation.
+func (s *FileAuditStore) SetEncrypter(encrypter *Encrypter) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.encrypter = encrypter
+}
+
+// encode returns the record as line of the file, without line break.
+func (s *FileAuditStore) encode(record AuditRecord) ([]byte, error) {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal audit record")
+	}
+	if s.encrypter == nil {
+		return line, nil
+	}
+
+	sealed, err := s.encrypter.Encrypt(line)
+	if err != nil {
+		return nil, errors.Wrap(err, "encrypt audit record")
+	}
+
+	return []byte(base64.StdEncoding.EncodeToString(sealed)), nil
+}
+
+// decode parses a line of the file.
+func (s *FileAuditStore) decode(line []byte) (AuditRecord, error) {
+	var record AuditRecord
+	if !bytes.HasPrefix(line, []byte("{")) {
+		if s.encrypter == nil {
+			return record, errors.New("audit record is encrypted, but no encrypter is set")
+		}
+
+		sealed, err := base64.StdEncoding.DecodeString(string(line))
+		if err != nil {
+			return record, errors.Wrap(err, "decode audit record")
+		}
+		if line, err = s.encrypter.Decrypt(sealed); err != nil {
+			return record, errors.Wrap(err, "decrypt audit record")
+		}
+	}
+
+	return record, errors.Wrap(json.Unmarshal(line, &record), "unmarshal audit record")
+}
+
+// readLines returns the lines of the file, or none if it doesn't exist.
+func (s *FileAuditStore) readLines() ([][]byte, error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "read audit file")
+	}
+
+	var lines [][]byte
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(nil, 1<<20)
+	for scanner.Scan() {
+		lines = append(lines, append([]byte(nil), scanner.Bytes()...))
+	}
+
+	return lines, errors.Wrap(scanner.Err(), "read audit file")
+}
+
+// Records returns all records of the file, decrypted if necessary.
+func (s *FileAuditStore) Records(_ context.Context) ([]AuditRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	lines, err := s.readLines()
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]AuditRecord, 0, len(lines))
+	for _, line := range lines {
+		record, err := s.decode(line)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+// Record appends the record to the file.
+func (s *FileAuditStore) Record(_ context.Context, record AuditRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	line, err := s.encode(record)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return errors.Wrap(err, "open audit file")
+	}
+
+	_, err = f.Write(append(line, '\n'))
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+
+	return errors.Wrap(err, "write audit record")
+}
+
+// Prune rewrites the file without the records older than before. Records that can't be decoded, e.g. because their
+// key is unknown, are kept as they are.
+func (s *FileAuditStore) Prune(_ context.Context, before time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	lines, err := s.readLines()
+	if err != nil || lines == nil {
+		return err
+	}
+
+	var kept bytes.Buffer
+	for _, line := range lines {
+		record, err := s.decode(line)
+		if err == nil {
+			if record.Time.Before(before) {
+				continue
+			}
+			if line, err = s.encode(record); err != nil {
+				return err
+			}
+		}
+		kept.Write(line)
+		kept.WriteByte('\n')
+	}
+
+	// Replace the file atomically, so that a crash doesn't lose the records.
+	tmp := s.path + ".tmp"
+	if err = os.WriteFile(tmp, kept.Bytes(), 0o600); err != nil {
+		return errors.Wrap(err, "write audit file")
+	}
+
+	return errors.Wrap(os.Rename(tmp, s.path), "replace audit file")
+}
+
+// SQLAuditStore stores audit records in a table of an SQL database. The table needs the following columns, e.g. for
+// PostgreSQL:
+//
+//	CREATE TABLE notify_audit (
+//	    time             TIMESTAMP WITH TIME ZONE NOT NULL,
+//	    message_hash     CHAR(64) NOT NULL,
+//	    service          VARCHAR(255) NOT NULL,
+//	    receivers        TEXT NOT NULL,
+//	    tags             TEXT NOT NULL,
+//	    outcome          VARCHAR(16) NOT NULL,
+//	    error            TEXT NOT NULL,
+//	    latency_ms       BIGINT NOT NULL,
+//	    template         VARCHAR(255) NOT NULL,
+//	    template_version VARCHAR(255) NOT NULL
+//	);
+//
+// Receivers and tags are stored comma separated. If an Encrypter is set, receivers, tags and error are stored
+// encrypted and base64 encoded.
+type SQLAuditStore struct {
+	db          *sql.DB
+	table       string
+	placeholder func(i int) string
+	encrypter   *Encrypter
+}
+
+// NewSQLAuditStore returns a store that inserts audit records into the given table. The table name is used in queries
+// as is and must not come from untrusted input. Queries use ? placeholders, as expected e.g. by MySQL and SQLite; see
+// UseDollarPlaceholders for PostgreSQL.
+func NewSQLAuditStore(db *sql.DB, table string) *SQLAuditStore {
+	return &SQLAuditStore{
+		db:          db,
+		table:       table,
+		placeholder: func(int) string { return "?" },
+	}
+}
+
+// UseDollarPlaceholders makes the store use $1, $2, ... placeholders, as expected by PostgreSQL.
+func (s *SQLAuditStore) UseDollarPlaceholders() {
+	s.placeholder = func(i int) string { return "$" + strconv.Itoa(i) }
+}
+
+// SetEncrypter makes the store encrypt the receivers, tags and error of the records it inserts with the given
+// Encrypter. The other columns stay readable, so records can still be queried and pruned. Use Encrypter.Decrypt on
+// the base64 decoded columns to read them.
+func (s *SQLAuditStore) SetEncrypter(encrypter *Encrypter) {
+	s.encrypter = encrypter
+}
+
+// seal encrypts and base64 encodes the value if the store has an Encrypter.
+func (s *SQLAuditStore) seal(value string) (string, error) {
+	if s.encrypter == nil {
+		return value, nil
+	}
+
+	sealed, err := s.encrypter.Encrypt([]byte(value))
+	if err != nil {
+		return "", errors.Wrap(err, "encrypt audit record")
+	}
+
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Record inserts the record into the table.
+func (s *SQLAuditStore) Record(ctx context.Context, record AuditRecord) error {
+	sensitive := []string{strings.Join(record.Receivers, ","), strings.Join(record.Tags, ","), record.Error}
+	for i, value := range sensitive {
+		sealed, err := s.seal(value)
+		if err != nil {
+			return err
+		}
+		sensitive[i] = sealed
+	}
+
+	placeholders := make([]string, 10)
+	for i := range placeholders {
+		placeholders[i] = s.placeholder(i + 1)
+	}
+
+	query := "INSERT INTO " + s.table +
+		" (time, message_hash, service, receivers, tags, outcome, error, latency_ms, template, template_version)" +
+		" VALUES (" + strings.Join(placeholders, ", ") + ")"
+	_, err := s.db.ExecContext(ctx, query,
+		record.Time.UTC(),
+		record.MessageHash,
+		record.Service,
+		sensitive[0],
+		sensitive[1],
+		record.Outcome,
+		sensitive[2],
+		record.Latency.Milliseconds(),
+		record.Template,
+		record.TemplateVersion,
+	)
+
+	return errors.Wrap(err, "insert audit record")
+}
+
+// Prune deletes the records older than before from the table.
+func (s *SQLAuditStore) Prune(ctx context.Context, before time.Time) error {
+	_, err := s.db.ExecContext(ctx, "DELETE FROM "+s.table+" WHERE time < "+s.placeholder(1), before.UTC())
+
+	return errors.Wrap(err, "delete audit records")
+}
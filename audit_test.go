@@ -0,0 +1,290 @@
+package notify
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// memoryAuditStore keeps audit records in memory.
+type memoryAuditStore struct {
+	mu      sync.Mutex
+	records []AuditRecord
+}
+
+func (s *memoryAuditStore) Record(_ context.Context, record AuditRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.records = append(s.records, record)
+
+	return nil
+}
+
+func TestNotifySendAudited(t *testing.T) {
+	t.Parallel()
+
+	store := &memoryAuditStore{}
+	service := &receiptService{}
+	n := NewWithServices(service)
+	n.WithOptions(WithAudit(store))
+
+	ctx, receipts := ContextWithReceipts(context.Background())
+	ctx = ContextWithTags(ctx, "db")
+	if err := n.Send(ctx, "Disk full", "db-1"); err != nil {
+		t.Fatalf("Send() returned error: %v", err)
+	}
+	if len(receipts.All()) != 1 {
+		t.Errorf("Send() recorded %d receipts in the context of the caller, want 1", len(receipts.All()))
+	}
+
+	n = NewWithServices(newFailingService())
+	n.WithOptions(WithAudit(store))
+	_ = n.SendWithOptions(context.Background(), "Disk full", "db-1", WithReceiversOverride("ops"))
+
+	if len(store.records) != 2 {
+		t.Fatalf("Send() recorded %d audit records, want 2", len(store.records))
+	}
+
+	sent, failed := store.records[0], store.records[1]
+	if sent.MessageHash == "" || sent.MessageHash != failed.MessageHash {
+		t.Errorf("Send() recorded message hashes %q and %q, want equal hashes", sent.MessageHash, failed.MessageHash)
+	}
+	if strings.Contains(sent.MessageHash, "Disk") {
+		t.Error("Send() recorded the message in the audit record")
+	}
+
+	ignore := cmpIgnoreAuditFields()
+	want := AuditRecord{
+		Service:   "*notify.receiptService",
+		Receivers: []string{"chat"},
+		Tags:      []string{"db"},
+		Outcome:   AuditOutcomeSent,
+	}
+	if diff := cmp.Diff(want, sent, ignore); diff != "" {
+		t.Errorf("Send() recorded unexpected audit record:\n%s", diff)
+	}
+	if failed.Outcome != AuditOutcomeFailed || failed.Error == "" || !cmp.Equal(failed.Receivers, []string{"ops"}) {
+		t.Errorf("Send() recorded unexpected audit record for failed send: %+v", failed)
+	}
+}
+
+// cmpIgnoreAuditFields ignores the fields of audit records that vary between runs.
+func cmpIgnoreAuditFields() cmp.Option {
+	return cmp.FilterPath(func(p cmp.Path) bool {
+		switch p.String() {
+		case "Time", "MessageHash", "Latency":
+			return true
+		}
+		return false
+	}, cmp.Ignore())
+}
+
+// contextAuditStore fails to record if the context is done.
+type contextAuditStore struct {
+	memoryAuditStore
+}
+
+func (s *contextAuditStore) Record(ctx context.Context, record AuditRecord) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return s.memoryAuditStore.Record(ctx, record)
+}
+
+// cancelingService cancels the context of the send, like a caller giving up while the service is sending.
+type cancelingService struct {
+	cancel context.CancelFunc
+}
+
+func (s *cancelingService) Send(ctx context.Context, _, _ string) error {
+	s.cancel()
+	return ctx.Err()
+}
+
+func TestNotifySendAuditedCanceled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	store := &contextAuditStore{}
+	n := NewWithServices(&cancelingService{cancel: cancel})
+	n.WithOptions(WithAudit(store))
+	if err := n.Send(ctx, "Disk full", "db-1"); err == nil {
+		t.Fatal("Send() returned no error")
+	}
+
+	if len(store.records) != 1 || store.records[0].Outcome != AuditOutcomeFailed {
+		t.Errorf("Send() recorded unexpected audit records: %+v", store.records)
+	}
+}
+
+func TestWithAuditErrorHandler(t *testing.T) {
+	t.Parallel()
+
+	var errs []error
+	n := NewWithServices(&receiptService{})
+	n.WithOptions(WithAudit(&failingAuditStore{}), WithAuditErrorHandler(func(err error) { errs = append(errs, err) }))
+	if err := n.Send(context.Background(), "Disk full", "db-1"); err != nil {
+		t.Fatalf("Send() returned error: %v", err)
+	}
+
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), "disk full") {
+		t.Errorf("Send() passed unexpected errors to the handler: %v", errs)
+	}
+}
+
+// failingAuditStore fails to record.
+type failingAuditStore struct{}
+
+func (failingAuditStore) Record(context.Context, AuditRecord) error {
+	return errors.New("disk full")
+}
+
+func TestNotifyPruneAudit(t *testing.T) {
+	t.Parallel()
+
+	if err := New().PruneAudit(context.Background(), time.Hour); err != nil {
+		t.Errorf("PruneAudit() without audit store returned error: %v", err)
+	}
+
+	n := NewWithOptions(WithAudit(&memoryAuditStore{}))
+	if err := n.PruneAudit(context.Background(), time.Hour); !errors.Is(err, ErrUnsupported) {
+		t.Errorf("PruneAudit() of store without pruning returned %v, want ErrUnsupported", err)
+	}
+}
+
+func TestFileAuditStore(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "audit.log")
+	store := NewFileAuditStore(path)
+	ctx := context.Background()
+
+	now := time.Now()
+	if err := store.Prune(ctx, now); err != nil {
+		t.Errorf("Prune() of missing file returned error: %v", err)
+	}
+	for _, age := range []time.Duration{48 * time.Hour, time.Hour} {
+		record := AuditRecord{Time: now.Add(-age), Service: "*slack.Slack", Outcome: AuditOutcomeSent}
+		if err := store.Record(ctx, record); err != nil {
+			t.Fatalf("Record() returned error: %v", err)
+		}
+	}
+
+	n := NewWithOptions(WithAudit(store))
+	if err := n.PruneAudit(ctx, 24*time.Hour); err != nil {
+		t.Fatalf("PruneAudit() returned error: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() returned error: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 1 || !strings.Contains(lines[0], `"service":"*slack.Slack"`) {
+		t.Errorf("PruneAudit() kept unexpected records: %q", lines)
+	}
+}
+
+// recordingDriver is an SQL driver that records the statements it executes.
+type recordingDriver struct {
+	mu    sync.Mutex
+	execs []string
+	args  [][]driver.Value
+}
+
+func (d *recordingDriver) Open(string) (driver.Conn, error) {
+	return &recordingConn{driver: d}, nil
+}
+
+type recordingConn struct {
+	driver *recordingDriver
+}
+
+func (c *recordingConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (c *recordingConn) Close() error {
+	return nil
+}
+
+func (c *recordingConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (c *recordingConn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	c.driver.mu.Lock()
+	defer c.driver.mu.Unlock()
+
+	values := make([]driver.Value, len(args))
+	for i, arg := range args {
+		values[i] = arg.Value
+	}
+	c.driver.execs = append(c.driver.execs, query)
+	c.driver.args = append(c.driver.args, values)
+
+	return driver.RowsAffected(1), nil
+}
+
+func TestSQLAuditStore(t *testing.T) {
+	t.Parallel()
+
+	rd := &recordingDriver{}
+	sql.Register("notify-recording-"+t.Name(), rd)
+	db, err := sql.Open("notify-recording-"+t.Name(), "")
+	if err != nil {
+		t.Fatalf("Open() returned error: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	store := NewSQLAuditStore(db, "notify_audit")
+	ctx := context.Background()
+	at := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+
+	record := AuditRecord{
+		Time:        at,
+		MessageHash: "abc",
+		Service:     "*mail.Mail",
+		Receivers:   []string{"alice@example.com", "bob@example.com"},
+		Outcome:     AuditOutcomeFailed,
+		Error:       "connection refused",
+		Latency:     1500 * time.Millisecond,
+	}
+	if err = store.Record(ctx, record); err != nil {
+		t.Fatalf("Record() returned error: %v", err)
+	}
+
+	store.UseDollarPlaceholders()
+	if err = store.Prune(ctx, at); err != nil {
+		t.Fatalf("Prune() returned error: %v", err)
+	}
+
+	wantExecs := []string{
+		"INSERT INTO notify_audit (time, message_hash, service, receivers, tags, outcome, error, latency_ms, " +
+			"template, template_version) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+		"DELETE FROM notify_audit WHERE time < $1",
+	}
+	if diff := cmp.Diff(wantExecs, rd.execs); diff != "" {
+		t.Errorf("SQLAuditStore executed unexpected statements:\n%s", diff)
+	}
+	wantArgs := [][]driver.Value{
+		{at, "abc", "*mail.Mail", "alice@example.com,bob@example.com", "", "failed", "connection refused", int64(1500), "", ""},
+		{at},
+	}
+	if diff := cmp.Diff(wantArgs, rd.args); diff != "" {
+		t.Errorf("SQLAuditStore executed statements with unexpected arguments:\n%s", diff)
+	}
+}
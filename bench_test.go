@@ -0,0 +1,100 @@
+package notify
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+// discardService drops all notifications.
+type discardService struct{}
+
+func (discardService) Send(context.Context, string, string) error {
+	return nil
+}
+
+// discardLimitedService drops all notifications and declares limits, so the dispatcher has to fit messages.
+type discardLimitedService struct {
+	discardService
+}
+
+func (discardLimitedService) Limits() Limits {
+	return Limits{Total: 4096}
+}
+
+func BenchmarkNotify_Send(b *testing.B) {
+	n := NewWithServices(discardService{}, discardService{}, discardLimitedService{})
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := n.Send(ctx, "Disk usage high", "The disk of db-1 is 95% full."); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkNotify_SendMessage(b *testing.B) {
+	n := NewWithServices(discardService{}, discardLimitedService{})
+	ctx := context.Background()
+	message := Message{
+		Subject: "Disk usage high",
+		Body:    "The disk of db-1 is 95% full.",
+		Actions: []Action{
+			{Label: "Acknowledge", URL: "https://example.com/ack"},
+			{Label: "Dashboard", URL: "https://example.com/dashboard"},
+		},
+		Card: &Card{
+			Title:  "db-1",
+			Fields: []CardField{{Name: "Usage", Value: "95%"}, {Name: "Mount", Value: "/var/lib/postgresql"}},
+			Footer: "monitoring",
+		},
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := n.SendMessage(ctx, message); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkNotify_SendLong(b *testing.B) {
+	n := NewWithServices(discardLimitedService{})
+	ctx := context.Background()
+	body := strings.Repeat("The disk of db-1 is 95% full. ", 200)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := n.Send(ctx, "Disk usage high", body); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkNotify_SendJSON(b *testing.B) {
+	n := NewWithServices(discardService{})
+	ctx := context.Background()
+	payload := map[string]any{"host": "db-1", "usage": 95, "mounts": []string{"/", "/var/lib/postgresql"}}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := n.SendJSON(ctx, payload); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkHTMLToText(b *testing.B) {
+	html := strings.Repeat(`<p>The disk of <b>db-1</b> is <a href="https://example.com">95% full</a>.</p><ul><li>a</li></ul>`, 20)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = HTMLToText(html)
+	}
+}
@@ -0,0 +1,231 @@
+package bridge
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
+)
+
+// DefaultMaxBodySize is the default maximum size of webhook payloads.
+const DefaultMaxBodySize = 1 << 20
+
+// retryWindow is the time the bridge remembers which notifications of a failed webhook were sent, so that retries of
+// the webhook don't send them again.
+const retryWindow = time.Hour
+
+// Sender delivers the notifications received by the bridge. It is implemented by *notify.Notify.
+type Sender interface {
+	SendMessage(ctx context.Context, message notify.Message) error
+}
+
+// Parser converts the payload of a webhook into notifications. A parser may return no messages for webhooks that
+// should not be forwarded, e.g. pings.
+type Parser func(r *http.Request, body []byte) ([]notify.Message, error)
+
+// Bridge is an http.Handler that forwards webhooks as notifications.
+type Bridge struct {
+	sender      Sender
+	parsers     map[string]Parser
+	token       string
+	signer      *notify.Signer
+	maxBodySize int64
+	onError     func(error)
+
+	mu      sync.Mutex
+	partial map[string]partialDelivery
+}
+
+// partialDelivery is the number of notifications of a failed webhook that were sent before sending failed.
+type partialDelivery struct {
+	sent    int
+	expires time.Time
+}
+
+// New returns a new bridge that forwards webhooks through the given sender, usually a *notify.Notify. The parsers for
+// Alertmanager, Grafana, GitHub and generic payloads are registered under the sources alertmanager, grafana, github
+// and generic.
+func New(sender Sender) *Bridge {
+	return &Bridge{
+		sender: sender,
+		parsers: map[string]Parser{
+			"alertmanager": ParseAlertmanager,
+			"grafana":      ParseGrafana,
+			"github":       ParseGitHub,
+			"generic":      ParseGeneric,
+		},
+		maxBodySize: DefaultMaxBodySize,
+		partial:     make(map[string]partialDelivery),
+	}
+}
+
+// AddParser registers a parser for webhooks posted to /<source>. It replaces the parser previously registered for the
+// source, if any.
+func (b *Bridge) AddParser(source string, parser Parser) {
+	b.parsers[source] = parser
+}
+
+// SetToken sets the token webhooks must authenticate with, either as bearer token in the Authorization header or as
+// token query parameter for senders that can't set headers. Without token, all webhooks are accepted.
+func (b *Bridge) SetToken(token string) {
+	b.token = token
+}
+
+// SetSigner makes the bridge reject webhooks whose payload isn't signed by the given signer with 401 Unauthorized, e.g.
+// webhooks sent by notify services with the same signer, or GitHub webhooks with a signer for the header
+// X-Hub-Signature-256 and the secret of the webhook. It can be combined with a token.
+func (b *Bridge) SetSigner(signer *notify.Signer) {
+	b.signer = signer
+}
+
+// SetMaxBodySize sets the maximum size of webhook payloads in bytes. Larger payloads are rejected. The default is
+// DefaultMaxBodySize.
+func (b *Bridge) SetMaxBodySize(size int64) {
+	if size > 0 {
+		b.maxBodySize = size
+	}
+}
+
+// SetErrorHandler sets the function errors of failed sends are passed to, e.g. to log them. Webhook senders only get a
+// generic error, since errors of services may contain credentials, e.g. in request URLs.
+func (b *Bridge) SetErrorHandler(onError func(error)) {
+	b.onError = onError
+}
+
+// sent returns the number of notifications of the webhook with the given key that were already sent by failed
+// attempts. It forgets expired attempts.
+func (b *Bridge) sent(key string, now time.Time) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for k, p := range b.partial {
+		if now.After(p.expires) {
+			delete(b.partial, k)
+		}
+	}
+
+	return b.partial[key].sent
+}
+
+// setSent records the number of notifications of the webhook with the given key that were sent, zero once all were.
+func (b *Bridge) setSent(key string, sent int, now time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if sent == 0 {
+		delete(b.partial, key)
+		return
+	}
+	b.partial[key] = partialDelivery{sent: sent, expires: now.Add(retryWindow)}
+}
+
+// authorized reports whether the request carries the token of the bridge.
+func (b *Bridge) authorized(r *http.Request) bool {
+	if b.token == "" {
+		return true
+	}
+
+	token := r.URL.Query().Get("token")
+	if auth := r.Header.Get("Authorization"); strings.HasPrefix(auth, "Bearer ") {
+		token = strings.TrimPrefix(auth, "Bearer ")
+	}
+
+	return subtle.ConstantTimeCompare([]byte(token), []byte(b.token)) == 1
+}
+
+// ServeHTTP parses the webhook with the parser registered for the last element of the request path and sends the
+// resulting notifications. It responds with 204 No Content once all notifications were sent and with 502 Bad Gateway
+// if sending failed, so that senders that retry webhooks try again. If a webhook fails after some of its notifications
+// were sent, retries with the same payload within an hour only send the remaining ones.
+func (b *Bridge) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if !b.authorized(r) {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	source := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+	parser, ok := b.parsers[source]
+	if !ok {
+		http.Error(w, "unknown source "+source, http.StatusNotFound)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, b.maxBodySize))
+	if err != nil {
+		http.Error(w, "payload too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+	if b.signer != nil {
+		if err = b.signer.VerifyRequest(r, body); err != nil {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+	}
+
+	messages, err := parser(r, body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	hash := sha256.Sum256(body)
+	key := source + "/" + hex.EncodeToString(hash[:])
+	sent := b.sent(key, time.Now())
+	for i := sent; i < len(messages); i++ {
+		if err = b.sender.SendMessage(r.Context(), messages[i]); err != nil {
+			b.setSent(key, i, time.Now())
+			if b.onError != nil {
+				b.onError(errors.Wrapf(err, "failed to send notification of %s webhook", source))
+			}
+			http.Error(w, "failed to send notification", http.StatusBadGateway)
+			return
+		}
+	}
+	b.setSent(key, 0, time.Now())
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListenAndServe serves the bridge on the given address until the context is done. It then waits for webhooks in
+// progress to finish before returning.
+func (b *Bridge) ListenAndServe(ctx context.Context, addr string) error {
+	server := &http.Server{
+		Addr:              addr,
+		Handler:           b,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	errs := make(chan error, 1)
+	go func() {
+		errs <- server.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errs:
+		return errors.Wrap(err, "failed to serve bridge")
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		return errors.Wrap(err, "failed to shut down bridge")
+	}
+
+	return nil
+}
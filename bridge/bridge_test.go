@@ -0,0 +1,342 @@
+package bridge
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nikoksr/notify"
+)
+
+// recordingSender records the messages it sends and fails with err, if set.
+type recordingSender struct {
+	messages []notify.Message
+	err      error
+}
+
+func (s *recordingSender) SendMessage(_ context.Context, message notify.Message) error {
+	s.messages = append(s.messages, message)
+	return s.err
+}
+
+func post(b *Bridge, target, body string, header http.Header) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(http.MethodPost, target, strings.NewReader(body))
+	for key, values := range header {
+		r.Header[key] = values
+	}
+	w := httptest.NewRecorder()
+	b.ServeHTTP(w, r)
+
+	return w
+}
+
+func TestBridge_ServeHTTP(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	sender := &recordingSender{}
+	b := New(sender)
+	b.SetToken("secret")
+
+	// Without or with wrong token, webhooks are rejected.
+	w := post(b, "/generic", `{"subject":"hi"}`, nil)
+	assert.Equal(http.StatusUnauthorized, w.Code)
+	w = post(b, "/generic", `{"subject":"hi"}`, http.Header{"Authorization": {"Bearer wrong"}})
+	assert.Equal(http.StatusUnauthorized, w.Code)
+
+	auth := http.Header{"Authorization": {"Bearer secret"}}
+	w = post(b, "/generic", `{"subject":"hi","body":"there"}`, auth)
+	assert.Equal(http.StatusNoContent, w.Code)
+	w = post(b, "/hooks/generic?token=secret", `{"subject":"again"}`, nil)
+	assert.Equal(http.StatusNoContent, w.Code)
+	assert.Equal([]notify.Message{{Subject: "hi", Body: "there"}, {Subject: "again"}}, sender.messages)
+
+	w = post(b, "/unknown", `{}`, auth)
+	assert.Equal(http.StatusNotFound, w.Code)
+	w = post(b, "/generic", `{`, auth)
+	assert.Equal(http.StatusBadRequest, w.Code)
+
+	b.SetMaxBodySize(8)
+	w = post(b, "/generic", `{"subject":"too long"}`, auth)
+	assert.Equal(http.StatusRequestEntityTooLarge, w.Code)
+
+	r := httptest.NewRequest(http.MethodGet, "/generic", nil)
+	w = httptest.NewRecorder()
+	b.ServeHTTP(w, r)
+	assert.Equal(http.StatusMethodNotAllowed, w.Code)
+
+	// Failed sends are reported, so that the sender of the webhook retries.
+	// Errors of services are passed to the error handler, since they may contain credentials.
+	var errs []error
+	b.SetErrorHandler(func(err error) { errs = append(errs, err) })
+	sender.err = errors.New("https://hooks.example.com/?access_token=secret: service unavailable")
+	b.SetMaxBodySize(DefaultMaxBodySize)
+	w = post(b, "/generic", `{"subject":"hi"}`, auth)
+	assert.Equal(http.StatusBadGateway, w.Code)
+	assert.NotContains(w.Body.String(), "access_token")
+	assert.Len(errs, 1)
+	assert.ErrorIs(errs[0], sender.err)
+}
+
+// failingSender fails to send the messages with the given subjects once.
+type failingSender struct {
+	recordingSender
+	fail map[string]bool
+}
+
+func (s *failingSender) SendMessage(ctx context.Context, message notify.Message) error {
+	if s.fail[message.Subject] {
+		delete(s.fail, message.Subject)
+		return errors.New("service unavailable")
+	}
+
+	return s.recordingSender.SendMessage(ctx, message)
+}
+
+func TestBridge_ServeHTTP_retry(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	sender := &failingSender{fail: map[string]bool{"second": true}}
+	b := New(sender)
+	b.AddParser("batch", func(_ *http.Request, body []byte) ([]notify.Message, error) {
+		var messages []notify.Message
+		for _, subject := range strings.Fields(string(body)) {
+			messages = append(messages, notify.Message{Subject: subject})
+		}
+		return messages, nil
+	})
+
+	w := post(b, "/batch", "first second third", nil)
+	assert.Equal(http.StatusBadGateway, w.Code)
+
+	// The retry only sends the notifications that weren't sent yet.
+	w = post(b, "/batch", "first second third", nil)
+	assert.Equal(http.StatusNoContent, w.Code)
+	assert.Equal([]notify.Message{{Subject: "first"}, {Subject: "second"}, {Subject: "third"}}, sender.messages)
+
+	// Once all were sent, the same payload is sent again.
+	w = post(b, "/batch", "first second third", nil)
+	assert.Equal(http.StatusNoContent, w.Code)
+	assert.Len(sender.messages, 6)
+}
+
+func TestBridge_SetSigner(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	sender := &recordingSender{}
+	b := New(sender)
+	signer := notify.NewSigner("secret")
+	b.SetSigner(signer)
+
+	body := `{"subject":"hi"}`
+	w := post(b, "/generic", body, nil)
+	assert.Equal(http.StatusUnauthorized, w.Code)
+	w = post(b, "/generic", body, http.Header{notify.DefaultSignatureHeader: {signer.Sign([]byte(`{}`))}})
+	assert.Equal(http.StatusUnauthorized, w.Code)
+	assert.Empty(sender.messages)
+
+	w = post(b, "/generic", body, http.Header{notify.DefaultSignatureHeader: {signer.Sign([]byte(body))}})
+	assert.Equal(http.StatusNoContent, w.Code)
+	assert.Equal([]notify.Message{{Subject: "hi"}}, sender.messages)
+}
+
+func TestBridge_AddParser(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	sender := &recordingSender{}
+	b := New(sender)
+	b.AddParser("plain", func(_ *http.Request, body []byte) ([]notify.Message, error) {
+		return []notify.Message{{Subject: "plain", Body: string(body)}}, nil
+	})
+
+	w := post(b, "/plain", "text", nil)
+	assert.Equal(http.StatusNoContent, w.Code)
+	assert.Equal([]notify.Message{{Subject: "plain", Body: "text"}}, sender.messages)
+}
+
+func TestParseAlertmanager(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	messages, err := ParseAlertmanager(nil, []byte(`{
+		"status": "firing",
+		"externalURL": "http://alertmanager:9093",
+		"groupLabels": {"alertname": "DiskFull"},
+		"commonLabels": {"alertname": "DiskFull", "severity": "critical"},
+		"alerts": [
+			{
+				"status": "firing",
+				"labels": {"alertname": "DiskFull", "instance": "db-1"},
+				"annotations": {"summary": "db-1 disk full", "description": "less than 1% left"},
+				"generatorURL": "http://prometheus:9090/graph"
+			},
+			{"status": "resolved", "labels": {"alertname": "DiskFull", "instance": "db-2"}}
+		]
+	}`))
+	assert.Nil(err)
+	assert.Equal([]notify.Message{{
+		Subject: "[FIRING:1] DiskFull",
+		Body:    "[firing] db-1 disk full: less than 1% left\n[resolved] DiskFull",
+		Card: &notify.Card{
+			Color: colorFiring,
+			Fields: []notify.CardField{
+				{Name: "alertname", Value: "DiskFull", Inline: true},
+				{Name: "severity", Value: "critical", Inline: true},
+			},
+		},
+		Actions: []notify.Action{
+			{Label: "Source", URL: "http://prometheus:9090/graph"},
+			{Label: "Open", URL: "http://alertmanager:9093"},
+		},
+	}}, messages)
+
+	_, err = ParseAlertmanager(nil, []byte(`[]`))
+	assert.NotNil(err)
+}
+
+func TestParseGrafana(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	messages, err := ParseGrafana(nil, []byte(`{
+		"status": "resolved",
+		"title": "[RESOLVED] High latency",
+		"message": "Latency is back to normal",
+		"alerts": [{"status": "resolved", "dashboardURL": "http://grafana/d/1", "silenceURL": "http://grafana/s"}]
+	}`))
+	assert.Nil(err)
+	assert.Len(messages, 1)
+	assert.Equal("[RESOLVED] High latency", messages[0].Subject)
+	assert.Equal("Latency is back to normal", messages[0].Body)
+	assert.Equal(colorResolved, messages[0].Card.Color)
+	assert.Equal([]notify.Action{
+		{Label: "Dashboard", URL: "http://grafana/d/1"},
+		{Label: "Silence", URL: "http://grafana/s"},
+	}, messages[0].Actions)
+}
+
+func TestParseGitHub(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		event   string
+		body    string
+		want    []notify.Message
+		wantErr bool
+	}{
+		{
+			name:    "missing event header",
+			body:    `{}`,
+			wantErr: true,
+		},
+		{
+			name:  "ping",
+			event: "ping",
+			body:  `{"zen": "Keep it logically awesome."}`,
+		},
+		{
+			name:  "push",
+			event: "push",
+			body: `{
+				"ref": "refs/heads/main",
+				"compare": "https://github.com/o/r/compare/a...b",
+				"repository": {"full_name": "o/r"},
+				"sender": {"login": "octocat"},
+				"commits": [{"id": "0123456789abcdef", "message": "Fix bug\n\nDetails"}]
+			}`,
+			want: []notify.Message{{
+				Subject: "[o/r] 1 new commit(s) pushed to main by octocat",
+				Body:    "0123456 Fix bug",
+				Actions: []notify.Action{{Label: "View on GitHub", URL: "https://github.com/o/r/compare/a...b"}},
+			}},
+		},
+		{
+			name:  "pull request",
+			event: "pull_request",
+			body: `{
+				"action": "opened",
+				"repository": {"full_name": "o/r"},
+				"sender": {"login": "octocat"},
+				"pull_request": {"number": 7, "title": "Add bridge", "html_url": "https://github.com/o/r/pull/7"}
+			}`,
+			want: []notify.Message{{
+				Subject: "[o/r] Pull request opened: #7 Add bridge",
+				Body:    "https://github.com/o/r/pull/7 by octocat",
+				Actions: []notify.Action{{Label: "View on GitHub", URL: "https://github.com/o/r/pull/7"}},
+			}},
+		},
+		{
+			name:  "other event",
+			event: "repository_dispatch",
+			body:  `{"action": "deploy", "repository": {"full_name": "o/r"}, "sender": {"login": "octocat"}}`,
+			want: []notify.Message{{
+				Subject: "[o/r] repository dispatch deploy",
+				Body:    "repository_dispatch by octocat",
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert := require.New(t)
+
+			r := httptest.NewRequest(http.MethodPost, "/github", nil)
+			if tt.event != "" {
+				r.Header.Set("X-GitHub-Event", tt.event)
+			}
+
+			messages, err := ParseGitHub(r, []byte(tt.body))
+			if tt.wantErr {
+				assert.NotNil(err)
+				return
+			}
+			assert.Nil(err)
+			assert.Equal(tt.want, messages)
+		})
+	}
+}
+
+func TestParseGeneric(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	messages, err := ParseGeneric(nil, []byte(`{
+		"subject": "Deploy finished",
+		"body": "v1.2.3 is live",
+		"actions": [{"label": "Changelog", "url": "https://example.com/changelog"}],
+		"card": {"title": "api", "color": "#00ff00", "fields": [{"name": "Version", "value": "v1.2.3", "inline": true}]}
+	}`))
+	assert.Nil(err)
+	assert.Equal([]notify.Message{{
+		Subject: "Deploy finished",
+		Body:    "v1.2.3 is live",
+		Actions: []notify.Action{{Label: "Changelog", URL: "https://example.com/changelog"}},
+		Card: &notify.Card{
+			Title:  "api",
+			Color:  "#00ff00",
+			Fields: []notify.CardField{{Name: "Version", Value: "v1.2.3", Inline: true}},
+		},
+	}}, messages)
+
+	_, err = ParseGeneric(nil, []byte(`{"actions": []}`))
+	assert.NotNil(err)
+}
@@ -0,0 +1,47 @@
+/*
+Package bridge provides an HTTP server that accepts webhooks, e.g. from Alertmanager, Grafana or GitHub, and forwards
+them as notifications through the configured services. It turns notify into a small self-hosted notification router.
+
+Webhooks are posted to /<source>, where source names the parser for the payload: alertmanager, grafana, github or
+generic. Further sources can be added with AddParser.
+
+Usage:
+
+	package main
+
+	import (
+	    "context"
+	    "log"
+	    "os"
+	    "os/signal"
+
+	    "github.com/nikoksr/notify"
+	    "github.com/nikoksr/notify/bridge"
+	    "github.com/nikoksr/notify/service/slack"
+	)
+
+	func main() {
+	    slackService := slack.New("your-slack-api-token")
+	    slackService.AddReceivers("alerts")
+
+	    notifier := notify.NewWithServices(slackService)
+
+	    // Forward webhooks to Slack.
+	    b := bridge.New(notifier)
+
+	    // Require webhooks to authenticate with "Authorization: Bearer your-secret-token" or "?token=your-secret-token".
+	    b.SetToken("your-secret-token")
+
+	    // Log why notifications failed; webhook senders only get a generic error.
+	    b.SetErrorHandler(func(err error) { log.Println(err) })
+
+	    ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	    defer stop()
+
+	    // Serve until interrupted, e.g. Alertmanager posts to http://localhost:8080/alertmanager.
+	    if err := b.ListenAndServe(ctx, ":8080"); err != nil {
+	        log.Fatal(err)
+	    }
+	}
+*/
+package bridge
@@ -0,0 +1,339 @@
+package bridge
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
+)
+
+// Card colors for firing and resolved alerts.
+const (
+	colorFiring   = "#d63232"
+	colorResolved = "#2eb886"
+)
+
+// alert is an alert as posted by Alertmanager and Grafana.
+type alert struct {
+	Status       string            `json:"status"`
+	Labels       map[string]string `json:"labels"`
+	Annotations  map[string]string `json:"annotations"`
+	GeneratorURL string            `json:"generatorURL"`
+	DashboardURL string            `json:"dashboardURL"`
+	PanelURL     string            `json:"panelURL"`
+	SilenceURL   string            `json:"silenceURL"`
+}
+
+// alertGroup is the webhook payload of Alertmanager. Grafana extends it with title and message.
+type alertGroup struct {
+	Status            string            `json:"status"`
+	Alerts            []alert           `json:"alerts"`
+	GroupLabels       map[string]string `json:"groupLabels"`
+	CommonLabels      map[string]string `json:"commonLabels"`
+	CommonAnnotations map[string]string `json:"commonAnnotations"`
+	ExternalURL       string            `json:"externalURL"`
+	Title             string            `json:"title"`
+	Message           string            `json:"message"`
+}
+
+// subject returns a subject like "[FIRING:2] DiskFull".
+func (g alertGroup) subject() string {
+	firing := 0
+	for _, a := range g.Alerts {
+		if a.Status == "firing" {
+			firing++
+		}
+	}
+
+	name := g.GroupLabels["alertname"]
+	if name == "" {
+		name = g.CommonLabels["alertname"]
+	}
+	if firing > 0 {
+		return fmt.Sprintf("[FIRING:%d] %s", firing, name)
+	}
+
+	return "[RESOLVED] " + name
+}
+
+// body returns one line per alert with its summary, or its name if it has none, and its description.
+func (g alertGroup) body() string {
+	lines := make([]string, 0, len(g.Alerts))
+	for _, a := range g.Alerts {
+		summary := a.Annotations["summary"]
+		if summary == "" {
+			summary = a.Labels["alertname"]
+		}
+
+		line := "[" + a.Status + "] " + summary
+		if description := a.Annotations["description"]; description != "" {
+			line += ": " + description
+		}
+		lines = append(lines, line)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// card returns a card with the labels all alerts have in common, colored by the status of the group.
+func (g alertGroup) card() *notify.Card {
+	card := &notify.Card{Color: colorFiring}
+	if g.Status == "resolved" {
+		card.Color = colorResolved
+	}
+
+	names := make([]string, 0, len(g.CommonLabels))
+	for name := range g.CommonLabels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		card.Fields = append(card.Fields, notify.CardField{Name: name, Value: g.CommonLabels[name], Inline: true})
+	}
+
+	return card
+}
+
+// actions returns links to the source of the first alert and to the sender of the webhook.
+func (g alertGroup) actions() []notify.Action {
+	var actions []notify.Action
+	if len(g.Alerts) > 0 {
+		a := g.Alerts[0]
+		for _, action := range []notify.Action{
+			{Label: "Source", URL: a.GeneratorURL},
+			{Label: "Dashboard", URL: a.DashboardURL},
+			{Label: "Panel", URL: a.PanelURL},
+			{Label: "Silence", URL: a.SilenceURL},
+		} {
+			if action.URL != "" {
+				actions = append(actions, action)
+			}
+		}
+	}
+	if g.ExternalURL != "" {
+		actions = append(actions, notify.Action{Label: "Open", URL: g.ExternalURL})
+	}
+
+	return actions
+}
+
+// ParseAlertmanager parses the webhook of Prometheus Alertmanager into one message per alert group.
+// For more information about the payload:
+//
+//	-> https://prometheus.io/docs/alerting/latest/configuration/#webhook_config
+func ParseAlertmanager(_ *http.Request, body []byte) ([]notify.Message, error) {
+	var group alertGroup
+	if err := json.Unmarshal(body, &group); err != nil {
+		return nil, errors.Wrap(err, "failed to decode alertmanager payload")
+	}
+
+	return []notify.Message{{
+		Subject: group.subject(),
+		Body:    group.body(),
+		Card:    group.card(),
+		Actions: group.actions(),
+	}}, nil
+}
+
+// ParseGrafana parses the webhook of Grafana alerting into one message per alert group. The title and message
+// rendered by Grafana are used as subject and body if present.
+// For more information about the payload:
+//
+//	-> https://grafana.com/docs/grafana/latest/alerting/configure-notifications/manage-contact-points/integrations/webhook-notifier/
+func ParseGrafana(_ *http.Request, body []byte) ([]notify.Message, error) {
+	var group alertGroup
+	if err := json.Unmarshal(body, &group); err != nil {
+		return nil, errors.Wrap(err, "failed to decode grafana payload")
+	}
+
+	message := notify.Message{
+		Subject: group.Title,
+		Body:    group.Message,
+		Card:    group.card(),
+		Actions: group.actions(),
+	}
+	if message.Subject == "" {
+		message.Subject = group.subject()
+	}
+	if message.Body == "" {
+		message.Body = group.body()
+	}
+
+	return []notify.Message{message}, nil
+}
+
+// githubEvent holds the fields of GitHub webhook payloads used by ParseGitHub.
+type githubEvent struct {
+	Action     string `json:"action"`
+	Ref        string `json:"ref"`
+	Compare    string `json:"compare"`
+	Repository struct {
+		FullName string `json:"full_name"`
+		HTMLURL  string `json:"html_url"`
+	} `json:"repository"`
+	Sender struct {
+		Login string `json:"login"`
+	} `json:"sender"`
+	Commits []struct {
+		ID      string `json:"id"`
+		Message string `json:"message"`
+	} `json:"commits"`
+	Issue       *githubItem `json:"issue"`
+	PullRequest *githubItem `json:"pull_request"`
+	Release     *struct {
+		Name    string `json:"name"`
+		TagName string `json:"tag_name"`
+		HTMLURL string `json:"html_url"`
+	} `json:"release"`
+	WorkflowRun *struct {
+		Name       string `json:"name"`
+		Conclusion string `json:"conclusion"`
+		HTMLURL    string `json:"html_url"`
+	} `json:"workflow_run"`
+}
+
+// githubItem is an issue or pull request.
+type githubItem struct {
+	Number  int    `json:"number"`
+	Title   string `json:"title"`
+	HTMLURL string `json:"html_url"`
+}
+
+// ParseGitHub parses GitHub webhooks, identified by the X-GitHub-Event header, into a message. Pushes, issues, pull
+// requests, releases and workflow runs are described in detail, other events by name and action only. Pings are not
+// forwarded.
+// For more information about the payloads:
+//
+//	-> https://docs.github.com/en/webhooks/webhook-events-and-payloads
+func ParseGitHub(r *http.Request, body []byte) ([]notify.Message, error) {
+	name := r.Header.Get("X-GitHub-Event")
+	if name == "" {
+		return nil, errors.New("missing X-GitHub-Event header")
+	}
+	if name == "ping" {
+		return nil, nil
+	}
+
+	var event githubEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		return nil, errors.Wrap(err, "failed to decode github payload")
+	}
+
+	repo := event.Repository.FullName
+	message := notify.Message{
+		Subject: fmt.Sprintf("[%s] %s", repo, strings.TrimSpace(strings.ReplaceAll(name, "_", " ")+" "+event.Action)),
+		Body:    fmt.Sprintf("%s by %s", name, event.Sender.Login),
+	}
+	link := event.Repository.HTMLURL
+
+	switch {
+	case name == "push":
+		branch := strings.TrimPrefix(event.Ref, "refs/heads/")
+		message.Subject = fmt.Sprintf("[%s] %d new commit(s) pushed to %s by %s", repo, len(event.Commits), branch,
+			event.Sender.Login)
+		lines := make([]string, 0, len(event.Commits))
+		for _, commit := range event.Commits {
+			id := commit.ID
+			if len(id) > 7 {
+				id = id[:7]
+			}
+			lines = append(lines, id+" "+strings.SplitN(commit.Message, "\n", 2)[0])
+		}
+		message.Body = strings.Join(lines, "\n")
+		link = event.Compare
+	case event.PullRequest != nil:
+		message.Subject = fmt.Sprintf("[%s] Pull request %s: #%d %s", repo, event.Action, event.PullRequest.Number,
+			event.PullRequest.Title)
+		message.Body = fmt.Sprintf("%s by %s", event.PullRequest.HTMLURL, event.Sender.Login)
+		link = event.PullRequest.HTMLURL
+	case event.Issue != nil:
+		message.Subject = fmt.Sprintf("[%s] Issue %s: #%d %s", repo, event.Action, event.Issue.Number, event.Issue.Title)
+		message.Body = fmt.Sprintf("%s by %s", event.Issue.HTMLURL, event.Sender.Login)
+		link = event.Issue.HTMLURL
+	case event.Release != nil:
+		release := event.Release.Name
+		if release == "" {
+			release = event.Release.TagName
+		}
+		message.Subject = fmt.Sprintf("[%s] Release %s: %s", repo, event.Action, release)
+		link = event.Release.HTMLURL
+	case event.WorkflowRun != nil:
+		message.Subject = fmt.Sprintf("[%s] Workflow %s %s", repo, event.WorkflowRun.Name, event.Action)
+		if event.WorkflowRun.Conclusion != "" {
+			message.Subject += ": " + event.WorkflowRun.Conclusion
+		}
+		link = event.WorkflowRun.HTMLURL
+	}
+
+	if link != "" {
+		message.Actions = []notify.Action{{Label: "View on GitHub", URL: link}}
+	}
+
+	return []notify.Message{message}, nil
+}
+
+// genericPayload is the payload accepted by ParseGeneric.
+type genericPayload struct {
+	Subject string `json:"subject"`
+	Body    string `json:"body"`
+	HTML    string `json:"html"`
+	Actions []struct {
+		Label string `json:"label"`
+		URL   string `json:"url"`
+	} `json:"actions"`
+	Card *struct {
+		Title  string `json:"title"`
+		Fields []struct {
+			Name   string `json:"name"`
+			Value  string `json:"value"`
+			Inline bool   `json:"inline"`
+		} `json:"fields"`
+		Color    string `json:"color"`
+		ImageURL string `json:"image_url"`
+		Footer   string `json:"footer"`
+	} `json:"card"`
+}
+
+// ParseGeneric parses a provider agnostic payload into a message. The payload is a JSON object with the fields subject,
+// body, html, actions, a list of objects with label and url, and card, an object with title, fields, color, image_url
+// and footer, whose fields are objects with name, value and inline. Either subject or body is required.
+func ParseGeneric(_ *http.Request, body []byte) ([]notify.Message, error) {
+	var payload genericPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, errors.Wrap(err, "failed to decode payload")
+	}
+	if payload.Subject == "" && payload.Body == "" && payload.HTML == "" {
+		return nil, errors.New("payload has neither subject nor body")
+	}
+
+	message := notify.Message{
+		Subject: payload.Subject,
+		Body:    payload.Body,
+		HTML:    payload.HTML,
+	}
+	for _, action := range payload.Actions {
+		message.Actions = append(message.Actions, notify.Action{Label: action.Label, URL: action.URL})
+	}
+	if payload.Card != nil {
+		message.Card = &notify.Card{
+			Title:    payload.Card.Title,
+			Color:    payload.Card.Color,
+			ImageURL: payload.Card.ImageURL,
+			Footer:   payload.Card.Footer,
+		}
+		for _, field := range payload.Card.Fields {
+			message.Card.Fields = append(message.Card.Fields, notify.CardField{
+				Name:   field.Name,
+				Value:  field.Value,
+				Inline: field.Inline,
+			})
+		}
+	}
+
+	return []notify.Message{message}, nil
+}
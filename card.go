@@ -0,0 +1,57 @@
+package notify
+
+import "bytes"
+
+// CardField is a named value shown on a Card. Inline fields may be shown side by side by services that support it.
+type CardField struct {
+	Name   string
+	Value  string
+	Inline bool
+}
+
+// Card is a rich, structured block of content, e.g. the details of an alert. Services render it natively where
+// possible, e.g. as Slack blocks, Discord embed, Microsoft Teams card or HTML table, and as plain text otherwise.
+type Card struct {
+	Title  string
+	Fields []CardField
+	// Color is the accent color of the card as hex code, e.g. "#ff0000".
+	Color    string
+	ImageURL string
+	Footer   string
+}
+
+// PlainText returns the card as plain text: the title, one "name: value" line per field and the footer.
+func (c Card) PlainText() string {
+	b := getBuffer()
+	defer putBuffer(b)
+
+	c.writePlainText(b)
+
+	return b.String()
+}
+
+// writePlainText writes the card as plain text to b, see PlainText.
+func (c Card) writePlainText(b *bytes.Buffer) {
+	start := b.Len()
+	line := func(parts ...string) {
+		if b.Len() > start {
+			b.WriteByte('\n')
+		}
+		for _, part := range parts {
+			b.WriteString(part)
+		}
+	}
+
+	if c.Title != "" {
+		line(c.Title)
+	}
+	for _, field := range c.Fields {
+		line(field.Name, ": ", field.Value)
+	}
+	if c.ImageURL != "" {
+		line(c.ImageURL)
+	}
+	if c.Footer != "" {
+		line(c.Footer)
+	}
+}
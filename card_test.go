@@ -0,0 +1,36 @@
+package notify
+
+import "testing"
+
+func TestCard_PlainText(t *testing.T) {
+	t.Parallel()
+
+	card := Card{
+		Title: "Disk usage high",
+		Fields: []CardField{
+			{Name: "Host", Value: "db-1", Inline: true},
+			{Name: "Usage", Value: "95%", Inline: true},
+		},
+		Color:  "#ff0000",
+		Footer: "monitoring",
+	}
+	want := "Disk usage high\nHost: db-1\nUsage: 95%\nmonitoring"
+	if got := card.PlainText(); got != want {
+		t.Errorf("PlainText() returned %q, want %q", got, want)
+	}
+
+	msg := Message{
+		Body:    "body",
+		Card:    &card,
+		Actions: []Action{{Label: "Acknowledge", URL: "https://example.com/ack"}},
+	}
+	want = "body\n\nDisk usage high\nHost: db-1\nUsage: 95%\nmonitoring\n\nAcknowledge: https://example.com/ack"
+	if got := msg.PlainText(); got != want {
+		t.Errorf("Message.PlainText() returned %q, want %q", got, want)
+	}
+
+	msg = Message{Card: &Card{Title: "title"}}
+	if got := msg.PlainText(); got != "title" {
+		t.Errorf("Message.PlainText() without body returned %q, want %q", got, "title")
+	}
+}
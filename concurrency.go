@@ -0,0 +1,106 @@
+package notify
+
+import (
+	"context"
+	"sync"
+)
+
+// concurrency bounds the number of sends that are in flight at the same time.
+type concurrency struct {
+	mu           sync.Mutex
+	total        chan struct{}
+	serviceLimit int
+	services     []chan struct{}
+}
+
+// WithConcurrencyLimit returns an Option that limits the number of sends in flight at the same time across all
+// services. Sends beyond the limit wait until another send finished or their context is done. This prevents bursts of
+// notifications from exhausting file descriptors or connections. A limit of zero or less disables the limit.
+func WithConcurrencyLimit(limit int) Option {
+	return func(n *Notify) {
+		if n == nil {
+			return
+		}
+		if n.concurrency == nil {
+			n.concurrency = &concurrency{}
+		}
+
+		n.concurrency.mu.Lock()
+		defer n.concurrency.mu.Unlock()
+
+		n.concurrency.total = nil
+		if limit > 0 {
+			n.concurrency.total = make(chan struct{}, limit)
+		}
+	}
+}
+
+// WithServiceConcurrencyLimit returns an Option that limits the number of sends in flight at the same time to each
+// individual service, e.g. to stay within the connection limits of a provider. It can be combined with
+// WithConcurrencyLimit. A limit of zero or less disables the limit.
+func WithServiceConcurrencyLimit(limit int) Option {
+	return func(n *Notify) {
+		if n == nil {
+			return
+		}
+		if n.concurrency == nil {
+			n.concurrency = &concurrency{}
+		}
+
+		n.concurrency.mu.Lock()
+		defer n.concurrency.mu.Unlock()
+
+		n.concurrency.serviceLimit = limit
+		n.concurrency.services = nil
+	}
+}
+
+// semaphores returns the semaphores that a send to the service at the given index has to acquire. Per-service
+// semaphores are created on first use.
+func (c *concurrency) semaphores(index int) []chan struct{} {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	semaphores := make([]chan struct{}, 0, 2)
+	if c.total != nil {
+		semaphores = append(semaphores, c.total)
+	}
+	if c.serviceLimit > 0 {
+		for len(c.services) <= index {
+			c.services = append(c.services, make(chan struct{}, c.serviceLimit))
+		}
+		semaphores = append(semaphores, c.services[index])
+	}
+
+	return semaphores
+}
+
+// acquire waits until a slot for a send to the service at the given index is free, see WithConcurrencyLimit and
+// WithServiceConcurrencyLimit, and returns the acquired semaphores. They must be released with release once the send
+// is done. It returns the error of the context if it is done before a slot is free.
+func (n *Notify) acquire(ctx context.Context, index int) ([]chan struct{}, error) {
+	if n.concurrency == nil {
+		return nil, nil
+	}
+
+	semaphores := n.concurrency.semaphores(index)
+
+	// Acquire the per-service slot first, so waiting for a busy service doesn't hold a slot of the global limit.
+	for i := len(semaphores) - 1; i >= 0; i-- {
+		select {
+		case semaphores[i] <- struct{}{}:
+		case <-ctx.Done():
+			release(semaphores[i+1:])
+			return nil, ctx.Err()
+		}
+	}
+
+	return semaphores, nil
+}
+
+// release frees the slots of the given semaphores acquired by acquire.
+func release(semaphores []chan struct{}) {
+	for _, semaphore := range semaphores {
+		<-semaphore
+	}
+}
@@ -0,0 +1,104 @@
+package notify
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// inFlight tracks the maximum number of sends in flight at the same time, shared by all services using it.
+type inFlight struct {
+	current atomic.Int32
+	max     atomic.Int32
+}
+
+// blockingService holds every send for a moment.
+type blockingService struct {
+	inFlight *inFlight
+}
+
+func (s *blockingService) Send(context.Context, string, string) error {
+	current := s.inFlight.current.Add(1)
+	defer s.inFlight.current.Add(-1)
+
+	for {
+		maxInFlight := s.inFlight.max.Load()
+		if current <= maxInFlight || s.inFlight.max.CompareAndSwap(maxInFlight, current) {
+			break
+		}
+	}
+
+	time.Sleep(10 * time.Millisecond)
+
+	return nil
+}
+
+func TestWithConcurrencyLimit(t *testing.T) {
+	t.Parallel()
+
+	tracker := &inFlight{}
+	n := NewWithOptions(WithConcurrencyLimit(2))
+	for i := 0; i < 5; i++ {
+		n.UseServices(&blockingService{inFlight: tracker})
+	}
+
+	if err := n.Send(context.Background(), "subject", "message"); err != nil {
+		t.Fatalf("Send() returned error: %v", err)
+	}
+	if got := tracker.max.Load(); got > 2 {
+		t.Errorf("Send() had %d sends in flight, want at most 2", got)
+	}
+}
+
+func TestWithServiceConcurrencyLimit(t *testing.T) {
+	t.Parallel()
+
+	tracker := &inFlight{}
+	n := NewWithServices(&blockingService{inFlight: tracker})
+	n.WithOptions(WithServiceConcurrencyLimit(1))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := n.Send(context.Background(), "subject", "message"); err != nil {
+				t.Errorf("Send() returned error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := tracker.max.Load(); got != 1 {
+		t.Errorf("Send() had %d sends in flight to one service, want 1", got)
+	}
+}
+
+func TestConcurrencyLimitContext(t *testing.T) {
+	t.Parallel()
+
+	n := NewWithOptions(WithConcurrencyLimit(1))
+	n.UseServices(&blockingService{inFlight: &inFlight{}})
+
+	// Occupy the only slot.
+	semaphores, err := n.acquire(context.Background(), 0)
+	if err != nil {
+		t.Fatalf("acquire() returned error: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = n.Send(ctx, "subject", "message")
+	if !errors.Is(err, ErrSendNotification) {
+		t.Errorf("Send() with done context returned %v, want ErrSendNotification", err)
+	}
+
+	release(semaphores)
+	if err = n.Send(context.Background(), "subject", "message"); err != nil {
+		t.Errorf("Send() after release returned error: %v", err)
+	}
+}
@@ -0,0 +1,136 @@
+package notify
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/sync/errgroup"
+)
+
+// Contact is a person notifications can be sent to, with an address for every channel the person can be reached on.
+// Services that implement ContactResolver pick the address they need, so contacts can be managed in one place instead
+// of configuring raw addresses on every service.
+type Contact struct {
+	// Name identifies the contact, e.g. for logging.
+	Name string
+	// Email is the mail address of the contact, used e.g. by mail.Mail.
+	Email string
+	// Phone is the phone number of the contact in E.164 format, used e.g. by twilio.Service.
+	Phone string
+	// ChatIDs are the IDs of the contact in chat services, keyed by the name of the service, e.g. {"slack": "U123"}.
+	ChatIDs map[string]string
+	// Locale is the preferred locale of the contact, e.g. "de-DE", see ContextWithLocale.
+	Locale string
+	// TimeZone is the time zone of the contact, e.g. for rendering times in templates. Nil means UTC.
+	TimeZone *time.Location
+}
+
+// ChatID returns the ID of the contact in the chat service with the given name and whether there is one.
+func (c Contact) ChatID(service string) (string, bool) {
+	id, ok := c.ChatIDs[service]
+	return id, ok && id != ""
+}
+
+// ContactResolver is implemented by services that can send to contacts.
+//
+// The ResolveContact function returns the receiver of the contact for the service and whether the contact can be
+// reached through the service at all.
+//
+//	E.g. for mail.Mail it returns the mail address of the contact.
+//
+// It's implemented by the discord, mail, slack and telegram services, which resolve chat IDs and mail addresses, and by
+// the SMS, voice and WhatsApp services acssms, clicksend, messagebird, plivo, signal, telnyx, textbelt, textmagic,
+// twilio, twiliovoice, twiliowhatsapp and whatsapp, which resolve phone numbers. Other services are skipped.
+type ContactResolver interface {
+	ResolveContact(contact Contact) (receiver string, ok bool)
+}
+
+// contactKey is the context key of the contact a notification is sent to.
+type contactKey struct{}
+
+// ContactFromContext returns the contact a notification is sent to by SendToContacts and whether there is one. Services
+// and templates can use it e.g. to render times in the time zone of the contact.
+func ContactFromContext(ctx context.Context) (Contact, bool) {
+	contact, ok := ctx.Value(contactKey{}).(Contact)
+	return contact, ok
+}
+
+// sendToContact sends the given message to a single contact through all services that can reach it.
+func (n *Notify) sendToContact(ctx context.Context, message Message, contact Contact) error {
+	reachable := false
+	for _, service := range n.notifiers {
+		if resolver, ok := service.(ContactResolver); ok {
+			if _, ok = resolver.ResolveContact(contact); ok {
+				reachable = true
+				break
+			}
+		}
+	}
+	if !reachable {
+		return errors.Errorf("contact %q can't be reached through any service", contact.Name)
+	}
+
+	var preferences Preferences
+	if n.preferences != nil {
+		var err error
+		if preferences, err = n.preferences.Preferences(ctx, contact); err != nil {
+			return errors.Wrapf(err, "look up preferences of contact %q", contact.Name)
+		}
+	}
+	priority := SendOptionsFromContext(ctx).Priority
+
+	ctx = context.WithValue(ctx, contactKey{}, contact)
+	if contact.Locale != "" {
+		ctx = ContextWithLocale(ctx, contact.Locale)
+	}
+
+	return n.sendMessageTo(ctx, message, func(service Notifier) []string {
+		resolver, ok := service.(ContactResolver)
+		if !ok || !preferences.Allows(channelName(service), priority) {
+			return nil
+		}
+		receiver, ok := resolver.ResolveContact(contact)
+		if !ok {
+			return nil
+		}
+
+		return []string{receiver}
+	})
+}
+
+// sendToContacts sends the given message to every contact.
+func (n *Notify) sendToContacts(ctx context.Context, message Message, contacts ...Contact) error {
+	if n.Disabled {
+		return nil
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	var eg errgroup.Group
+	for _, contact := range contacts {
+		contact := contact
+		eg.Go(func() error {
+			return n.sendToContact(ctx, message, contact)
+		})
+	}
+
+	return eg.Wait()
+}
+
+// SendToContacts sends the given message to every contact through all services that implement ContactResolver and can
+// reach the contact, in the locale of the contact. Services that can't reach a contact are skipped for it; it is an
+// error if no service can reach a contact. If a PreferenceStore is set, see WithPreferences, services the contact
+// opted out of for the priority of the notification, see WithPriority, are skipped as well.
+func (n *Notify) SendToContacts(ctx context.Context, message Message, contacts ...Contact) error {
+	return n.sendToContacts(ctx, message, contacts...)
+}
+
+// SendToContacts sends the given message to every contact through all services that implement ContactResolver and can
+// reach the contact, in the locale of the contact. Services that can't reach a contact are skipped for it; it is an
+// error if no service can reach a contact. If a PreferenceStore is set, see WithPreferences, services the contact
+// opted out of for the priority of the notification, see WithPriority, are skipped as well.
+func SendToContacts(ctx context.Context, message Message, contacts ...Contact) error {
+	return std.SendToContacts(ctx, message, contacts...)
+}
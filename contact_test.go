@@ -0,0 +1,125 @@
+package notify
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// contactService sends to the chat IDs of contacts for its name and records receivers and locales.
+type contactService struct {
+	name string
+
+	mu    sync.Mutex
+	sends []string
+}
+
+func (s *contactService) ResolveContact(contact Contact) (string, bool) {
+	return contact.ChatID(s.name)
+}
+
+func (s *contactService) ChannelName() string {
+	return s.name
+}
+
+func (s *contactService) Send(ctx context.Context, _, _ string) error {
+	locale, _ := LocaleFromContext(ctx)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, receiver := range ReceiversFromContext(ctx, []string{"configured"}) {
+		s.sends = append(s.sends, receiver+"/"+locale)
+	}
+
+	return nil
+}
+
+func TestNotifySendToContacts(t *testing.T) {
+	t.Parallel()
+
+	slack, sms, plain := &contactService{name: "slack"}, &contactService{name: "sms"}, &recordingService{}
+	n := NewWithServices(slack, sms, plain)
+
+	alice := Contact{Name: "Alice", ChatIDs: map[string]string{"slack": "U1", "sms": "+491"}, Locale: "de-DE"}
+	bob := Contact{Name: "Bob", ChatIDs: map[string]string{"slack": "U2"}}
+	if err := n.SendToContacts(context.Background(), Message{Subject: "Disk full"}, alice, bob); err != nil {
+		t.Fatalf("SendToContacts() returned error: %v", err)
+	}
+
+	sort.Strings(slack.sends)
+	if diff := cmp.Diff([]string{"U1/de-DE", "U2/"}, slack.sends); diff != "" {
+		t.Errorf("SendToContacts() sent unexpected messages through slack:\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"+491/de-DE"}, sms.sends); diff != "" {
+		t.Errorf("SendToContacts() sent unexpected messages through sms:\n%s", diff)
+	}
+	if len(plain.bodies) != 0 {
+		t.Errorf("SendToContacts() sent %d messages through a service without ContactResolver, want 0", len(plain.bodies))
+	}
+
+	carol := Contact{Name: "Carol", Email: "carol@example.com"}
+	if err := n.SendToContacts(context.Background(), Message{Subject: "Disk full"}, carol); err == nil {
+		t.Error("SendToContacts() to unreachable contact returned no error")
+	}
+}
+
+func TestNotifySendToContactsWithPreferences(t *testing.T) {
+	t.Parallel()
+
+	mail, slack := &contactService{name: "mail"}, &contactService{name: "slack"}
+	preferences := NewMemoryPreferences()
+	preferences.Set("Alice", Preferences{Channels: map[string]Priority{"mail": PriorityCritical}})
+	preferences.Set("Bob", Preferences{OptOut: []string{"slack"}})
+	n := NewWithServices(mail, slack)
+	n.WithOptions(WithPreferences(preferences))
+
+	alice := Contact{Name: "Alice", ChatIDs: map[string]string{"mail": "alice", "slack": "U1"}}
+	bob := Contact{Name: "Bob", ChatIDs: map[string]string{"mail": "bob", "slack": "U2"}}
+	ctx := context.Background()
+	if err := n.SendToContacts(ctx, Message{Subject: "Deploy done"}, alice, bob); err != nil {
+		t.Fatalf("SendToContacts() returned error: %v", err)
+	}
+	ctx = ContextWithSendOptions(ctx, WithPriority(PriorityCritical))
+	if err := n.SendToContacts(ctx, Message{Subject: "Disk full"}, alice); err != nil {
+		t.Fatalf("SendToContacts() returned error: %v", err)
+	}
+
+	sort.Strings(mail.sends)
+	if diff := cmp.Diff([]string{"alice/", "bob/"}, mail.sends); diff != "" {
+		t.Errorf("SendToContacts() sent unexpected mails:\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"U1/", "U1/"}, slack.sends); diff != "" {
+		t.Errorf("SendToContacts() sent unexpected slack messages:\n%s", diff)
+	}
+}
+
+func TestPreferences_Allows(t *testing.T) {
+	t.Parallel()
+
+	p := Preferences{MinPriority: PriorityHigh, Channels: map[string]Priority{"slack": PriorityLow}, OptOut: []string{"sms"}}
+	tests := []struct {
+		channel  string
+		priority Priority
+		want     bool
+	}{
+		{"mail", PriorityDefault, false},
+		{"mail", PriorityHigh, true},
+		{"slack", PriorityDefault, true},
+		{"sms", PriorityCritical, false},
+	}
+	for _, tt := range tests {
+		if got := p.Allows(tt.channel, tt.priority); got != tt.want {
+			t.Errorf("Allows(%q, %d) = %v, want %v", tt.channel, tt.priority, got, tt.want)
+		}
+	}
+	if !(Preferences{}).Allows("mail", PriorityLow) {
+		t.Error("zero Preferences don't allow a notification")
+	}
+	if got := channelName(&recordingService{}); got != "notify" {
+		t.Errorf("channelName() = %q, want notify", got)
+	}
+}
@@ -0,0 +1,45 @@
+package notify
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+// Deleter is implemented by services that can retract a message after it was sent.
+//
+// The Delete function removes the message identified by a Receipt the service recorded.
+//
+//	E.g. for telegram.Telegram it deletes the message from the chat it was sent to.
+type Deleter interface {
+	Delete(ctx context.Context, receipt Receipt) error
+}
+
+// delete asks the service that sent the message identified by the given receipt to retract it.
+func (n *Notify) delete(ctx context.Context, receipt Receipt) error {
+	if n.Disabled {
+		return nil
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	deleter, ok := receipt.Service.(Deleter)
+	if !ok {
+		return errors.Wrap(ErrUnsupported, "delete notification")
+	}
+
+	return errors.Wrap(deleter.Delete(ctx, receipt), "delete notification")
+}
+
+// Delete asks the service that sent the message identified by the given receipt to retract it. It returns
+// ErrUnsupported if the service does not implement Deleter.
+func (n *Notify) Delete(ctx context.Context, receipt Receipt) error {
+	return n.delete(ctx, receipt)
+}
+
+// Delete asks the service that sent the message identified by the given receipt to retract it. It returns
+// ErrUnsupported if the service does not implement Deleter.
+func Delete(ctx context.Context, receipt Receipt) error {
+	return std.Delete(ctx, receipt)
+}
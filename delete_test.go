@@ -0,0 +1,58 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type deleteService struct {
+	deleted []string
+}
+
+func (s *deleteService) Send(context.Context, string, string) error {
+	return nil
+}
+
+func (s *deleteService) Delete(_ context.Context, receipt Receipt) error {
+	if receipt.MessageID == "" {
+		return errors.New("missing message ID")
+	}
+	s.deleted = append(s.deleted, receipt.MessageID)
+
+	return nil
+}
+
+func TestNotifyDelete(t *testing.T) {
+	t.Parallel()
+
+	n := New()
+	ctx := context.Background()
+
+	service := &deleteService{}
+	if err := n.Delete(ctx, Receipt{Service: service, MessageID: "id"}); err != nil {
+		t.Fatalf("Delete() returned error: %v", err)
+	}
+	if len(service.deleted) != 1 || service.deleted[0] != "id" {
+		t.Errorf("Delete() deleted %v, want [id]", service.deleted)
+	}
+
+	if err := n.Delete(ctx, Receipt{Service: service}); err == nil {
+		t.Error("Delete() of failing service returned no error")
+	}
+
+	// Services that don't implement Deleter are reported as unsupported.
+	err := n.Delete(ctx, Receipt{Service: newFailingService(), MessageID: "id"})
+	if !errors.Is(err, ErrUnsupported) {
+		t.Errorf("Delete() of unsupported service returned %v, want ErrUnsupported", err)
+	}
+
+	// After disabling the Notifier, Delete() should return silently.
+	n.WithOptions(Disable)
+	if err = n.Delete(ctx, Receipt{Service: service, MessageID: "id"}); err != nil {
+		t.Errorf("Delete() of disabled Notifier returned error: %v", err)
+	}
+	if len(service.deleted) != 1 {
+		t.Errorf("Delete() of disabled Notifier deleted %v", service.deleted)
+	}
+}
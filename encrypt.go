@@ -0,0 +1,144 @@
+package notify
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// encryptionVersion is the version of the format of encrypted payloads, see Encrypter.Encrypt.
+const encryptionVersion = 1
+
+// ErrUnknownKey signals that a payload was encrypted with a key the Encrypter doesn't know.
+var ErrUnknownKey = errors.New("unknown encryption key")
+
+// Encrypter encrypts stored payloads, e.g. audit records, with AES-GCM, since they may contain personal data. It holds
+// several keys identified by IDs: payloads are encrypted with the primary key and decrypted with the key they were
+// encrypted with, so keys can be rotated without losing access to payloads stored before. It is safe for concurrent
+// use.
+type Encrypter struct {
+	mu      sync.RWMutex
+	primary string
+	keys    map[string]cipher.AEAD
+}
+
+// newAEAD returns AES-GCM with the given key, which must be 16, 24 or 32 bytes long.
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.Wrap(err, "create cipher")
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// NewEncrypter returns an Encrypter with the given primary key. The key must be 16, 24 or 32 bytes long to select
+// AES-128, AES-192 or AES-256, and the key ID must be at most 255 bytes long.
+func NewEncrypter(keyID string, key []byte) (*Encrypter, error) {
+	e := &Encrypter{keys: make(map[string]cipher.AEAD)}
+	if err := e.Rotate(keyID, key); err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
+
+// AddKey adds a key that is only used to decrypt payloads, e.g. a previous primary key.
+func (e *Encrypter) AddKey(keyID string, key []byte) error {
+	if keyID == "" || len(keyID) > 255 {
+		return errors.New("key ID must be between 1 and 255 bytes long")
+	}
+
+	aead, err := newAEAD(key)
+	if err != nil {
+		return err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.keys[keyID] = aead
+
+	return nil
+}
+
+// Rotate adds a key and makes it the primary key. Payloads encrypted with previous keys can still be decrypted.
+func (e *Encrypter) Rotate(keyID string, key []byte) error {
+	if err := e.AddKey(keyID, key); err != nil {
+		return err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.primary = keyID
+
+	return nil
+}
+
+// PrimaryKeyID returns the ID of the key payloads are encrypted with.
+func (e *Encrypter) PrimaryKeyID() string {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	return e.primary
+}
+
+// Encrypt encrypts the plaintext with the primary key. The result holds a format version, the ID of the key, a random
+// nonce and the sealed plaintext. Version and key ID are authenticated as well.
+func (e *Encrypter) Encrypt(plaintext []byte) ([]byte, error) {
+	e.mu.RLock()
+	keyID, aead := e.primary, e.keys[e.primary]
+	e.mu.RUnlock()
+
+	header := make([]byte, 0, 2+len(keyID))
+	header = append(header, encryptionVersion, byte(len(keyID)))
+	header = append(header, keyID...)
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, errors.Wrap(err, "generate nonce")
+	}
+
+	out := make([]byte, 0, len(header)+len(nonce)+len(plaintext)+aead.Overhead())
+	out = append(out, header...)
+	out = append(out, nonce...)
+
+	return aead.Seal(out, nonce, plaintext, header), nil
+}
+
+// Decrypt decrypts a payload encrypted by Encrypt with any of the keys of the Encrypter. It returns ErrUnknownKey if
+// the key of the payload is unknown.
+func (e *Encrypter) Decrypt(payload []byte) ([]byte, error) {
+	if len(payload) < 2 || payload[0] != encryptionVersion {
+		return nil, errors.New("unsupported payload format")
+	}
+
+	headerLen := 2 + int(payload[1])
+	if len(payload) < headerLen {
+		return nil, errors.New("truncated payload")
+	}
+	header, keyID := payload[:headerLen], string(payload[2:headerLen])
+
+	e.mu.RLock()
+	aead, ok := e.keys[keyID]
+	e.mu.RUnlock()
+	if !ok {
+		return nil, errors.Wrapf(ErrUnknownKey, "key %q", keyID)
+	}
+
+	rest := payload[headerLen:]
+	if len(rest) < aead.NonceSize() {
+		return nil, errors.New("truncated payload")
+	}
+
+	plaintext, err := aead.Open(nil, rest[:aead.NonceSize()], rest[aead.NonceSize():], header)
+	if err != nil {
+		return nil, errors.Wrap(err, "decrypt payload")
+	}
+
+	return plaintext, nil
+}
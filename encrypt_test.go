@@ -0,0 +1,153 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestEncrypter(t *testing.T) {
+	t.Parallel()
+
+	oldKey := bytes.Repeat([]byte{1}, 32)
+	newKey := bytes.Repeat([]byte{2}, 16)
+
+	e, err := NewEncrypter("2024-01", oldKey)
+	if err != nil {
+		t.Fatalf("NewEncrypter() returned error: %v", err)
+	}
+	if _, err = NewEncrypter("bad", []byte("short")); err == nil {
+		t.Error("NewEncrypter() with invalid key returned no error")
+	}
+
+	plaintext := []byte("alice@example.com")
+	sealed, err := e.Encrypt(plaintext)
+	if err != nil {
+		t.Fatalf("Encrypt() returned error: %v", err)
+	}
+	if bytes.Contains(sealed, plaintext) {
+		t.Error("Encrypt() returned the plaintext")
+	}
+	again, _ := e.Encrypt(plaintext)
+	if bytes.Equal(sealed, again) {
+		t.Error("Encrypt() returned the same payload twice")
+	}
+
+	// Payloads encrypted before a rotation can still be decrypted.
+	if err = e.Rotate("2024-02", newKey); err != nil {
+		t.Fatalf("Rotate() returned error: %v", err)
+	}
+	if got := e.PrimaryKeyID(); got != "2024-02" {
+		t.Errorf("PrimaryKeyID() = %q, want 2024-02", got)
+	}
+	rotated, _ := e.Encrypt(plaintext)
+	for _, payload := range [][]byte{sealed, rotated} {
+		got, err := e.Decrypt(payload)
+		if err != nil {
+			t.Fatalf("Decrypt() returned error: %v", err)
+		}
+		if !bytes.Equal(got, plaintext) {
+			t.Errorf("Decrypt() = %q, want %q", got, plaintext)
+		}
+	}
+
+	// Tampered payloads and unknown keys are rejected.
+	tampered := append([]byte(nil), rotated...)
+	tampered[len(tampered)-1] ^= 1
+	if _, err = e.Decrypt(tampered); err == nil {
+		t.Error("Decrypt() of tampered payload returned no error")
+	}
+	other, _ := NewEncrypter("2024-02", newKey)
+	if _, err = other.Decrypt(sealed); !errors.Is(err, ErrUnknownKey) {
+		t.Errorf("Decrypt() with unknown key returned %v, want ErrUnknownKey", err)
+	}
+	if _, err = e.Decrypt([]byte{9}); err == nil {
+		t.Error("Decrypt() of invalid payload returned no error")
+	}
+}
+
+func TestFileAuditStoreEncrypted(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "audit.log")
+	store := NewFileAuditStore(path)
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+
+	// A record written before encryption was enabled.
+	if err := store.Record(ctx, AuditRecord{Time: now, Receivers: []string{"plain@example.com"}}); err != nil {
+		t.Fatalf("Record() returned error: %v", err)
+	}
+
+	e, _ := NewEncrypter("v1", bytes.Repeat([]byte{1}, 32))
+	store.SetEncrypter(e)
+	for _, receiver := range []string{"old@example.com", "alice@example.com"} {
+		age := time.Hour
+		if receiver == "old@example.com" {
+			age = 48 * time.Hour
+		}
+		if err := store.Record(ctx, AuditRecord{Time: now.Add(-age), Receivers: []string{receiver}}); err != nil {
+			t.Fatalf("Record() returned error: %v", err)
+		}
+	}
+
+	data, _ := os.ReadFile(path)
+	if bytes.Contains(data, []byte("alice@example.com")) {
+		t.Error("Record() wrote the receivers unencrypted")
+	}
+
+	// Pruning after a rotation re-encrypts the kept records with the new key.
+	_ = e.Rotate("v2", bytes.Repeat([]byte{2}, 32))
+	if err := store.Prune(ctx, now.Add(-24*time.Hour)); err != nil {
+		t.Fatalf("Prune() returned error: %v", err)
+	}
+	v2, _ := NewEncrypter("v2", bytes.Repeat([]byte{2}, 32))
+	store.SetEncrypter(v2)
+
+	records, err := store.Records(ctx)
+	if err != nil {
+		t.Fatalf("Records() returned error: %v", err)
+	}
+	if len(records) != 2 || records[0].Receivers[0] != "plain@example.com" ||
+		records[1].Receivers[0] != "alice@example.com" {
+		t.Errorf("Records() returned unexpected records: %+v", records)
+	}
+}
+
+func TestSQLAuditStoreEncrypted(t *testing.T) {
+	t.Parallel()
+
+	rd := &recordingDriver{}
+	sql.Register("notify-recording-"+t.Name(), rd)
+	db, err := sql.Open("notify-recording-"+t.Name(), "")
+	if err != nil {
+		t.Fatalf("Open() returned error: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	e, _ := NewEncrypter("v1", bytes.Repeat([]byte{1}, 32))
+	store := NewSQLAuditStore(db, "notify_audit")
+	store.SetEncrypter(e)
+
+	record := AuditRecord{Time: time.Now(), Service: "*mail.Mail", Receivers: []string{"alice@example.com"}}
+	if err = store.Record(context.Background(), record); err != nil {
+		t.Fatalf("Record() returned error: %v", err)
+	}
+
+	if got := rd.args[0][2]; got != "*mail.Mail" {
+		t.Errorf("Record() stored service %v, want it unencrypted", got)
+	}
+	sealed, err := base64.StdEncoding.DecodeString(rd.args[0][3].(string))
+	if err != nil {
+		t.Fatalf("Record() stored receivers that aren't base64 encoded: %v", err)
+	}
+	if receivers, err := e.Decrypt(sealed); err != nil || string(receivers) != "alice@example.com" {
+		t.Errorf("Record() stored receivers %q (%v), want encrypted alice@example.com", receivers, err)
+	}
+}
@@ -0,0 +1,203 @@
+package notify
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ErrPermanent signals that a request failed in a way that retrying it will not fix, e.g. because it was malformed.
+var ErrPermanent = errors.New("permanent failure")
+
+// ErrTransient signals that a request failed in a way that retrying it later may fix, e.g. because the service was
+// overloaded. *ErrRateLimited errors are transient.
+var ErrTransient = errors.New("transient failure")
+
+// ErrAuthFailed signals that a service rejected the credentials it was configured with. It is a permanent error.
+var ErrAuthFailed error = &classError{msg: "authentication failed", class: ErrPermanent}
+
+// ErrInvalidReceiver signals that a service rejected a receiver, e.g. because the channel does not exist. It is a
+// permanent error.
+var ErrInvalidReceiver error = &classError{msg: "invalid receiver", class: ErrPermanent}
+
+// classError is an error class that belongs to a broader class, e.g. ErrAuthFailed is an ErrPermanent.
+type classError struct {
+	msg   string
+	class error
+}
+
+// Error implements the error interface.
+func (e *classError) Error() string {
+	return e.msg
+}
+
+// Is reports whether the error belongs to the target class.
+func (e *classError) Is(target error) bool {
+	return target == e.class
+}
+
+// classifiedError is an error marked with an error class.
+type classifiedError struct {
+	err   error
+	class error
+}
+
+// Error implements the error interface.
+func (e *classifiedError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the classified error.
+func (e *classifiedError) Unwrap() error {
+	return e.err
+}
+
+// Is reports whether the error belongs to the target class.
+func (e *classifiedError) Is(target error) bool {
+	return errors.Is(e.class, target)
+}
+
+// As finds the first error in the class of the error that matches target.
+func (e *classifiedError) As(target any) bool {
+	return errors.As(e.class, target)
+}
+
+// sendError is an ErrSendNotification caused by the errors of one or more services. It matches ErrSendNotification
+// and the errors of the services with errors.Is and errors.As, so that callers can tell e.g. an ErrAuthFailed or an
+// *ErrRateLimited of a service apart.
+type sendError struct {
+	errs []error
+}
+
+// newSendError returns a sendError for the given errors, or nil if there are none.
+func newSendError(errs ...error) error {
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return &sendError{errs: errs}
+}
+
+// Error implements the error interface.
+func (e *sendError) Error() string {
+	msgs := make([]string, len(e.errs))
+	for i, err := range e.errs {
+		msgs[i] = err.Error()
+	}
+
+	return strings.Join(msgs, "; ") + ": " + ErrSendNotification.Error()
+}
+
+// Unwrap returns ErrSendNotification and the errors of the services.
+func (e *sendError) Unwrap() []error {
+	return append([]error{ErrSendNotification}, e.errs...)
+}
+
+// Is reports whether the target is ErrSendNotification or matches the error of a service.
+func (e *sendError) Is(target error) bool {
+	if target == ErrSendNotification {
+		return true
+	}
+	for _, err := range e.errs {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// As finds the first error of a service that matches target.
+func (e *sendError) As(target any) bool {
+	for _, err := range e.errs {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ClassifyError marks the given error with an error class, e.g. ErrAuthFailed, so that errors.Is reports true for the
+// class and all classes it belongs to. The message of the error is not changed. It returns nil if err is nil.
+//
+//	err = notify.ClassifyError(err, notify.ErrInvalidReceiver)
+//	errors.Is(err, notify.ErrPermanent) // true
+func ClassifyError(err, class error) error {
+	if err == nil || class == nil {
+		return err
+	}
+
+	return &classifiedError{err: err, class: class}
+}
+
+// ClassifyStatus classifies the given error by the HTTP status code of the response that caused it:
+//
+//   - 401 and 403 as ErrAuthFailed
+//   - 404 and 410 as ErrInvalidReceiver
+//   - 429 as *ErrRateLimited
+//   - 408 and 5xx as ErrTransient
+//   - all other 4xx as ErrPermanent
+//
+// The error is returned unchanged for all other status codes. Prefer ClassifyResponse if the response is at hand, so
+// that the Retry-After header of 429 responses is honored.
+func ClassifyStatus(statusCode int, err error) error {
+	switch {
+	case statusCode == http.StatusUnauthorized, statusCode == http.StatusForbidden:
+		return ClassifyError(err, ErrAuthFailed)
+	case statusCode == http.StatusNotFound, statusCode == http.StatusGone:
+		return ClassifyError(err, ErrInvalidReceiver)
+	case statusCode == http.StatusTooManyRequests:
+		return ClassifyError(err, &ErrRateLimited{})
+	case statusCode == http.StatusRequestTimeout, statusCode >= 500 && statusCode < 600:
+		return ClassifyError(err, ErrTransient)
+	case statusCode >= 400 && statusCode < 500:
+		return ClassifyError(err, ErrPermanent)
+	default:
+		return err
+	}
+}
+
+// ClassifyResponse classifies the given error by the status code of the given response like ClassifyStatus. For 429
+// Too Many Requests the *ErrRateLimited carries the delay of the Retry-After header, like for CheckRateLimit. The error
+// is returned unchanged if the response is nil.
+func ClassifyResponse(resp *http.Response, err error) error {
+	if resp == nil {
+		return err
+	}
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return ClassifyError(err, &ErrRateLimited{RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())})
+	}
+
+	return ClassifyStatus(resp.StatusCode, err)
+}
+
+// ClassifyNetworkError classifies errors of the network as ErrTransient: timeouts, failed dials and DNS lookups and
+// connections that were closed unexpectedly. It returns all other errors unchanged.
+func ClassifyNetworkError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var opErr *net.OpError
+	var dnsErr *net.DNSError
+	var netErr net.Error
+	switch {
+	case errors.As(err, &opErr), errors.As(err, &dnsErr):
+		return ClassifyError(err, ErrTransient)
+	case errors.As(err, &netErr) && netErr.Timeout():
+		return ClassifyError(err, ErrTransient)
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(err, os.ErrDeadlineExceeded):
+		return ClassifyError(err, ErrTransient)
+	case errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF), errors.Is(err, net.ErrClosed):
+		return ClassifyError(err, ErrTransient)
+	default:
+		return err
+	}
+}
@@ -0,0 +1,162 @@
+package notify
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// errorService fails every send with its error.
+type errorService struct {
+	err error
+}
+
+func (s errorService) Send(context.Context, string, string) error {
+	return s.err
+}
+
+func TestClassifyError(t *testing.T) {
+	t.Parallel()
+
+	if ClassifyError(nil, ErrPermanent) != nil {
+		t.Error("ClassifyError(nil) returned non-nil error")
+	}
+
+	cause := errors.New("channel not found")
+	err := errors.Wrap(ClassifyError(cause, ErrInvalidReceiver), "send")
+
+	if err.Error() != "send: channel not found" {
+		t.Errorf("ClassifyError() changed message to %q", err.Error())
+	}
+	if !errors.Is(err, cause) {
+		t.Error("ClassifyError() does not wrap the cause")
+	}
+	if !errors.Is(err, ErrInvalidReceiver) || !errors.Is(err, ErrPermanent) {
+		t.Error("ClassifyError() error is not an ErrInvalidReceiver and ErrPermanent")
+	}
+	if errors.Is(err, ErrTransient) || errors.Is(err, ErrAuthFailed) {
+		t.Error("ClassifyError() error belongs to unrelated classes")
+	}
+	if !errors.Is(&ErrRateLimited{}, ErrTransient) {
+		t.Error("ErrRateLimited is not an ErrTransient")
+	}
+}
+
+func TestClassifyStatus(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		status int
+		class  error
+	}{
+		{status: http.StatusBadRequest, class: ErrPermanent},
+		{status: http.StatusUnauthorized, class: ErrAuthFailed},
+		{status: http.StatusForbidden, class: ErrAuthFailed},
+		{status: http.StatusNotFound, class: ErrInvalidReceiver},
+		{status: http.StatusRequestTimeout, class: ErrTransient},
+		{status: http.StatusTooManyRequests, class: ErrTransient},
+		{status: http.StatusBadGateway, class: ErrTransient},
+	}
+
+	for _, tt := range tests {
+		err := ClassifyStatus(tt.status, errors.New("failed"))
+		if !errors.Is(err, tt.class) {
+			t.Errorf("ClassifyStatus(%d) = %v, want class %v", tt.status, err, tt.class)
+		}
+	}
+
+	err := ClassifyStatus(http.StatusTooManyRequests, errors.New("failed"))
+	if _, ok := RetryAfter(err); !ok {
+		t.Error("ClassifyStatus(429) is not an *ErrRateLimited")
+	}
+
+	err = errors.New("failed")
+	if got := ClassifyStatus(http.StatusMultipleChoices, err); got != err {
+		t.Errorf("ClassifyStatus(300) = %v, want unchanged error", got)
+	}
+}
+
+func TestClassifyResponse(t *testing.T) {
+	t.Parallel()
+
+	resp := &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{"Retry-After": []string{"30"}}}
+	err := ClassifyResponse(resp, errors.New("failed"))
+	if after, ok := RetryAfter(err); !ok || after != 30*time.Second {
+		t.Errorf("RetryAfter(ClassifyResponse(429)) = %v, %t, want 30s, true", after, ok)
+	}
+	if err.Error() != "failed" {
+		t.Errorf("ClassifyResponse(429) changed message to %q", err.Error())
+	}
+
+	resp = &http.Response{StatusCode: http.StatusNotFound, Header: http.Header{}}
+	if err = ClassifyResponse(resp, errors.New("failed")); !errors.Is(err, ErrInvalidReceiver) {
+		t.Errorf("ClassifyResponse(404) = %v, want class %v", err, ErrInvalidReceiver)
+	}
+
+	err = errors.New("failed")
+	if got := ClassifyResponse(nil, err); got != err {
+		t.Errorf("ClassifyResponse(nil) = %v, want unchanged error", got)
+	}
+}
+
+func TestClassifyNetworkError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		err       error
+		transient bool
+	}{
+		{name: "dial", err: &net.OpError{Op: "dial", Err: errors.New("connection refused")}, transient: true},
+		{name: "dns", err: &net.DNSError{Err: "no such host", Name: "example.invalid"}, transient: true},
+		{name: "deadline", err: errors.Wrap(context.DeadlineExceeded, "send"), transient: true},
+		{name: "eof", err: errors.Wrap(io.ErrUnexpectedEOF, "read response"), transient: true},
+		{name: "other", err: errors.New("invalid payload")},
+	}
+
+	for _, tt := range tests {
+		err := ClassifyNetworkError(tt.err)
+		if errors.Is(err, ErrTransient) != tt.transient {
+			t.Errorf("%s: ClassifyNetworkError() = %v, want transient %v", tt.name, err, tt.transient)
+		}
+	}
+
+	if err := ClassifyNetworkError(nil); err != nil {
+		t.Errorf("ClassifyNetworkError(nil) = %v, want nil", err)
+	}
+}
+
+func TestNotify_Send_errorClasses(t *testing.T) {
+	t.Parallel()
+
+	n := New()
+	n.UseServices(errorService{err: ClassifyError(errors.New("invalid token"), ErrAuthFailed)})
+
+	err := n.Send(context.Background(), "subject", "message")
+	if err.Error() != "invalid token: send notification" {
+		t.Errorf("Send() error = %q", err.Error())
+	}
+	if !errors.Is(err, ErrSendNotification) {
+		t.Error("Send() error is not an ErrSendNotification")
+	}
+	if !errors.Is(err, ErrAuthFailed) || !errors.Is(err, ErrPermanent) {
+		t.Error("Send() error lost the class of the service error")
+	}
+
+	// The errors of all services are kept.
+	n.UseServices(errorService{err: ClassifyError(errors.New("slow down"), &ErrRateLimited{RetryAfter: time.Minute})})
+
+	err = n.SendMessage(context.Background(), Message{Subject: "subject"})
+	if !errors.Is(err, ErrAuthFailed) || !errors.Is(err, ErrTransient) {
+		t.Errorf("SendMessage() error = %v, want errors of both services", err)
+	}
+	var rateLimited *ErrRateLimited
+	if !errors.As(err, &rateLimited) || rateLimited.RetryAfter != time.Minute {
+		t.Errorf("SendMessage() error lost RetryAfter: %v", err)
+	}
+}
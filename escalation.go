@@ -0,0 +1,167 @@
+package notify
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ErrNotAcknowledged signals that a notification was not acknowledged after all steps of its escalation policy.
+var ErrNotAcknowledged = errors.New("notification not acknowledged")
+
+// EscalationStep is a tier of an escalation policy.
+type EscalationStep struct {
+	// Services are notified when the step is reached, e.g. chat services first and SMS or voice call services later.
+	Services []Notifier
+	// Wait is how long to wait for an acknowledgement before escalating to the next step.
+	Wait time.Duration
+}
+
+// EscalationPolicy defines how a notification escalates until it is acknowledged.
+type EscalationPolicy struct {
+	Steps []EscalationStep
+}
+
+// Escalation is a running escalation, see Notify.Escalate.
+type Escalation struct {
+	token  string
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	mu   sync.Mutex
+	step int
+	ack  Ack
+	err  error
+}
+
+// Token returns the acknowledgement token of the escalation.
+func (e *Escalation) Token() string {
+	return e.token
+}
+
+// Step returns the index of the step of the policy that was notified last.
+func (e *Escalation) Step() int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.step
+}
+
+// Cancel stops the escalation. Steps that were not reached yet are not notified.
+func (e *Escalation) Cancel() {
+	e.cancel()
+}
+
+// Done returns a channel that is closed once the escalation ended.
+func (e *Escalation) Done() <-chan struct{} {
+	return e.done
+}
+
+// Wait waits for the escalation to end. It returns the acknowledgement of the notification, or ErrNotAcknowledged if
+// no step was acknowledged in time and the error of the context if the escalation was canceled.
+func (e *Escalation) Wait() (Ack, error) {
+	<-e.done
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.ack, e.err
+}
+
+// finish records the result of the escalation.
+func (e *Escalation) finish(ack Ack, err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.ack, e.err = ack, err
+}
+
+// run notifies the steps of the policy one after another until the token is acknowledged.
+func (e *Escalation) run(ctx context.Context, n *Notify, acks *Acks, policy EscalationPolicy, message Message) {
+	defer close(e.done)
+	defer acks.Forget(e.token)
+	defer e.cancel()
+
+	var sendErr error
+	for i, step := range policy.Steps {
+		if err := ctx.Err(); err != nil {
+			e.finish(Ack{}, err)
+			return
+		}
+
+		e.mu.Lock()
+		e.step = i
+		e.mu.Unlock()
+
+		tier := *n
+		tier.notifiers = step.Services
+		tier.concurrency = nil
+
+		wait := step.Wait
+		if sendErr = tier.sendMessage(ctx, message); sendErr != nil {
+			// Escalate right away, the step may never have been notified.
+			wait = time.Nanosecond
+		}
+
+		ack, err := acks.Wait(ctx, e.token, wait)
+		switch {
+		case err == nil:
+			e.finish(ack, nil)
+			return
+		case !errors.Is(err, ErrAckTimeout):
+			e.finish(Ack{}, err)
+			return
+		}
+	}
+
+	if sendErr != nil {
+		e.finish(Ack{}, errors.Wrap(ErrNotAcknowledged, sendErr.Error()))
+		return
+	}
+	e.finish(Ack{}, ErrNotAcknowledged)
+}
+
+// escalate starts an escalation of the message along the given policy.
+func (n *Notify) escalate(ctx context.Context, acks *Acks, policy EscalationPolicy, message Message) (*Escalation, error) {
+	if len(policy.Steps) == 0 {
+		return nil, errors.New("escalation policy has no steps")
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	ctx, token := acks.ContextWithAck(ctx)
+	ctx, cancel := context.WithCancel(ctx)
+	e := &Escalation{
+		token:  token,
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+	go e.run(ctx, n, acks, policy, message)
+
+	return e, nil
+}
+
+// Escalate sends the message to the services of the first step of the policy and, if the message is not acknowledged
+// within the wait of the step, to the services of the next step, until it is acknowledged or all steps were notified.
+// A step whose services fail to send escalates immediately. The message is sent with an acknowledgement token of the
+// given tracker, see Acks.ContextWithAck, and the escalation stops once the token is acknowledged.
+//
+// The steps are sent with the options of the Notify instance, except for concurrency limits, which only apply to its
+// own services. Escalate returns right away; use the returned Escalation to wait for the result or cancel it.
+func (n *Notify) Escalate(ctx context.Context, acks *Acks, policy EscalationPolicy, message Message) (*Escalation, error) {
+	return n.escalate(ctx, acks, policy, message)
+}
+
+// Escalate sends the message to the services of the first step of the policy and, if the message is not acknowledged
+// within the wait of the step, to the services of the next step, until it is acknowledged or all steps were notified.
+// A step whose services fail to send escalates immediately. The message is sent with an acknowledgement token of the
+// given tracker, see Acks.ContextWithAck, and the escalation stops once the token is acknowledged.
+//
+// The steps are sent with the options of the default Notify instance, except for concurrency limits. Escalate returns
+// right away; use the returned Escalation to wait for the result or cancel it.
+func Escalate(ctx context.Context, acks *Acks, policy EscalationPolicy, message Message) (*Escalation, error) {
+	return std.Escalate(ctx, acks, policy, message)
+}
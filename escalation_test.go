@@ -0,0 +1,93 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNotifyEscalate(t *testing.T) {
+	t.Parallel()
+
+	chat, sms, voice := &recordingService{}, &recordingService{}, &recordingService{}
+	policy := EscalationPolicy{Steps: []EscalationStep{
+		{Services: []Notifier{chat}, Wait: 10 * time.Millisecond},
+		{Services: []Notifier{sms}, Wait: time.Second},
+		{Services: []Notifier{voice}, Wait: time.Second},
+	}}
+
+	acks := NewAcks("")
+	e, err := New().Escalate(context.Background(), acks, policy, Message{Subject: "Disk full", Body: "db-1"})
+	if err != nil {
+		t.Fatalf("Escalate() returned error: %v", err)
+	}
+
+	// Acknowledge once the second step was notified.
+	for e.Step() < 1 {
+		time.Sleep(time.Millisecond)
+	}
+	if err = acks.Acknowledge(e.Token(), "alice"); err != nil {
+		t.Fatalf("Acknowledge() returned error: %v", err)
+	}
+
+	ack, err := e.Wait()
+	if err != nil {
+		t.Fatalf("Wait() returned error: %v", err)
+	}
+	if ack.By != "alice" {
+		t.Errorf("Wait() returned acknowledgement by %q, want alice", ack.By)
+	}
+	if len(chat.bodies) != 1 || len(sms.bodies) != 1 || len(voice.bodies) != 0 {
+		t.Errorf("Escalate() notified %d, %d and %d times, want 1, 1 and 0",
+			len(chat.bodies), len(sms.bodies), len(voice.bodies))
+	}
+}
+
+func TestNotifyEscalateExhausted(t *testing.T) {
+	t.Parallel()
+
+	chat := &recordingService{}
+	policy := EscalationPolicy{Steps: []EscalationStep{
+		// The first step fails and escalates immediately, although it would wait for an hour.
+		{Services: []Notifier{newFailingService()}, Wait: time.Hour},
+		{Services: []Notifier{chat}, Wait: time.Millisecond},
+	}}
+
+	e, err := New().Escalate(context.Background(), NewAcks(""), policy, Message{Subject: "Disk full"})
+	if err != nil {
+		t.Fatalf("Escalate() returned error: %v", err)
+	}
+	if _, err = e.Wait(); !errors.Is(err, ErrNotAcknowledged) {
+		t.Errorf("Wait() returned %v, want ErrNotAcknowledged", err)
+	}
+	if len(chat.bodies) != 1 {
+		t.Errorf("Escalate() notified last step %d times, want 1", len(chat.bodies))
+	}
+}
+
+func TestNotifyEscalateCancel(t *testing.T) {
+	t.Parallel()
+
+	chat, sms := &recordingService{}, &recordingService{}
+	policy := EscalationPolicy{Steps: []EscalationStep{
+		{Services: []Notifier{chat}, Wait: time.Hour},
+		{Services: []Notifier{sms}},
+	}}
+
+	e, err := New().Escalate(context.Background(), NewAcks(""), policy, Message{Subject: "Disk full"})
+	if err != nil {
+		t.Fatalf("Escalate() returned error: %v", err)
+	}
+	e.Cancel()
+	if _, err = e.Wait(); !errors.Is(err, context.Canceled) {
+		t.Errorf("Wait() returned %v, want context.Canceled", err)
+	}
+	if len(sms.bodies) != 0 {
+		t.Error("Escalate() notified step after cancellation")
+	}
+
+	if _, err = New().Escalate(context.Background(), NewAcks(""), EscalationPolicy{}, Message{}); err == nil {
+		t.Error("Escalate() without steps returned no error")
+	}
+}
@@ -0,0 +1,192 @@
+package notify
+
+import (
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// HTMLToText converts an HTML document or fragment to plain text. Paragraphs, headings and line breaks become line
+// breaks, list items are prefixed with "-" or their number, links are kept as "text (URL)" and scripts and styles are
+// dropped. Malformed HTML is converted as well as possible.
+func HTMLToText(s string) string {
+	nodes, err := html.ParseFragment(strings.NewReader(s), &html.Node{Type: html.ElementNode, Data: "body", DataAtom: atom.Body})
+	if err != nil {
+		// The tokenizer never fails on a strings.Reader, but fall back to the input just in case.
+		return s
+	}
+
+	w := &textWriter{}
+	for _, node := range nodes {
+		w.node(node)
+	}
+
+	return strings.TrimSpace(w.b.String())
+}
+
+// list is the state of an ordered or unordered list being written.
+type list struct {
+	ordered bool
+	next    int
+}
+
+// textWriter accumulates the text of an HTML tree, collapsing whitespace like a browser would.
+type textWriter struct {
+	b            strings.Builder
+	pendingSpace bool
+	newlines     int // number of trailing newlines written
+	lists        []*list
+	pre          int // depth of nested pre elements
+}
+
+func (w *textWriter) write(s string) {
+	if s == "" {
+		return
+	}
+	if w.pendingSpace && w.newlines == 0 && w.b.Len() > 0 {
+		w.b.WriteByte(' ')
+	}
+	w.pendingSpace = false
+	w.b.WriteString(s)
+	w.newlines = len(s) - len(strings.TrimRight(s, "\n"))
+}
+
+func (w *textWriter) text(s string) {
+	if w.pre > 0 {
+		w.write(s)
+		return
+	}
+
+	if strings.TrimLeft(s, " \t\r\n") != s {
+		w.pendingSpace = true
+	}
+	words := strings.Fields(s)
+	for i, word := range words {
+		if i > 0 {
+			w.pendingSpace = true
+		}
+		w.write(word)
+	}
+	if len(words) > 0 && strings.TrimRight(s, " \t\r\n") != s {
+		w.pendingSpace = true
+	}
+}
+
+// lineBreak ends the current line and makes sure the text ends with at least n line breaks.
+func (w *textWriter) lineBreak(n int) {
+	w.pendingSpace = false
+	if w.b.Len() == 0 {
+		return
+	}
+	for ; w.newlines < n; w.newlines++ {
+		w.b.WriteByte('\n')
+	}
+}
+
+func (w *textWriter) children(n *html.Node) {
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		w.node(c)
+	}
+}
+
+func (w *textWriter) node(n *html.Node) {
+	switch n.Type {
+	case html.TextNode:
+		w.text(n.Data)
+		return
+	case html.ElementNode:
+	default:
+		w.children(n)
+		return
+	}
+
+	switch n.DataAtom {
+	case atom.Script, atom.Style, atom.Head, atom.Title, atom.Template:
+	case atom.Br:
+		w.pendingSpace = false
+		w.b.WriteByte('\n')
+		w.newlines++
+	case atom.Hr:
+		w.lineBreak(1)
+		w.write("---")
+		w.lineBreak(1)
+	case atom.P, atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6, atom.Blockquote, atom.Table:
+		w.lineBreak(2)
+		w.children(n)
+		w.lineBreak(2)
+	case atom.Pre:
+		w.lineBreak(2)
+		w.pre++
+		w.children(n)
+		w.pre--
+		w.lineBreak(2)
+	case atom.Div, atom.Section, atom.Article, atom.Header, atom.Footer, atom.Tr, atom.Dt, atom.Dd:
+		w.lineBreak(1)
+		w.children(n)
+		w.lineBreak(1)
+	case atom.Td, atom.Th:
+		w.pendingSpace = true
+		w.children(n)
+		w.pendingSpace = true
+	case atom.Ul, atom.Ol:
+		w.lineBreak(1)
+		w.lists = append(w.lists, &list{ordered: n.DataAtom == atom.Ol, next: 1})
+		w.children(n)
+		w.lists = w.lists[:len(w.lists)-1]
+		w.lineBreak(1)
+	case atom.Li:
+		w.listItem(n)
+	case atom.A:
+		w.link(n)
+	case atom.Img:
+		w.text(attr(n, "alt"))
+	default:
+		w.children(n)
+	}
+}
+
+func (w *textWriter) listItem(n *html.Node) {
+	w.lineBreak(1)
+
+	marker := "-"
+	depth := len(w.lists)
+	if depth > 0 {
+		l := w.lists[depth-1]
+		if l.ordered {
+			marker = strconv.Itoa(l.next) + "."
+			l.next++
+		}
+		w.write(strings.Repeat("  ", depth-1))
+	}
+	w.write(marker + " ")
+	w.children(n)
+	w.lineBreak(1)
+}
+
+func (w *textWriter) link(n *html.Node) {
+	start := w.b.Len()
+	w.children(n)
+	label := strings.TrimSpace(w.b.String()[start:])
+
+	href := strings.TrimSpace(attr(n, "href"))
+	switch {
+	case href == "" || strings.HasPrefix(href, "#") || strings.HasPrefix(strings.ToLower(href), "javascript:"):
+	case label == "":
+		w.write(href)
+	case label != href && strings.TrimPrefix(href, "mailto:") != label:
+		w.pendingSpace = true
+		w.write("(" + href + ")")
+	}
+}
+
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+
+	return ""
+}
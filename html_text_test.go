@@ -0,0 +1,57 @@
+package notify
+
+import "testing"
+
+func TestHTMLToText(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		html string
+		want string
+	}{
+		{
+			name: "plain text",
+			html: "Disk   almost\n full",
+			want: "Disk almost full",
+		},
+		{
+			name: "paragraphs and line breaks",
+			html: "<h1>Disk full</h1><p>Host <b>db-1</b> is at 95%.<br>Act now.</p><p>Thanks</p>",
+			want: "Disk full\n\nHost db-1 is at 95%.\nAct now.\n\nThanks",
+		},
+		{
+			name: "links",
+			html: `See <a href="https://example.com/runbook">the runbook</a> or <a href="https://example.com">https://example.com</a>` +
+				` or <a href="mailto:ops@example.com">ops@example.com</a><a href="https://example.com/x"></a>.`,
+			want: "See the runbook (https://example.com/runbook) or https://example.com or ops@example.com" +
+				"https://example.com/x.",
+		},
+		{
+			name: "lists",
+			html: "<p>Steps:</p><ol><li>Check disk</li><li>Clean up<ul><li>logs</li><li>tmp</li></ul></li></ol>Done",
+			want: "Steps:\n\n1. Check disk\n2. Clean up\n  - logs\n  - tmp\nDone",
+		},
+		{
+			name: "scripts, styles and entities",
+			html: "<style>p { color: red }</style><script>alert(1)</script><p>5 &lt; 6 &amp;&amp; 7 &gt; 6</p>",
+			want: "5 < 6 && 7 > 6",
+		},
+		{
+			name: "preformatted text",
+			html: "<pre>  indented\n    code</pre>",
+			want: "indented\n    code",
+		},
+		{
+			name: "malformed html",
+			html: "<p>unclosed <b>bold <i>text",
+			want: "unclosed bold text",
+		},
+	}
+
+	for _, tt := range tests {
+		if got := HTMLToText(tt.html); got != tt.want {
+			t.Errorf("%s: HTMLToText() returned %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"text/template"
+
+	"github.com/pkg/errors"
+)
+
+// JSONSender is implemented by services that can deliver structured payloads natively, e.g. webhooks and message
+// queues. Each service serializes the payload in the format its receivers expect.
+type JSONSender interface {
+	SendJSON(ctx context.Context, v any) error
+}
+
+// JSONRenderer turns a structured payload into subject and message for services that only deliver text.
+type JSONRenderer func(v any) (subject, message string, err error)
+
+// WithJSONRenderer returns an Option that sets how SendJSON renders payloads for services that don't implement
+// JSONSender. By default, the subject is empty and the message is the indented JSON encoding of the payload.
+func WithJSONRenderer(renderer JSONRenderer) Option {
+	return func(n *Notify) {
+		if n != nil {
+			n.jsonRenderer = renderer
+		}
+	}
+}
+
+// renderJSON is the default JSONRenderer.
+func renderJSON(v any) (string, string, error) {
+	b := getBuffer()
+	defer putBuffer(b)
+
+	encoder := json.NewEncoder(b)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(v); err != nil {
+		return "", "", errors.Wrap(err, "marshal payload")
+	}
+
+	return "", string(bytes.TrimSuffix(b.Bytes(), []byte("\n"))), nil
+}
+
+// JSONTemplate returns a JSONRenderer that executes the given text/template templates to render subject and message.
+// The templates have access to TemplateFuncs. They are executed against the JSON representation of the payload, so
+// fields are referenced by their JSON names, e.g. {{ .severity }}, no matter whether the payload is a struct, a map or
+// json.RawMessage.
+func JSONTemplate(subject, message string) (JSONRenderer, error) {
+	subjectTmpl, err := template.New("subject").Funcs(TemplateFuncs()).Parse(subject)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse subject template")
+	}
+	messageTmpl, err := template.New("message").Funcs(TemplateFuncs()).Parse(message)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse message template")
+	}
+
+	return func(v any) (string, string, error) {
+		data, err := json.Marshal(v)
+		if err != nil {
+			return "", "", errors.Wrap(err, "marshal payload")
+		}
+		var fields any
+		if err = json.Unmarshal(data, &fields); err != nil {
+			return "", "", errors.Wrap(err, "unmarshal payload")
+		}
+
+		b := getBuffer()
+		defer putBuffer(b)
+
+		if err = subjectTmpl.Execute(b, fields); err != nil {
+			return "", "", errors.Wrap(err, "render subject")
+		}
+		subject := b.String()
+		b.Reset()
+		if err = messageTmpl.Execute(b, fields); err != nil {
+			return "", "", errors.Wrap(err, "render message")
+		}
+
+		return subject, b.String(), nil
+	}, nil
+}
+
+// sendJSON delivers the given payload natively to all services that implement JSONSender and rendered as text to all
+// others.
+func (n *Notify) sendJSON(ctx context.Context, v any) error {
+	if n.Disabled {
+		return nil
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	var rendered *Message
+	for _, service := range n.notifiers {
+		if _, ok := service.(JSONSender); ok || service == nil {
+			continue
+		}
+
+		renderer := n.jsonRenderer
+		if renderer == nil {
+			renderer = renderJSON
+		}
+		subject, message, err := renderer(v)
+		if err != nil {
+			return newSendError(errors.Wrap(err, "render payload"))
+		}
+		rendered = &Message{Subject: subject, Body: message}
+
+		break
+	}
+
+	var hash string
+	if n.audit != nil {
+		payload, _ := json.Marshal(v)
+		hash = hashMessage(string(payload))
+	}
+
+	return n.dispatch(ctx, hash, func(ctx context.Context, service Notifier) error {
+		if sender, ok := service.(JSONSender); ok {
+			return sender.SendJSON(ctx, v)
+		}
+
+		return n.deliver(ctx, service, *rendered, *rendered)
+	})
+}
+
+// SendJSON calls the underlying notification services to deliver the given structured payload. Services that implement
+// JSONSender deliver it natively, all others receive it rendered as text, see WithJSONRenderer.
+func (n *Notify) SendJSON(ctx context.Context, v any) error {
+	return n.sendJSON(ctx, v)
+}
+
+// SendJSON calls the underlying notification services to deliver the given structured payload. Services that implement
+// JSONSender deliver it natively, all others receive it rendered as text, see WithJSONRenderer.
+func SendJSON(ctx context.Context, v any) error {
+	return std.SendJSON(ctx, v)
+}
@@ -0,0 +1,118 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// jsonService records the payloads it receives through SendJSON.
+type jsonService struct {
+	recordingService
+	payloads []any
+}
+
+func (s *jsonService) SendJSON(_ context.Context, v any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.payloads = append(s.payloads, v)
+
+	return nil
+}
+
+type deployEvent struct {
+	Service string `json:"service"`
+	Version string `json:"version"`
+}
+
+func TestJSONTemplate(t *testing.T) {
+	t.Parallel()
+
+	renderer, err := JSONTemplate("Deployed {{ .service }}", "Version {{ .version }} is live.")
+	if err != nil {
+		t.Fatalf("JSONTemplate() returned error: %v", err)
+	}
+
+	for _, payload := range []any{
+		deployEvent{Service: "api", Version: "1.2.3"},
+		map[string]string{"service": "api", "version": "1.2.3"},
+		json.RawMessage(`{"service":"api","version":"1.2.3"}`),
+	} {
+		subject, message, err := renderer(payload)
+		if err != nil {
+			t.Fatalf("renderer(%T) returned error: %v", payload, err)
+		}
+		if subject != "Deployed api" || message != "Version 1.2.3 is live." {
+			t.Errorf("renderer(%T) returned %q, %q", payload, subject, message)
+		}
+	}
+
+	if _, err = JSONTemplate("{{ .service", ""); err == nil {
+		t.Error("JSONTemplate() with invalid subject template returned no error")
+	}
+}
+
+func TestJSONTemplate_Funcs(t *testing.T) {
+	t.Parallel()
+
+	renderer, err := JSONTemplate(
+		"Backup of {{ .database }} done",
+		"Wrote {{ humanizeBytes .size }} in {{ humanizeDuration .seconds }}.",
+	)
+	if err != nil {
+		t.Fatalf("JSONTemplate() returned error: %v", err)
+	}
+
+	// Numbers decoded from JSON are float64.
+	_, message, err := renderer(map[string]any{"database": "orders", "size": 3 << 20, "seconds": 245})
+	if err != nil {
+		t.Fatalf("renderer() returned error: %v", err)
+	}
+	if want := "Wrote 3.0 MiB in 4m 5s."; message != want {
+		t.Errorf("renderer() returned message %q, want %q", message, want)
+	}
+}
+
+func TestNotifySendJSON(t *testing.T) {
+	t.Parallel()
+
+	native := &jsonService{}
+	plain := &recordingService{}
+	n := NewWithServices(native, plain)
+
+	event := deployEvent{Service: "api", Version: "1.2.3"}
+	if err := n.SendJSON(context.Background(), event); err != nil {
+		t.Fatalf("SendJSON() returned error: %v", err)
+	}
+	if diff := cmp.Diff([]any{event}, native.payloads); diff != "" {
+		t.Errorf("SendJSON() delivered unexpected payloads:\n%s", diff)
+	}
+	want := "{\n  \"service\": \"api\",\n  \"version\": \"1.2.3\"\n}"
+	if diff := cmp.Diff([]string{want}, plain.bodies); diff != "" {
+		t.Errorf("SendJSON() rendered unexpected bodies:\n%s", diff)
+	}
+
+	renderer, _ := JSONTemplate("Deployed {{ .service }}", "Version {{ .version }}")
+	plain.bodies = nil
+	n.WithOptions(WithJSONRenderer(renderer))
+	if err := n.SendJSON(context.Background(), event); err != nil {
+		t.Fatalf("SendJSON() returned error: %v", err)
+	}
+	if diff := cmp.Diff([]string{"Version 1.2.3"}, plain.bodies); diff != "" {
+		t.Errorf("SendJSON() rendered unexpected bodies:\n%s", diff)
+	}
+
+	// Payloads that can't be rendered fail the send.
+	if err := n.SendJSON(context.Background(), make(chan int)); err == nil {
+		t.Error("SendJSON() with unserializable payload returned no error")
+	}
+
+	// After disabling the Notifier, SendJSON() should return silently.
+	n.WithOptions(Disable)
+	if err := n.SendJSON(context.Background(), event); err != nil {
+		t.Errorf("SendJSON() of disabled Notifier returned error: %v", err)
+	}
+}
@@ -0,0 +1,185 @@
+package notify
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// Limits are the maximum lengths, in characters, of messages a service accepts. Zero means no limit.
+type Limits struct {
+	// Subject is the maximum length of the subject.
+	Subject int
+	// Body is the maximum length of the body.
+	Body int
+	// Total is the maximum combined length of subject and body, for services that join both with a separator into a
+	// single text.
+	Total int
+}
+
+// Limiter is implemented by services that declare the limits of the provider they send to. The dispatcher fits
+// messages into these limits before delivery, see OverflowPolicy.
+type Limiter interface {
+	Limits() Limits
+}
+
+// OverflowPolicy determines how the dispatcher fits a body that exceeds the limits of a service. Subjects that are too
+// long are always truncated.
+type OverflowPolicy int
+
+const (
+	// OverflowTruncate cuts the body off and marks the cut with an ellipsis. This is the default.
+	OverflowTruncate OverflowPolicy = iota
+	// OverflowSplit sends the body in several messages that each fit the limits.
+	OverflowSplit
+	// OverflowAttach truncates the body and attaches the full body as text file. Services that can't receive
+	// attachments, i.e. that don't implement AttachmentSender, get the body split like with OverflowSplit instead, so
+	// that no text is lost.
+	OverflowAttach
+)
+
+// ellipsis marks truncated text.
+const ellipsis = "…"
+
+// WithOverflowPolicy returns an Option that sets how bodies exceeding the limits of a service are handled.
+func WithOverflowPolicy(policy OverflowPolicy) Option {
+	return func(n *Notify) {
+		if n != nil {
+			n.overflow = policy
+		}
+	}
+}
+
+// truncate shortens s to at most limit characters, marking the cut with an ellipsis.
+func truncate(s string, limit int) string {
+	if limit <= 0 {
+		return ""
+	}
+	if utf8.RuneCountInString(s) <= limit {
+		return s
+	}
+
+	// Find the cut without converting s to runes, which would copy a possibly long body.
+	count := 0
+	for i := range s {
+		if count == limit-1 {
+			return s[:i] + ellipsis
+		}
+		count++
+	}
+
+	return s
+}
+
+// split cuts s into chunks of at most limit characters, preferring to cut at line breaks and spaces.
+func split(s string, limit int) []string {
+	runes := []rune(s)
+	if limit <= 0 || len(runes) <= limit {
+		return []string{s}
+	}
+
+	chunks := make([]string, 0, len(runes)/limit+1)
+	for len(runes) > limit {
+		cut := limit
+		if i := lastIndexOf(runes[:limit], '\n'); i > limit/2 {
+			cut = i + 1
+		} else if i = lastIndexOf(runes[:limit], ' '); i > limit/2 {
+			cut = i + 1
+		}
+
+		chunks = append(chunks, strings.TrimRight(string(runes[:cut]), "\n "))
+		runes = runes[cut:]
+		for len(runes) > 0 && (runes[0] == '\n' || runes[0] == ' ') {
+			runes = runes[1:]
+		}
+	}
+
+	return append(chunks, string(runes))
+}
+
+func lastIndexOf(runes []rune, r rune) int {
+	for i := len(runes) - 1; i >= 0; i-- {
+		if runes[i] == r {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// fit appends the messages to deliver so that the given message fits the limits of the service to dst and returns the
+// extended slice. The limits apply to the text the service sends: the body with the card and the actions appended, see
+// Message.PlainText, or the HTML. HTML can't be cut safely, so HTML that doesn't fit is dropped and the service sends
+// the body instead.
+func (n *Notify) fit(service Notifier, message Message, dst []Message) []Message {
+	limiter, ok := service.(Limiter)
+	if !ok {
+		return append(dst, message)
+	}
+	limits := limiter.Limits()
+
+	if limits.Subject > 0 {
+		message.Subject = truncate(message.Subject, limits.Subject)
+	}
+
+	bodyLimit := limits.Body
+	if limits.Total > 0 {
+		// Reserve room for the subject and the separator the service joins subject and body with.
+		remaining := limits.Total - utf8.RuneCountInString(message.Subject) - 1
+		if bodyLimit == 0 || remaining < bodyLimit {
+			bodyLimit = remaining
+		}
+	}
+	if limits.Body == 0 && limits.Total == 0 {
+		return append(dst, message)
+	}
+
+	if utf8.RuneCountInString(message.HTML) > bodyLimit {
+		message.HTML = ""
+	}
+	body := utf8.RuneCountInString(message.Body)
+	extra := utf8.RuneCountInString(message.PlainText()) - body
+	if body+extra <= bodyLimit {
+		return append(dst, message)
+	}
+
+	// Keep room for the card and the actions. If they don't fit on their own, they are sent as part of the body.
+	if extra >= bodyLimit {
+		message.Body = message.PlainText()
+		message.Card, message.Actions = nil, nil
+		extra = 0
+	}
+
+	policy := n.overflow
+	if policy == OverflowAttach && !supportsAttachments(service) {
+		policy = OverflowSplit
+	}
+
+	switch {
+	case policy == OverflowSplit && bodyLimit > 0:
+		chunks := split(message.Body, bodyLimit)
+		if last := chunks[len(chunks)-1]; utf8.RuneCountInString(last)+extra > bodyLimit {
+			chunks = append(chunks[:len(chunks)-1], split(last, bodyLimit-extra)...)
+		}
+		for i, chunk := range chunks {
+			part := Message{Subject: message.Subject, Body: chunk}
+			if i == len(chunks)-1 {
+				part.Actions = message.Actions
+				part.Attachments = message.Attachments
+				part.Card = message.Card
+			}
+			dst = append(dst, part)
+		}
+
+		return dst
+	case policy == OverflowAttach:
+		full := Attachment{Name: "message.txt", ContentType: "text/plain; charset=utf-8", Data: []byte(message.Body)}
+		message.Attachments = append([]Attachment{full}, message.Attachments...)
+		message.Body = truncate(message.Body, bodyLimit-extra)
+
+		return append(dst, message)
+	default:
+		message.Body = truncate(message.Body, bodyLimit-extra)
+
+		return append(dst, message)
+	}
+}
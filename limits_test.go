@@ -0,0 +1,182 @@
+package notify
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// limitedService records the bodies it receives through Send and declares the given limits.
+type limitedService struct {
+	recordingService
+	limits Limits
+}
+
+func (s *limitedService) Limits() Limits {
+	return s.limits
+}
+
+// nativeLimitedService additionally records the messages it receives through SendMessage.
+type nativeLimitedService struct {
+	nativeService
+	limits Limits
+}
+
+func (s *nativeLimitedService) Limits() Limits {
+	return s.limits
+}
+
+// attachingLimitedService additionally delivers attachments.
+type attachingLimitedService struct {
+	nativeLimitedService
+}
+
+func (s *attachingLimitedService) SupportsAttachments() bool {
+	return true
+}
+
+func TestTruncate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		s     string
+		limit int
+		want  string
+	}{
+		{"hello", 10, "hello"},
+		{"hello", 5, "hello"},
+		{"hello world", 5, "hell…"},
+		{"héllö wörld", 5, "héll…"},
+		{"hello", 0, ""},
+	}
+
+	for _, tt := range tests {
+		if got := truncate(tt.s, tt.limit); got != tt.want {
+			t.Errorf("truncate(%q, %d) returned %q, want %q", tt.s, tt.limit, got, tt.want)
+		}
+	}
+}
+
+func TestSplit(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		s     string
+		limit int
+		want  []string
+	}{
+		{"short", 10, []string{"short"}},
+		{"one two three four", 10, []string{"one two", "three four"}},
+		{"line one\nline two", 12, []string{"line one", "line two"}},
+		{"abcdefghij", 4, []string{"abcd", "efgh", "ij"}},
+	}
+
+	for _, tt := range tests {
+		if diff := cmp.Diff(tt.want, split(tt.s, tt.limit)); diff != "" {
+			t.Errorf("split(%q, %d) returned unexpected chunks:\n%s", tt.s, tt.limit, diff)
+		}
+	}
+}
+
+func TestNotifyFit(t *testing.T) {
+	t.Parallel()
+
+	body := strings.Repeat("word ", 10) // 50 characters
+
+	// Services without limits receive the message unchanged.
+	n := New()
+	msg := Message{Subject: "subject", Body: body}
+	if diff := cmp.Diff([]Message{msg}, n.fit(&recordingService{}, msg, nil)); diff != "" {
+		t.Errorf("fit() changed message for service without limits:\n%s", diff)
+	}
+
+	// Subjects are always truncated, the total limit leaves room for subject and separator.
+	service := &limitedService{limits: Limits{Subject: 5, Total: 26}}
+	got := n.fit(service, msg, nil)
+	want := []Message{{Subject: "subj…", Body: "word word word word…"}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("fit() with truncate policy returned unexpected messages:\n%s", diff)
+	}
+
+	// The limits apply to the body with the actions appended, as services send it.
+	msg.Actions = []Action{{Label: "Open", URL: "https://x.io"}} // "\n\nOpen: https://x.io", 19 characters
+	got = n.fit(&limitedService{limits: Limits{Body: 30}}, msg, nil)
+	want = []Message{{Subject: "subject", Body: "word word…", Actions: msg.Actions}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("fit() with actions returned unexpected messages:\n%s", diff)
+	}
+
+	// Actions that don't fit on their own are sent as part of the body.
+	got = n.fit(&limitedService{limits: Limits{Body: 15}}, msg, nil)
+	want = []Message{{Subject: "subject", Body: "word word word…"}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("fit() with too long actions returned unexpected messages:\n%s", diff)
+	}
+
+	// HTML that doesn't fit is dropped, so that the service sends the body instead.
+	html := Message{Subject: "subject", Body: "word", HTML: "<p><b>word</b></p>"}
+	got = n.fit(&limitedService{limits: Limits{Body: 10}}, html, nil)
+	want = []Message{{Subject: "subject", Body: "word"}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("fit() with too long HTML returned unexpected messages:\n%s", diff)
+	}
+	got = n.fit(&limitedService{limits: Limits{Body: 20}}, html, nil)
+	if diff := cmp.Diff([]Message{html}, got); diff != "" {
+		t.Errorf("fit() changed HTML that fits:\n%s", diff)
+	}
+
+	// Splitting keeps actions and attachments on the last part, which is split again if they don't fit.
+	n = NewWithOptions(WithOverflowPolicy(OverflowSplit))
+	got = n.fit(&limitedService{limits: Limits{Body: 30}}, msg, nil)
+	want = []Message{
+		{Subject: "subject", Body: "word word word word word word"},
+		{Subject: "subject", Body: "word word"},
+		{Subject: "subject", Body: "word word ", Actions: msg.Actions},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("fit() with split policy returned unexpected messages:\n%s", diff)
+	}
+	for _, part := range got {
+		if length := len([]rune(part.PlainText())); length > 30 {
+			t.Errorf("fit() with split policy returned part of %d characters", length)
+		}
+	}
+
+	// Attaching only applies to services that can receive attachments.
+	n = NewWithOptions(WithOverflowPolicy(OverflowAttach))
+	msg.Actions = nil
+	got = n.fit(&attachingLimitedService{nativeLimitedService{limits: Limits{Body: 10}}}, msg, nil)
+	want = []Message{{
+		Subject:     "subject",
+		Body:        "word word…",
+		Attachments: []Attachment{{Name: "message.txt", ContentType: "text/plain; charset=utf-8", Data: []byte(body)}},
+	}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("fit() with attach policy returned unexpected messages:\n%s", diff)
+	}
+
+	// Other services get the body split, so that no text is lost.
+	plain, native := &limitedService{limits: Limits{Body: 10}}, &nativeLimitedService{limits: Limits{Body: 10}}
+	for _, service := range []Notifier{plain, native} {
+		got = n.fit(service, msg, nil)
+		if len(got) < 2 || got[0].Body != "word word" || len(got[0].Attachments) != 0 {
+			t.Errorf("fit() with attach policy for %T returned unexpected messages: %+v", service, got)
+		}
+	}
+}
+
+func TestNotifySendFitsLimits(t *testing.T) {
+	t.Parallel()
+
+	service := &limitedService{limits: Limits{Body: 10}}
+	n := NewWithServices(service).WithOptions(WithOverflowPolicy(OverflowSplit))
+
+	if err := n.Send(context.Background(), "subject", "first part\nsecond part"); err != nil {
+		t.Fatalf("Send() returned error: %v", err)
+	}
+	if diff := cmp.Diff([]string{"first part", "second", "part"}, service.bodies); diff != "" {
+		t.Errorf("Send() delivered unexpected bodies:\n%s", diff)
+	}
+}
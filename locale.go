@@ -0,0 +1,193 @@
+package notify
+
+import (
+	"context"
+	"text/template"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/message/catalog"
+)
+
+// Catalog holds the translations of strings used in templates, see Templates.SetCatalog. Translations are looked up by
+// locale, falling back to the parent locale, e.g. from "de-AT" to "de", and to the key itself if there is none. It is
+// safe for concurrent use.
+type Catalog struct {
+	builder *catalog.Builder
+}
+
+// NewCatalog returns an empty catalog.
+func NewCatalog() *Catalog {
+	return &Catalog{builder: catalog.NewBuilder()}
+}
+
+// Set sets the translation of the given key for a locale, e.g. "de-DE". Translations are fmt format strings, e.g.
+// "%d Dateien gelöscht", and receive the arguments passed to the translate template function.
+func (c *Catalog) Set(locale, key, translation string) error {
+	tag, err := language.Parse(locale)
+	if err != nil {
+		return errors.Wrapf(err, "parse locale %q", locale)
+	}
+
+	return errors.Wrapf(c.builder.SetString(tag, key, translation), "set translation of %q", key)
+}
+
+// printer returns a printer that formats and translates for the given locale.
+func (c *Catalog) printer(locale string) *message.Printer {
+	tag, err := language.Parse(locale)
+	if err != nil {
+		tag = language.Und
+	}
+	if c == nil {
+		return message.NewPrinter(tag, message.Catalog(catalog.NewBuilder()))
+	}
+
+	return message.NewPrinter(tag, message.Catalog(c.builder))
+}
+
+// localizedFuncs returns the template functions that depend on the locale and time zone of the receiver:
+//
+//	translate  translates a key of the catalog with arguments: {{ translate "%d files deleted" .Count }}
+//	number     formats a number with the separators of the locale: {{ .Bytes | number }}
+//	localTime  formats a time.Time in the time zone of the receiver: {{ .Time | localTime "02.01.2006 15:04" }}
+func localizedFuncs(printer *message.Printer, location *time.Location) template.FuncMap {
+	return template.FuncMap{
+		"translate": func(key string, args ...any) string {
+			return printer.Sprintf(key, args...)
+		},
+		"number": func(v any) string {
+			return printer.Sprint(v)
+		},
+		"localTime": func(layout string, t time.Time) string {
+			return t.In(location).Format(layout)
+		},
+	}
+}
+
+// parseFuncs returns the functions templates are parsed with: TemplateFuncs and the localized functions for an unknown
+// locale and UTC, which are replaced when rendering.
+func parseFuncs() template.FuncMap {
+	funcs := TemplateFuncs()
+	for name, fn := range localizedFuncs(message.NewPrinter(language.Und), time.UTC) {
+		funcs[name] = fn
+	}
+
+	return funcs
+}
+
+// SetCatalog sets the catalog the translate template function looks translations up in.
+func (t *Templates) SetCatalog(c *Catalog) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.catalog = c
+}
+
+// RenderLocalized works like Render, but renders for a receiver with the given locale, e.g. "de-DE", and time zone:
+// the translate, number and localTime template functions translate and format for them. A nil location means UTC.
+// Layouts of localTime aren't translated, but can be translated like other strings: {{ localTime (translate "layout")
+// .Time }}.
+func (t *Templates) RenderLocalized(name string, data any, locale string, location *time.Location) (
+	Message,
+	TemplateRef,
+	error,
+) {
+	if location == nil {
+		location = time.UTC
+	}
+
+	t.mu.RLock()
+	printer := t.catalog.printer(locale)
+	t.mu.RUnlock()
+
+	return t.render(name, data, localizedFuncs(printer, location))
+}
+
+// contactGroup are contacts that share locale and time zone, so that a message rendered for one fits all.
+type contactGroup struct {
+	locale   string
+	location *time.Location
+	contacts []Contact
+
+	message Message
+	ref     TemplateRef
+}
+
+// groupContacts groups the contacts by locale and time zone, in order of their first appearance.
+func groupContacts(contacts []Contact) []*contactGroup {
+	var groups []*contactGroup
+	index := make(map[string]*contactGroup)
+	for _, contact := range contacts {
+		location := contact.TimeZone
+		if location == nil {
+			location = time.UTC
+		}
+
+		key := contact.Locale + "\x00" + location.String()
+		group, ok := index[key]
+		if !ok {
+			group = &contactGroup{locale: contact.Locale, location: location}
+			index[key] = group
+			groups = append(groups, group)
+		}
+		group.contacts = append(group.contacts, contact)
+	}
+
+	return groups
+}
+
+// sendTemplateToContacts renders the template once per locale and time zone of the contacts and sends the results.
+func (n *Notify) sendTemplateToContacts(ctx context.Context, name string, data any, contacts ...Contact) error {
+	if n.Disabled {
+		return nil
+	}
+	if n.templates == nil {
+		return errors.Wrapf(ErrUnknownTemplate, "template %q", name)
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	// Render for all groups before sending, so that a template failing for one locale doesn't notify only some contacts.
+	groups := groupContacts(contacts)
+	for _, group := range groups {
+		var err error
+		group.message, group.ref, err = n.templates.RenderLocalized(name, data, group.locale, group.location)
+		if err != nil {
+			return newSendError(err)
+		}
+	}
+
+	var eg errgroup.Group
+	for _, group := range groups {
+		group := group
+		groupCtx := context.WithValue(ctx, templateKey{}, group.ref)
+		for _, contact := range group.contacts {
+			contact := contact
+			eg.Go(func() error {
+				return n.sendToContact(groupCtx, group.message, contact)
+			})
+		}
+	}
+
+	return eg.Wait()
+}
+
+// SendTemplateToContacts renders the template with the given name with data, see WithTemplates, in the locale and
+// time zone of every contact, see Templates.RenderLocalized, and sends the results like SendToContacts. The template
+// is rendered once per distinct locale and time zone, so contacts of the same locale and time zone receive the same
+// variant. The rendered version is recorded in the audit log.
+func (n *Notify) SendTemplateToContacts(ctx context.Context, name string, data any, contacts ...Contact) error {
+	return n.sendTemplateToContacts(ctx, name, data, contacts...)
+}
+
+// SendTemplateToContacts renders the template with the given name with data, see WithTemplates, in the locale and
+// time zone of every contact, see Templates.RenderLocalized, and sends the results like SendToContacts. The template
+// is rendered once per distinct locale and time zone, so contacts of the same locale and time zone receive the same
+// variant. The rendered version is recorded in the audit log.
+func SendTemplateToContacts(ctx context.Context, name string, data any, contacts ...Contact) error {
+	return std.SendTemplateToContacts(ctx, name, data, contacts...)
+}
@@ -0,0 +1,129 @@
+package notify
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+)
+
+func TestTemplates_RenderLocalized(t *testing.T) {
+	t.Parallel()
+
+	catalog := NewCatalog()
+	if err := catalog.Set("de", "%d files deleted", "%d Dateien gelöscht"); err != nil {
+		t.Fatalf("Set() returned error: %v", err)
+	}
+	if err := catalog.Set("not a locale", "key", "value"); err == nil {
+		t.Error("Set() with invalid locale returned no error")
+	}
+
+	templates := NewTemplates()
+	templates.SetCatalog(catalog)
+	err := templates.Add("cleanup", "v1",
+		`{{ translate "%d files deleted" .Count }}`,
+		`{{ .Bytes | number }} bytes at {{ .Time | localTime "15:04" }}`)
+	if err != nil {
+		t.Fatalf("Add() returned error: %v", err)
+	}
+
+	data := map[string]any{
+		"Count": 3,
+		"Bytes": 1234567,
+		"Time":  time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC),
+	}
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("time zone database unavailable: %v", err)
+	}
+
+	tests := []struct {
+		locale   string
+		location *time.Location
+		want     Message
+	}{
+		{"", nil, Message{Subject: "3 files deleted", Body: "1,234,567 bytes at 09:00"}},
+		{"en-US", nil, Message{Subject: "3 files deleted", Body: "1,234,567 bytes at 09:00"}},
+		{"de-DE", berlin, Message{Subject: "3 Dateien gelöscht", Body: "1.234.567 bytes at 10:00"}},
+	}
+	for _, tt := range tests {
+		message, _, err := templates.RenderLocalized("cleanup", data, tt.locale, tt.location)
+		if err != nil {
+			t.Fatalf("RenderLocalized(%q) returned error: %v", tt.locale, err)
+		}
+		if diff := cmp.Diff(tt.want, message); diff != "" {
+			t.Errorf("RenderLocalized(%q) returned unexpected message:\n%s", tt.locale, diff)
+		}
+	}
+}
+
+func TestNotifySendTemplateToContacts(t *testing.T) {
+	t.Parallel()
+
+	catalog := NewCatalog()
+	_ = catalog.Set("de", "Disk full", "Festplatte voll")
+	templates := NewTemplates()
+	templates.SetCatalog(catalog)
+	_ = templates.Add("disk", "v1", `{{ translate "Disk full" }}`, "")
+
+	var rendered []string
+	templates.random = func(int) int { return 0 }
+	chat := &contactService{name: "chat"}
+	n := NewWithServices(&subjectRecorder{contactService: chat, subjects: &rendered})
+	n.WithOptions(WithTemplates(templates))
+
+	contacts := []Contact{
+		{Name: "Alice", ChatIDs: map[string]string{"chat": "alice"}, Locale: "de-DE"},
+		{Name: "Bob", ChatIDs: map[string]string{"chat": "bob"}},
+		{Name: "Carol", ChatIDs: map[string]string{"chat": "carol"}, Locale: "de-DE"},
+	}
+	if err := n.SendTemplateToContacts(context.Background(), "disk", nil, contacts...); err != nil {
+		t.Fatalf("SendTemplateToContacts() returned error: %v", err)
+	}
+
+	sort.Strings(rendered)
+	want := []string{"alice/de-DE: Festplatte voll", "bob/: Disk full", "carol/de-DE: Festplatte voll"}
+	if diff := cmp.Diff(want, rendered); diff != "" {
+		t.Errorf("SendTemplateToContacts() sent unexpected messages:\n%s", diff)
+	}
+
+	// Nothing is sent if the template fails for any of the locales.
+	rendered = nil
+	_ = templates.Add("broken", "v1", `{{ call .Check (translate "Disk full") }}`, "")
+	data := struct{ Check func(string) (string, error) }{
+		Check: func(text string) (string, error) {
+			if text == "Disk full" {
+				return "", errors.New("no translation")
+			}
+			return text, nil
+		},
+	}
+	if err := n.SendTemplateToContacts(context.Background(), "broken", data, contacts...); err == nil {
+		t.Error("SendTemplateToContacts() returned no error for failing template")
+	}
+	if len(rendered) != 0 {
+		t.Errorf("SendTemplateToContacts() sent %v for failing template", rendered)
+	}
+}
+
+// subjectRecorder records receiver, locale and subject of every message.
+type subjectRecorder struct {
+	*contactService
+	subjects *[]string
+}
+
+func (s *subjectRecorder) Send(ctx context.Context, subject, _ string) error {
+	locale, _ := LocaleFromContext(ctx)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, receiver := range ReceiversFromContext(ctx, nil) {
+		*s.subjects = append(*s.subjects, receiver+"/"+locale+": "+subject)
+	}
+
+	return nil
+}
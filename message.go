@@ -0,0 +1,236 @@
+package notify
+
+import (
+	"context"
+	"sync"
+)
+
+// Action is a link offered to the receiver of a message, e.g. to acknowledge an alert or to open a dashboard.
+type Action struct {
+	Label string
+	URL   string
+}
+
+// Message is a notification that carries structured content in addition to subject and body. Services that implement
+// MessageSender render it natively, all other services receive it flattened to plain text, see Message.PlainText.
+type Message struct {
+	Subject string
+	Body    string
+	// HTML is an optional HTML version of the body. Services that support HTML send it, all others receive Body. If Body
+	// is empty, it is derived from HTML, see HTMLToText.
+	HTML string
+	// Actions are rendered as buttons by services that support them and as a list of links otherwise.
+	Actions []Action
+	// Attachments are uploaded by services that support them, see AttachmentSender, and dropped by all others.
+	Attachments []Attachment
+	// Card is an optional card shown below the body, see Card.
+	Card *Card
+}
+
+// PlainText returns the body of the message with its card and its actions appended as a list of links.
+func (m Message) PlainText() string {
+	if len(m.Actions) == 0 && m.Card == nil {
+		return m.Body
+	}
+
+	b := getBuffer()
+	defer putBuffer(b)
+
+	b.WriteString(m.Body)
+	if m.Card != nil {
+		if b.Len() > 0 {
+			b.WriteString("\n\n")
+		}
+		m.Card.writePlainText(b)
+	}
+	if len(m.Actions) > 0 {
+		b.WriteString("\n")
+	}
+	for _, action := range m.Actions {
+		b.WriteString("\n")
+		b.WriteString(action.Label)
+		b.WriteString(": ")
+		b.WriteString(action.URL)
+	}
+
+	return b.String()
+}
+
+// MessageSender is implemented by services that can render a Message natively.
+//
+//	E.g. for slack.Slack it renders the actions of a message as buttons.
+type MessageSender interface {
+	SendMessage(ctx context.Context, message Message) error
+}
+
+// AttachmentSender is implemented by message senders that can deliver the attachments of messages, see
+// OverflowAttach. SupportsAttachments may return false, e.g. for services whose current configuration can't upload
+// files.
+//
+// It is implemented by the discord, mail, slack and telegram services. The other chat services, e.g. Matrix and
+// Mattermost, drop attachments.
+type AttachmentSender interface {
+	MessageSender
+	SupportsAttachments() bool
+}
+
+// supportsAttachments reports whether the service delivers the attachments of messages.
+func supportsAttachments(service Notifier) bool {
+	sender, ok := service.(AttachmentSender)
+
+	return ok && sender.SupportsAttachments()
+}
+
+// deliver fits the given message into the limits of the service and sends it, natively if the service implements
+// MessageSender and as the given plain text message otherwise. If sanitization is enabled, the message is sanitized
+// for the service first, see WithSanitization.
+func (n *Notify) deliver(ctx context.Context, service Notifier, message, plain Message) error {
+	sender, native := service.(MessageSender)
+	if !native {
+		message = plain
+	}
+	if n.sanitize {
+		message = sanitizeFor(service, message)
+	}
+
+	// Most messages fit the limits as they are, so the backing array usually stays on the stack.
+	var buf [1]Message
+	for _, msg := range n.fit(service, message, buf[:0]) {
+		var err error
+		if native {
+			err = sender.SendMessage(ctx, msg)
+		} else {
+			err = service.Send(ctx, msg.Subject, msg.Body)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// dispatch calls fn for every service, concurrently if there is more than one, within the concurrency limits of the
+// Notify instance. Every call is recorded in the audit store, if any, with the given message hash. All errors are
+// combined into an ErrSendNotification.
+func (n *Notify) dispatch(ctx context.Context, hash string, fn func(ctx context.Context, service Notifier) error) error {
+	services := 0
+	for _, service := range n.notifiers {
+		if service != nil {
+			services++
+		}
+	}
+
+	call := func(index int, service Notifier) error {
+		semaphores, err := n.acquire(ctx, index)
+		if err != nil {
+			return err
+		}
+		defer release(semaphores)
+
+		if n.audit != nil {
+			return n.audited(ctx, service, hash, fn)
+		}
+
+		return fn(ctx, service)
+	}
+
+	var errs []error
+	if services == 1 {
+		// Spare the goroutine for the common case of a single service.
+		for i, service := range n.notifiers {
+			if service == nil {
+				continue
+			}
+			if err := call(i, service); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	} else {
+		var mu sync.Mutex
+		var wg sync.WaitGroup
+		for i, service := range n.notifiers {
+			if service == nil {
+				continue
+			}
+
+			i, service := i, service
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				if err := call(i, service); err != nil {
+					mu.Lock()
+					errs = append(errs, err)
+					mu.Unlock()
+				}
+			}()
+		}
+		wg.Wait()
+	}
+
+	return newSendError(errs...)
+}
+
+// sendMessage sends the given message to all services, natively to those that implement MessageSender and flattened to
+// plain text to all others.
+func (n *Notify) sendMessage(ctx context.Context, message Message) error {
+	return n.sendMessageTo(ctx, message, nil)
+}
+
+// sendMessageTo works like sendMessage, but if address is not nil, it sends to the receivers address returns for each
+// service instead of the configured ones, and skips services address returns none for.
+func (n *Notify) sendMessageTo(ctx context.Context, message Message, address func(service Notifier) []string) error {
+	if n.Disabled {
+		return nil
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if action, ok := ackAction(ctx); ok {
+		// Copy the actions, the caller owns the backing array.
+		message.Actions = append(message.Actions[:len(message.Actions):len(message.Actions)], action)
+	}
+
+	// Derive the texts once instead of once per service.
+	if message.Body == "" && message.HTML != "" {
+		message.Body = HTMLToText(message.HTML)
+	}
+	var plain Message
+	for _, service := range n.notifiers {
+		if _, native := service.(MessageSender); service != nil && !native {
+			plain = Message{Subject: message.Subject, Body: message.PlainText()}
+			break
+		}
+	}
+
+	var hash string
+	if n.audit != nil {
+		hash = hashMessage(message.Subject, message.Body, message.HTML)
+	}
+
+	return n.dispatch(ctx, hash, func(ctx context.Context, service Notifier) error {
+		if address != nil {
+			receivers := address(service)
+			if len(receivers) == 0 {
+				return nil
+			}
+			ctx = ContextWithSendOptions(ctx, WithReceiversOverride(receivers...))
+		}
+
+		return n.deliver(ctx, service, message, plain)
+	})
+}
+
+// SendMessage calls the underlying notification services to send the given message to their respective endpoints.
+// Services that implement MessageSender render the message natively, all others receive Message.PlainText as body.
+func (n *Notify) SendMessage(ctx context.Context, message Message) error {
+	return n.sendMessage(ctx, message)
+}
+
+// SendMessage calls the underlying notification services to send the given message to their respective endpoints.
+// Services that implement MessageSender render the message natively, all others receive Message.PlainText as body.
+func SendMessage(ctx context.Context, message Message) error {
+	return std.SendMessage(ctx, message)
+}
@@ -0,0 +1,117 @@
+package notify
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// recordingService records the bodies it receives through Send.
+type recordingService struct {
+	mu     sync.Mutex
+	bodies []string
+}
+
+func (s *recordingService) Send(_ context.Context, _, message string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.bodies = append(s.bodies, message)
+
+	return nil
+}
+
+// nativeService additionally records the messages it receives through SendMessage.
+type nativeService struct {
+	recordingService
+	messages []Message
+}
+
+func (s *nativeService) SendMessage(_ context.Context, message Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.messages = append(s.messages, message)
+
+	return nil
+}
+
+func TestMessage_PlainText(t *testing.T) {
+	t.Parallel()
+
+	msg := Message{Subject: "subject", Body: "body"}
+	if got := msg.PlainText(); got != "body" {
+		t.Errorf("PlainText() without actions returned %q, want %q", got, "body")
+	}
+
+	msg.Actions = []Action{
+		{Label: "Acknowledge", URL: "https://example.com/ack"},
+		{Label: "Dashboard", URL: "https://example.com/dashboard"},
+	}
+	want := "body\n\nAcknowledge: https://example.com/ack\nDashboard: https://example.com/dashboard"
+	if got := msg.PlainText(); got != want {
+		t.Errorf("PlainText() returned %q, want %q", got, want)
+	}
+}
+
+func TestNotifySendMessage(t *testing.T) {
+	t.Parallel()
+
+	plain := &recordingService{}
+	native := &nativeService{}
+	n := NewWithServices(plain, native)
+
+	msg := Message{
+		Subject: "subject",
+		Body:    "body",
+		Actions: []Action{{Label: "Acknowledge", URL: "https://example.com/ack"}},
+	}
+	//nolint:staticcheck
+	if err := n.SendMessage(nil, msg); err != nil {
+		t.Fatalf("SendMessage() returned error: %v", err)
+	}
+
+	if diff := cmp.Diff([]string{"body\n\nAcknowledge: https://example.com/ack"}, plain.bodies); diff != "" {
+		t.Errorf("SendMessage() sent unexpected body to plain service:\n%s", diff)
+	}
+	if diff := cmp.Diff([]Message{msg}, native.messages); diff != "" {
+		t.Errorf("SendMessage() sent unexpected message to native service:\n%s", diff)
+	}
+	if len(native.bodies) != 0 {
+		t.Errorf("SendMessage() called Send of native service: %v", native.bodies)
+	}
+
+	n.UseServices(newFailingService())
+	if err := n.SendMessage(context.Background(), msg); err == nil {
+		t.Error("SendMessage() failing service returned no error")
+	}
+
+	// After disabling the Notifier, SendMessage() should return silently.
+	n.WithOptions(Disable)
+	if err := n.SendMessage(context.Background(), msg); err != nil {
+		t.Errorf("SendMessage() of disabled Notifier returned error: %v", err)
+	}
+}
+
+func TestNotifySendMessageHTML(t *testing.T) {
+	t.Parallel()
+
+	plain := &recordingService{}
+	native := &nativeService{}
+	n := NewWithServices(plain, native)
+
+	msg := Message{Subject: "subject", HTML: "<p>Disk <b>full</b></p>"}
+	if err := n.SendMessage(context.Background(), msg); err != nil {
+		t.Fatalf("SendMessage() returned error: %v", err)
+	}
+
+	if diff := cmp.Diff([]string{"Disk full"}, plain.bodies); diff != "" {
+		t.Errorf("SendMessage() sent unexpected body to plain service:\n%s", diff)
+	}
+	msg.Body = "Disk full"
+	if diff := cmp.Diff([]Message{msg}, native.messages); diff != "" {
+		t.Errorf("SendMessage() sent unexpected message to native service:\n%s", diff)
+	}
+}
@@ -8,10 +8,26 @@ var _ Notifier = (*Notify)(nil)
 // ErrSendNotification signals that the notifier failed to send a notification.
 var ErrSendNotification = errors.New("send notification")
 
+// ErrUnsupported signals that a service does not support the requested operation.
+var ErrUnsupported = errors.New("operation not supported by service")
+
 // Notify is the central struct for managing notification services and sending messages to them.
 type Notify struct {
 	Disabled  bool
 	notifiers []Notifier
+	overflow  OverflowPolicy
+	sanitize  bool
+	schedule  Schedule
+	audit     AuditStore
+
+	auditOnError func(error)
+
+	preferences PreferenceStore
+	templates   *Templates
+
+	concurrency *concurrency
+
+	jsonRenderer JSONRenderer
 }
 
 // Option is a function that can be used to configure a Notify instance. It is used by the WithOptions and
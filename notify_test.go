@@ -2,13 +2,25 @@ package notify
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
-
-	"github.com/nikoksr/notify/service/mail"
 )
 
+// failingService is a Notifier that always fails to send, like a service that lacks a valid configuration.
+type failingService struct {
+	name string
+}
+
+func newFailingService() *failingService {
+	return &failingService{name: "failing"}
+}
+
+func (s *failingService) Send(context.Context, string, string) error {
+	return errors.New("send failed")
+}
+
 func TestNew(t *testing.T) {
 	t.Parallel()
 
@@ -84,17 +96,17 @@ func TestNewWithServices(t *testing.T) {
 		t.Error("NewWithServices(nil) did not return empty Notifier")
 	}
 
-	mailService := mail.New("", "")
-	n3 := NewWithServices(mailService)
+	failing := newFailingService()
+	n3 := NewWithServices(failing)
 	if n3 == nil {
-		t.Fatal("NewWithServices(mail.New()) returned nil")
+		t.Fatal("NewWithServices(newFailingService()) returned nil")
 	}
 	if len(n3.notifiers) != 1 {
-		t.Errorf("NewWithServices(mail.New()) was expected to have 1 notifier but had %d", len(n3.notifiers))
+		t.Errorf("NewWithServices(newFailingService()) was expected to have 1 notifier but had %d", len(n3.notifiers))
 	} else {
-		diff := cmp.Diff(n3.notifiers[0], mailService, cmp.AllowUnexported(mail.Mail{}))
+		diff := cmp.Diff(n3.notifiers[0], failing, cmp.AllowUnexported(failingService{}))
 		if diff != "" {
-			t.Errorf("NewWithServices(mail.New()) did not correctly use service:\n%s", diff)
+			t.Errorf("NewWithServices(newFailingService()) did not correctly use service:\n%s", diff)
 		}
 	}
 }
@@ -107,12 +119,12 @@ func TestGlobal(t *testing.T) {
 		t.Errorf("Send() with no receivers returned error: %v", err)
 	}
 
-	UseServices(mail.New("", ""), nil)
+	UseServices(newFailingService(), nil)
 	if len(std.notifiers) != 1 {
-		t.Errorf("UseServices(mail.New()) was expected to have 1 notifier but had %d", len(std.notifiers))
+		t.Errorf("UseServices(newFailingService()) was expected to have 1 notifier but had %d", len(std.notifiers))
 	}
 
 	if err := Send(ctx, "subject", "message"); err == nil {
-		t.Error("Send() with invalid mail returned no error")
+		t.Error("Send() with failing service returned no error")
 	}
 }
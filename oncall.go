@@ -0,0 +1,160 @@
+package notify
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ErrNoSchedule signals that a notification was sent to the on-call person without an on-call schedule configured.
+var ErrNoSchedule = errors.New("no on-call schedule configured")
+
+// OnCall is the person on call.
+type OnCall struct {
+	// Name identifies the person, e.g. for logging.
+	Name string
+	// Receivers are mail addresses and phone numbers in E.164 format notifications for the person are sent to, each
+	// through the services that resolve it, see ContactResolver.
+	Receivers []string
+	// Contact holds further addresses of the person, e.g. chat IDs, see ContactResolver.
+	Contact Contact
+}
+
+// receiversFor returns the receivers of the person for the given service, resolved like for SendToContacts. Services
+// that don't implement ContactResolver are skipped, since it's unknown which receivers they can take.
+func (o OnCall) receiversFor(service Notifier) []string {
+	resolver, ok := service.(ContactResolver)
+	if !ok {
+		return nil
+	}
+
+	var receivers []string
+	add := func(contact Contact) {
+		receiver, ok := resolver.ResolveContact(contact)
+		if !ok {
+			return
+		}
+		for _, r := range receivers {
+			if r == receiver {
+				return
+			}
+		}
+		receivers = append(receivers, receiver)
+	}
+
+	add(o.Contact)
+	for _, receiver := range o.Receivers {
+		switch {
+		case ValidatePhoneNumber(receiver) == nil:
+			add(Contact{Name: o.Name, Phone: receiver})
+		case ValidateMailAddress(receiver) == nil:
+			add(Contact{Name: o.Name, Email: receiver})
+		}
+	}
+
+	return receivers
+}
+
+// Schedule resolves who is on call at a given time.
+//
+// The OnCall function returns the person on call at the given time.
+//
+//	E.g. pagerduty.Schedule queries a PagerDuty schedule, Rotation is a static rotation.
+type Schedule interface {
+	OnCall(ctx context.Context, at time.Time) (OnCall, error)
+}
+
+// Rotation is a static on-call schedule that hands over from one person to the next every shift.
+type Rotation struct {
+	// Start is the start of the first shift of the first person.
+	Start time.Time
+	// Shift is the length of a shift, e.g. a week.
+	Shift time.Duration
+	// People are on call one after another, starting over after the last person.
+	People []OnCall
+}
+
+// OnCall returns the person whose shift covers the given time.
+func (r Rotation) OnCall(_ context.Context, at time.Time) (OnCall, error) {
+	if len(r.People) == 0 {
+		return OnCall{}, errors.New("rotation has no people")
+	}
+	if r.Shift <= 0 {
+		return OnCall{}, errors.New("rotation has no shift length")
+	}
+
+	shifts := int64(at.Sub(r.Start) / r.Shift)
+	if at.Before(r.Start) && at.Sub(r.Start)%r.Shift != 0 {
+		shifts-- // Round down for times before the start.
+	}
+	index := shifts % int64(len(r.People))
+	if index < 0 {
+		index += int64(len(r.People))
+	}
+
+	return r.People[index], nil
+}
+
+// WithSchedule returns an Option that sets the on-call schedule used by SendToOnCall.
+func WithSchedule(schedule Schedule) Option {
+	return func(n *Notify) {
+		if n != nil {
+			n.schedule = schedule
+		}
+	}
+}
+
+// sendToOnCall sends the given message to the receivers of the person currently on call.
+func (n *Notify) sendToOnCall(ctx context.Context, message Message) error {
+	if n.Disabled {
+		return nil
+	}
+	if n.schedule == nil {
+		return ErrNoSchedule
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	onCall, err := n.schedule.OnCall(ctx, time.Now())
+	if err != nil {
+		return errors.Wrap(err, "resolve on-call person")
+	}
+
+	reachable := false
+	for _, service := range n.notifiers {
+		if len(onCall.receiversFor(service)) > 0 {
+			reachable = true
+			break
+		}
+	}
+	if !reachable {
+		return errors.Errorf("on-call person %q can't be reached through any service", onCall.Name)
+	}
+
+	contact := onCall.Contact
+	if contact.Name == "" {
+		contact.Name = onCall.Name
+	}
+	ctx = context.WithValue(ctx, contactKey{}, contact)
+	if contact.Locale != "" {
+		ctx = ContextWithLocale(ctx, contact.Locale)
+	}
+
+	return n.sendMessageTo(ctx, message, onCall.receiversFor)
+}
+
+// SendToOnCall sends the given message to the person currently on call, as resolved by the schedule set with
+// WithSchedule at send time, through all services that implement ContactResolver and can reach the person, like
+// SendToContacts. Other services are skipped. It returns ErrNoSchedule if no schedule is set.
+func (n *Notify) SendToOnCall(ctx context.Context, message Message) error {
+	return n.sendToOnCall(ctx, message)
+}
+
+// SendToOnCall sends the given message to the person currently on call, as resolved by the schedule set with
+// WithSchedule at send time, through all services that implement ContactResolver and can reach the person, like
+// SendToContacts. Other services are skipped. It returns ErrNoSchedule if no schedule is set.
+func SendToOnCall(ctx context.Context, message Message) error {
+	return std.SendToOnCall(ctx, message)
+}
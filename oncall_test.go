@@ -0,0 +1,96 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRotationOnCall(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	rotation := Rotation{
+		Start: start,
+		Shift: 7 * 24 * time.Hour,
+		People: []OnCall{
+			{Name: "alice", Receivers: []string{"alice@example.com"}},
+			{Name: "bob", Receivers: []string{"bob@example.com"}},
+			{Name: "carol", Receivers: []string{"carol@example.com"}},
+		},
+	}
+
+	tests := []struct {
+		at   time.Time
+		want string
+	}{
+		{at: start, want: "alice"},
+		{at: start.Add(7*24*time.Hour - time.Second), want: "alice"},
+		{at: start.Add(7 * 24 * time.Hour), want: "bob"},
+		{at: start.Add(3 * 7 * 24 * time.Hour), want: "alice"},
+		{at: start.Add(-time.Second), want: "carol"},
+		{at: start.Add(-7 * 24 * time.Hour), want: "carol"},
+	}
+
+	for _, tt := range tests {
+		got, err := rotation.OnCall(context.Background(), tt.at)
+		if err != nil {
+			t.Fatalf("OnCall(%v) returned error: %v", tt.at, err)
+		}
+		if got.Name != tt.want {
+			t.Errorf("OnCall(%v) = %q, want %q", tt.at, got.Name, tt.want)
+		}
+	}
+
+	if _, err := (Rotation{Shift: time.Hour}).OnCall(context.Background(), start); err == nil {
+		t.Error("OnCall() of empty rotation returned no error")
+	}
+}
+
+// mailContactService resolves contacts by their mail address.
+type mailContactService struct {
+	*contactService
+}
+
+func (s mailContactService) ResolveContact(contact Contact) (string, bool) {
+	return contact.Email, contact.Email != ""
+}
+
+func TestNotifySendToOnCall(t *testing.T) {
+	t.Parallel()
+
+	mail := mailContactService{&contactService{name: "mail"}}
+	chat, plain := &contactService{name: "chat"}, &optionsService{}
+	n := NewWithServices(mail, chat, plain)
+
+	if err := n.SendToOnCall(context.Background(), Message{Subject: "Disk full"}); !errors.Is(err, ErrNoSchedule) {
+		t.Errorf("SendToOnCall() without schedule returned %v, want ErrNoSchedule", err)
+	}
+
+	alice := OnCall{
+		Name:      "alice",
+		Receivers: []string{"alice@example.com", "+15551234567"},
+		Contact:   Contact{ChatIDs: map[string]string{"chat": "U1"}},
+	}
+	n.WithOptions(WithSchedule(Rotation{Start: time.Now(), Shift: time.Hour, People: []OnCall{alice}}))
+	if err := n.SendToOnCall(context.Background(), Message{Subject: "Disk full"}); err != nil {
+		t.Fatalf("SendToOnCall() returned error: %v", err)
+	}
+	if diff := cmp.Diff([]string{"alice@example.com/"}, mail.sends); diff != "" {
+		t.Errorf("SendToOnCall() sent unexpected mails:\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"U1/"}, chat.sends); diff != "" {
+		t.Errorf("SendToOnCall() sent unexpected chat messages:\n%s", diff)
+	}
+	if len(plain.options) != 0 {
+		t.Errorf("SendToOnCall() sent to service without ContactResolver")
+	}
+
+	n.WithOptions(WithSchedule(Rotation{Start: time.Now(), Shift: time.Hour, People: []OnCall{{Name: "bob"}}}))
+	if err := n.SendToOnCall(context.Background(), Message{Subject: "Disk full"}); err == nil {
+		t.Error("SendToOnCall() to person without receivers returned no error")
+	}
+}
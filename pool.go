@@ -0,0 +1,30 @@
+package notify
+
+import (
+	"bytes"
+	"sync"
+)
+
+// maxPooledBufferSize is the capacity above which buffers are not returned to the pool, so a single large message
+// doesn't keep its memory alive.
+const maxPooledBufferSize = 64 << 10
+
+// bufferPool holds buffers for building message texts, which are built for every send.
+var bufferPool = sync.Pool{
+	New: func() any { return new(bytes.Buffer) },
+}
+
+// getBuffer returns an empty buffer from the pool. It must be returned with putBuffer once its content was copied.
+func getBuffer() *bytes.Buffer {
+	return bufferPool.Get().(*bytes.Buffer)
+}
+
+// putBuffer resets the buffer and returns it to the pool.
+func putBuffer(b *bytes.Buffer) {
+	if b.Cap() > maxPooledBufferSize {
+		return
+	}
+
+	b.Reset()
+	bufferPool.Put(b)
+}
@@ -0,0 +1,106 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Preferences are the choices of a contact which notifications to receive through which channels. Channels are named
+// after the package of their service, e.g. "mail" for mail.Mail or "slack" for slack.Slack, unless the service
+// implements ChannelNamer. The zero value allows all notifications through all channels.
+//
+//	E.g. Preferences{Channels: map[string]Priority{"mail": PriorityCritical}} means "email me only for critical".
+type Preferences struct {
+	// MinPriority is the lowest priority the contact wants to receive through channels missing from Channels.
+	// Notifications without priority count as PriorityNormal.
+	MinPriority Priority
+	// Channels are the lowest priorities the contact wants to receive through the given channels.
+	Channels map[string]Priority
+	// OptOut are the channels the contact doesn't want to receive any notification through.
+	OptOut []string
+}
+
+// Allows reports whether the contact wants to receive a notification with the given priority through the channel.
+func (p Preferences) Allows(channel string, priority Priority) bool {
+	for _, optOut := range p.OptOut {
+		if optOut == channel {
+			return false
+		}
+	}
+
+	if priority == PriorityDefault {
+		priority = PriorityNormal
+	}
+	if min, ok := p.Channels[channel]; ok {
+		return priority >= min
+	}
+
+	return priority >= p.MinPriority
+}
+
+// PreferenceStore looks up the preferences of contacts, e.g. in the database of an application.
+//
+// The Preferences function returns the preferences of the given contact, or zero Preferences if the contact has none.
+type PreferenceStore interface {
+	Preferences(ctx context.Context, contact Contact) (Preferences, error)
+}
+
+// MemoryPreferences is a PreferenceStore that keeps the preferences of contacts in memory, keyed by contact name. It is
+// safe for concurrent use.
+type MemoryPreferences struct {
+	mu          sync.RWMutex
+	preferences map[string]Preferences
+}
+
+// NewMemoryPreferences returns an empty MemoryPreferences.
+func NewMemoryPreferences() *MemoryPreferences {
+	return &MemoryPreferences{preferences: make(map[string]Preferences)}
+}
+
+// Set sets the preferences of the contact with the given name.
+func (m *MemoryPreferences) Set(name string, preferences Preferences) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.preferences[name] = preferences
+}
+
+// Preferences returns the preferences of the contact.
+func (m *MemoryPreferences) Preferences(_ context.Context, contact Contact) (Preferences, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.preferences[contact.Name], nil
+}
+
+// WithPreferences returns an Option that makes SendToContacts consult the given store before dispatching, so that
+// contacts only receive notifications through the channels and with the priorities they chose, see Preferences.
+func WithPreferences(store PreferenceStore) Option {
+	return func(n *Notify) {
+		if n != nil {
+			n.preferences = store
+		}
+	}
+}
+
+// ChannelNamer is implemented by services that name their channel in Preferences themselves, e.g. to tell apart
+// several services of the same package.
+type ChannelNamer interface {
+	ChannelName() string
+}
+
+// channelName returns the channel name of a service in Preferences, see ChannelNamer, or the name of its package.
+func channelName(service Notifier) string {
+	if namer, ok := service.(ChannelNamer); ok {
+		return namer.ChannelName()
+	}
+
+	name := strings.TrimLeft(fmt.Sprintf("%T", service), "*")
+	if i := strings.Index(name, "."); i >= 0 {
+		name = name[:i]
+	}
+
+	return name
+}
@@ -0,0 +1,110 @@
+package notify
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ErrRateLimited signals that a service rejected a request because it received too many requests or is temporarily
+// unavailable. RetryAfter is the time the service asked to wait before retrying, zero if it did not say.
+type ErrRateLimited struct {
+	RetryAfter time.Duration
+}
+
+// Error implements the error interface.
+func (e *ErrRateLimited) Error() string {
+	if e.RetryAfter <= 0 {
+		return "rate limited"
+	}
+
+	return "rate limited, retry after " + e.RetryAfter.String()
+}
+
+// Is reports whether the target is ErrTransient, the class of rate limit errors.
+func (e *ErrRateLimited) Is(target error) bool {
+	return target == ErrTransient
+}
+
+// RetryAfter returns how long to wait before retrying a request that failed with the given error. The boolean is false
+// if the error does not wrap an *ErrRateLimited.
+func RetryAfter(err error) (time.Duration, bool) {
+	var rateLimited *ErrRateLimited
+	if !errors.As(err, &rateLimited) {
+		return 0, false
+	}
+
+	return rateLimited.RetryAfter, true
+}
+
+// parseRetryAfter parses the value of a Retry-After header, which is either a delay in seconds or an HTTP date. Invalid
+// values and dates in the past result in no delay.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+
+	date, err := http.ParseTime(value)
+	if err != nil || !date.After(now) {
+		return 0
+	}
+
+	return date.Sub(now)
+}
+
+// CheckRateLimit returns an *ErrRateLimited if the given response has status 429 Too Many Requests or 503 Service
+// Unavailable, honoring its Retry-After header. It returns nil for all other responses.
+func CheckRateLimit(resp *http.Response) error {
+	if resp == nil {
+		return nil
+	}
+	if resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode != http.StatusServiceUnavailable {
+		return nil
+	}
+
+	return &ErrRateLimited{RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())}
+}
+
+// RateLimitTransport is an http.RoundTripper that turns rate limit responses into *ErrRateLimited errors, see
+// CheckRateLimit. HTTP based services use it for their default clients, so callers can tell rate limits apart from
+// other failures and back off as asked by the service.
+type RateLimitTransport struct {
+	// Base is the transport used to send requests. If nil, http.DefaultTransport is used.
+	Base http.RoundTripper
+}
+
+// NewRateLimitTransport returns a new RateLimitTransport that sends requests using the given transport.
+func NewRateLimitTransport(base http.RoundTripper) *RateLimitTransport {
+	return &RateLimitTransport{Base: base}
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (t *RateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	resp, err := base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = CheckRateLimit(resp); err != nil {
+		_ = resp.Body.Close()
+		return nil, err
+	}
+
+	return resp, nil
+}
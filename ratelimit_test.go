@@ -0,0 +1,92 @@
+package notify
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		value string
+		want  time.Duration
+	}{
+		{name: "empty", value: "", want: 0},
+		{name: "seconds", value: "120", want: 2 * time.Minute},
+		{name: "negative seconds", value: "-5", want: 0},
+		{name: "http date", value: "Sun, 01 Oct 2023 12:00:30 GMT", want: 30 * time.Second},
+		{name: "http date in the past", value: "Sun, 01 Oct 2023 11:00:00 GMT", want: 0},
+		{name: "invalid", value: "soon", want: 0},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := parseRetryAfter(tt.value, now); got != tt.want {
+				t.Errorf("parseRetryAfter(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRateLimitTransport(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/limited":
+			w.Header().Set("Retry-After", "3")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case "/unavailable":
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: NewRateLimitTransport(nil)}
+
+	resp, err := client.Get(server.URL + "/ok")
+	if err != nil {
+		t.Fatalf("Get() returned error: %v", err)
+	}
+	_ = resp.Body.Close()
+
+	_, err = client.Get(server.URL + "/limited") //nolint:bodyclose // The transport closes the body.
+	retryAfter, ok := RetryAfter(errors.Wrap(err, "send request"))
+	if !ok || retryAfter != 3*time.Second {
+		t.Errorf("RetryAfter() = %v, %v, want %v, true", retryAfter, ok, 3*time.Second)
+	}
+
+	_, err = client.Get(server.URL + "/unavailable") //nolint:bodyclose // The transport closes the body.
+	var rateLimited *ErrRateLimited
+	if !errors.As(err, &rateLimited) || rateLimited.RetryAfter != 0 {
+		t.Errorf("Get() returned error %v, want *ErrRateLimited without delay", err)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	if _, ok := RetryAfter(fmt.Errorf("other")); ok {
+		t.Error("RetryAfter() reported rate limit for unrelated error")
+	}
+	if got := (&ErrRateLimited{}).Error(); got != "rate limited" {
+		t.Errorf("Error() = %q, want %q", got, "rate limited")
+	}
+	if got := (&ErrRateLimited{RetryAfter: time.Second}).Error(); got != "rate limited, retry after 1s" {
+		t.Errorf("Error() = %q, want %q", got, "rate limited, retry after 1s")
+	}
+}
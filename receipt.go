@@ -0,0 +1,73 @@
+package notify
+
+import (
+	"context"
+	"sync"
+)
+
+// Receipt identifies a single message that a service sent to one of its receivers. Services that get an identifier
+// back from their provider record a receipt for every message they send; see ContextWithReceipts.
+type Receipt struct {
+	// Service is the service that sent the message.
+	Service Notifier
+	// Receiver is the receiver the message was sent to, as configured on the service.
+	Receiver string
+	// MessageID is the identifier the provider assigned to the message.
+	MessageID string
+}
+
+// Receipts collects the receipts of all messages sent with a context returned by ContextWithReceipts. It is safe for
+// concurrent use.
+type Receipts struct {
+	mu       sync.Mutex
+	receipts []Receipt
+	// parent collects the receipts of an outer ContextWithReceipts, if any.
+	parent *Receipts
+}
+
+// All returns the receipts collected so far.
+func (r *Receipts) All() []Receipt {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	all := make([]Receipt, len(r.receipts))
+	copy(all, r.receipts)
+
+	return all
+}
+
+func (r *Receipts) add(receipt Receipt) {
+	r.mu.Lock()
+	r.receipts = append(r.receipts, receipt)
+	r.mu.Unlock()
+
+	if r.parent != nil {
+		r.parent.add(receipt)
+	}
+}
+
+type receiptsKey struct{}
+
+// ContextWithReceipts returns a copy of ctx that collects the receipts of all messages sent with it, along with the
+// Receipts they are collected in. If ctx already collects receipts, they are collected there as well.
+func ContextWithReceipts(ctx context.Context) (context.Context, *Receipts) {
+	parent, _ := ctx.Value(receiptsKey{}).(*Receipts)
+	receipts := &Receipts{parent: parent}
+
+	return context.WithValue(ctx, receiptsKey{}, receipts), receipts
+}
+
+// RecordReceipt records the receipt of a sent message if ctx collects receipts and does nothing otherwise. It is meant
+// to be called by services after each message they sent. If the message was sent with an acknowledgement token, see
+// Acks.ContextWithAck, replies to it acknowledge the token.
+func RecordReceipt(ctx context.Context, receipt Receipt) {
+	if ctx == nil {
+		return
+	}
+	if receipts, ok := ctx.Value(receiptsKey{}).(*Receipts); ok {
+		receipts.add(receipt)
+	}
+	if ac, ok := ctx.Value(ackKey{}).(ackContext); ok {
+		ac.acks.recordReceipt(ac.token, receipt)
+	}
+}
@@ -0,0 +1,41 @@
+package notify
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestContextWithReceipts(t *testing.T) {
+	t.Parallel()
+
+	// Recording without a collecting context must not panic.
+	RecordReceipt(context.Background(), Receipt{MessageID: "ignored"})
+	//nolint:staticcheck
+	RecordReceipt(nil, Receipt{MessageID: "ignored"})
+
+	ctx, receipts := ContextWithReceipts(context.Background())
+	if len(receipts.All()) != 0 {
+		t.Fatalf("ContextWithReceipts() returned non-empty receipts: %v", receipts.All())
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			RecordReceipt(ctx, Receipt{Receiver: "receiver", MessageID: "id"})
+		}()
+	}
+	wg.Wait()
+
+	all := receipts.All()
+	if len(all) != 10 {
+		t.Fatalf("All() returned %d receipts, want 10", len(all))
+	}
+	if diff := cmp.Diff(Receipt{Receiver: "receiver", MessageID: "id"}, all[0]); diff != "" {
+		t.Errorf("All() returned unexpected receipt:\n%s", diff)
+	}
+}
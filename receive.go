@@ -0,0 +1,86 @@
+package notify
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/sync/errgroup"
+)
+
+// Reply is an inbound message a service received, e.g. an answer to a notification or a command sent to a bot.
+type Reply struct {
+	// Service is the service that received the reply.
+	Service Notifier
+	// Sender identifies who sent the reply, e.g. a user ID or mail address.
+	Sender string
+	// Receiver is the receiver the reply was posted to, as configured on the service, e.g. a chat or channel ID.
+	Receiver string
+	// Text is the content of the reply.
+	Text string
+	// InReplyTo identifies the notification that was replied to, if the service knows it. It can be matched against
+	// the receipts recorded when sending, see ContextWithReceipts.
+	InReplyTo *Receipt
+	// Time is the time the reply was sent.
+	Time time.Time
+}
+
+// ReplyHandler handles replies delivered by a Receiver. An error returned by the handler stops receiving.
+type ReplyHandler func(ctx context.Context, reply Reply) error
+
+// Receiver is implemented by services that can receive replies and commands, enabling workflows like "reply ACK to
+// acknowledge".
+//
+// The Receive function passes every reply to the handler until the context is done, in which case it returns nil, or
+// receiving fails.
+//
+//	E.g. for telegram.Telegram it polls the bot for new messages.
+type Receiver interface {
+	Receive(ctx context.Context, handler ReplyHandler) error
+}
+
+// receive passes the replies of all services that implement Receiver to the handler.
+func (n *Notify) receive(ctx context.Context, handler ReplyHandler) error {
+	if n.Disabled {
+		return nil
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	var receivers []Receiver
+	for _, service := range n.notifiers {
+		if receiver, ok := service.(Receiver); ok {
+			receivers = append(receivers, receiver)
+		}
+	}
+	if len(receivers) == 0 {
+		return errors.Wrap(ErrUnsupported, "receive replies")
+	}
+
+	g, ctx := errgroup.WithContext(ctx)
+	for _, receiver := range receivers {
+		receiver := receiver
+		g.Go(func() error {
+			return receiver.Receive(ctx, handler)
+		})
+	}
+
+	return errors.Wrap(g.Wait(), "receive replies")
+}
+
+// Receive passes the replies of all services that implement Receiver to the handler until the context is done. The
+// handler is called concurrently for replies of different services. If a service fails to receive or the handler
+// returns an error, receiving stops for all services and the error is returned. It returns ErrUnsupported if no service
+// implements Receiver.
+func (n *Notify) Receive(ctx context.Context, handler ReplyHandler) error {
+	return n.receive(ctx, handler)
+}
+
+// Receive passes the replies of all services that implement Receiver to the handler until the context is done. The
+// handler is called concurrently for replies of different services. If a service fails to receive or the handler
+// returns an error, receiving stops for all services and the error is returned. It returns ErrUnsupported if no service
+// implements Receiver.
+func Receive(ctx context.Context, handler ReplyHandler) error {
+	return std.Receive(ctx, handler)
+}
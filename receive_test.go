@@ -0,0 +1,69 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+)
+
+// replyingService delivers its replies and then waits for the context to be done.
+type replyingService struct {
+	replies []string
+}
+
+func (s *replyingService) Send(context.Context, string, string) error {
+	return nil
+}
+
+func (s *replyingService) Receive(ctx context.Context, handler ReplyHandler) error {
+	for _, text := range s.replies {
+		if err := handler(ctx, Reply{Service: s, Text: text}); err != nil {
+			return err
+		}
+	}
+	<-ctx.Done()
+
+	return nil
+}
+
+func TestNotifyReceive(t *testing.T) {
+	t.Parallel()
+
+	first := &replyingService{replies: []string{"ACK"}}
+	second := &replyingService{replies: []string{"status", "stop"}}
+	n := NewWithServices(first, newFailingService(), second)
+
+	var mu sync.Mutex
+	received := make(map[string]Notifier)
+	errStop := errors.New("stop")
+	err := n.Receive(context.Background(), func(_ context.Context, reply Reply) error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		received[reply.Text] = reply.Service
+		if len(received) == 3 {
+			return errStop
+		}
+		return nil
+	})
+	if !errors.Is(err, errStop) {
+		t.Errorf("Receive() returned %v, want error of handler", err)
+	}
+	if received["ACK"] != first || received["status"] != second || received["stop"] != second {
+		t.Errorf("Receive() passed unexpected replies to handler: %v", received)
+	}
+
+	// Receive returns once the context is done.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err = n.Receive(ctx, func(context.Context, Reply) error { return nil }); err != nil {
+		t.Errorf("Receive() with done context returned error: %v", err)
+	}
+
+	// Without services that implement Receiver, receiving is unsupported.
+	err = New().Receive(context.Background(), func(context.Context, Reply) error { return nil })
+	if !errors.Is(err, ErrUnsupported) {
+		t.Errorf("Receive() without receivers returned %v, want ErrUnsupported", err)
+	}
+}
@@ -0,0 +1,193 @@
+package notify
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// DefaultReloadInterval is the interval ReloadOnChange checks the file at by default.
+const DefaultReloadInterval = 5 * time.Second
+
+// LoadFunc builds a Notify from the current configuration of the application, e.g. by reading a config file.
+type LoadFunc func() (*Notify, error)
+
+// generation is a Notify used by a Reloader together with the number of sends in flight through it.
+type generation struct {
+	notify *Notify
+
+	mu       sync.Mutex
+	inFlight int
+	retired  bool
+	drained  chan struct{}
+}
+
+// release marks a send through the generation as done.
+func (g *generation) release() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.inFlight--
+	if g.retired && g.inFlight == 0 {
+		close(g.drained)
+	}
+}
+
+// Reloader sends notifications through a Notify that can be replaced while the application is running, e.g. when its
+// config file changed, see ReloadOnSignal and ReloadOnChange. Replacing is atomic: every send uses either the
+// previous or the new Notify with all its services, never a mix. It is safe for concurrent use.
+type Reloader struct {
+	current atomic.Pointer[generation]
+}
+
+// NewReloader returns a Reloader that sends through the given Notify until it is replaced.
+func NewReloader(n *Notify) *Reloader {
+	r := &Reloader{}
+	r.current.Store(&generation{notify: n, drained: make(chan struct{})})
+
+	return r
+}
+
+// Notify returns the Notify currently in use.
+func (r *Reloader) Notify() *Notify {
+	return r.current.Load().notify
+}
+
+// acquire returns the current generation and counts a send in flight through it.
+func (r *Reloader) acquire() *generation {
+	for {
+		g := r.current.Load()
+
+		g.mu.Lock()
+		if !g.retired {
+			g.inFlight++
+			g.mu.Unlock()
+			return g
+		}
+		g.mu.Unlock()
+	}
+}
+
+// Send sends a notification through the services of the current Notify.
+func (r *Reloader) Send(ctx context.Context, subject, message string) error {
+	g := r.acquire()
+	defer g.release()
+
+	return g.notify.Send(ctx, subject, message)
+}
+
+// SendMessage sends a structured message through the services of the current Notify.
+func (r *Reloader) SendMessage(ctx context.Context, message Message) error {
+	g := r.acquire()
+	defer g.release()
+
+	return g.notify.SendMessage(ctx, message)
+}
+
+// Swap replaces the current Notify with the given one. Sends started afterwards use the new Notify; Swap waits until
+// the sends in flight through the previous Notify are done, so that resources of removed services can be released
+// safely afterwards. It returns the error of the context if it is done first, the swap has happened regardless.
+func (r *Reloader) Swap(ctx context.Context, n *Notify) error {
+	previous := r.current.Swap(&generation{notify: n, drained: make(chan struct{})})
+
+	previous.mu.Lock()
+	previous.retired = true
+	if previous.inFlight == 0 {
+		close(previous.drained)
+	}
+	previous.mu.Unlock()
+
+	select {
+	case <-previous.drained:
+		return nil
+	case <-ctx.Done():
+		return errors.Wrap(ctx.Err(), "drain sends in flight")
+	}
+}
+
+// Reload builds a new Notify with load and swaps it in, see Swap. If load fails, the current Notify stays in use.
+func (r *Reloader) Reload(ctx context.Context, load LoadFunc) error {
+	n, err := load()
+	if err != nil {
+		return errors.Wrap(err, "load configuration")
+	}
+
+	return r.Swap(ctx, n)
+}
+
+// ReloadOnSignal reloads, see Reload, whenever the process receives one of the given signals, SIGHUP by default, until
+// the context is done. Errors of failed reloads are passed to onError, which may be nil.
+func (r *Reloader) ReloadOnSignal(ctx context.Context, load LoadFunc, onError func(error), signals ...os.Signal) {
+	if len(signals) == 0 {
+		signals = []os.Signal{syscall.SIGHUP}
+	}
+
+	received := make(chan os.Signal, 1)
+	signal.Notify(received, signals...)
+	defer signal.Stop(received)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-received:
+			if err := r.Reload(ctx, load); err != nil && onError != nil {
+				onError(err)
+			}
+		}
+	}
+}
+
+// ReloadOnChange reloads, see Reload, whenever the modification time or size of the file at the given path changes,
+// until the context is done. The file is checked every interval, zero for DefaultReloadInterval. Errors of failed
+// reloads are passed to onError, which may be nil.
+func (r *Reloader) ReloadOnChange(
+	ctx context.Context,
+	path string,
+	interval time.Duration,
+	load LoadFunc,
+	onError func(error),
+) {
+	if interval <= 0 {
+		interval = DefaultReloadInterval
+	}
+
+	var modTime time.Time
+	var size int64
+	if info, err := os.Stat(path); err == nil {
+		modTime, size = info.ModTime(), info.Size()
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		info, err := os.Stat(path)
+		if err != nil {
+			if onError != nil {
+				onError(errors.Wrap(err, "stat configuration"))
+			}
+			continue
+		}
+		if info.ModTime().Equal(modTime) && info.Size() == size {
+			continue
+		}
+		modTime, size = info.ModTime(), info.Size()
+
+		if err = r.Reload(ctx, load); err != nil && onError != nil {
+			onError(err)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// gatedService blocks sends until release is closed.
+type gatedService struct {
+	started chan struct{}
+	release chan struct{}
+}
+
+func (s *gatedService) Send(context.Context, string, string) error {
+	s.started <- struct{}{}
+	<-s.release
+
+	return nil
+}
+
+func TestReloader_Swap(t *testing.T) {
+	t.Parallel()
+
+	gated := &gatedService{started: make(chan struct{}), release: make(chan struct{})}
+	r := NewReloader(NewWithServices(gated))
+
+	sent := make(chan error)
+	go func() { sent <- r.Send(context.Background(), "subject", "message") }()
+	<-gated.started
+
+	next := &recordingService{}
+	swapped := make(chan error)
+	go func() { swapped <- r.Swap(context.Background(), NewWithServices(next)) }()
+
+	// New sends use the new services while the previous ones drain.
+	for r.Notify().notifiers[0] != next {
+		time.Sleep(time.Millisecond)
+	}
+	if err := r.Send(context.Background(), "subject", "new"); err != nil {
+		t.Fatalf("Send() returned error: %v", err)
+	}
+	select {
+	case <-swapped:
+		t.Fatal("Swap() returned before the sends in flight were done")
+	default:
+	}
+
+	close(gated.release)
+	if err := <-sent; err != nil {
+		t.Errorf("Send() in flight returned error: %v", err)
+	}
+	if err := <-swapped; err != nil {
+		t.Errorf("Swap() returned error: %v", err)
+	}
+	if len(next.bodies) != 1 || next.bodies[0] != "new" {
+		t.Errorf("new services received %q, want [new]", next.bodies)
+	}
+}
+
+func TestReloader_Reload(t *testing.T) {
+	t.Parallel()
+
+	current := New()
+	r := NewReloader(current)
+
+	err := r.Reload(context.Background(), func() (*Notify, error) { return nil, errors.New("invalid config") })
+	if err == nil || r.Notify() != current {
+		t.Errorf("Reload() with failing load returned %v and swapped the Notify, want error and no swap", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "notify.yaml")
+	if err = os.WriteFile(path, []byte("a"), 0o600); err != nil {
+		t.Fatalf("WriteFile() returned error: %v", err)
+	}
+
+	reloaded := make(chan *Notify, 1)
+	load := func() (*Notify, error) {
+		n := New()
+		reloaded <- n
+		return n, nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go r.ReloadOnChange(ctx, path, time.Millisecond, load, nil)
+
+	time.Sleep(10 * time.Millisecond)
+	if err = os.WriteFile(path, []byte("ab"), 0o600); err != nil {
+		t.Fatalf("WriteFile() returned error: %v", err)
+	}
+
+	select {
+	case n := <-reloaded:
+		for r.Notify() != n {
+			time.Sleep(time.Millisecond)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("ReloadOnChange() didn't reload after the file changed")
+	}
+
+	// A zero interval falls back to DefaultReloadInterval instead of panicking.
+	cancel()
+	r.ReloadOnChange(ctx, path, 0, load, nil)
+}
@@ -0,0 +1,209 @@
+package notify
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// Sanitizer is implemented by services that know how to neutralize user provided content for their markup, e.g. by
+// escaping markdown. If sanitization is enabled, see WithSanitization, the dispatcher passes every message through
+// Sanitize before delivering it. Messages to services that don't implement Sanitizer are sanitized as plain text, see
+// StripControlChars.
+type Sanitizer interface {
+	Sanitize(message Message) Message
+}
+
+// WithSanitization returns an Option that enables or disables the sanitization of messages before delivery, see
+// Sanitizer. Enable it if notifications embed user input, to prevent the input from injecting markup, mentions or
+// scripts into notification channels. It is disabled by default.
+func WithSanitization(enabled bool) Option {
+	return func(n *Notify) {
+		if n != nil {
+			n.sanitize = enabled
+		}
+	}
+}
+
+// sanitizeFor sanitizes the given message for the given service.
+func sanitizeFor(service Notifier, message Message) Message {
+	if sanitizer, ok := service.(Sanitizer); ok {
+		return sanitizer.Sanitize(message)
+	}
+
+	return SanitizeMessage(message, StripControlChars)
+}
+
+// SanitizeMessage returns a copy of the message with the given function applied to its texts: the subject, the body,
+// the labels of the actions and the texts of the card. The HTML body, if any, is sanitized with SanitizeHTML. It is a
+// building block for implementations of Sanitizer.
+func SanitizeMessage(message Message, fn func(string) string) Message {
+	message.Subject = fn(message.Subject)
+	message.Body = fn(message.Body)
+	if message.HTML != "" {
+		message.HTML = SanitizeHTML(message.HTML)
+	}
+
+	if len(message.Actions) > 0 {
+		actions := make([]Action, len(message.Actions))
+		for i, action := range message.Actions {
+			actions[i] = Action{Label: fn(action.Label), URL: action.URL}
+		}
+		message.Actions = actions
+	}
+
+	if message.Card != nil {
+		card := *message.Card
+		card.Title = fn(card.Title)
+		card.Footer = fn(card.Footer)
+		card.Fields = make([]CardField, len(message.Card.Fields))
+		for i, field := range message.Card.Fields {
+			card.Fields[i] = CardField{Name: fn(field.Name), Value: fn(field.Value), Inline: field.Inline}
+		}
+		message.Card = &card
+	}
+
+	return message
+}
+
+// isBidiControl reports whether r is a bidirectional formatting character, which can be abused to make text display
+// differently than it reads, e.g. to disguise links.
+func isBidiControl(r rune) bool {
+	return (r >= '\u202a' && r <= '\u202e') || (r >= '\u2066' && r <= '\u2069')
+}
+
+// StripControlChars removes control characters, except for line breaks and tabs, and bidirectional formatting
+// characters from s. It is the sanitization for plain text channels like SMS.
+func StripControlChars(s string) string {
+	clean := func(r rune) bool {
+		return (unicode.IsControl(r) && r != '\n' && r != '\t') || isBidiControl(r)
+	}
+	if strings.IndexFunc(s, clean) < 0 {
+		return s
+	}
+
+	return strings.Map(func(r rune) rune {
+		if clean(r) {
+			return -1
+		}
+		return r
+	}, s)
+}
+
+// markdownEscaper escapes the characters that start markdown formatting, links, mentions and quotes.
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	"`", "\\`",
+	`*`, `\*`,
+	`_`, `\_`,
+	`~`, `\~`,
+	`|`, `\|`,
+	`[`, `\[`,
+	`]`, `\]`,
+	`<`, `\<`,
+	`>`, `\>`,
+	`#`, `\#`,
+)
+
+// EscapeMarkdown escapes the markdown control characters in s with backslashes, so that s is displayed literally by
+// chat services that render markdown. Control characters are removed, see StripControlChars.
+func EscapeMarkdown(s string) string {
+	return markdownEscaper.Replace(StripControlChars(s))
+}
+
+// unsafeElements are removed with their content by SanitizeHTML.
+var unsafeElements = map[atom.Atom]bool{
+	atom.Script:   true,
+	atom.Style:    true,
+	atom.Iframe:   true,
+	atom.Frame:    true,
+	atom.Frameset: true,
+	atom.Object:   true,
+	atom.Embed:    true,
+	atom.Applet:   true,
+	atom.Form:     true,
+	atom.Input:    true,
+	atom.Button:   true,
+	atom.Textarea: true,
+	atom.Select:   true,
+	atom.Link:     true,
+	atom.Meta:     true,
+	atom.Base:     true,
+}
+
+// urlAttributes are the attributes whose values SanitizeHTML checks for script URLs.
+var urlAttributes = map[string]bool{
+	"href":       true,
+	"src":        true,
+	"action":     true,
+	"formaction": true,
+	"background": true,
+	"xlink:href": true,
+}
+
+// isUnsafeURL reports whether the URL runs code when opened.
+func isUnsafeURL(value string) bool {
+	value = strings.ToLower(strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) || unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, value))
+
+	return strings.HasPrefix(value, "javascript:") || strings.HasPrefix(value, "vbscript:") ||
+		strings.HasPrefix(value, "data:text/html")
+}
+
+// sanitizeNode removes unsafe elements and attributes from the children of n.
+func sanitizeNode(n *html.Node) {
+	for child := n.FirstChild; child != nil; {
+		next := child.NextSibling
+		switch {
+		case child.Type == html.CommentNode:
+			n.RemoveChild(child)
+		case child.Type == html.ElementNode && unsafeElements[child.DataAtom]:
+			n.RemoveChild(child)
+		case child.Type == html.ElementNode:
+			attrs := child.Attr[:0]
+			for _, a := range child.Attr {
+				key := strings.ToLower(a.Key)
+				if strings.HasPrefix(key, "on") || key == "style" || (urlAttributes[key] && isUnsafeURL(a.Val)) {
+					continue
+				}
+				attrs = append(attrs, a)
+			}
+			child.Attr = attrs
+			sanitizeNode(child)
+		}
+		child = next
+	}
+}
+
+// SanitizeHTML removes scripts and other active content from an HTML document or fragment: script, style, frame,
+// object and form elements, comments, event handler and style attributes and javascript: URLs. All other markup is
+// kept. It is the sanitization for HTML mail.
+func SanitizeHTML(s string) string {
+	body := &html.Node{Type: html.ElementNode, Data: "body", DataAtom: atom.Body}
+	nodes, err := html.ParseFragment(strings.NewReader(s), body)
+	if err != nil {
+		// The tokenizer never fails on a strings.Reader, but fall back to escaping the input just in case.
+		return html.EscapeString(s)
+	}
+
+	for _, node := range nodes {
+		body.AppendChild(node)
+	}
+	sanitizeNode(body)
+
+	b := getBuffer()
+	defer putBuffer(b)
+	for node := body.FirstChild; node != nil; node = node.NextSibling {
+		if err = html.Render(b, node); err != nil {
+			return html.EscapeString(s)
+		}
+	}
+
+	return b.String()
+}
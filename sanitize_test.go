@@ -0,0 +1,139 @@
+package notify
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestStripControlChars(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "plain text", want: "plain text"},
+		{in: "line\nbreak\ttab", want: "line\nbreak\ttab"},
+		{in: "bell\a escape\x1b[31m", want: "bell escape[31m"},
+		{in: "invoice‮fdp.exe", want: "invoicefdp.exe"},
+	}
+
+	for _, tt := range tests {
+		if got := StripControlChars(tt.in); got != tt.want {
+			t.Errorf("StripControlChars(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestEscapeMarkdown(t *testing.T) {
+	t.Parallel()
+
+	in := "*bold* _it_ `code` [link](https://example.com) <@everyone> # \\"
+	want := "\\*bold\\* \\_it\\_ \\`code\\` \\[link\\](https://example.com) \\<@everyone\\> \\# \\\\"
+	if got := EscapeMarkdown(in); got != want {
+		t.Errorf("EscapeMarkdown() = %q, want %q", got, want)
+	}
+}
+
+func TestSanitizeHTML(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{
+			name: "safe markup is kept",
+			in:   `<p>Disk <b>full</b> on <a href="https://example.com">db-1</a></p>`,
+			want: `<p>Disk <b>full</b> on <a href="https://example.com">db-1</a></p>`,
+		},
+		{
+			name: "scripts are removed",
+			in:   `<p>hello<script>alert(1)</script></p><style>p{}</style>`,
+			want: `<p>hello</p>`,
+		},
+		{
+			name: "event handlers and script URLs are removed",
+			in:   `<img src="x.png" onerror="alert(1)"><a href=" JavaScript:alert(1)" style="color:red">x</a>`,
+			want: `<img src="x.png"/><a>x</a>`,
+		},
+		{
+			name: "forms and frames are removed",
+			in:   `<form action="https://evil.example"><input name="password"></form><iframe src="x"></iframe>ok`,
+			want: `ok`,
+		},
+		{
+			name: "comments are removed",
+			in:   `a<!-- secret -->b`,
+			want: `ab`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := SanitizeHTML(tt.in); got != tt.want {
+				t.Errorf("SanitizeHTML(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+// sanitizingService escapes markdown in the messages it receives.
+type sanitizingService struct {
+	nativeService
+}
+
+func (s *sanitizingService) Sanitize(message Message) Message {
+	return SanitizeMessage(message, EscapeMarkdown)
+}
+
+func TestNotifySendMessageSanitized(t *testing.T) {
+	t.Parallel()
+
+	plain := &recordingService{}
+	native := &sanitizingService{}
+	n := NewWithServices(plain, native)
+
+	msg := Message{
+		Subject: "Login by *admin*",
+		Body:    "user\x1b[2J agent: _evil_",
+		Card:    &Card{Fields: []CardField{{Name: "IP", Value: "[1.2.3.4](https://evil.example)"}}},
+	}
+
+	// Sanitization is disabled by default.
+	if err := n.SendMessage(context.Background(), msg); err != nil {
+		t.Fatalf("SendMessage() returned error: %v", err)
+	}
+	if !strings.Contains(plain.bodies[0], "\x1b") {
+		t.Errorf("SendMessage() sanitized although sanitization is disabled: %q", plain.bodies[0])
+	}
+
+	n.WithOptions(WithSanitization(true))
+	if err := n.SendMessage(context.Background(), msg); err != nil {
+		t.Fatalf("SendMessage() returned error: %v", err)
+	}
+
+	want := "user[2J agent: _evil_\n\nIP: [1.2.3.4](https://evil.example)"
+	if plain.bodies[1] != want {
+		t.Errorf("SendMessage() sent body %q to plain service, want %q", plain.bodies[1], want)
+	}
+
+	wantMessage := Message{
+		Subject: "Login by \\*admin\\*",
+		Body:    "user\\[2J agent: \\_evil\\_",
+		Card:    &Card{Fields: []CardField{{Name: "IP", Value: "\\[1.2.3.4\\](https://evil.example)"}}},
+	}
+	if diff := cmp.Diff(wantMessage, native.messages[1]); diff != "" {
+		t.Errorf("SendMessage() sent unexpected message to sanitizing service:\n%s", diff)
+	}
+	if diff := cmp.Diff(msg, native.messages[0]); diff != "" {
+		t.Errorf("SendMessage() modified the message of the caller:\n%s", diff)
+	}
+}
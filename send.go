@@ -1,39 +1,10 @@
 package notify
 
-import (
-	"context"
-
-	"github.com/pkg/errors"
-	"golang.org/x/sync/errgroup"
-)
+import "context"
 
 // send calls the underlying notification services to send the given subject and message to their respective endpoints.
 func (n *Notify) send(ctx context.Context, subject, message string) error {
-	if n.Disabled {
-		return nil
-	}
-	if ctx == nil {
-		ctx = context.Background()
-	}
-
-	var eg errgroup.Group
-	for _, service := range n.notifiers {
-		if service == nil {
-			continue
-		}
-
-		service := service
-		eg.Go(func() error {
-			return service.Send(ctx, subject, message)
-		})
-	}
-
-	err := eg.Wait()
-	if err != nil {
-		err = errors.Wrap(ErrSendNotification, err.Error())
-	}
-
-	return err
+	return n.sendMessage(ctx, Message{Subject: subject, Body: message})
 }
 
 // Send calls the underlying notification services to send the given subject and message to their respective endpoints.
@@ -42,6 +13,7 @@ func (n *Notify) Send(ctx context.Context, subject, message string) error {
 }
 
 // Send calls the underlying notification services to send the given subject and message to their respective endpoints.
-func Send(ctx context.Context, subject, message string) error {
-	return std.Send(ctx, subject, message)
+// The given options apply to this send only, see SendWithOptions.
+func Send(ctx context.Context, subject, message string, options ...SendOption) error {
+	return std.SendWithOptions(ctx, subject, message, options...)
 }
@@ -0,0 +1,138 @@
+package notify
+
+import "context"
+
+// Priority is the urgency of a notification. Services that support priorities map it to their own scale.
+type Priority int
+
+// All priorities. PriorityDefault keeps the priority the service is configured with.
+const (
+	PriorityDefault Priority = iota
+	PriorityLow
+	PriorityNormal
+	PriorityHigh
+	PriorityCritical
+)
+
+// SendOptions customize a single send without reconfiguring the services. Services read them from the context of the
+// send, see SendOptionsFromContext.
+type SendOptions struct {
+	// Tags are added to the notification by services that support tags.
+	Tags []string
+	// Priority overrides the configured priority of services that support priorities.
+	Priority Priority
+	// Receivers replace the configured receivers of services that support overrides, see WithReceiversOverride. They
+	// must be in the format the service expects, e.g. channel IDs for slack.Slack.
+	Receivers []string
+	// Locale is the BCP 47 language tag of the notification, e.g. "de-DE", used by services that support languages.
+	Locale string
+}
+
+// SendOption is a function that customizes a single send, see SendWithOptions.
+type SendOption func(*SendOptions)
+
+// WithTags is a SendOption that adds the given tags to the notification.
+func WithTags(tags ...string) SendOption {
+	return func(o *SendOptions) {
+		o.Tags = append(o.Tags, tags...)
+	}
+}
+
+// WithPriority is a SendOption that sets the priority of the notification. It is mapped to the native priority or
+// severity by alerta, datadog, freshdesk, jira, linear, ntfy, opsgenie, pagerduty, syslog, victorops and zendesk;
+// other services ignore it.
+func WithPriority(priority Priority) SendOption {
+	return func(o *SendOptions) {
+		o.Priority = priority
+	}
+}
+
+// WithReceiversOverride is a SendOption that sends the notification to the given receivers instead of the configured
+// ones. It's supported by ntfy and by the services that implement ContactResolver, other services ignore it and send
+// to their configured receivers.
+func WithReceiversOverride(receivers ...string) SendOption {
+	return func(o *SendOptions) {
+		o.Receivers = receivers
+	}
+}
+
+// WithLocale is a SendOption that sets the locale of the notification, a BCP 47 language tag like "de-DE".
+func WithLocale(locale string) SendOption {
+	return func(o *SendOptions) {
+		o.Locale = locale
+	}
+}
+
+type sendOptionsKey struct{}
+
+// ContextWithSendOptions returns a copy of ctx that carries the send options of ctx with the given options applied.
+// Sends with the returned context behave like SendWithOptions.
+func ContextWithSendOptions(ctx context.Context, options ...SendOption) context.Context {
+	o := SendOptionsFromContext(ctx)
+	o.Tags = append([]string(nil), o.Tags...) // Avoid appending to the tags of the parent context.
+	for _, option := range options {
+		if option != nil {
+			option(&o)
+		}
+	}
+
+	return context.WithValue(ctx, sendOptionsKey{}, o)
+}
+
+// SendOptionsFromContext returns the send options carried by ctx, see SendWithOptions. It returns zero options if ctx
+// carries none.
+func SendOptionsFromContext(ctx context.Context) SendOptions {
+	if ctx == nil {
+		return SendOptions{}
+	}
+
+	o, _ := ctx.Value(sendOptionsKey{}).(SendOptions)
+
+	return o
+}
+
+// ContextWithTags returns a copy of ctx that adds the given tags to all notifications sent with it, like WithTags. It
+// allows e.g. middleware of a web framework to tag all notifications of a request without access to the send calls.
+func ContextWithTags(ctx context.Context, tags ...string) context.Context {
+	return ContextWithSendOptions(ctx, WithTags(tags...))
+}
+
+// ContextWithLocale returns a copy of ctx that sets the locale of all notifications sent with it, like WithLocale.
+func ContextWithLocale(ctx context.Context, locale string) context.Context {
+	return ContextWithSendOptions(ctx, WithLocale(locale))
+}
+
+// LocaleFromContext returns the locale set for the notification by ContextWithLocale or WithLocale, if any.
+func LocaleFromContext(ctx context.Context) (string, bool) {
+	locale := SendOptionsFromContext(ctx).Locale
+
+	return locale, locale != ""
+}
+
+// ReceiversFromContext returns the receivers the send options carried by ctx override, see WithReceiversOverride, or the
+// given receivers if there is no override.
+func ReceiversFromContext(ctx context.Context, receivers []string) []string {
+	if o := SendOptionsFromContext(ctx); o.Receivers != nil {
+		return o.Receivers
+	}
+
+	return receivers
+}
+
+// sendWithOptions sends the given subject and message with the given options applied to this send only.
+func (n *Notify) sendWithOptions(ctx context.Context, subject, message string, options ...SendOption) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	return n.send(ContextWithSendOptions(ctx, options...), subject, message)
+}
+
+// SendWithOptions works like Send, but applies the given options to this send only, e.g. to raise the priority of a
+// single notification. The options are applied on top of those carried by the context, see ContextWithTags and
+// ContextWithLocale. Services read the options from the context, see SendOptionsFromContext.
+//
+//	n.SendWithOptions(ctx, "Disk full", "db-1", notify.WithTags("db"), notify.WithPriority(notify.PriorityCritical))
+func (n *Notify) SendWithOptions(ctx context.Context, subject, message string, options ...SendOption) error {
+	return n.sendWithOptions(ctx, subject, message, options...)
+}
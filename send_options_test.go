@@ -0,0 +1,106 @@
+package notify
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// optionsService records the send options it receives through the context.
+type optionsService struct {
+	options []SendOptions
+}
+
+func (s *optionsService) Send(ctx context.Context, _, _ string) error {
+	s.options = append(s.options, SendOptionsFromContext(ctx))
+
+	return nil
+}
+
+func TestNotifySendWithOptions(t *testing.T) {
+	t.Parallel()
+
+	service := &optionsService{}
+	n := NewWithServices(service)
+
+	ctx := context.Background()
+	if err := n.Send(ctx, "subject", "message"); err != nil {
+		t.Fatalf("Send() returned error: %v", err)
+	}
+	err := n.SendWithOptions(ctx, "subject", "message",
+		WithTags("db"),
+		WithTags("prod"),
+		WithPriority(PriorityCritical),
+		WithReceiversOverride("C1234"),
+		nil,
+	)
+	if err != nil {
+		t.Fatalf("SendWithOptions() returned error: %v", err)
+	}
+
+	want := []SendOptions{
+		{},
+		{Tags: []string{"db", "prod"}, Priority: PriorityCritical, Receivers: []string{"C1234"}},
+	}
+	if diff := cmp.Diff(want, service.options); diff != "" {
+		t.Errorf("SendWithOptions() passed unexpected options:\n%s", diff)
+	}
+}
+
+func TestReceiversFromContext(t *testing.T) {
+	t.Parallel()
+
+	configured := []string{"a", "b"}
+
+	ctx := context.Background()
+	if diff := cmp.Diff(configured, ReceiversFromContext(ctx, configured)); diff != "" {
+		t.Errorf("ReceiversFromContext() without override returned unexpected receivers:\n%s", diff)
+	}
+
+	ctx = ContextWithSendOptions(ctx, WithReceiversOverride("c"))
+	if diff := cmp.Diff([]string{"c"}, ReceiversFromContext(ctx, configured)); diff != "" {
+		t.Errorf("ReceiversFromContext() with override returned unexpected receivers:\n%s", diff)
+	}
+}
+
+func TestContextWithSendOptions(t *testing.T) {
+	t.Parallel()
+
+	parent := ContextWithSendOptions(context.Background(), WithTags("a"))
+	child := ContextWithSendOptions(parent, WithTags("b"), WithPriority(PriorityLow))
+
+	if diff := cmp.Diff([]string{"a"}, SendOptionsFromContext(parent).Tags); diff != "" {
+		t.Errorf("ContextWithSendOptions() modified parent options:\n%s", diff)
+	}
+	want := SendOptions{Tags: []string{"a", "b"}, Priority: PriorityLow}
+	if diff := cmp.Diff(want, SendOptionsFromContext(child)); diff != "" {
+		t.Errorf("ContextWithSendOptions() returned unexpected options:\n%s", diff)
+	}
+}
+
+func TestContextOverrides(t *testing.T) {
+	t.Parallel()
+
+	service := &optionsService{}
+	n := NewWithServices(service)
+
+	ctx := ContextWithTags(context.Background(), "request")
+	ctx = ContextWithLocale(ctx, "de-DE")
+	if locale, ok := LocaleFromContext(ctx); !ok || locale != "de-DE" {
+		t.Errorf("LocaleFromContext() = %q, %v, want %q, true", locale, ok, "de-DE")
+	}
+
+	if err := n.SendWithOptions(ctx, "subject", "message", WithTags("db"), WithLocale("fr-FR")); err != nil {
+		t.Fatalf("SendWithOptions() returned error: %v", err)
+	}
+
+	want := []SendOptions{{Tags: []string{"request", "db"}, Locale: "fr-FR"}}
+	if diff := cmp.Diff(want, service.options); diff != "" {
+		t.Errorf("SendWithOptions() passed unexpected options:\n%s", diff)
+	}
+
+	if _, ok := LocaleFromContext(context.Background()); ok {
+		t.Error("LocaleFromContext() reported locale for empty context")
+	}
+}
@@ -3,8 +3,6 @@ package notify
 import (
 	"context"
 	"testing"
-
-	"github.com/nikoksr/notify/service/mail"
 )
 
 func TestNotifySend(t *testing.T) {
@@ -32,11 +30,11 @@ func TestNotifySend(t *testing.T) {
 		t.Errorf("Send() returned error: %v", err)
 	}
 
-	// This is not meant to test the mail service, but rather the general capability of the Send() function to catch
-	// errors.
-	n.UseServices(mail.New("", ""))
+	// The failing service stands in for a misconfigured one. This tests the general capability of the Send() function
+	// to catch errors.
+	n.UseServices(newFailingService())
 	if err := n.Send(ctx, "subject", "message"); err == nil {
-		t.Errorf("Send() invalid mail returned no error: %v", err)
+		t.Errorf("Send() failing service returned no error: %v", err)
 	}
 
 	// After disabling the Notifier, Send() should return silently.
@@ -72,12 +70,12 @@ func TestSendMany(t *testing.T) {
 	var services []Notifier
 
 	for i := 0; i < 10; i++ {
-		services = append(services, mail.New("", ""))
+		services = append(services, newFailingService())
 	}
 
 	n.UseServices(services...)
 
 	if err := n.Send(context.Background(), "subject", "message"); err == nil {
-		t.Errorf("Send() invalid mail returned no error: %v", err)
+		t.Errorf("Send() failing service returned no error: %v", err)
 	}
 }
@@ -0,0 +1,169 @@
+package acsemail
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
+)
+
+const apiVersion = "2023-03-31"
+
+// Service encapsulates the Azure Communication Services email client.
+type Service struct {
+	client        *http.Client
+	endpoint      string
+	accessKey     string
+	senderAddress string
+	replyTo       []string
+	useHTML       bool
+	receivers     []string
+}
+
+// New returns a new instance of an Azure Communication Services email notification service. The endpoint and access
+// key can be found under "Keys" of the Communication Services resource, e.g. https://my-resource.communication.azure.com.
+// The sender address must belong to a domain connected to the resource, e.g. DoNotReply@<id>.azurecomm.net.
+// For more information about the email API:
+//
+//	-> https://learn.microsoft.com/en-us/rest/api/communication/dataplane/email/send
+func New(endpoint, accessKey, senderAddress string) *Service {
+	return &Service{
+		client: &http.Client{
+			Transport: notify.NewRateLimitTransport(nil),
+			Timeout:   10 * time.Second,
+		},
+		endpoint:      strings.TrimSuffix(endpoint, "/"),
+		accessKey:     accessKey,
+		senderAddress: senderAddress,
+		receivers:     []string{},
+	}
+}
+
+// WithClient sets the http client to be used for sending requests. Calling this method is optional, the default client
+// will be used if this method is not called.
+func (s *Service) WithClient(client *http.Client) {
+	if client != nil {
+		s.client = client
+	}
+}
+
+// AddReceivers takes email addresses and adds them to the internal address list. The Send method will send a given
+// message to all those addresses.
+func (s *Service) AddReceivers(addresses ...string) {
+	s.receivers = append(s.receivers, addresses...)
+}
+
+// AddReplyTo adds addresses that replies to sent emails should be addressed to.
+func (s *Service) AddReplyTo(addresses ...string) {
+	s.replyTo = append(s.replyTo, addresses...)
+}
+
+// BodyFormat sets the format of the message body. Pass true to send the message as HTML instead of plain text.
+func (s *Service) BodyFormat(html bool) {
+	s.useHTML = html
+}
+
+// Validate checks that the endpoint is well-formed, that the access key is set and that the sender, receiver and
+// reply-to addresses parse, see notify.Validator.
+func (s *Service) Validate() error {
+	var problems notify.ConfigErrors
+	problems.Check("endpoint", notify.ValidateURL(s.endpoint))
+	problems.Require("access key", s.accessKey)
+	problems.Check("sender", notify.ValidateMailAddress(s.senderAddress))
+	for _, address := range s.receivers {
+		problems.Check("receiver", notify.ValidateMailAddress(address))
+	}
+	for _, address := range s.replyTo {
+		problems.Check("reply-to", notify.ValidateMailAddress(address))
+	}
+
+	return problems.Err()
+}
+
+type address struct {
+	Address string `json:"address"`
+}
+
+type emailContent struct {
+	Subject   string `json:"subject"`
+	PlainText string `json:"plainText,omitempty"`
+	HTML      string `json:"html,omitempty"`
+}
+
+type emailRecipients struct {
+	To []address `json:"to"`
+}
+
+type emailMessage struct {
+	SenderAddress string          `json:"senderAddress"`
+	Recipients    emailRecipients `json:"recipients"`
+	Content       emailContent    `json:"content"`
+	ReplyTo       []address       `json:"replyTo,omitempty"`
+}
+
+func toAddresses(addresses []string) []address {
+	result := make([]address, 0, len(addresses))
+	for _, addr := range addresses {
+		result = append(result, address{Address: addr})
+	}
+
+	return result
+}
+
+// Send takes a message subject and a message body and sends them to all previously set email addresses. The email is
+// accepted asynchronously by Azure; delivery failures are not reported back.
+func (s *Service) Send(ctx context.Context, subject, message string) error {
+	if len(s.receivers) == 0 {
+		return nil
+	}
+
+	msg := emailMessage{
+		SenderAddress: s.senderAddress,
+		Recipients:    emailRecipients{To: toAddresses(s.receivers)},
+		Content:       emailContent{Subject: subject},
+	}
+	if s.useHTML {
+		msg.Content.HTML = message
+	} else {
+		msg.Content.PlainText = message
+	}
+	if len(s.replyTo) > 0 {
+		msg.ReplyTo = toAddresses(s.replyTo)
+	}
+
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal email")
+	}
+
+	url := s.endpoint + "/emails:send?api-version=" + apiVersion
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "failed to create request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if err = signRequest(req, s.accessKey, time.Now()); err != nil {
+		return errors.Wrap(err, "failed to sign request")
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "failed to send email")
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusAccepted {
+		b, _ := io.ReadAll(resp.Body)
+		return notify.ClassifyResponse(resp, fmt.Errorf("failed to send email: status %d: %s", resp.StatusCode, string(b)))
+	}
+
+	return nil
+}
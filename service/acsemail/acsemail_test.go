@@ -0,0 +1,93 @@
+package acsemail
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nikoksr/notify"
+)
+
+var accessKey = base64.StdEncoding.EncodeToString([]byte("secret"))
+
+func TestACSEmail_Send(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	var got emailMessage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hash := r.Header.Get("x-ms-content-sha256")
+		stringToSign := r.Method + "\n" + r.URL.RequestURI() + "\n" + r.Header.Get("x-ms-date") + ";" + r.Host + ";" + hash
+		mac := hmac.New(sha256.New, []byte("secret"))
+		mac.Write([]byte(stringToSign))
+		want := "HMAC-SHA256 SignedHeaders=x-ms-date;host;x-ms-content-sha256&Signature=" +
+			base64.StdEncoding.EncodeToString(mac.Sum(nil))
+		if r.Header.Get("Authorization") != want || r.URL.Path != "/emails:send" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		got = emailMessage{}
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	service := New(server.URL+"/", accessKey, "DoNotReply@example.com")
+
+	// No receivers added
+	assert.NoError(service.Send(context.Background(), "subject", "message"))
+
+	service.AddReceivers("a@example.com", "b@example.com")
+	service.AddReplyTo("support@example.com")
+	assert.NoError(service.Send(context.Background(), "subject", "message"))
+	assert.Equal(emailMessage{
+		SenderAddress: "DoNotReply@example.com",
+		Recipients:    emailRecipients{To: []address{{Address: "a@example.com"}, {Address: "b@example.com"}}},
+		Content:       emailContent{Subject: "subject", PlainText: "message"},
+		ReplyTo:       []address{{Address: "support@example.com"}},
+	}, got)
+
+	service.BodyFormat(true)
+	assert.NoError(service.Send(context.Background(), "subject", "<b>message</b>"))
+	assert.Equal(emailContent{Subject: "subject", HTML: "<b>message</b>"}, got.Content)
+
+	// Test error response
+	service = New(server.URL, base64.StdEncoding.EncodeToString([]byte("invalid")), "DoNotReply@example.com")
+	service.AddReceivers("a@example.com")
+	assert.Error(service.Send(context.Background(), "subject", "message"))
+
+	// Test invalid access key
+	service = New(server.URL, "not base64!", "DoNotReply@example.com")
+	service.AddReceivers("a@example.com")
+	assert.Error(service.Send(context.Background(), "subject", "message"))
+}
+
+func TestACSEmail_Validate(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := New("https://my-resource.communication.azure.com", accessKey, "DoNotReply@example.azurecomm.net")
+	service.AddReceivers("alice@example.com")
+	service.AddReplyTo("Ops <ops@example.com>")
+	assert.NoError(service.Validate())
+
+	service = New("my-resource.communication.azure.com", "", "DoNotReply")
+	service.AddReceivers("alice")
+	service.AddReplyTo("ops")
+	err := service.Validate()
+	assert.ErrorIs(err, notify.ErrInvalidConfig)
+	assert.ErrorContains(err, "endpoint")
+	assert.ErrorContains(err, "access key is missing")
+	assert.ErrorContains(err, `sender: mail address "DoNotReply"`)
+	assert.ErrorContains(err, `receiver: mail address "alice"`)
+	assert.ErrorContains(err, `reply-to: mail address "ops"`)
+}
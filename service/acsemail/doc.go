@@ -0,0 +1,35 @@
+/*
+Package acsemail provides a service for sending emails through Azure Communication Services.
+
+Usage:
+
+	package main
+
+	import (
+	    "context"
+	    "log"
+
+	    "github.com/nikoksr/notify"
+	    "github.com/nikoksr/notify/service/acsemail"
+	)
+
+	func main() {
+	    emailService := acsemail.New(
+	        "https://my-resource.communication.azure.com",
+	        "your-access-key",
+	        "DoNotReply@your-domain.azurecomm.net",
+	    )
+
+	    // Send to these addresses.
+	    emailService.AddReceivers("john.doe@example.com", "jane.doe@example.com")
+
+	    // Tell our notifier to use the email service.
+	    notify.UseServices(emailService)
+
+	    // Send a test message.
+	    if err := notify.Send(context.Background(), "Subject/Title", "The actual message"); err != nil {
+	        log.Fatal(err)
+	    }
+	}
+*/
+package acsemail
@@ -0,0 +1,182 @@
+package acssms
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
+)
+
+const apiVersion = "2021-03-07"
+
+// Service encapsulates the Azure Communication Services SMS client.
+type Service struct {
+	client         *http.Client
+	endpoint       string
+	accessKey      string
+	from           string
+	deliveryReport bool
+	tag            string
+	receivers      []string
+}
+
+// New returns a new instance of an Azure Communication Services SMS notification service. The endpoint and access key
+// can be found under "Keys" of the Communication Services resource, e.g. https://my-resource.communication.azure.com.
+// The sender must be a phone number acquired through the resource, in E.164 format.
+// For more information about the SMS API:
+//
+//	-> https://learn.microsoft.com/en-us/rest/api/communication/dataplane/sms/send
+func New(endpoint, accessKey, from string) *Service {
+	return &Service{
+		client: &http.Client{
+			Transport: notify.NewRateLimitTransport(nil),
+			Timeout:   10 * time.Second,
+		},
+		endpoint:  strings.TrimSuffix(endpoint, "/"),
+		accessKey: accessKey,
+		from:      from,
+		receivers: []string{},
+	}
+}
+
+// WithClient sets the http client to be used for sending requests. Calling this method is optional, the default client
+// will be used if this method is not called.
+func (s *Service) WithClient(client *http.Client) {
+	if client != nil {
+		s.client = client
+	}
+}
+
+// AddReceivers takes phone numbers in E.164 format and adds them to the internal receiver list. The Send method will
+// send a given message to all those numbers.
+func (s *Service) AddReceivers(phoneNumbers ...string) {
+	s.receivers = append(s.receivers, phoneNumbers...)
+}
+
+// EnableDeliveryReport opts in to delivery reports. Azure publishes them as SMSDeliveryReportReceived events to Event
+// Grid, where they can be picked up by a subscription. The optional tag is included in the reports to correlate them.
+func (s *Service) EnableDeliveryReport(tag string) {
+	s.deliveryReport = true
+	s.tag = tag
+}
+
+type smsRecipient struct {
+	To string `json:"to"`
+}
+
+type smsSendOptions struct {
+	EnableDeliveryReport bool   `json:"enableDeliveryReport"`
+	Tag                  string `json:"tag,omitempty"`
+}
+
+type sendRequest struct {
+	From           string         `json:"from"`
+	SMSRecipients  []smsRecipient `json:"smsRecipients"`
+	Message        string         `json:"message"`
+	SMSSendOptions smsSendOptions `json:"smsSendOptions"`
+}
+
+type sendResult struct {
+	To             string `json:"to"`
+	MessageID      string `json:"messageId"`
+	HTTPStatusCode int    `json:"httpStatusCode"`
+	Successful     bool   `json:"successful"`
+	ErrorMessage   string `json:"errorMessage"`
+}
+
+type sendResponse struct {
+	Value []sendResult `json:"value"`
+}
+
+// Validate checks that the endpoint is well-formed, that the access key is set and that the sender and receiver phone
+// numbers are in E.164 format, see notify.Validator.
+func (s *Service) Validate() error {
+	var problems notify.ConfigErrors
+	problems.Check("endpoint", notify.ValidateURL(s.endpoint))
+	problems.Require("access key", s.accessKey)
+	problems.Check("sender", notify.ValidatePhoneNumber(s.from))
+	for _, number := range s.receivers {
+		problems.Check("receiver", notify.ValidatePhoneNumber(number))
+	}
+
+	return problems.Err()
+}
+
+// ResolveContact returns the phone number of the contact, see notify.SendToContacts.
+func (s *Service) ResolveContact(contact notify.Contact) (string, bool) {
+	return contact.Phone, contact.Phone != ""
+}
+
+// Send takes a message subject and a message body and sends them to all previously set phone numbers. Subject and
+// message are joined by a newline. Azure reports the outcome per recipient; an error is returned if any of them failed.
+// The phone numbers can be overridden per send, see notify.WithReceiversOverride.
+func (s *Service) Send(ctx context.Context, subject, message string) error {
+	receivers := notify.ReceiversFromContext(ctx, s.receivers)
+	if len(receivers) == 0 {
+		return nil
+	}
+
+	recipients := make([]smsRecipient, 0, len(receivers))
+	for _, receiver := range receivers {
+		recipients = append(recipients, smsRecipient{To: receiver})
+	}
+
+	body, err := json.Marshal(sendRequest{
+		From:          s.from,
+		SMSRecipients: recipients,
+		Message:       subject + "\n" + message,
+		SMSSendOptions: smsSendOptions{
+			EnableDeliveryReport: s.deliveryReport,
+			Tag:                  s.tag,
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal sms")
+	}
+
+	url := s.endpoint + "/sms?api-version=" + apiVersion
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "failed to create request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if err = signRequest(req, s.accessKey, time.Now()); err != nil {
+		return errors.Wrap(err, "failed to sign request")
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "failed to send sms")
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	b, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusAccepted {
+		return notify.ClassifyResponse(resp, fmt.Errorf("failed to send sms: status %d: %s", resp.StatusCode, string(b)))
+	}
+
+	var result sendResponse
+	if err = json.Unmarshal(b, &result); err != nil {
+		return errors.Wrap(err, "failed to decode response")
+	}
+
+	var failed []string
+	for _, r := range result.Value {
+		if !r.Successful {
+			failed = append(failed, fmt.Sprintf("%s (%d: %s)", r.To, r.HTTPStatusCode, r.ErrorMessage))
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to send sms to %s", strings.Join(failed, ", "))
+	}
+
+	return nil
+}
@@ -0,0 +1,87 @@
+package acssms
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nikoksr/notify"
+)
+
+func TestACSSMS_Send(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	var got sendRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("x-ms-content-sha256") == "" || r.URL.Path != "/sms" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		got = sendRequest{}
+		_ = json.NewDecoder(r.Body).Decode(&got)
+
+		var resp sendResponse
+		for _, recipient := range got.SMSRecipients {
+			resp.Value = append(resp.Value, sendResult{
+				To:             recipient.To,
+				Successful:     recipient.To != "+15550000000",
+				HTTPStatusCode: http.StatusAccepted,
+			})
+		}
+		w.WriteHeader(http.StatusAccepted)
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	service := New(server.URL, base64.StdEncoding.EncodeToString([]byte("secret")), "+15551234567")
+
+	// No receivers added
+	assert.NoError(service.Send(context.Background(), "subject", "message"))
+
+	service.AddReceivers("+15557654321")
+	service.EnableDeliveryReport("alerts")
+	assert.NoError(service.Send(context.Background(), "subject", "message"))
+	assert.Equal(sendRequest{
+		From:           "+15551234567",
+		SMSRecipients:  []smsRecipient{{To: "+15557654321"}},
+		Message:        "subject\nmessage",
+		SMSSendOptions: smsSendOptions{EnableDeliveryReport: true, Tag: "alerts"},
+	}, got)
+
+	// Test partial failure
+	service.AddReceivers("+15550000000")
+	err := service.Send(context.Background(), "subject", "message")
+	assert.Error(err)
+	assert.Contains(err.Error(), "+15550000000")
+
+	// Test invalid access key
+	service = New(server.URL, "not base64!", "+15551234567")
+	service.AddReceivers("+15557654321")
+	assert.Error(service.Send(context.Background(), "subject", "message"))
+}
+
+func TestACSSMS_Validate(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := New("https://my-resource.communication.azure.com", "key", "+18005551234")
+	service.AddReceivers("+4915112345678")
+	assert.NoError(service.Validate())
+
+	service = New("my-resource.communication.azure.com", "", "18005551234")
+	service.AddReceivers("015112345678")
+	err := service.Validate()
+	assert.ErrorIs(err, notify.ErrInvalidConfig)
+	assert.ErrorContains(err, "endpoint")
+	assert.ErrorContains(err, "access key is missing")
+	assert.ErrorContains(err, `sender: phone number "18005551234" is not in E.164 format`)
+	assert.ErrorContains(err, `receiver: phone number "015112345678" is not in E.164 format`)
+}
@@ -0,0 +1,38 @@
+/*
+Package acssms provides a service for sending SMS through Azure Communication Services.
+
+Usage:
+
+	package main
+
+	import (
+	    "context"
+	    "log"
+
+	    "github.com/nikoksr/notify"
+	    "github.com/nikoksr/notify/service/acssms"
+	)
+
+	func main() {
+	    smsService := acssms.New(
+	        "https://my-resource.communication.azure.com",
+	        "your-access-key",
+	        "+15551234567",
+	    )
+
+	    // Send to these phone numbers.
+	    smsService.AddReceivers("+15557654321")
+
+	    // Opt in to delivery reports, which are published to Event Grid.
+	    smsService.EnableDeliveryReport("alerts")
+
+	    // Tell our notifier to use the sms service.
+	    notify.UseServices(smsService)
+
+	    // Send a test message.
+	    if err := notify.Send(context.Background(), "Subject/Title", "The actual message"); err != nil {
+	        log.Fatal(err)
+	    }
+	}
+*/
+package acssms
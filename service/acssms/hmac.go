@@ -0,0 +1,48 @@
+package acssms
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// signRequest authenticates the request with the access key of the Communication Services resource.
+// For more information about HMAC authentication:
+//
+//	-> https://learn.microsoft.com/en-us/rest/api/communication/authentication#authentication-with-hmac
+func signRequest(req *http.Request, accessKey string, now time.Time) error {
+	key, err := base64.StdEncoding.DecodeString(accessKey)
+	if err != nil {
+		return errors.Wrap(err, "decode access key")
+	}
+
+	var body []byte
+	if req.Body != nil {
+		body, err = io.ReadAll(req.Body)
+		if err != nil {
+			return errors.Wrap(err, "read request body")
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	contentHash := sha256.Sum256(body)
+	encodedHash := base64.StdEncoding.EncodeToString(contentHash[:])
+	date := now.UTC().Format(http.TimeFormat)
+
+	stringToSign := req.Method + "\n" + req.URL.RequestURI() + "\n" + date + ";" + req.URL.Host + ";" + encodedHash
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(stringToSign))
+	signature := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+
+	req.Header.Set("x-ms-date", date)
+	req.Header.Set("x-ms-content-sha256", encodedHash)
+	req.Header.Set("Authorization", "HMAC-SHA256 SignedHeaders=x-ms-date;host;x-ms-content-sha256&Signature="+signature)
+
+	return nil
+}
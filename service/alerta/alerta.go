@@ -0,0 +1,231 @@
+package alerta
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
+)
+
+// Severity represents the severity of an alert.
+type Severity string
+
+// Severities supported by Alerta by default. Alerts with SeverityNormal or SeverityOK close open alerts of the same
+// resource and event.
+const (
+	SeveritySecurity      Severity = "security"
+	SeverityCritical      Severity = "critical"
+	SeverityMajor         Severity = "major"
+	SeverityMinor         Severity = "minor"
+	SeverityWarning       Severity = "warning"
+	SeverityInformational Severity = "informational"
+	SeverityDebug         Severity = "debug"
+	SeverityNormal        Severity = "normal"
+	SeverityOK            Severity = "ok"
+)
+
+// severities maps the priorities of notify.WithPriority to Alerta severities.
+var severities = map[notify.Priority]Severity{
+	notify.PriorityLow:      SeverityInformational,
+	notify.PriorityNormal:   SeverityWarning,
+	notify.PriorityHigh:     SeverityMajor,
+	notify.PriorityCritical: SeverityCritical,
+}
+
+// Service allow you to configure the Alerta service.
+type Service struct {
+	client   *http.Client
+	endpoint string
+	apiKey   string
+
+	environment string
+	resource    string
+	severity    Severity
+	services    []string
+	group       string
+	correlate   []string
+	tags        []string
+	attributes  map[string]string
+}
+
+func defaultHTTPClient() *http.Client {
+	return &http.Client{
+		Transport: notify.NewRateLimitTransport(nil),
+		Timeout:   10 * time.Second,
+	}
+}
+
+// New returns a new instance of an Alerta notification service. The endpoint is the URL of the Alerta API, e.g.
+// https://alerta.example.com/api, and the API key needs the write:alerts scope.
+// For more information about the Alerta API:
+//
+//	-> https://docs.alerta.io/api/reference.html#create-an-alert
+func New(endpoint, apiKey string) *Service {
+	return &Service{
+		client:      defaultHTTPClient(),
+		endpoint:    strings.TrimSuffix(endpoint, "/"),
+		apiKey:      apiKey,
+		environment: "Production",
+		resource:    "notify",
+		severity:    SeverityWarning,
+		attributes:  map[string]string{},
+	}
+}
+
+// WithClient sets the http client to be used for sending requests. Calling this method is optional, the default client
+// will be used if this method is not called.
+func (s *Service) WithClient(client *http.Client) {
+	if client != nil {
+		s.client = client
+	}
+}
+
+// SetEnvironment sets the environment of all alerts. It must be one of the environments allowed by the server.
+// Defaults to "Production".
+func (s *Service) SetEnvironment(environment string) {
+	s.environment = environment
+}
+
+// SetResource sets the resource all alerts are raised for, e.g. a hostname. Alerts with the same resource, event and
+// environment are deduplicated. Defaults to "notify".
+func (s *Service) SetResource(resource string) {
+	s.resource = resource
+}
+
+// SetSeverity sets the severity of alerts. Defaults to SeverityWarning. The priority of a notification, see
+// notify.WithPriority, overrides it: low is informational, normal warning, high major and critical critical.
+func (s *Service) SetSeverity(severity Severity) {
+	s.severity = severity
+}
+
+// AddServices adds services affected by all alerts.
+func (s *Service) AddServices(services ...string) {
+	s.services = append(s.services, services...)
+}
+
+// SetGroup sets the group of all alerts, used to group them by type, e.g. "Performance".
+func (s *Service) SetGroup(group string) {
+	s.group = group
+}
+
+// SetCorrelate sets events correlated with the alerts' events. Alerts of correlated events for the same resource
+// replace each other, e.g. "NodeDown" and "NodeUp".
+func (s *Service) SetCorrelate(events ...string) {
+	s.correlate = events
+}
+
+// AddTags adds tags to all alerts.
+func (s *Service) AddTags(tags ...string) {
+	s.tags = append(s.tags, tags...)
+}
+
+// AddAttribute adds a custom attribute to all alerts.
+func (s *Service) AddAttribute(key, value string) {
+	s.attributes[key] = value
+}
+
+// Validate checks that the endpoint is well-formed and that the API key, environment and resource are set, see
+// notify.Validator.
+func (s *Service) Validate() error {
+	var problems notify.ConfigErrors
+	problems.Check("endpoint", notify.ValidateURL(s.endpoint))
+	problems.Require("API key", s.apiKey)
+	problems.Require("environment", s.environment)
+	problems.Require("resource", s.resource)
+
+	return problems.Err()
+}
+
+// alert is the request body expected by the Alerta API.
+type alert struct {
+	Resource    string            `json:"resource"`
+	Event       string            `json:"event"`
+	Environment string            `json:"environment"`
+	Severity    Severity          `json:"severity"`
+	Correlate   []string          `json:"correlate,omitempty"`
+	Service     []string          `json:"service,omitempty"`
+	Group       string            `json:"group,omitempty"`
+	Text        string            `json:"text,omitempty"`
+	Tags        []string          `json:"tags,omitempty"`
+	Attributes  map[string]string `json:"attributes,omitempty"`
+	Origin      string            `json:"origin"`
+}
+
+func (s *Service) send(ctx context.Context, a *alert) error {
+	body, err := json.Marshal(a)
+	if err != nil {
+		return errors.Wrap(err, "marshal alert")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint+"/alert", bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "create request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Key "+s.apiKey)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "send request")
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusCreated {
+		result, _ := io.ReadAll(resp.Body)
+		return notify.ClassifyResponse(resp, fmt.Errorf("alerta returned status code %d: %s", resp.StatusCode, string(result)))
+	}
+
+	return nil
+}
+
+func (s *Service) newAlert(event string, severity Severity, text string) *alert {
+	a := &alert{
+		Resource:    s.resource,
+		Event:       event,
+		Environment: s.environment,
+		Severity:    severity,
+		Correlate:   s.correlate,
+		Service:     s.services,
+		Group:       s.group,
+		Text:        text,
+		Tags:        s.tags,
+		Origin:      "notify",
+	}
+	if len(s.attributes) > 0 {
+		a.Attributes = s.attributes
+	}
+
+	return a
+}
+
+// Send takes a message subject and a message body and raises an alert with the subject as event and the message as
+// text.
+func (s *Service) Send(ctx context.Context, subject, message string) error {
+	severity := s.severity
+	if mapped, ok := severities[notify.SendOptionsFromContext(ctx).Priority]; ok {
+		severity = mapped
+	}
+
+	if err := s.send(ctx, s.newAlert(subject, severity, message)); err != nil {
+		return errors.Wrap(err, "failed to send alert to Alerta")
+	}
+
+	return nil
+}
+
+// Resolve closes the open alert for the given event by sending it with normal severity.
+func (s *Service) Resolve(ctx context.Context, event string) error {
+	if err := s.send(ctx, s.newAlert(event, SeverityNormal, "")); err != nil {
+		return errors.Wrap(err, "failed to resolve alert in Alerta")
+	}
+
+	return nil
+}
@@ -0,0 +1,92 @@
+package alerta
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nikoksr/notify"
+)
+
+func TestAlerta_Validate(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	assert.NoError(New("https://alerta.example.com/api", "key").Validate())
+
+	service := New("alerta.example.com", "")
+	service.SetResource("")
+	err := service.Validate()
+	assert.ErrorIs(err, notify.ErrInvalidConfig)
+	assert.ErrorContains(err, "endpoint")
+	assert.ErrorContains(err, "API key is missing")
+	assert.ErrorContains(err, "resource is missing")
+}
+
+func TestAlerta_Send(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	var alerts []alert
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Key key" || r.URL.Path != "/api/alert" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"status":"error","message":"Missing authorization API Key or Bearer Token"}`))
+			return
+		}
+		var a alert
+		_ = json.NewDecoder(r.Body).Decode(&a)
+		alerts = append(alerts, a)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	service := New(server.URL+"/api/", "key")
+	service.SetEnvironment("Development")
+	service.SetResource("api-01")
+	service.SetSeverity(SeverityCritical)
+	service.AddServices("API")
+	service.SetGroup("Disk")
+	service.SetCorrelate("DiskFull", "DiskOK")
+	service.AddTags("dc1")
+	service.AddAttribute("region", "eu")
+
+	assert.NoError(service.Send(context.Background(), "DiskFull", "less than 1% left"))
+	assert.NoError(service.Resolve(context.Background(), "DiskFull"))
+
+	want := alert{
+		Resource:    "api-01",
+		Event:       "DiskFull",
+		Environment: "Development",
+		Severity:    SeverityCritical,
+		Correlate:   []string{"DiskFull", "DiskOK"},
+		Service:     []string{"API"},
+		Group:       "Disk",
+		Text:        "less than 1% left",
+		Tags:        []string{"dc1"},
+		Attributes:  map[string]string{"region": "eu"},
+		Origin:      "notify",
+	}
+	assert.Equal(want, alerts[0])
+
+	want.Severity = SeverityNormal
+	want.Text = ""
+	assert.Equal(want, alerts[1])
+
+	// The priority of the notification overrides the severity of the service.
+	ctx := notify.ContextWithSendOptions(context.Background(), notify.WithPriority(notify.PriorityLow))
+	assert.NoError(service.Send(ctx, "DiskFull", "less than 1% left"))
+	assert.Equal(SeverityInformational, alerts[2].Severity)
+
+	// Test error response
+	service.apiKey = "invalid"
+	err := service.Send(context.Background(), "subject", "message")
+	assert.ErrorContains(err, "Missing authorization")
+	assert.ErrorIs(err, notify.ErrAuthFailed)
+}
@@ -0,0 +1,38 @@
+/*
+Package alerta provides a service for raising alerts in Alerta.
+
+Usage:
+
+	package main
+
+	import (
+	    "context"
+	    "log"
+
+	    "github.com/nikoksr/notify"
+	    "github.com/nikoksr/notify/service/alerta"
+	)
+
+	func main() {
+	    alertaService := alerta.New("https://alerta.example.com/api", "your-api-key")
+
+	    // Alerts with the same resource, event and environment are deduplicated.
+	    alertaService.SetEnvironment("Production")
+	    alertaService.SetResource("api-01")
+	    alertaService.SetSeverity(alerta.SeverityCritical)
+
+	    // Tell our notifier to use the alerta service.
+	    notify.UseServices(alertaService)
+
+	    // Send a test message.
+	    if err := notify.Send(context.Background(), "DiskFull", "api-01 has less than 1% disk space left"); err != nil {
+	        log.Fatal(err)
+	    }
+
+	    // Close the alert once the problem is gone.
+	    if err := alertaService.Resolve(context.Background(), "DiskFull"); err != nil {
+	        log.Fatal(err)
+	    }
+	}
+*/
+package alerta
@@ -0,0 +1,198 @@
+package alertmanager
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
+)
+
+// Service allow you to configure the Alertmanager service.
+type Service struct {
+	client *http.Client
+	urls   []string
+
+	username     string
+	password     string
+	labels       map[string]string
+	annotations  map[string]string
+	generatorURL string
+
+	now func() time.Time
+}
+
+func defaultHTTPClient() *http.Client {
+	return &http.Client{
+		Transport: notify.NewRateLimitTransport(nil),
+		Timeout:   10 * time.Second,
+	}
+}
+
+// New returns a new instance of an Alertmanager notification service. Notifications are posted as alerts named after
+// alertName, so they are routed, grouped and silenced like any other alert.
+// For more information about the Alertmanager API:
+//
+//	-> https://prometheus.io/docs/alerting/latest/clients/
+func New(alertName string) *Service {
+	if alertName == "" {
+		alertName = "notify"
+	}
+
+	return &Service{
+		client:      defaultHTTPClient(),
+		urls:        []string{},
+		labels:      map[string]string{"alertname": alertName},
+		annotations: map[string]string{},
+		now:         time.Now,
+	}
+}
+
+// WithClient sets the http client to be used for sending requests. Calling this method is optional, the default client
+// will be used if this method is not called.
+func (s *Service) WithClient(client *http.Client) {
+	if client != nil {
+		s.client = client
+	}
+}
+
+// AddReceivers takes base URLs of Alertmanager instances, e.g. http://alertmanager:9093, and adds them to the internal
+// URL list. Alerts are posted to every one of them; for a highly available cluster, add all of its instances.
+func (s *Service) AddReceivers(urls ...string) {
+	for _, url := range urls {
+		s.urls = append(s.urls, strings.TrimSuffix(url, "/"))
+	}
+}
+
+// SetBasicAuth sets credentials for Alertmanager instances behind basic authentication.
+func (s *Service) SetBasicAuth(username, password string) {
+	s.username = username
+	s.password = password
+}
+
+// AddLabel adds a label to all alerts. Labels identify an alert and are used by Alertmanager for routing, grouping and
+// inhibition, e.g. "severity" or "team".
+func (s *Service) AddLabel(name, value string) {
+	s.labels[name] = value
+}
+
+// AddAnnotation adds an annotation to all alerts. Annotations carry additional information, e.g. a "runbook_url", and
+// don't identify the alert.
+func (s *Service) AddAnnotation(name, value string) {
+	s.annotations[name] = value
+}
+
+// SetGeneratorURL sets the URL linked as source of all alerts.
+func (s *Service) SetGeneratorURL(url string) {
+	s.generatorURL = url
+}
+
+// alert is an alert as expected by the Alertmanager API v2.
+type alert struct {
+	Labels       map[string]string `json:"labels"`
+	Annotations  map[string]string `json:"annotations,omitempty"`
+	StartsAt     *time.Time        `json:"startsAt,omitempty"`
+	EndsAt       *time.Time        `json:"endsAt,omitempty"`
+	GeneratorURL string            `json:"generatorURL,omitempty"`
+}
+
+func (s *Service) send(ctx context.Context, url string, alerts []alert) error {
+	body, err := json.Marshal(alerts)
+	if err != nil {
+		return errors.Wrap(err, "marshal alerts")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url+"/api/v2/alerts", bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "create request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.username != "" || s.password != "" {
+		req.SetBasicAuth(s.username, s.password)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "send request")
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		result, _ := io.ReadAll(resp.Body)
+		return notify.ClassifyResponse(resp, fmt.Errorf("alertmanager returned status code %d: %s", resp.StatusCode, string(result)))
+	}
+
+	return nil
+}
+
+// sendAll posts the alert to every Alertmanager instance.
+func (s *Service) sendAll(ctx context.Context, a alert) error {
+	for _, url := range s.urls {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			if err := s.send(ctx, url, []alert{a}); err != nil {
+				return errors.Wrap(err, "failed to send alert to Alertmanager")
+			}
+		}
+	}
+
+	return nil
+}
+
+// Validate checks that the Alertmanager URLs and the generator URL, if set, are well-formed and that basic auth has a
+// username, see notify.Validator.
+func (s *Service) Validate() error {
+	var problems notify.ConfigErrors
+	for _, url := range s.urls {
+		problems.Check("Alertmanager URL", notify.ValidateURL(url))
+	}
+	if s.generatorURL != "" {
+		problems.Check("generator URL", notify.ValidateURL(s.generatorURL))
+	}
+	if s.username == "" && s.password != "" {
+		problems.Addf("basic auth password is set without username")
+	}
+
+	return problems.Err()
+}
+
+// Send takes a message subject and a message body and posts a firing alert to every previously set Alertmanager
+// instance. The subject is set as "summary" and the message as "description" annotation. Alertmanager resolves the
+// alert automatically after its resolve_timeout unless it is sent again.
+func (s *Service) Send(ctx context.Context, subject, message string) error {
+	annotations := make(map[string]string, len(s.annotations)+2)
+	for name, value := range s.annotations {
+		annotations[name] = value
+	}
+	annotations["summary"] = subject
+	annotations["description"] = message
+
+	now := s.now()
+
+	return s.sendAll(ctx, alert{
+		Labels:       s.labels,
+		Annotations:  annotations,
+		StartsAt:     &now,
+		GeneratorURL: s.generatorURL,
+	})
+}
+
+// Resolve resolves the alert carrying the currently set labels on every previously set Alertmanager instance.
+func (s *Service) Resolve(ctx context.Context) error {
+	now := s.now()
+
+	return s.sendAll(ctx, alert{
+		Labels:       s.labels,
+		EndsAt:       &now,
+		GeneratorURL: s.generatorURL,
+	})
+}
@@ -0,0 +1,90 @@
+package alertmanager
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nikoksr/notify"
+)
+
+func TestAlertmanager_Send(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	var alerts []alert
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, pass, ok := r.BasicAuth(); !ok || user != "user" || pass != "pass" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Path != "/api/v2/alerts" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var received []alert
+		_ = json.NewDecoder(r.Body).Decode(&received)
+		alerts = append(alerts, received...)
+	}))
+	defer server.Close()
+
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	service := New("")
+	service.now = func() time.Time { return now }
+	service.AddReceivers(server.URL + "/")
+	service.SetBasicAuth("user", "pass")
+	service.AddLabel("severity", "critical")
+	service.AddAnnotation("runbook_url", "https://example.com/runbook")
+	service.SetGeneratorURL("https://example.com")
+
+	assert.NoError(service.Send(context.Background(), "subject", "message"))
+	assert.NoError(service.Resolve(context.Background()))
+
+	labels := map[string]string{"alertname": "notify", "severity": "critical"}
+	assert.Equal([]alert{
+		{
+			Labels: labels,
+			Annotations: map[string]string{
+				"summary":     "subject",
+				"description": "message",
+				"runbook_url": "https://example.com/runbook",
+			},
+			StartsAt:     &now,
+			GeneratorURL: "https://example.com",
+		},
+		{Labels: labels, EndsAt: &now, GeneratorURL: "https://example.com"},
+	}, alerts)
+
+	// Test error response
+	service.SetBasicAuth("user", "invalid")
+	assert.Error(service.Send(context.Background(), "subject", "message"))
+}
+
+func TestAlertmanager_Validate(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := New("")
+	service.AddReceivers("http://alertmanager:9093")
+	service.SetGeneratorURL("https://app.example.com/jobs")
+	service.SetBasicAuth("user", "secret")
+	assert.NoError(service.Validate())
+
+	service = New("")
+	service.AddReceivers("alertmanager:9093")
+	service.SetGeneratorURL("/jobs")
+	service.SetBasicAuth("", "secret")
+	err := service.Validate()
+	assert.ErrorIs(err, notify.ErrInvalidConfig)
+	assert.ErrorContains(err, "Alertmanager URL")
+	assert.ErrorContains(err, "generator URL")
+	assert.ErrorContains(err, "basic auth password is set without username")
+}
@@ -0,0 +1,40 @@
+/*
+Package alertmanager provides a service for posting notifications as alerts to Prometheus Alertmanager.
+
+Usage:
+
+	package main
+
+	import (
+	    "context"
+	    "log"
+
+	    "github.com/nikoksr/notify"
+	    "github.com/nikoksr/notify/service/alertmanager"
+	)
+
+	func main() {
+	    alertmanagerService := alertmanager.New("DiskFull")
+
+	    // Post alerts to these Alertmanager instances.
+	    alertmanagerService.AddReceivers("http://alertmanager:9093")
+
+	    // Labels are used by Alertmanager for routing and grouping.
+	    alertmanagerService.AddLabel("severity", "critical")
+	    alertmanagerService.AddLabel("instance", "api-01")
+
+	    // Tell our notifier to use the alertmanager service.
+	    notify.UseServices(alertmanagerService)
+
+	    // Send a test message.
+	    if err := notify.Send(context.Background(), "Disk full", "api-01 has less than 1% disk space left"); err != nil {
+	        log.Fatal(err)
+	    }
+
+	    // Resolve it once the problem is gone.
+	    if err := alertmanagerService.Resolve(context.Background()); err != nil {
+	        log.Fatal(err)
+	    }
+	}
+*/
+package alertmanager
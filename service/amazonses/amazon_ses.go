@@ -4,11 +4,15 @@ import (
 	"context"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/ses"
 	"github.com/aws/aws-sdk-go-v2/service/ses/types"
+	"github.com/aws/smithy-go"
 	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
 )
 
 //go:generate mockery --name=sesClient --output=. --case=underscore --inpackage
@@ -54,8 +58,20 @@ func (a *AmazonSES) AddReceivers(addresses ...string) {
 	a.receiverAddresses = append(a.receiverAddresses, addresses...)
 }
 
+// Validate checks that the sender and receiver addresses parse, see notify.Validator.
+func (a AmazonSES) Validate() error {
+	var problems notify.ConfigErrors
+	problems.Check("sender", notify.ValidateMailAddress(aws.ToString(a.senderAddress)))
+	for _, address := range a.receiverAddresses {
+		problems.Check("receiver", notify.ValidateMailAddress(address))
+	}
+
+	return problems.Err()
+}
+
 // Send takes a message subject and a message body and sends them to all previously set chats. Message body supports
-// html as markup language.
+// html as markup language. The message ID assigned by SES is recorded as receipt for each receiver, see
+// notify.ContextWithReceipts.
 func (a AmazonSES) Send(ctx context.Context, subject, message string) error {
 	input := &ses.SendEmailInput{
 		Source: a.senderAddress,
@@ -77,10 +93,37 @@ func (a AmazonSES) Send(ctx context.Context, subject, message string) error {
 		},
 	}
 
-	_, err := a.client.SendEmail(ctx, input)
+	output, err := a.client.SendEmail(ctx, input)
 	if err != nil {
-		return errors.Wrap(err, "failed to send mail using Amazon SES service")
+		return classifyError(errors.Wrap(err, "failed to send mail using Amazon SES service"))
+	}
+
+	if output != nil && output.MessageId != nil {
+		for _, address := range a.receiverAddresses {
+			notify.RecordReceipt(ctx, notify.Receipt{Service: a, Receiver: address, MessageID: *output.MessageId})
+		}
 	}
 
 	return nil
 }
+
+// classifyError classifies errors returned by the Amazon SES API, see notify.ClassifyError.
+func classifyError(err error) error {
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.ErrorCode() {
+		case "Throttling", "ThrottlingException", "ThrottledException", "RequestThrottled",
+			"TooManyRequestsException", "RequestLimitExceeded":
+			return notify.ClassifyError(err, &notify.ErrRateLimited{})
+		case "MessageRejected", "MailFromDomainNotVerifiedException", "ConfigurationSetDoesNotExistException":
+			return notify.ClassifyError(err, notify.ErrPermanent)
+		}
+	}
+
+	var respErr *awshttp.ResponseError
+	if errors.As(err, &respErr) {
+		return notify.ClassifyStatus(respErr.HTTPStatusCode(), err)
+	}
+
+	return notify.ClassifyNetworkError(err)
+}
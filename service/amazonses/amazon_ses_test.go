@@ -9,6 +9,8 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/ses/types"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
+
+	"github.com/nikoksr/notify"
 )
 
 func TestAmazonSES_New(t *testing.T) {
@@ -41,6 +43,24 @@ func TestAmazonSES_AddReceivers(t *testing.T) {
 	assert.Equal(service.receiverAddresses, receivers)
 }
 
+func TestAmazonSES_Validate(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service, err := New("", "", "", "Ops <ops@example.com>")
+	assert.NoError(err)
+	service.AddReceivers("alice@example.com")
+	assert.NoError(service.Validate())
+
+	service.senderAddress = aws.String("")
+	service.AddReceivers("bob")
+	err = service.Validate()
+	assert.ErrorIs(err, notify.ErrInvalidConfig)
+	assert.ErrorContains(err, "sender")
+	assert.ErrorContains(err, `receiver: mail address "bob"`)
+}
+
 func TestAmazonSES_Send(t *testing.T) {
 	t.Parallel()
 
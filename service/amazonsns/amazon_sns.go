@@ -4,10 +4,14 @@ import (
 	"context"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/sns"
+	"github.com/aws/smithy-go"
 	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
 )
 
 // snsSendMessageAPI Basic interface to send messages through SNS.
@@ -75,8 +79,29 @@ func (s AmazonSNS) Send(ctx context.Context, subject, message string) error {
 		// Send the message
 		_, err := s.sendMessageClient.SendMessage(ctx, input)
 		if err != nil {
-			return errors.Wrapf(err, "failed to send message using Amazon SNS to ARN TOPIC '%s'", topic)
+			return classifyError(errors.Wrapf(err, "failed to send message using Amazon SNS to ARN TOPIC '%s'", topic))
 		}
 	}
 	return nil
 }
+
+// classifyError classifies errors returned by the Amazon SNS API, see notify.ClassifyError.
+func classifyError(err error) error {
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.ErrorCode() {
+		case "Throttling", "ThrottlingException", "ThrottledException", "RequestThrottled",
+			"TooManyRequestsException", "RequestLimitExceeded":
+			return notify.ClassifyError(err, &notify.ErrRateLimited{})
+		case "NotFound", "EndpointDisabled":
+			return notify.ClassifyError(err, notify.ErrInvalidReceiver)
+		}
+	}
+
+	var respErr *awshttp.ResponseError
+	if errors.As(err, &respErr) {
+		return notify.ClassifyStatus(respErr.HTTPStatusCode(), err)
+	}
+
+	return notify.ClassifyNetworkError(err)
+}
@@ -0,0 +1,167 @@
+package amazonsqs
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/smithy-go"
+	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
+)
+
+// DefaultMessageGroupID is the message group ID used for FIFO queues, unless set otherwise.
+const DefaultMessageGroupID = "notify"
+
+// sqsSendMessageAPI Basic interface to send messages through SQS.
+//
+//go:generate mockery --name=sqsSendMessageAPI --output=. --case=underscore --inpackage
+type sqsSendMessageAPI interface {
+	SendMessage(ctx context.Context,
+		params *sqs.SendMessageInput,
+		optFns ...func(*sqs.Options)) (*sqs.SendMessageOutput, error)
+}
+
+// Compile-time check to ensure that AmazonSQS implements the notify.JSONSender interface.
+var _ notify.JSONSender = AmazonSQS{}
+
+// AmazonSQS Basic structure with SQS information
+type AmazonSQS struct {
+	sendMessageClient sqsSendMessageAPI
+	queueURLs         []string
+	messageGroupID    string
+	deduplicationID   string
+}
+
+// payload is the JSON document enqueued for every notification.
+type payload struct {
+	Subject   string    `json:"subject"`
+	Message   string    `json:"message"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// New creates a new AmazonSQS
+func New(accessKeyID, secretKey, region string) (*AmazonSQS, error) {
+	credProvider := credentials.NewStaticCredentialsProvider(accessKeyID, secretKey, "")
+	cfg, err := config.LoadDefaultConfig(
+		context.Background(),
+		config.WithCredentialsProvider(credProvider),
+		config.WithRegion(region),
+	)
+	if err != nil {
+		return nil, err
+	}
+	client := sqs.NewFromConfig(cfg)
+	return &AmazonSQS{
+		sendMessageClient: client,
+		messageGroupID:    DefaultMessageGroupID,
+	}, nil
+}
+
+// AddReceivers takes queue urls and adds them to the internal queue
+// list. The Send method will enqueue a given message to all those
+// queues. Queues with a URL ending in ".fifo" are treated as FIFO queues.
+func (s *AmazonSQS) AddReceivers(queueURLs ...string) {
+	s.queueURLs = append(s.queueURLs, queueURLs...)
+}
+
+// SetMessageGroupID sets the message group ID used for FIFO queues.
+// Messages of the same group are delivered in order.
+func (s *AmazonSQS) SetMessageGroupID(id string) {
+	s.messageGroupID = id
+}
+
+// SetDeduplicationID sets the deduplication ID used for FIFO queues.
+// By default, it's derived from the subject and message, so identical
+// notifications sent within the five-minute deduplication interval
+// are only delivered once.
+func (s *AmazonSQS) SetDeduplicationID(id string) {
+	s.deduplicationID = id
+}
+
+// Send enqueues the message to all queues as JSON document with the
+// fields "subject", "message" and "timestamp".
+func (s AmazonSQS) Send(ctx context.Context, subject, message string) error {
+	if len(s.queueURLs) == 0 {
+		return nil
+	}
+
+	body, err := json.Marshal(&payload{Subject: subject, Message: message, Timestamp: time.Now().UTC()})
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal payload")
+	}
+
+	return s.enqueue(ctx, body, []byte(subject+"\x00"+message))
+}
+
+// SendJSON enqueues the JSON encoding of v to all queues. Unless set, the
+// deduplication ID for FIFO queues is derived from the encoding.
+func (s AmazonSQS) SendJSON(ctx context.Context, v any) error {
+	if len(s.queueURLs) == 0 {
+		return nil
+	}
+
+	body, err := json.Marshal(v)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal payload")
+	}
+
+	return s.enqueue(ctx, body, body)
+}
+
+// enqueue sends the body to all queues. FIFO queues deduplicate by the
+// hash of content, unless a deduplication ID was set.
+func (s AmazonSQS) enqueue(ctx context.Context, body, content []byte) error {
+	deduplicationID := s.deduplicationID
+	if deduplicationID == "" {
+		sum := sha256.Sum256(content)
+		deduplicationID = hex.EncodeToString(sum[:])
+	}
+
+	for _, queueURL := range s.queueURLs {
+		input := &sqs.SendMessageInput{
+			QueueUrl:    aws.String(queueURL),
+			MessageBody: aws.String(string(body)),
+		}
+		if strings.HasSuffix(queueURL, ".fifo") {
+			input.MessageGroupId = aws.String(s.messageGroupID)
+			input.MessageDeduplicationId = aws.String(deduplicationID)
+		}
+
+		_, err := s.sendMessageClient.SendMessage(ctx, input)
+		if err != nil {
+			return classifyError(errors.Wrapf(err, "failed to send message using Amazon SQS to queue '%s'", queueURL))
+		}
+	}
+	return nil
+}
+
+// classifyError classifies errors returned by the Amazon SQS API, see notify.ClassifyError.
+func classifyError(err error) error {
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.ErrorCode() {
+		case "Throttling", "ThrottlingException", "ThrottledException", "RequestThrottled",
+			"TooManyRequestsException", "RequestLimitExceeded":
+			return notify.ClassifyError(err, &notify.ErrRateLimited{})
+		case "AWS.SimpleQueueService.NonExistentQueue", "QueueDoesNotExist":
+			return notify.ClassifyError(err, notify.ErrInvalidReceiver)
+		}
+	}
+
+	var respErr *awshttp.ResponseError
+	if errors.As(err, &respErr) {
+		return notify.ClassifyStatus(respErr.HTTPStatusCode(), err)
+	}
+
+	return notify.ClassifyNetworkError(err)
+}
@@ -0,0 +1,120 @@
+package amazonsqs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAmazonSQS_New(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service, err := New("", "", "")
+	assert.NotNil(service)
+	assert.Nil(err)
+	assert.Equal(DefaultMessageGroupID, service.messageGroupID)
+}
+
+func TestAmazonSQS_SendMessageWithNoQueuesConfigured(t *testing.T) {
+	t.Parallel()
+
+	mockSqs := newMockSqsSendMessageAPI(t)
+	amazonSQS := AmazonSQS{
+		sendMessageClient: mockSqs,
+	}
+
+	err := amazonSQS.Send(context.Background(), "Subject", "Message")
+	require.Nil(t, err)
+}
+
+func TestAmazonSQS_SendMessage(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	var inputs []*sqs.SendMessageInput
+	mockSqs := newMockSqsSendMessageAPI(t)
+	mockSqs.On("SendMessage", mock.Anything, mock.AnythingOfType("*sqs.SendMessageInput")).
+		Run(func(args mock.Arguments) { inputs = append(inputs, args.Get(1).(*sqs.SendMessageInput)) }).
+		Return(&sqs.SendMessageOutput{}, nil)
+
+	amazonSQS := AmazonSQS{
+		sendMessageClient: mockSqs,
+		messageGroupID:    DefaultMessageGroupID,
+	}
+	amazonSQS.AddReceivers(
+		"https://sqs.eu-west-1.amazonaws.com/123456789012/standard",
+		"https://sqs.eu-west-1.amazonaws.com/123456789012/ordered.fifo",
+	)
+	err := amazonSQS.Send(context.Background(), "Subject", "Message")
+	assert.Nil(err)
+	assert.Len(inputs, 2)
+
+	assert.Nil(inputs[0].MessageGroupId)
+	assert.Nil(inputs[0].MessageDeduplicationId)
+	assert.Equal(DefaultMessageGroupID, *inputs[1].MessageGroupId)
+	assert.Len(*inputs[1].MessageDeduplicationId, 64)
+
+	var p payload
+	assert.Nil(json.Unmarshal([]byte(*inputs[0].MessageBody), &p))
+	assert.Equal("Subject", p.Subject)
+	assert.Equal("Message", p.Message)
+
+	// Explicit IDs are used as is
+	inputs = nil
+	amazonSQS.SetMessageGroupID("group")
+	amazonSQS.SetDeduplicationID("dedup")
+	err = amazonSQS.Send(context.Background(), "Subject", "Message")
+	assert.Nil(err)
+	assert.Equal("group", *inputs[1].MessageGroupId)
+	assert.Equal("dedup", *inputs[1].MessageDeduplicationId)
+}
+
+func TestAmazonSQS_SendMessageWithErr(t *testing.T) {
+	t.Parallel()
+
+	mockSqs := newMockSqsSendMessageAPI(t)
+	mockSqs.On("SendMessage", mock.Anything, mock.Anything).
+		Return(nil, errors.New("error on sending message"))
+
+	amazonSQS := AmazonSQS{
+		sendMessageClient: mockSqs,
+	}
+	amazonSQS.AddReceivers("https://sqs.eu-west-1.amazonaws.com/123456789012/standard")
+	err := amazonSQS.Send(context.Background(), "Subject", "Message")
+	require.NotNil(t, err)
+}
+
+func TestAmazonSQS_SendJSON(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	var inputs []*sqs.SendMessageInput
+	mockSqs := newMockSqsSendMessageAPI(t)
+	mockSqs.On("SendMessage", mock.Anything, mock.AnythingOfType("*sqs.SendMessageInput")).
+		Run(func(args mock.Arguments) { inputs = append(inputs, args.Get(1).(*sqs.SendMessageInput)) }).
+		Return(&sqs.SendMessageOutput{}, nil)
+
+	amazonSQS := AmazonSQS{
+		sendMessageClient: mockSqs,
+		messageGroupID:    DefaultMessageGroupID,
+	}
+	amazonSQS.AddReceivers("https://sqs.eu-west-1.amazonaws.com/123456789012/ordered.fifo")
+
+	err := amazonSQS.SendJSON(context.Background(), map[string]any{"service": "api", "version": "1.2.3"})
+	assert.Nil(err)
+	assert.Len(inputs, 1)
+	assert.JSONEq(`{"service":"api","version":"1.2.3"}`, *inputs[0].MessageBody)
+	assert.Len(*inputs[0].MessageDeduplicationId, 64)
+
+	err = amazonSQS.SendJSON(context.Background(), make(chan int))
+	assert.NotNil(err)
+}
@@ -0,0 +1,104 @@
+package amqp
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nikoksr/notify"
+)
+
+func TestAMQP_ExpandRoutingKeys(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := New("amqp://localhost", "notifications")
+	service.AddReceivers("all", "alerts.{priority}", "tags.{priority}.{tag}")
+	assert.NoError(service.SetPriority(5))
+	assert.Equal([]string{"all", "alerts.5"}, service.expandRoutingKeys())
+
+	service.SetTags("db", "disk")
+	assert.Equal([]string{"all", "alerts.5", "tags.5.db", "tags.5.disk"}, service.expandRoutingKeys())
+
+	assert.Error(service.SetPriority(10))
+}
+
+func TestAMQP_Send(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+	ctx := context.Background()
+
+	service := New("amqp://localhost", "notifications")
+
+	// No receivers added
+	err := service.Send(ctx, "subject", "message")
+	assert.NoError(err)
+
+	// Test publishing successfully
+	var published amqp.Publishing
+	mockPublisher := newMockPublisher(t)
+	mockPublisher.
+		On("Publish", ctx, "notifications", "alerts.critical", mock.AnythingOfType("amqp091.Publishing")).
+		Run(func(args mock.Arguments) { published = args.Get(3).(amqp.Publishing) }).
+		Return(nil)
+
+	service.publisher = mockPublisher
+	service.AddReceivers("alerts.{tag}")
+	service.SetTags("critical")
+	assert.NoError(service.SetPriority(9))
+	err = service.Send(ctx, "subject", "message")
+	assert.NoError(err)
+	assert.Equal(uint8(9), published.Priority)
+	assert.Equal(amqp.Persistent, published.DeliveryMode)
+
+	var p payload
+	assert.NoError(json.Unmarshal(published.Body, &p))
+	assert.Equal("subject", p.Subject)
+	assert.Equal([]string{"critical"}, p.Tags)
+
+	// Test publishing with error
+	mockPublisher = newMockPublisher(t)
+	mockPublisher.
+		On("Publish", ctx, "notifications", "alerts.critical", mock.Anything).
+		Return(&amqp.Error{Code: amqp.NotFound, Reason: "no exchange 'notifications'"})
+	mockPublisher.On("Close").Return(nil)
+
+	service.publisher = mockPublisher
+	err = service.Send(ctx, "subject", "message")
+	assert.ErrorIs(err, notify.ErrInvalidReceiver)
+
+	assert.NoError(service.Close())
+	assert.Nil(service.publisher)
+}
+
+func TestAMQP_SendJSON(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+	ctx := context.Background()
+
+	var published amqp.Publishing
+	mockPublisher := newMockPublisher(t)
+	mockPublisher.
+		On("Publish", ctx, "notifications", "deploys", mock.AnythingOfType("amqp091.Publishing")).
+		Run(func(args mock.Arguments) { published = args.Get(3).(amqp.Publishing) }).
+		Return(nil)
+
+	service := New("amqp://localhost", "notifications")
+	service.publisher = mockPublisher
+	service.AddReceivers("deploys")
+
+	err := service.SendJSON(ctx, map[string]any{"service": "api", "version": "1.2.3"})
+	assert.NoError(err)
+	assert.JSONEq(`{"service":"api","version":"1.2.3"}`, string(published.Body))
+	assert.Equal("application/json", published.ContentType)
+
+	err = service.SendJSON(ctx, make(chan int))
+	assert.Error(err)
+}
@@ -0,0 +1,120 @@
+package asana
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
+)
+
+// DefaultAPIURL is the base URL of the Asana API.
+const DefaultAPIURL = "https://app.asana.com/api/1.0"
+
+// Service encapsulates the Asana client.
+type Service struct {
+	client      *http.Client
+	apiURL      string
+	accessToken string
+	projectIDs  []string
+	assignee    string
+}
+
+// New returns a new instance of an Asana notification service, authenticated with a personal access token.
+// For more information about personal access tokens:
+//
+//	-> https://developers.asana.com/docs/personal-access-token
+func New(accessToken string) *Service {
+	return &Service{
+		client: &http.Client{
+			Transport: notify.NewRateLimitTransport(nil),
+			Timeout:   10 * time.Second,
+		},
+		apiURL:      DefaultAPIURL,
+		accessToken: accessToken,
+		projectIDs:  []string{},
+	}
+}
+
+// WithClient sets the http client to be used for sending requests. Calling this method is optional, the default client
+// will be used if this method is not called.
+func (s *Service) WithClient(client *http.Client) {
+	if client != nil {
+		s.client = client
+	}
+}
+
+// AddReceivers takes project GIDs and adds them to the internal receiver list. The Send method creates a new task in
+// each of those projects.
+func (s *Service) AddReceivers(projectIDs ...string) {
+	s.projectIDs = append(s.projectIDs, projectIDs...)
+}
+
+// SetAssignee sets the user created tasks get assigned to, identified by GID, email address or "me".
+func (s *Service) SetAssignee(assignee string) {
+	s.assignee = assignee
+}
+
+type task struct {
+	Name     string   `json:"name"`
+	Notes    string   `json:"notes"`
+	Projects []string `json:"projects"`
+	Assignee string   `json:"assignee,omitempty"`
+}
+
+func (s *Service) createTask(ctx context.Context, t *task) error {
+	payload, err := json.Marshal(map[string]*task{"data": t})
+	if err != nil {
+		return errors.Wrap(err, "marshal task")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.apiURL+"/tasks", bytes.NewReader(payload))
+	if err != nil {
+		return errors.Wrap(err, "create request")
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+s.accessToken)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "send request")
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(resp.Body)
+		return notify.ClassifyResponse(resp, fmt.Errorf("asana returned status code %d: %s", resp.StatusCode, string(body)))
+	}
+
+	return nil
+}
+
+// Send takes a message subject and a message body and creates a task with the subject as name and the body as
+// description in all previously set projects.
+func (s *Service) Send(ctx context.Context, subject, message string) error {
+	for _, projectID := range s.projectIDs {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			t := &task{
+				Name:     subject,
+				Notes:    message,
+				Projects: []string{projectID},
+				Assignee: s.assignee,
+			}
+			if err := s.createTask(ctx, t); err != nil {
+				return errors.Wrapf(err, "failed to create task in project %q", projectID)
+			}
+		}
+	}
+
+	return nil
+}
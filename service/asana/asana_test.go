@@ -0,0 +1,56 @@
+package asana
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAsana_Send(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	var got []task
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/tasks" || r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var body struct {
+			Data task `json:"data"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		got = append(got, body.Data)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	service := New("token")
+	service.apiURL = server.URL
+
+	// No receivers added
+	err := service.Send(context.Background(), "subject", "message")
+	assert.Nil(err)
+	assert.Empty(got)
+
+	service.AddReceivers("1", "2")
+	service.SetAssignee("me")
+	err = service.Send(context.Background(), "subject", "message")
+	assert.Nil(err)
+	assert.Equal([]task{
+		{Name: "subject", Notes: "message", Projects: []string{"1"}, Assignee: "me"},
+		{Name: "subject", Notes: "message", Projects: []string{"2"}, Assignee: "me"},
+	}, got)
+
+	// Test error response
+	service = New("invalid")
+	service.apiURL = server.URL
+	service.AddReceivers("1")
+	err = service.Send(context.Background(), "subject", "message")
+	assert.NotNil(err)
+}
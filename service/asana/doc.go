@@ -0,0 +1,32 @@
+/*
+Package asana provides a service for creating tasks in Asana projects.
+
+Usage:
+
+	package main
+
+	import (
+	    "context"
+	    "log"
+
+	    "github.com/nikoksr/notify"
+	    "github.com/nikoksr/notify/service/asana"
+	)
+
+	func main() {
+	    asanaService := asana.New("your-personal-access-token")
+
+	    // Create tasks in this project and assign them to yourself.
+	    asanaService.AddReceivers("1201234567890123")
+	    asanaService.SetAssignee("me")
+
+	    // Tell our notifier to use the asana service.
+	    notify.UseServices(asanaService)
+
+	    // Send a test message.
+	    if err := notify.Send(context.Background(), "Subject/Title", "The actual message"); err != nil {
+	        log.Fatal(err)
+	    }
+	}
+*/
+package asana
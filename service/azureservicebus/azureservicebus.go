@@ -0,0 +1,211 @@
+package azureservicebus
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/Azure/azure-sdk-for-go/sdk/messaging/azservicebus"
+	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
+)
+
+// messageSender abstracts azservicebus.Sender for writing unit tests.
+//
+//go:generate mockery --name=messageSender --output=. --case=underscore --inpackage
+type messageSender interface {
+	SendMessage(ctx context.Context, message *azservicebus.Message, options *azservicebus.SendMessageOptions) error
+	Close(ctx context.Context) error
+}
+
+// Compile-time check to ensure that azservicebus.Sender implements the messageSender interface.
+var _ messageSender = new(azservicebus.Sender)
+
+// Compile-time check to ensure that Service implements the notify.JSONSender interface.
+var _ notify.JSONSender = new(Service)
+
+// Service encapsulates the Azure Service Bus client.
+type Service struct {
+	client     *azservicebus.Client
+	newSender  func(queueOrTopic string) (messageSender, error)
+	entities   []string
+	properties map[string]any
+
+	mu      sync.Mutex
+	senders map[string]messageSender
+}
+
+func newService(client *azservicebus.Client) *Service {
+	return &Service{
+		client: client,
+		newSender: func(queueOrTopic string) (messageSender, error) {
+			return client.NewSender(queueOrTopic, nil)
+		},
+		entities:   []string{},
+		properties: map[string]any{},
+		senders:    map[string]messageSender{},
+	}
+}
+
+// NewWithConnectionString returns a new instance of an Azure Service Bus notification service authenticated with a
+// connection string of a shared access policy with send rights.
+func NewWithConnectionString(connectionString string) (*Service, error) {
+	client, err := azservicebus.NewClientFromConnectionString(connectionString, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create service bus client")
+	}
+
+	return newService(client), nil
+}
+
+// NewWithManagedIdentity returns a new instance of an Azure Service Bus notification service for the given namespace,
+// e.g. "my-namespace.servicebus.windows.net", authenticated with the managed identity of the hosting environment. Pass
+// the client ID of a user-assigned identity or an empty string for the system-assigned identity. The identity needs
+// the "Azure Service Bus Data Sender" role.
+func NewWithManagedIdentity(namespace, clientID string) (*Service, error) {
+	options := &azidentity.ManagedIdentityCredentialOptions{}
+	if clientID != "" {
+		options.ID = azidentity.ClientID(clientID)
+	}
+
+	credential, err := azidentity.NewManagedIdentityCredential(options)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create managed identity credential")
+	}
+
+	return NewWithCredential(namespace, credential)
+}
+
+// NewWithCredential returns a new instance of an Azure Service Bus notification service for the given namespace,
+// authenticated with the given credential, e.g. one created via azidentity.NewDefaultAzureCredential.
+func NewWithCredential(namespace string, credential azcore.TokenCredential) (*Service, error) {
+	client, err := azservicebus.NewClient(namespace, credential, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create service bus client")
+	}
+
+	return newService(client), nil
+}
+
+// AddReceivers takes queue or topic names and adds them to the internal receiver list. The Send method sends a message
+// to each of them.
+func (s *Service) AddReceivers(queuesOrTopics ...string) {
+	s.entities = append(s.entities, queuesOrTopics...)
+}
+
+// AddApplicationProperty adds an application property that is sent with every message. Topic subscriptions can filter
+// messages by application properties.
+func (s *Service) AddApplicationProperty(key string, value any) {
+	s.properties[key] = value
+}
+
+// sender returns the cached sender for the given queue or topic, creating it first if necessary.
+func (s *Service) sender(queueOrTopic string) (messageSender, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if sender, ok := s.senders[queueOrTopic]; ok {
+		return sender, nil
+	}
+
+	sender, err := s.newSender(queueOrTopic)
+	if err != nil {
+		return nil, err
+	}
+	s.senders[queueOrTopic] = sender
+
+	return sender, nil
+}
+
+// Close closes all senders and the client.
+func (s *Service) Close(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for name, sender := range s.senders {
+		_ = sender.Close(ctx)
+		delete(s.senders, name)
+	}
+	if s.client == nil {
+		return nil
+	}
+
+	return s.client.Close(ctx)
+}
+
+// Send takes a message subject and a message body and sends them to all previously set queues and topics. The subject
+// is set as the message's subject (label) and the body as its content.
+func (s *Service) Send(ctx context.Context, subject, message string) error {
+	return s.send(ctx, func() *azservicebus.Message {
+		return &azservicebus.Message{
+			Subject:     to.Ptr(subject),
+			Body:        []byte(message),
+			ContentType: to.Ptr("text/plain; charset=utf-8"),
+		}
+	})
+}
+
+// SendJSON sends the JSON encoding of v as the content of a message to all previously set queues and topics.
+func (s *Service) SendJSON(ctx context.Context, v any) error {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal payload")
+	}
+
+	return s.send(ctx, func() *azservicebus.Message {
+		return &azservicebus.Message{
+			Body:        body,
+			ContentType: to.Ptr("application/json"),
+		}
+	})
+}
+
+// send sends a message built by newMessage to all previously set queues and topics.
+func (s *Service) send(ctx context.Context, newMessage func() *azservicebus.Message) error {
+	for _, entity := range s.entities {
+		sender, err := s.sender(entity)
+		if err != nil {
+			return classifyError(errors.Wrapf(err, "failed to create sender for %q", entity))
+		}
+
+		msg := newMessage()
+		if len(s.properties) > 0 {
+			msg.ApplicationProperties = s.properties
+		}
+
+		if err = sender.SendMessage(ctx, msg, nil); err != nil {
+			return classifyError(errors.Wrapf(err, "failed to send message to %q", entity))
+		}
+	}
+
+	return nil
+}
+
+// classifyError classifies errors of the Service Bus client, see notify.ClassifyError.
+func classifyError(err error) error {
+	var authErr *azidentity.AuthenticationFailedError
+	if errors.As(err, &authErr) {
+		return notify.ClassifyError(err, notify.ErrAuthFailed)
+	}
+	if errors.Is(err, azservicebus.ErrMessageTooLarge) {
+		return notify.ClassifyError(err, notify.ErrPermanent)
+	}
+
+	var sbErr *azservicebus.Error
+	if !errors.As(err, &sbErr) {
+		return notify.ClassifyNetworkError(err)
+	}
+
+	switch sbErr.Code {
+	case azservicebus.CodeUnauthorizedAccess:
+		return notify.ClassifyError(err, notify.ErrAuthFailed)
+	case azservicebus.CodeConnectionLost, azservicebus.CodeTimeout:
+		return notify.ClassifyError(err, notify.ErrTransient)
+	default:
+		return err
+	}
+}
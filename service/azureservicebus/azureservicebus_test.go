@@ -0,0 +1,111 @@
+package azureservicebus
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/messaging/azservicebus"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nikoksr/notify"
+)
+
+func TestAzureServiceBus_New(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	_, err := NewWithConnectionString("invalid")
+	assert.Error(err)
+
+	service, err := NewWithConnectionString("Endpoint=sb://ns.servicebus.windows.net/;SharedAccessKeyName=send;SharedAccessKey=key")
+	assert.NoError(err)
+	assert.NotNil(service)
+}
+
+func TestAzureServiceBus_Send(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+	ctx := context.Background()
+
+	var sent *azservicebus.Message
+	mockSender := newMockMessageSender(t)
+	mockSender.
+		On("SendMessage", ctx, mock.AnythingOfType("*azservicebus.Message"), (*azservicebus.SendMessageOptions)(nil)).
+		Run(func(args mock.Arguments) { sent = args.Get(1).(*azservicebus.Message) }).
+		Return(nil).
+		Once()
+	mockSender.
+		On("SendMessage", ctx, mock.Anything, mock.Anything).
+		Return(&azservicebus.Error{Code: azservicebus.CodeConnectionLost}).
+		Once()
+
+	created := 0
+	service := newService(nil)
+	service.newSender = func(queueOrTopic string) (messageSender, error) {
+		if queueOrTopic != "alerts" {
+			return nil, errors.New("unknown entity")
+		}
+		created++
+		return mockSender, nil
+	}
+
+	// No receivers added
+	assert.NoError(service.Send(ctx, "subject", "message"))
+
+	service.AddReceivers("alerts")
+	service.AddApplicationProperty("severity", "high")
+	assert.NoError(service.Send(ctx, "subject", "message"))
+	assert.Equal("subject", *sent.Subject)
+	assert.Equal([]byte("message"), sent.Body)
+	assert.Equal(map[string]any{"severity": "high"}, sent.ApplicationProperties)
+
+	// Test error response; the sender is reused
+	assert.ErrorIs(service.Send(ctx, "subject", "message"), notify.ErrTransient)
+	assert.Equal(1, created)
+
+	// Test unknown entity
+	service.AddReceivers("unknown")
+	service.senders = map[string]messageSender{"alerts": &nopSender{}}
+	assert.Error(service.Send(ctx, "subject", "message"))
+}
+
+func TestAzureServiceBus_SendJSON(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+	ctx := context.Background()
+
+	var sent *azservicebus.Message
+	mockSender := newMockMessageSender(t)
+	mockSender.
+		On("SendMessage", ctx, mock.AnythingOfType("*azservicebus.Message"), (*azservicebus.SendMessageOptions)(nil)).
+		Run(func(args mock.Arguments) { sent = args.Get(1).(*azservicebus.Message) }).
+		Return(nil).
+		Once()
+
+	service := newService(nil)
+	service.newSender = func(string) (messageSender, error) { return mockSender, nil }
+	service.AddReceivers("deploys")
+
+	assert.NoError(service.SendJSON(ctx, map[string]any{"service": "api", "version": "1.2.3"}))
+	assert.JSONEq(`{"service": "api", "version": "1.2.3"}`, string(sent.Body))
+	assert.Equal("application/json", *sent.ContentType)
+	assert.Nil(sent.Subject)
+
+	assert.Error(service.SendJSON(ctx, make(chan int)))
+}
+
+// nopSender is a messageSender that does nothing.
+type nopSender struct{}
+
+func (*nopSender) SendMessage(context.Context, *azservicebus.Message, *azservicebus.SendMessageOptions) error {
+	return nil
+}
+
+func (*nopSender) Close(context.Context) error {
+	return nil
+}
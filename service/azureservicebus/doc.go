@@ -0,0 +1,43 @@
+/*
+Package azureservicebus provides a service for sending notifications to Azure Service Bus queues and topics.
+
+Usage:
+
+	package main
+
+	import (
+	    "context"
+	    "log"
+
+	    "github.com/nikoksr/notify"
+	    "github.com/nikoksr/notify/service/azureservicebus"
+	)
+
+	func main() {
+	    ctx := context.Background()
+
+	    // Authenticate with a connection string...
+	    serviceBus, err := azureservicebus.NewWithConnectionString("Endpoint=sb://...")
+	    if err != nil {
+	        log.Fatal(err)
+	    }
+
+	    // ...or with the managed identity of the hosting environment.
+	    // serviceBus, err := azureservicebus.NewWithManagedIdentity("my-namespace.servicebus.windows.net", "")
+
+	    defer func() { _ = serviceBus.Close(ctx) }()
+
+	    // Send to these queues or topics.
+	    serviceBus.AddReceivers("notifications")
+	    serviceBus.AddApplicationProperty("severity", "high")
+
+	    // Tell our notifier to use the service bus service.
+	    notify.UseServices(serviceBus)
+
+	    // Send a test message.
+	    if err := notify.Send(ctx, "Subject/Title", "The actual message"); err != nil {
+	        log.Fatal(err)
+	    }
+	}
+*/
+package azureservicebus
@@ -11,24 +11,35 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
 )
 
 // Service allow you to configure Bark service.
 type Service struct {
-	deviceKey  string
+	deviceKeys []string
 	client     *http.Client
 	serverURLs []string
+
+	group string
+	icon  string
+	sound string
+	url   string
 }
 
 func defaultHTTPClient() *http.Client {
 	return &http.Client{
-		Timeout: 5 * time.Second,
+		Transport: notify.NewRateLimitTransport(nil),
+		Timeout:   5 * time.Second,
 	}
 }
 
 // DefaultServerURL is the default server to use for the bark service.
 const DefaultServerURL = "https://api.day.app/"
 
+// DefaultSound is the notification sound played by default.
+const DefaultSound = "alarm.caf"
+
 // normalizeServerURL normalizes the server URL. It prefixes it with https:// if it's not already and appends a slash
 // if it's not already there. If the serverURL is empty, the DefaultServerURL is used. We're not validating the url here
 // on purpose, we leave that to the http client.
@@ -63,8 +74,9 @@ func (s *Service) AddReceivers(serverURLs ...string) {
 // (https://api.day.app/) if you don't specify any servers.
 func NewWithServers(deviceKey string, serverURLs ...string) *Service {
 	s := &Service{
-		deviceKey: deviceKey,
-		client:    defaultHTTPClient(),
+		deviceKeys: []string{deviceKey},
+		client:     defaultHTTPClient(),
+		sound:      DefaultSound,
 	}
 
 	if len(serverURLs) == 0 {
@@ -83,6 +95,32 @@ func New(deviceKey string) *Service {
 	return NewWithServers(deviceKey)
 }
 
+// AddDeviceKeys adds further device keys. Every message is pushed to all devices on all servers.
+func (s *Service) AddDeviceKeys(deviceKeys ...string) {
+	s.deviceKeys = append(s.deviceKeys, deviceKeys...)
+}
+
+// SetGroup sets the group under which notifications are grouped on the device.
+func (s *Service) SetGroup(group string) {
+	s.group = group
+}
+
+// SetIcon sets the URL of a custom icon shown with notifications. Requires iOS 15 or later.
+func (s *Service) SetIcon(iconURL string) {
+	s.icon = iconURL
+}
+
+// SetSound sets the notification sound, e.g. "minuet.caf". Defaults to DefaultSound; an empty string makes Bark use
+// the sound configured on the device.
+func (s *Service) SetSound(sound string) {
+	s.sound = sound
+}
+
+// SetURL sets a URL that gets opened when the notification is tapped.
+func (s *Service) SetURL(url string) {
+	s.url = url
+}
+
 // postData is the data to send to the bark server.
 type postData struct {
 	DeviceKey string `json:"device_key"`
@@ -92,20 +130,23 @@ type postData struct {
 	Sound     string `json:"sound,omitempty"`
 	Icon      string `json:"icon,omitempty"`
 	Group     string `json:"group,omitempty"`
-	URL       string `json:"pushURL,omitempty"`
+	URL       string `json:"url,omitempty"`
 }
 
-func (s *Service) send(ctx context.Context, serverURL, subject, content string) (err error) {
+func (s *Service) send(ctx context.Context, serverURL, deviceKey, subject, content string) (err error) {
 	if serverURL == "" {
 		return errors.New("server url is empty")
 	}
 
 	// Marshal the message to post
 	message := &postData{
-		DeviceKey: s.deviceKey,
+		DeviceKey: deviceKey,
 		Title:     subject,
 		Body:      content,
-		Sound:     "alarm.caf",
+		Sound:     s.sound,
+		Icon:      s.icon,
+		Group:     s.group,
+		URL:       s.url,
 	}
 
 	messageJSON, err := json.Marshal(message)
@@ -137,12 +178,31 @@ func (s *Service) send(ctx context.Context, serverURL, subject, content string)
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("bark returned status code %d: %s", resp.StatusCode, string(result))
+		return notify.ClassifyResponse(resp, fmt.Errorf("bark returned status code %d: %s", resp.StatusCode, string(result)))
 	}
 
 	return nil
 }
 
+// Validate checks that the server URLs and the icon URL, if set, are well-formed and that no device key is empty, see
+// notify.Validator.
+func (s *Service) Validate() error {
+	var problems notify.ConfigErrors
+	for _, serverURL := range s.serverURLs {
+		problems.Check("server URL", notify.ValidateURL(serverURL))
+	}
+	for _, deviceKey := range s.deviceKeys {
+		if deviceKey == "" {
+			problems.Addf("device key is empty")
+		}
+	}
+	if s.icon != "" {
+		problems.Check("icon URL", notify.ValidateURL(s.icon))
+	}
+
+	return problems.Err()
+}
+
 // Send takes a message subject and a message content and sends them to bark application.
 func (s *Service) Send(ctx context.Context, subject, content string) error {
 	if s.client == nil {
@@ -150,13 +210,15 @@ func (s *Service) Send(ctx context.Context, subject, content string) error {
 	}
 
 	for _, serverURL := range s.serverURLs {
-		select {
-		case <-ctx.Done():
-			return ctx.Err()
-		default:
-			err := s.send(ctx, serverURL, subject, content)
-			if err != nil {
-				return errors.Wrapf(err, "failed to send message to bark server %q", serverURL)
+		for _, deviceKey := range s.deviceKeys {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			default:
+				err := s.send(ctx, serverURL, deviceKey, subject, content)
+				if err != nil {
+					return errors.Wrapf(err, "failed to send message to bark server %q", serverURL)
+				}
 			}
 		}
 	}
@@ -0,0 +1,96 @@
+package bark
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nikoksr/notify"
+)
+
+func TestBark_New(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := New("key")
+	assert.Equal([]string{DefaultServerURL}, service.serverURLs)
+	assert.Equal([]string{"key"}, service.deviceKeys)
+	assert.Equal(DefaultSound, service.sound)
+
+	service = NewWithServers("key", "bark.example.com")
+	assert.Equal([]string{"https://bark.example.com/"}, service.serverURLs)
+}
+
+func TestBark_Validate(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := New("key")
+	service.SetIcon("https://example.com/icon.png")
+	assert.NoError(service.Validate())
+
+	service.AddDeviceKeys("")
+	service.SetIcon("icon.png")
+	err := service.Validate()
+	assert.ErrorIs(err, notify.ErrInvalidConfig)
+	assert.ErrorContains(err, "device key is empty")
+	assert.ErrorContains(err, "icon URL")
+}
+
+func TestBark_Send(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	var got []postData
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var data postData
+		if r.URL.Path != "/push" || json.NewDecoder(r.Body).Decode(&data) != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		got = append(got, data)
+	}))
+	defer server.Close()
+
+	service := NewWithServers("key1", server.URL)
+	service.AddDeviceKeys("key2")
+	service.SetGroup("group")
+	service.SetIcon("https://example.com/icon.png")
+	service.SetSound("minuet.caf")
+	service.SetURL("https://example.com")
+
+	err := service.Send(context.Background(), "subject", "body")
+	assert.Nil(err)
+	assert.Equal([]postData{
+		{
+			DeviceKey: "key1",
+			Title:     "subject",
+			Body:      "body",
+			Sound:     "minuet.caf",
+			Icon:      "https://example.com/icon.png",
+			Group:     "group",
+			URL:       "https://example.com",
+		},
+		{
+			DeviceKey: "key2",
+			Title:     "subject",
+			Body:      "body",
+			Sound:     "minuet.caf",
+			Icon:      "https://example.com/icon.png",
+			Group:     "group",
+			URL:       "https://example.com",
+		},
+	}, got)
+
+	// Test error response
+	service = NewWithServers("key", server.URL+"/invalid")
+	err = service.Send(context.Background(), "subject", "body")
+	assert.NotNil(err)
+}
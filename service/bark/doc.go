@@ -21,6 +21,13 @@ Usage:
 	    // Or use `bark.New` to create a service with the default server.
 	    barkService = bark.New("your bark device key")
 
+	    // Push to further devices and customize the notifications.
+	    barkService.AddDeviceKeys("another bark device key")
+	    barkService.SetGroup("alerts")
+	    barkService.SetIcon("https://example.com/icon.png")
+	    barkService.SetSound("minuet.caf")
+	    barkService.SetURL("https://example.com/dashboard")
+
 	    // Tell our notifier to use the bark service.
 	    notify.UseServices(barkService)
 
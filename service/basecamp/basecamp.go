@@ -0,0 +1,108 @@
+package basecamp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
+)
+
+// Service encapsulates the Basecamp chatbot client.
+type Service struct {
+	client   *http.Client
+	lineURLs []string
+}
+
+// New returns a new instance of a Basecamp notification service. Receivers are the lines URLs of chatbots added to a
+// Campfire, e.g. https://3.basecamp.com/1/integrations/abc/buckets/2/chats/3/lines.
+// For more information about Basecamp chatbots:
+//
+//	-> https://github.com/basecamp/bc3-api/blob/master/sections/chatbots.md
+func New() *Service {
+	return &Service{
+		client: &http.Client{
+			Transport: notify.NewRateLimitTransport(nil),
+			Timeout:   10 * time.Second,
+		},
+		lineURLs: []string{},
+	}
+}
+
+// WithClient sets the http client to be used for sending requests. Calling this method is optional, the default client
+// will be used if this method is not called.
+func (s *Service) WithClient(client *http.Client) {
+	if client != nil {
+		s.client = client
+	}
+}
+
+// AddReceivers takes chatbot lines URLs and adds them to the internal URL list. The Send method will post a line to
+// every one of those Campfires.
+func (s *Service) AddReceivers(lineURLs ...string) {
+	s.lineURLs = append(s.lineURLs, lineURLs...)
+}
+
+type line struct {
+	Content string `json:"content"`
+}
+
+// formatContent escapes subject and message and joins them into the rich text supported by Campfire lines.
+func formatContent(subject, message string) string {
+	message = strings.ReplaceAll(html.EscapeString(message), "\n", "<br>")
+	if subject == "" {
+		return message
+	}
+
+	return fmt.Sprintf("<strong>%s</strong><br>%s", html.EscapeString(subject), message)
+}
+
+// Send takes a message subject and a message body and posts them as a line to all previously set Campfires.
+func (s *Service) Send(ctx context.Context, subject, message string) error {
+	body, err := json.Marshal(line{Content: formatContent(subject, message)})
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal line")
+	}
+
+	for _, lineURL := range s.lineURLs {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			if err = s.post(ctx, lineURL, body); err != nil {
+				return errors.Wrap(err, "failed to send line to Basecamp")
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) post(ctx context.Context, lineURL string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, lineURL, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "create request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "send request")
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusCreated {
+		result, _ := io.ReadAll(resp.Body)
+		return notify.ClassifyResponse(resp, fmt.Errorf("basecamp returned status code %d: %s", resp.StatusCode, string(result)))
+	}
+
+	return nil
+}
@@ -0,0 +1,44 @@
+package basecamp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBasecamp_Send(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	var got []line
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/1/integrations/abc/buckets/2/chats/3/lines" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var l line
+		_ = json.NewDecoder(r.Body).Decode(&l)
+		got = append(got, l)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	service := New()
+	service.AddReceivers(server.URL + "/1/integrations/abc/buckets/2/chats/3/lines")
+
+	assert.NoError(service.Send(context.Background(), "Deploy <prod>", "line 1\nline 2"))
+	assert.NoError(service.Send(context.Background(), "", "message"))
+	assert.Equal([]line{
+		{Content: "<strong>Deploy &lt;prod&gt;</strong><br>line 1<br>line 2"},
+		{Content: "message"},
+	}, got)
+
+	// Test error response
+	service.AddReceivers(server.URL + "/invalid")
+	assert.Error(service.Send(context.Background(), "subject", "message"))
+}
@@ -0,0 +1,31 @@
+/*
+Package basecamp provides a service for posting lines to Basecamp Campfire chats through chatbots.
+
+Usage:
+
+	package main
+
+	import (
+	    "context"
+	    "log"
+
+	    "github.com/nikoksr/notify"
+	    "github.com/nikoksr/notify/service/basecamp"
+	)
+
+	func main() {
+	    basecampService := basecamp.New()
+
+	    // Add the lines URLs of your chatbots.
+	    basecampService.AddReceivers("https://3.basecamp.com/1/integrations/abc/buckets/2/chats/3/lines")
+
+	    // Tell our notifier to use the basecamp service.
+	    notify.UseServices(basecampService)
+
+	    // Send a test message.
+	    if err := notify.Send(context.Background(), "Subject/Title", "The actual message - Hello, you awesome gophers! :)"); err != nil {
+	        log.Fatal(err)
+	    }
+	}
+*/
+package basecamp
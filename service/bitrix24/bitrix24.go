@@ -0,0 +1,130 @@
+package bitrix24
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
+)
+
+// Service encapsulates the Bitrix24 incoming webhook client.
+type Service struct {
+	client     *http.Client
+	webhookURL string
+	dialogIDs  []string
+}
+
+// New returns a new instance of a Bitrix24 notification service. The webhook URL is the URL of an inbound webhook with
+// access to the "im" scope, e.g. https://example.bitrix24.com/rest/1/abcdef/.
+// For more information about im.message.add:
+//
+//	-> https://training.bitrix24.com/rest_help/im/messages/im_message_add.php
+func New(webhookURL string) *Service {
+	return &Service{
+		client: &http.Client{
+			Transport: notify.NewRateLimitTransport(nil),
+			Timeout:   10 * time.Second,
+		},
+		webhookURL: strings.TrimSuffix(webhookURL, "/"),
+		dialogIDs:  []string{},
+	}
+}
+
+// WithClient sets the http client to be used for sending requests. Calling this method is optional, the default client
+// will be used if this method is not called.
+func (s *Service) WithClient(client *http.Client) {
+	if client != nil {
+		s.client = client
+	}
+}
+
+// AddReceivers takes Bitrix24 user IDs and adds them to the internal dialog list. The Send method will send a private
+// message to every one of those users.
+func (s *Service) AddReceivers(userIDs ...int) {
+	for _, userID := range userIDs {
+		s.dialogIDs = append(s.dialogIDs, strconv.Itoa(userID))
+	}
+}
+
+// AddChats takes Bitrix24 chat IDs and adds them to the internal dialog list. The Send method will send a message to
+// every one of those chats.
+func (s *Service) AddChats(chatIDs ...int) {
+	for _, chatID := range chatIDs {
+		s.dialogIDs = append(s.dialogIDs, "chat"+strconv.Itoa(chatID))
+	}
+}
+
+type messageRequest struct {
+	DialogID string `json:"DIALOG_ID"`
+	Message  string `json:"MESSAGE"`
+}
+
+type messageResponse struct {
+	Result           any    `json:"result"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// Send takes a message subject and a message body and sends them to all previously set users and chats. The subject
+// is rendered in bold using BB code.
+func (s *Service) Send(ctx context.Context, subject, message string) error {
+	text := message
+	if subject != "" {
+		text = fmt.Sprintf("[B]%s[/B]\n%s", subject, message)
+	}
+
+	for _, dialogID := range s.dialogIDs {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			if err := s.send(ctx, messageRequest{DialogID: dialogID, Message: text}); err != nil {
+				return errors.Wrapf(err, "failed to send message to Bitrix24 dialog '%s'", dialogID)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) send(ctx context.Context, payload messageRequest) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return errors.Wrap(err, "marshal message")
+	}
+
+	endpoint := s.webhookURL + "/im.message.add.json"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "create request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "send request")
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	var result messageResponse
+	if err = json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return errors.Wrapf(err, "decode response with status code %d", resp.StatusCode)
+	}
+
+	if result.Error != "" {
+		return fmt.Errorf("bitrix24 returned error %s: %s", result.Error, result.ErrorDescription)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return notify.ClassifyResponse(resp, fmt.Errorf("bitrix24 returned status code %d", resp.StatusCode))
+	}
+
+	return nil
+}
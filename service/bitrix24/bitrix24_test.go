@@ -0,0 +1,48 @@
+package bitrix24
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBitrix24_Send(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	var got []messageRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/1/secret/im.message.add.json" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error":"INVALID_CREDENTIALS","error_description":"Invalid request credentials"}`))
+			return
+		}
+		var m messageRequest
+		_ = json.NewDecoder(r.Body).Decode(&m)
+		got = append(got, m)
+		_, _ = w.Write([]byte(`{"result":42}`))
+	}))
+	defer server.Close()
+
+	service := New(server.URL + "/rest/1/secret/")
+	service.AddReceivers(1)
+	service.AddChats(7)
+
+	assert.NoError(service.Send(context.Background(), "subject", "message"))
+	assert.Equal([]messageRequest{
+		{DialogID: "1", Message: "[B]subject[/B]\nmessage"},
+		{DialogID: "chat7", Message: "[B]subject[/B]\nmessage"},
+	}, got)
+
+	// Test error response
+	service = New(server.URL + "/rest/1/invalid/")
+	service.AddReceivers(1)
+	err := service.Send(context.Background(), "subject", "message")
+	assert.Error(err)
+	assert.Contains(err.Error(), "INVALID_CREDENTIALS")
+}
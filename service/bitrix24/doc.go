@@ -0,0 +1,32 @@
+/*
+Package bitrix24 provides a service for sending messages to Bitrix24 users and chats through an inbound webhook.
+
+Usage:
+
+	package main
+
+	import (
+	    "context"
+	    "log"
+
+	    "github.com/nikoksr/notify"
+	    "github.com/nikoksr/notify/service/bitrix24"
+	)
+
+	func main() {
+	    bitrixService := bitrix24.New("https://example.bitrix24.com/rest/1/your-webhook-code/")
+
+	    // Send private messages to users and messages to chats.
+	    bitrixService.AddReceivers(1, 2)
+	    bitrixService.AddChats(42)
+
+	    // Tell our notifier to use the bitrix24 service.
+	    notify.UseServices(bitrixService)
+
+	    // Send a test message.
+	    if err := notify.Send(context.Background(), "Subject/Title", "The actual message - Hello, you awesome gophers! :)"); err != nil {
+	        log.Fatal(err)
+	    }
+	}
+*/
+package bitrix24
@@ -0,0 +1,185 @@
+package bluesky
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
+)
+
+// DefaultServiceURL is the URL of the Bluesky PDS (personal data server) hosting most accounts.
+const DefaultServiceURL = "https://bsky.social"
+
+// maxPostLength is the maximum number of characters a post may have.
+const maxPostLength = 300
+
+// linkPattern matches http(s) links in the post text.
+var linkPattern = regexp.MustCompile(`https?://[^\s<>"]+`)
+
+// Service encapsulates the Bluesky client.
+type Service struct {
+	client      *http.Client
+	serviceURL  string
+	handle      string
+	appPassword string
+}
+
+// New returns a new instance of a Bluesky notification service that posts to the account identified by the given
+// handle, e.g. "alice.bsky.social". Use an app password instead of the account password; it can be created in the
+// account's privacy and security settings.
+// For more information about posting via the API:
+//
+//	-> https://docs.bsky.app/docs/advanced-guides/posts
+func New(handle, appPassword string) *Service {
+	return &Service{
+		client: &http.Client{
+			Transport: notify.NewRateLimitTransport(nil),
+			Timeout:   10 * time.Second,
+		},
+		serviceURL:  DefaultServiceURL,
+		handle:      handle,
+		appPassword: appPassword,
+	}
+}
+
+// WithClient sets the http client to be used for sending requests. Calling this method is optional, the default client
+// will be used if this method is not called.
+func (s *Service) WithClient(client *http.Client) {
+	if client != nil {
+		s.client = client
+	}
+}
+
+// WithServiceURL sets the URL of the PDS hosting the account. Only required for self-hosted accounts.
+func (s *Service) WithServiceURL(serviceURL string) {
+	s.serviceURL = strings.TrimSuffix(serviceURL, "/")
+}
+
+type (
+	session struct {
+		AccessJwt string `json:"accessJwt"`
+		DID       string `json:"did"`
+	}
+
+	byteSlice struct {
+		ByteStart int `json:"byteStart"`
+		ByteEnd   int `json:"byteEnd"`
+	}
+
+	feature struct {
+		Type string `json:"$type"`
+		URI  string `json:"uri"`
+	}
+
+	facet struct {
+		Index    byteSlice `json:"index"`
+		Features []feature `json:"features"`
+	}
+
+	post struct {
+		Type      string  `json:"$type"`
+		Text      string  `json:"text"`
+		CreatedAt string  `json:"createdAt"`
+		Facets    []facet `json:"facets,omitempty"`
+	}
+
+	createRecordRequest struct {
+		Repo       string `json:"repo"`
+		Collection string `json:"collection"`
+		Record     post   `json:"record"`
+	}
+)
+
+// detectFacets returns link facets for all links in the text. Facets index the UTF-8 encoded text by bytes.
+func detectFacets(text string) []facet {
+	var facets []facet
+	for _, loc := range linkPattern.FindAllStringIndex(text, -1) {
+		uri := strings.TrimRight(text[loc[0]:loc[1]], ".,;:!?)")
+		facets = append(facets, facet{
+			Index:    byteSlice{ByteStart: loc[0], ByteEnd: loc[0] + len(uri)},
+			Features: []feature{{Type: "app.bsky.richtext.facet#link", URI: uri}},
+		})
+	}
+
+	return facets
+}
+
+// truncate shortens the text to the maximum post length.
+func truncate(text string) string {
+	runes := []rune(text)
+	if len(runes) <= maxPostLength {
+		return text
+	}
+
+	return string(runes[:maxPostLength-1]) + "…"
+}
+
+// xrpc calls the given XRPC procedure and decodes the response into out, if not nil.
+func (s *Service) xrpc(ctx context.Context, method, accessJwt string, in, out any) error {
+	payload, err := json.Marshal(in)
+	if err != nil {
+		return errors.Wrap(err, "marshal request")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.serviceURL+"/xrpc/"+method, bytes.NewReader(payload))
+	if err != nil {
+		return errors.Wrap(err, "create request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if accessJwt != "" {
+		req.Header.Set("Authorization", "Bearer "+accessJwt)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "send request")
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Wrap(err, "read response")
+	}
+	if resp.StatusCode != http.StatusOK {
+		return notify.ClassifyResponse(resp, fmt.Errorf("bluesky returned status code %d: %s", resp.StatusCode, string(body)))
+	}
+
+	if out != nil {
+		return errors.Wrap(json.Unmarshal(body, out), "decode response")
+	}
+
+	return nil
+}
+
+// Send takes a message subject and a message body and posts them to the account. Posts longer than 300 characters get
+// truncated; links are turned into clickable facets.
+func (s *Service) Send(ctx context.Context, subject, message string) error {
+	var sess session
+	credentials := map[string]string{"identifier": s.handle, "password": s.appPassword}
+	if err := s.xrpc(ctx, "com.atproto.server.createSession", "", credentials, &sess); err != nil {
+		return errors.Wrap(err, "failed to create session")
+	}
+
+	text := truncate(subject + "\n\n" + message)
+	request := &createRecordRequest{
+		Repo:       sess.DID,
+		Collection: "app.bsky.feed.post",
+		Record: post{
+			Type:      "app.bsky.feed.post",
+			Text:      text,
+			CreatedAt: time.Now().UTC().Format(time.RFC3339),
+			Facets:    detectFacets(text),
+		},
+	}
+
+	return errors.Wrap(s.xrpc(ctx, "com.atproto.repo.createRecord", sess.AccessJwt, request, nil), "failed to create post")
+}
@@ -0,0 +1,75 @@
+package bluesky
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBluesky_DetectFacets(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	text := "Ünïcode first: https://example.com/a?b=c, then http://example.org."
+	facets := detectFacets(text)
+	assert.Len(facets, 2)
+	assert.Equal("https://example.com/a?b=c", facets[0].Features[0].URI)
+	assert.Equal("https://example.com/a?b=c", text[facets[0].Index.ByteStart:facets[0].Index.ByteEnd])
+	assert.Equal("http://example.org", text[facets[1].Index.ByteStart:facets[1].Index.ByteEnd])
+
+	assert.Empty(detectFacets("no links"))
+}
+
+func TestBluesky_Send(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	var got createRecordRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/xrpc/com.atproto.server.createSession":
+			var body map[string]string
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			if body["password"] != "password" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			_, _ = w.Write([]byte(`{"accessJwt":"jwt","did":"did:plc:alice"}`))
+		case "/xrpc/com.atproto.repo.createRecord":
+			if r.Header.Get("Authorization") != "Bearer jwt" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			_ = json.NewDecoder(r.Body).Decode(&got)
+			_, _ = w.Write([]byte(`{"uri":"at://did:plc:alice/app.bsky.feed.post/1"}`))
+		}
+	}))
+	defer server.Close()
+
+	service := New("alice.bsky.social", "password")
+	service.WithServiceURL(server.URL + "/")
+
+	err := service.Send(context.Background(), "subject", "see https://example.com")
+	assert.Nil(err)
+	assert.Equal("did:plc:alice", got.Repo)
+	assert.Equal("app.bsky.feed.post", got.Collection)
+	assert.Equal("subject\n\nsee https://example.com", got.Record.Text)
+	assert.Len(got.Record.Facets, 1)
+
+	err = service.Send(context.Background(), "subject", strings.Repeat("a", 400))
+	assert.Nil(err)
+	assert.Len([]rune(got.Record.Text), maxPostLength)
+
+	// Test invalid credentials
+	service = New("alice.bsky.social", "invalid")
+	service.WithServiceURL(server.URL)
+	err = service.Send(context.Background(), "subject", "message")
+	assert.NotNil(err)
+}
@@ -0,0 +1,29 @@
+/*
+Package bluesky provides a service for posting messages to a Bluesky account.
+
+Usage:
+
+	package main
+
+	import (
+	    "context"
+	    "log"
+
+	    "github.com/nikoksr/notify"
+	    "github.com/nikoksr/notify/service/bluesky"
+	)
+
+	func main() {
+	    // Use an app password, not the account password.
+	    blueskyService := bluesky.New("alice.bsky.social", "xxxx-xxxx-xxxx-xxxx")
+
+	    // Tell our notifier to use the bluesky service.
+	    notify.UseServices(blueskyService)
+
+	    // Send a test message. Links in the message become clickable.
+	    if err := notify.Send(context.Background(), "Subject/Title", "Details: https://example.com"); err != nil {
+	        log.Fatal(err)
+	    }
+	}
+*/
+package bluesky
@@ -0,0 +1,166 @@
+package chanify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
+)
+
+// DefaultServerURL is the URL of the public Chanify node server.
+const DefaultServerURL = "https://api.chanify.net"
+
+// InterruptionLevel controls how a notification is presented on iOS.
+type InterruptionLevel string
+
+// Interruption levels supported by Chanify.
+const (
+	InterruptionActive        InterruptionLevel = "active"
+	InterruptionPassive       InterruptionLevel = "passive"
+	InterruptionTimeSensitive InterruptionLevel = "time-sensitive"
+)
+
+// Service encapsulates the Chanify client.
+type Service struct {
+	client            *http.Client
+	serverURL         string
+	sound             bool
+	priority          int
+	interruptionLevel InterruptionLevel
+	tokens            []string
+}
+
+// New returns a new instance of a Chanify notification service using the public node server.
+// For more information about the Chanify API:
+//
+//	-> https://github.com/chanify/chanify#http-api
+func New() *Service {
+	return &Service{
+		client: &http.Client{
+			Transport: notify.NewRateLimitTransport(nil),
+			Timeout:   10 * time.Second,
+		},
+		serverURL: DefaultServerURL,
+		tokens:    []string{},
+	}
+}
+
+// WithClient sets the http client to be used for sending requests. Calling this method is optional, the default client
+// will be used if this method is not called.
+func (s *Service) WithClient(client *http.Client) {
+	if client != nil {
+		s.client = client
+	}
+}
+
+// SetServerURL sets the URL of a self-hosted node server. Defaults to DefaultServerURL.
+func (s *Service) SetServerURL(serverURL string) {
+	s.serverURL = strings.TrimSuffix(serverURL, "/")
+}
+
+// AddReceivers takes tokens and adds them to the internal token list. The Send method will send a given message with
+// every one of those tokens.
+func (s *Service) AddReceivers(tokens ...string) {
+	s.tokens = append(s.tokens, tokens...)
+}
+
+// SetSound enables the notification sound.
+func (s *Service) SetSound(sound bool) {
+	s.sound = sound
+}
+
+// SetPriority sets the priority of notifications, from 0 to 10. Defaults to 0, which leaves it to the server.
+func (s *Service) SetPriority(priority int) error {
+	if priority < 0 || priority > 10 {
+		return fmt.Errorf("invalid priority %d: must be between 0 and 10", priority)
+	}
+	s.priority = priority
+
+	return nil
+}
+
+// SetInterruptionLevel sets how notifications are presented on iOS.
+func (s *Service) SetInterruptionLevel(level InterruptionLevel) {
+	s.interruptionLevel = level
+}
+
+type notification struct {
+	Title             string            `json:"title,omitempty"`
+	Text              string            `json:"text"`
+	Sound             int               `json:"sound,omitempty"`
+	Priority          int               `json:"priority,omitempty"`
+	InterruptionLevel InterruptionLevel `json:"interruption-level,omitempty"`
+}
+
+// Validate checks that the server URL is well-formed and that no token is empty, see notify.Validator.
+func (s *Service) Validate() error {
+	var problems notify.ConfigErrors
+	problems.Check("server URL", notify.ValidateURL(s.serverURL))
+	for _, token := range s.tokens {
+		if token == "" {
+			problems.Addf("token is empty")
+		}
+	}
+
+	return problems.Err()
+}
+
+// Send takes a message subject and a message body and sends them with all previously set tokens.
+func (s *Service) Send(ctx context.Context, subject, message string) error {
+	n := notification{
+		Title:             subject,
+		Text:              message,
+		Priority:          s.priority,
+		InterruptionLevel: s.interruptionLevel,
+	}
+	if s.sound {
+		n.Sound = 1
+	}
+
+	body, err := json.Marshal(n)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal notification")
+	}
+
+	for _, token := range s.tokens {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			if err = s.post(ctx, token, body); err != nil {
+				return errors.Wrap(err, "failed to send notification")
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) post(ctx context.Context, token string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.serverURL+"/v1/sender/"+token, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "create request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "send request")
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		result, _ := io.ReadAll(resp.Body)
+		return notify.ClassifyResponse(resp, fmt.Errorf("chanify returned status code %d: %s", resp.StatusCode, string(result)))
+	}
+
+	return nil
+}
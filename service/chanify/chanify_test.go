@@ -0,0 +1,73 @@
+package chanify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nikoksr/notify"
+)
+
+func TestChanify_Send(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	got := map[string]notification{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/sender/invalid" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"res":401,"msg":"invalid token"}`))
+			return
+		}
+		var n notification
+		_ = json.NewDecoder(r.Body).Decode(&n)
+		got[r.URL.Path] = n
+		_, _ = w.Write([]byte(`{"request-uid":"1"}`))
+	}))
+	defer server.Close()
+
+	service := New()
+	assert.Equal(DefaultServerURL, service.serverURL)
+	service.SetServerURL(server.URL + "/")
+	service.AddReceivers("a", "b")
+	service.SetSound(true)
+	assert.Error(service.SetPriority(11))
+	assert.NoError(service.SetPriority(10))
+	service.SetInterruptionLevel(InterruptionTimeSensitive)
+
+	assert.NoError(service.Send(context.Background(), "subject", "message"))
+	want := notification{
+		Title:             "subject",
+		Text:              "message",
+		Sound:             1,
+		Priority:          10,
+		InterruptionLevel: InterruptionTimeSensitive,
+	}
+	assert.Equal(map[string]notification{"/v1/sender/a": want, "/v1/sender/b": want}, got)
+
+	// Test error response
+	service.AddReceivers("invalid")
+	assert.ErrorContains(service.Send(context.Background(), "subject", "message"), "invalid token")
+}
+
+func TestChanify_Validate(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := New()
+	service.AddReceivers("token")
+	assert.NoError(service.Validate())
+
+	service.SetServerURL("chanify.example.com")
+	service.AddReceivers("")
+	err := service.Validate()
+	assert.ErrorIs(err, notify.ErrInvalidConfig)
+	assert.ErrorContains(err, "server URL")
+	assert.ErrorContains(err, "token is empty")
+}
@@ -0,0 +1,38 @@
+/*
+Package chanify provides a service for sending push notifications via Chanify.
+
+Usage:
+
+	package main
+
+	import (
+	    "context"
+	    "log"
+
+	    "github.com/nikoksr/notify"
+	    "github.com/nikoksr/notify/service/chanify"
+	)
+
+	func main() {
+	    chanifyService := chanify.New()
+
+	    // Use a self-hosted node server.
+	    chanifyService.SetServerURL("https://chanify.example.com")
+
+	    // Send with these tokens.
+	    chanifyService.AddReceivers("your-token")
+	    chanifyService.SetSound(true)
+	    if err := chanifyService.SetPriority(10); err != nil {
+	        log.Fatal(err)
+	    }
+
+	    // Tell our notifier to use the chanify service.
+	    notify.UseServices(chanifyService)
+
+	    // Send a test message.
+	    if err := notify.Send(context.Background(), "Subject/Title", "The actual message"); err != nil {
+	        log.Fatal(err)
+	    }
+	}
+*/
+package chanify
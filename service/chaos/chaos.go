@@ -0,0 +1,195 @@
+package chaos
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
+)
+
+// DefaultTimeout is the time sends that time out hang for, see SetTimeoutRate.
+const DefaultTimeout = 30 * time.Second
+
+// ErrTimeout signals that a send timed out, see SetTimeoutRate. It is a transient error.
+var ErrTimeout = notify.ClassifyError(errors.New("chaos: timeout"), notify.ErrTransient)
+
+// Service is a notification service that sends nothing anywhere, but fails, hangs and slows down on purpose, to test
+// how applications retry, fail over and back off. Failures are random, but reproducible: services created with the
+// same seed and configuration fail the same sends. It is safe for concurrent use.
+type Service struct {
+	mu sync.Mutex
+
+	random      *rand.Rand
+	failureRate float64
+	errs        []error
+	timeoutRate float64
+	timeout     time.Duration
+	latency     time.Duration
+	jitter      time.Duration
+	script      []error
+
+	sends     int
+	delivered []notify.Message
+}
+
+// New returns a new instance of a chaos notification service whose random failures are determined by the given seed.
+// Without further configuration it delivers every notification.
+func New(seed int64) *Service {
+	return &Service{
+		random:  rand.New(rand.NewSource(seed)), //nolint:gosec // Failures are random, not secret.
+		errs:    []error{notify.ErrTransient},
+		timeout: DefaultTimeout,
+	}
+}
+
+// SetFailureRate sets the share of sends that fail, from 0, the default, to 1 for all sends. Failing sends return
+// one of the errors set by SetErrors.
+func (s *Service) SetFailureRate(rate float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.failureRate = rate
+}
+
+// SetErrors sets the error classes failing sends return, picked at random, e.g. notify.ErrTransient, the default,
+// notify.ErrAuthFailed or &notify.ErrRateLimited{RetryAfter: time.Second}. Returned errors match their class with
+// errors.Is and errors.As.
+func (s *Service) SetErrors(errs ...error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.errs = errs
+}
+
+// SetTimeoutRate sets the share of sends that time out, from 0, the default, to 1. Sends that time out hang for the
+// given timeout, zero for DefaultTimeout, and return ErrTimeout. They return early with the error of the context if it
+// is done first.
+func (s *Service) SetTimeoutRate(rate float64, timeout time.Duration) {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.timeoutRate = rate
+	s.timeout = timeout
+}
+
+// SetLatency sets the time every send takes, plus a random share of jitter. Sends return early with the error of the
+// context if it is done first.
+func (s *Service) SetLatency(latency, jitter time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.latency = latency
+	s.jitter = jitter
+}
+
+// FailNext makes the next sends return the given errors in order, before the failure and timeout rates apply again,
+// e.g. FailNext(notify.ErrTransient, notify.ErrTransient, nil) fails two sends and delivers the third. A nil error
+// delivers the notification; ErrTimeout times out like SetTimeoutRate.
+func (s *Service) FailNext(errs ...error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.script = append(s.script, errs...)
+}
+
+// Sends returns the number of sends so far, including failed ones.
+func (s *Service) Sends() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.sends
+}
+
+// Delivered returns the notifications that were delivered, i.e. whose sends didn't fail, in order.
+func (s *Service) Delivered() []notify.Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]notify.Message(nil), s.delivered...)
+}
+
+// outcome decides how a send behaves: how long it takes, whether it times out and with which error it fails, if any.
+func (s *Service) outcome() (latency time.Duration, timeout time.Duration, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sends++
+
+	latency = s.latency
+	if s.jitter > 0 {
+		latency += time.Duration(s.random.Int63n(int64(s.jitter)))
+	}
+
+	if len(s.script) > 0 {
+		err, s.script = s.script[0], s.script[1:]
+		if errors.Is(err, ErrTimeout) {
+			return latency, s.timeout, nil
+		}
+
+		return latency, 0, err
+	}
+
+	// Always roll both, so that changing one rate doesn't change which sends the other one hits.
+	timedOut := s.random.Float64() < s.timeoutRate
+	failed := s.random.Float64() < s.failureRate
+	switch {
+	case timedOut:
+		return latency, s.timeout, nil
+	case failed && len(s.errs) > 0:
+		return latency, 0, s.errs[s.random.Intn(len(s.errs))]
+	default:
+		return latency, 0, nil
+	}
+}
+
+// wait waits for the given duration or until the context is done.
+func wait(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// Send takes a message subject and a message body and, after the configured latency, fails, times out or records them
+// as delivered, see Delivered.
+func (s *Service) Send(ctx context.Context, subject, message string) error {
+	latency, timeout, injected := s.outcome()
+
+	if err := wait(ctx, latency); err != nil {
+		return errors.Wrap(err, "chaos: wait for latency")
+	}
+	if timeout > 0 {
+		if err := wait(ctx, timeout); err != nil {
+			return errors.Wrap(err, "chaos: wait for timeout")
+		}
+
+		return ErrTimeout
+	}
+	if injected != nil {
+		return notify.ClassifyError(errors.New("chaos: injected failure"), injected)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.delivered = append(s.delivered, notify.Message{Subject: subject, Body: message})
+
+	return nil
+}
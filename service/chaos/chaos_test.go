@@ -0,0 +1,138 @@
+package chaos
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nikoksr/notify"
+)
+
+// outcomes sends n notifications and returns which of them failed.
+func outcomes(service *Service, n int) []bool {
+	failed := make([]bool, n)
+	for i := range failed {
+		failed[i] = service.Send(context.Background(), "subject", "message") != nil
+	}
+
+	return failed
+}
+
+func TestChaos_Send(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := New(1)
+	assert.NoError(service.Send(context.Background(), "subject", "message"))
+	assert.Equal(1, service.Sends())
+	assert.Equal([]notify.Message{{Subject: "subject", Body: "message"}}, service.Delivered())
+
+	service.SetFailureRate(1)
+	err := service.Send(context.Background(), "subject", "message")
+	assert.ErrorIs(err, notify.ErrTransient)
+	assert.Equal(2, service.Sends())
+	assert.Len(service.Delivered(), 1)
+}
+
+func TestChaos_SetFailureRate(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	newService := func(seed int64) *Service {
+		service := New(seed)
+		service.SetFailureRate(0.3)
+		return service
+	}
+
+	// The same seed fails the same sends.
+	failed := outcomes(newService(42), 1000)
+	assert.Equal(failed, outcomes(newService(42), 1000))
+	assert.NotEqual(failed, outcomes(newService(7), 1000))
+
+	count := 0
+	for _, f := range failed {
+		if f {
+			count++
+		}
+	}
+	assert.InDelta(300, count, 50)
+}
+
+func TestChaos_SetErrors(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := New(1)
+	service.SetFailureRate(1)
+	service.SetErrors(notify.ErrAuthFailed)
+	err := service.Send(context.Background(), "subject", "message")
+	assert.ErrorIs(err, notify.ErrAuthFailed)
+	assert.ErrorIs(err, notify.ErrPermanent)
+
+	service.SetErrors(&notify.ErrRateLimited{RetryAfter: time.Minute})
+	err = service.Send(context.Background(), "subject", "message")
+	assert.ErrorIs(err, notify.ErrTransient)
+	var rateLimited *notify.ErrRateLimited
+	assert.True(errors.As(err, &rateLimited))
+	assert.Equal(time.Minute, rateLimited.RetryAfter)
+}
+
+func TestChaos_FailNext(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := New(1)
+	service.SetTimeoutRate(0, time.Millisecond)
+	service.FailNext(notify.ErrInvalidReceiver, nil, ErrTimeout)
+
+	assert.ErrorIs(service.Send(context.Background(), "first", ""), notify.ErrInvalidReceiver)
+	assert.NoError(service.Send(context.Background(), "second", ""))
+	err := service.Send(context.Background(), "third", "")
+	assert.ErrorIs(err, ErrTimeout)
+	assert.ErrorIs(err, notify.ErrTransient)
+	assert.NoError(service.Send(context.Background(), "fourth", ""))
+	assert.Equal([]notify.Message{{Subject: "second"}, {Subject: "fourth"}}, service.Delivered())
+}
+
+func TestChaos_SetTimeoutRate(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := New(1)
+	service.SetTimeoutRate(1, time.Hour)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := service.Send(ctx, "subject", "message")
+	assert.ErrorIs(err, context.DeadlineExceeded)
+	assert.Less(time.Since(start), time.Minute)
+	assert.Empty(service.Delivered())
+}
+
+func TestChaos_SetLatency(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := New(1)
+	service.SetLatency(20*time.Millisecond, 10*time.Millisecond)
+
+	start := time.Now()
+	assert.NoError(service.Send(context.Background(), "subject", "message"))
+	assert.GreaterOrEqual(time.Since(start), 20*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(service.Send(ctx, "subject", "message"), context.Canceled)
+	assert.Len(service.Delivered(), 1)
+}
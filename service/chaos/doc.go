@@ -0,0 +1,43 @@
+/*
+Package chaos provides a notification service that fails on purpose, to test how applications handle failing
+services: retries, circuit breakers and fail over to other services. It injects failures of given error classes,
+latency and timeouts at configurable rates. Failures are random, but reproducible with the same seed.
+
+Usage:
+
+	package main
+
+	import (
+	    "context"
+	    "log"
+	    "time"
+
+	    "github.com/nikoksr/notify"
+	    "github.com/nikoksr/notify/service/chaos"
+	)
+
+	func main() {
+	    // Create a chaos service with a fixed seed, so that every run fails the same sends.
+	    chaosService := chaos.New(42)
+
+	    // Fail one in five sends with a transient error or a rate limit, and time out one in twenty.
+	    chaosService.SetFailureRate(0.2)
+	    chaosService.SetErrors(notify.ErrTransient, &notify.ErrRateLimited{RetryAfter: time.Second})
+	    chaosService.SetTimeoutRate(0.05, 5*time.Second)
+
+	    // Every send takes between 100 and 150 milliseconds.
+	    chaosService.SetLatency(100*time.Millisecond, 50*time.Millisecond)
+
+	    // Fail the next send with an authentication error, no matter the rates.
+	    chaosService.FailNext(notify.ErrAuthFailed)
+
+	    // Tell our notifier to use the chaos service.
+	    notify.UseServices(chaosService)
+
+	    // Send a test message.
+	    if err := notify.Send(context.Background(), "Subject/Title", "The actual message"); err != nil {
+	        log.Println(err)
+	    }
+	}
+*/
+package chaos
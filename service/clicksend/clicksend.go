@@ -0,0 +1,205 @@
+package clicksend
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
+)
+
+const (
+	defaultBaseURL = "https://rest.clicksend.com/v3"
+
+	// maxMessages is the maximum number of messages ClickSend accepts per send request.
+	maxMessages = 1000
+)
+
+// Service encapsulates the ClickSend SMS client.
+type Service struct {
+	client   *http.Client
+	baseURL  string
+	username string
+	apiKey   string
+
+	from      string
+	schedule  time.Time
+	receivers []string
+}
+
+// New returns a new instance of a ClickSend notification service. Username and API key can be found in the API
+// credentials section of the ClickSend dashboard.
+// For more information about the ClickSend SMS API:
+//
+//	-> https://developers.clicksend.com/docs/rest/v3/#send-sms
+func New(username, apiKey string) *Service {
+	return &Service{
+		client: &http.Client{
+			Transport: notify.NewRateLimitTransport(nil),
+			Timeout:   10 * time.Second,
+		},
+		baseURL:   defaultBaseURL,
+		username:  username,
+		apiKey:    apiKey,
+		receivers: []string{},
+	}
+}
+
+// WithClient sets the http client to be used for sending requests. Calling this method is optional, the default client
+// will be used if this method is not called.
+func (s *Service) WithClient(client *http.Client) {
+	if client != nil {
+		s.client = client
+	}
+}
+
+// SetSenderID sets the sender of all messages; either a dedicated number or an alphanumeric sender ID. If left empty,
+// ClickSend uses a shared number.
+func (s *Service) SetSenderID(from string) {
+	s.from = from
+}
+
+// SetSchedule schedules all messages to be delivered at the given time. Pass the zero time to send messages
+// immediately again.
+func (s *Service) SetSchedule(at time.Time) {
+	s.schedule = at
+}
+
+// AddReceivers takes phone numbers in E.164 format and adds them to the internal receiver list. The Send method will
+// send a given message to all those numbers.
+func (s *Service) AddReceivers(phoneNumbers ...string) {
+	s.receivers = append(s.receivers, phoneNumbers...)
+}
+
+type message struct {
+	Source   string `json:"source"`
+	From     string `json:"from,omitempty"`
+	To       string `json:"to"`
+	Body     string `json:"body"`
+	Schedule int64  `json:"schedule,omitempty"`
+}
+
+type sendRequest struct {
+	Messages []message `json:"messages"`
+}
+
+type sendResponse struct {
+	ResponseCode string `json:"response_code"`
+	ResponseMsg  string `json:"response_msg"`
+	Data         struct {
+		Messages []struct {
+			To     string `json:"to"`
+			Status string `json:"status"`
+		} `json:"messages"`
+	} `json:"data"`
+}
+
+// Validate checks that username and API key are set and that the sender, if it is a phone number, and the receiver
+// phone numbers are in E.164 format, see notify.Validator.
+func (s *Service) Validate() error {
+	var problems notify.ConfigErrors
+	problems.Require("username", s.username)
+	problems.Require("API key", s.apiKey)
+	if strings.HasPrefix(s.from, "+") {
+		problems.Check("sender", notify.ValidatePhoneNumber(s.from))
+	}
+	for _, number := range s.receivers {
+		problems.Check("receiver", notify.ValidatePhoneNumber(number))
+	}
+
+	return problems.Err()
+}
+
+// ResolveContact returns the phone number of the contact, see notify.SendToContacts.
+func (s *Service) ResolveContact(contact notify.Contact) (string, bool) {
+	return contact.Phone, contact.Phone != ""
+}
+
+// Send takes a message subject and a message body and sends them to all previously set phone numbers. Subject and
+// message are joined by a newline. Receivers are sent in batches of up to 1000 messages per request. The phone numbers
+// can be overridden per send, see notify.WithReceiversOverride.
+func (s *Service) Send(ctx context.Context, subject, msg string) error {
+	receivers := notify.ReceiversFromContext(ctx, s.receivers)
+	body := subject + "\n" + msg
+
+	var schedule int64
+	if !s.schedule.IsZero() {
+		schedule = s.schedule.Unix()
+	}
+
+	for start := 0; start < len(receivers); start += maxMessages {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			end := start + maxMessages
+			if end > len(receivers) {
+				end = len(receivers)
+			}
+
+			messages := make([]message, 0, end-start)
+			for _, to := range receivers[start:end] {
+				messages = append(messages, message{
+					Source:   "notify",
+					From:     s.from,
+					To:       to,
+					Body:     body,
+					Schedule: schedule,
+				})
+			}
+
+			if err := s.send(ctx, sendRequest{Messages: messages}); err != nil {
+				return errors.Wrap(err, "failed to send SMS via ClickSend")
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) send(ctx context.Context, payload sendRequest) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return errors.Wrap(err, "marshal messages")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.baseURL+"/sms/send", bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "create request")
+	}
+	req.SetBasicAuth(s.username, s.apiKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "send request")
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	var result sendResponse
+	if err = json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return errors.Wrapf(err, "decode response with status code %d", resp.StatusCode)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return notify.ClassifyResponse(resp, fmt.Errorf("clicksend returned status code %d: %s", resp.StatusCode, result.ResponseMsg))
+	}
+
+	var failed []string
+	for _, m := range result.Data.Messages {
+		if m.Status != "SUCCESS" {
+			failed = append(failed, fmt.Sprintf("%s: %s", m.To, m.Status))
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("clicksend rejected messages: %s", strings.Join(failed, ", "))
+	}
+
+	return nil
+}
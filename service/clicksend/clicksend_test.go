@@ -0,0 +1,127 @@
+package clicksend
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nikoksr/notify"
+)
+
+func newTestServer(t *testing.T, requests *[]sendRequest) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, key, ok := r.BasicAuth()
+		if !ok || user != "user" || key != "key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"http_code":401,"response_code":"UNAUTHORIZED","response_msg":"Invalid credentials"}`))
+			return
+		}
+
+		var req sendRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		*requests = append(*requests, req)
+
+		resp := sendResponse{ResponseCode: "SUCCESS"}
+		for _, m := range req.Messages {
+			status := "SUCCESS"
+			if m.To == "invalid" {
+				status = "INVALID_RECIPIENT"
+			}
+			resp.Data.Messages = append(resp.Data.Messages, struct {
+				To     string `json:"to"`
+				Status string `json:"status"`
+			}{To: m.To, Status: status})
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestClickSend_Send(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	var requests []sendRequest
+	server := newTestServer(t, &requests)
+
+	service := New("user", "key")
+	service.baseURL = server.URL
+	service.SetSenderID("notify")
+	service.SetSchedule(time.Unix(1700000000, 0))
+	service.AddReceivers("+61411111111")
+
+	assert.NoError(service.Send(context.Background(), "subject", "message"))
+	assert.Equal([]sendRequest{{Messages: []message{{
+		Source:   "notify",
+		From:     "notify",
+		To:       "+61411111111",
+		Body:     "subject\nmessage",
+		Schedule: 1700000000,
+	}}}}, requests)
+
+	// Test rejected recipient
+	service.AddReceivers("invalid")
+	err := service.Send(context.Background(), "subject", "message")
+	assert.Error(err)
+	assert.Contains(err.Error(), "invalid: INVALID_RECIPIENT")
+
+	// Test error response
+	service.apiKey = "invalid"
+	assert.Error(service.Send(context.Background(), "subject", "message"))
+}
+
+func TestClickSend_Validate(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := New("user", "key")
+	service.AddReceivers("+61411111111")
+	assert.NoError(service.Validate())
+
+	// Alphanumeric sender IDs are allowed.
+	service.SetSenderID("Notify")
+	assert.NoError(service.Validate())
+
+	service = New("", "")
+	service.SetSenderID("+61 411")
+	service.AddReceivers("0411111111")
+	err := service.Validate()
+	assert.ErrorIs(err, notify.ErrInvalidConfig)
+	assert.ErrorContains(err, "username is missing")
+	assert.ErrorContains(err, "API key is missing")
+	assert.ErrorContains(err, `sender: phone number "+61 411" is not in E.164 format`)
+	assert.ErrorContains(err, `receiver: phone number "0411111111" is not in E.164 format`)
+}
+
+func TestClickSend_SendBatches(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	var requests []sendRequest
+	server := newTestServer(t, &requests)
+
+	service := New("user", "key")
+	service.baseURL = server.URL
+	for i := 0; i < maxMessages+1; i++ {
+		service.AddReceivers(fmt.Sprintf("+61%09d", i))
+	}
+
+	assert.NoError(service.Send(context.Background(), "subject", "message"))
+	assert.Len(requests, 2)
+	assert.Len(requests[0].Messages, maxMessages)
+	assert.Len(requests[1].Messages, 1)
+	assert.Zero(requests[1].Messages[0].Schedule)
+}
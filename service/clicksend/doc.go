@@ -0,0 +1,36 @@
+/*
+Package clicksend provides a service for sending SMS messages through ClickSend.
+
+Usage:
+
+	package main
+
+	import (
+	    "context"
+	    "log"
+	    "time"
+
+	    "github.com/nikoksr/notify"
+	    "github.com/nikoksr/notify/service/clicksend"
+	)
+
+	func main() {
+	    clicksendService := clicksend.New("your-username", "your-api-key")
+
+	    // Optionally set a sender ID and schedule the delivery.
+	    clicksendService.SetSenderID("notify")
+	    clicksendService.SetSchedule(time.Now().Add(time.Hour))
+
+	    // Add phone numbers in E.164 format.
+	    clicksendService.AddReceivers("+61411111111")
+
+	    // Tell our notifier to use the clicksend service.
+	    notify.UseServices(clicksendService)
+
+	    // Send a test message.
+	    if err := notify.Send(context.Background(), "Subject/Title", "The actual message - Hello, you awesome gophers! :)"); err != nil {
+	        log.Fatal(err)
+	    }
+	}
+*/
+package clicksend
@@ -0,0 +1,221 @@
+package cloudevents
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
+)
+
+const (
+	specVersion = "1.0"
+
+	// DefaultEventType is the type attribute of emitted events unless set otherwise.
+	DefaultEventType = "com.github.nikoksr.notify.notification"
+)
+
+// Mode is the HTTP protocol binding content mode used to transfer events.
+type Mode int
+
+const (
+	// Binary mode transfers event attributes as ce- headers and the notification as body.
+	Binary Mode = iota
+	// Structured mode transfers the whole event as application/cloudevents+json body.
+	Structured
+)
+
+// extensionName matches valid CloudEvents extension attribute names.
+var extensionName = regexp.MustCompile(`^[a-z0-9]{1,20}$`)
+
+// Compile-time check to ensure that Service implements the notify.JSONSender interface.
+var _ notify.JSONSender = new(Service)
+
+// Service encapsulates the CloudEvents HTTP emitter.
+type Service struct {
+	client     *http.Client
+	sinkURLs   []string
+	source     string
+	eventType  string
+	mode       Mode
+	extensions map[string]string
+
+	newID func() string
+	now   func() time.Time
+}
+
+// New returns a new instance of a CloudEvents notification service. The source identifies the context in which events
+// happen, e.g. "/services/billing" or "https://example.com/api".
+// For more information about CloudEvents:
+//
+//	-> https://github.com/cloudevents/spec/blob/v1.0.2/cloudevents/bindings/http-protocol-binding.md
+func New(source string) *Service {
+	return &Service{
+		client: &http.Client{
+			Transport: notify.NewRateLimitTransport(nil),
+			Timeout:   10 * time.Second,
+		},
+		sinkURLs:   []string{},
+		source:     source,
+		eventType:  DefaultEventType,
+		mode:       Binary,
+		extensions: map[string]string{},
+		newID:      uuid.NewString,
+		now:        time.Now,
+	}
+}
+
+// WithClient sets the http client to be used for sending requests. Calling this method is optional, the default client
+// will be used if this method is not called.
+func (s *Service) WithClient(client *http.Client) {
+	if client != nil {
+		s.client = client
+	}
+}
+
+// AddReceivers takes sink URLs, e.g. the address of a Knative broker, and adds them to the internal sink list. The
+// Send method will emit an event to every one of those sinks.
+func (s *Service) AddReceivers(sinkURLs ...string) {
+	s.sinkURLs = append(s.sinkURLs, sinkURLs...)
+}
+
+// SetEventType sets the type attribute of all events. Defaults to DefaultEventType.
+func (s *Service) SetEventType(eventType string) {
+	s.eventType = eventType
+}
+
+// SetMode sets the content mode events are transferred in. Defaults to Binary.
+func (s *Service) SetMode(mode Mode) {
+	s.mode = mode
+}
+
+// AddExtension adds an extension attribute to all events. Names must consist of 1 to 20 lower-case letters or digits.
+func (s *Service) AddExtension(name, value string) error {
+	if !extensionName.MatchString(name) {
+		return fmt.Errorf("invalid extension attribute name '%s'", name)
+	}
+	s.extensions[name] = value
+
+	return nil
+}
+
+// data is the payload of emitted events.
+type data struct {
+	Subject string `json:"subject"`
+	Message string `json:"message"`
+}
+
+// newRequest builds the request carrying the event in the configured content mode.
+func (s *Service) newRequest(ctx context.Context, sinkURL string, attributes map[string]string, payload any) (*http.Request, error) {
+	var (
+		body        []byte
+		err         error
+		contentType = "application/json"
+	)
+
+	switch s.mode {
+	case Structured:
+		event := make(map[string]any, len(attributes)+2)
+		for name, value := range attributes {
+			event[name] = value
+		}
+		event["datacontenttype"] = contentType
+		event["data"] = payload
+		body, err = json.Marshal(event)
+		contentType = "application/cloudevents+json"
+	default:
+		body, err = json.Marshal(payload)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal event")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sinkURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, errors.Wrap(err, "create request")
+	}
+	req.Header.Set("Content-Type", contentType)
+
+	if s.mode == Binary {
+		for name, value := range attributes {
+			req.Header.Set("ce-"+name, value)
+		}
+	}
+
+	return req, nil
+}
+
+// Send takes a message subject and a message body and emits them as event to all previously set sinks. The event
+// carries subject and message as JSON data; the subject is also used as the event's subject attribute. All sinks
+// receive the same event ID.
+func (s *Service) Send(ctx context.Context, subject, message string) error {
+	return s.emit(ctx, subject, data{Subject: subject, Message: message})
+}
+
+// SendJSON emits an event carrying the JSON encoding of v as data to all previously set sinks. All sinks receive the
+// same event ID.
+func (s *Service) SendJSON(ctx context.Context, v any) error {
+	payload, err := json.Marshal(v)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal payload")
+	}
+
+	return s.emit(ctx, "", json.RawMessage(payload))
+}
+
+// emit emits an event with the given subject attribute, if any, and data to all previously set sinks.
+func (s *Service) emit(ctx context.Context, subject string, payload any) error {
+	attributes := make(map[string]string, len(s.extensions)+6)
+	for name, value := range s.extensions {
+		attributes[name] = value
+	}
+	attributes["specversion"] = specVersion
+	attributes["id"] = s.newID()
+	attributes["source"] = s.source
+	attributes["type"] = s.eventType
+	attributes["time"] = s.now().UTC().Format(time.RFC3339Nano)
+	if subject != "" {
+		attributes["subject"] = subject
+	}
+
+	for _, sinkURL := range s.sinkURLs {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			if err := s.send(ctx, sinkURL, attributes, payload); err != nil {
+				return errors.Wrapf(err, "failed to emit event to '%s'", sinkURL)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) send(ctx context.Context, sinkURL string, attributes map[string]string, payload any) error {
+	req, err := s.newRequest(ctx, sinkURL, attributes, payload)
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "send request")
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		result, _ := io.ReadAll(resp.Body)
+		return notify.ClassifyResponse(resp, fmt.Errorf("sink returned status code %d: %s", resp.StatusCode, string(result)))
+	}
+
+	return nil
+}
@@ -0,0 +1,137 @@
+package cloudevents
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type request struct {
+	header http.Header
+	body   map[string]any
+}
+
+func newTestServer(t *testing.T, requests *[]request) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/broker" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		b, _ := io.ReadAll(r.Body)
+		var body map[string]any
+		_ = json.Unmarshal(b, &body)
+		*requests = append(*requests, request{header: r.Header, body: body})
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func newTestService(sinkURL string) *Service {
+	s := New("/services/billing")
+	s.newID = func() string { return "id" }
+	s.now = func() time.Time { return time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC) }
+	s.AddReceivers(sinkURL)
+
+	return s
+}
+
+func TestCloudEvents_SendBinary(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	var requests []request
+	server := newTestServer(t, &requests)
+
+	service := newTestService(server.URL + "/broker")
+	assert.NoError(service.AddExtension("severity", "high"))
+
+	assert.NoError(service.Send(context.Background(), "subject", "message"))
+	assert.Len(requests, 1)
+
+	header := requests[0].header
+	assert.Equal("application/json", header.Get("Content-Type"))
+	assert.Equal("1.0", header.Get("ce-specversion"))
+	assert.Equal("id", header.Get("ce-id"))
+	assert.Equal("/services/billing", header.Get("ce-source"))
+	assert.Equal(DefaultEventType, header.Get("ce-type"))
+	assert.Equal("2023-01-02T03:04:05Z", header.Get("ce-time"))
+	assert.Equal("subject", header.Get("ce-subject"))
+	assert.Equal("high", header.Get("ce-severity"))
+	assert.Equal(map[string]any{"subject": "subject", "message": "message"}, requests[0].body)
+
+	// Test error response
+	service.AddReceivers(server.URL + "/invalid")
+	assert.Error(service.Send(context.Background(), "subject", "message"))
+}
+
+func TestCloudEvents_SendStructured(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	var requests []request
+	server := newTestServer(t, &requests)
+
+	service := newTestService(server.URL + "/broker")
+	service.SetMode(Structured)
+	service.SetEventType("com.example.alert")
+
+	assert.NoError(service.Send(context.Background(), "subject", "message"))
+	assert.Len(requests, 1)
+	assert.Equal("application/cloudevents+json", requests[0].header.Get("Content-Type"))
+	assert.Empty(requests[0].header.Get("ce-id"))
+	assert.Equal(map[string]any{
+		"specversion":     "1.0",
+		"id":              "id",
+		"source":          "/services/billing",
+		"type":            "com.example.alert",
+		"time":            "2023-01-02T03:04:05Z",
+		"subject":         "subject",
+		"datacontenttype": "application/json",
+		"data":            map[string]any{"subject": "subject", "message": "message"},
+	}, requests[0].body)
+}
+
+func TestCloudEvents_SendJSON(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	var requests []request
+	server := newTestServer(t, &requests)
+
+	service := newTestService(server.URL + "/broker")
+	assert.NoError(service.SendJSON(context.Background(), map[string]any{"service": "api", "version": "1.2.3"}))
+
+	service.SetMode(Structured)
+	assert.NoError(service.SendJSON(context.Background(), map[string]any{"service": "api", "version": "1.2.3"}))
+
+	assert.Len(requests, 2)
+	assert.Empty(requests[0].header.Get("ce-subject"))
+	assert.Equal(map[string]any{"service": "api", "version": "1.2.3"}, requests[0].body)
+	assert.Equal(map[string]any{"service": "api", "version": "1.2.3"}, requests[1].body["data"])
+	assert.NotContains(requests[1].body, "subject")
+
+	assert.Error(service.SendJSON(context.Background(), make(chan int)))
+}
+
+func TestCloudEvents_AddExtension(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := New("source")
+	assert.NoError(service.AddExtension("traceparent", "00-abc"))
+	assert.Error(service.AddExtension("Invalid-Name", "value"))
+}
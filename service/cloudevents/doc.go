@@ -0,0 +1,36 @@
+/*
+Package cloudevents provides a service for emitting notifications as CloudEvents over HTTP, e.g. to Knative brokers or
+other event-driven pipelines.
+
+Usage:
+
+	package main
+
+	import (
+	    "context"
+	    "log"
+
+	    "github.com/nikoksr/notify"
+	    "github.com/nikoksr/notify/service/cloudevents"
+	)
+
+	func main() {
+	    eventsService := cloudevents.New("/services/billing")
+
+	    // Optionally set the event type and use structured mode.
+	    eventsService.SetEventType("com.example.billing.alert")
+	    eventsService.SetMode(cloudevents.Structured)
+
+	    // Add sink URLs.
+	    eventsService.AddReceivers("http://broker-ingress.knative-eventing.svc.cluster.local/default/default")
+
+	    // Tell our notifier to use the cloudevents service.
+	    notify.UseServices(eventsService)
+
+	    // Send a test message.
+	    if err := notify.Send(context.Background(), "Subject/Title", "The actual message - Hello, you awesome gophers! :)"); err != nil {
+	        log.Fatal(err)
+	    }
+	}
+*/
+package cloudevents
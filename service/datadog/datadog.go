@@ -0,0 +1,204 @@
+package datadog
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
+)
+
+// DefaultSite is the Datadog site used if none is set via SetSite.
+const DefaultSite = "datadoghq.com"
+
+// AlertType represents the type of event, which controls how it is displayed in the event stream.
+type AlertType string
+
+// All alert types supported by Datadog.
+const (
+	AlertTypeError   AlertType = "error"
+	AlertTypeWarning AlertType = "warning"
+	AlertTypeInfo    AlertType = "info"
+	AlertTypeSuccess AlertType = "success"
+)
+
+// Priority represents the priority of an event.
+type Priority string
+
+// All priorities supported by Datadog.
+const (
+	PriorityNormal Priority = "normal"
+	PriorityLow    Priority = "low"
+)
+
+// alertTypes maps the priorities of notify.WithPriority to Datadog alert types.
+var alertTypes = map[notify.Priority]AlertType{
+	notify.PriorityLow:      AlertTypeInfo,
+	notify.PriorityNormal:   AlertTypeInfo,
+	notify.PriorityHigh:     AlertTypeWarning,
+	notify.PriorityCritical: AlertTypeError,
+}
+
+// priorities maps the priorities of notify.WithPriority to Datadog priorities.
+var priorities = map[notify.Priority]Priority{
+	notify.PriorityLow:      PriorityLow,
+	notify.PriorityNormal:   PriorityNormal,
+	notify.PriorityHigh:     PriorityNormal,
+	notify.PriorityCritical: PriorityNormal,
+}
+
+// Service allow you to configure the Datadog service.
+type Service struct {
+	client    *http.Client
+	eventsURL string
+	apiKey    string
+
+	alertType      AlertType
+	priority       Priority
+	tags           []string
+	aggregationKey string
+	host           string
+}
+
+func defaultHTTPClient() *http.Client {
+	return &http.Client{
+		Transport: notify.NewRateLimitTransport(nil),
+		Timeout:   10 * time.Second,
+	}
+}
+
+// New returns a new instance of a Datadog notification service. The API key can be created under Organization
+// Settings > API Keys.
+// For more information about the Events API:
+//
+//	-> https://docs.datadoghq.com/api/latest/events/#post-an-event
+func New(apiKey string) *Service {
+	s := &Service{
+		client:    defaultHTTPClient(),
+		apiKey:    apiKey,
+		alertType: AlertTypeInfo,
+		priority:  PriorityNormal,
+		tags:      []string{},
+	}
+	s.SetSite(DefaultSite)
+
+	return s
+}
+
+// WithClient sets the http client to be used for sending requests. Calling this method is optional, the default client
+// will be used if this method is not called.
+func (s *Service) WithClient(client *http.Client) {
+	if client != nil {
+		s.client = client
+	}
+}
+
+// SetSite sets the Datadog site the account belongs to, e.g. "datadoghq.eu" or "us5.datadoghq.com". Defaults to
+// DefaultSite.
+func (s *Service) SetSite(site string) {
+	s.eventsURL = "https://api." + site + "/api/v1/events"
+}
+
+// SetAlertType sets the alert type of events posted by this service. Defaults to AlertTypeInfo. The priority of a
+// notification, see notify.WithPriority, overrides it: low and normal are info, high warning and critical error.
+func (s *Service) SetAlertType(alertType AlertType) {
+	s.alertType = alertType
+}
+
+// SetPriority sets the priority of events posted by this service. Defaults to PriorityNormal. The priority of a
+// notification, see notify.WithPriority, overrides it: low is low, everything else normal.
+func (s *Service) SetPriority(priority Priority) {
+	s.priority = priority
+}
+
+// AddTags adds tags, e.g. "env:prod", to all events posted by this service. Tags can be used to overlay events on
+// dashboards.
+func (s *Service) AddTags(tags ...string) {
+	s.tags = append(s.tags, tags...)
+}
+
+// SetAggregationKey sets a key that groups related events in the event stream.
+func (s *Service) SetAggregationKey(aggregationKey string) {
+	s.aggregationKey = aggregationKey
+}
+
+// SetHost sets the host name the events are associated with.
+func (s *Service) SetHost(host string) {
+	s.host = host
+}
+
+// Validate checks that the API key is set, that the site yields a well-formed URL and that no tag is empty, see
+// notify.Validator.
+func (s *Service) Validate() error {
+	var problems notify.ConfigErrors
+	problems.Require("API key", s.apiKey)
+	problems.Check("site", notify.ValidateURL(s.eventsURL))
+	for _, tag := range s.tags {
+		if tag == "" {
+			problems.Addf("tag is empty")
+		}
+	}
+
+	return problems.Err()
+}
+
+// event is the request body expected by the Events API.
+type event struct {
+	Title          string    `json:"title"`
+	Text           string    `json:"text"`
+	AlertType      AlertType `json:"alert_type"`
+	Priority       Priority  `json:"priority"`
+	Tags           []string  `json:"tags,omitempty"`
+	AggregationKey string    `json:"aggregation_key,omitempty"`
+	Host           string    `json:"host,omitempty"`
+	SourceTypeName string    `json:"source_type_name"`
+}
+
+// Send takes a message subject and a message body and posts them as event to Datadog.
+func (s *Service) Send(ctx context.Context, subject, message string) error {
+	alertType, priority := s.alertType, s.priority
+	p := notify.SendOptionsFromContext(ctx).Priority
+	if mapped, ok := alertTypes[p]; ok {
+		alertType, priority = mapped, priorities[p]
+	}
+
+	body, err := json.Marshal(event{
+		Title:          subject,
+		Text:           message,
+		AlertType:      alertType,
+		Priority:       priority,
+		Tags:           s.tags,
+		AggregationKey: s.aggregationKey,
+		Host:           s.host,
+		SourceTypeName: "notify",
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal event")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.eventsURL, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "failed to create request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("DD-API-KEY", s.apiKey)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "failed to send event to Datadog")
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusAccepted {
+		result, _ := io.ReadAll(resp.Body)
+		return notify.ClassifyResponse(resp, fmt.Errorf("datadog returned status code %d: %s", resp.StatusCode, string(result)))
+	}
+
+	return nil
+}
@@ -0,0 +1,91 @@
+package datadog
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nikoksr/notify"
+)
+
+func TestDatadog_New(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := New("key")
+	assert.Equal("https://api.datadoghq.com/api/v1/events", service.eventsURL)
+
+	service.SetSite("datadoghq.eu")
+	assert.Equal("https://api.datadoghq.eu/api/v1/events", service.eventsURL)
+}
+
+func TestDatadog_Validate(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := New("key")
+	service.AddTags("env:prod")
+	assert.NoError(service.Validate())
+
+	service = New("")
+	service.SetSite("")
+	service.AddTags("")
+	err := service.Validate()
+	assert.ErrorIs(err, notify.ErrInvalidConfig)
+	assert.ErrorContains(err, "API key is missing")
+	assert.ErrorContains(err, "tag is empty")
+}
+
+func TestDatadog_Send(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	var got event
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("DD-API-KEY") != "key" {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"errors":["Forbidden"]}`))
+			return
+		}
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	service := New("key")
+	service.eventsURL = server.URL
+	service.SetAlertType(AlertTypeError)
+	service.SetPriority(PriorityLow)
+	service.AddTags("env:prod", "team:ops")
+	service.SetAggregationKey("deploy")
+	service.SetHost("api-01")
+
+	assert.NoError(service.Send(context.Background(), "subject", "message"))
+	assert.Equal(event{
+		Title:          "subject",
+		Text:           "message",
+		AlertType:      AlertTypeError,
+		Priority:       PriorityLow,
+		Tags:           []string{"env:prod", "team:ops"},
+		AggregationKey: "deploy",
+		Host:           "api-01",
+		SourceTypeName: "notify",
+	}, got)
+
+	// The priority of the notification overrides the alert type and priority of the service.
+	ctx := notify.ContextWithSendOptions(context.Background(), notify.WithPriority(notify.PriorityHigh))
+	assert.NoError(service.Send(ctx, "subject", "message"))
+	assert.Equal(AlertTypeWarning, got.AlertType)
+	assert.Equal(PriorityNormal, got.Priority)
+
+	// Test error response
+	service.apiKey = "invalid"
+	assert.Error(service.Send(context.Background(), "subject", "message"))
+}
@@ -0,0 +1,34 @@
+/*
+Package datadog provides a service for posting notifications as events to Datadog.
+
+Usage:
+
+	package main
+
+	import (
+	    "context"
+	    "log"
+
+	    "github.com/nikoksr/notify"
+	    "github.com/nikoksr/notify/service/datadog"
+	)
+
+	func main() {
+	    datadogService := datadog.New("your-api-key")
+
+	    // Accounts outside of US1 need to set their site.
+	    datadogService.SetSite("datadoghq.eu")
+
+	    datadogService.SetAlertType(datadog.AlertTypeWarning)
+	    datadogService.AddTags("env:prod", "service:api")
+
+	    // Tell our notifier to use the datadog service.
+	    notify.UseServices(datadogService)
+
+	    // Send a test message.
+	    if err := notify.Send(context.Background(), "Subject/Title", "The actual message"); err != nil {
+	        log.Fatal(err)
+	    }
+	}
+*/
+package datadog
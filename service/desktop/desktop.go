@@ -0,0 +1,96 @@
+package desktop
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Urgency is the urgency of a notification. Only respected on Linux.
+type Urgency byte
+
+// Urgency levels defined by the desktop notifications specification.
+const (
+	UrgencyLow Urgency = iota
+	UrgencyNormal
+	UrgencyCritical
+)
+
+// Service encapsulates the platform specific desktop notification mechanism.
+type Service struct {
+	appName string
+	icon    string
+	urgency Urgency
+	timeout time.Duration
+}
+
+// New returns a new instance of a desktop notification service, which shows notifications on the desktop of the
+// current user. On Linux the notifications are sent via D-Bus to the notification daemon, on macOS via the
+// Notification Center and on Windows as toast notifications. Other platforms are not supported.
+func New(appName string) *Service {
+	return &Service{
+		appName: appName,
+		urgency: UrgencyNormal,
+		timeout: -1,
+	}
+}
+
+// SetIcon sets the icon shown with notifications, either a file path or, on Linux, the name of an icon of the current
+// icon theme. Not supported on macOS.
+func (s *Service) SetIcon(icon string) {
+	s.icon = icon
+}
+
+// SetUrgency sets the urgency of notifications. Only respected on Linux, where critical notifications usually don't
+// expire.
+func (s *Service) SetUrgency(urgency Urgency) {
+	s.urgency = urgency
+}
+
+// SetTimeout sets how long notifications are shown. A negative duration lets the notification daemon decide, which is
+// the default; zero means notifications never expire. Only respected on Linux.
+func (s *Service) SetTimeout(timeout time.Duration) {
+	s.timeout = timeout
+}
+
+// Send takes a message subject and a message body and shows them as desktop notification.
+func (s *Service) Send(ctx context.Context, subject, message string) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+
+	return errors.Wrap(s.notify(ctx, subject, message), "failed to show desktop notification")
+}
+
+// quoteAppleScript returns the string as AppleScript string literal.
+func quoteAppleScript(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+
+	return `"` + s + `"`
+}
+
+// toastXML returns the XML of a Windows toast notification with the given icon, which may be empty, subject and message.
+func toastXML(icon, subject, message string) string {
+	xml := `<toast><visual><binding template="ToastGeneric">`
+	if icon != "" {
+		xml += `<image placement="appLogoOverride" src="` + escapeXML(icon) + `"/>`
+	}
+
+	return xml + `<text>` + escapeXML(subject) + `</text><text>` + escapeXML(message) + `</text></binding></visual></toast>`
+}
+
+// escapeXML escapes the string for use in XML text and attribute values.
+func escapeXML(s string) string {
+	return strings.NewReplacer(
+		"&", "&amp;",
+		"<", "&lt;",
+		">", "&gt;",
+		`"`, "&quot;",
+		"'", "&apos;",
+	).Replace(s)
+}
@@ -0,0 +1,56 @@
+package desktop
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDesktop_New(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := New("app")
+	assert.Equal("app", service.appName)
+	assert.Equal(UrgencyNormal, service.urgency)
+	assert.Negative(service.timeout)
+
+	service.SetUrgency(UrgencyCritical)
+	service.SetIcon("dialog-warning")
+	assert.Equal(UrgencyCritical, service.urgency)
+	assert.Equal("dialog-warning", service.icon)
+}
+
+func TestDesktop_Quote(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	assert.Equal(`"say \"hi\" \\o/"`, quoteAppleScript(`say "hi" \o/`))
+	assert.Equal("&lt;b&gt; &amp; &quot;x&quot; &apos;y&apos;", escapeXML(`<b> & "x" 'y'`))
+}
+
+func TestDesktop_toastXML(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	assert.Equal(
+		`<toast><visual><binding template="ToastGeneric"><image placement="appLogoOverride" src="C:\icon.png"/>`+
+			`<text>it’s &apos;up&apos;</text><text>&lt;b&gt;</text></binding></visual></toast>`,
+		toastXML(`C:\icon.png`, `it’s 'up'`, `<b>`),
+	)
+	assert.NotContains(toastXML("", "subject", "message"), "<image")
+}
+
+func TestDesktop_SendCanceled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := New("app").Send(ctx, "subject", "message")
+	require.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,30 @@
+/*
+Package desktop provides a service for showing notifications on the local desktop, which is useful for CLI tools.
+Linux (via D-Bus), macOS and Windows are supported.
+
+Usage:
+
+	package main
+
+	import (
+	    "context"
+	    "log"
+
+	    "github.com/nikoksr/notify"
+	    "github.com/nikoksr/notify/service/desktop"
+	)
+
+	func main() {
+	    desktopService := desktop.New("my-cli")
+	    desktopService.SetIcon("dialog-information")
+
+	    // Tell our notifier to use the desktop service.
+	    notify.UseServices(desktopService)
+
+	    // Show a test notification.
+	    if err := notify.Send(context.Background(), "Build finished", "All tests passed."); err != nil {
+	        log.Fatal(err)
+	    }
+	}
+*/
+package desktop
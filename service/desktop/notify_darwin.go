@@ -0,0 +1,16 @@
+package desktop
+
+import (
+	"context"
+	"os/exec"
+)
+
+// notify shows the notification in the Notification Center via AppleScript.
+func (s *Service) notify(ctx context.Context, subject, message string) error {
+	script := "display notification " + quoteAppleScript(message) + " with title " + quoteAppleScript(subject)
+	if s.appName != "" {
+		script += " subtitle " + quoteAppleScript(s.appName)
+	}
+
+	return exec.CommandContext(ctx, "osascript", "-e", script).Run()
+}
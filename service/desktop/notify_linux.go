@@ -0,0 +1,36 @@
+package desktop
+
+import (
+	"context"
+
+	"github.com/godbus/dbus/v5"
+)
+
+const (
+	notificationsDest = "org.freedesktop.Notifications"
+	notificationsPath = "/org/freedesktop/Notifications"
+)
+
+// notify sends the notification to the notification daemon via the session bus.
+// See https://specifications.freedesktop.org/notification-spec/latest/protocol.html
+func (s *Service) notify(ctx context.Context, subject, message string) error {
+	conn, err := dbus.ConnectSessionBus(dbus.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer func() { _ = conn.Close() }()
+
+	timeout := int32(-1)
+	if s.timeout >= 0 {
+		timeout = int32(s.timeout.Milliseconds())
+	}
+	hints := map[string]dbus.Variant{
+		"urgency": dbus.MakeVariant(byte(s.urgency)),
+	}
+
+	obj := conn.Object(notificationsDest, notificationsPath)
+	call := obj.CallWithContext(ctx, notificationsDest+".Notify", 0,
+		s.appName, uint32(0), s.icon, subject, message, []string{}, hints, timeout)
+
+	return call.Err
+}
@@ -0,0 +1,15 @@
+//go:build !linux && !darwin && !windows
+
+package desktop
+
+import (
+	"context"
+	"runtime"
+
+	"github.com/pkg/errors"
+)
+
+// notify is not supported on this platform.
+func (s *Service) notify(_ context.Context, _, _ string) error {
+	return errors.Errorf("desktop notifications are not supported on %s", runtime.GOOS)
+}
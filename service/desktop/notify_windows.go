@@ -0,0 +1,32 @@
+package desktop
+
+import (
+	"context"
+	"os"
+	"os/exec"
+)
+
+// toastScript shows a toast notification using the Windows Runtime API. The toast XML and the application ID are read
+// from the environment, so that no text of the notification ends up in the script.
+const toastScript = `[Windows.UI.Notifications.ToastNotificationManager, Windows.UI.Notifications, ContentType = WindowsRuntime] | Out-Null
+[Windows.Data.Xml.Dom.XmlDocument, Windows.Data.Xml.Dom.XmlDocument, ContentType = WindowsRuntime] | Out-Null
+$xml = New-Object Windows.Data.Xml.Dom.XmlDocument
+$xml.LoadXml($env:NOTIFY_TOAST_XML)
+$toast = New-Object Windows.UI.Notifications.ToastNotification $xml
+[Windows.UI.Notifications.ToastNotificationManager]::CreateToastNotifier($env:NOTIFY_TOAST_APP_ID).Show($toast)`
+
+// notify shows the notification as toast notification via PowerShell.
+func (s *Service) notify(ctx context.Context, subject, message string) error {
+	appName := s.appName
+	if appName == "" {
+		appName = "notify"
+	}
+
+	cmd := exec.CommandContext(ctx, "powershell", "-NoProfile", "-NonInteractive", "-Command", toastScript)
+	cmd.Env = append(os.Environ(),
+		"NOTIFY_TOAST_XML="+toastXML(s.icon, subject, message),
+		"NOTIFY_TOAST_APP_ID="+appName,
+	)
+
+	return cmd.Run()
+}
@@ -1,45 +1,240 @@
 package dingding
 
 import (
+	"bytes"
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
 
-	"github.com/blinkbean/dingtalk"
 	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
+)
+
+// DefaultWebhookURL is the endpoint of DingTalk's custom robot API.
+const DefaultWebhookURL = "https://oapi.dingtalk.com/robot/send"
+
+// MessageType is the type of message sent to the robot.
+type MessageType string
+
+// All message types supported by this service.
+const (
+	Text     MessageType = "text"
+	Markdown MessageType = "markdown"
 )
 
 // Service encapsulates the DingTalk client.
 type Service struct {
-	config Config
-	client *dingtalk.DingTalk
+	config     Config
+	client     *http.Client
+	webhookURL string
+	now        func() time.Time
 }
 
 // Config is the Service configuration.
 type Config struct {
-	Token  string
+	// Token is the access token of the robot's webhook URL.
+	Token string
+	// Secret is the robot's signing secret, required if the robot's security setting "sign" is enabled.
 	Secret string
+
+	// MessageType is the type of message to send. Defaults to Text.
+	MessageType MessageType
+
+	// AtMobiles are the phone numbers of the group members to @-mention.
+	AtMobiles []string
+	// AtUserIDs are the user IDs of the group members to @-mention.
+	AtUserIDs []string
+	// AtAll mentions everyone in the group.
+	AtAll bool
 }
 
 // New returns a new instance of a DingTalk notification service.
 func New(cfg *Config) *Service {
-	dt := dingtalk.InitDingTalkWithSecret(cfg.Token, cfg.Secret)
 	s := Service{
-		config: *cfg,
-		client: dt,
+		config:     *cfg,
+		client:     &http.Client{Timeout: 10 * time.Second, Transport: notify.NewRateLimitTransport(nil)},
+		webhookURL: DefaultWebhookURL,
+		now:        time.Now,
+	}
+	if s.config.MessageType == "" {
+		s.config.MessageType = Text
 	}
 	return &s
 }
 
+// WithClient sets the http client to be used for sending requests. Calling this method is optional, the default client
+// will be used if this method is not called.
+func (s *Service) WithClient(client *http.Client) {
+	if client != nil {
+		s.client = client
+	}
+}
+
+// sign computes the signature of the given timestamp as required by robots with the "sign" security setting.
+//
+// See https://open.dingtalk.com/document/robots/customize-robot-security-settings
+func sign(timestamp int64, secret string) string {
+	stringToSign := strconv.FormatInt(timestamp, 10) + "\n" + secret
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = mac.Write([]byte(stringToSign))
+
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// requestURL returns the webhook URL including the access token and, if a secret was configured, the signature.
+func (s *Service) requestURL() string {
+	query := url.Values{"access_token": {s.config.Token}}
+	if s.config.Secret != "" {
+		timestamp := s.now().UnixMilli()
+		query.Set("timestamp", strconv.FormatInt(timestamp, 10))
+		query.Set("sign", sign(timestamp, s.config.Secret))
+	}
+
+	return s.webhookURL + "?" + query.Encode()
+}
+
+type (
+	at struct {
+		AtMobiles []string `json:"atMobiles,omitempty"`
+		AtUserIDs []string `json:"atUserIds,omitempty"`
+		IsAtAll   bool     `json:"isAtAll,omitempty"`
+	}
+
+	textContent struct {
+		Content string `json:"content"`
+	}
+
+	markdownContent struct {
+		Title string `json:"title"`
+		Text  string `json:"text"`
+	}
+
+	// message is the request body expected by the robot API.
+	message struct {
+		MsgType  MessageType      `json:"msgtype"`
+		Text     *textContent     `json:"text,omitempty"`
+		Markdown *markdownContent `json:"markdown,omitempty"`
+		At       at               `json:"at"`
+	}
+
+	// response is the body returned by the robot API.
+	response struct {
+		ErrCode int    `json:"errcode"`
+		ErrMsg  string `json:"errmsg"`
+	}
+)
+
+// newMessage builds the message for the given subject and content.
+func (s *Service) newMessage(subject, content string) *message {
+	msg := &message{
+		MsgType: s.config.MessageType,
+		At: at{
+			AtMobiles: s.config.AtMobiles,
+			AtUserIDs: s.config.AtUserIDs,
+			IsAtAll:   s.config.AtAll,
+		},
+	}
+
+	if s.config.MessageType == Markdown {
+		// Markdown messages only notify mentioned members if they are also mentioned in the text.
+		mentions := make([]string, 0, len(s.config.AtMobiles)+len(s.config.AtUserIDs))
+		for _, mobile := range s.config.AtMobiles {
+			mentions = append(mentions, "@"+mobile)
+		}
+		for _, userID := range s.config.AtUserIDs {
+			mentions = append(mentions, "@"+userID)
+		}
+
+		text := "### " + subject + "\n\n" + content
+		if len(mentions) > 0 {
+			text += "\n\n" + strings.Join(mentions, " ")
+		}
+		msg.Markdown = &markdownContent{Title: subject, Text: text}
+
+		return msg
+	}
+
+	msg.Text = &textContent{Content: subject + "\n" + content}
+
+	return msg
+}
+
+// Validate checks that the token is set, that the message type is supported and that no phone number or user ID to
+// mention is empty, see notify.Validator.
+func (s *Service) Validate() error {
+	var problems notify.ConfigErrors
+	problems.Require("token", s.config.Token)
+	if s.config.MessageType != Text && s.config.MessageType != Markdown {
+		problems.Addf("unsupported message type %q", s.config.MessageType)
+	}
+	for _, mobile := range s.config.AtMobiles {
+		if mobile == "" {
+			problems.Addf("mobile to mention is empty")
+		}
+	}
+	for _, userID := range s.config.AtUserIDs {
+		if userID == "" {
+			problems.Addf("user ID to mention is empty")
+		}
+	}
+
+	return problems.Err()
+}
+
+// Sanitize escapes the markdown of the message if the message type is Markdown, so that user provided content can't
+// format the message or inject links, see notify.Sanitizer. Text messages only have control characters removed.
+func (s *Service) Sanitize(message notify.Message) notify.Message {
+	if s.config.MessageType == Markdown {
+		return notify.SanitizeMessage(message, notify.EscapeMarkdown)
+	}
+
+	return notify.SanitizeMessage(message, notify.StripControlChars)
+}
+
 // Send takes a message subject and a message content and sends them to all previously set users.
 func (s *Service) Send(ctx context.Context, subject, content string) error {
 	select {
 	case <-ctx.Done():
 		return ctx.Err()
 	default:
-		text := subject + "\n" + content
-		err := s.client.SendTextMessage(text)
-		if err != nil {
-			return errors.Wrapf(err, "failed to send message")
-		}
+	}
+
+	body, err := json.Marshal(s.newMessage(subject, content))
+	if err != nil {
+		return errors.Wrap(err, "marshal message")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.requestURL(), bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "create request")
+	}
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "failed to send message")
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	result, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Wrap(err, "read response")
+	}
+
+	var r response
+	if err = json.Unmarshal(result, &r); err != nil || resp.StatusCode != http.StatusOK || r.ErrCode != 0 {
+		return notify.ClassifyResponse(resp, fmt.Errorf("dingtalk returned status code %d: %s", resp.StatusCode, string(result)))
 	}
 
 	return nil
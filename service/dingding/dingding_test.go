@@ -0,0 +1,110 @@
+package dingding
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nikoksr/notify"
+)
+
+func TestDingDing_New(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := New(&Config{Token: "token"})
+	assert.NotNil(service)
+	assert.Equal(Text, service.config.MessageType)
+}
+
+func TestDingDing_Sign(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	// Reference value computed with the algorithm from DingTalk's documentation.
+	assert.Equal("bm0ywHJH8t/pHkwxe/l85nFbcTSuRgIRBEj55yQE/j4=", sign(1577836800000, "secret"))
+
+	service := New(&Config{Token: "token", Secret: "secret"})
+	service.now = func() time.Time { return time.UnixMilli(1577836800000) }
+
+	u, err := url.Parse(service.requestURL())
+	assert.Nil(err)
+	assert.Equal("token", u.Query().Get("access_token"))
+	assert.Equal("1577836800000", u.Query().Get("timestamp"))
+	assert.Equal("bm0ywHJH8t/pHkwxe/l85nFbcTSuRgIRBEj55yQE/j4=", u.Query().Get("sign"))
+}
+
+func TestDingDing_Validate(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	assert.NoError(New(&Config{Token: "token", AtMobiles: []string{"13800138000"}}).Validate())
+
+	err := New(&Config{MessageType: "image", AtMobiles: []string{""}, AtUserIDs: []string{""}}).Validate()
+	assert.ErrorIs(err, notify.ErrInvalidConfig)
+	assert.ErrorContains(err, "token is missing")
+	assert.ErrorContains(err, `unsupported message type "image"`)
+	assert.ErrorContains(err, "mobile to mention is empty")
+	assert.ErrorContains(err, "user ID to mention is empty")
+}
+
+func TestDingDing_Sanitize(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	message := notify.Message{Body: "# [click](https://evil.example.com)"}
+	assert.Equal(message.Body, New(&Config{Token: "token"}).Sanitize(message).Body)
+	assert.Equal(
+		`\# \[click\](https://evil.example.com)`,
+		New(&Config{Token: "token", MessageType: Markdown}).Sanitize(message).Body,
+	)
+}
+
+func TestDingDing_Send(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	var got message
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("access_token") != "token" {
+			_, _ = w.Write([]byte(`{"errcode":300001,"errmsg":"token is not exist"}`))
+			return
+		}
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		_, _ = w.Write([]byte(`{"errcode":0,"errmsg":"ok"}`))
+	}))
+	defer server.Close()
+
+	service := New(&Config{Token: "token", AtMobiles: []string{"13800000000"}})
+	service.webhookURL = server.URL
+	err := service.Send(context.Background(), "subject", "content")
+	assert.Nil(err)
+	assert.Equal(Text, got.MsgType)
+	assert.Equal("subject\ncontent", got.Text.Content)
+	assert.Equal([]string{"13800000000"}, got.At.AtMobiles)
+
+	service = New(&Config{Token: "token", MessageType: Markdown, AtMobiles: []string{"13800000000"}})
+	service.webhookURL = server.URL
+	err = service.Send(context.Background(), "subject", "content")
+	assert.Nil(err)
+	assert.Equal(Markdown, got.MsgType)
+	assert.Equal("subject", got.Markdown.Title)
+	assert.Equal("### subject\n\ncontent\n\n@13800000000", got.Markdown.Text)
+
+	// Test error response
+	service = New(&Config{Token: "wrong"})
+	service.webhookURL = server.URL
+	err = service.Send(context.Background(), "subject", "content")
+	assert.NotNil(err)
+}
@@ -1,20 +1,52 @@
 package discord
 
 import (
+	"bytes"
 	"context"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
 )
 
 //go:generate mockery --name=discordSession --output=. --case=underscore --inpackage
 type discordSession interface {
 	ChannelMessageSend(channelID string, content string, options ...discordgo.RequestOption) (*discordgo.Message, error)
+	ChannelMessageSendComplex(channelID string, data *discordgo.MessageSend, options ...discordgo.RequestOption) (*discordgo.Message, error)
 }
 
 // Compile-time check to ensure that discordgo.Session implements the discordSession interface.
 var _ discordSession = new(discordgo.Session)
 
+// Compile-time check to ensure that Discord implements the notify.Limiter interface.
+var _ notify.Limiter = Discord{}
+
+// Compile-time check to ensure that Discord implements the notify.MessageSender interface.
+var _ notify.MessageSender = Discord{}
+
+// Compile-time check to ensure that Discord implements the notify.AttachmentSender interface.
+var _ notify.AttachmentSender = Discord{}
+
+// Compile-time check to ensure that Discord implements the notify.Sanitizer interface.
+var _ notify.Sanitizer = Discord{}
+
+// Compile-time check to ensure that Discord implements the notify.ContactResolver interface.
+var _ notify.ContactResolver = Discord{}
+
+// Compile-time check to ensure that Discord implements the notify.Validator interface.
+var _ notify.Validator = Discord{}
+
+// uploadOptions configures the upload of message attachments. Discord accepts files of up to 10 MiB without boosts.
+var uploadOptions = notify.UploadOptions{
+	MaxSize:    10 << 20,
+	Retries:    2,
+	RetryDelay: time.Second,
+}
+
 // Discord struct holds necessary data to communicate with the Discord API.
 type Discord struct {
 	client     discordSession
@@ -77,21 +109,163 @@ func (d *Discord) AddReceivers(channelIDs ...string) {
 	d.channelIDs = append(d.channelIDs, channelIDs...)
 }
 
+// Validate checks that the service is authenticated and that the channel IDs are Discord snowflakes, see
+// notify.Validator.
+func (d Discord) Validate() error {
+	var problems notify.ConfigErrors
+	if session, ok := d.client.(*discordgo.Session); ok && session.Token == "" {
+		problems.Addf("token is missing, see AuthenticateWithBotToken")
+	}
+	for _, channelID := range d.channelIDs {
+		if _, err := strconv.ParseUint(channelID, 10, 64); err != nil {
+			problems.Addf("channel ID %q is not a Discord ID", channelID)
+		}
+	}
+
+	return problems.Err()
+}
+
+// ResolveContact returns the chat ID of the contact for "discord", the ID of a channel, e.g. a direct message channel
+// with the contact, see notify.SendToContacts.
+func (d Discord) ResolveContact(contact notify.Contact) (string, bool) {
+	return contact.ChatID("discord")
+}
+
 // Send takes a message subject and a message body and sends them to all previously set chats.
 func (d Discord) Send(ctx context.Context, subject, message string) error {
-	fullMessage := subject + "\n" + message // Treating subject as message title
+	return d.SendMessage(ctx, notify.Message{Subject: subject, Body: message})
+}
 
-	for _, channelID := range d.channelIDs {
+// embed renders a card as Discord embed.
+func embed(card *notify.Card) *discordgo.MessageEmbed {
+	e := &discordgo.MessageEmbed{Title: card.Title}
+	for _, field := range card.Fields {
+		e.Fields = append(e.Fields, &discordgo.MessageEmbedField{
+			Name:   field.Name,
+			Value:  field.Value,
+			Inline: field.Inline,
+		})
+	}
+	if color, err := strconv.ParseInt(strings.TrimPrefix(card.Color, "#"), 16, 32); err == nil {
+		e.Color = int(color)
+	}
+	if card.ImageURL != "" {
+		e.Image = &discordgo.MessageEmbedImage{URL: card.ImageURL}
+	}
+	if card.Footer != "" {
+		e.Footer = &discordgo.MessageEmbedFooter{Text: card.Footer}
+	}
+
+	return e
+}
+
+// uploadAttachments sends the attachments as files in reply to the message with the given ID, if any.
+func (d Discord) uploadAttachments(
+	ctx context.Context,
+	channelID, messageID string,
+	attachments []notify.Attachment,
+) error {
+	var reference *discordgo.MessageReference
+	if messageID != "" {
+		reference = &discordgo.MessageReference{MessageID: messageID, ChannelID: channelID}
+	}
+
+	_, err := notify.UploadAttachments(ctx, attachments, uploadOptions,
+		func(_ context.Context, attachment notify.Attachment) (string, error) {
+			sent, err := d.client.ChannelMessageSendComplex(channelID, &discordgo.MessageSend{
+				Files: []*discordgo.File{{
+					Name:        attachment.Name,
+					ContentType: attachment.ContentType,
+					Reader:      bytes.NewReader(attachment.Data),
+				}},
+				Reference: reference,
+			})
+			if err != nil {
+				return "", classifyError(err)
+			}
+			if sent == nil {
+				return "", nil
+			}
+
+			return sent.ID, nil
+		})
+
+	return err
+}
+
+// SupportsAttachments reports that Discord delivers the attachments of messages, see notify.AttachmentSender.
+func (d Discord) SupportsAttachments() bool {
+	return true
+}
+
+// SendMessage works like Send, but renders the card of the message as embed. Actions are appended as links and
+// attachments are sent as files in reply to the message.
+func (d Discord) SendMessage(ctx context.Context, message notify.Message) error {
+	card := message.Card
+	message.Card = nil
+	fullMessage := message.Subject + "\n" + message.PlainText() // Treating subject as message title
+
+	for _, channelID := range notify.ReceiversFromContext(ctx, d.channelIDs) {
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
 		default:
-			_, err := d.client.ChannelMessageSend(channelID, fullMessage)
+			var (
+				sent *discordgo.Message
+				err  error
+			)
+			if card == nil {
+				sent, err = d.client.ChannelMessageSend(channelID, fullMessage)
+			} else {
+				sent, err = d.client.ChannelMessageSendComplex(channelID, &discordgo.MessageSend{
+					Content: fullMessage,
+					Embeds:  []*discordgo.MessageEmbed{embed(card)},
+				})
+			}
 			if err != nil {
-				return errors.Wrapf(err, "failed to send message to Discord channel '%s'", channelID)
+				return classifyError(errors.Wrapf(err, "failed to send message to Discord channel '%s'", channelID))
+			}
+
+			var messageID string
+			if sent != nil {
+				messageID = sent.ID
+			}
+			if err = d.uploadAttachments(ctx, channelID, messageID, message.Attachments); err != nil {
+				return classifyError(errors.Wrapf(err, "failed to upload attachments to Discord channel '%s'", channelID))
 			}
 		}
 	}
 
 	return nil
 }
+
+// classifyError classifies errors returned by the Discord API, see notify.ClassifyError.
+func classifyError(err error) error {
+	var rateLimited *discordgo.RateLimitError
+	if errors.As(err, &rateLimited) {
+		return notify.ClassifyError(err, &notify.ErrRateLimited{RetryAfter: rateLimited.RetryAfter})
+	}
+
+	var restErr *discordgo.RESTError
+	if errors.As(err, &restErr) && restErr.Response != nil {
+		return notify.ClassifyResponse(restErr.Response, err)
+	}
+
+	return notify.ClassifyNetworkError(err)
+}
+
+// massMentions breaks the mentions of @everyone and @here with a zero width space, as they aren't markdown.
+var massMentions = strings.NewReplacer("@everyone", "@\u200beveryone", "@here", "@\u200bhere")
+
+// Sanitize escapes the markdown of the message, so that user provided content can neither format the message nor
+// mention users, roles or @everyone.
+func (d Discord) Sanitize(message notify.Message) notify.Message {
+	return notify.SanitizeMessage(message, func(s string) string {
+		return massMentions.Replace(notify.EscapeMarkdown(s))
+	})
+}
+
+// Limits returns the maximum length of Discord messages, which contain subject and body.
+func (d Discord) Limits() notify.Limits {
+	return notify.Limits{Total: 2000}
+}
@@ -2,10 +2,15 @@ package discord
 
 import (
 	"context"
+	"io"
+	"net/http"
 	"testing"
 
-	"github.com/pkg/errors"
+	"github.com/bwmarrin/discordgo"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+
+	"github.com/nikoksr/notify"
 )
 
 func TestDiscord_New(t *testing.T) {
@@ -53,6 +58,24 @@ func TestDiscord_Authenticate(t *testing.T) {
 	assert.Nil(err)
 }
 
+func TestDiscord_Validate(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := New()
+	assert.NoError(service.AuthenticateWithBotToken("token"))
+	service.AddReceivers("1234567890")
+	assert.NoError(service.Validate())
+
+	service = New()
+	service.AddReceivers("general")
+	err := service.Validate()
+	assert.ErrorIs(err, notify.ErrInvalidConfig)
+	assert.ErrorContains(err, "token is missing")
+	assert.ErrorContains(err, `channel ID "general" is not a Discord ID`)
+}
+
 func TestDiscord_Send(t *testing.T) {
 	t.Parallel()
 
@@ -70,12 +93,12 @@ func TestDiscord_Send(t *testing.T) {
 	mockClient := newMockDiscordSession(t)
 	mockClient.
 		On("ChannelMessageSend", "1234", "subject\nmessage").
-		Return(nil, errors.New("some error"))
+		Return(nil, &discordgo.RESTError{Response: &http.Response{StatusCode: http.StatusNotFound}})
 
 	service.client = mockClient
 	service.AddReceivers("1234")
 	err = service.Send(ctx, "subject", "message")
-	assert.NotNil(err)
+	assert.ErrorIs(err, notify.ErrInvalidReceiver)
 	mockClient.AssertExpectations(t)
 
 	// Test success response
@@ -94,3 +117,82 @@ func TestDiscord_Send(t *testing.T) {
 	assert.Nil(err)
 	mockClient.AssertExpectations(t)
 }
+
+func TestDiscord_SendMessageCard(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := New()
+	assert.NotNil(service)
+	service.AddReceivers("1234")
+
+	mockClient := newMockDiscordSession(t)
+	mockClient.
+		On("ChannelMessageSendComplex", "1234", &discordgo.MessageSend{
+			Content: "subject\nmessage",
+			Embeds: []*discordgo.MessageEmbed{{
+				Title: "Disk usage high",
+				Fields: []*discordgo.MessageEmbedField{
+					{Name: "Host", Value: "db-1", Inline: true},
+				},
+				Color:  0xff0000,
+				Image:  &discordgo.MessageEmbedImage{URL: "https://example.com/graph.png"},
+				Footer: &discordgo.MessageEmbedFooter{Text: "monitoring"},
+			}},
+		}).
+		Return(nil, nil)
+	service.client = mockClient
+
+	err := service.SendMessage(context.Background(), notify.Message{
+		Subject: "subject",
+		Body:    "message",
+		Card: &notify.Card{
+			Title:    "Disk usage high",
+			Fields:   []notify.CardField{{Name: "Host", Value: "db-1", Inline: true}},
+			Color:    "#ff0000",
+			ImageURL: "https://example.com/graph.png",
+			Footer:   "monitoring",
+		},
+	})
+	assert.Nil(err)
+}
+
+func TestDiscord_SendMessageAttachments(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := New()
+	service.AddReceivers("1234")
+
+	mockClient := newMockDiscordSession(t)
+	mockClient.
+		On("ChannelMessageSend", "1234", "subject\nmessage").
+		Return(&discordgo.Message{ID: "42"}, nil)
+	mockClient.
+		On("ChannelMessageSendComplex", "1234", mock.MatchedBy(func(data *discordgo.MessageSend) bool {
+			content, _ := io.ReadAll(data.Files[0].Reader)
+			return data.Reference.MessageID == "42" && data.Files[0].Name == "message.txt" && string(content) == "full"
+		})).
+		Return(&discordgo.Message{ID: "43"}, nil)
+	service.client = mockClient
+
+	assert.True(service.SupportsAttachments())
+	err := service.SendMessage(context.Background(), notify.Message{
+		Subject:     "subject",
+		Body:        "message",
+		Attachments: []notify.Attachment{{Name: "message.txt", Data: []byte("full")}},
+	})
+	assert.NoError(err)
+}
+
+func TestDiscord_Sanitize(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	sanitized := New().Sanitize(notify.Message{Subject: "**Alert**", Body: "ping @everyone <@&123>"})
+	assert.Equal(`\*\*Alert\*\*`, sanitized.Subject)
+	assert.Equal("ping @\u200beveryone \\<@&123\\>", sanitized.Body)
+}
@@ -45,6 +45,39 @@ func (_m *mockDiscordSession) ChannelMessageSend(channelID string, content strin
 	return r0, r1
 }
 
+// ChannelMessageSendComplex provides a mock function with given fields: channelID, data, options
+func (_m *mockDiscordSession) ChannelMessageSendComplex(channelID string, data *discordgo.MessageSend, options ...discordgo.RequestOption) (*discordgo.Message, error) {
+	_va := make([]interface{}, len(options))
+	for _i := range options {
+		_va[_i] = options[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, channelID, data)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *discordgo.Message
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, *discordgo.MessageSend, ...discordgo.RequestOption) (*discordgo.Message, error)); ok {
+		return rf(channelID, data, options...)
+	}
+	if rf, ok := ret.Get(0).(func(string, *discordgo.MessageSend, ...discordgo.RequestOption) *discordgo.Message); ok {
+		r0 = rf(channelID, data, options...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*discordgo.Message)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, *discordgo.MessageSend, ...discordgo.RequestOption) error); ok {
+		r1 = rf(channelID, data, options...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type mockConstructorTestingTnewMockDiscordSession interface {
 	mock.TestingT
 	Cleanup(func())
@@ -0,0 +1,35 @@
+/*
+Package elasticsearch provides a service for indexing notifications as documents in Elasticsearch.
+
+Usage:
+
+	package main
+
+	import (
+	    "context"
+	    "log"
+
+	    "github.com/nikoksr/notify"
+	    "github.com/nikoksr/notify/service/elasticsearch"
+	)
+
+	func main() {
+	    elasticsearchService := elasticsearch.New()
+
+	    // Index notifications in this cluster.
+	    elasticsearchService.AddReceivers("https://localhost:9200")
+	    elasticsearchService.SetAPIKey("your-base64-encoded-api-key")
+
+	    // Write to a monthly index, e.g. notify-2023.01.
+	    elasticsearchService.SetIndex("<notify-{now/M{yyyy.MM}}>")
+
+	    // Tell our notifier to use the elasticsearch service.
+	    notify.UseServices(elasticsearchService)
+
+	    // Send a test message.
+	    if err := notify.Send(context.Background(), "Subject/Title", "The actual message"); err != nil {
+	        log.Fatal(err)
+	    }
+	}
+*/
+package elasticsearch
@@ -0,0 +1,208 @@
+package elasticsearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
+)
+
+// DefaultIndex is the index used if none is set via SetIndex. It creates a new index per day, e.g.
+// notify-2023.01.02.
+const DefaultIndex = "<notify-{now/d}>"
+
+// Service allow you to configure the Elasticsearch service.
+type Service struct {
+	client *http.Client
+	urls   []string
+
+	username string
+	password string
+	apiKey   string
+	index    string
+	fields   map[string]any
+
+	now func() time.Time
+}
+
+func defaultHTTPClient() *http.Client {
+	return &http.Client{
+		Transport: notify.NewRateLimitTransport(nil),
+		Timeout:   10 * time.Second,
+	}
+}
+
+// New returns a new instance of an Elasticsearch notification service, indexing every notification as a document.
+// For more information about the index API:
+//
+//	-> https://www.elastic.co/guide/en/elasticsearch/reference/current/docs-index_.html
+func New() *Service {
+	return &Service{
+		client: defaultHTTPClient(),
+		urls:   []string{},
+		index:  DefaultIndex,
+		fields: map[string]any{},
+		now:    time.Now,
+	}
+}
+
+// WithClient sets the http client to be used for sending requests. Calling this method is optional, the default client
+// will be used if this method is not called.
+func (s *Service) WithClient(client *http.Client) {
+	if client != nil {
+		s.client = client
+	}
+}
+
+// AddReceivers takes base URLs of Elasticsearch clusters, e.g. https://localhost:9200, and adds them to the internal
+// URL list. Every notification is indexed in every one of those clusters.
+func (s *Service) AddReceivers(urls ...string) {
+	for _, u := range urls {
+		s.urls = append(s.urls, strings.TrimSuffix(u, "/"))
+	}
+}
+
+// SetBasicAuth sets the credentials of a user allowed to write to the index.
+func (s *Service) SetBasicAuth(username, password string) {
+	s.username = username
+	s.password = password
+}
+
+// SetAPIKey sets the base64 encoded API key used to authenticate requests. It takes precedence over basic auth.
+func (s *Service) SetAPIKey(apiKey string) {
+	s.apiKey = apiKey
+}
+
+// SetIndex sets the index, alias or data stream documents are written to. Date math is supported and resolved by
+// Elasticsearch, e.g. "<notify-{now/M{yyyy.MM}}>" writes to a monthly index. Defaults to DefaultIndex.
+//
+//	-> https://www.elastic.co/guide/en/elasticsearch/reference/current/api-conventions.html#api-date-math-index-names
+func (s *Service) SetIndex(index string) {
+	s.index = index
+}
+
+// AddField adds a field to all indexed documents.
+func (s *Service) AddField(key string, value any) {
+	s.fields[key] = value
+}
+
+// Compile-time check to ensure that Service implements the notify.AuditStore and notify.AuditPruner interfaces.
+var (
+	_ notify.AuditStore  = (*Service)(nil)
+	_ notify.AuditPruner = (*Service)(nil)
+)
+
+func (s *Service) send(ctx context.Context, baseURL, path string, body []byte, wantStatus int) error {
+	endpoint := baseURL + "/" + url.PathEscape(s.index) + path
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "create request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	switch {
+	case s.apiKey != "":
+		req.Header.Set("Authorization", "ApiKey "+s.apiKey)
+	case s.username != "" || s.password != "":
+		req.SetBasicAuth(s.username, s.password)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "send request")
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != wantStatus {
+		result, _ := io.ReadAll(resp.Body)
+		return notify.ClassifyResponse(resp, fmt.Errorf("elasticsearch returned status code %d: %s", resp.StatusCode, string(result)))
+	}
+
+	return nil
+}
+
+// indexDocument indexes a document with the given fields, the fields added with AddField and the given timestamp in every
+// previously set cluster.
+func (s *Service) indexDocument(ctx context.Context, timestamp time.Time, fields map[string]any) error {
+	doc := make(map[string]any, len(s.fields)+len(fields)+1)
+	for key, value := range s.fields {
+		doc[key] = value
+	}
+	for key, value := range fields {
+		doc[key] = value
+	}
+	doc["@timestamp"] = timestamp.UTC().Format(time.RFC3339Nano)
+
+	body, err := json.Marshal(doc)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal document")
+	}
+
+	for _, baseURL := range s.urls {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			if err = s.send(ctx, baseURL, "/_doc", body, http.StatusCreated); err != nil {
+				return errors.Wrapf(err, "failed to index document in %q", baseURL)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Send takes a message subject and a message body and indexes them as document with "@timestamp", "subject" and
+// "message" fields in every previously set cluster.
+func (s *Service) Send(ctx context.Context, subject, message string) error {
+	return s.indexDocument(ctx, s.now(), map[string]any{"subject": subject, "message": message})
+}
+
+// Record indexes an audit record as document, which makes the service usable as audit store, see notify.WithAudit.
+// Use a service with its own index for audit records.
+func (s *Service) Record(ctx context.Context, record notify.AuditRecord) error {
+	return s.indexDocument(ctx, record.Time, map[string]any{
+		"message_hash":     record.MessageHash,
+		"service":          record.Service,
+		"receivers":        record.Receivers,
+		"tags":             record.Tags,
+		"outcome":          record.Outcome,
+		"error":            record.Error,
+		"latency_ms":       record.Latency.Milliseconds(),
+		"template":         record.Template,
+		"template_version": record.TemplateVersion,
+	})
+}
+
+// Prune deletes the documents older than before from the index in every previously set cluster, see
+// notify.PruneAudit. Indexes named with date math only cover the current index; use index lifecycle management to
+// delete old indices instead.
+func (s *Service) Prune(ctx context.Context, before time.Time) error {
+	query := map[string]any{
+		"query": map[string]any{
+			"range": map[string]any{
+				"@timestamp": map[string]any{"lt": before.UTC().Format(time.RFC3339Nano)},
+			},
+		},
+	}
+	body, err := json.Marshal(query)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal query")
+	}
+
+	for _, baseURL := range s.urls {
+		if err = s.send(ctx, baseURL, "/_delete_by_query", body, http.StatusOK); err != nil {
+			return errors.Wrapf(err, "failed to delete documents in %q", baseURL)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,109 @@
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nikoksr/notify"
+)
+
+func TestElasticsearch_Send(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	var (
+		paths []string
+		docs  []map[string]any
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth := r.Header.Get("Authorization")
+		if user, pass, ok := r.BasicAuth(); !(ok && user == "elastic" && pass == "changeme") && auth != "ApiKey key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var doc map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&doc)
+		paths = append(paths, r.URL.EscapedPath())
+		docs = append(docs, doc)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	service := New()
+	service.now = func() time.Time { return time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC) }
+	service.AddReceivers(server.URL + "/")
+	service.SetBasicAuth("elastic", "changeme")
+	service.AddField("service", "api")
+
+	assert.NoError(service.Send(context.Background(), "subject", "message"))
+
+	service.SetAPIKey("key")
+	service.SetIndex("notifications")
+	assert.NoError(service.Send(context.Background(), "subject", "message"))
+
+	assert.Equal([]string{"/%3Cnotify-%7Bnow%2Fd%7D%3E/_doc", "/notifications/_doc"}, paths)
+	assert.Equal(map[string]any{
+		"@timestamp": "2023-01-02T03:04:05Z",
+		"subject":    "subject",
+		"message":    "message",
+		"service":    "api",
+	}, docs[0])
+
+	// Test error response
+	service.SetAPIKey("")
+	service.SetBasicAuth("elastic", "invalid")
+	assert.Error(service.Send(context.Background(), "subject", "message"))
+}
+
+func TestElasticsearch_Audit(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	var (
+		paths  []string
+		bodies []map[string]any
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		paths = append(paths, r.URL.Path)
+		bodies = append(bodies, body)
+		if strings.HasSuffix(r.URL.Path, "/_delete_by_query") {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	service := New()
+	service.AddReceivers(server.URL)
+	service.SetIndex("notify-audit")
+
+	at := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	assert.NoError(service.Record(context.Background(), notify.AuditRecord{
+		Time:        at,
+		MessageHash: "abc",
+		Service:     "*slack.Slack",
+		Outcome:     notify.AuditOutcomeSent,
+		Latency:     250 * time.Millisecond,
+	}))
+	assert.NoError(service.Prune(context.Background(), at))
+
+	assert.Equal([]string{"/notify-audit/_doc", "/notify-audit/_delete_by_query"}, paths)
+	assert.Equal("2023-01-02T03:04:05Z", bodies[0]["@timestamp"])
+	assert.Equal("abc", bodies[0]["message_hash"])
+	assert.Equal(float64(250), bodies[0]["latency_ms"])
+	assert.Equal(map[string]any{
+		"range": map[string]any{"@timestamp": map[string]any{"lt": "2023-01-02T03:04:05Z"}},
+	}, bodies[1]["query"])
+}
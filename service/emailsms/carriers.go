@@ -0,0 +1,44 @@
+package emailsms
+
+// Carrier identifies a mobile carrier with an email-to-SMS gateway.
+type Carrier string
+
+// Carriers with a built-in email-to-SMS gateway.
+const (
+	ATT        Carrier = "att"
+	Bell       Carrier = "bell"
+	Boost      Carrier = "boost"
+	Cricket    Carrier = "cricket"
+	Fido       Carrier = "fido"
+	GoogleFi   Carrier = "googlefi"
+	MetroPCS   Carrier = "metropcs"
+	Rogers     Carrier = "rogers"
+	TMobile    Carrier = "tmobile"
+	Telus      Carrier = "telus"
+	USCellular Carrier = "uscellular"
+	Verizon    Carrier = "verizon"
+	Virgin     Carrier = "virgin"
+)
+
+// gateways maps carriers to the domain of their email-to-SMS gateway.
+var gateways = map[Carrier]string{
+	ATT:        "txt.att.net",
+	Bell:       "txt.bell.ca",
+	Boost:      "sms.myboostmobile.com",
+	Cricket:    "sms.cricketwireless.net",
+	Fido:       "fido.ca",
+	GoogleFi:   "msg.fi.google.com",
+	MetroPCS:   "mymetropcs.com",
+	Rogers:     "pcs.rogers.com",
+	TMobile:    "tmomail.net",
+	Telus:      "msg.telus.com",
+	USCellular: "email.uscc.net",
+	Verizon:    "vtext.com",
+	Virgin:     "vmobl.com",
+}
+
+// Gateway returns the domain of the email-to-SMS gateway of the given carrier and whether the carrier is known.
+func Gateway(carrier Carrier) (string, bool) {
+	domain, ok := gateways[carrier]
+	return domain, ok
+}
@@ -0,0 +1,40 @@
+/*
+Package emailsms provides a service for sending SMS messages through the email-to-SMS gateways of mobile carriers.
+
+The service composes gateway addresses, e.g. 5551234567@vtext.com, and delivers messages through the mail service.
+
+Usage:
+
+	package main
+
+	import (
+	    "context"
+	    "log"
+
+	    "github.com/nikoksr/notify"
+	    "github.com/nikoksr/notify/service/emailsms"
+	    "github.com/nikoksr/notify/service/mail"
+	)
+
+	func main() {
+	    // Configure the mail service used to reach the gateways.
+	    mailService := mail.New("alerts@example.com", "smtp.example.com:587")
+	    mailService.AuthenticateSMTP("", "alerts@example.com", "password", "smtp.example.com")
+
+	    smsService := emailsms.New(mailService)
+
+	    // Add phone numbers along with their carriers.
+	    if err := smsService.AddReceivers(emailsms.Verizon, "555-123-4567"); err != nil {
+	        log.Fatal(err)
+	    }
+
+	    // Tell our notifier to use the email-to-SMS service.
+	    notify.UseServices(smsService)
+
+	    // Send a test message.
+	    if err := notify.Send(context.Background(), "Subject/Title", "The actual message - Hello, you awesome gophers! :)"); err != nil {
+	        log.Fatal(err)
+	    }
+	}
+*/
+package emailsms
@@ -0,0 +1,120 @@
+package emailsms
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
+	"github.com/nikoksr/notify/service/mail"
+)
+
+// mailer abstracts the mail service used to deliver messages to the gateways.
+//
+//go:generate mockery --name=mailer --output=. --case=underscore --inpackage
+type mailer interface {
+	AddReceivers(addresses ...string)
+	Send(ctx context.Context, subject, message string) error
+}
+
+// Compile-time check to ensure that mail.Mail implements the mailer interface.
+var _ mailer = new(mail.Mail)
+
+// maxLength is the maximum length of texts sent through the gateways. Most gateways deliver a single SMS and drop
+// everything beyond it.
+const maxLength = 160
+
+// Compile-time check to ensure that Service implements the notify.Limiter interface.
+var _ notify.Limiter = new(Service)
+
+// Service sends SMS messages through the email-to-SMS gateways of mobile carriers.
+type Service struct {
+	mailer mailer
+}
+
+// New returns a new instance of an email-to-SMS notification service on top of the given, fully configured mail
+// service. The mail service is switched to plain text bodies, as gateways don't render HTML.
+func New(m *mail.Mail) *Service {
+	m.BodyFormat(mail.PlainText)
+
+	return &Service{mailer: m}
+}
+
+// Address returns the gateway email address of the given phone number at the given carrier. All carriers of the
+// built-in gateway table are North American, so the phone number is reduced to its ten digit national number.
+func Address(carrier Carrier, phoneNumber string) (string, error) {
+	domain, ok := Gateway(carrier)
+	if !ok {
+		return "", fmt.Errorf("unknown carrier '%s'", carrier)
+	}
+
+	number := strings.Map(func(r rune) rune {
+		if unicode.IsDigit(r) {
+			return r
+		}
+		return -1
+	}, phoneNumber)
+	if len(number) == 11 && number[0] == '1' {
+		number = number[1:]
+	}
+	if len(number) != 10 {
+		return "", fmt.Errorf("invalid phone number '%s'", phoneNumber)
+	}
+
+	return number + "@" + domain, nil
+}
+
+// AddReceivers takes phone numbers of subscribers of the given carrier and adds their gateway addresses to the
+// underlying mail service. No receivers are added if any of the phone numbers is invalid.
+func (s *Service) AddReceivers(carrier Carrier, phoneNumbers ...string) error {
+	addresses := make([]string, 0, len(phoneNumbers))
+	for _, phone := range phoneNumbers {
+		address, err := Address(carrier, phone)
+		if err != nil {
+			return err
+		}
+		addresses = append(addresses, address)
+	}
+
+	s.mailer.AddReceivers(addresses...)
+
+	return nil
+}
+
+// AddGatewayReceivers takes phone numbers and adds their addresses at the given gateway domain to the underlying mail
+// service. Use this for carriers missing from the built-in gateway table; phone numbers are used as is.
+func (s *Service) AddGatewayReceivers(domain string, phoneNumbers ...string) {
+	addresses := make([]string, 0, len(phoneNumbers))
+	for _, phone := range phoneNumbers {
+		addresses = append(addresses, phone+"@"+domain)
+	}
+
+	s.mailer.AddReceivers(addresses...)
+}
+
+// Limits returns the maximum length of texts delivered by the gateways, which contain subject and body.
+func (s *Service) Limits() notify.Limits {
+	return notify.Limits{Total: maxLength}
+}
+
+// Validate validates the underlying mail service, which carries the gateway addresses of all receivers, see
+// notify.Validator.
+func (s *Service) Validate() error {
+	if validator, ok := s.mailer.(notify.Validator); ok {
+		return validator.Validate()
+	}
+
+	return nil
+}
+
+// Send takes a message subject and a message body and sends them to all previously set phone numbers.
+func (s *Service) Send(ctx context.Context, subject, message string) error {
+	if err := s.mailer.Send(ctx, subject, message); err != nil {
+		return errors.Wrap(err, "failed to send SMS via email gateway")
+	}
+
+	return nil
+}
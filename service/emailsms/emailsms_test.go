@@ -0,0 +1,92 @@
+package emailsms
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nikoksr/notify"
+	"github.com/nikoksr/notify/service/mail"
+)
+
+func TestAddress(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		carrier Carrier
+		phone   string
+		want    string
+		wantErr bool
+	}{
+		{name: "plain number", carrier: Verizon, phone: "5551234567", want: "5551234567@vtext.com"},
+		{name: "formatted number", carrier: ATT, phone: "(555) 123-4567", want: "5551234567@txt.att.net"},
+		{name: "country code", carrier: TMobile, phone: "+1 555 123 4567", want: "5551234567@tmomail.net"},
+		{name: "too short", carrier: TMobile, phone: "123456", wantErr: true},
+		{name: "unknown carrier", carrier: "unknown", phone: "5551234567", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := Address(tt.carrier, tt.phone)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestEmailSMS_New(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := New(mail.New("alerts@example.com", "smtp.example.com:587"))
+	assert.NotNil(service)
+	assert.NotNil(service.mailer)
+}
+
+func TestEmailSMS_Validate(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := New(mail.New("alerts@example.com", "smtp.example.com:587"))
+	assert.NoError(service.AddReceivers(Verizon, "555-123-4567"))
+	assert.NoError(service.Validate())
+
+	// Gateway addresses are validated by the mail service.
+	service.AddGatewayReceivers("", "5551234567")
+	err := service.Validate()
+	assert.ErrorIs(err, notify.ErrInvalidConfig)
+	assert.ErrorContains(err, `receiver: mail address "5551234567@"`)
+}
+
+func TestEmailSMS_Send(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	mailer := newMockMailer(t)
+	mailer.On("AddReceivers", "5551234567@vtext.com", "5557654321@vtext.com").Once()
+	mailer.On("AddReceivers", "0170123@sms.example.net").Once()
+	mailer.On("Send", mock.Anything, "subject", "message").Return(nil).Once()
+	mailer.On("Send", mock.Anything, "subject", "message").Return(errors.New("connection refused")).Once()
+
+	service := &Service{mailer: mailer}
+	assert.NoError(service.AddReceivers(Verizon, "5551234567", "555-765-4321"))
+	assert.Error(service.AddReceivers(Verizon, "invalid"))
+	service.AddGatewayReceivers("sms.example.net", "0170123")
+
+	assert.NoError(service.Send(context.Background(), "subject", "message"))
+	assert.Error(service.Send(context.Background(), "subject", "message"))
+}
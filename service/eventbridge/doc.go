@@ -0,0 +1,39 @@
+/*
+Package eventbridge provides a service for putting notifications as events onto Amazon EventBridge event buses, so
+that EventBridge rules can route them further.
+
+Usage:
+
+	package main
+
+	import (
+	    "context"
+	    "log"
+
+	    "github.com/nikoksr/notify"
+	    "github.com/nikoksr/notify/service/eventbridge"
+	)
+
+	func main() {
+	    eventBridgeService, err := eventbridge.New("your-access-key-id", "your-secret-key", "eu-west-1")
+	    if err != nil {
+	        log.Fatal(err)
+	    }
+
+	    // Set what rules can match on.
+	    eventBridgeService.SetSource("com.example.billing")
+	    eventBridgeService.SetDetailType("Billing Alert")
+
+	    // Add event buses.
+	    eventBridgeService.AddReceivers("default")
+
+	    // Tell our notifier to use the eventbridge service.
+	    notify.UseServices(eventBridgeService)
+
+	    // Send a test message.
+	    if err = notify.Send(context.Background(), "Subject/Title", "The actual message - Hello, you awesome gophers! :)"); err != nil {
+	        log.Fatal(err)
+	    }
+	}
+*/
+package eventbridge
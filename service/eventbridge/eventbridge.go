@@ -0,0 +1,197 @@
+package eventbridge
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/eventbridge"
+	"github.com/aws/aws-sdk-go-v2/service/eventbridge/types"
+	"github.com/aws/smithy-go"
+	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
+)
+
+const (
+	// DefaultSource is the source of events unless set otherwise.
+	DefaultSource = "notify"
+	// DefaultDetailType is the detail type of events unless set otherwise.
+	DefaultDetailType = "Notification"
+
+	// maxEntries is the maximum number of entries EventBridge accepts per PutEvents request.
+	maxEntries = 10
+)
+
+// putEventsAPI Basic interface to put events onto EventBridge.
+//
+//go:generate mockery --name=putEventsAPI --output=. --case=underscore --inpackage
+type putEventsAPI interface {
+	PutEvents(ctx context.Context,
+		params *eventbridge.PutEventsInput,
+		optFns ...func(*eventbridge.Options)) (*eventbridge.PutEventsOutput, error)
+}
+
+// Compile-time check to ensure that Service implements the notify.JSONSender interface.
+var _ notify.JSONSender = new(Service)
+
+// Service Basic structure with EventBridge information
+type Service struct {
+	client     putEventsAPI
+	eventBuses []string
+	source     string
+	detailType string
+	resources  []string
+}
+
+// detail is the JSON document sent as detail of every event.
+type detail struct {
+	Subject string `json:"subject"`
+	Message string `json:"message"`
+}
+
+// New creates a new EventBridge service
+func New(accessKeyID, secretKey, region string) (*Service, error) {
+	credProvider := credentials.NewStaticCredentialsProvider(accessKeyID, secretKey, "")
+	cfg, err := config.LoadDefaultConfig(
+		context.Background(),
+		config.WithCredentialsProvider(credProvider),
+		config.WithRegion(region),
+	)
+	if err != nil {
+		return nil, err
+	}
+	client := eventbridge.NewFromConfig(cfg)
+	return &Service{
+		client:     client,
+		source:     DefaultSource,
+		detailType: DefaultDetailType,
+	}, nil
+}
+
+// AddReceivers takes event bus names or ARNs and adds them to the
+// internal event bus list. The Send method will put an event onto all
+// those event buses. Use "default" for the account's default event bus.
+func (s *Service) AddReceivers(eventBuses ...string) {
+	s.eventBuses = append(s.eventBuses, eventBuses...)
+}
+
+// SetSource sets the source of all events, which rules can match on.
+// Sources starting with "aws." are reserved.
+func (s *Service) SetSource(source string) {
+	s.source = source
+}
+
+// SetDetailType sets the detail type of all events, which rules can
+// match on.
+func (s *Service) SetDetailType(detailType string) {
+	s.detailType = detailType
+}
+
+// AddResources adds ARNs of AWS resources the events concern.
+func (s *Service) AddResources(arns ...string) {
+	s.resources = append(s.resources, arns...)
+}
+
+// Send puts an event onto all event buses with a JSON detail containing
+// the fields "subject" and "message".
+func (s *Service) Send(ctx context.Context, subject, message string) error {
+	return s.SendJSON(ctx, &detail{Subject: subject, Message: message})
+}
+
+// SendJSON puts an event onto all event buses with the JSON encoding
+// of v as its detail. EventBridge requires the detail to be a JSON
+// object.
+func (s *Service) SendJSON(ctx context.Context, v any) error {
+	if len(s.eventBuses) == 0 {
+		return nil
+	}
+
+	body, err := json.Marshal(v)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal detail")
+	}
+
+	for start := 0; start < len(s.eventBuses); start += maxEntries {
+		end := start + maxEntries
+		if end > len(s.eventBuses) {
+			end = len(s.eventBuses)
+		}
+
+		entries := make([]types.PutEventsRequestEntry, 0, end-start)
+		for _, eventBus := range s.eventBuses[start:end] {
+			entries = append(entries, types.PutEventsRequestEntry{
+				EventBusName: aws.String(eventBus),
+				Source:       aws.String(s.source),
+				DetailType:   aws.String(s.detailType),
+				Detail:       aws.String(string(body)),
+				Resources:    s.resources,
+			})
+		}
+
+		if err = s.put(ctx, s.eventBuses[start:end], entries); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) put(ctx context.Context, eventBuses []string, entries []types.PutEventsRequestEntry) error {
+	output, err := s.client.PutEvents(ctx, &eventbridge.PutEventsInput{Entries: entries})
+	if err != nil {
+		return classifyError(errors.Wrap(err, "failed to put events using Amazon EventBridge"))
+	}
+	if output.FailedEntryCount == 0 {
+		return nil
+	}
+
+	// Result entries are in the same order as the request entries. The entries fail permanently, unless one of them
+	// failed for a reason that may go away.
+	var failed []string
+	var class error = notify.ErrPermanent
+	for i, entry := range output.Entries {
+		if entry.ErrorCode != nil && i < len(eventBuses) {
+			failed = append(failed, fmt.Sprintf("%s: %s (%s)",
+				eventBuses[i], aws.ToString(entry.ErrorMessage), aws.ToString(entry.ErrorCode)))
+
+			switch aws.ToString(entry.ErrorCode) {
+			case "ThrottlingException":
+				class = &notify.ErrRateLimited{}
+			case "InternalException", "InternalFailure":
+				if class == notify.ErrPermanent {
+					class = notify.ErrTransient
+				}
+			}
+		}
+	}
+
+	return notify.ClassifyError(fmt.Errorf("failed to put %d events using Amazon EventBridge: %s",
+		output.FailedEntryCount, strings.Join(failed, ", ")), class)
+}
+
+// classifyError classifies errors returned by the Amazon EventBridge API, see notify.ClassifyError.
+func classifyError(err error) error {
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.ErrorCode() {
+		case "Throttling", "ThrottlingException", "ThrottledException", "RequestThrottled",
+			"TooManyRequestsException", "RequestLimitExceeded":
+			return notify.ClassifyError(err, &notify.ErrRateLimited{})
+		case "ResourceNotFoundException":
+			return notify.ClassifyError(err, notify.ErrInvalidReceiver)
+		}
+	}
+
+	var respErr *awshttp.ResponseError
+	if errors.As(err, &respErr) {
+		return notify.ClassifyStatus(respErr.HTTPStatusCode(), err)
+	}
+
+	return notify.ClassifyNetworkError(err)
+}
@@ -0,0 +1,118 @@
+package eventbridge
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/eventbridge"
+	"github.com/aws/aws-sdk-go-v2/service/eventbridge/types"
+	"github.com/aws/smithy-go"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nikoksr/notify"
+)
+
+func TestEventBridge_New(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service, err := New("", "", "")
+	assert.NotNil(service)
+	assert.Nil(err)
+	assert.Equal(DefaultSource, service.source)
+	assert.Equal(DefaultDetailType, service.detailType)
+}
+
+func TestEventBridge_SendWithNoEventBuses(t *testing.T) {
+	t.Parallel()
+
+	service := Service{client: newMockPutEventsAPI(t)}
+
+	err := service.Send(context.Background(), "subject", "message")
+	require.Nil(t, err)
+}
+
+func TestEventBridge_Send(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	var inputs []*eventbridge.PutEventsInput
+	client := newMockPutEventsAPI(t)
+	client.On("PutEvents", mock.Anything, mock.AnythingOfType("*eventbridge.PutEventsInput")).
+		Run(func(args mock.Arguments) { inputs = append(inputs, args.Get(1).(*eventbridge.PutEventsInput)) }).
+		Return(&eventbridge.PutEventsOutput{}, nil)
+
+	service := Service{client: client, source: "com.example.billing", detailType: "Alert"}
+	service.AddResources("arn:aws:ec2:eu-west-1:123456789012:instance/i-1")
+	for i := 0; i < maxEntries+1; i++ {
+		service.AddReceivers(fmt.Sprintf("bus-%d", i))
+	}
+
+	assert.Nil(service.Send(context.Background(), "subject", "message"))
+	assert.Len(inputs, 2)
+	assert.Len(inputs[0].Entries, maxEntries)
+	assert.Len(inputs[1].Entries, 1)
+	assert.Equal(types.PutEventsRequestEntry{
+		EventBusName: aws.String("bus-10"),
+		Source:       aws.String("com.example.billing"),
+		DetailType:   aws.String("Alert"),
+		Detail:       aws.String(`{"subject":"subject","message":"message"}`),
+		Resources:    []string{"arn:aws:ec2:eu-west-1:123456789012:instance/i-1"},
+	}, inputs[1].Entries[0])
+}
+
+func TestEventBridge_SendJSON(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	var input *eventbridge.PutEventsInput
+	client := newMockPutEventsAPI(t)
+	client.On("PutEvents", mock.Anything, mock.AnythingOfType("*eventbridge.PutEventsInput")).
+		Run(func(args mock.Arguments) { input = args.Get(1).(*eventbridge.PutEventsInput) }).
+		Return(&eventbridge.PutEventsOutput{}, nil).
+		Once()
+
+	service := Service{client: client, source: DefaultSource, detailType: DefaultDetailType}
+	service.AddReceivers("default")
+
+	assert.Nil(service.SendJSON(context.Background(), map[string]any{"service": "api", "version": "1.2.3"}))
+	assert.Len(input.Entries, 1)
+	assert.JSONEq(`{"service": "api", "version": "1.2.3"}`, aws.ToString(input.Entries[0].Detail))
+
+	assert.Error(service.SendJSON(context.Background(), make(chan int)))
+}
+
+func TestEventBridge_SendErrors(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	client := newMockPutEventsAPI(t)
+	client.On("PutEvents", mock.Anything, mock.Anything).
+		Return(&eventbridge.PutEventsOutput{
+			FailedEntryCount: 1,
+			Entries: []types.PutEventsResultEntry{
+				{EventId: aws.String("1")},
+				{ErrorCode: aws.String("AccessDeniedException"), ErrorMessage: aws.String("Access denied")},
+			},
+		}, nil).Once()
+	client.On("PutEvents", mock.Anything, mock.Anything).
+		Return(nil, &smithy.GenericAPIError{Code: "ThrottlingException", Message: "Rate exceeded"}).Once()
+
+	service := Service{client: client, source: DefaultSource, detailType: DefaultDetailType}
+	service.AddReceivers("default", "restricted")
+
+	err := service.Send(context.Background(), "subject", "message")
+	assert.Error(err)
+	assert.Contains(err.Error(), "restricted: Access denied (AccessDeniedException)")
+	assert.ErrorIs(err, notify.ErrPermanent)
+
+	var rateLimited *notify.ErrRateLimited
+	assert.ErrorAs(service.Send(context.Background(), "subject", "message"), &rateLimited)
+}
@@ -0,0 +1,40 @@
+/*
+Package expo provides a service for sending push notifications to React Native apps via Expo.
+
+Usage:
+
+	package main
+
+	import (
+	    "context"
+	    "log"
+
+	    "github.com/nikoksr/notify"
+	    "github.com/nikoksr/notify/service/expo"
+	)
+
+	func main() {
+	    expoService := expo.New()
+
+	    // Send to these push tokens.
+	    if err := expoService.AddReceivers("ExponentPushToken[xxxxxxxxxxxxxxxxxxxxxx]"); err != nil {
+	        log.Fatal(err)
+	    }
+
+	    // Tell our notifier to use the expo service.
+	    notify.UseServices(expoService)
+
+	    // Send a test message.
+	    if err := notify.Send(context.Background(), "Subject/Title", "The actual message"); err != nil {
+	        log.Fatal(err)
+	    }
+
+	    // Later on, check whether the notifications were delivered and drop tokens of uninstalled apps.
+	    unregistered, err := expoService.CheckReceipts(context.Background())
+	    if err != nil {
+	        log.Println(err)
+	    }
+	    log.Println("no longer registered:", unregistered)
+	}
+*/
+package expo
@@ -0,0 +1,258 @@
+package expo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
+)
+
+const (
+	defaultBaseURL = "https://exp.host/--/api/v2/push"
+
+	// maxBatchSize is the maximum number of messages, and of receipt IDs, accepted per request.
+	maxBatchSize = 100
+)
+
+// deviceNotRegistered is the error reported for push tokens of devices that can no longer receive notifications, e.g.
+// because the app was uninstalled. Such tokens should not be used anymore.
+const deviceNotRegistered = "DeviceNotRegistered"
+
+// Service encapsulates the Expo push client.
+type Service struct {
+	client      *http.Client
+	baseURL     string
+	accessToken string
+	sound       string
+	receivers   []string
+
+	mu sync.Mutex
+	// tickets maps the IDs of push tickets whose receipts haven't been checked yet to their push token.
+	tickets map[string]string
+}
+
+// New returns a new instance of an Expo push notification service.
+// For more information about sending notifications with Expo:
+//
+//	-> https://docs.expo.dev/push-notifications/sending-notifications/
+func New() *Service {
+	return &Service{
+		client: &http.Client{
+			Transport: notify.NewRateLimitTransport(nil),
+			Timeout:   10 * time.Second,
+		},
+		baseURL:   defaultBaseURL,
+		receivers: []string{},
+		tickets:   map[string]string{},
+	}
+}
+
+// WithClient sets the http client to be used for sending requests. Calling this method is optional, the default client
+// will be used if this method is not called.
+func (s *Service) WithClient(client *http.Client) {
+	if client != nil {
+		s.client = client
+	}
+}
+
+// SetAccessToken sets the access token used to authenticate requests. It is only required if enhanced push security
+// is enabled for the project.
+func (s *Service) SetAccessToken(token string) {
+	s.accessToken = token
+}
+
+// SetSound sets the sound played on iOS when the notification is received, e.g. "default". No sound is played by
+// default.
+func (s *Service) SetSound(sound string) {
+	s.sound = sound
+}
+
+// AddReceivers takes Expo push tokens, e.g. "ExponentPushToken[xxxxxxxxxxxxxxxxxxxxxx]", and adds them to the internal
+// receiver list. Invalid tokens are rejected.
+func (s *Service) AddReceivers(pushTokens ...string) error {
+	for _, token := range pushTokens {
+		if !isPushToken(token) {
+			return fmt.Errorf("invalid expo push token %q", token)
+		}
+	}
+	s.receivers = append(s.receivers, pushTokens...)
+
+	return nil
+}
+
+func isPushToken(token string) bool {
+	return (strings.HasPrefix(token, "ExponentPushToken[") || strings.HasPrefix(token, "ExpoPushToken[")) &&
+		strings.HasSuffix(token, "]")
+}
+
+type pushMessage struct {
+	To    string `json:"to"`
+	Title string `json:"title"`
+	Body  string `json:"body"`
+	Sound string `json:"sound,omitempty"`
+}
+
+type details struct {
+	Error string `json:"error"`
+}
+
+type pushTicket struct {
+	Status  string  `json:"status"`
+	ID      string  `json:"id"`
+	Message string  `json:"message"`
+	Details details `json:"details"`
+}
+
+type pushReceipt struct {
+	Status  string  `json:"status"`
+	Message string  `json:"message"`
+	Details details `json:"details"`
+}
+
+// Send takes a message subject and a message body and sends them to all previously set push tokens, in batches of up
+// to 100. The push tickets returned by Expo are kept, so that their receipts can be checked later on via
+// CheckReceipts.
+func (s *Service) Send(ctx context.Context, subject, message string) error {
+	var failed []string
+
+	for start := 0; start < len(s.receivers); start += maxBatchSize {
+		end := start + maxBatchSize
+		if end > len(s.receivers) {
+			end = len(s.receivers)
+		}
+		batch := s.receivers[start:end]
+
+		messages := make([]pushMessage, 0, len(batch))
+		for _, token := range batch {
+			messages = append(messages, pushMessage{To: token, Title: subject, Body: message, Sound: s.sound})
+		}
+
+		var tickets []pushTicket
+		if err := s.post(ctx, "/send", messages, &tickets); err != nil {
+			return errors.Wrap(err, "failed to send push notifications")
+		}
+		if len(tickets) != len(batch) {
+			return fmt.Errorf("failed to send push notifications: got %d tickets for %d messages", len(tickets), len(batch))
+		}
+
+		s.mu.Lock()
+		for i, ticket := range tickets {
+			if ticket.Status == "ok" {
+				s.tickets[ticket.ID] = batch[i]
+				continue
+			}
+			failed = append(failed, fmt.Sprintf("%s: %s", batch[i], ticket.Message))
+		}
+		s.mu.Unlock()
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to send push notifications: %s", strings.Join(failed, "; "))
+	}
+
+	return nil
+}
+
+// CheckReceipts fetches the receipts of all push tickets from previous calls to Send. Expo makes receipts available
+// once the notifications were handed to Apple or Google, usually within 15 minutes; tickets without a receipt yet are
+// kept and checked again on the next call.
+//
+// It returns the push tokens of devices that are no longer registered; those should be removed from the receivers.
+// Any other delivery failure is reported as error.
+func (s *Service) CheckReceipts(ctx context.Context) ([]string, error) {
+	s.mu.Lock()
+	ids := make([]string, 0, len(s.tickets))
+	for id := range s.tickets {
+		ids = append(ids, id)
+	}
+	s.mu.Unlock()
+
+	var (
+		unregistered []string
+		failed       []string
+	)
+	for start := 0; start < len(ids); start += maxBatchSize {
+		end := start + maxBatchSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+
+		var receipts map[string]pushReceipt
+		request := map[string][]string{"ids": ids[start:end]}
+		if err := s.post(ctx, "/getReceipts", request, &receipts); err != nil {
+			return unregistered, errors.Wrap(err, "failed to get push receipts")
+		}
+
+		s.mu.Lock()
+		for id, receipt := range receipts {
+			token, ok := s.tickets[id]
+			if !ok {
+				continue
+			}
+			delete(s.tickets, id)
+
+			if receipt.Status == "ok" {
+				continue
+			}
+			if receipt.Details.Error == deviceNotRegistered {
+				unregistered = append(unregistered, token)
+				continue
+			}
+			failed = append(failed, fmt.Sprintf("%s: %s", token, receipt.Message))
+		}
+		s.mu.Unlock()
+	}
+
+	if len(failed) > 0 {
+		return unregistered, fmt.Errorf("failed to deliver push notifications: %s", strings.Join(failed, "; "))
+	}
+
+	return unregistered, nil
+}
+
+// post sends payload to the given endpoint and decodes the data field of the response into result.
+func (s *Service) post(ctx context.Context, path string, payload, result any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return errors.Wrap(err, "marshal request")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.baseURL+path, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "create request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	if s.accessToken != "" {
+		req.Header.Set("Authorization", "Bearer "+s.accessToken)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "send request")
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	b, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return notify.ClassifyResponse(resp, fmt.Errorf("status %d: %s", resp.StatusCode, string(b)))
+	}
+
+	var envelope struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if err = json.Unmarshal(b, &envelope); err != nil {
+		return errors.Wrap(err, "decode response")
+	}
+
+	return errors.Wrap(json.Unmarshal(envelope.Data, result), "decode response data")
+}
@@ -0,0 +1,103 @@
+package expo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpo_AddReceivers(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := New()
+	assert.NoError(service.AddReceivers("ExponentPushToken[a]", "ExpoPushToken[b]"))
+	assert.Error(service.AddReceivers("invalid"))
+	assert.Equal([]string{"ExponentPushToken[a]", "ExpoPushToken[b]"}, service.receivers)
+}
+
+func TestExpo_Send(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	var sent []pushMessage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/send":
+			var messages []pushMessage
+			_ = json.NewDecoder(r.Body).Decode(&messages)
+			sent = append(sent, messages...)
+
+			tickets := make([]pushTicket, 0, len(messages))
+			for _, msg := range messages {
+				if msg.To == "ExponentPushToken[bad]" {
+					tickets = append(tickets, pushTicket{Status: "error", Message: "invalid token"})
+					continue
+				}
+				tickets = append(tickets, pushTicket{Status: "ok", ID: "id-" + msg.To})
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"data": tickets})
+		case "/getReceipts":
+			var req map[string][]string
+			_ = json.NewDecoder(r.Body).Decode(&req)
+
+			receipts := map[string]pushReceipt{}
+			for _, id := range req["ids"] {
+				switch id {
+				case "id-ExponentPushToken[gone]":
+					receipts[id] = pushReceipt{Status: "error", Details: details{Error: deviceNotRegistered}}
+				case "id-ExponentPushToken[pending]":
+					// Receipt not available yet
+				default:
+					receipts[id] = pushReceipt{Status: "ok"}
+				}
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"data": receipts})
+		}
+	}))
+	defer server.Close()
+
+	service := New()
+	service.baseURL = server.URL
+	service.SetAccessToken("token")
+	service.SetSound("default")
+
+	// No receivers added
+	assert.NoError(service.Send(context.Background(), "subject", "message"))
+
+	for i := 0; i < 150; i++ {
+		assert.NoError(service.AddReceivers(fmt.Sprintf("ExponentPushToken[%d]", i)))
+	}
+	assert.NoError(service.AddReceivers("ExponentPushToken[gone]", "ExponentPushToken[pending]"))
+	assert.NoError(service.Send(context.Background(), "subject", "message"))
+	assert.Len(sent, 152)
+	assert.Equal(pushMessage{To: "ExponentPushToken[0]", Title: "subject", Body: "message", Sound: "default"}, sent[0])
+	assert.Len(service.tickets, 152)
+
+	unregistered, err := service.CheckReceipts(context.Background())
+	assert.NoError(err)
+	assert.Equal([]string{"ExponentPushToken[gone]"}, unregistered)
+	assert.Equal(map[string]string{"id-ExponentPushToken[pending]": "ExponentPushToken[pending]"}, service.tickets)
+
+	// Test ticket error
+	assert.NoError(service.AddReceivers("ExponentPushToken[bad]"))
+	assert.ErrorContains(service.Send(context.Background(), "subject", "message"), "invalid token")
+
+	// Test error response
+	service.SetAccessToken("invalid")
+	assert.Error(service.Send(context.Background(), "subject", "message"))
+	_, err = service.CheckReceipts(context.Background())
+	assert.Error(err)
+}
@@ -2,9 +2,13 @@ package fcm
 
 import (
 	"context"
+	"regexp"
+	"strconv"
 
 	"github.com/appleboy/go-fcm"
 	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
 )
 
 // Compile-time check that fcm.Client satisfies fcmClient interface.
@@ -55,7 +59,8 @@ func (s *Service) AddReceivers(deviceTokens ...string) {
 	s.deviceTokens = append(s.deviceTokens, deviceTokens...)
 }
 
-// Send takes a message subject and a message body and sends them to all previously set devices.
+// Send takes a message subject and a message body and sends them to all previously set devices. The ID of each sent
+// message is recorded as receipt, see notify.ContextWithReceipts.
 func (s *Service) Send(ctx context.Context, subject, message string) error {
 	msg := &fcm.Message{
 		Notification: &fcm.Notification{
@@ -77,9 +82,20 @@ func (s *Service) Send(ctx context.Context, subject, message string) error {
 		default:
 			msg.To = deviceToken
 
-			_, err := s.client.SendWithRetry(msg, retryAttempts)
+			resp, err := s.client.SendWithRetry(msg, retryAttempts)
+			if err == nil && resp != nil && len(resp.Results) > 0 {
+				err = resp.Results[0].Error
+			}
 			if err != nil {
-				return errors.Wrapf(err, "failed to send message to FCM device with token '%s'", deviceToken)
+				return classifyError(errors.Wrapf(err, "failed to send message to FCM device with token '%s'", deviceToken))
+			}
+
+			if resp != nil && len(resp.Results) > 0 && resp.Results[0].MessageID != "" {
+				notify.RecordReceipt(ctx, notify.Receipt{
+					Service:   s,
+					Receiver:  deviceToken,
+					MessageID: resp.Results[0].MessageID,
+				})
 			}
 		}
 	}
@@ -104,3 +120,30 @@ func getMessageRetryAttempts(ctx context.Context) int {
 	}
 	return 0
 }
+
+// statusPattern extracts the HTTP status from the errors of the FCM client, which doesn't expose it otherwise.
+var statusPattern = regexp.MustCompile(`^(\d{3}) error: `)
+
+// classifyError classifies errors returned by FCM, for the request as well as for the device, see
+// notify.ClassifyError.
+func classifyError(err error) error {
+	var temporary interface{ Temporary() bool }
+	switch cause := errors.Cause(err); {
+	case errors.Is(cause, fcm.ErrNotRegistered), errors.Is(cause, fcm.ErrInvalidRegistration),
+		errors.Is(cause, fcm.ErrMissingRegistration), errors.Is(cause, fcm.ErrMismatchSenderID):
+		return notify.ClassifyError(err, notify.ErrInvalidReceiver)
+	case errors.Is(cause, fcm.ErrDeviceMessageRateExceeded), errors.Is(cause, fcm.ErrTopicsMessageRateExceeded):
+		return notify.ClassifyError(err, &notify.ErrRateLimited{})
+	case errors.Is(cause, fcm.ErrInvalidAPIKey):
+		return notify.ClassifyError(err, notify.ErrAuthFailed)
+	case errors.As(cause, &temporary) && temporary.Temporary():
+		return notify.ClassifyError(err, notify.ErrTransient)
+	}
+
+	if match := statusPattern.FindStringSubmatch(errors.Cause(err).Error()); match != nil {
+		status, _ := strconv.Atoi(match[1])
+		return notify.ClassifyStatus(status, err)
+	}
+
+	return notify.ClassifyNetworkError(err)
+}
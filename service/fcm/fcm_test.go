@@ -7,6 +7,8 @@ import (
 
 	"github.com/appleboy/go-fcm"
 	"github.com/stretchr/testify/require"
+
+	"github.com/nikoksr/notify"
 )
 
 func TestFCM_New(t *testing.T) {
@@ -119,6 +121,20 @@ func TestFCM_Send(t *testing.T) {
 	assert.NotNil(err)
 	mockClient.AssertExpectations(t)
 
+	// test fcm rejecting the device
+	mockClient = newMockFcmClient(t)
+	mockClient.On("SendWithRetry", &fcm.Message{
+		To: mockToken,
+		Notification: &fcm.Notification{
+			Title: "subject",
+			Body:  "message",
+		},
+	}, 0).Return(&fcm.Response{Failure: 1, Results: []fcm.Result{{Error: fcm.ErrNotRegistered}}}, nil)
+	svc.client = mockClient
+	err = svc.Send(context.Background(), "subject", "message")
+	assert.ErrorIs(err, notify.ErrInvalidReceiver)
+	mockClient.AssertExpectations(t)
+
 	// test fcm client multiple receivers
 	anotherMockToken := "another_device_token"
 	mockClient = newMockFcmClient(t)
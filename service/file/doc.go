@@ -0,0 +1,36 @@
+/*
+Package file provides a service for appending notifications as JSON lines to a file, e.g. as audit trail or as offline
+fallback channel. Files can be rotated by size and time.
+
+Usage:
+
+	package main
+
+	import (
+	    "context"
+	    "log"
+	    "time"
+
+	    "github.com/nikoksr/notify"
+	    "github.com/nikoksr/notify/service/file"
+	)
+
+	func main() {
+	    fileService := file.New("/var/log/notify/notifications.log")
+	    defer fileService.Close()
+
+	    // Rotate daily or once the file reaches 10 MB, and keep a week of files.
+	    fileService.SetRotationInterval(24 * time.Hour)
+	    fileService.SetMaxSize(10 << 20)
+	    fileService.SetMaxBackups(7)
+
+	    // Tell our notifier to use the file service.
+	    notify.UseServices(fileService)
+
+	    // Send a test message.
+	    if err := notify.Send(context.Background(), "Subject/Title", "The actual message - Hello, you awesome gophers! :)"); err != nil {
+	        log.Fatal(err)
+	    }
+	}
+*/
+package file
@@ -0,0 +1,150 @@
+package file
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Service appends notifications as JSON lines to a file, optionally rotating it by size and time.
+type Service struct {
+	mu sync.Mutex
+
+	path       string
+	maxSize    int64
+	interval   time.Duration
+	maxBackups int
+
+	file     *os.File
+	size     int64
+	openedAt time.Time
+
+	now func() time.Time
+}
+
+// New returns a new instance of a file notification service appending to the file at the given path. The file and
+// its rotated backups are created with permissions 0600. Call Close once the service is no longer used.
+func New(path string) *Service {
+	return &Service{
+		path: path,
+		now:  time.Now,
+	}
+}
+
+// SetMaxSize sets the size in bytes a file may grow to before it is rotated. Zero, the default, disables size based
+// rotation.
+func (s *Service) SetMaxSize(maxSize int64) {
+	s.maxSize = maxSize
+}
+
+// SetRotationInterval sets the interval files are rotated in, e.g. 24 hours for daily files. Intervals are aligned to
+// multiples of the interval since the zero time, so daily files rotate at midnight UTC. Zero, the default, disables
+// time based rotation.
+func (s *Service) SetRotationInterval(interval time.Duration) {
+	s.interval = interval
+}
+
+// SetMaxBackups sets the number of rotated files to keep; older ones are removed. Zero, the default, keeps all files.
+func (s *Service) SetMaxBackups(maxBackups int) {
+	s.maxBackups = maxBackups
+}
+
+// entry is the JSON line written for every notification.
+type entry struct {
+	Subject   string `json:"subject"`
+	Message   string `json:"message"`
+	Timestamp string `json:"timestamp"`
+}
+
+// openFile opens the file for appending. An existing file is continued, with its modification time counting as the
+// time it was opened.
+func (s *Service) openFile() error {
+	f, err := os.OpenFile(s.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return errors.Wrap(err, "open file")
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return errors.Wrap(err, "stat file")
+	}
+
+	s.file = f
+	s.size = info.Size()
+	s.openedAt = s.now()
+	if s.size > 0 {
+		s.openedAt = info.ModTime()
+	}
+
+	return nil
+}
+
+func (s *Service) closeFile() error {
+	if s.file == nil {
+		return nil
+	}
+
+	err := s.file.Close()
+	s.file = nil
+
+	return errors.Wrap(err, "close file")
+}
+
+// Send takes a message subject and a message body and appends them as JSON line to the file. The file is rotated
+// beforehand if required.
+func (s *Service) Send(ctx context.Context, subject, message string) error {
+	line, err := json.Marshal(entry{
+		Subject:   subject,
+		Message:   message,
+		Timestamp: s.now().UTC().Format(time.RFC3339),
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to encode entry")
+	}
+	line = append(line, '\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+
+	if s.file == nil {
+		if err = s.openFile(); err != nil {
+			return errors.Wrapf(err, "failed to open '%s'", s.path)
+		}
+	}
+
+	if s.shouldRotate(int64(len(line))) {
+		if err = s.rotate(); err != nil {
+			return errors.Wrapf(err, "failed to rotate '%s'", s.path)
+		}
+		if err = s.openFile(); err != nil {
+			return errors.Wrapf(err, "failed to open '%s'", s.path)
+		}
+	}
+
+	n, err := s.file.Write(line)
+	s.size += int64(n)
+	if err != nil {
+		return errors.Wrapf(err, "failed to write to '%s'", s.path)
+	}
+
+	return nil
+}
+
+// Close closes the underlying file. A subsequent Send reopens it.
+func (s *Service) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.closeFile()
+}
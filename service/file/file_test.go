@@ -0,0 +1,108 @@
+package file
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func readLines(t *testing.T, path string) []string {
+	t.Helper()
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	return strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+}
+
+func TestFile_Send(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	path := filepath.Join(t.TempDir(), "notify.log")
+	service := New(path)
+	service.now = func() time.Time { return time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC) }
+	defer func() { _ = service.Close() }()
+
+	assert.NoError(service.Send(context.Background(), "subject", "line 1\nline 2"))
+	assert.NoError(service.Close())
+
+	// Sending after Close reopens and continues the file.
+	assert.NoError(service.Send(context.Background(), "subject", "message"))
+
+	assert.Equal([]string{
+		`{"subject":"subject","message":"line 1\nline 2","timestamp":"2023-01-02T03:04:05Z"}`,
+		`{"subject":"subject","message":"message","timestamp":"2023-01-02T03:04:05Z"}`,
+	}, readLines(t, path))
+}
+
+func TestFile_RotateBySize(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "notify.log")
+
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	service := New(path)
+	service.now = func() time.Time {
+		now = now.Add(time.Second)
+		return now
+	}
+	service.SetMaxSize(100)
+	service.SetMaxBackups(2)
+	defer func() { _ = service.Close() }()
+
+	// Every entry is about 75 bytes, so every entry starts a new file.
+	for i := 0; i < 4; i++ {
+		assert.NoError(service.Send(context.Background(), "subject", "message"))
+	}
+
+	backups, err := filepath.Glob(filepath.Join(dir, "notify-20230102T*.log"))
+	assert.NoError(err)
+	assert.Len(backups, 2)
+	for _, backup := range backups {
+		assert.Len(readLines(t, backup), 1)
+	}
+	assert.Len(readLines(t, path), 1)
+}
+
+func TestFile_RotateByTime(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "notify.log")
+
+	now := time.Date(2023, 1, 2, 23, 59, 0, 0, time.UTC)
+	service := New(path)
+	service.now = func() time.Time { return now }
+	service.SetRotationInterval(24 * time.Hour)
+	defer func() { _ = service.Close() }()
+
+	assert.NoError(service.Send(context.Background(), "subject", "message"))
+	assert.NoError(service.Send(context.Background(), "subject", "message"))
+
+	now = now.Add(2 * time.Minute)
+	assert.NoError(service.Send(context.Background(), "subject", "message"))
+
+	assert.Len(readLines(t, filepath.Join(dir, "notify-20230103T000100.000.log")), 2)
+	assert.Len(readLines(t, path), 1)
+}
+
+func TestFile_SendErrors(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := New(filepath.Join(t.TempDir(), "missing", "notify.log"))
+	assert.Error(service.Send(context.Background(), "subject", "message"))
+}
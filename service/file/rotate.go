@@ -0,0 +1,79 @@
+package file
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// backupTimeFormat is the timestamp format used in the names of rotated files. It sorts lexically by time.
+const backupTimeFormat = "20060102T150405.000"
+
+// splitPath splits the path into the part before and after the extension, e.g. "/var/log/notify" and ".log".
+func splitPath(path string) (prefix, ext string) {
+	ext = filepath.Ext(path)
+	return strings.TrimSuffix(path, ext), ext
+}
+
+// backupPath returns the path the current file is renamed to when rotated at the given time.
+func backupPath(path string, t time.Time) string {
+	prefix, ext := splitPath(path)
+	return prefix + "-" + t.UTC().Format(backupTimeFormat) + ext
+}
+
+// shouldRotate reports whether the current file must be rotated before writing n more bytes.
+func (s *Service) shouldRotate(n int64) bool {
+	if s.size == 0 {
+		return false
+	}
+	if s.maxSize > 0 && s.size+n > s.maxSize {
+		return true
+	}
+	if s.interval > 0 && !s.now().Truncate(s.interval).Equal(s.openedAt.Truncate(s.interval)) {
+		return true
+	}
+
+	return false
+}
+
+// rotate closes the current file, renames it to a backup and removes backups exceeding the limit.
+func (s *Service) rotate() error {
+	if err := s.closeFile(); err != nil {
+		return err
+	}
+
+	if err := os.Rename(s.path, backupPath(s.path, s.now())); err != nil {
+		return errors.Wrap(err, "rename file")
+	}
+
+	return s.removeOldBackups()
+}
+
+// removeOldBackups removes the oldest backups, so that at most maxBackups remain.
+func (s *Service) removeOldBackups() error {
+	if s.maxBackups <= 0 {
+		return nil
+	}
+
+	prefix, ext := splitPath(s.path)
+	backups, err := filepath.Glob(prefix + "-*" + ext)
+	if err != nil {
+		return errors.Wrap(err, "list backups")
+	}
+	if len(backups) <= s.maxBackups {
+		return nil
+	}
+
+	sort.Strings(backups)
+	for _, backup := range backups[:len(backups)-s.maxBackups] {
+		if err = os.Remove(backup); err != nil {
+			return errors.Wrap(err, "remove backup")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,35 @@
+/*
+Package flock provides a service for sending messages to Flock channels and users.
+
+Usage:
+
+	package main
+
+	import (
+	    "context"
+	    "log"
+
+	    "github.com/nikoksr/notify"
+	    "github.com/nikoksr/notify/service/flock"
+	)
+
+	func main() {
+	    flockService := flock.New()
+
+	    // Post through incoming webhooks...
+	    flockService.AddWebhooks("https://api.flock.com/hooks/sendMessage/your-webhook-id")
+
+	    // ...or as bot to channels and users.
+	    flockService.SetBotToken("your-bot-token")
+	    flockService.AddReceivers("g:channel-id", "u:user-id")
+
+	    // Tell our notifier to use the flock service.
+	    notify.UseServices(flockService)
+
+	    // Send a test message.
+	    if err := notify.Send(context.Background(), "Subject/Title", "The actual message"); err != nil {
+	        log.Fatal(err)
+	    }
+	}
+*/
+package flock
@@ -0,0 +1,173 @@
+package flock
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
+)
+
+const defaultAPIURL = "https://api.flock.co/v1"
+
+// Service encapsulates the Flock client.
+type Service struct {
+	client      *http.Client
+	apiURL      string
+	botToken    string
+	webhookURLs []string
+	receivers   []string
+}
+
+// New returns a new instance of a Flock notification service. Messages can be sent through incoming webhooks, see
+// AddWebhooks, and, given a bot token set via SetBotToken, to channels and users through the chat.sendMessage method.
+// For more information about the Flock APIs:
+//
+//	-> https://docs.flock.com/display/flockos/Incoming+Webhooks
+//	-> https://docs.flock.com/display/flockos/chat.sendMessage
+func New() *Service {
+	return &Service{
+		client: &http.Client{
+			Transport: notify.NewRateLimitTransport(nil),
+			Timeout:   10 * time.Second,
+		},
+		apiURL:      defaultAPIURL,
+		webhookURLs: []string{},
+		receivers:   []string{},
+	}
+}
+
+// WithClient sets the http client to be used for sending requests. Calling this method is optional, the default client
+// will be used if this method is not called.
+func (s *Service) WithClient(client *http.Client) {
+	if client != nil {
+		s.client = client
+	}
+}
+
+// AddWebhooks takes incoming webhook URLs, e.g. https://api.flock.com/hooks/sendMessage/xxx, and adds them to the
+// internal webhook list. Each of them posts to the channel it was created for.
+func (s *Service) AddWebhooks(webhookURLs ...string) {
+	s.webhookURLs = append(s.webhookURLs, webhookURLs...)
+}
+
+// SetBotToken sets the token of the bot sending messages to the receivers added via AddReceivers.
+func (s *Service) SetBotToken(token string) {
+	s.botToken = token
+}
+
+// AddReceivers takes IDs of channels, e.g. "g:abc", or users, e.g. "u:xyz", and adds them to the internal receiver
+// list. Sending to them requires a bot token.
+func (s *Service) AddReceivers(ids ...string) {
+	s.receivers = append(s.receivers, ids...)
+}
+
+type flockMessage struct {
+	To      string `json:"to,omitempty"`
+	Text    string `json:"text"`
+	FlockML string `json:"flockml,omitempty"`
+}
+
+// Send takes a message subject and a message body and sends them to all previously set webhooks and receivers. The
+// subject is formatted bold for clients supporting FlockML.
+func (s *Service) Send(ctx context.Context, subject, message string) error {
+	msg := flockMessage{
+		Text:    subject + "\n" + message,
+		FlockML: "<flockml><b>" + escape(subject) + "</b><br/>" + escape(message) + "</flockml>",
+	}
+
+	for _, webhookURL := range s.webhookURLs {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			if err := s.post(ctx, webhookURL, msg); err != nil {
+				return errors.Wrap(err, "failed to send message to webhook")
+			}
+		}
+	}
+
+	if len(s.receivers) > 0 && s.botToken == "" {
+		return errors.New("failed to send message: no bot token set")
+	}
+	endpoint := s.apiURL + "/chat.sendMessage?token=" + url.QueryEscape(s.botToken)
+	for _, receiver := range s.receivers {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			m := msg
+			m.To = receiver
+			if err := s.post(ctx, endpoint, m); err != nil {
+				return errors.Wrapf(err, "failed to send message to %q", receiver)
+			}
+		}
+	}
+
+	return nil
+}
+
+// escape escapes text for use in FlockML and converts line breaks.
+func escape(text string) string {
+	var buf bytes.Buffer
+	for _, r := range text {
+		switch r {
+		case '<':
+			buf.WriteString("&lt;")
+		case '>':
+			buf.WriteString("&gt;")
+		case '&':
+			buf.WriteString("&amp;")
+		case '"':
+			buf.WriteString("&quot;")
+		case '\n':
+			buf.WriteString("<br/>")
+		default:
+			buf.WriteRune(r)
+		}
+	}
+
+	return buf.String()
+}
+
+func (s *Service) post(ctx context.Context, endpoint string, msg flockMessage) error {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return errors.Wrap(err, "marshal message")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "create request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "send request")
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		b, _ := io.ReadAll(resp.Body)
+
+		var errResp struct {
+			Error       string `json:"error"`
+			Description string `json:"description"`
+		}
+		if json.Unmarshal(b, &errResp) == nil && errResp.Error != "" {
+			return notify.ClassifyResponse(resp, fmt.Errorf("flock returned status code %d: %s: %s", resp.StatusCode, errResp.Error, errResp.Description))
+		}
+
+		return notify.ClassifyResponse(resp, fmt.Errorf("flock returned status code %d: %s", resp.StatusCode, string(b)))
+	}
+
+	return nil
+}
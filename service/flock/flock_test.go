@@ -0,0 +1,67 @@
+package flock
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlock_Send(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	var (
+		webhook []flockMessage
+		api     []flockMessage
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var msg flockMessage
+		_ = json.NewDecoder(r.Body).Decode(&msg)
+
+		switch r.URL.Path {
+		case "/hooks/sendMessage/hook":
+			webhook = append(webhook, msg)
+		case "/v1/chat.sendMessage":
+			if r.URL.Query().Get("token") != "token" {
+				w.WriteHeader(http.StatusUnauthorized)
+				_, _ = w.Write([]byte(`{"error":"InvalidToken","description":"The token is invalid"}`))
+				return
+			}
+			api = append(api, msg)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"uid":"1"}`))
+	}))
+	defer server.Close()
+
+	service := New()
+	service.apiURL = server.URL + "/v1"
+	service.AddWebhooks(server.URL + "/hooks/sendMessage/hook")
+	service.AddReceivers("g:channel")
+
+	// Receivers require a bot token
+	assert.Error(service.Send(context.Background(), "subject", "message"))
+
+	webhook = nil
+	service.SetBotToken("token")
+	assert.NoError(service.Send(context.Background(), "subject", "a < b\nc"))
+
+	want := flockMessage{
+		Text:    "subject\na < b\nc",
+		FlockML: "<flockml><b>subject</b><br/>a &lt; b<br/>c</flockml>",
+	}
+	assert.Equal([]flockMessage{want}, webhook)
+	want.To = "g:channel"
+	assert.Equal([]flockMessage{want}, api)
+
+	// Test error response
+	service.SetBotToken("invalid")
+	assert.ErrorContains(service.Send(context.Background(), "subject", "message"), "InvalidToken")
+}
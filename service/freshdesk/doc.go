@@ -0,0 +1,33 @@
+/*
+Package freshdesk provides a service for creating Freshdesk tickets.
+
+Usage:
+
+	package main
+
+	import (
+	    "context"
+	    "log"
+
+	    "github.com/nikoksr/notify"
+	    "github.com/nikoksr/notify/service/freshdesk"
+	)
+
+	func main() {
+	    freshdeskService := freshdesk.New("your-domain", "your-api-key")
+
+	    // Set who requests the tickets and how urgent they are.
+	    freshdeskService.SetRequester("alerts@example.com")
+	    freshdeskService.SetPriority(freshdesk.PriorityHigh)
+	    freshdeskService.AddTags("notify")
+
+	    // Tell our notifier to use the freshdesk service.
+	    notify.UseServices(freshdeskService)
+
+	    // Create a ticket.
+	    if err := notify.Send(context.Background(), "Subject/Title", "The actual message - Hello, you awesome gophers! :)"); err != nil {
+	        log.Fatal(err)
+	    }
+	}
+*/
+package freshdesk
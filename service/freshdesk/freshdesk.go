@@ -0,0 +1,198 @@
+package freshdesk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
+)
+
+// Priority represents the priority of a ticket.
+type Priority int
+
+// All ticket priorities supported by Freshdesk.
+const (
+	PriorityLow Priority = iota + 1
+	PriorityMedium
+	PriorityHigh
+	PriorityUrgent
+)
+
+// priorities maps the priorities of notify.WithPriority to Freshdesk priorities.
+var priorities = map[notify.Priority]Priority{
+	notify.PriorityLow:      PriorityLow,
+	notify.PriorityNormal:   PriorityMedium,
+	notify.PriorityHigh:     PriorityHigh,
+	notify.PriorityCritical: PriorityUrgent,
+}
+
+// Status represents the status of a ticket.
+type Status int
+
+// All ticket statuses supported by Freshdesk.
+const (
+	StatusOpen Status = iota + 2
+	StatusPending
+	StatusResolved
+	StatusClosed
+)
+
+// Service allow you to configure the Freshdesk service.
+type Service struct {
+	client *http.Client
+	apiURL string
+	apiKey string
+
+	requesterEmail string
+	requesterID    int64
+	priority       Priority
+	status         Status
+	tags           []string
+}
+
+// New returns a new instance of a Freshdesk notification service for the given domain, e.g. "acme" for
+// acme.freshdesk.com. The API key can be found in the profile settings of an agent.
+// For more information about creating tickets:
+//
+//	-> https://developers.freshdesk.com/api/#create_ticket
+func New(domain, apiKey string) *Service {
+	return &Service{
+		client: &http.Client{
+			Transport: notify.NewRateLimitTransport(nil),
+			Timeout:   10 * time.Second,
+		},
+		apiURL:   fmt.Sprintf("https://%s.freshdesk.com/api/v2", domain),
+		apiKey:   apiKey,
+		priority: PriorityLow,
+		status:   StatusOpen,
+		tags:     []string{},
+	}
+}
+
+// WithClient sets the http client to be used for sending requests. Calling this method is optional, the default client
+// will be used if this method is not called.
+func (s *Service) WithClient(client *http.Client) {
+	if client != nil {
+		s.client = client
+	}
+}
+
+// SetRequester sets the email address of the requester of all tickets. Freshdesk creates a contact if none with the
+// email address exists. Either a requester email address or ID is required.
+func (s *Service) SetRequester(email string) {
+	s.requesterEmail = email
+	s.requesterID = 0
+}
+
+// SetRequesterID sets the ID of the contact all tickets are requested by. Either a requester email address or ID is
+// required.
+func (s *Service) SetRequesterID(id int64) {
+	s.requesterID = id
+	s.requesterEmail = ""
+}
+
+// SetPriority sets the priority of tickets. Defaults to PriorityLow. The priority of a notification, see
+// notify.WithPriority, overrides it: low is low, normal medium, high high and critical urgent.
+func (s *Service) SetPriority(priority Priority) {
+	s.priority = priority
+}
+
+// SetStatus sets the status of all tickets. Defaults to StatusOpen.
+func (s *Service) SetStatus(status Status) {
+	s.status = status
+}
+
+// AddTags adds tags to all tickets.
+func (s *Service) AddTags(tags ...string) {
+	s.tags = append(s.tags, tags...)
+}
+
+type ticket struct {
+	Subject     string   `json:"subject"`
+	Description string   `json:"description"`
+	Email       string   `json:"email,omitempty"`
+	RequesterID int64    `json:"requester_id,omitempty"`
+	Priority    Priority `json:"priority"`
+	Status      Status   `json:"status"`
+	Tags        []string `json:"tags,omitempty"`
+}
+
+// formatDescription escapes the message and converts line breaks, as descriptions are HTML.
+func formatDescription(message string) string {
+	return strings.ReplaceAll(html.EscapeString(message), "\n", "<br>")
+}
+
+// Validate checks that the domain, API key and requester are set and that the email address of the requester is
+// well-formed, see notify.Validator.
+func (s *Service) Validate() error {
+	var problems notify.ConfigErrors
+	if strings.HasPrefix(s.apiURL, "https://.") {
+		problems.Addf("domain is missing")
+	}
+	problems.Require("API key", s.apiKey)
+	switch {
+	case s.requesterEmail != "":
+		problems.Check("requester", notify.ValidateMailAddress(s.requesterEmail))
+	case s.requesterID == 0:
+		problems.Addf("no requester set")
+	}
+
+	return problems.Err()
+}
+
+// Send takes a message subject and a message body and creates a ticket with the subject as title and the message as
+// description.
+func (s *Service) Send(ctx context.Context, subject, message string) error {
+	if s.requesterEmail == "" && s.requesterID == 0 {
+		return errors.New("failed to create Freshdesk ticket: no requester set")
+	}
+
+	t := ticket{
+		Subject:     subject,
+		Description: formatDescription(message),
+		Email:       s.requesterEmail,
+		RequesterID: s.requesterID,
+		Priority:    s.priority,
+		Status:      s.status,
+	}
+	if mapped, ok := priorities[notify.SendOptionsFromContext(ctx).Priority]; ok {
+		t.Priority = mapped
+	}
+	if len(s.tags) > 0 {
+		t.Tags = s.tags
+	}
+
+	body, err := json.Marshal(t)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal ticket")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.apiURL+"/tickets", bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "failed to create request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.SetBasicAuth(s.apiKey, "X")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "failed to create Freshdesk ticket")
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusCreated {
+		result, _ := io.ReadAll(resp.Body)
+		return notify.ClassifyResponse(resp, fmt.Errorf("freshdesk returned status code %d: %s", resp.StatusCode, string(result)))
+	}
+
+	return nil
+}
@@ -0,0 +1,111 @@
+package freshdesk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nikoksr/notify"
+)
+
+func TestFreshdesk_New(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := New("acme", "key")
+	assert.NotNil(service)
+	assert.Equal("https://acme.freshdesk.com/api/v2", service.apiURL)
+	assert.Equal(PriorityLow, service.priority)
+	assert.Equal(StatusOpen, service.status)
+}
+
+func TestFreshdesk_Validate(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := New("acme", "key")
+	service.SetRequester("jane@example.com")
+	assert.NoError(service.Validate())
+	service.SetRequesterID(42)
+	assert.NoError(service.Validate())
+
+	err := New("", "").Validate()
+	assert.ErrorIs(err, notify.ErrInvalidConfig)
+	assert.ErrorContains(err, "domain is missing")
+	assert.ErrorContains(err, "API key is missing")
+	assert.ErrorContains(err, "no requester set")
+
+	service.SetRequester("jane")
+	assert.ErrorContains(service.Validate(), "requester: mail address")
+}
+
+func TestFreshdesk_Send(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	var got []map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key, password, ok := r.BasicAuth()
+		if !ok || key != "key" || password != "X" || r.URL.Path != "/tickets" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"code":"invalid_credentials"}`))
+			return
+		}
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		got = append(got, body)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":1}`))
+	}))
+	defer server.Close()
+
+	service := New("acme", "key")
+	service.apiURL = server.URL
+
+	// No requester
+	assert.Error(service.Send(context.Background(), "subject", "message"))
+
+	service.SetRequester("jane@example.com")
+	service.SetPriority(PriorityUrgent)
+	service.SetStatus(StatusPending)
+	service.AddTags("outage")
+	assert.NoError(service.Send(context.Background(), "Billing outage", "Invoices <b>fail</b>\nsince 10am"))
+
+	service.SetRequesterID(42)
+	assert.NoError(service.Send(context.Background(), "subject", "message"))
+
+	assert.Equal([]map[string]any{
+		{
+			"subject":     "Billing outage",
+			"description": "Invoices &lt;b&gt;fail&lt;/b&gt;<br>since 10am",
+			"email":       "jane@example.com",
+			"priority":    float64(4),
+			"status":      float64(3),
+			"tags":        []any{"outage"},
+		},
+		{
+			"subject":      "subject",
+			"description":  "message",
+			"requester_id": float64(42),
+			"priority":     float64(4),
+			"status":       float64(3),
+			"tags":         []any{"outage"},
+		},
+	}, got)
+
+	// The priority of the notification overrides the priority of the service.
+	ctx := notify.ContextWithSendOptions(context.Background(), notify.WithPriority(notify.PriorityNormal))
+	assert.NoError(service.Send(ctx, "subject", "message"))
+	assert.Equal(float64(PriorityMedium), got[2]["priority"])
+
+	// Test error response
+	service.apiKey = "invalid"
+	assert.Error(service.Send(context.Background(), "subject", "message"))
+}
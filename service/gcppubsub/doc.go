@@ -0,0 +1,40 @@
+/*
+Package gcppubsub provides a service for publishing notifications to Google Cloud Pub/Sub topics.
+
+Usage:
+
+	package main
+
+	import (
+	    "context"
+	    "log"
+
+	    "github.com/nikoksr/notify"
+	    "github.com/nikoksr/notify/service/gcppubsub"
+	)
+
+	func main() {
+	    ctx := context.Background()
+
+	    // Uses Application Default Credentials.
+	    pubsubService, err := gcppubsub.New(ctx, "your-project-id")
+	    if err != nil {
+	        log.Fatal(err)
+	    }
+	    defer func() { _ = pubsubService.Close() }()
+
+	    // Publish to these topics, with attributes subscriptions can filter on.
+	    pubsubService.AddReceivers("notifications")
+	    pubsubService.AddAttribute("severity", "high")
+	    pubsubService.SetOrderingKey("billing-api")
+
+	    // Tell our notifier to use the pubsub service.
+	    notify.UseServices(pubsubService)
+
+	    // Send a test message.
+	    if err := notify.Send(ctx, "Subject/Title", "The actual message"); err != nil {
+	        log.Fatal(err)
+	    }
+	}
+*/
+package gcppubsub
@@ -0,0 +1,156 @@
+package gcppubsub
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/pubsub"
+	"github.com/pkg/errors"
+	"google.golang.org/api/option"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/nikoksr/notify"
+)
+
+// Compile-time check to ensure that Service implements the notify.JSONSender interface.
+var _ notify.JSONSender = new(Service)
+
+// Service encapsulates the Google Cloud Pub/Sub client.
+type Service struct {
+	client      *pubsub.Client
+	topicIDs    []string
+	attributes  map[string]string
+	orderingKey string
+
+	mu     sync.Mutex
+	topics map[string]*pubsub.Topic
+}
+
+// payload is the JSON document published for every notification.
+type payload struct {
+	Subject   string    `json:"subject"`
+	Message   string    `json:"message"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// New returns a new instance of a Google Cloud Pub/Sub notification service for the given project. Without options,
+// Application Default Credentials are used; use option.WithCredentialsFile or similar to pass other credentials.
+// For more information about authentication:
+//
+//	-> https://cloud.google.com/pubsub/docs/authentication
+func New(ctx context.Context, projectID string, opts ...option.ClientOption) (*Service, error) {
+	client, err := pubsub.NewClient(ctx, projectID, opts...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create pubsub client")
+	}
+
+	return &Service{
+		client:     client,
+		topicIDs:   []string{},
+		attributes: map[string]string{},
+		topics:     map[string]*pubsub.Topic{},
+	}, nil
+}
+
+// AddReceivers takes topic IDs and adds them to the internal receiver list. The Send method publishes a JSON document
+// with the fields "subject", "message" and "timestamp" to each of them.
+func (s *Service) AddReceivers(topicIDs ...string) {
+	s.topicIDs = append(s.topicIDs, topicIDs...)
+}
+
+// AddAttribute adds an attribute that is sent with every message. Subscriptions can filter messages by attributes.
+func (s *Service) AddAttribute(key, value string) {
+	s.attributes[key] = value
+}
+
+// SetOrderingKey sets the ordering key of published messages. Messages with the same ordering key are delivered in
+// order to subscriptions with message ordering enabled. Use an empty string to disable ordering again.
+func (s *Service) SetOrderingKey(key string) {
+	s.orderingKey = key
+}
+
+// topic returns the cached handle of the given topic.
+func (s *Service) topic(id string) *pubsub.Topic {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, ok := s.topics[id]
+	if !ok {
+		t = s.client.Topic(id)
+		t.EnableMessageOrdering = true
+		s.topics[id] = t
+	}
+
+	return t
+}
+
+// Close flushes pending messages and closes the client.
+func (s *Service) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for id, t := range s.topics {
+		t.Stop()
+		delete(s.topics, id)
+	}
+
+	return s.client.Close()
+}
+
+// Send takes a message subject and a message body and publishes them to all previously set topics, waiting for the
+// server to acknowledge each message.
+func (s *Service) Send(ctx context.Context, subject, message string) error {
+	return s.SendJSON(ctx, &payload{Subject: subject, Message: message, Timestamp: time.Now().UTC()})
+}
+
+// SendJSON publishes the JSON encoding of v to all previously set topics, waiting for the server to acknowledge each
+// message.
+func (s *Service) SendJSON(ctx context.Context, v any) error {
+	if len(s.topicIDs) == 0 {
+		return nil
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal payload")
+	}
+
+	for _, topicID := range s.topicIDs {
+		msg := &pubsub.Message{Data: data, OrderingKey: s.orderingKey}
+		if len(s.attributes) > 0 {
+			msg.Attributes = s.attributes
+		}
+
+		t := s.topic(topicID)
+		if _, err = t.Publish(ctx, msg).Get(ctx); err != nil {
+			// A failed publish pauses publishing for the ordering key until resumed.
+			if s.orderingKey != "" {
+				t.ResumePublish(s.orderingKey)
+			}
+			return classifyError(errors.Wrapf(err, "failed to publish to topic %q", topicID))
+		}
+	}
+
+	return nil
+}
+
+// classifyError classifies errors of the Pub/Sub API by their gRPC status code, see notify.ClassifyError.
+func classifyError(err error) error {
+	switch status.Code(err) {
+	case codes.Unauthenticated, codes.PermissionDenied:
+		return notify.ClassifyError(err, notify.ErrAuthFailed)
+	case codes.NotFound:
+		return notify.ClassifyError(err, notify.ErrInvalidReceiver)
+	case codes.ResourceExhausted:
+		return notify.ClassifyError(err, &notify.ErrRateLimited{})
+	case codes.Unavailable, codes.DeadlineExceeded, codes.Aborted, codes.Internal:
+		return notify.ClassifyError(err, notify.ErrTransient)
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange, codes.Unimplemented:
+		return notify.ClassifyError(err, notify.ErrPermanent)
+	default:
+		return notify.ClassifyNetworkError(err)
+	}
+}
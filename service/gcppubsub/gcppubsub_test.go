@@ -0,0 +1,86 @@
+package gcppubsub
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"cloud.google.com/go/pubsub/pstest"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/option"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/nikoksr/notify"
+)
+
+func TestGCPPubSub_Send(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+	ctx := context.Background()
+
+	server := pstest.NewServer()
+	defer func() { _ = server.Close() }()
+
+	conn, err := grpc.Dial(server.Addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	assert.NoError(err)
+
+	service, err := New(ctx, "project", option.WithGRPCConn(conn))
+	assert.NoError(err)
+	defer func() { _ = service.Close() }()
+
+	_, err = service.client.CreateTopic(ctx, "alerts")
+	assert.NoError(err)
+
+	// No receivers added
+	assert.NoError(service.Send(ctx, "subject", "message"))
+
+	service.AddReceivers("alerts")
+	service.AddAttribute("severity", "high")
+	service.SetOrderingKey("db-1")
+	assert.NoError(service.Send(ctx, "subject", "message"))
+
+	messages := server.Messages()
+	assert.Len(messages, 1)
+	assert.Equal(map[string]string{"severity": "high"}, messages[0].Attributes)
+	assert.Equal("db-1", messages[0].OrderingKey)
+
+	var p payload
+	assert.NoError(json.Unmarshal(messages[0].Data, &p))
+	assert.Equal("subject", p.Subject)
+	assert.Equal("message", p.Message)
+
+	// Test error response
+	service.AddReceivers("does-not-exist")
+	assert.ErrorIs(service.Send(ctx, "subject", "message"), notify.ErrInvalidReceiver)
+}
+
+func TestGCPPubSub_SendJSON(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+	ctx := context.Background()
+
+	server := pstest.NewServer()
+	defer func() { _ = server.Close() }()
+
+	conn, err := grpc.Dial(server.Addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	assert.NoError(err)
+
+	service, err := New(ctx, "project", option.WithGRPCConn(conn))
+	assert.NoError(err)
+	defer func() { _ = service.Close() }()
+
+	_, err = service.client.CreateTopic(ctx, "deploys")
+	assert.NoError(err)
+
+	service.AddReceivers("deploys")
+	assert.NoError(service.SendJSON(ctx, map[string]any{"service": "api", "version": "1.2.3"}))
+
+	messages := server.Messages()
+	assert.Len(messages, 1)
+	assert.JSONEq(`{"service": "api", "version": "1.2.3"}`, string(messages[0].Data))
+
+	assert.Error(service.SendJSON(ctx, make(chan int)))
+}
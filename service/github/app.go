@@ -0,0 +1,81 @@
+package github
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// appCredentials authenticate as installation of a GitHub App and cache the installation access token.
+type appCredentials struct {
+	appID          int64
+	installationID int64
+	key            *rsa.PrivateKey
+
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+}
+
+// jwt creates a JSON Web Token identifying the app, as required for requesting installation access tokens.
+func (a *appCredentials) jwt(now time.Time) (string, error) {
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"RS256","typ":"JWT"}`))
+	claims, err := json.Marshal(map[string]any{
+		// Issued 60 seconds in the past to allow for clock drift.
+		"iat": now.Add(-time.Minute).Unix(),
+		"exp": now.Add(9 * time.Minute).Unix(),
+		"iss": strconv.FormatInt(a.appID, 10),
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "marshal claims")
+	}
+
+	unsigned := header + "." + base64.RawURLEncoding.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(unsigned))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, a.key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", errors.Wrap(err, "sign token")
+	}
+
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// installationToken returns a cached installation access token or requests a new one if there is none or it is about
+// to expire.
+func (a *appCredentials) installationToken(ctx context.Context, s *Service) (string, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	now := time.Now()
+	if a.token != "" && now.Before(a.expiresAt) {
+		return a.token, nil
+	}
+
+	jwt, err := a.jwt(now)
+	if err != nil {
+		return "", err
+	}
+
+	var resp struct {
+		Token     string    `json:"token"`
+		ExpiresAt time.Time `json:"expires_at"`
+	}
+	path := "/app/installations/" + strconv.FormatInt(a.installationID, 10) + "/access_tokens"
+	if err = s.post(ctx, path, "Bearer "+jwt, struct{}{}, &resp); err != nil {
+		return "", errors.Wrap(err, "request installation access token")
+	}
+
+	a.token = resp.Token
+	a.expiresAt = resp.ExpiresAt.Add(-5 * time.Minute)
+
+	return a.token, nil
+}
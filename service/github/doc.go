@@ -0,0 +1,46 @@
+/*
+Package github provides a service for opening issues and commenting on issues and pull requests on GitHub.
+
+Usage:
+
+	package main
+
+	import (
+	    "context"
+	    "log"
+	    "os"
+
+	    "github.com/nikoksr/notify"
+	    "github.com/nikoksr/notify/service/github"
+	)
+
+	func main() {
+	    // Authenticate with a personal access token...
+	    githubService := github.New("your-access-token")
+
+	    // ...or as installation of a GitHub App.
+	    privateKey, _ := os.ReadFile("your-app.private-key.pem")
+	    githubService, err := github.NewWithApp(123456, 7890123, privateKey)
+	    if err != nil {
+	        log.Fatal(err)
+	    }
+
+	    // Open issues in these repositories.
+	    githubService.AddReceivers("owner/repo")
+	    githubService.SetLabels("ci-failure")
+
+	    // Comment on these issues and pull requests.
+	    if err := githubService.AddCommentReceivers("owner/repo#42"); err != nil {
+	        log.Fatal(err)
+	    }
+
+	    // Tell our notifier to use the github service.
+	    notify.UseServices(githubService)
+
+	    // Send a test message.
+	    if err := notify.Send(context.Background(), "Nightly build failed", "See the logs for details."); err != nil {
+	        log.Fatal(err)
+	    }
+	}
+*/
+package github
@@ -0,0 +1,227 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
+)
+
+// DefaultAPIURL is the base URL of the GitHub REST API. GitHub Enterprise Server instances use
+// https://HOSTNAME/api/v3 instead.
+const DefaultAPIURL = "https://api.github.com"
+
+// Service encapsulates the GitHub client.
+type Service struct {
+	client   *http.Client
+	apiURL   string
+	token    string
+	app      *appCredentials
+	repos    []string
+	comments []issueRef
+	labels   []string
+}
+
+// issueRef identifies an issue or pull request.
+type issueRef struct {
+	repo   string
+	number int
+}
+
+// New returns a new instance of a GitHub notification service authenticated with a personal access token. The token
+// needs write access to issues (and pull requests, if commenting on them) of all configured repositories.
+// For more information about personal access tokens:
+//
+//	-> https://docs.github.com/en/authentication/keeping-your-account-and-data-secure/managing-your-personal-access-tokens
+func New(token string) *Service {
+	return &Service{
+		client: &http.Client{
+			Transport: notify.NewRateLimitTransport(nil),
+			Timeout:   10 * time.Second,
+		},
+		apiURL:   DefaultAPIURL,
+		token:    token,
+		repos:    []string{},
+		comments: []issueRef{},
+	}
+}
+
+// NewWithApp returns a new instance of a GitHub notification service authenticated as installation of a GitHub App.
+// The private key is the PEM encoded key generated in the app's settings. Installation access tokens are requested
+// and cached automatically.
+// For more information about authenticating as a GitHub App installation:
+//
+//	-> https://docs.github.com/en/apps/creating-github-apps/authenticating-with-a-github-app/authenticating-as-a-github-app-installation
+func NewWithApp(appID, installationID int64, privateKey []byte) (*Service, error) {
+	block, _ := pem.Decode(privateKey)
+	if block == nil {
+		return nil, errors.New("failed to decode private key: no PEM data found")
+	}
+
+	key, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+	if err != nil {
+		parsed, err8 := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err8 != nil {
+			return nil, errors.Wrap(err, "failed to parse private key")
+		}
+		var ok bool
+		if key, ok = parsed.(*rsa.PrivateKey); !ok {
+			return nil, errors.New("failed to parse private key: not an RSA key")
+		}
+	}
+
+	s := New("")
+	s.app = &appCredentials{
+		appID:          appID,
+		installationID: installationID,
+		key:            key,
+	}
+
+	return s, nil
+}
+
+// WithClient sets the http client to be used for sending requests. Calling this method is optional, the default client
+// will be used if this method is not called.
+func (s *Service) WithClient(client *http.Client) {
+	if client != nil {
+		s.client = client
+	}
+}
+
+// WithAPIURL sets the base URL of the API, e.g. for GitHub Enterprise Server.
+func (s *Service) WithAPIURL(apiURL string) {
+	s.apiURL = strings.TrimSuffix(apiURL, "/")
+}
+
+// AddReceivers takes repositories in the "owner/repo" format and adds them to the internal receiver list. The Send
+// method opens a new issue in each of them.
+func (s *Service) AddReceivers(repos ...string) {
+	s.repos = append(s.repos, repos...)
+}
+
+// AddCommentReceivers takes references to existing issues or pull requests in the "owner/repo#number" format and adds
+// them to the internal receiver list. The Send method comments on each of them.
+func (s *Service) AddCommentReceivers(refs ...string) error {
+	for _, ref := range refs {
+		repo, number, found := strings.Cut(ref, "#")
+		n, err := strconv.Atoi(number)
+		if !found || err != nil || strings.Count(repo, "/") != 1 {
+			return fmt.Errorf("invalid issue reference %q, expected owner/repo#number", ref)
+		}
+		s.comments = append(s.comments, issueRef{repo: repo, number: n})
+	}
+
+	return nil
+}
+
+// SetLabels sets the labels applied to opened issues. Labels that don't exist yet get created by GitHub.
+func (s *Service) SetLabels(labels ...string) {
+	s.labels = labels
+}
+
+func (s *Service) authorization(ctx context.Context) (string, error) {
+	if s.app == nil {
+		return "Bearer " + s.token, nil
+	}
+
+	token, err := s.app.installationToken(ctx, s)
+	if err != nil {
+		return "", err
+	}
+
+	return "Bearer " + token, nil
+}
+
+// post sends the payload to the given API path, authenticating with the given authorization header, and decodes the
+// response into out, if not nil.
+func (s *Service) post(ctx context.Context, path, authorization string, payload, out any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return errors.Wrap(err, "marshal request")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.apiURL+path, bytes.NewReader(data))
+	if err != nil {
+		return errors.Wrap(err, "create request")
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	req.Header.Set("Authorization", authorization)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "send request")
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Wrap(err, "read response")
+	}
+	if resp.StatusCode != http.StatusCreated {
+		return notify.ClassifyResponse(resp, fmt.Errorf("github returned status code %d: %s", resp.StatusCode, string(body)))
+	}
+
+	if out != nil {
+		return errors.Wrap(json.Unmarshal(body, out), "decode response")
+	}
+
+	return nil
+}
+
+// Send takes a message subject and a message body and opens an issue in all previously set repositories and comments
+// on all previously set issues and pull requests.
+func (s *Service) Send(ctx context.Context, subject, message string) error {
+	if len(s.repos) == 0 && len(s.comments) == 0 {
+		return nil
+	}
+
+	authorization, err := s.authorization(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to authenticate")
+	}
+
+	issue := map[string]any{"title": subject, "body": message}
+	if len(s.labels) > 0 {
+		issue["labels"] = s.labels
+	}
+
+	for _, repo := range s.repos {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			if err = s.post(ctx, "/repos/"+repo+"/issues", authorization, issue, nil); err != nil {
+				return errors.Wrapf(err, "failed to open issue in %q", repo)
+			}
+		}
+	}
+
+	comment := map[string]string{"body": "**" + subject + "**\n\n" + message}
+	for _, ref := range s.comments {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			path := "/repos/" + ref.repo + "/issues/" + strconv.Itoa(ref.number) + "/comments"
+			if err = s.post(ctx, path, authorization, comment, nil); err != nil {
+				return errors.Wrapf(err, "failed to comment on %s#%d", ref.repo, ref.number)
+			}
+		}
+	}
+
+	return nil
+}
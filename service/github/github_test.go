@@ -0,0 +1,134 @@
+package github
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitHub_AddCommentReceivers(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := New("token")
+	err := service.AddCommentReceivers("owner/repo#1", "owner/other#42")
+	assert.Nil(err)
+	assert.Equal([]issueRef{{"owner/repo", 1}, {"owner/other", 42}}, service.comments)
+
+	for _, ref := range []string{"owner/repo", "repo#1", "owner/repo#x"} {
+		assert.NotNil(service.AddCommentReceivers(ref), ref)
+	}
+}
+
+func TestGitHub_Send(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	got := map[string]map[string]any{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		got[r.URL.Path] = body
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	service := New("token")
+	service.WithAPIURL(server.URL + "/")
+
+	// No receivers added
+	err := service.Send(context.Background(), "subject", "message")
+	assert.Nil(err)
+	assert.Empty(got)
+
+	service.AddReceivers("owner/repo")
+	service.SetLabels("ci")
+	assert.Nil(service.AddCommentReceivers("owner/repo#7"))
+	err = service.Send(context.Background(), "subject", "message")
+	assert.Nil(err)
+	assert.Equal(map[string]map[string]any{
+		"/repos/owner/repo/issues":            {"title": "subject", "body": "message", "labels": []any{"ci"}},
+		"/repos/owner/repo/issues/7/comments": {"body": "**subject**\n\nmessage"},
+	}, got)
+
+	// Test error response
+	service = New("invalid")
+	service.WithAPIURL(server.URL)
+	service.AddReceivers("owner/repo")
+	err = service.Send(context.Background(), "subject", "message")
+	assert.NotNil(err)
+}
+
+func TestGitHub_SendWithApp(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.Nil(err)
+	privateKey := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+
+	var tokenRequests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		switch r.URL.Path {
+		case "/app/installations/2/access_tokens":
+			atomic.AddInt32(&tokenRequests, 1)
+			parts := strings.Split(auth, ".")
+			if len(parts) != 3 {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			signature, _ := base64.RawURLEncoding.DecodeString(parts[2])
+			digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+			if rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, digest[:], signature) != nil {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.WriteHeader(http.StatusCreated)
+			expiresAt := time.Now().Add(time.Hour).Format(time.RFC3339)
+			_, _ = w.Write([]byte(`{"token":"installation-token","expires_at":"` + expiresAt + `"}`))
+		case "/repos/owner/repo/issues":
+			if auth != "installation-token" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.WriteHeader(http.StatusCreated)
+		}
+	}))
+	defer server.Close()
+
+	service, err := NewWithApp(1, 2, privateKey)
+	assert.Nil(err)
+	service.WithAPIURL(server.URL)
+	service.AddReceivers("owner/repo")
+
+	err = service.Send(context.Background(), "subject", "message")
+	assert.Nil(err)
+	err = service.Send(context.Background(), "subject", "message")
+	assert.Nil(err)
+	assert.EqualValues(1, atomic.LoadInt32(&tokenRequests))
+
+	_, err = NewWithApp(1, 2, []byte("invalid"))
+	assert.NotNil(err)
+}
@@ -0,0 +1,40 @@
+/*
+Package gitlab provides a service for creating issues and adding notes to issues and merge requests on GitLab.
+
+Usage:
+
+	package main
+
+	import (
+	    "context"
+	    "log"
+
+	    "github.com/nikoksr/notify"
+	    "github.com/nikoksr/notify/service/gitlab"
+	)
+
+	func main() {
+	    gitlabService := gitlab.New("your-access-token")
+
+	    // Only required for self-hosted instances.
+	    gitlabService.WithBaseURL("https://gitlab.example.com")
+
+	    // Create issues in these projects.
+	    gitlabService.AddReceivers("group/project")
+	    gitlabService.SetLabels("alert")
+
+	    // Add notes to this issue and merge request.
+	    if err := gitlabService.AddNoteReceivers("group/project#12", "group/project!34"); err != nil {
+	        log.Fatal(err)
+	    }
+
+	    // Tell our notifier to use the gitlab service.
+	    notify.UseServices(gitlabService)
+
+	    // Send a test message.
+	    if err := notify.Send(context.Background(), "Pipeline failed", "See the job logs for details."); err != nil {
+	        log.Fatal(err)
+	    }
+	}
+*/
+package gitlab
@@ -0,0 +1,165 @@
+package gitlab
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
+)
+
+// DefaultBaseURL is the URL of GitLab.com. Self-hosted instances use their own URL instead.
+const DefaultBaseURL = "https://gitlab.com"
+
+// Service encapsulates the GitLab client.
+type Service struct {
+	client   *http.Client
+	baseURL  string
+	token    string
+	projects []string
+	notes    []noteRef
+	labels   []string
+}
+
+// noteRef identifies an issue or merge request to add notes to.
+type noteRef struct {
+	project string
+	kind    string // "issues" or "merge_requests"
+	iid     int
+}
+
+// New returns a new instance of a GitLab notification service. The token can be a personal, group or project access
+// token with the api scope.
+// For more information about access tokens:
+//
+//	-> https://docs.gitlab.com/ee/user/profile/personal_access_tokens.html
+func New(token string) *Service {
+	return &Service{
+		client: &http.Client{
+			Transport: notify.NewRateLimitTransport(nil),
+			Timeout:   10 * time.Second,
+		},
+		baseURL:  DefaultBaseURL,
+		token:    token,
+		projects: []string{},
+		notes:    []noteRef{},
+	}
+}
+
+// WithClient sets the http client to be used for sending requests. Calling this method is optional, the default client
+// will be used if this method is not called.
+func (s *Service) WithClient(client *http.Client) {
+	if client != nil {
+		s.client = client
+	}
+}
+
+// WithBaseURL sets the URL of a self-hosted GitLab instance, e.g. "https://gitlab.example.com".
+func (s *Service) WithBaseURL(baseURL string) {
+	s.baseURL = strings.TrimSuffix(baseURL, "/")
+}
+
+// AddReceivers takes project IDs or full project paths, e.g. "group/project", and adds them to the internal receiver
+// list. The Send method creates a new issue in each of them.
+func (s *Service) AddReceivers(projects ...string) {
+	s.projects = append(s.projects, projects...)
+}
+
+// AddNoteReceivers takes references to existing issues ("group/project#12") or merge requests ("group/project!34")
+// and adds them to the internal receiver list. The Send method adds a note to each of them.
+func (s *Service) AddNoteReceivers(refs ...string) error {
+	for _, ref := range refs {
+		i := strings.LastIndexAny(ref, "#!")
+		if i <= 0 {
+			return fmt.Errorf("invalid reference %q, expected project#iid or project!iid", ref)
+		}
+		iid, err := strconv.Atoi(ref[i+1:])
+		if err != nil {
+			return fmt.Errorf("invalid reference %q, expected project#iid or project!iid", ref)
+		}
+
+		kind := "issues"
+		if ref[i] == '!' {
+			kind = "merge_requests"
+		}
+		s.notes = append(s.notes, noteRef{project: ref[:i], kind: kind, iid: iid})
+	}
+
+	return nil
+}
+
+// SetLabels sets the labels applied to created issues.
+func (s *Service) SetLabels(labels ...string) {
+	s.labels = labels
+}
+
+func (s *Service) post(ctx context.Context, path string, payload any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return errors.Wrap(err, "marshal request")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.baseURL+"/api/v4"+path, bytes.NewReader(data))
+	if err != nil {
+		return errors.Wrap(err, "create request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("PRIVATE-TOKEN", s.token)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "send request")
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(resp.Body)
+		return notify.ClassifyResponse(resp, fmt.Errorf("gitlab returned status code %d: %s", resp.StatusCode, string(body)))
+	}
+
+	return nil
+}
+
+// Send takes a message subject and a message body and creates an issue in all previously set projects and adds a
+// note to all previously set issues and merge requests.
+func (s *Service) Send(ctx context.Context, subject, message string) error {
+	issue := map[string]string{"title": subject, "description": message}
+	if len(s.labels) > 0 {
+		issue["labels"] = strings.Join(s.labels, ",")
+	}
+
+	for _, project := range s.projects {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			if err := s.post(ctx, "/projects/"+url.PathEscape(project)+"/issues", issue); err != nil {
+				return errors.Wrapf(err, "failed to create issue in %q", project)
+			}
+		}
+	}
+
+	note := map[string]string{"body": "**" + subject + "**\n\n" + message}
+	for _, ref := range s.notes {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			path := "/projects/" + url.PathEscape(ref.project) + "/" + ref.kind + "/" + strconv.Itoa(ref.iid) + "/notes"
+			if err := s.post(ctx, path, note); err != nil {
+				return errors.Wrapf(err, "failed to add note to %s %s/%d", ref.project, ref.kind, ref.iid)
+			}
+		}
+	}
+
+	return nil
+}
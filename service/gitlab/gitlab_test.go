@@ -0,0 +1,68 @@
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitLab_AddNoteReceivers(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := New("token")
+	err := service.AddNoteReceivers("group/project#12", "group/sub/project!34")
+	assert.Nil(err)
+	assert.Equal([]noteRef{
+		{project: "group/project", kind: "issues", iid: 12},
+		{project: "group/sub/project", kind: "merge_requests", iid: 34},
+	}, service.notes)
+
+	for _, ref := range []string{"group/project", "#12", "group/project!x"} {
+		assert.NotNil(service.AddNoteReceivers(ref), ref)
+	}
+}
+
+func TestGitLab_Send(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	got := map[string]map[string]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("PRIVATE-TOKEN") != "token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var body map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		got[r.URL.EscapedPath()] = body
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	service := New("token")
+	service.WithBaseURL(server.URL + "/")
+	service.AddReceivers("group/project")
+	service.SetLabels("alert", "ci")
+	assert.Nil(service.AddNoteReceivers("group/project!34"))
+
+	err := service.Send(context.Background(), "subject", "message")
+	assert.Nil(err)
+	assert.Equal(map[string]map[string]string{
+		"/api/v4/projects/group%2Fproject/issues":                  {"title": "subject", "description": "message", "labels": "alert,ci"},
+		"/api/v4/projects/group%2Fproject/merge_requests/34/notes": {"body": "**subject**\n\nmessage"},
+	}, got)
+
+	// Test error response
+	service = New("invalid")
+	service.WithBaseURL(server.URL)
+	service.AddReceivers("group/project")
+	err = service.Send(context.Background(), "subject", "message")
+	assert.NotNil(err)
+}
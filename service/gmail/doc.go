@@ -0,0 +1,50 @@
+/*
+Package gmail provides a service for sending emails through the Gmail API.
+
+Unlike SMTP, the Gmail API authenticates with OAuth2 and requires no app passwords.
+
+Usage:
+
+	package main
+
+	import (
+	    "context"
+	    "log"
+
+	    "golang.org/x/oauth2"
+	    "golang.org/x/oauth2/google"
+	    "google.golang.org/api/gmail/v1"
+	    "google.golang.org/api/option"
+
+	    "github.com/nikoksr/notify"
+	    gmailservice "github.com/nikoksr/notify/service/gmail"
+	)
+
+	func main() {
+	    ctx := context.Background()
+
+	    // Load the OAuth2 client config and a previously obtained token of the sending account.
+	    config, err := google.ConfigFromJSON([]byte("your-client-secret-json"), gmail.GmailSendScope)
+	    if err != nil {
+	        log.Fatal(err)
+	    }
+	    token := &oauth2.Token{RefreshToken: "your-refresh-token"}
+
+	    gmailService, err := gmailservice.New(ctx, option.WithTokenSource(config.TokenSource(ctx, token)))
+	    if err != nil {
+	        log.Fatal(err)
+	    }
+
+	    // Add email addresses.
+	    gmailService.AddReceivers("oncall@example.com")
+
+	    // Tell our notifier to use the gmail service.
+	    notify.UseServices(gmailService)
+
+	    // Send a test message.
+	    if err = notify.Send(ctx, "Subject/Title", "The actual message - Hello, you awesome gophers! :)"); err != nil {
+	        log.Fatal(err)
+	    }
+	}
+*/
+package gmail
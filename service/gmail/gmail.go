@@ -0,0 +1,155 @@
+package gmail
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"strings"
+
+	"github.com/pkg/errors"
+	"google.golang.org/api/gmail/v1"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/option"
+
+	"github.com/nikoksr/notify"
+)
+
+// me is the special user ID referring to the authenticated user.
+const me = "me"
+
+// Service encapsulates the Gmail API client along with internal state for storing receiver addresses.
+type Service struct {
+	messages *gmail.UsersMessagesService
+
+	senderAddress string
+	receivers     []string
+	useHTML       bool
+	threadID      string
+}
+
+// New returns an instance of the Gmail notification service. Authenticate with OAuth2 credentials of the sending
+// account, e.g. option.WithTokenSource, granting at least the gmail.send scope.
+// For more information about sending messages with the Gmail API:
+//
+//	-> https://developers.google.com/gmail/api/guides/sending
+func New(ctx context.Context, options ...option.ClientOption) (*Service, error) {
+	options = append([]option.ClientOption{option.WithScopes(gmail.GmailSendScope)}, options...)
+
+	svc, err := gmail.NewService(ctx, options...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create gmail service")
+	}
+
+	return &Service{
+		messages:  svc.Users.Messages,
+		receivers: []string{},
+	}, nil
+}
+
+// SetSender sets the From address of all messages, e.g. a configured send-as alias. If left empty, Gmail uses the
+// address of the authenticated account.
+func (s *Service) SetSender(address string) {
+	s.senderAddress = address
+}
+
+// AddReceivers takes email addresses and adds them to the internal address list. The Send method will send a given
+// message to all those addresses.
+func (s *Service) AddReceivers(addresses ...string) {
+	s.receivers = append(s.receivers, addresses...)
+}
+
+// BodyFormat sets the format of the message body. Pass true to send the message as HTML instead of plain text.
+func (s *Service) BodyFormat(html bool) {
+	s.useHTML = html
+}
+
+// SetThreadID sets the ID of the thread all messages are added to. Gmail only adds a message to the thread if its
+// subject matches the thread's subject.
+func (s *Service) SetThreadID(threadID string) {
+	s.threadID = threadID
+}
+
+// Validate checks that the sender address, if set, and the receiver addresses parse, see notify.Validator.
+func (s *Service) Validate() error {
+	var problems notify.ConfigErrors
+	if s.senderAddress != "" {
+		problems.Check("sender", notify.ValidateMailAddress(s.senderAddress))
+	}
+	for _, address := range s.receivers {
+		problems.Check("receiver", notify.ValidateMailAddress(address))
+	}
+
+	return problems.Err()
+}
+
+// buildMessage builds the RFC 2822 message for the given subject and body.
+func (s *Service) buildMessage(subject, message string) ([]byte, error) {
+	contentType := "text/plain"
+	if s.useHTML {
+		contentType = "text/html"
+	}
+
+	var buf bytes.Buffer
+	if s.senderAddress != "" {
+		fmt.Fprintf(&buf, "From: %s\r\n", s.senderAddress)
+	}
+	fmt.Fprintf(&buf, "To: %s\r\n", strings.Join(s.receivers, ", "))
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&buf, "Content-Type: %s; charset=\"utf-8\"\r\n", contentType)
+	buf.WriteString("Content-Transfer-Encoding: quoted-printable\r\n\r\n")
+
+	w := quotedprintable.NewWriter(&buf)
+	if _, err := w.Write([]byte(message)); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// Send takes a message subject and a message body and sends them as a single email to all previously set email
+// addresses.
+func (s *Service) Send(ctx context.Context, subject, message string) error {
+	if len(s.receivers) == 0 {
+		return nil
+	}
+
+	raw, err := s.buildMessage(subject, message)
+	if err != nil {
+		return errors.Wrap(err, "failed to build message")
+	}
+
+	msg := &gmail.Message{
+		Raw:      base64.URLEncoding.EncodeToString(raw),
+		ThreadId: s.threadID,
+	}
+
+	if _, err = s.messages.Send(me, msg).Context(ctx).Do(); err != nil {
+		return classifyError(errors.Wrap(err, "failed to send mail via Gmail"))
+	}
+
+	return nil
+}
+
+// classifyError classifies errors returned by the Gmail API, see notify.ClassifyError. Google reports rate limits as 403
+// with the reason rateLimitExceeded.
+func classifyError(err error) error {
+	var apiErr *googleapi.Error
+	if !errors.As(err, &apiErr) {
+		return notify.ClassifyNetworkError(err)
+	}
+
+	for _, item := range apiErr.Errors {
+		if item.Reason == "rateLimitExceeded" || item.Reason == "userRateLimitExceeded" {
+			return notify.ClassifyError(err, &notify.ErrRateLimited{})
+		}
+	}
+
+	return notify.ClassifyStatus(apiErr.Code, err)
+}
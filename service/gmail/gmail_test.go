@@ -0,0 +1,92 @@
+package gmail
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/gmail/v1"
+	"google.golang.org/api/option"
+
+	"github.com/nikoksr/notify"
+)
+
+func TestGmail_Send(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	var got []gmail.Message
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/gmail/v1/users/me/messages/send" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var msg gmail.Message
+		_ = json.NewDecoder(r.Body).Decode(&msg)
+		if msg.ThreadId == "invalid" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":{"code":404,"message":"Requested entity was not found."}}`))
+			return
+		}
+		got = append(got, msg)
+		_, _ = w.Write([]byte(`{"id":"1","threadId":"thread"}`))
+	}))
+	defer server.Close()
+
+	service, err := New(context.Background(),
+		option.WithEndpoint(server.URL+"/"),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(server.Client()),
+	)
+	assert.NoError(err)
+
+	// No receivers, no request
+	assert.NoError(service.Send(context.Background(), "subject", "message"))
+	assert.Empty(got)
+
+	service.SetSender("Alerts <alerts@example.com>")
+	service.AddReceivers("a@example.com", "b@example.com")
+	service.BodyFormat(true)
+	service.SetThreadID("thread")
+
+	assert.NoError(service.Send(context.Background(), "Störung", "<p>message</p>"))
+	assert.Len(got, 1)
+	assert.Equal("thread", got[0].ThreadId)
+
+	raw, err := base64.URLEncoding.DecodeString(got[0].Raw)
+	assert.NoError(err)
+	assert.Equal("From: Alerts <alerts@example.com>\r\n"+
+		"To: a@example.com, b@example.com\r\n"+
+		"Subject: =?utf-8?q?St=C3=B6rung?=\r\n"+
+		"MIME-Version: 1.0\r\n"+
+		"Content-Type: text/html; charset=\"utf-8\"\r\n"+
+		"Content-Transfer-Encoding: quoted-printable\r\n\r\n"+
+		"<p>message</p>", string(raw))
+
+	// Test error response
+	service.SetThreadID("invalid")
+	assert.Error(service.Send(context.Background(), "subject", "message"))
+}
+
+func TestGmail_Validate(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	// The sender is optional.
+	service := &Service{}
+	service.AddReceivers("alice@example.com")
+	assert.NoError(service.Validate())
+
+	service.SetSender("alerts")
+	service.AddReceivers("bob")
+	err := service.Validate()
+	assert.ErrorIs(err, notify.ErrInvalidConfig)
+	assert.ErrorContains(err, `sender: mail address "alerts"`)
+	assert.ErrorContains(err, `receiver: mail address "bob"`)
+}
@@ -0,0 +1,45 @@
+/*
+Package googlecalendar provides a service for creating Google Calendar events, e.g. for maintenance windows or on-call
+handovers.
+
+Usage:
+
+	package main
+
+	import (
+	    "context"
+	    "log"
+	    "time"
+
+	    "google.golang.org/api/option"
+
+	    "github.com/nikoksr/notify"
+	    "github.com/nikoksr/notify/service/googlecalendar"
+	)
+
+	func main() {
+	    ctx := context.Background()
+
+	    // Authenticate with a service account that the calendars are shared with.
+	    calendarService, err := googlecalendar.New(ctx, option.WithCredentialsFile("service-account.json"))
+	    if err != nil {
+	        log.Fatal(err)
+	    }
+
+	    // Schedule a two hour maintenance window.
+	    calendarService.SetStartTime(time.Date(2023, 2, 1, 22, 0, 0, 0, time.UTC))
+	    calendarService.SetDuration(2 * time.Hour)
+
+	    // Add calendar IDs.
+	    calendarService.AddReceivers("team@group.calendar.google.com")
+
+	    // Tell our notifier to use the google calendar service.
+	    notify.UseServices(calendarService)
+
+	    // Create the event.
+	    if err = notify.Send(ctx, "Database maintenance", "The primary database is upgraded to a new version."); err != nil {
+	        log.Fatal(err)
+	    }
+	}
+*/
+package googlecalendar
@@ -0,0 +1,122 @@
+package googlecalendar
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/api/calendar/v3"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/option"
+
+	"github.com/nikoksr/notify"
+)
+
+// Service encapsulates the Google Calendar client along with internal state for storing calendar IDs.
+type Service struct {
+	events    *calendar.EventsService
+	calendars []string
+
+	startTime time.Time
+	duration  time.Duration
+	location  string
+
+	now func() time.Time
+}
+
+// New returns an instance of the Google Calendar notification service. Authenticate with a service account, e.g.
+// option.WithCredentialsFile, that has write access to the calendars; share the calendars with the service account's
+// email address to grant it.
+// For more information about the Calendar API:
+//
+//	-> https://developers.google.com/calendar/api/v3/reference/events/insert
+func New(ctx context.Context, options ...option.ClientOption) (*Service, error) {
+	options = append([]option.ClientOption{option.WithScopes(calendar.CalendarEventsScope)}, options...)
+
+	svc, err := calendar.NewService(ctx, options...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create calendar service")
+	}
+
+	return &Service{
+		events:    svc.Events,
+		calendars: []string{},
+		duration:  time.Hour,
+		now:       time.Now,
+	}, nil
+}
+
+// AddReceivers takes calendar IDs, e.g. "team@group.calendar.google.com", and adds them to the internal calendar list.
+// The Send method will create an event in every one of those calendars.
+func (s *Service) AddReceivers(calendarIDs ...string) {
+	s.calendars = append(s.calendars, calendarIDs...)
+}
+
+// SetStartTime sets the start time of all created events, e.g. the begin of a maintenance window. If left zero,
+// events start at the time Send is called.
+func (s *Service) SetStartTime(start time.Time) {
+	s.startTime = start
+}
+
+// SetDuration sets the duration of all created events. Defaults to one hour.
+func (s *Service) SetDuration(duration time.Duration) {
+	s.duration = duration
+}
+
+// SetLocation sets the location of all created events, e.g. a meeting room or a video call link.
+func (s *Service) SetLocation(location string) {
+	s.location = location
+}
+
+// newEvent creates the event for the given subject and message.
+func (s *Service) newEvent(subject, message string) *calendar.Event {
+	start := s.startTime
+	if start.IsZero() {
+		start = s.now()
+	}
+	end := start.Add(s.duration)
+
+	return &calendar.Event{
+		Summary:     subject,
+		Description: message,
+		Location:    s.location,
+		Start:       &calendar.EventDateTime{DateTime: start.Format(time.RFC3339)},
+		End:         &calendar.EventDateTime{DateTime: end.Format(time.RFC3339)},
+	}
+}
+
+// Send takes a message subject and a message body and creates an event with the subject as title and the message as
+// description in all previously set calendars.
+func (s *Service) Send(ctx context.Context, subject, message string) error {
+	event := s.newEvent(subject, message)
+
+	for _, calendarID := range s.calendars {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			if _, err := s.events.Insert(calendarID, event).Context(ctx).Do(); err != nil {
+				return classifyError(errors.Wrapf(err, "failed to create event in calendar '%s'", calendarID))
+			}
+		}
+	}
+
+	return nil
+}
+
+// classifyError classifies errors returned by the Google Calendar API, see notify.ClassifyError. Google reports rate
+// limits as 403 with the reason rateLimitExceeded.
+func classifyError(err error) error {
+	var apiErr *googleapi.Error
+	if !errors.As(err, &apiErr) {
+		return notify.ClassifyNetworkError(err)
+	}
+
+	for _, item := range apiErr.Errors {
+		if item.Reason == "rateLimitExceeded" || item.Reason == "userRateLimitExceeded" {
+			return notify.ClassifyError(err, &notify.ErrRateLimited{})
+		}
+	}
+
+	return notify.ClassifyStatus(apiErr.Code, err)
+}
@@ -0,0 +1,65 @@
+package googlecalendar
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/calendar/v3"
+	"google.golang.org/api/option"
+)
+
+func TestGoogleCalendar_Send(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	got := map[string]calendar.Event{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/calendars/invalid/events" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":{"code":404,"message":"Not Found"}}`))
+			return
+		}
+		var event calendar.Event
+		_ = json.NewDecoder(r.Body).Decode(&event)
+		got[r.URL.Path] = event
+		_ = json.NewEncoder(w).Encode(event)
+	}))
+	defer server.Close()
+
+	service, err := New(context.Background(),
+		option.WithEndpoint(server.URL),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(server.Client()),
+	)
+	assert.NoError(err)
+
+	service.now = func() time.Time { return time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC) }
+	service.SetDuration(30 * time.Minute)
+	service.SetLocation("Room 1")
+	service.AddReceivers("team@group.calendar.google.com")
+
+	assert.NoError(service.Send(context.Background(), "On-call handover", "Alice hands over to Bob."))
+	assert.Equal(map[string]calendar.Event{
+		"/calendars/team@group.calendar.google.com/events": {
+			Summary:     "On-call handover",
+			Description: "Alice hands over to Bob.",
+			Location:    "Room 1",
+			Start:       &calendar.EventDateTime{DateTime: "2023-01-02T03:04:05Z"},
+			End:         &calendar.EventDateTime{DateTime: "2023-01-02T03:34:05Z"},
+		},
+	}, got)
+
+	service.SetStartTime(time.Date(2023, 2, 1, 22, 0, 0, 0, time.UTC))
+	assert.NoError(service.Send(context.Background(), "Maintenance", "Database upgrade"))
+	assert.Equal("2023-02-01T22:00:00Z", got["/calendars/team@group.calendar.google.com/events"].Start.DateTime)
+
+	// Test error response
+	service.AddReceivers("invalid")
+	assert.Error(service.Send(context.Background(), "subject", "message"))
+}
@@ -3,13 +3,22 @@
 package googlechat
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	"google.golang.org/api/chat/v1"
 	"google.golang.org/api/googleapi"
 	"google.golang.org/api/option"
+
+	"github.com/nikoksr/notify"
 )
 
 //go:generate mockery --name=spacesMessageCreator --output=. --case=underscore --inpackage
@@ -45,10 +54,18 @@ func newMessageCreator(ctx context.Context, options ...option.ClientOption) (spa
 	return &messageCreator{svc.Spaces.Messages}, nil
 }
 
+// replyMessageFallbackToNewThread tells google chat to reply to the thread identified by
+// the message's thread key, or to start a new thread if no such thread exists yet.
+const replyMessageFallbackToNewThread = "REPLY_MESSAGE_FALLBACK_TO_NEW_THREAD"
+
 // Create creates a createCall struct for google chat. In order to execute sending
 // the message utilize the `.Do` method found on the createCall.
 func (m *messageCreator) Create(parent string, message *chat.Message) createCall {
-	return m.SpacesMessagesService.Create(parent, message)
+	call := m.SpacesMessagesService.Create(parent, message)
+	if message.Thread != nil && message.Thread.ThreadKey != "" {
+		call = call.MessageReplyOption(replyMessageFallbackToNewThread)
+	}
+	return call
 }
 
 // Service encapsulates the google chat client along with internal state for storing
@@ -56,6 +73,38 @@ func (m *messageCreator) Create(parent string, message *chat.Message) createCall
 type Service struct {
 	messageCreator spacesMessageCreator
 	spaces         []string
+	webhooks       []string
+	client         *http.Client
+	threadKey      string
+}
+
+// NewWithWebhooks returns an instance of the google chat notification service that
+// posts to the given incoming webhook URLs instead of using the Chat API. No service
+// account is required in this mode.
+func NewWithWebhooks(webhookURLs ...string) *Service {
+	s := &Service{
+		spaces:   []string{},
+		webhooks: []string{},
+		client:   &http.Client{Timeout: 10 * time.Second, Transport: notify.NewRateLimitTransport(nil)},
+	}
+	s.AddWebhooks(webhookURLs...)
+	return s
+}
+
+// AddWebhooks takes incoming webhook URLs of spaces and appends them to the internal
+// webhooks slice. The Send method will post a given message to all those webhooks.
+func (s *Service) AddWebhooks(webhookURLs ...string) {
+	s.webhooks = append(s.webhooks, webhookURLs...)
+	if s.client == nil {
+		s.client = &http.Client{Timeout: 10 * time.Second, Transport: notify.NewRateLimitTransport(nil)}
+	}
+}
+
+// SetThreadKey sets the thread key used for messages sent without thread key, see
+// notify.ContextWithThreadKey. Messages sharing the same thread key are posted as replies
+// to the same thread; the first one starts it.
+func (s *Service) SetThreadKey(threadKey string) {
+	s.threadKey = threadKey
 }
 
 // New returns an instance of the google chat notification service
@@ -93,11 +142,34 @@ func (s *Service) AddReceivers(spaces ...string) {
 	s.spaces = append(s.spaces, spaces...)
 }
 
+// Validate checks that the webhook URLs are well-formed and that the space names are given without "spaces/" prefix,
+// see notify.Validator.
+func (s *Service) Validate() error {
+	var problems notify.ConfigErrors
+	for _, webhookURL := range s.webhooks {
+		problems.Check("webhook URL", notify.ValidateURL(webhookURL))
+	}
+	for _, space := range s.spaces {
+		if space == "" || strings.Contains(space, "/") {
+			problems.Addf("space %q is not a space name", space)
+		}
+	}
+
+	return problems.Err()
+}
+
 // Send takes a message subject and a message body and sends them to all the spaces
 // previously set.
 func (s *Service) Send(ctx context.Context, subject, message string) error {
 	// Treating subject as message title
 	msg := &chat.Message{Text: subject + "\n" + message}
+	threadKey := s.threadKey
+	if key, ok := notify.ThreadKeyFromContext(ctx); ok {
+		threadKey = key
+	}
+	if threadKey != "" {
+		msg.Thread = &chat.Thread{ThreadKey: threadKey}
+	}
 	for _, space := range s.spaces {
 		parent := fmt.Sprintf("spaces/%s", space)
 		select {
@@ -109,5 +181,65 @@ func (s *Service) Send(ctx context.Context, subject, message string) error {
 			}
 		}
 	}
+	for _, webhook := range s.webhooks {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			if err := s.postWebhook(ctx, webhook, msg); err != nil {
+				return errors.Wrap(err, "failed to send message to the google chat webhook")
+			}
+		}
+	}
+	return nil
+}
+
+// textEscaper escapes the characters that start links and mentions, e.g. <users/all>, in Google Chat's text format.
+var textEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// Sanitize escapes the links and mentions of Google Chat's text format, so that user provided content can't inject
+// links or mention users or @all, see notify.Sanitizer. Google Chat has no way to escape the remaining formatting
+// characters, like * and _.
+func (s *Service) Sanitize(message notify.Message) notify.Message {
+	return notify.SanitizeMessage(message, func(text string) string {
+		return textEscaper.Replace(notify.StripControlChars(text))
+	})
+}
+
+// postWebhook posts the given message to a single incoming webhook.
+func (s *Service) postWebhook(ctx context.Context, webhookURL string, msg *chat.Message) error {
+	if msg.Thread != nil {
+		u, err := url.Parse(webhookURL)
+		if err != nil {
+			return errors.Wrap(err, "parse webhook url")
+		}
+		query := u.Query()
+		query.Set("messageReplyOption", replyMessageFallbackToNewThread)
+		u.RawQuery = query.Encode()
+		webhookURL = u.String()
+	}
+
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return errors.Wrap(err, "marshal message")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "create request")
+	}
+	req.Header.Set("Content-Type", "application/json; charset=UTF-8")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "send request")
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		result, _ := io.ReadAll(resp.Body)
+		return notify.ClassifyResponse(resp, fmt.Errorf("google chat returned status code %d: %s", resp.StatusCode, string(result)))
+	}
+
 	return nil
 }
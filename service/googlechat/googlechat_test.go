@@ -2,6 +2,9 @@ package googlechat
 
 import (
 	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -9,6 +12,8 @@ import (
 	"github.com/stretchr/testify/require"
 	"google.golang.org/api/chat/v1"
 	"google.golang.org/api/option"
+
+	"github.com/nikoksr/notify"
 )
 
 func TestGoogleChat_New(t *testing.T) {
@@ -51,6 +56,32 @@ func TestGoogleChat_AddReceivers(t *testing.T) {
 	assert.Equal("", diff) // assert that there is no difference
 }
 
+func TestGoogleChat_Validate(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := NewWithWebhooks("https://chat.googleapis.com/v1/spaces/AAAA/messages?key=k&token=t")
+	service.AddReceivers("AAAA")
+	assert.NoError(service.Validate())
+
+	service.AddWebhooks("chat.googleapis.com/v1/spaces/AAAA/messages")
+	service.AddReceivers("spaces/BBBB")
+	err := service.Validate()
+	assert.ErrorIs(err, notify.ErrInvalidConfig)
+	assert.ErrorContains(err, "webhook URL")
+	assert.ErrorContains(err, `space "spaces/BBBB" is not a space name`)
+}
+
+func TestGoogleChat_Sanitize(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	sanitized := (&Service{}).Sanitize(notify.Message{Body: "<users/all> see <https://evil.example.com|docs>"})
+	assert.Equal("&lt;users/all&gt; see &lt;https://evil.example.com|docs&gt;", sanitized.Body)
+}
+
 func TestGoogleChat_Send(t *testing.T) {
 	t.Parallel()
 	ctx := context.Background()
@@ -98,3 +129,42 @@ func TestGoogleChat_Send(t *testing.T) {
 	assert.NotNil(err)
 	mockMsgCreator.AssertExpectations(t)
 }
+
+func TestGoogleChat_SendWebhook(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	var (
+		got         chat.Message
+		replyOption string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/invalid" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		replyOption = r.URL.Query().Get("messageReplyOption")
+		_ = json.NewDecoder(r.Body).Decode(&got)
+	}))
+	defer server.Close()
+
+	service := NewWithWebhooks(server.URL + "/webhook?key=abc")
+	service.SetThreadKey("incident-42")
+
+	err := service.Send(context.Background(), "subject", "message")
+	assert.Nil(err)
+	assert.Equal("subject\nmessage", got.Text)
+	assert.Equal("incident-42", got.Thread.ThreadKey)
+	assert.Equal(replyMessageFallbackToNewThread, replyOption)
+
+	// The thread key of the context takes precedence.
+	err = service.Send(notify.ContextWithThreadKey(context.Background(), "incident-43"), "subject", "message")
+	assert.Nil(err)
+	assert.Equal("incident-43", got.Thread.ThreadKey)
+
+	// Test error response
+	service.AddWebhooks(server.URL + "/invalid")
+	err = service.Send(context.Background(), "subject", "message")
+	assert.NotNil(err)
+}
@@ -0,0 +1,36 @@
+/*
+Package gotify provides a service for pushing messages to a self-hosted Gotify server.
+
+Usage:
+
+	package main
+
+	import (
+	    "context"
+	    "log"
+
+	    "github.com/nikoksr/notify"
+	    "github.com/nikoksr/notify/service/gotify"
+	)
+
+	func main() {
+	    // Create a gotify service using your server's URL and an application token.
+	    gotifyService := gotify.New("https://gotify.example.com", "your-app-token")
+
+	    // Optionally render the message body as markdown and raise the priority.
+	    gotifyService.UseMarkdown(true)
+	    gotifyService.SetPriority(8)
+
+	    // Instances using a self-signed certificate can be reached by passing a custom TLS config.
+	    // gotifyService.WithTLSConfig(&tls.Config{RootCAs: pool})
+
+	    // Tell our notifier to use the gotify service.
+	    notify.UseServices(gotifyService)
+
+	    // Send a test message.
+	    if err := notify.Send(context.Background(), "Subject/Title", "The actual message - **Hello**!"); err != nil {
+	        log.Fatal(err)
+	    }
+	}
+*/
+package gotify
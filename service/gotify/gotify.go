@@ -0,0 +1,184 @@
+package gotify
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
+)
+
+// DefaultPriority is the priority used for messages if no other priority was set. Gotify clients usually only show a
+// popup for messages with a priority of 4 or higher.
+const DefaultPriority = 5
+
+// Service allow you to configure the Gotify service.
+type Service struct {
+	client    *http.Client
+	serverURL string
+	appToken  string
+	priority  int
+	markdown  bool
+	extras    map[string]any
+}
+
+func defaultHTTPClient() *http.Client {
+	return &http.Client{
+		Transport: notify.NewRateLimitTransport(nil),
+		Timeout:   10 * time.Second,
+	}
+}
+
+// New returns a new instance of a Gotify notification service. The serverURL is the base URL of your Gotify server
+// (e.g. https://gotify.example.com) and appToken is the token of the application the messages should be pushed as.
+// For more information about Gotify applications:
+//
+//	-> https://gotify.net/docs/pushmsg
+func New(serverURL, appToken string) *Service {
+	return &Service{
+		client:    defaultHTTPClient(),
+		serverURL: strings.TrimSuffix(serverURL, "/"),
+		appToken:  appToken,
+		priority:  DefaultPriority,
+	}
+}
+
+// WithClient sets the http client to be used for sending requests. Calling this method is optional, the default client
+// will be used if this method is not called.
+func (s *Service) WithClient(client *http.Client) {
+	if client != nil {
+		s.client = client
+	}
+}
+
+// WithTLSConfig sets a custom TLS config on the service's http client. This is useful for self-hosted instances that
+// use self-signed certificates, in which case you should prefer adding your CA to config.RootCAs over disabling
+// verification.
+//
+// The config is applied to a copy of the current client, so it can be combined with WithClient in any order and the
+// client passed to WithClient is left unchanged. All other settings of the client and its *http.Transport, e.g.
+// proxies and timeouts, are kept. A transport that is neither an *http.Transport nor a notify.RateLimitTransport
+// wrapping one can't be configured and is replaced by a copy of http.DefaultTransport.
+func (s *Service) WithTLSConfig(config *tls.Config) {
+	if config == nil {
+		return
+	}
+
+	client := *s.client
+	client.Transport = withTLSConfig(client.Transport, config)
+	s.client = &client
+}
+
+// withTLSConfig returns a copy of the given transport using the given TLS config. Rate limit transports are unwrapped
+// and wrapped again, so rate limits are still reported after changing the TLS config.
+func withTLSConfig(roundTripper http.RoundTripper, config *tls.Config) http.RoundTripper {
+	switch transport := roundTripper.(type) {
+	case *notify.RateLimitTransport:
+		return notify.NewRateLimitTransport(withTLSConfig(transport.Base, config))
+	case *http.Transport:
+		transport = transport.Clone()
+		transport.TLSClientConfig = config
+		return transport
+	default:
+		cloned := http.DefaultTransport.(*http.Transport).Clone()
+		cloned.TLSClientConfig = config
+		return cloned
+	}
+}
+
+// SetPriority sets the priority of all messages sent by this service.
+func (s *Service) SetPriority(priority int) {
+	s.priority = priority
+}
+
+// UseMarkdown tells Gotify clients to render the message body as markdown.
+func (s *Service) UseMarkdown(enabled bool) {
+	s.markdown = enabled
+}
+
+// SetExtras sets additional extras that get sent along with every message. Extras are namespaced, e.g.
+// "client::notification". See https://gotify.net/docs/msgextras for a list of supported extras.
+func (s *Service) SetExtras(extras map[string]any) {
+	s.extras = extras
+}
+
+// message is the payload expected by Gotify's message endpoint.
+type message struct {
+	Title    string         `json:"title,omitempty"`
+	Message  string         `json:"message"`
+	Priority int            `json:"priority"`
+	Extras   map[string]any `json:"extras,omitempty"`
+}
+
+// buildExtras merges the user-defined extras with the extras required by the service settings.
+func (s *Service) buildExtras() map[string]any {
+	if len(s.extras) == 0 && !s.markdown {
+		return nil
+	}
+
+	extras := make(map[string]any, len(s.extras)+1)
+	for key, value := range s.extras {
+		extras[key] = value
+	}
+	if s.markdown {
+		extras["client::display"] = map[string]string{"contentType": "text/markdown"}
+	}
+
+	return extras
+}
+
+// Validate checks that the server URL is well-formed and that the app token is set, see notify.Validator.
+func (s *Service) Validate() error {
+	var problems notify.ConfigErrors
+	problems.Check("server URL", notify.ValidateURL(s.serverURL))
+	problems.Require("app token", s.appToken)
+
+	return problems.Err()
+}
+
+// Send takes a message subject and a message body and pushes them to the Gotify server.
+func (s *Service) Send(ctx context.Context, subject, body string) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+
+	payload, err := json.Marshal(&message{
+		Title:    subject,
+		Message:  body,
+		Priority: s.priority,
+		Extras:   s.buildExtras(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "marshal message")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.serverURL+"/message", bytes.NewReader(payload))
+	if err != nil {
+		return errors.Wrap(err, "create request")
+	}
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	req.Header.Set("X-Gotify-Key", s.appToken)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "send request")
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		result, _ := io.ReadAll(resp.Body)
+		return notify.ClassifyResponse(resp, fmt.Errorf("gotify returned status code %d: %s", resp.StatusCode, string(result)))
+	}
+
+	return nil
+}
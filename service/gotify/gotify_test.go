@@ -0,0 +1,91 @@
+package gotify
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nikoksr/notify"
+)
+
+func TestGotify_New(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := New("https://gotify.example.com/", "token")
+	assert.NotNil(service)
+	assert.Equal("https://gotify.example.com", service.serverURL)
+	assert.Equal(DefaultPriority, service.priority)
+
+	config := &tls.Config{MinVersion: tls.VersionTLS12}
+	service.WithTLSConfig(config)
+	transport, ok := service.client.Transport.(*notify.RateLimitTransport).Base.(*http.Transport)
+	assert.True(ok)
+	assert.Same(config, transport.TLSClientConfig)
+	assert.NotNil(transport.Proxy)
+
+	// The TLS config is applied to a client set by WithClient, without changing the client itself.
+	base := &http.Transport{MaxIdleConns: 7}
+	client := &http.Client{Transport: base, Timeout: time.Minute}
+	service.WithClient(client)
+	service.WithTLSConfig(config)
+	transport, ok = service.client.Transport.(*http.Transport)
+	assert.True(ok)
+	assert.Same(config, transport.TLSClientConfig)
+	assert.Equal(7, transport.MaxIdleConns)
+	assert.Equal(time.Minute, service.client.Timeout)
+	assert.Same(base, client.Transport)
+}
+
+func TestGotify_Validate(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	assert.NoError(New("https://gotify.example.com", "token").Validate())
+
+	err := New("gotify.example.com", "").Validate()
+	assert.ErrorIs(err, notify.ErrInvalidConfig)
+	assert.ErrorContains(err, "server URL")
+	assert.ErrorContains(err, "app token is missing")
+}
+
+func TestGotify_Send(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	var got message
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/message" || r.Header.Get("X-Gotify-Key") != "token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	service := New(server.URL, "token")
+	service.UseMarkdown(true)
+	service.SetPriority(8)
+
+	err := service.Send(context.Background(), "subject", "message")
+	assert.Nil(err)
+	assert.Equal("subject", got.Title)
+	assert.Equal("message", got.Message)
+	assert.Equal(8, got.Priority)
+	assert.Contains(got.Extras, "client::display")
+
+	// Test error response
+	service = New(server.URL, "wrong-token")
+	err = service.Send(context.Background(), "subject", "message")
+	assert.NotNil(err)
+}
@@ -0,0 +1,39 @@
+/*
+Package grafanaoncall provides a service for sending alerts to Grafana OnCall.
+
+Usage:
+
+	package main
+
+	import (
+	    "context"
+	    "log"
+
+	    "github.com/nikoksr/notify"
+	    "github.com/nikoksr/notify/service/grafanaoncall"
+	)
+
+	func main() {
+	    onCallService := grafanaoncall.New()
+
+	    // Post alerts to these formatted webhook integrations.
+	    onCallService.AddReceivers("https://oncall.example.com/integrations/v1/formatted_webhook/your-token/")
+
+	    // Group alerts for the same problem, so they can be resolved later.
+	    onCallService.SetAlertUID("api-01/disk-full")
+
+	    // Tell our notifier to use the grafana oncall service.
+	    notify.UseServices(onCallService)
+
+	    // Send a test message.
+	    if err := notify.Send(context.Background(), "Disk full", "api-01 has less than 1% disk space left"); err != nil {
+	        log.Fatal(err)
+	    }
+
+	    // Resolve it once the problem is gone.
+	    if err := onCallService.Resolve(context.Background(), "api-01/disk-full"); err != nil {
+	        log.Fatal(err)
+	    }
+	}
+*/
+package grafanaoncall
@@ -0,0 +1,168 @@
+package grafanaoncall
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
+)
+
+// State represents the state of the alert an event describes.
+type State string
+
+// All states supported by the formatted webhook integration of Grafana OnCall.
+const (
+	StateAlerting State = "alerting"
+	StateResolved State = "ok"
+)
+
+// Service allow you to configure the Grafana OnCall service.
+type Service struct {
+	client      *http.Client
+	webhookURLs []string
+
+	alertUID string
+	imageURL string
+	link     string
+}
+
+func defaultHTTPClient() *http.Client {
+	return &http.Client{
+		Transport: notify.NewRateLimitTransport(nil),
+		Timeout:   10 * time.Second,
+	}
+}
+
+// New returns a new instance of a Grafana OnCall notification service. Receivers are the URLs of "Formatted webhook"
+// integrations, which can be created in the Integrations tab of Grafana OnCall.
+// For more information about formatted webhooks:
+//
+//	-> https://grafana.com/docs/oncall/latest/integrations/webhook/
+func New() *Service {
+	return &Service{
+		client:      defaultHTTPClient(),
+		webhookURLs: []string{},
+	}
+}
+
+// WithClient sets the http client to be used for sending requests. Calling this method is optional, the default client
+// will be used if this method is not called.
+func (s *Service) WithClient(client *http.Client) {
+	if client != nil {
+		s.client = client
+	}
+}
+
+// AddReceivers takes integration webhook URLs and adds them to the internal URL list. The Send method will post an
+// alert to every one of those URLs.
+func (s *Service) AddReceivers(webhookURLs ...string) {
+	s.webhookURLs = append(s.webhookURLs, webhookURLs...)
+}
+
+// SetAlertUID sets the unique ID of all alerts posted by this service. Alerts sharing a UID are grouped together and
+// can be resolved via Resolve. If left empty, Grafana OnCall treats every alert as a new one.
+func (s *Service) SetAlertUID(alertUID string) {
+	s.alertUID = alertUID
+}
+
+// SetImageURL sets the URL of an image, e.g. a graph, that is shown with all alerts.
+func (s *Service) SetImageURL(imageURL string) {
+	s.imageURL = imageURL
+}
+
+// SetLink sets a link to upstream details, e.g. a dashboard, that is shown with all alerts.
+func (s *Service) SetLink(link string) {
+	s.link = link
+}
+
+// alert is the request body expected by the formatted webhook integration.
+type alert struct {
+	AlertUID              string `json:"alert_uid,omitempty"`
+	Title                 string `json:"title,omitempty"`
+	ImageURL              string `json:"image_url,omitempty"`
+	State                 State  `json:"state"`
+	LinkToUpstreamDetails string `json:"link_to_upstream_details,omitempty"`
+	Message               string `json:"message,omitempty"`
+}
+
+func (s *Service) send(ctx context.Context, webhookURL string, a *alert) error {
+	body, err := json.Marshal(a)
+	if err != nil {
+		return errors.Wrap(err, "marshal alert")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "create request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "send request")
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		result, _ := io.ReadAll(resp.Body)
+		return notify.ClassifyResponse(resp, fmt.Errorf("grafana oncall returned status code %d: %s", resp.StatusCode, string(result)))
+	}
+
+	return nil
+}
+
+// sendAll sends the alert to every webhook URL.
+func (s *Service) sendAll(ctx context.Context, a *alert) error {
+	for _, webhookURL := range s.webhookURLs {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			if err := s.send(ctx, webhookURL, a); err != nil {
+				return errors.Wrap(err, "failed to send alert to Grafana OnCall")
+			}
+		}
+	}
+
+	return nil
+}
+
+// Validate checks that the webhook URLs and the image and link URLs, if set, are well-formed, see notify.Validator.
+func (s *Service) Validate() error {
+	var problems notify.ConfigErrors
+	for _, webhookURL := range s.webhookURLs {
+		problems.Check("webhook URL", notify.ValidateURL(webhookURL))
+	}
+	if s.imageURL != "" {
+		problems.Check("image URL", notify.ValidateURL(s.imageURL))
+	}
+	if s.link != "" {
+		problems.Check("link", notify.ValidateURL(s.link))
+	}
+
+	return problems.Err()
+}
+
+// Send takes a message subject and a message body and posts a firing alert to every previously set webhook URL.
+func (s *Service) Send(ctx context.Context, subject, message string) error {
+	return s.sendAll(ctx, &alert{
+		AlertUID:              s.alertUID,
+		Title:                 subject,
+		ImageURL:              s.imageURL,
+		State:                 StateAlerting,
+		LinkToUpstreamDetails: s.link,
+		Message:               message,
+	})
+}
+
+// Resolve resolves the alert group identified by the given alert UID for every previously set webhook URL.
+func (s *Service) Resolve(ctx context.Context, alertUID string) error {
+	return s.sendAll(ctx, &alert{AlertUID: alertUID, State: StateResolved})
+}
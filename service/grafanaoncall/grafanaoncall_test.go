@@ -0,0 +1,75 @@
+package grafanaoncall
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nikoksr/notify"
+)
+
+func TestGrafanaOnCall_Send(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	var alerts []alert
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/invalid/" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var a alert
+		_ = json.NewDecoder(r.Body).Decode(&a)
+		alerts = append(alerts, a)
+	}))
+	defer server.Close()
+
+	service := New()
+	service.AddReceivers(server.URL + "/integrations/v1/formatted_webhook/token/")
+	service.SetAlertUID("api-01/disk-full")
+	service.SetImageURL("https://example.com/graph.png")
+	service.SetLink("https://example.com/dashboard")
+
+	assert.NoError(service.Send(context.Background(), "subject", "message"))
+	assert.NoError(service.Resolve(context.Background(), "api-01/disk-full"))
+	assert.Equal([]alert{
+		{
+			AlertUID:              "api-01/disk-full",
+			Title:                 "subject",
+			ImageURL:              "https://example.com/graph.png",
+			State:                 StateAlerting,
+			LinkToUpstreamDetails: "https://example.com/dashboard",
+			Message:               "message",
+		},
+		{AlertUID: "api-01/disk-full", State: StateResolved},
+	}, alerts)
+
+	// Test error response
+	service.AddReceivers(server.URL + "/invalid/")
+	assert.Error(service.Send(context.Background(), "subject", "message"))
+}
+
+func TestGrafanaOnCall_Validate(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := New()
+	service.AddReceivers("https://oncall.example.com/integrations/v1/formatted_webhook/abc/")
+	service.SetLink("https://grafana.example.com/d/abc")
+	assert.NoError(service.Validate())
+
+	service.AddReceivers("oncall.example.com/integrations/v1/formatted_webhook/abc/")
+	service.SetImageURL("graph.png")
+	service.SetLink("/d/abc")
+	err := service.Validate()
+	assert.ErrorIs(err, notify.ErrInvalidConfig)
+	assert.ErrorContains(err, "webhook URL")
+	assert.ErrorContains(err, "image URL")
+	assert.ErrorContains(err, "link")
+}
@@ -0,0 +1,36 @@
+/*
+Package gsmmodem provides a service for sending SMS messages through a GSM modem attached to a serial port.
+
+This allows sending alerts from networks without internet access. Messages are sent in SMS text mode.
+
+Usage:
+
+	package main
+
+	import (
+	    "context"
+	    "log"
+
+	    "github.com/nikoksr/notify"
+	    "github.com/nikoksr/notify/service/gsmmodem"
+	)
+
+	func main() {
+	    modemService := gsmmodem.New("/dev/ttyUSB0", gsmmodem.DefaultBaudRate)
+
+	    // Unlock the SIM card, if required.
+	    modemService.SetPIN("1234")
+
+	    // Add phone numbers.
+	    modemService.AddReceivers("+491701234567")
+
+	    // Tell our notifier to use the GSM modem service.
+	    notify.UseServices(modemService)
+
+	    // Send a test message.
+	    if err := notify.Send(context.Background(), "Subject/Title", "The actual message - Hello, you awesome gophers! :)"); err != nil {
+	        log.Fatal(err)
+	    }
+	}
+*/
+package gsmmodem
@@ -0,0 +1,135 @@
+package gsmmodem
+
+import (
+	"context"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/tarm/serial"
+
+	"github.com/nikoksr/notify"
+)
+
+const (
+	// DefaultBaudRate is the baud rate most GSM modems use out of the box.
+	DefaultBaudRate = 115200
+
+	// maxLength is the maximum number of characters of a single text mode SMS.
+	maxLength = 160
+
+	defaultTimeout = 30 * time.Second
+)
+
+// Compile-time check to ensure that Service implements the notify.Limiter interface.
+var _ notify.Limiter = new(Service)
+
+// Service encapsulates a GSM modem attached to a serial port.
+type Service struct {
+	mu sync.Mutex
+
+	device    string
+	baudRate  int
+	pin       string
+	timeout   time.Duration
+	receivers []string
+
+	open func(device string, baudRate int) (io.ReadWriteCloser, error)
+}
+
+// openSerial opens the serial port with a short read timeout, so that reads never block forever.
+func openSerial(device string, baudRate int) (io.ReadWriteCloser, error) {
+	return serial.OpenPort(&serial.Config{
+		Name:        device,
+		Baud:        baudRate,
+		ReadTimeout: 500 * time.Millisecond,
+	})
+}
+
+// New returns a new instance of a GSM modem notification service. The device is the serial port the modem is attached
+// to, e.g. /dev/ttyUSB0 or COM3. A baud rate of 0 selects DefaultBaudRate.
+//
+// Messages are sent in SMS text mode and are truncated to 160 characters.
+func New(device string, baudRate int) *Service {
+	if baudRate <= 0 {
+		baudRate = DefaultBaudRate
+	}
+
+	return &Service{
+		device:    device,
+		baudRate:  baudRate,
+		timeout:   defaultTimeout,
+		receivers: []string{},
+		open:      openSerial,
+	}
+}
+
+// SetPIN sets the PIN used to unlock the SIM card, if it is locked.
+func (s *Service) SetPIN(pin string) {
+	s.pin = pin
+}
+
+// SetTimeout sets how long to wait for the modem to answer a single command. Sending an SMS can take several seconds
+// on a weak network. Defaults to 30 seconds.
+func (s *Service) SetTimeout(timeout time.Duration) {
+	s.timeout = timeout
+}
+
+// AddReceivers takes phone numbers and adds them to the internal receiver list. The Send method will send a given
+// message to all those numbers.
+func (s *Service) AddReceivers(phoneNumbers ...string) {
+	s.receivers = append(s.receivers, phoneNumbers...)
+}
+
+// Limits returns the maximum length of a single text mode SMS, which contains subject and body.
+func (s *Service) Limits() notify.Limits {
+	return notify.Limits{Total: maxLength}
+}
+
+// formatText joins subject and message and prepares them for text mode: control characters that end or abort the
+// message prompt are removed, line breaks are normalized and the text is truncated to a single SMS.
+func formatText(subject, message string) string {
+	text := subject + "\n" + message
+	text = strings.NewReplacer(ctrlZ, "", escape, "", "\r\n", "\n").Replace(text)
+
+	runes := []rune(text)
+	if len(runes) > maxLength {
+		runes = runes[:maxLength]
+	}
+
+	return string(runes)
+}
+
+// Send takes a message subject and a message body and sends them to all previously set phone numbers. The serial port
+// is opened for the duration of the call; concurrent calls are serialized.
+func (s *Service) Send(ctx context.Context, subject, message string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	port, err := s.open(s.device, s.baudRate)
+	if err != nil {
+		return errors.Wrapf(err, "failed to open serial port '%s'", s.device)
+	}
+	defer func() { _ = port.Close() }()
+
+	m := &modem{port: port, timeout: s.timeout}
+	if err = m.init(s.pin); err != nil {
+		return errors.Wrap(err, "failed to initialize GSM modem")
+	}
+
+	text := formatText(subject, message)
+	for _, phone := range s.receivers {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			if err = m.sendSMS(phone, text); err != nil {
+				return errors.Wrapf(err, "failed to send SMS to '%s' via GSM modem", phone)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,153 @@
+package gsmmodem
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeModem answers AT commands like a GSM modem with a PIN locked SIM card.
+type fakeModem struct {
+	out      bytes.Buffer
+	prompt   string
+	commands []string
+	messages map[string]string
+	locked   bool
+	closed   bool
+}
+
+func newFakeModem() *fakeModem {
+	return &fakeModem{messages: map[string]string{}, locked: true}
+}
+
+func (m *fakeModem) Write(p []byte) (int, error) {
+	data := string(p)
+
+	if m.prompt != "" {
+		if strings.HasSuffix(data, ctrlZ) {
+			m.messages[m.prompt] = strings.TrimSuffix(data, ctrlZ)
+			m.out.WriteString("\r\n+CMGS: 1\r\n\r\nOK\r\n")
+		}
+		m.prompt = ""
+		return len(p), nil
+	}
+
+	cmd := strings.TrimSuffix(data, "\r")
+	m.commands = append(m.commands, cmd)
+
+	switch {
+	case cmd == "AT+CPIN?" && m.locked:
+		m.out.WriteString("\r\n+CPIN: SIM PIN\r\n\r\nOK\r\n")
+	case cmd == `AT+CPIN="1234"`:
+		m.locked = false
+		m.out.WriteString("\r\nOK\r\n")
+	case cmd == `AT+CMGS="invalid"`:
+		m.out.WriteString("\r\n+CMS ERROR: 21\r\n")
+	case strings.HasPrefix(cmd, "AT+CMGS="):
+		if m.locked {
+			m.out.WriteString("\r\n+CMS ERROR: 311\r\n")
+			break
+		}
+		m.prompt = strings.Trim(strings.TrimPrefix(cmd, "AT+CMGS="), `"`)
+		m.out.WriteString("\r\n> ")
+	default:
+		m.out.WriteString("\r\nOK\r\n")
+	}
+
+	return len(p), nil
+}
+
+func (m *fakeModem) Read(p []byte) (int, error) {
+	if m.out.Len() == 0 {
+		return 0, io.EOF
+	}
+
+	return m.out.Read(p)
+}
+
+func (m *fakeModem) Close() error {
+	m.closed = true
+	return nil
+}
+
+func TestGSMModem_Send(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	fake := newFakeModem()
+	service := New("/dev/ttyUSB0", 0)
+	service.open = func(device string, baudRate int) (io.ReadWriteCloser, error) {
+		assert.Equal("/dev/ttyUSB0", device)
+		assert.Equal(DefaultBaudRate, baudRate)
+		return fake, nil
+	}
+	service.SetPIN("1234")
+	service.AddReceivers("+491701234567", "+491707654321")
+
+	assert.NoError(service.Send(context.Background(), "subject", "message\x1a"))
+	assert.True(fake.closed)
+	assert.Equal([]string{
+		"AT",
+		"ATE0",
+		"AT+CPIN?",
+		`AT+CPIN="1234"`,
+		"AT+CMGF=1",
+		`AT+CMGS="+491701234567"`,
+		`AT+CMGS="+491707654321"`,
+	}, fake.commands)
+	assert.Equal(map[string]string{
+		"+491701234567": "subject\nmessage",
+		"+491707654321": "subject\nmessage",
+	}, fake.messages)
+
+	// Test error response
+	service.AddReceivers("invalid")
+	err := service.Send(context.Background(), "subject", "message")
+	assert.Error(err)
+	assert.Contains(err.Error(), "+CMS ERROR: 21")
+}
+
+func TestGSMModem_SendErrors(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	// Locked SIM without PIN
+	service := New("/dev/ttyUSB0", 9600)
+	service.open = func(string, int) (io.ReadWriteCloser, error) { return newFakeModem(), nil }
+	service.AddReceivers("+491701234567")
+	assert.Error(service.Send(context.Background(), "subject", "message"))
+
+	// Unresponsive modem
+	service.SetTimeout(10 * time.Millisecond)
+	service.open = func(string, int) (io.ReadWriteCloser, error) { return &silentPort{}, nil }
+	err := service.Send(context.Background(), "subject", "message")
+	assert.Error(err)
+	assert.Contains(err.Error(), "timed out")
+
+	// Serial port unavailable
+	service.open = func(string, int) (io.ReadWriteCloser, error) { return nil, errors.New("no such device") }
+	assert.Error(service.Send(context.Background(), "subject", "message"))
+}
+
+func TestFormatText(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	assert.Equal("subject\nline 1\nline 2", formatText("subject", "line 1\r\nline 2"))
+	assert.Len([]rune(formatText("subject", strings.Repeat("ä", 200))), maxLength)
+}
+
+type silentPort struct{}
+
+func (*silentPort) Read([]byte) (int, error)    { return 0, io.EOF }
+func (*silentPort) Write(p []byte) (int, error) { return len(p), nil }
+func (*silentPort) Close() error                { return nil }
@@ -0,0 +1,128 @@
+package gsmmodem
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
+)
+
+const (
+	ctrlZ  = "\x1a"
+	escape = "\x1b"
+)
+
+// modem wraps a serial connection to a GSM modem and speaks the AT command set.
+type modem struct {
+	port    io.ReadWriter
+	timeout time.Duration
+}
+
+// command writes the given command followed by a carriage return and waits for a final result code. It returns the
+// response without the result code.
+func (m *modem) command(cmd string) (string, error) {
+	if _, err := io.WriteString(m.port, cmd+"\r"); err != nil {
+		return "", errors.Wrapf(err, "write command %q", cmd)
+	}
+
+	return m.readResult()
+}
+
+// readResult reads from the modem until it answers with OK, ERROR or a message service error.
+func (m *modem) readResult() (string, error) {
+	resp, err := m.readUntil(func(buf []byte) bool {
+		return bytes.Contains(buf, []byte("OK\r\n")) ||
+			bytes.Contains(buf, []byte("ERROR"))
+	})
+	if err != nil {
+		return resp, err
+	}
+
+	if idx := strings.Index(resp, "ERROR"); idx >= 0 {
+		return resp, fmt.Errorf("modem returned %s", strings.TrimSpace(resp[strings.LastIndex(resp[:idx], "\n")+1:]))
+	}
+
+	return strings.TrimSpace(strings.Replace(resp, "OK\r\n", "", 1)), nil
+}
+
+// readUntil reads from the modem until done reports true for the data read so far or the timeout expires. Serial
+// ports configured with a read timeout report io.EOF when no data arrived in time, so io.EOF is not treated as error.
+func (m *modem) readUntil(done func([]byte) bool) (string, error) {
+	var buf []byte
+	chunk := make([]byte, 128)
+	deadline := time.Now().Add(m.timeout)
+
+	for !done(buf) {
+		if time.Now().After(deadline) {
+			return string(buf), notify.ClassifyError(errors.New("timed out waiting for modem response"), notify.ErrTransient)
+		}
+
+		n, err := m.port.Read(chunk)
+		buf = append(buf, chunk[:n]...)
+		if err != nil && !errors.Is(err, io.EOF) {
+			return string(buf), errors.Wrap(err, "read response")
+		}
+	}
+
+	return string(buf), nil
+}
+
+// init checks that the modem responds, unlocks the SIM if required and switches to SMS text mode.
+func (m *modem) init(pin string) error {
+	if _, err := m.command("AT"); err != nil {
+		return err
+	}
+	if _, err := m.command("ATE0"); err != nil {
+		return err
+	}
+
+	if pin != "" {
+		resp, err := m.command("AT+CPIN?")
+		if err != nil {
+			return err
+		}
+		if strings.Contains(resp, "SIM PIN") {
+			if _, err = m.command(fmt.Sprintf("AT+CPIN=%q", pin)); err != nil {
+				return errors.Wrap(err, "unlock SIM")
+			}
+		}
+	}
+
+	if _, err := m.command("AT+CMGF=1"); err != nil {
+		return errors.Wrap(err, "enable text mode")
+	}
+
+	return nil
+}
+
+// sendSMS sends a text mode SMS to the given phone number.
+func (m *modem) sendSMS(phoneNumber, text string) error {
+	if _, err := fmt.Fprintf(m.port, "AT+CMGS=%q\r", phoneNumber); err != nil {
+		return errors.Wrap(err, "write command")
+	}
+
+	resp, err := m.readUntil(func(buf []byte) bool {
+		return bytes.Contains(buf, []byte(">")) || bytes.Contains(buf, []byte("ERROR"))
+	})
+	if err != nil {
+		return err
+	}
+	if strings.Contains(resp, "ERROR") {
+		return fmt.Errorf("modem returned %s", strings.TrimSpace(resp))
+	}
+
+	if _, err = io.WriteString(m.port, text+ctrlZ); err != nil {
+		// Try to leave the message prompt, so that the modem accepts further commands.
+		_, _ = io.WriteString(m.port, escape)
+		return errors.Wrap(err, "write message")
+	}
+
+	_, err = m.readResult()
+
+	return err
+}
@@ -0,0 +1,31 @@
+/*
+Package homeassistant provides a service for sending notifications through the notify services of Home Assistant.
+
+Usage:
+
+	package main
+
+	import (
+	    "context"
+	    "log"
+
+	    "github.com/nikoksr/notify"
+	    "github.com/nikoksr/notify/service/homeassistant"
+	)
+
+	func main() {
+	    homeAssistantService := homeassistant.New("http://homeassistant.local:8123", "your-long-lived-token")
+
+	    // Call these notify services.
+	    homeAssistantService.AddReceivers("mobile_app_pixel_7", "persistent_notification")
+
+	    // Tell our notifier to use the home assistant service.
+	    notify.UseServices(homeAssistantService)
+
+	    // Send a test message.
+	    if err := notify.Send(context.Background(), "Subject/Title", "The actual message"); err != nil {
+	        log.Fatal(err)
+	    }
+	}
+*/
+package homeassistant
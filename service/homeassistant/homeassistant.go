@@ -0,0 +1,130 @@
+package homeassistant
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
+)
+
+// Service encapsulates the Home Assistant client.
+type Service struct {
+	client    *http.Client
+	baseURL   string
+	token     string
+	data      map[string]any
+	receivers []string
+}
+
+// New returns a new instance of a Home Assistant notification service. The base URL is the address of the instance,
+// e.g. http://homeassistant.local:8123, and the token a long-lived access token, which can be created on the user
+// profile page.
+// For more information about the Home Assistant REST API:
+//
+//	-> https://developers.home-assistant.io/docs/api/rest/
+func New(baseURL, token string) *Service {
+	return &Service{
+		client: &http.Client{
+			Transport: notify.NewRateLimitTransport(nil),
+			Timeout:   10 * time.Second,
+		},
+		baseURL:   strings.TrimSuffix(baseURL, "/"),
+		token:     token,
+		data:      map[string]any{},
+		receivers: []string{},
+	}
+}
+
+// WithClient sets the http client to be used for sending requests. Calling this method is optional, the default client
+// will be used if this method is not called.
+func (s *Service) WithClient(client *http.Client) {
+	if client != nil {
+		s.client = client
+	}
+}
+
+// AddReceivers takes names of notify services, e.g. "mobile_app_pixel_7" or "persistent_notification", and adds them
+// to the internal receiver list. The "notify." domain prefix is optional. The Send method will call every one of those
+// services.
+func (s *Service) AddReceivers(services ...string) {
+	for _, service := range services {
+		s.receivers = append(s.receivers, strings.TrimPrefix(service, "notify."))
+	}
+}
+
+// AddData adds a key to the data passed to the notify services, e.g. "priority" or "tag" for the companion apps.
+func (s *Service) AddData(key string, value any) {
+	s.data[key] = value
+}
+
+type serviceData struct {
+	Title   string         `json:"title,omitempty"`
+	Message string         `json:"message"`
+	Data    map[string]any `json:"data,omitempty"`
+}
+
+// Validate checks that the base URL is well-formed and that the token is set, see notify.Validator.
+func (s *Service) Validate() error {
+	var problems notify.ConfigErrors
+	problems.Check("base URL", notify.ValidateURL(s.baseURL))
+	problems.Require("token", s.token)
+
+	return problems.Err()
+}
+
+// Send takes a message subject and a message body and sends them to all previously set notify services.
+func (s *Service) Send(ctx context.Context, subject, message string) error {
+	data := serviceData{Title: subject, Message: message}
+	if len(s.data) > 0 {
+		data.Data = s.data
+	}
+
+	body, err := json.Marshal(data)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal service data")
+	}
+
+	for _, service := range s.receivers {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			if err = s.call(ctx, service, body); err != nil {
+				return errors.Wrapf(err, "failed to call notify service %q", service)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) call(ctx context.Context, service string, body []byte) error {
+	url := s.baseURL + "/api/services/notify/" + service
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "create request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+s.token)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "send request")
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		result, _ := io.ReadAll(resp.Body)
+		return notify.ClassifyResponse(resp, fmt.Errorf("home assistant returned status code %d: %s", resp.StatusCode, string(result)))
+	}
+
+	return nil
+}
@@ -0,0 +1,60 @@
+package homeassistant
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nikoksr/notify"
+)
+
+func TestHomeAssistant_Send(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	got := map[string]serviceData{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var data serviceData
+		_ = json.NewDecoder(r.Body).Decode(&data)
+		got[r.URL.Path] = data
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	service := New(server.URL+"/", "token")
+	service.AddReceivers("mobile_app_pixel_7", "notify.persistent_notification")
+	service.AddData("priority", "high")
+
+	assert.NoError(service.Send(context.Background(), "subject", "message"))
+	want := serviceData{Title: "subject", Message: "message", Data: map[string]any{"priority": "high"}}
+	assert.Equal(map[string]serviceData{
+		"/api/services/notify/mobile_app_pixel_7":      want,
+		"/api/services/notify/persistent_notification": want,
+	}, got)
+
+	// Test error response
+	service.token = "invalid"
+	assert.Error(service.Send(context.Background(), "subject", "message"))
+}
+
+func TestHomeAssistant_Validate(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	assert.NoError(New("http://homeassistant.local:8123", "token").Validate())
+
+	err := New("homeassistant.local:8123", "").Validate()
+	assert.ErrorIs(err, notify.ErrInvalidConfig)
+	assert.ErrorContains(err, "base URL")
+	assert.ErrorContains(err, "token is missing")
+}
@@ -14,6 +14,9 @@ import (
 	"github.com/nikoksr/notify"
 )
 
+// Compile-time check to ensure that Service implements the notify.JSONSender interface.
+var _ notify.JSONSender = new(Service)
+
 type (
 	// PreSendHookFn defines a function signature for a pre-send hook.
 	PreSendHookFn func(req *http.Request) error
@@ -48,6 +51,7 @@ type (
 		webhooks      []*Webhook
 		preSendHooks  []PreSendHookFn
 		postSendHooks []PostSendHookFn
+		signer        *notify.Signer
 		Serializer    Serializer
 	}
 )
@@ -101,7 +105,7 @@ func buildDefaultPayload(subject, message string) any {
 // empty, it has a fallback value.
 func New() *Service {
 	return &Service{
-		client:        http.DefaultClient,
+		client:        &http.Client{Transport: notify.NewRateLimitTransport(nil)},
 		webhooks:      []*Webhook{},
 		preSendHooks:  []PreSendHookFn{},
 		postSendHooks: []PostSendHookFn{},
@@ -150,6 +154,13 @@ func (s *Service) WithClient(client *http.Client) {
 	}
 }
 
+// SetSigner makes the service sign the payload of every request with the given signer, so that receivers can verify
+// that it was sent by this service. The signature is set after the webhook headers and before the pre-send hooks run.
+// See notify.Signer.
+func (s *Service) SetSigner(signer *notify.Signer) {
+	s.signer = signer
+}
+
 // doPreSendHooks executes all the pre-send hooks. If any of the hooks returns an error, the execution is stopped and
 // the error is returned.
 func (s *Service) doPreSendHooks(req *http.Request) error {
@@ -216,7 +227,7 @@ func (s *Service) do(req *http.Request) error {
 	// Actually send the HTTP request.
 	resp, err := s.client.Do(req)
 	if err != nil {
-		return err
+		return notify.ClassifyNetworkError(err)
 	}
 	defer func() { _ = resp.Body.Close() }()
 
@@ -227,7 +238,7 @@ func (s *Service) do(req *http.Request) error {
 
 	// Check if response code is 2xx. Should this be configurable?
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return fmt.Errorf("responded with status code: %d", resp.StatusCode)
+		return notify.ClassifyResponse(resp, fmt.Errorf("responded with status code: %d", resp.StatusCode))
 	}
 
 	return nil
@@ -243,11 +254,51 @@ func (s *Service) send(ctx context.Context, webhook *Webhook, payload []byte) er
 	}
 	defer func() { _ = req.Body.Close() }()
 
+	if s.signer != nil {
+		s.signer.SignRequest(req, payload)
+	}
+
 	return s.do(req)
 }
 
+// Validate checks that the URLs of the webhooks are well-formed and that every webhook has a method and a payload
+// builder, see notify.Validator.
+func (s *Service) Validate() error {
+	var problems notify.ConfigErrors
+	for _, webhook := range s.webhooks {
+		if webhook == nil {
+			continue
+		}
+		problems.Check("webhook URL", notify.ValidateURL(webhook.URL))
+		if webhook.Method == "" {
+			problems.Addf("webhook %q has no method", webhook.URL)
+		}
+		if webhook.BuildPayload == nil {
+			problems.Addf("webhook %q has no payload builder", webhook.URL)
+		}
+	}
+
+	return problems.Err()
+}
+
 // Send takes a message and sends it to all webhooks.
 func (s *Service) Send(ctx context.Context, subject, message string) error {
+	return s.sendPayloads(ctx, func(webhook *Webhook) any {
+		return webhook.BuildPayload(subject, message)
+	})
+}
+
+// SendJSON sends v as payload to all webhooks, instead of the payload their BuildPayload function builds. It is
+// serialized for the content type of each webhook by the Serializer, so webhooks need a content type the Serializer
+// can serialize arbitrary values for, e.g. application/json for the default one.
+func (s *Service) SendJSON(ctx context.Context, v any) error {
+	return s.sendPayloads(ctx, func(*Webhook) any {
+		return v
+	})
+}
+
+// sendPayloads sends the payload returned by build for each webhook to all webhooks.
+func (s *Service) sendPayloads(ctx context.Context, build func(webhook *Webhook) any) error {
 	// Send message to all webhooks.
 	for _, webhook := range s.webhooks {
 		select {
@@ -259,11 +310,8 @@ func (s *Service) Send(ctx context.Context, subject, message string) error {
 				continue
 			}
 
-			// Build the payload for the current webhook.
-			payload := webhook.BuildPayload(subject, message)
-
 			// Marshal the message into a payload.
-			payloadRaw, err := s.Serializer.Marshal(webhook.ContentType, payload)
+			payloadRaw, err := s.Serializer.Marshal(webhook.ContentType, build(webhook))
 			if err != nil {
 				return errors.Wrap(err, "marshal payload")
 			}
@@ -13,6 +13,8 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+
+	"github.com/nikoksr/notify"
 )
 
 // Set up a test server to handle the requests
@@ -316,6 +318,44 @@ func TestService_Send(t *testing.T) {
 	assert.Error(t, err, "error should not be nil")
 }
 
+func TestService_SendJSON(t *testing.T) {
+	t.Parallel()
+
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+	}))
+	defer server.Close()
+
+	service := New()
+	service.AddReceiversURLs(server.URL)
+
+	err := service.SendJSON(context.Background(), map[string]any{"service": "api", "version": "1.2.3"})
+	assert.NoError(t, err, "error should be nil")
+	assert.JSONEq(t, `{"service":"api","version":"1.2.3"}`, string(body))
+
+	err = service.SendJSON(context.Background(), make(chan int))
+	assert.Error(t, err, "error should not be nil")
+}
+
+func TestService_Validate(t *testing.T) {
+	t.Parallel()
+
+	s := New()
+	s.AddReceiversURLs("https://example.com/hook")
+	assert.NoError(t, s.Validate())
+
+	s.AddReceivers(
+		newWebhook("example.com/hook"),
+		&Webhook{URL: "https://example.com/other"},
+	)
+	err := s.Validate()
+	assert.ErrorIs(t, err, notify.ErrInvalidConfig)
+	assert.ErrorContains(t, err, "webhook URL")
+	assert.ErrorContains(t, err, `webhook "https://example.com/other" has no method`)
+	assert.ErrorContains(t, err, `webhook "https://example.com/other" has no payload builder`)
+}
+
 func Test_newWebhook(t *testing.T) {
 	t.Parallel()
 
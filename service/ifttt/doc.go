@@ -0,0 +1,32 @@
+/*
+Package ifttt provides a service for triggering IFTTT applets via Webhooks events.
+
+Usage:
+
+	package main
+
+	import (
+	    "context"
+	    "log"
+
+	    "github.com/nikoksr/notify"
+	    "github.com/nikoksr/notify/service/ifttt"
+	)
+
+	func main() {
+	    iftttService := ifttt.New("your-webhooks-key")
+
+	    // Trigger these events. The subject is passed as value1, the message as value2.
+	    iftttService.AddReceivers("server_down")
+	    iftttService.SetValue3("api-01")
+
+	    // Tell our notifier to use the ifttt service.
+	    notify.UseServices(iftttService)
+
+	    // Send a test message.
+	    if err := notify.Send(context.Background(), "Subject/Title", "The actual message"); err != nil {
+	        log.Fatal(err)
+	    }
+	}
+*/
+package ifttt
@@ -0,0 +1,154 @@
+package ifttt
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
+)
+
+const defaultBaseURL = "https://maker.ifttt.com"
+
+// Compile-time check to ensure that Service implements the notify.JSONSender interface.
+var _ notify.JSONSender = new(Service)
+
+// Service encapsulates the IFTTT Webhooks client.
+type Service struct {
+	client  *http.Client
+	baseURL string
+	key     string
+	value3  string
+	events  []string
+	signer  *notify.Signer
+}
+
+// New returns a new instance of an IFTTT notification service. The key can be found in the documentation section of
+// the Webhooks service: https://ifttt.com/maker_webhooks/settings.
+func New(key string) *Service {
+	return &Service{
+		client: &http.Client{
+			Transport: notify.NewRateLimitTransport(nil),
+			Timeout:   10 * time.Second,
+		},
+		baseURL: defaultBaseURL,
+		key:     key,
+		events:  []string{},
+	}
+}
+
+// WithClient sets the http client to be used for sending requests. Calling this method is optional, the default client
+// will be used if this method is not called.
+func (s *Service) WithClient(client *http.Client) {
+	if client != nil {
+		s.client = client
+	}
+}
+
+// AddReceivers takes event names and adds them to the internal event list. The Send method will trigger every one of
+// those events, and with it all applets listening for them.
+func (s *Service) AddReceivers(eventNames ...string) {
+	s.events = append(s.events, eventNames...)
+}
+
+// SetValue3 sets the third ingredient passed with every event, e.g. a link or the name of the sending host. The first
+// two ingredients are the subject and the message.
+func (s *Service) SetValue3(value string) {
+	s.value3 = value
+}
+
+// SetSigner makes the service sign every payload with the given signer, so that the receiver can verify that it was
+// sent by this service. See notify.Signer.
+func (s *Service) SetSigner(signer *notify.Signer) {
+	s.signer = signer
+}
+
+type values struct {
+	Value1 string `json:"value1"`
+	Value2 string `json:"value2"`
+	Value3 string `json:"value3,omitempty"`
+}
+
+// Validate checks that the key is set and that no event name is empty, see notify.Validator.
+func (s *Service) Validate() error {
+	var problems notify.ConfigErrors
+	problems.Require("key", s.key)
+	problems.Check("base URL", notify.ValidateURL(s.baseURL))
+	for _, event := range s.events {
+		if event == "" {
+			problems.Addf("event name is empty")
+		}
+	}
+
+	return problems.Err()
+}
+
+// Send takes a message subject and a message body and triggers all previously set events with the subject as value1
+// and the message as value2.
+func (s *Service) Send(ctx context.Context, subject, message string) error {
+	body, err := json.Marshal(values{Value1: subject, Value2: message, Value3: s.value3})
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal values")
+	}
+
+	return s.triggerAll(ctx, "/with/key/", body)
+}
+
+// SendJSON triggers all previously set events with the JSON encoding of v as payload, through the JSON endpoint of the
+// Webhooks service. Applets receive the payload as JsonPayload ingredient instead of value1 to value3.
+func (s *Service) SendJSON(ctx context.Context, v any) error {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal payload")
+	}
+
+	return s.triggerAll(ctx, "/json/with/key/", body)
+}
+
+// triggerAll triggers all events through the endpoint with the given path between event name and key.
+func (s *Service) triggerAll(ctx context.Context, path string, body []byte) error {
+	for _, event := range s.events {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			if err := s.trigger(ctx, event, path, body); err != nil {
+				return errors.Wrapf(err, "failed to trigger event %q", event)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) trigger(ctx context.Context, event, path string, body []byte) error {
+	endpoint := s.baseURL + "/trigger/" + url.PathEscape(event) + path + url.PathEscape(s.key)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "create request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.signer != nil {
+		s.signer.SignRequest(req, body)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "send request")
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		result, _ := io.ReadAll(resp.Body)
+		return notify.ClassifyResponse(resp, fmt.Errorf("ifttt returned status code %d: %s", resp.StatusCode, string(result)))
+	}
+
+	return nil
+}
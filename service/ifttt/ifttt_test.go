@@ -0,0 +1,93 @@
+package ifttt
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nikoksr/notify"
+)
+
+func TestIFTTT_Send(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	got := map[string]values{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var v values
+		_ = json.NewDecoder(r.Body).Decode(&v)
+		switch r.URL.Path {
+		case "/trigger/server_down/with/key/key", "/trigger/backup done/with/key/key":
+			got[r.URL.Path] = v
+			_, _ = w.Write([]byte("Congratulations! You've fired the event"))
+		default:
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer server.Close()
+
+	service := New("key")
+	service.baseURL = server.URL
+	service.AddReceivers("server_down", "backup done")
+	service.SetValue3("api-01")
+
+	assert.NoError(service.Send(context.Background(), "subject", "message"))
+	want := values{Value1: "subject", Value2: "message", Value3: "api-01"}
+	assert.Equal(map[string]values{
+		"/trigger/server_down/with/key/key": want,
+		"/trigger/backup done/with/key/key": want,
+	}, got)
+
+	// Test error response
+	service.key = "invalid"
+	assert.Error(service.Send(context.Background(), "subject", "message"))
+}
+
+func TestIFTTT_SendJSON(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	got := map[string]map[string]any{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var v map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&v)
+		got[r.URL.Path] = v
+	}))
+	defer server.Close()
+
+	service := New("key")
+	service.baseURL = server.URL
+	service.AddReceivers("deployed")
+
+	assert.NoError(service.SendJSON(context.Background(), map[string]any{"service": "api", "version": "1.2.3"}))
+	assert.Equal(map[string]map[string]any{
+		"/trigger/deployed/json/with/key/key": {"service": "api", "version": "1.2.3"},
+	}, got)
+
+	assert.Error(service.SendJSON(context.Background(), make(chan int)))
+}
+
+func TestIFTTT_Validate(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := New("key")
+	service.AddReceivers("server_down")
+	assert.NoError(service.Validate())
+
+	service = New("")
+	service.baseURL = "maker.ifttt.com"
+	service.AddReceivers("")
+	err := service.Validate()
+	assert.ErrorIs(err, notify.ErrInvalidConfig)
+	assert.ErrorContains(err, "key is missing")
+	assert.ErrorContains(err, "base URL")
+	assert.ErrorContains(err, "event name is empty")
+}
@@ -0,0 +1,36 @@
+/*
+Package infobip provides a service for sending SMS and WhatsApp messages via Infobip.
+
+Usage:
+
+	package main
+
+	import (
+	    "context"
+	    "log"
+
+	    "github.com/nikoksr/notify"
+	    "github.com/nikoksr/notify/service/infobip"
+	)
+
+	func main() {
+	    infobipService := infobip.New("xxxxx.api.infobip.com", "your-api-key")
+
+	    // Send SMS to these phone numbers.
+	    infobipService.SetSender(infobip.SMS, "Notify")
+	    infobipService.AddReceivers(infobip.SMS, "41793026727")
+
+	    // Send WhatsApp messages to these phone numbers.
+	    infobipService.SetSender(infobip.WhatsApp, "441134960000")
+	    infobipService.AddReceivers(infobip.WhatsApp, "41793026727")
+
+	    // Tell our notifier to use the infobip service.
+	    notify.UseServices(infobipService)
+
+	    // Send a test message.
+	    if err := notify.Send(context.Background(), "Subject/Title", "The actual message"); err != nil {
+	        log.Fatal(err)
+	    }
+	}
+*/
+package infobip
@@ -0,0 +1,206 @@
+package infobip
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
+)
+
+// Channel is a messaging channel supported by Infobip.
+type Channel string
+
+// Channels supported by the service.
+const (
+	SMS      Channel = "sms"
+	WhatsApp Channel = "whatsapp"
+)
+
+// Service encapsulates the Infobip client.
+type Service struct {
+	client    *http.Client
+	baseURL   string
+	apiKey    string
+	senders   map[Channel]string
+	receivers map[Channel][]string
+}
+
+// New returns a new instance of an Infobip notification service. The base URL is specific to each account, e.g.
+// https://xxxxx.api.infobip.com, and can be found together with the API key on the Infobip portal homepage.
+// For more information about the Infobip API:
+//
+//	-> https://www.infobip.com/docs/api
+func New(baseURL, apiKey string) *Service {
+	if !strings.HasPrefix(baseURL, "http://") && !strings.HasPrefix(baseURL, "https://") {
+		baseURL = "https://" + baseURL
+	}
+
+	return &Service{
+		client: &http.Client{
+			Transport: notify.NewRateLimitTransport(nil),
+			Timeout:   10 * time.Second,
+		},
+		baseURL:   strings.TrimSuffix(baseURL, "/"),
+		apiKey:    apiKey,
+		senders:   map[Channel]string{},
+		receivers: map[Channel][]string{},
+	}
+}
+
+// WithClient sets the http client to be used for sending requests. Calling this method is optional, the default client
+// will be used if this method is not called.
+func (s *Service) WithClient(client *http.Client) {
+	if client != nil {
+		s.client = client
+	}
+}
+
+// SetSender sets the sender for the given channel. For SMS this is a phone number or an alphanumeric sender ID; if
+// unset, Infobip uses a default sender. For WhatsApp it is the registered WhatsApp business number and is required.
+func (s *Service) SetSender(channel Channel, from string) {
+	s.senders[channel] = from
+}
+
+// AddReceivers takes phone numbers in international format and adds them as receivers on the given channel.
+func (s *Service) AddReceivers(channel Channel, phoneNumbers ...string) {
+	s.receivers[channel] = append(s.receivers[channel], phoneNumbers...)
+}
+
+type smsDestination struct {
+	To string `json:"to"`
+}
+
+type smsMessage struct {
+	From         string           `json:"from,omitempty"`
+	Destinations []smsDestination `json:"destinations"`
+	Text         string           `json:"text"`
+}
+
+type smsRequest struct {
+	Messages []smsMessage `json:"messages"`
+}
+
+type whatsAppContent struct {
+	Text string `json:"text"`
+}
+
+type whatsAppRequest struct {
+	From    string          `json:"from"`
+	To      string          `json:"to"`
+	Content whatsAppContent `json:"content"`
+}
+
+type errorResponse struct {
+	RequestError struct {
+		ServiceException struct {
+			MessageID string `json:"messageId"`
+			Text      string `json:"text"`
+		} `json:"serviceException"`
+	} `json:"requestError"`
+}
+
+// Validate checks that the base URL is well-formed, that the API key is set, that WhatsApp receivers have a sender and
+// that the receivers are phone numbers in international format, see notify.Validator.
+func (s *Service) Validate() error {
+	var problems notify.ConfigErrors
+	problems.Check("base URL", notify.ValidateURL(s.baseURL))
+	problems.Require("API key", s.apiKey)
+	if len(s.receivers[WhatsApp]) > 0 && s.senders[WhatsApp] == "" {
+		problems.Addf("WhatsApp sender is missing, see SetSender")
+	}
+	for _, channel := range []Channel{SMS, WhatsApp} {
+		for _, number := range s.receivers[channel] {
+			problems.Check(string(channel)+" receiver", notify.ValidatePhoneNumber("+"+strings.TrimPrefix(number, "+")))
+		}
+	}
+
+	return problems.Err()
+}
+
+// Send takes a message subject and a message body and sends them to all previously set receivers, on their respective
+// channels. Subject and message are joined by a newline. All SMS receivers are sent a single bulk request, WhatsApp
+// receivers are sent one request each.
+func (s *Service) Send(ctx context.Context, subject, message string) error {
+	text := subject + "\n" + message
+
+	if receivers := s.receivers[SMS]; len(receivers) > 0 {
+		destinations := make([]smsDestination, 0, len(receivers))
+		for _, receiver := range receivers {
+			destinations = append(destinations, smsDestination{To: receiver})
+		}
+
+		req := smsRequest{Messages: []smsMessage{{
+			From:         s.senders[SMS],
+			Destinations: destinations,
+			Text:         text,
+		}}}
+		if err := s.post(ctx, "/sms/2/text/advanced", req); err != nil {
+			return errors.Wrap(err, "failed to send sms")
+		}
+	}
+
+	receivers := s.receivers[WhatsApp]
+	if len(receivers) > 0 && s.senders[WhatsApp] == "" {
+		return errors.New("failed to send whatsapp message: no sender set")
+	}
+	for _, receiver := range receivers {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			req := whatsAppRequest{
+				From:    s.senders[WhatsApp],
+				To:      receiver,
+				Content: whatsAppContent{Text: text},
+			}
+			if err := s.post(ctx, "/whatsapp/1/message/text", req); err != nil {
+				return errors.Wrapf(err, "failed to send whatsapp message to %q", receiver)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) post(ctx context.Context, path string, payload any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return errors.Wrap(err, "marshal request")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.baseURL+path, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "create request")
+	}
+	req.Header.Set("Authorization", "App "+s.apiKey)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "send request")
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		b, _ := io.ReadAll(resp.Body)
+
+		var errResp errorResponse
+		if json.Unmarshal(b, &errResp) == nil && errResp.RequestError.ServiceException.Text != "" {
+			exception := errResp.RequestError.ServiceException
+			return fmt.Errorf("%s: %s", exception.MessageID, exception.Text)
+		}
+
+		return notify.ClassifyResponse(resp, fmt.Errorf("status %d: %s", resp.StatusCode, string(b)))
+	}
+
+	return nil
+}
@@ -0,0 +1,103 @@
+package infobip
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nikoksr/notify"
+)
+
+func TestInfobip_New(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := New("xxxxx.api.infobip.com/", "key")
+	assert.Equal("https://xxxxx.api.infobip.com", service.baseURL)
+}
+
+func TestInfobip_Validate(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := New("https://xxxxx.api.infobip.com", "key")
+	service.AddReceivers(SMS, "41793026727", "+41793026728")
+	assert.NoError(service.Validate())
+
+	service = New("", "")
+	service.AddReceivers(WhatsApp, "0793026727")
+	err := service.Validate()
+	assert.ErrorIs(err, notify.ErrInvalidConfig)
+	assert.ErrorContains(err, "base URL")
+	assert.ErrorContains(err, "API key is missing")
+	assert.ErrorContains(err, "WhatsApp sender is missing")
+	assert.ErrorContains(err, "whatsapp receiver")
+}
+
+func TestInfobip_Send(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	var (
+		sms      []smsRequest
+		whatsApp []whatsAppRequest
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "App key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"requestError":{"serviceException":{"messageId":"UNAUTHORIZED","text":"Invalid login details"}}}`))
+			return
+		}
+		switch r.URL.Path {
+		case "/sms/2/text/advanced":
+			var req smsRequest
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			sms = append(sms, req)
+		case "/whatsapp/1/message/text":
+			var req whatsAppRequest
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			whatsApp = append(whatsApp, req)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	service := New(server.URL, "key")
+
+	// No receivers added
+	assert.NoError(service.Send(context.Background(), "subject", "message"))
+
+	service.SetSender(SMS, "Notify")
+	service.AddReceivers(SMS, "41793026727", "41793026728")
+	service.AddReceivers(WhatsApp, "41793026729")
+
+	// WhatsApp requires a sender
+	assert.Error(service.Send(context.Background(), "subject", "message"))
+
+	sms = nil
+	service.SetSender(WhatsApp, "441134960000")
+	assert.NoError(service.Send(context.Background(), "subject", "message"))
+	assert.Equal([]smsRequest{{Messages: []smsMessage{{
+		From:         "Notify",
+		Destinations: []smsDestination{{To: "41793026727"}, {To: "41793026728"}},
+		Text:         "subject\nmessage",
+	}}}}, sms)
+	assert.Equal([]whatsAppRequest{{
+		From:    "441134960000",
+		To:      "41793026729",
+		Content: whatsAppContent{Text: "subject\nmessage"},
+	}}, whatsApp)
+
+	// Test error response
+	service.apiKey = "invalid"
+	err := service.Send(context.Background(), "subject", "message")
+	assert.ErrorContains(err, "Invalid login details")
+}
@@ -0,0 +1,34 @@
+/*
+Package intercom provides a service for sending in-app or email messages to users through Intercom.
+
+Usage:
+
+	package main
+
+	import (
+	    "context"
+	    "log"
+
+	    "github.com/nikoksr/notify"
+	    "github.com/nikoksr/notify/service/intercom"
+	)
+
+	func main() {
+	    intercomService := intercom.New("your-access-token", "your-admin-id")
+
+	    // Optionally send emails instead of in-app messages.
+	    intercomService.SetMessageType(intercom.Email)
+
+	    // Add contact IDs of users.
+	    intercomService.AddReceivers("5f7f0d1b2c3d4e5f6a7b8c9d")
+
+	    // Tell our notifier to use the intercom service.
+	    notify.UseServices(intercomService)
+
+	    // Send a test message.
+	    if err := notify.Send(context.Background(), "Subject/Title", "The actual message - Hello, you awesome gophers! :)"); err != nil {
+	        log.Fatal(err)
+	    }
+	}
+*/
+package intercom
@@ -0,0 +1,158 @@
+package intercom
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
+)
+
+const (
+	defaultAPIURL = "https://api.intercom.io"
+	apiVersion    = "2.10"
+)
+
+// MessageType is the channel admin initiated messages are delivered through.
+type MessageType string
+
+// Message types supported by Intercom.
+const (
+	InApp MessageType = "inapp"
+	Email MessageType = "email"
+)
+
+// Service encapsulates the Intercom client.
+type Service struct {
+	client      *http.Client
+	apiURL      string
+	accessToken string
+	adminID     string
+	messageType MessageType
+	contactIDs  []string
+}
+
+// New returns a new instance of an Intercom notification service. The access token belongs to an Intercom app, the
+// admin ID identifies the teammate messages are sent by.
+// For more information about admin initiated messages:
+//
+//	-> https://developers.intercom.com/docs/references/rest-api/api.intercom.io/Messages/createMessage/
+func New(accessToken, adminID string) *Service {
+	return &Service{
+		client: &http.Client{
+			Transport: notify.NewRateLimitTransport(nil),
+			Timeout:   10 * time.Second,
+		},
+		apiURL:      defaultAPIURL,
+		accessToken: accessToken,
+		adminID:     adminID,
+		messageType: InApp,
+		contactIDs:  []string{},
+	}
+}
+
+// WithClient sets the http client to be used for sending requests. Calling this method is optional, the default client
+// will be used if this method is not called.
+func (s *Service) WithClient(client *http.Client) {
+	if client != nil {
+		s.client = client
+	}
+}
+
+// AddReceivers takes Intercom contact IDs of users and adds them to the internal contact list. The Send method will
+// message every one of those users; each message starts a new conversation.
+func (s *Service) AddReceivers(contactIDs ...string) {
+	s.contactIDs = append(s.contactIDs, contactIDs...)
+}
+
+// SetMessageType sets whether messages are shown in-app or sent by email. Defaults to InApp.
+func (s *Service) SetMessageType(messageType MessageType) {
+	s.messageType = messageType
+}
+
+type participant struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+}
+
+type messageRequest struct {
+	MessageType MessageType `json:"message_type"`
+	Subject     string      `json:"subject,omitempty"`
+	Body        string      `json:"body"`
+	Template    string      `json:"template,omitempty"`
+	From        participant `json:"from"`
+	To          participant `json:"to"`
+}
+
+// formatBody escapes the message and converts line breaks into paragraphs.
+func formatBody(message string) string {
+	paragraphs := strings.Split(html.EscapeString(message), "\n")
+	return "<p>" + strings.Join(paragraphs, "</p><p>") + "</p>"
+}
+
+// Send takes a message subject and a message body and sends them to all previously set contacts. Emails use the
+// subject as email subject; in-app messages show it in bold above the message.
+func (s *Service) Send(ctx context.Context, subject, message string) error {
+	payload := messageRequest{
+		MessageType: s.messageType,
+		Body:        formatBody(message),
+		From:        participant{Type: "admin", ID: s.adminID},
+	}
+	if s.messageType == Email {
+		payload.Subject = subject
+		payload.Template = "plain"
+	} else if subject != "" {
+		payload.Body = "<p><b>" + html.EscapeString(subject) + "</b></p>" + payload.Body
+	}
+
+	for _, contactID := range s.contactIDs {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			payload.To = participant{Type: "user", ID: contactID}
+			if err := s.send(ctx, payload); err != nil {
+				return errors.Wrapf(err, "failed to send message to Intercom contact '%s'", contactID)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) send(ctx context.Context, payload messageRequest) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return errors.Wrap(err, "marshal message")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.apiURL+"/messages", bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "create request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", "Bearer "+s.accessToken)
+	req.Header.Set("Intercom-Version", apiVersion)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "send request")
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		result, _ := io.ReadAll(resp.Body)
+		return notify.ClassifyResponse(resp, fmt.Errorf("intercom returned status code %d: %s", resp.StatusCode, string(result)))
+	}
+
+	return nil
+}
@@ -0,0 +1,61 @@
+package intercom
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntercom_Send(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	var got []messageRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" || r.Header.Get("Intercom-Version") != apiVersion {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"type":"error.list","errors":[{"code":"unauthorized"}]}`))
+			return
+		}
+		var m messageRequest
+		_ = json.NewDecoder(r.Body).Decode(&m)
+		got = append(got, m)
+		_, _ = w.Write([]byte(`{"type":"user_message","id":"1"}`))
+	}))
+	defer server.Close()
+
+	service := New("token", "42")
+	service.apiURL = server.URL
+	service.AddReceivers("contact-1")
+
+	assert.NoError(service.Send(context.Background(), "Scheduled <maintenance>", "Tonight\nat 10pm"))
+
+	service.SetMessageType(Email)
+	assert.NoError(service.Send(context.Background(), "Scheduled maintenance", "Tonight"))
+
+	assert.Equal([]messageRequest{
+		{
+			MessageType: InApp,
+			Body:        "<p><b>Scheduled &lt;maintenance&gt;</b></p><p>Tonight</p><p>at 10pm</p>",
+			From:        participant{Type: "admin", ID: "42"},
+			To:          participant{Type: "user", ID: "contact-1"},
+		},
+		{
+			MessageType: Email,
+			Subject:     "Scheduled maintenance",
+			Body:        "<p>Tonight</p>",
+			Template:    "plain",
+			From:        participant{Type: "admin", ID: "42"},
+			To:          participant{Type: "user", ID: "contact-1"},
+		},
+	}, got)
+
+	// Test error response
+	service.accessToken = "invalid"
+	assert.Error(service.Send(context.Background(), "subject", "message"))
+}
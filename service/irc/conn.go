@@ -0,0 +1,248 @@
+package irc
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
+)
+
+// Numeric replies we're interested in.
+const (
+	rplWelcome        = "001"
+	rplEndOfNames     = "366"
+	errNicknameInUse  = "433"
+	errPasswdMismatch = "464"
+	rplSASLSuccess    = "903"
+	errSASLFail       = "904"
+	errSASLTooLong    = "905"
+	errSASLAborted    = "906"
+)
+
+// message is a parsed IRC protocol message.
+type message struct {
+	prefix  string
+	command string
+	params  []string
+}
+
+// parseMessage parses a single IRC line without the trailing CRLF.
+func parseMessage(line string) message {
+	var msg message
+
+	if strings.HasPrefix(line, "@") { // Skip IRCv3 message tags
+		if i := strings.IndexByte(line, ' '); i >= 0 {
+			line = line[i+1:]
+		}
+	}
+	if strings.HasPrefix(line, ":") {
+		i := strings.IndexByte(line, ' ')
+		if i < 0 {
+			return msg
+		}
+		msg.prefix, line = line[1:i], line[i+1:]
+	}
+
+	var trailing string
+	hasTrailing := false
+	if i := strings.Index(line, " :"); i >= 0 {
+		line, trailing, hasTrailing = line[:i], line[i+2:], true
+	}
+
+	fields := strings.Fields(line)
+	if len(fields) > 0 {
+		msg.command = strings.ToUpper(fields[0])
+		msg.params = fields[1:]
+	}
+	if hasTrailing {
+		msg.params = append(msg.params, trailing)
+	}
+
+	return msg
+}
+
+// param returns the i-th parameter of the message or an empty string.
+func (m message) param(i int) string {
+	if i < len(m.params) {
+		return m.params[i]
+	}
+
+	return ""
+}
+
+// conn is a registered connection to an IRC server. A background goroutine reads from the server, answers PINGs and
+// forwards all other messages to the incoming channel.
+type conn struct {
+	netConn  net.Conn
+	incoming chan message
+	done     chan struct{}
+
+	mu  sync.Mutex
+	err error
+}
+
+func newConn(netConn net.Conn) *conn {
+	c := &conn{
+		netConn:  netConn,
+		incoming: make(chan message, 64),
+		done:     make(chan struct{}),
+	}
+	go c.readLoop()
+
+	return c
+}
+
+func (c *conn) readLoop() {
+	defer close(c.done)
+
+	scanner := bufio.NewScanner(c.netConn)
+	for scanner.Scan() {
+		msg := parseMessage(strings.TrimRight(scanner.Text(), "\r"))
+		if msg.command == "PING" {
+			_ = c.write("PONG :%s", msg.param(0))
+			continue
+		}
+
+		// Never block the reader; messages are only of interest while someone is waiting for them.
+		select {
+		case c.incoming <- msg:
+		default:
+		}
+	}
+
+	c.mu.Lock()
+	c.err = scanner.Err()
+	if c.err == nil {
+		c.err = errors.New("connection closed by server")
+	}
+	c.mu.Unlock()
+}
+
+// alive reports whether the connection is still open.
+func (c *conn) alive() bool {
+	select {
+	case <-c.done:
+		return false
+	default:
+		return true
+	}
+}
+
+// write sends a single line to the server. It refuses lines containing CR, LF or NUL, which would end the line early
+// and let the rest be read as another command.
+func (c *conn) write(format string, args ...any) error {
+	line := fmt.Sprintf(format, args...)
+	if strings.ContainsAny(line, "\r\n\x00") {
+		return errors.Errorf("line %q contains CR, LF or NUL", line)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	_, err := io.WriteString(c.netConn, line+"\r\n")
+	return err
+}
+
+// waitFor reads incoming messages until match returns true or an error.
+func (c *conn) waitFor(ctx context.Context, match func(message) (bool, error)) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-c.done:
+			c.mu.Lock()
+			defer c.mu.Unlock()
+			return c.err
+		case msg := <-c.incoming:
+			ok, err := match(msg)
+			if err != nil {
+				return err
+			}
+			if ok {
+				return nil
+			}
+		}
+	}
+}
+
+// register registers the connection using the given nickname and optional credentials.
+func (c *conn) register(ctx context.Context, nick, password, saslUser, saslPassword string) error {
+	if saslUser != "" {
+		if err := c.write("CAP REQ :sasl"); err != nil {
+			return err
+		}
+	}
+	if password != "" {
+		if err := c.write("PASS %s", password); err != nil {
+			return err
+		}
+	}
+	if err := c.write("NICK %s", nick); err != nil {
+		return err
+	}
+	if err := c.write("USER %s 0 * :%s", nick, nick); err != nil {
+		return err
+	}
+
+	return c.waitFor(ctx, func(msg message) (bool, error) {
+		switch msg.command {
+		case rplWelcome:
+			return true, nil
+		case errNicknameInUse:
+			nick += "_"
+			return false, c.write("NICK %s", nick)
+		case errPasswdMismatch:
+			return false, notify.ClassifyError(errors.New("server password mismatch"), notify.ErrAuthFailed)
+		case "CAP":
+			if msg.param(1) == "NAK" {
+				return false, errors.New("server does not support SASL")
+			}
+			if msg.param(1) == "ACK" {
+				return false, c.write("AUTHENTICATE PLAIN")
+			}
+		case "AUTHENTICATE":
+			credentials := base64.StdEncoding.EncodeToString([]byte(saslUser + "\x00" + saslUser + "\x00" + saslPassword))
+			return false, c.write("AUTHENTICATE %s", credentials)
+		case rplSASLSuccess:
+			return false, c.write("CAP END")
+		case errSASLFail, errSASLTooLong, errSASLAborted:
+			return false, notify.ClassifyError(errors.New("sasl authentication failed"), notify.ErrAuthFailed)
+		case "ERROR":
+			return false, errors.Errorf("server error: %s", msg.param(0))
+		}
+		return false, nil
+	})
+}
+
+// join joins the given channel and waits until the server sent the channel's name list.
+func (c *conn) join(ctx context.Context, channel string) error {
+	if err := c.write("JOIN %s", channel); err != nil {
+		return err
+	}
+
+	return c.waitFor(ctx, func(msg message) (bool, error) {
+		switch {
+		case msg.command == rplEndOfNames && strings.EqualFold(msg.param(1), channel):
+			return true, nil
+		case len(msg.command) == 3 && msg.command[0] == '4' && strings.EqualFold(msg.param(1), channel):
+			// The channel doesn't exist, is full, invite-only or we're banned.
+			err := errors.Errorf("join %s: %s", channel, msg.param(len(msg.params)-1))
+			return false, notify.ClassifyError(err, notify.ErrInvalidReceiver)
+		}
+		return false, nil
+	})
+}
+
+// close quits and closes the connection.
+func (c *conn) close() error {
+	_ = c.write("QUIT :bye")
+	return c.netConn.Close()
+}
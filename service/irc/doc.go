@@ -0,0 +1,45 @@
+/*
+Package irc provides a service for sending messages to IRC channels and users.
+
+By default, the service connects to the server, registers, joins all configured channels, sends the notification and
+disconnects again for every call to Send. Call KeepConnection(true) to keep the connection open instead, which avoids
+the join/part noise in busy channels.
+
+Usage:
+
+	package main
+
+	import (
+	    "context"
+	    "crypto/tls"
+	    "log"
+
+	    "github.com/nikoksr/notify"
+	    "github.com/nikoksr/notify/service/irc"
+	)
+
+	func main() {
+	    // Create an irc service.
+	    ircService := irc.New("irc.libera.chat:6697", "notify-bot")
+
+	    // Connect using TLS and authenticate the registered nickname using SASL.
+	    ircService.SetTLS(&tls.Config{MinVersion: tls.VersionTLS12})
+	    ircService.AuthenticateSASL("notify-bot", "password")
+
+	    // Add channels and nicknames to send messages to.
+	    ircService.AddReceivers("#my-ops-channel", "jane")
+
+	    // Optionally keep the connection open between sends.
+	    ircService.KeepConnection(true)
+	    defer func() { _ = ircService.Close() }()
+
+	    // Tell our notifier to use the irc service.
+	    notify.UseServices(ircService)
+
+	    // Send a test message.
+	    if err := notify.Send(context.Background(), "Subject/Title", "The actual message"); err != nil {
+	        log.Fatal(err)
+	    }
+	}
+*/
+package irc
@@ -0,0 +1,267 @@
+package irc
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
+)
+
+// maxLineLength is the maximum length of a message text we send in a single PRIVMSG. IRC limits lines to 512 bytes
+// including the command, target and the prefix added by the server, so we stay well below that.
+const maxLineLength = 400
+
+// maxLines is the maximum number of lines of a message. Servers throttle or disconnect clients that send many lines at
+// once, so longer messages are better split into several notifications.
+const maxLines = 10
+
+// Compile-time check to ensure that IRC implements the notify.Limiter interface.
+var _ notify.Limiter = new(IRC)
+
+// IRC struct holds necessary data to send messages to IRC channels and users.
+type IRC struct {
+	serverAddr   string
+	nick         string
+	password     string
+	saslUser     string
+	saslPassword string
+	useTLS       bool
+	tlsConfig    *tls.Config
+	timeout      time.Duration
+	receivers    []string
+
+	persistent bool
+	mu         sync.Mutex
+	conn       *conn
+}
+
+// New returns a new instance of an IRC notification service. The serverAddr is the host:port of the IRC server and
+// nick the nickname used to send messages.
+func New(serverAddr, nick string) *IRC {
+	return &IRC{
+		serverAddr: serverAddr,
+		nick:       nick,
+		timeout:    30 * time.Second,
+		receivers:  []string{},
+	}
+}
+
+// AddReceivers takes channel names (e.g. #ops) or nicknames and adds them to the internal receiver list. The Send
+// method joins all channels and sends a given message to all of those receivers.
+func (i *IRC) AddReceivers(receivers ...string) {
+	i.receivers = append(i.receivers, receivers...)
+}
+
+// SetTLS can be used to connect to the server over tls with an optional TLS config.
+func (i *IRC) SetTLS(tlsConfig *tls.Config) {
+	i.useTLS = true
+	i.tlsConfig = tlsConfig
+}
+
+// UnSetTLS can be used to connect to the server without tls.
+func (i *IRC) UnSetTLS() {
+	i.useTLS = false
+	i.tlsConfig = nil
+}
+
+// SetPassword sets the server password sent during registration.
+func (i *IRC) SetPassword(password string) {
+	i.password = password
+}
+
+// AuthenticateSASL enables SASL PLAIN authentication, which is required by many networks to send messages from
+// registered nicknames.
+func (i *IRC) AuthenticateSASL(username, password string) {
+	i.saslUser = username
+	i.saslPassword = password
+}
+
+// KeepConnection keeps the connection open between calls to Send instead of connecting, joining and disconnecting
+// every time. The connection is re-established automatically if it got lost. Call Close to disconnect.
+func (i *IRC) KeepConnection(enabled bool) {
+	i.persistent = enabled
+}
+
+// Validate checks that the server address contains a port, that the nickname is set and contains no spaces and that at
+// least one receiver is set and none is empty, see notify.Validator.
+func (i *IRC) Validate() error {
+	var problems notify.ConfigErrors
+	if _, _, err := net.SplitHostPort(i.serverAddr); err != nil {
+		problems.Check("server address", err)
+	}
+	problems.Require("nickname", i.nick)
+	if strings.ContainsAny(i.nick, " \r\n") {
+		problems.Addf("nickname %q contains spaces", i.nick)
+	}
+	if len(i.receivers) == 0 {
+		problems.Addf("no receiver set")
+	}
+	for _, receiver := range i.receivers {
+		if receiver == "" {
+			problems.Addf("receiver is empty")
+		}
+	}
+
+	return problems.Err()
+}
+
+// Close closes a connection kept open by KeepConnection. It is a no-op otherwise.
+func (i *IRC) Close() error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if i.conn == nil {
+		return nil
+	}
+	err := i.conn.close()
+	i.conn = nil
+
+	return err
+}
+
+// isChannel reports whether the given receiver is a channel rather than a nickname.
+func isChannel(receiver string) bool {
+	return receiver != "" && strings.ContainsAny(receiver[:1], "#&+!")
+}
+
+// dial establishes a registered connection and joins all channels.
+func (i *IRC) dial(ctx context.Context) (*conn, error) {
+	ctx, cancel := context.WithTimeout(ctx, i.timeout)
+	defer cancel()
+
+	var (
+		netConn net.Conn
+		err     error
+	)
+	if i.useTLS {
+		config := &tls.Config{MinVersion: tls.VersionTLS12}
+		if i.tlsConfig != nil {
+			config = i.tlsConfig.Clone()
+		}
+		if config.ServerName == "" {
+			config.ServerName, _, _ = net.SplitHostPort(i.serverAddr)
+		}
+		dialer := &tls.Dialer{Config: config}
+		netConn, err = dialer.DialContext(ctx, "tcp", i.serverAddr)
+	} else {
+		var dialer net.Dialer
+		netConn, err = dialer.DialContext(ctx, "tcp", i.serverAddr)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "dial server")
+	}
+
+	c := newConn(netConn)
+	if err = c.register(ctx, i.nick, i.password, i.saslUser, i.saslPassword); err != nil {
+		_ = c.close()
+		return nil, errors.Wrap(err, "register")
+	}
+
+	for _, receiver := range i.receivers {
+		if !isChannel(receiver) {
+			continue
+		}
+		if err = c.join(ctx, receiver); err != nil {
+			_ = c.close()
+			return nil, err
+		}
+	}
+
+	return c, nil
+}
+
+// Limits returns the maximum length of IRC messages, which contain subject and body and are sent in lines of at most
+// maxLineLength bytes. The limit is counted in characters, so texts with multibyte characters take more lines.
+func (i *IRC) Limits() notify.Limits {
+	return notify.Limits{Total: maxLines * maxLineLength}
+}
+
+// splitText splits the given text into lines short enough to be sent as single messages. It splits on every CR and LF
+// and drops NUL bytes, since the server would read them as end of the message and run the rest as a command.
+func splitText(text string) []string {
+	var lines []string
+	isLineBreak := func(r rune) bool { return r == '\r' || r == '\n' }
+	for _, line := range strings.FieldsFunc(strings.ReplaceAll(text, "\x00", ""), isLineBreak) {
+		for len(line) > maxLineLength {
+			cut := maxLineLength
+			for cut > 0 && !isRuneStart(line[cut]) {
+				cut--
+			}
+			lines = append(lines, line[:cut])
+			line = line[cut:]
+		}
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	return lines
+}
+
+// isRuneStart reports whether the given byte is the first byte of an UTF-8 encoded rune.
+func isRuneStart(b byte) bool {
+	return b&0xC0 != 0x80
+}
+
+// Send takes a message subject and a message body and sends them to all previously set receivers. Each line is sent as
+// separate message, since IRC does not support multi-line messages.
+func (i *IRC) Send(ctx context.Context, subject, message string) error {
+	if len(i.receivers) == 0 {
+		return nil
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	c := i.conn
+	if c == nil || !c.alive() {
+		var err error
+		if c, err = i.dial(ctx); err != nil {
+			return notify.ClassifyNetworkError(errors.Wrap(err, "failed to connect to irc server"))
+		}
+	}
+
+	if i.persistent {
+		i.conn = c
+	} else {
+		defer func() {
+			for _, receiver := range i.receivers {
+				if isChannel(receiver) {
+					_ = c.write("PART %s", receiver)
+				}
+			}
+			_ = c.close()
+		}()
+	}
+
+	text := message
+	if subject != "" {
+		text = subject + "\n" + message
+	}
+	lines := splitText(text)
+
+	for _, receiver := range i.receivers {
+		for _, line := range lines {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			default:
+				if err := c.write("PRIVMSG %s :%s", receiver, line); err != nil {
+					if i.persistent {
+						_ = c.close()
+						i.conn = nil
+					}
+					return notify.ClassifyNetworkError(errors.Wrapf(err, "failed to send message to %q", receiver))
+				}
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,197 @@
+package irc
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nikoksr/notify"
+)
+
+// fakeServer is a minimal IRC server recording all received PRIVMSG commands.
+type fakeServer struct {
+	listener net.Listener
+
+	mu          sync.Mutex
+	connections int
+	messages    []string
+}
+
+func newFakeServer(t *testing.T) *fakeServer {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = listener.Close() })
+
+	s := &fakeServer{listener: listener}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			s.mu.Lock()
+			s.connections++
+			s.mu.Unlock()
+			go s.handle(conn)
+		}
+	}()
+
+	return s
+}
+
+func (s *fakeServer) handle(conn net.Conn) {
+	defer func() { _ = conn.Close() }()
+
+	scanner := bufio.NewScanner(conn)
+	for scanner.Scan() {
+		msg := parseMessage(strings.TrimRight(scanner.Text(), "\r"))
+		switch msg.command {
+		case "NICK":
+			if msg.param(0) == "taken" {
+				_, _ = fmt.Fprintf(conn, ":server 433 * taken :Nickname is already in use\r\n")
+				continue
+			}
+			_, _ = fmt.Fprintf(conn, "PING :server\r\n:server 001 %s :Welcome\r\n", msg.param(0))
+		case "JOIN":
+			if msg.param(0) == "#secret" {
+				_, _ = fmt.Fprintf(conn, ":server 475 notify #secret :Cannot join channel (+k)\r\n")
+				continue
+			}
+			_, _ = fmt.Fprintf(conn, ":server 366 notify %s :End of /NAMES list.\r\n", msg.param(0))
+		case "PRIVMSG":
+			s.mu.Lock()
+			s.messages = append(s.messages, msg.param(0)+" "+msg.param(1))
+			s.mu.Unlock()
+		case "QUIT":
+			return
+		}
+	}
+}
+
+func TestIRC_ParseMessage(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	msg := parseMessage("@time=now :nick!user@host PRIVMSG #chan :hello world")
+	assert.Equal("nick!user@host", msg.prefix)
+	assert.Equal("PRIVMSG", msg.command)
+	assert.Equal([]string{"#chan", "hello world"}, msg.params)
+
+	msg = parseMessage("PING :server")
+	assert.Equal("PING", msg.command)
+	assert.Equal("server", msg.param(0))
+	assert.Equal("", msg.param(1))
+}
+
+func TestIRC_SplitText(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	assert.Equal([]string{"a", "b"}, splitText("a\r\n\nb"))
+	assert.Equal([]string{"hi", "QUIT :x"}, splitText("hi\rQUIT :x"))
+	assert.Equal([]string{"hiQUIT :x"}, splitText("hi\x00QUIT :x"))
+
+	lines := splitText(strings.Repeat("ä", maxLineLength))
+	assert.Len(lines, 2)
+	assert.Equal(strings.Repeat("ä", maxLineLength), lines[0]+lines[1])
+}
+
+func TestIRC_Validate(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := New("irc.example.com:6697", "notify")
+	service.AddReceivers("#ops")
+	assert.NoError(service.Validate())
+
+	service = New("irc.example.com", "notify bot")
+	err := service.Validate()
+	assert.ErrorIs(err, notify.ErrInvalidConfig)
+	assert.ErrorContains(err, "server address")
+	assert.ErrorContains(err, `nickname "notify bot" contains spaces`)
+	assert.ErrorContains(err, "no receiver set")
+
+	service.AddReceivers("")
+	assert.ErrorContains(service.Validate(), "receiver is empty")
+}
+
+func TestIRC_Send(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	server := newFakeServer(t)
+	ctx := context.Background()
+
+	// No receivers added
+	service := New(server.listener.Addr().String(), "taken")
+	err := service.Send(ctx, "subject", "message")
+	assert.Nil(err)
+
+	service.AddReceivers("#ops", "jane")
+	err = service.Send(ctx, "subject", "message")
+	assert.Nil(err)
+
+	expected := []string{"#ops subject", "#ops message", "jane subject", "jane message"}
+	assert.Eventually(func() bool {
+		server.mu.Lock()
+		defer server.mu.Unlock()
+		return len(server.messages) == len(expected)
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(expected, server.messages)
+
+	// Line breaks can't inject commands, every line is sent as message.
+	err = service.Send(ctx, "", "hi\rQUIT :x")
+	assert.Nil(err)
+	expected = append(expected, "#ops hi", "#ops QUIT :x", "jane hi", "jane QUIT :x")
+	assert.Eventually(func() bool {
+		server.mu.Lock()
+		defer server.mu.Unlock()
+		return len(server.messages) == len(expected)
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(expected, server.messages)
+
+	// Test join error
+	service.AddReceivers("#secret")
+	err = service.Send(ctx, "subject", "message")
+	assert.NotNil(err)
+}
+
+func TestIRC_SendPersistent(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	server := newFakeServer(t)
+	ctx := context.Background()
+
+	service := New(server.listener.Addr().String(), "notify")
+	service.KeepConnection(true)
+	service.AddReceivers("#ops")
+
+	assert.Nil(service.Send(ctx, "", "first"))
+	assert.Nil(service.Send(ctx, "", "second"))
+	assert.Nil(service.Close())
+
+	assert.Eventually(func() bool {
+		server.mu.Lock()
+		defer server.mu.Unlock()
+		return len(server.messages) == 2
+	}, time.Second, 10*time.Millisecond)
+
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	assert.Equal(1, server.connections)
+}
@@ -0,0 +1,38 @@
+/*
+Package jira provides a service for creating issues and adding comments to issues in Jira.
+
+Usage:
+
+	package main
+
+	import (
+	    "context"
+	    "log"
+
+	    "github.com/nikoksr/notify"
+	    "github.com/nikoksr/notify/service/jira"
+	)
+
+	func main() {
+	    // Jira Cloud uses the account's email address and an API token. Use jira.NewWithToken for personal access
+	    // tokens on Jira Data Center and Server.
+	    jiraService := jira.New("https://your-domain.atlassian.net", "you@example.com", "your-api-token")
+
+	    // Create bugs with high priority in these projects.
+	    jiraService.AddReceivers("OPS")
+	    jiraService.SetIssueType("Bug")
+	    jiraService.SetPriority(jira.PriorityHigh)
+
+	    // Comment on these issues.
+	    jiraService.AddCommentReceivers("OPS-123")
+
+	    // Tell our notifier to use the jira service.
+	    notify.UseServices(jiraService)
+
+	    // Send a test message.
+	    if err := notify.Send(context.Background(), "Disk almost full", "Only 2% left on db-1."); err != nil {
+	        log.Fatal(err)
+	    }
+	}
+*/
+package jira
@@ -0,0 +1,270 @@
+package jira
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
+)
+
+// DefaultIssueType is the type of created issues, unless set otherwise.
+const DefaultIssueType = "Task"
+
+// Priority is the priority of created issues, independent of the priority scheme of the Jira instance.
+type Priority int
+
+// Priorities that can be mapped to Jira priorities. PriorityNone leaves the priority up to Jira's default.
+const (
+	PriorityNone Priority = iota
+	PriorityLowest
+	PriorityLow
+	PriorityMedium
+	PriorityHigh
+	PriorityHighest
+)
+
+// priorities maps the priorities of notify.WithPriority to issue priorities.
+var priorities = map[notify.Priority]Priority{
+	notify.PriorityLow:      PriorityLow,
+	notify.PriorityNormal:   PriorityMedium,
+	notify.PriorityHigh:     PriorityHigh,
+	notify.PriorityCritical: PriorityHighest,
+}
+
+// defaultPriorityNames maps priorities to the names used by Jira's default priority scheme.
+var defaultPriorityNames = map[Priority]string{
+	PriorityLowest:  "Lowest",
+	PriorityLow:     "Low",
+	PriorityMedium:  "Medium",
+	PriorityHigh:    "High",
+	PriorityHighest: "Highest",
+}
+
+// Service encapsulates the Jira client.
+type Service struct {
+	client        *http.Client
+	baseURL       string
+	authorization string
+	projects      []string
+	issues        []string
+	issueType     string
+	priority      Priority
+	priorityNames map[Priority]string
+	labels        []string
+}
+
+// New returns a new instance of a Jira Cloud notification service, authenticated with the email address of the
+// account and an API token. The base URL is the URL of the site, e.g. "https://your-domain.atlassian.net".
+// For more information about API tokens:
+//
+//	-> https://support.atlassian.com/atlassian-account/docs/manage-api-tokens-for-your-atlassian-account/
+func New(baseURL, email, apiToken string) *Service {
+	s := newService(baseURL)
+	s.authorization = "Basic " + base64.StdEncoding.EncodeToString([]byte(email+":"+apiToken))
+
+	return s
+}
+
+// NewWithToken returns a new instance of a Jira Data Center or Server notification service, authenticated with a
+// personal access token.
+func NewWithToken(baseURL, token string) *Service {
+	s := newService(baseURL)
+	s.authorization = "Bearer " + token
+
+	return s
+}
+
+func newService(baseURL string) *Service {
+	priorityNames := make(map[Priority]string, len(defaultPriorityNames))
+	for p, name := range defaultPriorityNames {
+		priorityNames[p] = name
+	}
+
+	return &Service{
+		client: &http.Client{
+			Transport: notify.NewRateLimitTransport(nil),
+			Timeout:   10 * time.Second,
+		},
+		baseURL:       strings.TrimSuffix(baseURL, "/"),
+		projects:      []string{},
+		issues:        []string{},
+		issueType:     DefaultIssueType,
+		priorityNames: priorityNames,
+	}
+}
+
+// WithClient sets the http client to be used for sending requests. Calling this method is optional, the default client
+// will be used if this method is not called.
+func (s *Service) WithClient(client *http.Client) {
+	if client != nil {
+		s.client = client
+	}
+}
+
+// AddReceivers takes project keys, e.g. "OPS", and adds them to the internal receiver list. The Send method creates a
+// new issue in each of them.
+func (s *Service) AddReceivers(projectKeys ...string) {
+	s.projects = append(s.projects, projectKeys...)
+}
+
+// AddCommentReceivers takes keys of existing issues, e.g. "OPS-123", and adds them to the internal receiver list. The
+// Send method adds a comment to each of them.
+func (s *Service) AddCommentReceivers(issueKeys ...string) {
+	s.issues = append(s.issues, issueKeys...)
+}
+
+// SetIssueType sets the name of the type of created issues. Defaults to DefaultIssueType.
+func (s *Service) SetIssueType(issueType string) {
+	s.issueType = issueType
+}
+
+// SetPriority sets the priority of created issues. The priority of a notification, see notify.WithPriority, overrides
+// it: low is low, normal medium, high high and critical highest.
+func (s *Service) SetPriority(priority Priority) {
+	s.priority = priority
+}
+
+// SetPriorityName overrides the name of the Jira priority the given priority maps to, for instances with a custom
+// priority scheme, e.g. SetPriorityName(jira.PriorityHighest, "Blocker").
+func (s *Service) SetPriorityName(priority Priority, name string) {
+	s.priorityNames[priority] = name
+}
+
+// SetLabels sets the labels applied to created issues. Labels must not contain spaces.
+func (s *Service) SetLabels(labels ...string) {
+	s.labels = labels
+}
+
+type (
+	named struct {
+		Name string `json:"name"`
+	}
+
+	keyed struct {
+		Key string `json:"key"`
+	}
+
+	issueFields struct {
+		Project     keyed    `json:"project"`
+		Summary     string   `json:"summary"`
+		Description string   `json:"description"`
+		IssueType   named    `json:"issuetype"`
+		Priority    *named   `json:"priority,omitempty"`
+		Labels      []string `json:"labels,omitempty"`
+	}
+)
+
+func (s *Service) post(ctx context.Context, path string, payload any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return errors.Wrap(err, "marshal request")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.baseURL+"/rest/api/2"+path, bytes.NewReader(data))
+	if err != nil {
+		return errors.Wrap(err, "create request")
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", s.authorization)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "send request")
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(resp.Body)
+		return notify.ClassifyResponse(resp, fmt.Errorf("jira returned status code %d: %s", resp.StatusCode, string(body)))
+	}
+
+	return nil
+}
+
+// Validate checks that the base URL is well-formed, that the credentials are set and that no project or issue key is
+// empty, see notify.Validator.
+func (s *Service) Validate() error {
+	var problems notify.ConfigErrors
+	problems.Check("base URL", notify.ValidateURL(s.baseURL))
+	if strings.HasPrefix(s.authorization, "Bearer ") {
+		problems.Require("token", strings.TrimPrefix(s.authorization, "Bearer "))
+	} else {
+		credentials, _ := base64.StdEncoding.DecodeString(strings.TrimPrefix(s.authorization, "Basic "))
+		email, apiToken, _ := strings.Cut(string(credentials), ":")
+		problems.Require("email", email)
+		problems.Require("API token", apiToken)
+	}
+	problems.Require("issue type", s.issueType)
+	for _, project := range s.projects {
+		if project == "" {
+			problems.Addf("project key is empty")
+		}
+	}
+	for _, issue := range s.issues {
+		if issue == "" {
+			problems.Addf("issue key is empty")
+		}
+	}
+	for _, label := range s.labels {
+		if strings.ContainsAny(label, " \t\n") {
+			problems.Addf("label %q contains spaces", label)
+		}
+	}
+
+	return problems.Err()
+}
+
+// Send takes a message subject and a message body and creates an issue in all previously set projects and comments
+// on all previously set issues.
+func (s *Service) Send(ctx context.Context, subject, message string) error {
+	fields := issueFields{
+		Summary:     subject,
+		Description: message,
+		IssueType:   named{Name: s.issueType},
+		Labels:      s.labels,
+	}
+	priority := s.priority
+	if mapped, ok := priorities[notify.SendOptionsFromContext(ctx).Priority]; ok {
+		priority = mapped
+	}
+	if name, ok := s.priorityNames[priority]; ok && priority != PriorityNone {
+		fields.Priority = &named{Name: name}
+	}
+
+	for _, project := range s.projects {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			fields.Project = keyed{Key: project}
+			if err := s.post(ctx, "/issue", map[string]any{"fields": fields}); err != nil {
+				return errors.Wrapf(err, "failed to create issue in project %q", project)
+			}
+		}
+	}
+
+	comment := map[string]string{"body": "*" + subject + "*\n\n" + message}
+	for _, issue := range s.issues {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			if err := s.post(ctx, "/issue/"+url.PathEscape(issue)+"/comment", comment); err != nil {
+				return errors.Wrapf(err, "failed to comment on issue %q", issue)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,99 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nikoksr/notify"
+)
+
+func TestJira_Validate(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := New("https://acme.atlassian.net", "jane@example.com", "token")
+	service.AddReceivers("OPS")
+	service.AddCommentReceivers("OPS-123")
+	service.SetLabels("outage")
+	assert.NoError(service.Validate())
+	assert.NoError(NewWithToken("https://jira.example.com", "token").Validate())
+
+	service = New("acme.atlassian.net", "", "")
+	service.AddReceivers("")
+	service.SetLabels("billing outage")
+	err := service.Validate()
+	assert.ErrorIs(err, notify.ErrInvalidConfig)
+	assert.ErrorContains(err, "base URL")
+	assert.ErrorContains(err, "email is missing")
+	assert.ErrorContains(err, "API token is missing")
+	assert.ErrorContains(err, "project key is empty")
+	assert.ErrorContains(err, `label "billing outage" contains spaces`)
+
+	assert.ErrorContains(NewWithToken("https://jira.example.com", "").Validate(), "token is missing")
+}
+
+func TestJira_Send(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	got := map[string]map[string]any{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		username, password, ok := r.BasicAuth()
+		if !ok || username != "you@example.com" || password != "token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		got[r.URL.Path] = body
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	service := New(server.URL+"/", "you@example.com", "token")
+	service.AddReceivers("OPS")
+	service.AddCommentReceivers("OPS-1")
+	service.SetIssueType("Bug")
+	service.SetPriority(PriorityHighest)
+	service.SetPriorityName(PriorityHighest, "Blocker")
+	service.SetLabels("alert")
+
+	err := service.Send(context.Background(), "subject", "message")
+	assert.Nil(err)
+	assert.Equal(map[string]map[string]any{
+		"/rest/api/2/issue": {"fields": map[string]any{
+			"project":     map[string]any{"key": "OPS"},
+			"summary":     "subject",
+			"description": "message",
+			"issuetype":   map[string]any{"name": "Bug"},
+			"priority":    map[string]any{"name": "Blocker"},
+			"labels":      []any{"alert"},
+		}},
+		"/rest/api/2/issue/OPS-1/comment": {"body": "*subject*\n\nmessage"},
+	}, got)
+
+	// No priority set
+	service.SetPriority(PriorityNone)
+	err = service.Send(context.Background(), "subject", "message")
+	assert.Nil(err)
+	assert.NotContains(got["/rest/api/2/issue"]["fields"], "priority")
+
+	// The priority of the notification overrides the priority of the service.
+	ctx := notify.ContextWithSendOptions(context.Background(), notify.WithPriority(notify.PriorityCritical))
+	err = service.Send(ctx, "subject", "message")
+	assert.Nil(err)
+	assert.Equal(map[string]any{"name": "Blocker"}, got["/rest/api/2/issue"]["fields"].(map[string]any)["priority"])
+
+	// Test error response
+	service = NewWithToken(server.URL, "token")
+	service.AddReceivers("OPS")
+	err = service.Send(context.Background(), "subject", "message")
+	assert.NotNil(err)
+}
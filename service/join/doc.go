@@ -0,0 +1,32 @@
+/*
+Package join provides a service for pushing notifications to devices via Join by joaoapps.
+
+Usage:
+
+	package main
+
+	import (
+	    "context"
+	    "log"
+
+	    "github.com/nikoksr/notify"
+	    "github.com/nikoksr/notify/service/join"
+	)
+
+	func main() {
+	    joinService := join.New("your-api-key")
+
+	    // Push to these devices and device groups.
+	    joinService.AddReceivers("your-device-id")
+	    joinService.AddGroups(join.GroupPhone)
+
+	    // Tell our notifier to use the join service.
+	    notify.UseServices(joinService)
+
+	    // Send a test message.
+	    if err := notify.Send(context.Background(), "Subject/Title", "The actual message"); err != nil {
+	        log.Fatal(err)
+	    }
+	}
+*/
+package join
@@ -0,0 +1,166 @@
+package join
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
+)
+
+const defaultAPIURL = "https://joinjoaomgcd.appspot.com/_ah/api/messaging/v1/sendPush"
+
+// Group is a group of devices that can be pushed to at once.
+type Group string
+
+// Device groups supported by Join.
+const (
+	GroupAll     Group = "group.all"
+	GroupAndroid Group = "group.android"
+	GroupChrome  Group = "group.chrome"
+	GroupWindows Group = "group.windows10"
+	GroupPhone   Group = "group.phone"
+	GroupTablet  Group = "group.tablet"
+	GroupPC      Group = "group.pc"
+)
+
+// Service encapsulates the Join client.
+type Service struct {
+	client    *http.Client
+	apiURL    string
+	apiKey    string
+	url       string
+	icon      string
+	deviceIDs []string
+	groups    []Group
+}
+
+// New returns a new instance of a Join notification service. The API key can be found on the Join website under
+// "Join API" after selecting a device.
+// For more information about the Join API:
+//
+//	-> https://joaoapps.com/join/api/
+func New(apiKey string) *Service {
+	return &Service{
+		client: &http.Client{
+			Transport: notify.NewRateLimitTransport(nil),
+			Timeout:   10 * time.Second,
+		},
+		apiURL:    defaultAPIURL,
+		apiKey:    apiKey,
+		deviceIDs: []string{},
+		groups:    []Group{},
+	}
+}
+
+// WithClient sets the http client to be used for sending requests. Calling this method is optional, the default client
+// will be used if this method is not called.
+func (s *Service) WithClient(client *http.Client) {
+	if client != nil {
+		s.client = client
+	}
+}
+
+// AddReceivers takes device IDs and adds them to the internal receiver list. All devices receive the message with a
+// single request.
+func (s *Service) AddReceivers(deviceIDs ...string) {
+	s.deviceIDs = append(s.deviceIDs, deviceIDs...)
+}
+
+// AddGroups takes device groups and adds them to the internal receiver list.
+func (s *Service) AddGroups(groups ...Group) {
+	s.groups = append(s.groups, groups...)
+}
+
+// SetURL sets a URL opened when the notification is tapped.
+func (s *Service) SetURL(url string) {
+	s.url = url
+}
+
+// SetIcon sets the URL of an icon shown with the notifications.
+func (s *Service) SetIcon(icon string) {
+	s.icon = icon
+}
+
+type response struct {
+	Success      bool   `json:"success"`
+	ErrorMessage string `json:"errorMessage"`
+}
+
+// Send takes a message subject and a message body and sends them to all previously set devices and groups.
+func (s *Service) Send(ctx context.Context, subject, message string) error {
+	query := url.Values{
+		"apikey": {s.apiKey},
+		"title":  {subject},
+		"text":   {message},
+	}
+	if s.url != "" {
+		query.Set("url", s.url)
+	}
+	if s.icon != "" {
+		query.Set("icon", s.icon)
+	}
+
+	var targets []url.Values
+	if len(s.deviceIDs) > 0 {
+		targets = append(targets, url.Values{"deviceIds": {strings.Join(s.deviceIDs, ",")}})
+	}
+	for _, group := range s.groups {
+		targets = append(targets, url.Values{"deviceId": {string(group)}})
+	}
+
+	for _, target := range targets {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			q := url.Values{}
+			for key, values := range query {
+				q[key] = values
+			}
+			for key, values := range target {
+				q[key] = values
+			}
+			if err := s.push(ctx, q); err != nil {
+				return errors.Wrap(err, "failed to send push")
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) push(ctx context.Context, query url.Values) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.apiURL+"?"+query.Encode(), http.NoBody)
+	if err != nil {
+		return errors.Wrap(err, "create request")
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "send request")
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	b, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return notify.ClassifyResponse(resp, fmt.Errorf("join returned status code %d: %s", resp.StatusCode, string(b)))
+	}
+
+	var result response
+	if err = json.Unmarshal(b, &result); err != nil {
+		return errors.Wrap(err, "decode response")
+	}
+	if !result.Success {
+		return fmt.Errorf("join returned an error: %s", result.ErrorMessage)
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package join
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestJoin_Send(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	var got []url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("apikey") != "key" {
+			_, _ = w.Write([]byte(`{"success":false,"errorMessage":"User Not Authenticated"}`))
+			return
+		}
+		got = append(got, r.URL.Query())
+		_, _ = w.Write([]byte(`{"success":true}`))
+	}))
+	defer server.Close()
+
+	service := New("key")
+	service.apiURL = server.URL
+
+	// No receivers added
+	assert.NoError(service.Send(context.Background(), "subject", "message"))
+	assert.Empty(got)
+
+	service.AddReceivers("a", "b")
+	service.AddGroups(GroupAndroid)
+	service.SetURL("https://example.com")
+	service.SetIcon("https://example.com/icon.png")
+
+	assert.NoError(service.Send(context.Background(), "subject", "message"))
+	assert.Equal([]url.Values{
+		{
+			"apikey":    {"key"},
+			"deviceIds": {"a,b"},
+			"title":     {"subject"},
+			"text":      {"message"},
+			"url":       {"https://example.com"},
+			"icon":      {"https://example.com/icon.png"},
+		},
+		{
+			"apikey":   {"key"},
+			"deviceId": {"group.android"},
+			"title":    {"subject"},
+			"text":     {"message"},
+			"url":      {"https://example.com"},
+			"icon":     {"https://example.com/icon.png"},
+		},
+	}, got)
+
+	// Test error response
+	service.apiKey = "invalid"
+	assert.ErrorContains(service.Send(context.Background(), "subject", "message"), "User Not Authenticated")
+}
@@ -0,0 +1,40 @@
+/*
+Package kafka provides a service for producing notifications to Kafka topics.
+
+Every notification is produced as JSON document with the fields "subject", "message" and "timestamp".
+
+Usage:
+
+	package main
+
+	import (
+	    "context"
+	    "crypto/tls"
+	    "log"
+
+	    "github.com/nikoksr/notify"
+	    "github.com/nikoksr/notify/service/kafka"
+	)
+
+	func main() {
+	    kafkaService := kafka.New("kafka-1.example.com:9093", "kafka-2.example.com:9093")
+	    kafkaService.SetTLS(&tls.Config{MinVersion: tls.VersionTLS12})
+	    if err := kafkaService.SetSASLSCRAM(kafka.SHA512, "username", "password"); err != nil {
+	        log.Fatal(err)
+	    }
+	    defer func() { _ = kafkaService.Close() }()
+
+	    // Produce to these topics, keyed by a deduplication key.
+	    kafkaService.AddReceivers("notifications")
+	    kafkaService.SetKey("disk-full-db-1")
+
+	    // Tell our notifier to use the kafka service.
+	    notify.UseServices(kafkaService)
+
+	    // Send a test message.
+	    if err := notify.Send(context.Background(), "Subject/Title", "The actual message"); err != nil {
+	        log.Fatal(err)
+	    }
+	}
+*/
+package kafka
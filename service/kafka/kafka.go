@@ -0,0 +1,236 @@
+package kafka
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/sasl"
+	"github.com/segmentio/kafka-go/sasl/plain"
+	"github.com/segmentio/kafka-go/sasl/scram"
+
+	"github.com/nikoksr/notify"
+)
+
+// messageWriter abstracts kafka.Writer for writing unit tests.
+//
+//go:generate mockery --name=messageWriter --output=. --case=underscore --inpackage
+type messageWriter interface {
+	WriteMessages(ctx context.Context, msgs ...kafka.Message) error
+	Close() error
+}
+
+// Compile-time check to ensure that kafka.Writer implements the messageWriter interface.
+var _ messageWriter = new(kafka.Writer)
+
+// Compile-time check to ensure that Service implements the notify.JSONSender interface.
+var _ notify.JSONSender = new(Service)
+
+// SCRAM algorithms supported for SASL/SCRAM authentication.
+var (
+	SHA256 = scram.SHA256
+	SHA512 = scram.SHA512
+)
+
+// Service encapsulates the Kafka producer.
+type Service struct {
+	brokers   []string
+	tlsConfig *tls.Config
+	mechanism sasl.Mechanism
+	topics    []string
+	key       string
+	headers   []kafka.Header
+
+	mu     sync.Mutex
+	writer messageWriter
+}
+
+// payload is the JSON document produced for every notification.
+type payload struct {
+	Subject   string    `json:"subject"`
+	Message   string    `json:"message"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// New returns a new instance of a Kafka notification service that produces to the given brokers, e.g.
+// "kafka-1.example.com:9092". The producer is created on the first Send and waits for all in-sync replicas to
+// acknowledge each message.
+func New(brokers ...string) *Service {
+	return &Service{
+		brokers: brokers,
+		topics:  []string{},
+	}
+}
+
+// SetTLS enables TLS with the given configuration.
+func (s *Service) SetTLS(cfg *tls.Config) {
+	s.tlsConfig = cfg
+}
+
+// SetSASLPlain makes the producer authenticate using SASL/PLAIN. Should be combined with TLS.
+func (s *Service) SetSASLPlain(username, password string) {
+	s.mechanism = plain.Mechanism{Username: username, Password: password}
+}
+
+// SetSASLSCRAM makes the producer authenticate using SASL/SCRAM with the given algorithm, SHA256 or SHA512.
+func (s *Service) SetSASLSCRAM(algorithm scram.Algorithm, username, password string) error {
+	mechanism, err := scram.Mechanism(algorithm, username, password)
+	if err != nil {
+		return errors.Wrap(err, "failed to create scram mechanism")
+	}
+	s.mechanism = mechanism
+
+	return nil
+}
+
+// AddReceivers takes topics and adds them to the internal receiver list. The Send method produces to all of them.
+func (s *Service) AddReceivers(topics ...string) {
+	s.topics = append(s.topics, topics...)
+}
+
+// SetKey sets the key of produced messages, e.g. a deduplication key. Messages with the same key end up in the same
+// partition, so consumers see them in order, and compacted topics keep only the latest of them.
+func (s *Service) SetKey(key string) {
+	s.key = key
+}
+
+// AddHeader adds a header that is sent with every message.
+func (s *Service) AddHeader(key, value string) {
+	s.headers = append(s.headers, kafka.Header{Key: key, Value: []byte(value)})
+}
+
+// getWriter returns the writer, creating it first if necessary.
+func (s *Service) getWriter() messageWriter {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.writer == nil {
+		s.writer = &kafka.Writer{
+			Addr:         kafka.TCP(s.brokers...),
+			Balancer:     &kafka.Hash{},
+			RequiredAcks: kafka.RequireAll,
+			Transport: &kafka.Transport{
+				TLS:  s.tlsConfig,
+				SASL: s.mechanism,
+			},
+		}
+	}
+
+	return s.writer
+}
+
+// Validate checks that at least one broker is set and all of them are host:port addresses and that at least one topic
+// is set and none is empty, see notify.Validator.
+func (s *Service) Validate() error {
+	var problems notify.ConfigErrors
+	if len(s.brokers) == 0 {
+		problems.Addf("no broker set")
+	}
+	for _, broker := range s.brokers {
+		if _, _, err := net.SplitHostPort(broker); err != nil {
+			problems.Check("broker", err)
+		}
+	}
+	if len(s.topics) == 0 {
+		problems.Addf("no topic set")
+	}
+	for _, topic := range s.topics {
+		if topic == "" {
+			problems.Addf("topic is empty")
+		}
+	}
+
+	return problems.Err()
+}
+
+// Close flushes pending messages and closes the producer. The next Send creates a new one.
+func (s *Service) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.writer == nil {
+		return nil
+	}
+	err := s.writer.Close()
+	s.writer = nil
+
+	return err
+}
+
+// Send takes a message subject and a message body and produces them as JSON document to all previously set topics.
+func (s *Service) Send(ctx context.Context, subject, message string) error {
+	return s.SendJSON(ctx, &payload{Subject: subject, Message: message, Timestamp: time.Now().UTC()})
+}
+
+// SendJSON produces the JSON encoding of v to all previously set topics.
+func (s *Service) SendJSON(ctx context.Context, v any) error {
+	if len(s.topics) == 0 {
+		return nil
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal payload")
+	}
+
+	msgs := make([]kafka.Message, 0, len(s.topics))
+	for _, topic := range s.topics {
+		msg := kafka.Message{Topic: topic, Value: data, Headers: s.headers}
+		if s.key != "" {
+			msg.Key = []byte(s.key)
+		}
+		msgs = append(msgs, msg)
+	}
+
+	if err = s.getWriter().WriteMessages(ctx, msgs...); err != nil {
+		return notify.ClassifyError(errors.Wrap(err, "failed to produce messages"), errorClass(err))
+	}
+
+	return nil
+}
+
+// errorClass returns the class of an error of the producer, see notify.ClassifyError, or nil if it is unknown. Errors
+// of single messages are classified by the first one.
+func errorClass(err error) error {
+	var writeErrs kafka.WriteErrors
+	if errors.As(err, &writeErrs) {
+		for _, writeErr := range writeErrs {
+			if writeErr != nil {
+				return errorClass(writeErr)
+			}
+		}
+	}
+
+	var tooLarge kafka.MessageTooLargeError
+	if errors.As(err, &tooLarge) {
+		return notify.ErrPermanent
+	}
+
+	var kafkaErr kafka.Error
+	if errors.As(err, &kafkaErr) {
+		switch {
+		case kafkaErr == kafka.SASLAuthenticationFailed, kafkaErr == kafka.TopicAuthorizationFailed,
+			kafkaErr == kafka.ClusterAuthorizationFailed:
+			return notify.ErrAuthFailed
+		case kafkaErr == kafka.UnknownTopicOrPartition, kafkaErr == kafka.InvalidTopic:
+			return notify.ErrInvalidReceiver
+		case kafkaErr == kafka.ThrottlingQuotaExceeded:
+			return &notify.ErrRateLimited{}
+		case kafkaErr.Temporary():
+			return notify.ErrTransient
+		default:
+			return notify.ErrPermanent
+		}
+	}
+
+	if notify.ClassifyNetworkError(err) != err {
+		return notify.ErrTransient
+	}
+
+	return nil
+}
@@ -0,0 +1,130 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nikoksr/notify"
+)
+
+func TestKafka_SetSASL(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := New("localhost:9092")
+	service.SetSASLPlain("user", "password")
+	assert.Equal("PLAIN", service.mechanism.Name())
+
+	assert.NoError(service.SetSASLSCRAM(SHA512, "user", "password"))
+	assert.Equal("SCRAM-SHA-512", service.mechanism.Name())
+}
+
+func TestKafka_Validate(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := New("kafka-1.example.com:9092")
+	service.AddReceivers("alerts")
+	assert.NoError(service.Validate())
+
+	err := New().Validate()
+	assert.ErrorIs(err, notify.ErrInvalidConfig)
+	assert.ErrorContains(err, "no broker set")
+	assert.ErrorContains(err, "no topic set")
+
+	service = New("kafka-1.example.com")
+	service.AddReceivers("")
+	err = service.Validate()
+	assert.ErrorContains(err, "broker")
+	assert.ErrorContains(err, "topic is empty")
+}
+
+func TestKafka_Send(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+	ctx := context.Background()
+
+	service := New("localhost:9092")
+
+	// No receivers added
+	err := service.Send(ctx, "subject", "message")
+	assert.NoError(err)
+
+	// Test producing successfully
+	var produced []kafka.Message
+	mockWriter := newMockMessageWriter(t)
+	mockWriter.
+		On("WriteMessages", ctx, mock.AnythingOfType("kafka.Message"), mock.AnythingOfType("kafka.Message")).
+		Run(func(args mock.Arguments) {
+			for _, arg := range args[1:] {
+				produced = append(produced, arg.(kafka.Message))
+			}
+		}).
+		Return(nil)
+
+	service.writer = mockWriter
+	service.AddReceivers("alerts", "audit")
+	service.SetKey("disk-full-db-1")
+	service.AddHeader("source", "notify")
+	err = service.Send(ctx, "subject", "message")
+	assert.NoError(err)
+	assert.Len(produced, 2)
+	assert.Equal("alerts", produced[0].Topic)
+	assert.Equal("audit", produced[1].Topic)
+	assert.Equal([]byte("disk-full-db-1"), produced[0].Key)
+	assert.Equal([]kafka.Header{{Key: "source", Value: []byte("notify")}}, produced[0].Headers)
+
+	var p payload
+	assert.NoError(json.Unmarshal(produced[0].Value, &p))
+	assert.Equal("subject", p.Subject)
+	assert.Equal("message", p.Message)
+
+	// Test producing with error
+	mockWriter = newMockMessageWriter(t)
+	mockWriter.
+		On("WriteMessages", ctx, mock.Anything, mock.Anything).
+		Return(kafka.WriteErrors{nil, kafka.TopicAuthorizationFailed})
+	mockWriter.On("Close").Return(nil)
+
+	service.writer = mockWriter
+	err = service.Send(ctx, "subject", "message")
+	assert.ErrorIs(err, notify.ErrAuthFailed)
+
+	assert.NoError(service.Close())
+	assert.Nil(service.writer)
+}
+
+func TestKafka_SendJSON(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+	ctx := context.Background()
+
+	var produced []kafka.Message
+	mockWriter := newMockMessageWriter(t)
+	mockWriter.
+		On("WriteMessages", ctx, mock.AnythingOfType("kafka.Message")).
+		Run(func(args mock.Arguments) { produced = append(produced, args.Get(1).(kafka.Message)) }).
+		Return(nil)
+
+	service := New("localhost:9092")
+	service.writer = mockWriter
+	service.AddReceivers("deployments")
+
+	err := service.SendJSON(ctx, map[string]any{"service": "api", "version": "1.2.3"})
+	assert.NoError(err)
+	assert.Len(produced, 1)
+	assert.JSONEq(`{"service":"api","version":"1.2.3"}`, string(produced[0].Value))
+
+	// Test unserializable payload
+	err = service.SendJSON(ctx, make(chan int))
+	assert.Error(err)
+}
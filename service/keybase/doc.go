@@ -0,0 +1,35 @@
+/*
+Package keybase provides a service for sending chat messages via the local Keybase client.
+
+Usage:
+
+	package main
+
+	import (
+	    "context"
+	    "log"
+
+	    "github.com/nikoksr/notify"
+	    "github.com/nikoksr/notify/service/keybase"
+	)
+
+	func main() {
+	    keybaseService := keybase.New()
+
+	    // Use a separate client instance logged in as bot account.
+	    keybaseService.SetHomeDir("/var/lib/keybase-bot")
+
+	    // Send to these users and team channels.
+	    keybaseService.AddReceivers("alice")
+	    keybaseService.AddTeamChannel("acme.security", "alerts")
+
+	    // Tell our notifier to use the keybase service.
+	    notify.UseServices(keybaseService)
+
+	    // Send a test message.
+	    if err := notify.Send(context.Background(), "Subject/Title", "The actual message"); err != nil {
+	        log.Fatal(err)
+	    }
+	}
+*/
+package keybase
@@ -0,0 +1,177 @@
+package keybase
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
+)
+
+// channel identifies a Keybase conversation.
+type channel struct {
+	Name        string `json:"name"`
+	MembersType string `json:"members_type,omitempty"`
+	TopicName   string `json:"topic_name,omitempty"`
+}
+
+// Service encapsulates the Keybase chat client.
+type Service struct {
+	binary   string
+	homeDir  string
+	channels []channel
+
+	// run executes the chat API with the given input and returns its output.
+	run func(ctx context.Context, input []byte) ([]byte, error)
+}
+
+// New returns a new instance of a Keybase notification service. Messages are sent through the chat API of the local
+// keybase client, which must be installed and logged in, ideally as a dedicated bot account. Use SetBinary and
+// SetHomeDir to run a separate client instance for the bot, e.g. one started with "keybase --home /bot oneshot".
+// For more information about the chat API:
+//
+//	-> https://keybase.io/docs/chat
+func New() *Service {
+	s := &Service{
+		binary:   "keybase",
+		channels: []channel{},
+	}
+	s.run = s.exec
+
+	return s
+}
+
+// SetBinary sets the path of the keybase binary. Defaults to "keybase", looked up in PATH.
+func (s *Service) SetBinary(path string) {
+	s.binary = path
+}
+
+// SetHomeDir sets the home directory of the keybase client instance to use.
+func (s *Service) SetHomeDir(dir string) {
+	s.homeDir = dir
+}
+
+// AddReceivers takes Keybase usernames and adds them to the internal receiver list. Every one of them receives the
+// messages in a private conversation.
+func (s *Service) AddReceivers(usernames ...string) {
+	for _, username := range usernames {
+		s.channels = append(s.channels, channel{Name: username})
+	}
+}
+
+// AddTeamChannel adds a channel of a team, e.g. "general" of "acme.security", to the internal receiver list.
+func (s *Service) AddTeamChannel(team, topic string) {
+	s.channels = append(s.channels, channel{Name: team, MembersType: "team", TopicName: topic})
+}
+
+func (s *Service) exec(ctx context.Context, input []byte) ([]byte, error) {
+	var args []string
+	if s.homeDir != "" {
+		args = append(args, "--home", s.homeDir)
+	}
+	args = append(args, "chat", "api")
+
+	cmd := exec.CommandContext(ctx, s.binary, args...)
+	cmd.Stdin = bytes.NewReader(input)
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, errors.Wrapf(err, "keybase chat api: %s", stderr.String())
+	}
+
+	return output, nil
+}
+
+type sendRequest struct {
+	Method string     `json:"method"`
+	Params sendParams `json:"params"`
+}
+
+type sendParams struct {
+	Options sendOptions `json:"options"`
+}
+
+type sendOptions struct {
+	Channel channel     `json:"channel"`
+	Message messageBody `json:"message"`
+}
+
+type messageBody struct {
+	Body string `json:"body"`
+}
+
+type apiResponse struct {
+	Error *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// Send takes a message subject and a message body and sends them to all previously set users and team channels. The
+// subject is formatted bold.
+func (s *Service) Send(ctx context.Context, subject, message string) error {
+	body := message
+	if subject != "" {
+		body = "*" + subject + "*\n" + message
+	}
+
+	for _, ch := range s.channels {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			if err := s.send(ctx, ch, body); err != nil {
+				return errors.Wrapf(err, "failed to send message to %q", ch.Name)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) send(ctx context.Context, ch channel, body string) error {
+	input, err := json.Marshal(sendRequest{
+		Method: "send",
+		Params: sendParams{Options: sendOptions{Channel: ch, Message: messageBody{Body: body}}},
+	})
+	if err != nil {
+		return errors.Wrap(err, "marshal request")
+	}
+
+	output, err := s.run(ctx, input)
+	if err != nil {
+		return err
+	}
+
+	var resp apiResponse
+	if err = json.Unmarshal(output, &resp); err != nil {
+		return errors.Wrap(err, "decode response")
+	}
+	if resp.Error != nil {
+		return classifyError(fmt.Errorf("%s (code %d)", resp.Error.Message, resp.Error.Code))
+	}
+
+	return nil
+}
+
+// classifyError classifies an error reported by the Keybase chat API, see notify.ClassifyError. The API has no stable
+// error codes, so the message is matched.
+func classifyError(err error) error {
+	msg := strings.ToLower(err.Error())
+	switch {
+	case strings.Contains(msg, "logged in"), strings.Contains(msg, "login required"):
+		return notify.ClassifyError(err, notify.ErrAuthFailed)
+	case strings.Contains(msg, "not found"), strings.Contains(msg, "not a member"), strings.Contains(msg, "resolve"):
+		return notify.ClassifyError(err, notify.ErrInvalidReceiver)
+	default:
+		return notify.ClassifyError(err, notify.ErrPermanent)
+	}
+}
@@ -0,0 +1,58 @@
+package keybase
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeybase_Send(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	var got []sendOptions
+	service := New()
+	service.run = func(_ context.Context, input []byte) ([]byte, error) {
+		var req sendRequest
+		_ = json.Unmarshal(input, &req)
+		if req.Method != "send" {
+			return []byte(`{"error":{"code":0,"message":"unknown method"}}`), nil
+		}
+		if req.Params.Options.Channel.Name == "unknown" {
+			return []byte(`{"error":{"code":2,"message":"user not found"}}`), nil
+		}
+		got = append(got, req.Params.Options)
+		return []byte(`{"result":{"message":"message sent","id":1}}`), nil
+	}
+
+	service.AddReceivers("alice")
+	service.AddTeamChannel("acme.security", "alerts")
+
+	assert.NoError(service.Send(context.Background(), "subject", "message"))
+	assert.Equal([]sendOptions{
+		{Channel: channel{Name: "alice"}, Message: messageBody{Body: "*subject*\nmessage"}},
+		{
+			Channel: channel{Name: "acme.security", MembersType: "team", TopicName: "alerts"},
+			Message: messageBody{Body: "*subject*\nmessage"},
+		},
+	}, got)
+
+	// Test error response
+	service.AddReceivers("unknown")
+	assert.ErrorContains(service.Send(context.Background(), "subject", "message"), "user not found")
+}
+
+func TestKeybase_exec(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := New()
+	service.SetBinary("/nonexistent/keybase")
+	service.SetHomeDir("/tmp/bot")
+	service.AddReceivers("alice")
+	assert.Error(service.Send(context.Background(), "subject", "message"))
+}
@@ -1,5 +1,13 @@
 package lark
 
+import (
+	"fmt"
+
+	"github.com/go-lark/lark"
+
+	"github.com/nikoksr/notify"
+)
+
 // sender is an interface for sending a message to an already defined receiver.
 //
 //go:generate mockery --name=sender --output=. --case=underscore --inpackage
@@ -60,3 +68,56 @@ const (
 	email   receiverIDType = "email"
 	chatID  receiverIDType = "chat_id"
 )
+
+// link is a hyperlink appended to rich-text messages.
+type link struct {
+	text string
+	href string
+}
+
+// richText holds the optional elements that get rendered into post messages
+// in addition to the subject and the message body.
+type richText struct {
+	links    []link
+	mentions []string
+}
+
+// render builds a post (rich-text) message. The subject becomes the title,
+// the message body is rendered as text, followed by links and mentions.
+func (r *richText) render(subject, message string) *lark.PostContent {
+	builder := lark.NewPostBuilder().
+		Title(subject).
+		TextTag(message, 1, false)
+	for _, l := range r.links {
+		builder.TextTag(" ", 1, false).LinkTag(l.text, l.href)
+	}
+	for _, userID := range r.mentions {
+		builder.AtTag("", userID)
+	}
+	return builder.Render()
+}
+
+// sanitize removes control characters from the message. Lark displays the texts of rich-text messages literally, as
+// they are sent without un_escape, so no markup needs to be escaped.
+func sanitize(message notify.Message) notify.Message {
+	return notify.SanitizeMessage(message, notify.StripControlChars)
+}
+
+// codeError returns the error for a request that Lark answered with the given
+// error code, classified by that code, see notify.ClassifyError.
+func codeError(code int) error {
+	err := fmt.Errorf("send failed with error code %d, please see https://open.larksuite.com/document/ukTMukTMukTM/ugjM14COyUjL4ITN for details", code)
+
+	switch code {
+	case 19021, 99991661, 99991663, 99991668:
+		// Signature mismatch and invalid or expired access tokens.
+		return notify.ClassifyError(err, notify.ErrAuthFailed)
+	case 11232, 99991400:
+		return notify.ClassifyError(err, &notify.ErrRateLimited{})
+	case 230002, 230013, 99992361:
+		// The bot isn't in the chat or can't reach the user.
+		return notify.ClassifyError(err, notify.ErrInvalidReceiver)
+	default:
+		return notify.ClassifyError(err, notify.ErrPermanent)
+	}
+}
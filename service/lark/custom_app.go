@@ -15,6 +15,10 @@ import (
 type CustomAppService struct {
 	receiveIDs []*ReceiverID
 	cli        sendToer
+	bot        *lark.Bot
+	rich       *richText
+	appID      string
+	appSecret  string
 }
 
 // Compile time check that larkCustomAppService implements notify.Notifer.
@@ -32,19 +36,44 @@ func NewCustomAppService(appID, appSecret string) *CustomAppService {
 	// Let the bot use a HTTP client with a longer timeout than the default 5
 	// seconds.
 	bot.SetClient(&http.Client{
-		Timeout: 8 * time.Second,
+		Transport: notify.NewRateLimitTransport(nil),
+		Timeout:   8 * time.Second,
 	})
 
 	_ = bot.StartHeartbeat()
 
+	rich := &richText{}
 	return &CustomAppService{
 		receiveIDs: make([]*ReceiverID, 0),
 		cli: &larkClientGoLarkChatBot{
-			bot: bot,
+			bot:  bot,
+			rich: rich,
 		},
+		bot:       bot,
+		rich:      rich,
+		appID:     appID,
+		appSecret: appSecret,
 	}
 }
 
+// UseFeishu makes the service use Feishu's open.feishu.cn domain instead of
+// Lark's open.larksuite.com domain. Apps created on Feishu can only obtain
+// tenant access tokens and send messages through the former.
+func (c *CustomAppService) UseFeishu() {
+	c.bot.SetDomain(lark.DomainFeishu)
+}
+
+// AddLink appends a hyperlink to all future messages.
+func (c *CustomAppService) AddLink(text, href string) {
+	c.rich.links = append(c.rich.links, link{text: text, href: href})
+}
+
+// Mention adds users, identified by their Open ID, that get mentioned in all
+// future messages sent to group chats. Use "all" to mention everyone.
+func (c *CustomAppService) Mention(openIDs ...string) {
+	c.rich.mentions = append(c.rich.mentions, openIDs...)
+}
+
 // AddReceivers adds recipients to future notifications. There are five different
 // types of receiver IDs available in Lark and they must be specified here. For
 // example:
@@ -60,6 +89,34 @@ func (c *CustomAppService) AddReceivers(ids ...*ReceiverID) {
 	c.receiveIDs = append(c.receiveIDs, ids...)
 }
 
+// Validate checks that the app ID and secret are set, that at least one
+// receiver is set and that no receiver ID is empty, see notify.Validator.
+func (c *CustomAppService) Validate() error {
+	var problems notify.ConfigErrors
+	problems.Require("app ID", c.appID)
+	problems.Require("app secret", c.appSecret)
+	if len(c.receiveIDs) == 0 {
+		problems.Addf("no receiver set")
+	}
+	for _, id := range c.receiveIDs {
+		if id == nil || id.id == "" {
+			problems.Addf("receiver ID is empty")
+			continue
+		}
+		if id.typ == email {
+			problems.Check("receiver", notify.ValidateMailAddress(id.id))
+		}
+	}
+
+	return problems.Err()
+}
+
+// Sanitize removes control characters from the message, see
+// notify.Sanitizer. Texts are displayed literally and need no escaping.
+func (c *CustomAppService) Sanitize(message notify.Message) notify.Message {
+	return sanitize(message)
+}
+
 // Send takes a message subject and a message body and sends them to all
 // previously registered recipient IDs.
 func (c *CustomAppService) Send(ctx context.Context, subject, message string) error {
@@ -79,16 +136,13 @@ func (c *CustomAppService) Send(ctx context.Context, subject, message string) er
 // larkClientGoLarkChatBot is a wrapper around go-lark/lark's Bot, to be used
 // for sending messages with custom apps.
 type larkClientGoLarkChatBot struct {
-	bot *lark.Bot
+	bot  *lark.Bot
+	rich *richText
 }
 
 // SendTo implements the sendToer interface using a go-lark/lark chat bot.
 func (l *larkClientGoLarkChatBot) SendTo(subject, message, receiverID, idType string) error {
-	content := lark.NewPostBuilder().
-		Title(subject).
-		TextTag(message, 1, false).
-		Render()
-	msg := lark.NewMsgBuffer(lark.MsgPost).Post(content)
+	msg := lark.NewMsgBuffer(lark.MsgPost).Post(l.rich.render(subject, message))
 	switch receiverIDType(idType) {
 	case openID:
 		msg.BindOpenID(receiverID)
@@ -103,10 +157,10 @@ func (l *larkClientGoLarkChatBot) SendTo(subject, message, receiverID, idType st
 	}
 	res, err := l.bot.PostMessage(msg.Build())
 	if err != nil {
-		return fmt.Errorf("failed to send message: %w", err)
+		return notify.ClassifyNetworkError(fmt.Errorf("failed to send message: %w", err))
 	}
 	if res.Code != 0 {
-		return fmt.Errorf("send failed with error code %d, please see https://open.larksuite.com/document/ukTMukTMukTM/ugjM14COyUjL4ITN for details", res.Code)
+		return codeError(res.Code)
 	}
 	return nil
 }
@@ -7,6 +7,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/nikoksr/notify"
 )
 
 func TestLark_NewCustomAppService(t *testing.T) {
@@ -41,6 +43,27 @@ func TestLark_AddReceivers(t *testing.T) {
 	assert.ElementsMatch(t, svc.receiveIDs, append(xs, ys...))
 }
 
+func TestLark_ValidateCustomApp(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := NewCustomAppService("app", "secret")
+	service.AddReceivers(OpenID("ou_c99c5f35d542efc7ee492afe11af19ef"), Email("xyz@example.com"))
+	assert.NoError(service.Validate())
+
+	err := NewCustomAppService("", "").Validate()
+	assert.ErrorIs(err, notify.ErrInvalidConfig)
+	assert.ErrorContains(err, "app ID is missing")
+	assert.ErrorContains(err, "app secret is missing")
+	assert.ErrorContains(err, "no receiver set")
+
+	service.AddReceivers(ChatID(""), Email("xyz"))
+	err = service.Validate()
+	assert.ErrorContains(err, "receiver ID is empty")
+	assert.ErrorContains(err, "receiver: mail address")
+}
+
 func TestLark_SendCustomApp(t *testing.T) {
 	t.Parallel()
 	ctx := context.Background()
@@ -3,6 +3,7 @@ package lark
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/go-lark/lark"
 
@@ -11,7 +12,9 @@ import (
 
 // WebhookService is a Notify service that uses a Lark webhook to send messages.
 type WebhookService struct {
-	cli sender
+	cli        sender
+	rich       *richText
+	webhookURL string
 }
 
 // Compile time check that larkCustomAppService implements notify.Notifer.
@@ -22,14 +25,52 @@ var _ notify.Notifier = &WebhookService{}
 // notification receivers because it can only push messages to the group chat
 // it belongs to.
 func NewWebhookService(webhookURL string) *WebhookService {
+	return NewWebhookServiceWithSecret(webhookURL, "")
+}
+
+// NewWebhookServiceWithSecret returns a new instance of a Lark notify service
+// using a Lark group chat webhook that has signature verification enabled.
+// Every message gets signed with the given secret, as described in
+// https://open.larksuite.com/document/client-docs/bot-v3/add-custom-bot.
+func NewWebhookServiceWithSecret(webhookURL, secret string) *WebhookService {
 	bot := lark.NewNotificationBot(webhookURL)
+	rich := &richText{}
 	return &WebhookService{
 		cli: &larkClientGoLarkNotificationBot{
-			bot: bot,
+			bot:    bot,
+			secret: secret,
+			rich:   rich,
 		},
+		rich:       rich,
+		webhookURL: webhookURL,
 	}
 }
 
+// AddLink appends a hyperlink to all future messages.
+func (w *WebhookService) AddLink(text, href string) {
+	w.rich.links = append(w.rich.links, link{text: text, href: href})
+}
+
+// Mention adds users that get mentioned in all future messages. Use "all" to
+// mention everyone in the group chat.
+func (w *WebhookService) Mention(userIDs ...string) {
+	w.rich.mentions = append(w.rich.mentions, userIDs...)
+}
+
+// Validate checks that the webhook URL is well-formed, see notify.Validator.
+func (w *WebhookService) Validate() error {
+	var problems notify.ConfigErrors
+	problems.Check("webhook URL", notify.ValidateURL(w.webhookURL))
+
+	return problems.Err()
+}
+
+// Sanitize removes control characters from the message, see
+// notify.Sanitizer. Texts are displayed literally and need no escaping.
+func (w *WebhookService) Sanitize(message notify.Message) notify.Message {
+	return sanitize(message)
+}
+
 // Send sends the message subject and body to the group chat.
 func (w *WebhookService) Send(_ context.Context, subject, message string) error {
 	return w.cli.Send(subject, message)
@@ -38,22 +79,23 @@ func (w *WebhookService) Send(_ context.Context, subject, message string) error
 // larkClientGoLarkNotificationBot is a wrapper around go-lark/lark's Bot, to
 // be used for notifications via webhooks only.
 type larkClientGoLarkNotificationBot struct {
-	bot *lark.Bot
+	bot    *lark.Bot
+	secret string
+	rich   *richText
 }
 
 // Send implements the sender interface using a go-lark/lark notification bot.
 func (w *larkClientGoLarkNotificationBot) Send(subject, message string) error {
-	content := lark.NewPostBuilder().
-		Title(subject).
-		TextTag(message, 1, false).
-		Render()
-	msg := lark.NewMsgBuffer(lark.MsgPost).Post(content)
+	msg := lark.NewMsgBuffer(lark.MsgPost).Post(w.rich.render(subject, message))
+	if w.secret != "" {
+		msg.WithSign(w.secret, time.Now().Unix())
+	}
 	res, err := w.bot.PostNotificationV2(msg.Build())
 	if err != nil {
-		return fmt.Errorf("failed to post webhook message: %w", err)
+		return notify.ClassifyNetworkError(fmt.Errorf("failed to post webhook message: %w", err))
 	}
 	if res.Code != 0 {
-		return fmt.Errorf("send failed with error code %d, please see https://open.larksuite.com/document/ukTMukTMukTM/ugjM14COyUjL4ITN for details", res.Code)
+		return codeError(res.Code)
 	}
 	return nil
 }
@@ -2,11 +2,17 @@ package lark
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	"github.com/go-lark/lark"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/nikoksr/notify"
 )
 
 func TestLark_NewWebhookService(t *testing.T) {
@@ -18,6 +24,29 @@ func TestLark_NewWebhookService(t *testing.T) {
 	assert.NotNil(service)
 }
 
+func TestLark_ValidateWebhook(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	assert.NoError(NewWebhookService("https://open.larksuite.com/open-apis/bot/v2/hook/abc").Validate())
+
+	err := NewWebhookService("").Validate()
+	assert.ErrorIs(err, notify.ErrInvalidConfig)
+	assert.ErrorContains(err, "webhook URL")
+}
+
+func TestLark_Sanitize(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	message := notify.Message{Subject: "Alert\u202e", Body: "<at user_id=\"all\"></at> **down**\x07"}
+	want := notify.Message{Subject: "Alert", Body: "<at user_id=\"all\"></at> **down**"}
+	assert.Equal(want, NewWebhookService("").Sanitize(message))
+	assert.Equal(want, NewCustomAppService("", "").Sanitize(message))
+}
+
 func TestLark_SendWebhook(t *testing.T) {
 	t.Parallel()
 
@@ -52,3 +81,35 @@ func TestLark_SendWebhook(t *testing.T) {
 		mockSender.AssertExpectations(t)
 	}
 }
+
+func TestLark_SendSignedWebhook(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	var got map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		_, _ = w.Write([]byte(`{"code":0}`))
+	}))
+	defer server.Close()
+
+	svc := NewWebhookServiceWithSecret(server.URL, "secret")
+	svc.AddLink("details", "https://example.com")
+	svc.Mention("all")
+	err := svc.Send(context.Background(), "subject", "message")
+	assert.Nil(err)
+
+	sign, err := lark.GenSign("secret", int64(got["timestamp"].(float64)))
+	assert.Nil(err)
+	assert.Equal(sign, got["sign"])
+
+	content := got["content"].(map[string]any)["post"].(map[string]any)["zh_cn"].(map[string]any)
+	assert.Equal("subject", content["title"])
+	elems := content["content"].([]any)[0].([]any)
+	assert.Len(elems, 4)
+	assert.Equal("a", elems[2].(map[string]any)["tag"])
+	assert.Equal("https://example.com", elems[2].(map[string]any)["href"])
+	assert.Equal("at", elems[3].(map[string]any)["tag"])
+	assert.Equal("all", elems[3].(map[string]any)["user_id"])
+}
@@ -5,19 +5,29 @@ import (
 
 	"github.com/line/line-bot-sdk-go/linebot"
 	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
 )
 
+// maxTextLength is the maximum number of characters of a text message accepted by the Messaging API.
+const maxTextLength = 5000
+
+// Compile-time check to ensure that Line implements the notify.Limiter interface.
+var _ notify.Limiter = new(Line)
+
 // Line struct holds info about client and destination ID for communicating with line API
 type Line struct {
 	client      *linebot.Client
 	receiverIDs []string
+	silent      bool
 }
 
-// New creates a new instance of Line notifier service
+// New creates a new instance of Line notifier service. Additional client options, e.g. linebot.WithHTTPClient, are
+// passed on to the underlying client.
 // For more info about line api credential:
 // -> https://github.com/line/line-bot-sdk-go
-func New(channelSecret, channelAccessToken string) (*Line, error) {
-	bot, err := linebot.New(channelSecret, channelAccessToken)
+func New(channelSecret, channelAccessToken string, options ...linebot.ClientOption) (*Line, error) {
+	bot, err := linebot.New(channelSecret, channelAccessToken, options...)
 	if err != nil {
 		return nil, err
 	}
@@ -34,24 +44,51 @@ func (l *Line) AddReceivers(receiverIDs ...string) {
 	l.receiverIDs = append(l.receiverIDs, receiverIDs...)
 }
 
+// DisableNotification tells LINE to deliver messages silently, without notifying the receivers.
+func (l *Line) DisableNotification(disabled bool) {
+	l.silent = disabled
+}
+
+// Limits returns the maximum length of LINE text messages, which contain subject and body.
+func (l *Line) Limits() notify.Limits {
+	return notify.Limits{Total: maxTextLength}
+}
+
 // Send receives message subject and body then sends it to all receivers set previously
-// Subject will be on the first line followed by message on the next line
+// Subject will be on the first line followed by message on the next line. Texts exceeding the limit of the Messaging
+// API are truncated.
 func (l *Line) Send(ctx context.Context, subject, message string) error {
-	lineMessage := &linebot.TextMessage{
-		Text: subject + "\n" + message,
+	text := []rune(subject + "\n" + message)
+	if len(text) > maxTextLength {
+		text = text[:maxTextLength]
 	}
+	lineMessage := linebot.NewTextMessage(string(text))
 
 	for _, receiverID := range l.receiverIDs {
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
 		default:
-			_, err := l.client.PushMessage(receiverID, lineMessage).WithContext(ctx).Do()
+			call := l.client.PushMessage(receiverID, lineMessage).WithContext(ctx)
+			if l.silent {
+				call = call.WithNotificationDisabled()
+			}
+			_, err := call.Do()
 			if err != nil {
-				return errors.Wrapf(err, "failed to send message to LINE contact '%s'", receiverID)
+				return classifyError(errors.Wrapf(err, "failed to send message to LINE contact '%s'", receiverID))
 			}
 		}
 	}
 
 	return nil
 }
+
+// classifyError classifies errors returned by the LINE Messaging API by their HTTP status, see notify.ClassifyError.
+func classifyError(err error) error {
+	var apiErr *linebot.APIError
+	if errors.As(err, &apiErr) {
+		return notify.ClassifyStatus(apiErr.Code, err)
+	}
+
+	return notify.ClassifyNetworkError(err)
+}
@@ -2,9 +2,13 @@ package line
 
 import (
 	"context"
+	"net/http"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/utahta/go-linenotify"
+
+	"github.com/nikoksr/notify"
 )
 
 // Line Notify struct holds info about client and destination token for communicating with line API
@@ -39,12 +43,31 @@ func (ln *Notify) Send(ctx context.Context, subject, message string) error {
 		case <-ctx.Done():
 			return ctx.Err()
 		default:
-			_, err := ln.client.NotifyMessage(ctx, receiverToken, lineMessage)
+			resp, err := ln.client.NotifyMessage(ctx, receiverToken, lineMessage)
 			if err != nil {
-				return errors.Wrapf(err, "failed to send message to LINE contact '%s'", receiverToken)
+				return classifyNotifyError(resp, errors.Wrapf(err, "failed to send message to LINE contact '%s'", receiverToken))
 			}
 		}
 	}
 
 	return nil
 }
+
+// classifyNotifyError classifies errors returned by LINE Notify, see notify.ClassifyError. Each receiver has its own
+// access token, so an invalid token means an invalid receiver.
+func classifyNotifyError(resp *linenotify.NotifyResponse, err error) error {
+	switch {
+	case errors.Is(err, linenotify.ErrNotifyInvalidAccessToken):
+		return notify.ClassifyError(err, notify.ErrInvalidReceiver)
+	case resp != nil && resp.Status == http.StatusTooManyRequests:
+		var retryAfter time.Duration
+		if !resp.RateLimit.Reset.IsZero() {
+			retryAfter = time.Until(resp.RateLimit.Reset)
+		}
+		return notify.ClassifyError(err, &notify.ErrRateLimited{RetryAfter: retryAfter})
+	case resp != nil && resp.Status != 0:
+		return notify.ClassifyStatus(resp.Status, err)
+	default:
+		return notify.ClassifyNetworkError(err)
+	}
+}
@@ -0,0 +1,73 @@
+package line
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/line/line-bot-sdk-go/linebot"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLine_New(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service, err := New("secret", "token")
+	assert.Nil(err)
+	assert.NotNil(service)
+
+	_, err = New("", "token")
+	assert.NotNil(err)
+}
+
+func TestLine_Send(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	type pushRequest struct {
+		To                   string `json:"to"`
+		NotificationDisabled bool   `json:"notificationDisabled"`
+		Messages             []struct {
+			Text string `json:"text"`
+		} `json:"messages"`
+	}
+
+	var got pushRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		if got.To == "invalid" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"message":"The request body has 1 error(s)"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	service, err := New("secret", "token", linebot.WithEndpointBase(server.URL))
+	assert.Nil(err)
+	service.DisableNotification(true)
+	service.AddReceivers("user-id")
+
+	err = service.Send(context.Background(), "subject", "message")
+	assert.Nil(err)
+	assert.Equal("user-id", got.To)
+	assert.True(got.NotificationDisabled)
+	assert.Equal("subject\nmessage", got.Messages[0].Text)
+
+	// Test truncation of long messages
+	err = service.Send(context.Background(), "subject", strings.Repeat("a", 2*maxTextLength))
+	assert.Nil(err)
+	assert.Len(got.Messages[0].Text, maxTextLength)
+
+	// Test error response
+	service.AddReceivers("invalid")
+	err = service.Send(context.Background(), "subject", "message")
+	assert.NotNil(err)
+}
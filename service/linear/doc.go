@@ -0,0 +1,33 @@
+/*
+Package linear provides a service for creating issues in Linear.
+
+Usage:
+
+	package main
+
+	import (
+	    "context"
+	    "log"
+
+	    "github.com/nikoksr/notify"
+	    "github.com/nikoksr/notify/service/linear"
+	)
+
+	func main() {
+	    linearService := linear.New("lin_api_xxx")
+
+	    // Create urgent issues with the given labels for this team.
+	    linearService.AddReceivers("9cfb482a-81e3-4154-b5b9-2c805e70a02d")
+	    linearService.SetLabelIDs("a5e3b7f0-2b83-4b6c-9d5b-31d57b3c4a1e")
+	    linearService.SetPriority(linear.PriorityUrgent)
+
+	    // Tell our notifier to use the linear service.
+	    notify.UseServices(linearService)
+
+	    // Send a test message.
+	    if err := notify.Send(context.Background(), "Subject/Title", "The actual message"); err != nil {
+	        log.Fatal(err)
+	    }
+	}
+*/
+package linear
@@ -0,0 +1,191 @@
+package linear
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
+)
+
+// DefaultAPIURL is the URL of the Linear GraphQL API.
+const DefaultAPIURL = "https://api.linear.app/graphql"
+
+// Priority is the priority of created issues. The values match the ones used by the Linear API.
+type Priority int
+
+// Priorities supported by Linear.
+const (
+	PriorityNone Priority = iota
+	PriorityUrgent
+	PriorityHigh
+	PriorityMedium
+	PriorityLow
+)
+
+// priorities maps the priorities of notify.WithPriority to Linear priorities.
+var priorities = map[notify.Priority]Priority{
+	notify.PriorityLow:      PriorityLow,
+	notify.PriorityNormal:   PriorityMedium,
+	notify.PriorityHigh:     PriorityHigh,
+	notify.PriorityCritical: PriorityUrgent,
+}
+
+const issueCreateMutation = `mutation IssueCreate($input: IssueCreateInput!) {
+  issueCreate(input: $input) {
+    success
+  }
+}`
+
+// Service encapsulates the Linear client.
+type Service struct {
+	client   *http.Client
+	apiURL   string
+	apiKey   string
+	teamIDs  []string
+	labelIDs []string
+	priority Priority
+}
+
+// New returns a new instance of a Linear notification service, authenticated with a personal API key.
+// For more information about API keys:
+//
+//	-> https://developers.linear.app/docs/graphql/working-with-the-graphql-api#personal-api-keys
+func New(apiKey string) *Service {
+	return &Service{
+		client: &http.Client{
+			Transport: notify.NewRateLimitTransport(nil),
+			Timeout:   10 * time.Second,
+		},
+		apiURL:  DefaultAPIURL,
+		apiKey:  apiKey,
+		teamIDs: []string{},
+	}
+}
+
+// WithClient sets the http client to be used for sending requests. Calling this method is optional, the default client
+// will be used if this method is not called.
+func (s *Service) WithClient(client *http.Client) {
+	if client != nil {
+		s.client = client
+	}
+}
+
+// AddReceivers takes team IDs and adds them to the internal receiver list. The Send method creates a new issue for each
+// of those teams.
+func (s *Service) AddReceivers(teamIDs ...string) {
+	s.teamIDs = append(s.teamIDs, teamIDs...)
+}
+
+// SetLabelIDs sets the IDs of the labels applied to created issues.
+func (s *Service) SetLabelIDs(labelIDs ...string) {
+	s.labelIDs = labelIDs
+}
+
+// SetPriority sets the priority of created issues. Defaults to PriorityNone. The priority of a notification, see
+// notify.WithPriority, overrides it: low is low, normal medium, high high and critical urgent.
+func (s *Service) SetPriority(priority Priority) {
+	s.priority = priority
+}
+
+type (
+	issueCreateInput struct {
+		TeamID      string   `json:"teamId"`
+		Title       string   `json:"title"`
+		Description string   `json:"description"`
+		LabelIDs    []string `json:"labelIds,omitempty"`
+		Priority    Priority `json:"priority"`
+	}
+
+	graphQLRequest struct {
+		Query     string         `json:"query"`
+		Variables map[string]any `json:"variables"`
+	}
+
+	graphQLResponse struct {
+		Data struct {
+			IssueCreate struct {
+				Success bool `json:"success"`
+			} `json:"issueCreate"`
+		} `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+)
+
+func (s *Service) createIssue(ctx context.Context, input *issueCreateInput) error {
+	payload, err := json.Marshal(&graphQLRequest{
+		Query:     issueCreateMutation,
+		Variables: map[string]any{"input": input},
+	})
+	if err != nil {
+		return errors.Wrap(err, "marshal request")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.apiURL, bytes.NewReader(payload))
+	if err != nil {
+		return errors.Wrap(err, "create request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", s.apiKey)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "send request")
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Wrap(err, "read response")
+	}
+
+	var result graphQLResponse
+	if err = json.Unmarshal(body, &result); err != nil {
+		return notify.ClassifyResponse(resp, fmt.Errorf("linear returned status code %d: %s", resp.StatusCode, string(body)))
+	}
+	if len(result.Errors) > 0 {
+		return fmt.Errorf("linear returned error: %s", result.Errors[0].Message)
+	}
+	if resp.StatusCode != http.StatusOK || !result.Data.IssueCreate.Success {
+		return notify.ClassifyResponse(resp, fmt.Errorf("linear returned status code %d: %s", resp.StatusCode, string(body)))
+	}
+
+	return nil
+}
+
+// Send takes a message subject and a message body and creates an issue with the subject as title and the body as
+// markdown description for all previously set teams.
+func (s *Service) Send(ctx context.Context, subject, message string) error {
+	priority := s.priority
+	if mapped, ok := priorities[notify.SendOptionsFromContext(ctx).Priority]; ok {
+		priority = mapped
+	}
+
+	for _, teamID := range s.teamIDs {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			input := &issueCreateInput{
+				TeamID:      teamID,
+				Title:       subject,
+				Description: message,
+				LabelIDs:    s.labelIDs,
+				Priority:    priority,
+			}
+			if err := s.createIssue(ctx, input); err != nil {
+				return errors.Wrapf(err, "failed to create issue for team %q", teamID)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,66 @@
+package linear
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nikoksr/notify"
+)
+
+func TestLinear_Send(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	var got issueCreateInput
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "key" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"errors":[{"message":"Authentication required, not authenticated"}]}`))
+			return
+		}
+		var body struct {
+			Query     string `json:"query"`
+			Variables struct {
+				Input issueCreateInput `json:"input"`
+			} `json:"variables"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		got = body.Variables.Input
+		_, _ = w.Write([]byte(`{"data":{"issueCreate":{"success":true}}}`))
+	}))
+	defer server.Close()
+
+	service := New("key")
+	service.apiURL = server.URL
+	service.AddReceivers("team")
+	service.SetLabelIDs("label")
+	service.SetPriority(PriorityHigh)
+
+	err := service.Send(context.Background(), "subject", "message")
+	assert.Nil(err)
+	assert.Equal(issueCreateInput{
+		TeamID:      "team",
+		Title:       "subject",
+		Description: "message",
+		LabelIDs:    []string{"label"},
+		Priority:    PriorityHigh,
+	}, got)
+
+	// The priority of the notification overrides the priority of the service.
+	ctx := notify.ContextWithSendOptions(context.Background(), notify.WithPriority(notify.PriorityCritical))
+	assert.Nil(service.Send(ctx, "subject", "message"))
+	assert.Equal(PriorityUrgent, got.Priority)
+
+	// Test error response
+	service = New("invalid")
+	service.apiURL = server.URL
+	service.AddReceivers("team")
+	err = service.Send(context.Background(), "subject", "message")
+	assert.ErrorContains(err, "not authenticated")
+}
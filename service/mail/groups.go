@@ -0,0 +1,203 @@
+package mail
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/sync/singleflight"
+
+	"github.com/nikoksr/notify"
+)
+
+// DefaultGroupRefresh is the interval after which the members of receiver groups are resolved again by default.
+const DefaultGroupRefresh = 15 * time.Minute
+
+// Directory resolves groups of a directory, e.g. LDAP or Active Directory groups, to the mail addresses of their
+// members, see AddReceiverGroup.
+type Directory interface {
+	GroupMembers(ctx context.Context, group string) ([]string, error)
+}
+
+// DirectoryFunc is a function that implements Directory, e.g. a closure around the search of an LDAP client.
+type DirectoryFunc func(ctx context.Context, group string) ([]string, error)
+
+// GroupMembers calls f.
+func (f DirectoryFunc) GroupMembers(ctx context.Context, group string) ([]string, error) {
+	return f(ctx, group)
+}
+
+// groupMembers are the resolved members of a receiver group.
+type groupMembers struct {
+	addresses []string
+	resolved  time.Time
+}
+
+// groupCache resolves receiver groups through a directory and caches their members for the refresh interval. It is
+// shared by all copies of a Mail. The directory is queried without holding the lock, and concurrent sends share a
+// single query per group.
+type groupCache struct {
+	mu         sync.Mutex
+	directory  Directory
+	generation int // Incremented by set, so that queries of a replaced directory don't fill the cache.
+	refresh    time.Duration
+	groups     []string
+	members    map[string]groupMembers
+
+	lookups singleflight.Group
+	now     func() time.Time
+}
+
+// SetDirectory sets the directory receiver groups are resolved with, see AddReceiverGroup. Members are resolved at send
+// time and cached for the refresh interval, DefaultGroupRefresh if refresh is zero or less, so that distribution lists
+// stay in sync with the directory without querying it for every mail.
+func (m *Mail) SetDirectory(directory Directory, refresh time.Duration) {
+	if refresh <= 0 {
+		refresh = DefaultGroupRefresh
+	}
+
+	m.groupCache().set(directory, refresh)
+}
+
+// AddReceiverGroup takes groups of the directory set with SetDirectory, e.g. the distinguished name
+// "cn=ops,ou=groups,dc=example,dc=com", and adds their members to the receivers of every mail.
+func (m *Mail) AddReceiverGroup(groups ...string) {
+	m.groupCache().add(groups...)
+}
+
+// groupCache returns the group cache of the mail, creating it on first use.
+func (m *Mail) groupCache() *groupCache {
+	if m.groups == nil {
+		m.groups = &groupCache{
+			refresh: DefaultGroupRefresh,
+			members: make(map[string]groupMembers),
+			now:     time.Now,
+		}
+	}
+
+	return m.groups
+}
+
+func (c *groupCache) set(directory Directory, refresh time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.directory = directory
+	c.generation++
+	c.refresh = refresh
+	c.members = make(map[string]groupMembers)
+}
+
+func (c *groupCache) add(groups ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.groups = append(c.groups, groups...)
+}
+
+// validate checks that receiver groups have a directory to be resolved with.
+func (c *groupCache) validate() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.groups) > 0 && c.directory == nil {
+		return errors.New("receiver groups require a directory, see SetDirectory")
+	}
+
+	return nil
+}
+
+// resolve returns the addresses of the members of all groups. Groups whose members are older than the refresh interval
+// are resolved again; if that fails, their previous members are used. It only fails for groups that were never
+// resolved.
+func (c *groupCache) resolve(ctx context.Context) ([]string, error) {
+	c.mu.Lock()
+	groups := c.groups
+	directory, generation := c.directory, c.generation
+	c.mu.Unlock()
+
+	if len(groups) == 0 {
+		return nil, nil
+	}
+	if directory == nil {
+		return nil, errors.New("receiver groups require a directory, see SetDirectory")
+	}
+
+	var addresses []string
+	for _, group := range groups {
+		members, err := c.lookup(ctx, directory, generation, group)
+		if err != nil {
+			return nil, err
+		}
+		addresses = append(addresses, members...)
+	}
+
+	return addresses, nil
+}
+
+// lookup returns the members of the group, queried from the directory if the cached ones are missing or expired.
+func (c *groupCache) lookup(ctx context.Context, directory Directory, generation int, group string) ([]string, error) {
+	c.mu.Lock()
+	cached, ok := c.members[group]
+	fresh := ok && c.now().Sub(cached.resolved) < c.refresh
+	c.mu.Unlock()
+
+	if fresh {
+		return cached.addresses, nil
+	}
+
+	result, err, _ := c.lookups.Do(strconv.Itoa(generation)+"/"+group, func() (interface{}, error) {
+		members, err := directory.GroupMembers(ctx, group)
+		if err != nil {
+			return nil, err
+		}
+
+		c.mu.Lock()
+		defer c.mu.Unlock()
+
+		if c.generation == generation {
+			c.members[group] = groupMembers{addresses: members, resolved: c.now()}
+		}
+
+		return members, nil
+	})
+	switch {
+	case err == nil:
+		return result.([]string), nil
+	case ok:
+		return cached.addresses, nil
+	default:
+		return nil, errors.Wrapf(err, "resolve receiver group %q", group)
+	}
+}
+
+// receivers returns the receivers of a mail: the override of the send, if any, or the receivers added with
+// AddReceivers together with the members of the receiver groups, without duplicates.
+func (m Mail) receivers(ctx context.Context) ([]string, error) {
+	if override := notify.ReceiversFromContext(ctx, nil); override != nil {
+		return override, nil
+	}
+	if m.groups == nil {
+		return m.receiverAddresses, nil
+	}
+
+	members, err := m.groups.resolve(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool, len(m.receiverAddresses)+len(members))
+	receivers := make([]string, 0, len(m.receiverAddresses)+len(members))
+	for _, address := range append(append([]string(nil), m.receiverAddresses...), members...) {
+		key := strings.ToLower(address)
+		if !seen[key] {
+			seen[key] = true
+			receivers = append(receivers, address)
+		}
+	}
+
+	return receivers, nil
+}
@@ -1,13 +1,30 @@
 package mail
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
 	"crypto/tls"
+	"encoding/hex"
+	"html"
+	"net"
 	"net/smtp"
 	"net/textproto"
+	"strings"
 
 	"github.com/jordan-wright/email"
 	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
+)
+
+// Compile-time check to ensure that Mail implements the optional notify interfaces.
+var (
+	_ notify.MessageSender    = Mail{}
+	_ notify.AttachmentSender = Mail{}
+	_ notify.Sanitizer        = Mail{}
+	_ notify.ContactResolver  = Mail{}
+	_ notify.Validator        = Mail{}
 )
 
 // Mail struct holds necessary data to send emails.
@@ -19,6 +36,7 @@ type Mail struct {
 	receiverAddresses []string
 	useTLS            bool
 	tlsConfig         *tls.Config
+	groups            *groupCache
 }
 
 // New returns a new instance of a Mail notification service.
@@ -96,12 +114,169 @@ func (m *Mail) newEmail(subject, message string) *email.Email {
 	return msg
 }
 
+// threadMessageID derives a stable message ID from a thread key. All mails of a thread reference it, so mail clients
+// group them even though no mail with that ID was ever sent.
+func (m *Mail) threadMessageID(key string) string {
+	domain := "notify"
+	if i := strings.LastIndex(m.senderAddress, "@"); i >= 0 && i < len(m.senderAddress)-1 {
+		domain = strings.TrimSuffix(m.senderAddress[i+1:], ">")
+	}
+
+	sum := sha256.Sum256([]byte(key))
+
+	return "<" + hex.EncodeToString(sum[:16]) + "@" + domain + ">"
+}
+
+// actionsHTML renders actions as a paragraph of links.
+func actionsHTML(actions []notify.Action) string {
+	var b strings.Builder
+	b.WriteString("<p>")
+	for i, action := range actions {
+		if i > 0 {
+			b.WriteString("<br>")
+		}
+		b.WriteString(`<a href="` + html.EscapeString(action.URL) + `">` + html.EscapeString(action.Label) + "</a>")
+	}
+	b.WriteString("</p>")
+
+	return b.String()
+}
+
+// cardHTML renders a card as table with a colored border, one row per field and the footer in small print.
+func cardHTML(card *notify.Card) string {
+	var b strings.Builder
+	b.WriteString(`<table style="border-left:4px solid ` + html.EscapeString(card.Color) + `;padding-left:8px">`)
+	if card.Title != "" {
+		b.WriteString(`<tr><th colspan="2" style="text-align:left">` + html.EscapeString(card.Title) + "</th></tr>")
+	}
+	for _, field := range card.Fields {
+		b.WriteString("<tr><td><b>" + html.EscapeString(field.Name) + "</b></td><td>" + html.EscapeString(field.Value) +
+			"</td></tr>")
+	}
+	if card.ImageURL != "" {
+		b.WriteString(`<tr><td colspan="2"><img src="` + html.EscapeString(card.ImageURL) + `" alt="` +
+			html.EscapeString(card.Title) + `"></td></tr>`)
+	}
+	if card.Footer != "" {
+		b.WriteString(`<tr><td colspan="2"><small>` + html.EscapeString(card.Footer) + "</small></td></tr>")
+	}
+	b.WriteString("</table>")
+
+	return b.String()
+}
+
+// newMessageEmail builds a mail from a notify.Message. Messages with an HTML body are sent with an HTML part and a
+// plain text part, derived from the HTML if the message has no plain text body. Other messages are sent in the format
+// set by BodyFormat. The card is appended to the body as table, actions as links and attachments are attached as files.
+func (m *Mail) newMessageEmail(message notify.Message) (*email.Email, error) {
+	msg := m.newEmail(message.Subject, message.Body)
+	if message.HTML != "" {
+		text := message.Body
+		if text == "" {
+			text = notify.HTMLToText(message.HTML)
+		}
+		msg.HTML = []byte(message.HTML)
+		msg.Text = []byte(text)
+	}
+
+	if len(message.Actions) > 0 || message.Card != nil {
+		if msg.Text != nil {
+			msg.Text = []byte(notify.Message{
+				Body:    string(msg.Text),
+				Actions: message.Actions,
+				Card:    message.Card,
+			}.PlainText())
+		}
+		if msg.HTML != nil && message.Card != nil {
+			msg.HTML = append(msg.HTML, cardHTML(message.Card)...)
+		}
+		if msg.HTML != nil && len(message.Actions) > 0 {
+			msg.HTML = append(msg.HTML, actionsHTML(message.Actions)...)
+		}
+	}
+
+	for _, attachment := range message.Attachments {
+		_, err := msg.Attach(bytes.NewReader(attachment.Data), attachment.Name, attachment.ContentType)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to attach %q", attachment.Name)
+		}
+	}
+
+	return msg, nil
+}
+
+// lineBreaks replaces line breaks with spaces.
+var lineBreaks = strings.NewReplacer("\r\n", " ", "\r", " ", "\n", " ")
+
+// Sanitize removes control characters from the texts of the message and line breaks from its subject, which would
+// otherwise end up in the mail headers. Bodies sent as HTML are stripped of scripts and other active content, see
+// notify.SanitizeHTML. Card and actions need no escaping, they are always escaped when rendered as HTML.
+func (m Mail) Sanitize(message notify.Message) notify.Message {
+	sanitized := notify.SanitizeMessage(message, notify.StripControlChars)
+	sanitized.Subject = lineBreaks.Replace(sanitized.Subject)
+	if !m.usePlainText && message.HTML == "" {
+		sanitized.Body = notify.SanitizeHTML(sanitized.Body)
+	}
+
+	return sanitized
+}
+
+// Validate checks that the sender and receiver addresses parse, that the SMTP server address has host and port and that
+// receiver groups have a directory to be resolved with, see notify.Validator.
+func (m Mail) Validate() error {
+	var problems notify.ConfigErrors
+	problems.Check("sender", notify.ValidateMailAddress(m.senderAddress))
+	if _, _, err := net.SplitHostPort(m.smtpHostAddr); err != nil {
+		problems.Addf("SMTP server address %q must have host and port", m.smtpHostAddr)
+	}
+	for _, address := range m.receiverAddresses {
+		problems.Check("receiver", notify.ValidateMailAddress(address))
+	}
+	if m.groups != nil {
+		problems.Check("receiver groups", m.groups.validate())
+	}
+
+	return problems.Err()
+}
+
+// ResolveContact returns the mail address of the contact, see notify.SendToContacts.
+func (m Mail) ResolveContact(contact notify.Contact) (string, bool) {
+	return contact.Email, contact.Email != ""
+}
+
 // Send takes a message subject and a message body and sends them to all previously set chats. Message body supports
-// html as markup language.
+// html as markup language. Mails sent with the same thread key, see notify.ContextWithThreadKey, carry In-Reply-To and
+// References headers that make mail clients show them as one conversation. The locale of the notification, see
+// notify.ContextWithLocale, is set as Content-Language header. The receivers can be overridden per send, see
+// notify.WithReceiversOverride. Members of receiver groups are resolved before sending, see AddReceiverGroup.
 func (m Mail) Send(ctx context.Context, subject, message string) error {
-	msg := m.newEmail(subject, message)
+	return m.SendMessage(ctx, notify.Message{Subject: subject, Body: message})
+}
+
+// SupportsAttachments reports that mails carry the attachments of messages, see notify.AttachmentSender.
+func (m Mail) SupportsAttachments() bool {
+	return true
+}
+
+// SendMessage works like Send, but sends messages with an HTML body as HTML with a plain text alternative, appends the
+// card of the message as table and its actions as links and attaches its attachments.
+func (m Mail) SendMessage(ctx context.Context, message notify.Message) error {
+	msg, err := m.newMessageEmail(message)
+	if err != nil {
+		return err
+	}
+	if msg.To, err = m.receivers(ctx); err != nil {
+		return err
+	}
+	if key, ok := notify.ThreadKeyFromContext(ctx); ok {
+		id := m.threadMessageID(key)
+		msg.Headers.Set("In-Reply-To", id)
+		msg.Headers.Set("References", id)
+	}
+	if locale, ok := notify.LocaleFromContext(ctx); ok {
+		msg.Headers.Set("Content-Language", locale)
+	}
 
-	var err error
 	select {
 	case <-ctx.Done():
 		err = ctx.Err()
@@ -112,9 +287,32 @@ func (m Mail) Send(ctx context.Context, subject, message string) error {
 			err = msg.Send(m.smtpHostAddr, m.smtpAuth)
 		}
 		if err != nil {
-			err = errors.Wrap(err, "failed to send mail")
+			err = classifyError(errors.Wrap(err, "failed to send mail"))
 		}
 	}
 
 	return err
 }
+
+// classifyError classifies errors of the SMTP server by their reply code, and errors of the connection to it, see
+// notify.ClassifyError.
+func classifyError(err error) error {
+	var smtpErr *textproto.Error
+	if !errors.As(err, &smtpErr) {
+		return notify.ClassifyNetworkError(err)
+	}
+
+	switch {
+	case smtpErr.Code == 530, smtpErr.Code == 534, smtpErr.Code == 535, smtpErr.Code == 538:
+		return notify.ClassifyError(err, notify.ErrAuthFailed)
+	case smtpErr.Code == 550, smtpErr.Code == 551, smtpErr.Code == 553:
+		// The mailbox doesn't exist or isn't allowed.
+		return notify.ClassifyError(err, notify.ErrInvalidReceiver)
+	case smtpErr.Code >= 400 && smtpErr.Code < 500:
+		return notify.ClassifyError(err, notify.ErrTransient)
+	case smtpErr.Code >= 500:
+		return notify.ClassifyError(err, notify.ErrPermanent)
+	default:
+		return err
+	}
+}
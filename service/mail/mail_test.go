@@ -1,10 +1,19 @@
 package mail
 
 import (
+	"context"
 	"crypto/tls"
+	"errors"
+	"net"
+	"net/textproto"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/nikoksr/notify"
 )
 
 func TestMail_newEmailHtml(t *testing.T) {
@@ -79,3 +88,214 @@ func TestMail_UnSetTLS(t *testing.T) {
 	assert.False(t, m.useTLS)
 	assert.Nil(t, m.tlsConfig)
 }
+
+func TestMail_threadMessageID(t *testing.T) {
+	t.Parallel()
+
+	m := New("Alerts <alerts@example.com>", "server")
+	id := m.threadMessageID("incident-42")
+
+	assert.Equal(t, id, m.threadMessageID("incident-42"))
+	assert.NotEqual(t, id, m.threadMessageID("incident-43"))
+	assert.Regexp(t, `^<[0-9a-f]{32}@example\.com>$`, id)
+
+	m = New("foo", "server")
+	assert.Regexp(t, `^<[0-9a-f]{32}@notify>$`, m.threadMessageID("incident-42"))
+}
+
+func TestMail_newMessageEmail(t *testing.T) {
+	t.Parallel()
+
+	m := New("foo", "server")
+
+	// Without HTML body, the body is used as configured.
+	email, err := m.newMessageEmail(notify.Message{Subject: "test", Body: "<b>body</b>"})
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("<b>body</b>"), email.HTML)
+	assert.Equal(t, []byte(nil), email.Text)
+
+	// With HTML body, a plain text alternative is derived.
+	email, err = m.newMessageEmail(notify.Message{
+		Subject: "test",
+		HTML:    `<p>Disk <b>full</b></p>`,
+		Actions: []notify.Action{{Label: "Runbook", URL: "https://example.com/runbook?a=1&b=2"}},
+		Attachments: []notify.Attachment{
+			{Name: "df.txt", ContentType: "text/plain", Data: []byte("/dev/sda1 95%")},
+		},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, `<p>Disk <b>full</b></p><p><a href="https://example.com/runbook?a=1&amp;b=2">Runbook</a></p>`,
+		string(email.HTML))
+	assert.Equal(t, "Disk full\n\nRunbook: https://example.com/runbook?a=1&b=2", string(email.Text))
+	assert.Len(t, email.Attachments, 1)
+	assert.Equal(t, "df.txt", email.Attachments[0].Filename)
+
+	// An explicit plain text body takes precedence.
+	email, err = m.newMessageEmail(notify.Message{Subject: "test", Body: "plain", HTML: "<p>html</p>"})
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("plain"), email.Text)
+
+	// Cards are rendered as table.
+	email, err = m.newMessageEmail(notify.Message{
+		Subject: "test",
+		HTML:    "<p>Disk full</p>",
+		Card: &notify.Card{
+			Title:  "db-1",
+			Fields: []notify.CardField{{Name: "Usage", Value: "95%"}},
+			Color:  "#ff0000",
+			Footer: "monitoring",
+		},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, `<p>Disk full</p><table style="border-left:4px solid #ff0000;padding-left:8px">`+
+		`<tr><th colspan="2" style="text-align:left">db-1</th></tr>`+
+		`<tr><td><b>Usage</b></td><td>95%</td></tr>`+
+		`<tr><td colspan="2"><small>monitoring</small></td></tr></table>`, string(email.HTML))
+	assert.Equal(t, "Disk full\n\ndb-1\nUsage: 95%\nmonitoring", string(email.Text))
+}
+
+func TestMail_Sanitize(t *testing.T) {
+	t.Parallel()
+
+	m := New("foo", "server")
+	message := notify.Message{
+		Subject: "Reset\r\nBcc: victim@example.com",
+		Body:    `<p onclick="steal()">Hi<script>alert(1)</script></p>`,
+	}
+
+	sanitized := m.Sanitize(message)
+	assert.Equal(t, "Reset Bcc: victim@example.com", sanitized.Subject)
+	assert.Equal(t, "<p>Hi</p>", sanitized.Body)
+
+	// Plain text bodies are kept as they are.
+	m.BodyFormat(PlainText)
+	sanitized = m.Sanitize(message)
+	assert.Equal(t, message.Body, sanitized.Body)
+}
+
+func TestMail_AddReceiverGroup(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	members := map[string][]string{"cn=ops": {"alice@example.com", "Bob@example.com"}}
+	var lookups int
+	var lookupErr error
+	directory := DirectoryFunc(func(_ context.Context, group string) ([]string, error) {
+		lookups++
+		return members[group], lookupErr
+	})
+
+	m := New("foo", "server")
+	m.AddReceivers("bob@example.com")
+	m.AddReceiverGroup("cn=ops")
+
+	ctx := context.Background()
+	_, err := m.receivers(ctx)
+	assert.Error(t, err, "receiver groups without directory")
+
+	m.SetDirectory(directory, time.Hour)
+	m.groups.now = func() time.Time { return now }
+
+	receivers, err := m.receivers(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"bob@example.com", "alice@example.com"}, receivers)
+
+	// Members are cached for the refresh interval, and kept if the directory fails afterwards.
+	members["cn=ops"] = []string{"carol@example.com"}
+	receivers, _ = m.receivers(ctx)
+	assert.Equal(t, []string{"bob@example.com", "alice@example.com"}, receivers)
+	assert.Equal(t, 1, lookups)
+
+	now = now.Add(time.Hour)
+	receivers, _ = m.receivers(ctx)
+	assert.Equal(t, []string{"bob@example.com", "carol@example.com"}, receivers)
+
+	now = now.Add(time.Hour)
+	lookupErr = errors.New("directory unavailable")
+	receivers, err = m.receivers(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"bob@example.com", "carol@example.com"}, receivers)
+
+	// An override replaces receivers and groups.
+	ctx = notify.ContextWithSendOptions(ctx, notify.WithReceiversOverride("dave@example.com"))
+	receivers, _ = m.receivers(ctx)
+	assert.Equal(t, []string{"dave@example.com"}, receivers)
+}
+
+func TestMail_AddReceiverGroup_concurrent(t *testing.T) {
+	t.Parallel()
+
+	started, release := make(chan struct{}), make(chan struct{})
+	var lookups int32
+	directory := DirectoryFunc(func(context.Context, string) ([]string, error) {
+		if atomic.AddInt32(&lookups, 1) == 1 {
+			close(started)
+		}
+		<-release
+		return []string{"alice@example.com"}, nil
+	})
+
+	m := New("ops@example.com", "smtp.example.com:587")
+	m.SetDirectory(directory, time.Hour)
+	m.AddReceiverGroup("cn=ops")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			receivers, err := m.receivers(context.Background())
+			assert.NoError(t, err)
+			assert.Equal(t, []string{"alice@example.com"}, receivers)
+		}()
+	}
+
+	// The cache isn't locked while the directory is queried.
+	<-started
+	assert.NoError(t, m.Validate())
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&lookups))
+}
+
+func TestMail_Validate(t *testing.T) {
+	t.Parallel()
+
+	m := New("Ops <ops@example.com>", "smtp.example.com:587")
+	m.AddReceivers("alice@example.com")
+	assert.NoError(t, m.Validate())
+
+	m = New("ops", "smtp.example.com")
+	m.AddReceivers("alice")
+	m.AddReceiverGroup("cn=ops")
+	err := m.Validate()
+	assert.ErrorIs(t, err, notify.ErrInvalidConfig)
+	assert.ErrorContains(t, err, `sender: mail address "ops"`)
+	assert.ErrorContains(t, err, `SMTP server address "smtp.example.com" must have host and port`)
+	assert.ErrorContains(t, err, `receiver: mail address "alice"`)
+	assert.ErrorContains(t, err, "receiver groups require a directory")
+
+	m.SetDirectory(DirectoryFunc(func(context.Context, string) ([]string, error) { return nil, nil }), time.Hour)
+	assert.NotContains(t, m.Validate().Error(), "receiver groups")
+}
+
+func TestMail_classifyError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		err   error
+		class error
+	}{
+		{err: &textproto.Error{Code: 535, Msg: "Authentication credentials invalid"}, class: notify.ErrAuthFailed},
+		{err: &textproto.Error{Code: 550, Msg: "No such user here"}, class: notify.ErrInvalidReceiver},
+		{err: &textproto.Error{Code: 452, Msg: "Too many recipients"}, class: notify.ErrTransient},
+		{err: &textproto.Error{Code: 552, Msg: "Message size exceeds limit"}, class: notify.ErrPermanent},
+		{err: &net.OpError{Op: "dial", Err: errors.New("connection refused")}, class: notify.ErrTransient},
+	}
+
+	for _, tt := range tests {
+		assert.ErrorIs(t, classifyError(tt.err), tt.class, tt.err.Error())
+	}
+	assert.NotErrorIs(t, classifyError(&textproto.Error{Code: 452}), notify.ErrPermanent)
+}
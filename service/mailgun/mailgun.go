@@ -5,6 +5,8 @@ import (
 
 	"github.com/mailgun/mailgun-go/v4"
 	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
 )
 
 // Mailgun struct holds necessary data to communicate with the Mailgun API.
@@ -37,6 +39,20 @@ func (m *Mailgun) AddReceivers(addresses ...string) {
 	m.receiverAddresses = append(m.receiverAddresses, addresses...)
 }
 
+// Validate checks that domain and API key are set and that the sender and receiver addresses parse, see
+// notify.Validator.
+func (m Mailgun) Validate() error {
+	var problems notify.ConfigErrors
+	problems.Require("domain", m.client.Domain())
+	problems.Require("API key", m.client.APIKey())
+	problems.Check("sender", notify.ValidateMailAddress(m.senderAddress))
+	for _, address := range m.receiverAddresses {
+		problems.Check("receiver", notify.ValidateMailAddress(address))
+	}
+
+	return problems.Err()
+}
+
 // Send takes a message subject and a message body and sends them to all previously set chats. Message body supports
 // html as markup language.
 func (m Mailgun) Send(ctx context.Context, subject, message string) error {
@@ -44,8 +60,18 @@ func (m Mailgun) Send(ctx context.Context, subject, message string) error {
 
 	_, _, err := m.client.Send(ctx, mailMessage)
 	if err != nil {
-		return errors.Wrap(err, "failed to send mail using Mailgun service")
+		return classifyError(errors.Wrap(err, "failed to send mail using Mailgun service"))
 	}
 
 	return nil
 }
+
+// classifyError classifies errors returned by the Mailgun API by their HTTP status, see notify.ClassifyError.
+func classifyError(err error) error {
+	var respErr *mailgun.UnexpectedResponseError
+	if errors.As(err, &respErr) {
+		return notify.ClassifyStatus(respErr.Actual, err)
+	}
+
+	return notify.ClassifyNetworkError(err)
+}
@@ -0,0 +1,28 @@
+package mailgun
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nikoksr/notify"
+)
+
+func TestMailgun_Validate(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := New("mg.example.com", "key", "alerts@mg.example.com")
+	service.AddReceivers("alice@example.com")
+	assert.NoError(service.Validate())
+
+	service = New("", "", "alerts")
+	service.AddReceivers("alice")
+	err := service.Validate()
+	assert.ErrorIs(err, notify.ErrInvalidConfig)
+	assert.ErrorContains(err, "domain is missing")
+	assert.ErrorContains(err, "API key is missing")
+	assert.ErrorContains(err, `sender: mail address "alerts"`)
+	assert.ErrorContains(err, `receiver: mail address "alice"`)
+}
@@ -0,0 +1,35 @@
+/*
+Package mastodon provides a service for posting statuses and direct messages to Mastodon.
+
+Usage:
+
+	package main
+
+	import (
+	    "context"
+	    "log"
+
+	    "github.com/nikoksr/notify"
+	    "github.com/nikoksr/notify/service/mastodon"
+	)
+
+	func main() {
+	    mastodonService := mastodon.New("https://mastodon.social", "your-access-token")
+
+	    // Post unlisted statuses hidden behind a content warning.
+	    mastodonService.SetVisibility(mastodon.Unlisted)
+	    mastodonService.SetContentWarning("Server alert")
+
+	    // Or send direct messages instead of posting to the timeline.
+	    mastodonService.AddReceivers("@admin@mastodon.social")
+
+	    // Tell our notifier to use the mastodon service.
+	    notify.UseServices(mastodonService)
+
+	    // Send a test message.
+	    if err := notify.Send(context.Background(), "Subject/Title", "The actual message"); err != nil {
+	        log.Fatal(err)
+	    }
+	}
+*/
+package mastodon
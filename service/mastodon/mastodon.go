@@ -0,0 +1,158 @@
+package mastodon
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
+)
+
+// Visibility controls who can see a posted status.
+type Visibility string
+
+// Visibilities supported by Mastodon.
+const (
+	Public   Visibility = "public"
+	Unlisted Visibility = "unlisted"
+	Private  Visibility = "private"
+	Direct   Visibility = "direct"
+)
+
+// Service encapsulates the Mastodon client.
+type Service struct {
+	client         *http.Client
+	instanceURL    string
+	accessToken    string
+	visibility     Visibility
+	contentWarning string
+	receivers      []string
+}
+
+// New returns a new instance of a Mastodon notification service. The access token needs the write:statuses scope and
+// can be created in the development settings of the account on the given instance, e.g. https://mastodon.social.
+// For more information about the Mastodon API:
+//
+//	-> https://docs.joinmastodon.org/methods/statuses/#create
+func New(instanceURL, accessToken string) *Service {
+	return &Service{
+		client: &http.Client{
+			Transport: notify.NewRateLimitTransport(nil),
+			Timeout:   10 * time.Second,
+		},
+		instanceURL: strings.TrimSuffix(instanceURL, "/"),
+		accessToken: accessToken,
+		visibility:  Public,
+		receivers:   []string{},
+	}
+}
+
+// WithClient sets the http client to be used for sending requests. Calling this method is optional, the default client
+// will be used if this method is not called.
+func (s *Service) WithClient(client *http.Client) {
+	if client != nil {
+		s.client = client
+	}
+}
+
+// AddReceivers takes account addresses, e.g. "@user@mastodon.social", and adds them to the internal receiver list.
+// If receivers are set, every one of them gets its own status with direct visibility mentioning only them, instead of
+// a single status being posted to the account's timeline.
+func (s *Service) AddReceivers(accounts ...string) {
+	for _, account := range accounts {
+		if !strings.HasPrefix(account, "@") {
+			account = "@" + account
+		}
+		s.receivers = append(s.receivers, account)
+	}
+}
+
+// SetVisibility sets the visibility of statuses posted to the timeline. Defaults to Public. Statuses sent to receivers
+// always use Direct visibility.
+func (s *Service) SetVisibility(visibility Visibility) {
+	s.visibility = visibility
+}
+
+// SetContentWarning sets a content warning (spoiler text) that hides the status behind a click. Use an empty string
+// to disable it again.
+func (s *Service) SetContentWarning(text string) {
+	s.contentWarning = text
+}
+
+// status is the request body for creating a status.
+type status struct {
+	Status      string     `json:"status"`
+	Visibility  Visibility `json:"visibility"`
+	SpoilerText string     `json:"spoiler_text,omitempty"`
+	Sensitive   bool       `json:"sensitive,omitempty"`
+}
+
+func (s *Service) post(ctx context.Context, st *status) error {
+	payload, err := json.Marshal(st)
+	if err != nil {
+		return errors.Wrap(err, "marshal status")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.instanceURL+"/api/v1/statuses", bytes.NewReader(payload))
+	if err != nil {
+		return errors.Wrap(err, "create request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+s.accessToken)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "send request")
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return notify.ClassifyResponse(resp, fmt.Errorf("mastodon returned status code %d: %s", resp.StatusCode, string(body)))
+	}
+
+	return nil
+}
+
+// Send takes a message subject and a message body and posts them as status, either to the account's timeline or as
+// direct message to all previously set receivers.
+func (s *Service) Send(ctx context.Context, subject, message string) error {
+	text := subject + "\n\n" + message
+
+	if len(s.receivers) == 0 {
+		st := &status{
+			Status:      text,
+			Visibility:  s.visibility,
+			SpoilerText: s.contentWarning,
+			Sensitive:   s.contentWarning != "",
+		}
+
+		return errors.Wrap(s.post(ctx, st), "failed to post status")
+	}
+
+	for _, receiver := range s.receivers {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			st := &status{
+				Status:      receiver + " " + text,
+				Visibility:  Direct,
+				SpoilerText: s.contentWarning,
+				Sensitive:   s.contentWarning != "",
+			}
+			if err := s.post(ctx, st); err != nil {
+				return errors.Wrapf(err, "failed to send direct message to %q", receiver)
+			}
+		}
+	}
+
+	return nil
+}
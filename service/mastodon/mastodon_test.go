@@ -0,0 +1,69 @@
+package mastodon
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMastodon_AddReceivers(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := New("https://mastodon.social/", "token")
+	service.AddReceivers("@a@mastodon.social", "b@example.com")
+	assert.Equal("https://mastodon.social", service.instanceURL)
+	assert.Equal([]string{"@a@mastodon.social", "@b@example.com"}, service.receivers)
+}
+
+func TestMastodon_Send(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	var got []status
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var st status
+		_ = json.NewDecoder(r.Body).Decode(&st)
+		got = append(got, st)
+		_, _ = w.Write([]byte(`{"id":"1"}`))
+	}))
+	defer server.Close()
+
+	service := New(server.URL, "token")
+	service.SetVisibility(Unlisted)
+	service.SetContentWarning("cw")
+
+	err := service.Send(context.Background(), "subject", "message")
+	assert.Nil(err)
+	assert.Equal([]status{{
+		Status:      "subject\n\nmessage",
+		Visibility:  Unlisted,
+		SpoilerText: "cw",
+		Sensitive:   true,
+	}}, got)
+
+	got = nil
+	service.SetContentWarning("")
+	service.AddReceivers("@a@example.com", "@b@example.com")
+	err = service.Send(context.Background(), "subject", "message")
+	assert.Nil(err)
+	assert.Equal([]status{
+		{Status: "@a@example.com subject\n\nmessage", Visibility: Direct},
+		{Status: "@b@example.com subject\n\nmessage", Visibility: Direct},
+	}, got)
+
+	// Test error response
+	service = New(server.URL, "invalid")
+	err = service.Send(context.Background(), "subject", "message")
+	assert.NotNil(err)
+}
@@ -2,21 +2,39 @@ package matrix
 
 import (
 	"context"
-	"errors"
+	"strings"
+	"time"
 
+	"github.com/pkg/errors"
 	matrix "maunium.net/go/mautrix"
 	"maunium.net/go/mautrix/event"
 	"maunium.net/go/mautrix/id"
+
+	"github.com/nikoksr/notify"
 )
 
 //go:generate mockery --name=matrixClient --output=. --case=underscore --inpackage
 type matrixClient interface {
 	SendMessageEvent(roomID id.RoomID, eventType event.Type, contentJSON interface{}, extra ...matrix.ReqSendEvent) (resp *matrix.RespSendEvent, err error)
+	RedactEvent(roomID id.RoomID, eventID id.EventID, extra ...matrix.ReqRedact) (resp *matrix.RespSendEvent, err error)
 }
 
 // Compile time check to ensure that matrix.Client implements the matrixClient interface
 var _ matrixClient = new(matrix.Client)
 
+// Compile time check to ensure that Matrix implements the notify.Deleter interface
+var _ notify.Deleter = new(Matrix)
+
+// Compile time check to ensure that Matrix implements the notify.Limiter interface
+var _ notify.Limiter = new(Matrix)
+
+// Compile time check to ensure that Matrix implements the notify.Sanitizer interface
+var _ notify.Sanitizer = new(Matrix)
+
+// maxBodyLength is the maximum length of message bodies. Homeservers reject events larger than 64 KiB; with HTML the
+// body is sent twice, as formatted body and as fallback, and a character takes up to six bytes of JSON.
+const maxBodyLength = 5000
+
 // New returns a new instance of a Matrix notification service.
 // For more information about the Matrix api specs:
 //
@@ -35,31 +53,143 @@ func New(userID id.UserID, roomID id.RoomID, homeServer, accessToken string) (*M
 			userID:      userID,
 			roomID:      roomID,
 		},
+		roomIDs: []id.RoomID{roomID},
 	}
 	return s, nil
 }
 
-// Send takes a message body and sends them to the previously set channel.
+// AddReceivers takes additional room IDs and adds them to the internal room list. The Send method will send a given
+// message to all of those rooms. The room passed to New is always included.
+func (s *Matrix) AddReceivers(roomIDs ...id.RoomID) {
+	s.roomIDs = append(s.roomIDs, roomIDs...)
+}
+
+// UseHTML tells the service to treat message bodies as HTML. The HTML is sent as formatted body together with a
+// plaintext fallback, see notify.HTMLToText, which is what clients without HTML support display.
+func (s *Matrix) UseHTML(enabled bool) {
+	s.html = enabled
+}
+
+// Limits returns the maximum length of Matrix message bodies. The subject isn't sent.
+func (s *Matrix) Limits() notify.Limits {
+	return notify.Limits{Body: maxBodyLength}
+}
+
+// Validate checks that the home server URL is well-formed, that the access token is set, that the user ID parses and
+// that every room is a room ID, see notify.Validator.
+func (s *Matrix) Validate() error {
+	var problems notify.ConfigErrors
+	problems.Check("home server", notify.ValidateURL(s.options.homeServer))
+	problems.Require("access token", s.options.accessToken)
+	if _, _, err := s.options.userID.Parse(); err != nil {
+		problems.Check("user ID", err)
+	}
+	for _, roomID := range s.roomIDs {
+		if !strings.HasPrefix(string(roomID), "!") {
+			problems.Addf("room ID %q must start with !", roomID)
+		}
+	}
+
+	return problems.Err()
+}
+
+// roomMentions breaks the mention of @room with a zero width space.
+var roomMentions = strings.NewReplacer("@room", "@\u200broom")
+
+// Sanitize removes control characters from the message and breaks mentions of the whole room, see notify.Sanitizer.
+// With UseHTML, message bodies are HTML and stripped of scripts and other active content, see notify.SanitizeHTML.
+func (s *Matrix) Sanitize(message notify.Message) notify.Message {
+	sanitized := notify.SanitizeMessage(message, func(text string) string {
+		return roomMentions.Replace(notify.StripControlChars(text))
+	})
+	if s.html {
+		sanitized.Body = notify.SanitizeHTML(sanitized.Body)
+	}
+
+	return sanitized
+}
+
+// Send takes a message body and sends them to the previously set rooms.
 // you will need an account, access token and roomID
 // see https://matrix.org
+//
+// The room ID and event ID of each sent message are recorded as receipt, see notify.ContextWithReceipts.
+//
+// NOTE: Messages are always sent unencrypted. Sending to an end-to-end encrypted room works, but the messages will show
+// up as unencrypted in that room.
 func (s *Matrix) Send(ctx context.Context, _, message string) error {
 	messageBody := createMessage(message)
+	if s.html {
+		messageBody = createHTMLMessage(message)
+	}
 
-	select {
-	case <-ctx.Done():
-		return ctx.Err()
-	default:
-		_, err := s.client.SendMessageEvent(s.options.roomID, event.EventMessage, &messageBody)
-		if err != nil {
-			return errors.New("failed to send message to the room using Matrix")
+	for _, roomID := range s.roomIDs {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			resp, err := s.client.SendMessageEvent(roomID, event.EventMessage, &messageBody)
+			if err != nil {
+				return classifyError(errors.Wrapf(err, "failed to send message to the room %q using Matrix", roomID))
+			}
+
+			if resp != nil && resp.EventID != "" {
+				notify.RecordReceipt(ctx, notify.Receipt{Service: s, Receiver: roomID.String(), MessageID: resp.EventID.String()})
+			}
 		}
 	}
 	return nil
 }
 
+// Delete takes a receipt recorded by Send and redacts the sent message, which removes its content for all room members.
+func (s *Matrix) Delete(_ context.Context, receipt notify.Receipt) error {
+	_, err := s.client.RedactEvent(id.RoomID(receipt.Receiver), id.EventID(receipt.MessageID))
+	if err != nil {
+		err = errors.Wrapf(err, "failed to redact event %q in the room %q using Matrix", receipt.MessageID, receipt.Receiver)
+		return classifyError(err)
+	}
+
+	return nil
+}
+
 func createMessage(message string) Message {
 	return Message{
 		Body:    message,
 		Msgtype: event.MsgText,
 	}
 }
+
+func createHTMLMessage(message string) Message {
+	return Message{
+		Body:          notify.HTMLToText(message),
+		Format:        string(event.FormatHTML),
+		FormattedBody: message,
+		Msgtype:       event.MsgText,
+	}
+}
+
+// classifyError classifies errors returned by the Matrix homeserver by their error code and HTTP status, see
+// notify.ClassifyError.
+func classifyError(err error) error {
+	var httpErr matrix.HTTPError
+	if !errors.As(err, &httpErr) {
+		return notify.ClassifyNetworkError(err)
+	}
+
+	if httpErr.RespError != nil {
+		switch httpErr.RespError.ErrCode {
+		case matrix.MLimitExceeded.ErrCode:
+			retryAfter, _ := httpErr.RespError.ExtraData["retry_after_ms"].(float64)
+			return notify.ClassifyError(err, &notify.ErrRateLimited{RetryAfter: time.Duration(retryAfter) * time.Millisecond})
+		case matrix.MUnknownToken.ErrCode, matrix.MMissingToken.ErrCode:
+			return notify.ClassifyError(err, notify.ErrAuthFailed)
+		case matrix.MForbidden.ErrCode, matrix.MNotFound.ErrCode:
+			return notify.ClassifyError(err, notify.ErrInvalidReceiver)
+		}
+	}
+	if httpErr.Response != nil {
+		return notify.ClassifyResponse(httpErr.Response, err)
+	}
+
+	return notify.ClassifyNetworkError(err)
+}
@@ -9,6 +9,8 @@ import (
 	matrix "maunium.net/go/mautrix"
 	"maunium.net/go/mautrix/event"
 	"maunium.net/go/mautrix/id"
+
+	"github.com/nikoksr/notify"
 )
 
 func TestMatrix_New(t *testing.T) {
@@ -22,6 +24,40 @@ func TestMatrix_New(t *testing.T) {
 	assert.Equal("fake-access-token", service.options.accessToken)
 }
 
+func TestMatrix_Validate(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service, err := New("@bot:example.com", "!room:example.com", "https://matrix.example.com", "token")
+	assert.NoError(err)
+	assert.NoError(service.Validate())
+
+	service = &Matrix{
+		options: ServiceOptions{homeServer: "matrix.example.com", userID: "bot"},
+		roomIDs: []id.RoomID{"#ops:example.com"},
+	}
+	err = service.Validate()
+	assert.ErrorIs(err, notify.ErrInvalidConfig)
+	assert.ErrorContains(err, "home server")
+	assert.ErrorContains(err, "access token is missing")
+	assert.ErrorContains(err, "user ID")
+	assert.ErrorContains(err, `room ID "#ops:example.com" must start with !`)
+}
+
+func TestMatrix_Sanitize(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := &Matrix{}
+	message := notify.Message{Body: "@room <b>down</b><script>alert(1)</script>"}
+	assert.Equal("@\u200broom <b>down</b><script>alert(1)</script>", service.Sanitize(message).Body)
+
+	service.UseHTML(true)
+	assert.Equal("@\u200broom <b>down</b>", service.Sanitize(message).Body)
+}
+
 func TestService_Send(t *testing.T) {
 	t.Parallel()
 	assert := require.New(t)
@@ -47,3 +83,60 @@ func TestService_Send(t *testing.T) {
 	assert.NotNil(err)
 	mockClient.AssertExpectations(t)
 }
+
+func TestService_SendHTML(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+
+	expected := &Message{
+		Body:          "fake & message\n\nsee docs (https://example.com)",
+		Format:        string(event.FormatHTML),
+		FormattedBody: `<p><b>fake</b> &amp; message</p><p>see <a href="https://example.com">docs</a></p>`,
+		Msgtype:       event.MsgText,
+	}
+	mockClient := newMockMatrixClient(t)
+	mockClient.
+		On("SendMessageEvent", id.RoomID("fake-room-id"), event.EventMessage, expected).Return(&matrix.RespSendEvent{}, nil)
+	mockClient.
+		On("SendMessageEvent", id.RoomID("other-room-id"), event.EventMessage, expected).Return(&matrix.RespSendEvent{}, nil)
+
+	service, _ := New("fake-user-id", "fake-room-id", "fake-home-server", "fake-access-token")
+	service.client = mockClient
+	service.UseHTML(true)
+	service.AddReceivers("other-room-id")
+	err := service.Send(context.Background(), "", expected.FormattedBody)
+	assert.Nil(err)
+	mockClient.AssertExpectations(t)
+}
+
+func TestService_Delete(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+
+	mockClient := newMockMatrixClient(t)
+	mockClient.
+		On("SendMessageEvent", id.RoomID("fake-room-id"), event.EventMessage, &Message{Body: "fake-message", Msgtype: event.MsgText}).
+		Return(&matrix.RespSendEvent{EventID: "$fake-event-id"}, nil)
+	mockClient.
+		On("RedactEvent", id.RoomID("fake-room-id"), id.EventID("$fake-event-id")).
+		Return(&matrix.RespSendEvent{}, nil).
+		Once()
+	mockClient.
+		On("RedactEvent", id.RoomID("fake-room-id"), id.EventID("$fake-event-id")).
+		Return(nil, errors.New("some error"))
+
+	service, _ := New("fake-user-id", "fake-room-id", "fake-home-server", "fake-access-token")
+	service.client = mockClient
+
+	ctx, receipts := notify.ContextWithReceipts(context.Background())
+	err := service.Send(ctx, "", "fake-message")
+	assert.Nil(err)
+	assert.Equal([]notify.Receipt{{Service: service, Receiver: "fake-room-id", MessageID: "$fake-event-id"}}, receipts.All())
+
+	err = notify.Delete(ctx, receipts.All()[0])
+	assert.Nil(err)
+
+	// Test error on Delete
+	err = service.Delete(ctx, receipts.All()[0])
+	assert.NotNil(err)
+}
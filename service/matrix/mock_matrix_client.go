@@ -14,6 +14,36 @@ type mockMatrixClient struct {
 	mock.Mock
 }
 
+// RedactEvent provides a mock function with given fields: roomID, eventID, extra
+func (_m *mockMatrixClient) RedactEvent(roomID id.RoomID, eventID id.EventID, extra ...mautrix.ReqRedact) (*mautrix.RespSendEvent, error) {
+	_va := make([]interface{}, len(extra))
+	for _i := range extra {
+		_va[_i] = extra[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, roomID, eventID)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *mautrix.RespSendEvent
+	if rf, ok := ret.Get(0).(func(id.RoomID, id.EventID, ...mautrix.ReqRedact) *mautrix.RespSendEvent); ok {
+		r0 = rf(roomID, eventID, extra...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*mautrix.RespSendEvent)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(id.RoomID, id.EventID, ...mautrix.ReqRedact) error); ok {
+		r1 = rf(roomID, eventID, extra...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SendMessageEvent provides a mock function with given fields: roomID, eventType, contentJSON, extra
 func (_m *mockMatrixClient) SendMessageEvent(roomID id.RoomID, eventType event.Type, contentJSON interface{}, extra ...mautrix.ReqSendEvent) (*mautrix.RespSendEvent, error) {
 	_va := make([]interface{}, len(extra))
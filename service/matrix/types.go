@@ -25,4 +25,6 @@ type Message struct {
 type Matrix struct {
 	client  matrixClient
 	options ServiceOptions
+	roomIDs []id.RoomID
+	html    bool
 }
@@ -5,9 +5,11 @@ import (
 	"context"
 	"io"
 	stdhttp "net/http"
+	"strings"
 
 	"github.com/pkg/errors"
 
+	"github.com/nikoksr/notify"
 	"github.com/nikoksr/notify/service/http"
 )
 
@@ -24,18 +26,46 @@ type Service struct {
 	loginClient   httpClient
 	messageClient httpClient
 	channelIDs    map[string]bool
+	webhook       bool
+	markdown      bool
 }
 
 // New returns a new instance of a Mattermost notification service.
 func New(url string) *Service {
 	httpService := setupMsgService(url)
 	return &Service{
-		setupLoginService(url, httpService),
-		httpService,
-		make(map[string]bool),
+		loginClient:   setupLoginService(url, httpService),
+		messageClient: httpService,
+		channelIDs:    make(map[string]bool),
 	}
 }
 
+// NewWithWebhook returns a new instance of a Mattermost notification service that posts to an incoming webhook
+// instead of the REST API. No login is required in this mode. Receivers are optional and, if given, are channel names
+// that override the webhook's default channel; the webhook must be allowed to post to other channels for this to work.
+func NewWithWebhook(webhookURL string) *Service {
+	return &Service{
+		messageClient: setupWebhookService(webhookURL),
+		channelIDs:    make(map[string]bool),
+		webhook:       true,
+	}
+}
+
+// LoginWithToken provides helper for authentication using a bot account's or a user's personal access token. Unlike
+// LoginWithCredentials, no request is made to the server and the token does not expire.
+func (s *Service) LoginWithToken(token string) {
+	s.messageClient.PreSend(func(req *stdhttp.Request) error {
+		req.Header.Set("Authorization", "Bearer "+token)
+		return nil
+	})
+}
+
+// UseMarkdown tells the service to render the subject as a markdown heading above the message. Mattermost renders
+// markdown in the message body in either case.
+func (s *Service) UseMarkdown(enabled bool) {
+	s.markdown = enabled
+}
+
 // LoginWithCredentials provides helper for authentication using Mattermost user/admin credentials.
 func (s *Service) LoginWithCredentials(ctx context.Context, loginID, password string) error {
 	// request login
@@ -53,17 +83,55 @@ func (s *Service) AddReceivers(channelIDs ...string) {
 	}
 }
 
+// Validate checks that no channel is empty and, unless the service posts to a webhook, that at least one channel is
+// set, see notify.Validator.
+func (s *Service) Validate() error {
+	var problems notify.ConfigErrors
+	if !s.webhook && len(s.channelIDs) == 0 {
+		problems.Addf("no channel set")
+	}
+	if s.channelIDs[""] {
+		problems.Addf("channel ID is empty")
+	}
+
+	return problems.Err()
+}
+
+// massMentions breaks the mentions of @channel, @all and @here with a zero width space, as they aren't markdown.
+var massMentions = strings.NewReplacer("@channel", "@\u200bchannel", "@all", "@\u200ball", "@here", "@\u200bhere")
+
+// Sanitize escapes the markdown of the message, so that user provided content can neither format the message nor
+// mention the whole channel, see notify.Sanitizer.
+func (s *Service) Sanitize(message notify.Message) notify.Message {
+	return notify.SanitizeMessage(message, func(text string) string {
+		return massMentions.Replace(notify.EscapeMarkdown(text))
+	})
+}
+
 // Send takes a message subject and a message body and send them to added channel ids.
 // you will need a 'create_post' permission for your username.
 // refer https://api.mattermost.com/ for more info
 func (s *Service) Send(ctx context.Context, subject, message string) error {
+	text := subject + "\n" + message
+	if s.markdown {
+		text = "#### " + subject + "\n\n" + message
+	}
+
+	// Webhooks post to their default channel if no channel was given.
+	if s.webhook && len(s.channelIDs) == 0 {
+		if err := s.messageClient.Send(ctx, "", text); err != nil {
+			return errors.Wrapf(err, "failed to send message")
+		}
+		return nil
+	}
+
 	for id := range s.channelIDs {
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
 		default:
 			// create post
-			if err := s.messageClient.Send(ctx, id, subject+"\n"+message); err != nil {
+			if err := s.messageClient.Send(ctx, id, text); err != nil {
 				return errors.Wrapf(err, "failed to send message")
 			}
 		}
@@ -104,7 +172,38 @@ func setupMsgService(url string) *http.Service {
 	httpService.PostSend(func(req *stdhttp.Request, resp *stdhttp.Response) error {
 		if resp.StatusCode != stdhttp.StatusCreated {
 			b, _ := io.ReadAll(resp.Body)
-			return errors.New("failed to create post with status: " + resp.Status + " body: " + string(b))
+			err := errors.New("failed to create post with status: " + resp.Status + " body: " + string(b))
+			return notify.ClassifyResponse(resp, err)
+		}
+		return nil
+	})
+	return httpService
+}
+
+// setups webhook service for posting to an incoming webhook
+func setupWebhookService(url string) *http.Service {
+	httpService := http.New()
+
+	httpService.AddReceivers(&http.Webhook{
+		URL:         url,
+		Header:      stdhttp.Header{},
+		ContentType: "application/json",
+		Method:      stdhttp.MethodPost,
+		BuildPayload: func(channel, text string) (payload any) {
+			body := map[string]string{"text": text}
+			if channel != "" {
+				body["channel"] = channel
+			}
+			return body
+		},
+	})
+
+	// add post-send hook for error checks
+	httpService.PostSend(func(req *stdhttp.Request, resp *stdhttp.Response) error {
+		if resp.StatusCode != stdhttp.StatusOK {
+			b, _ := io.ReadAll(resp.Body)
+			err := errors.New("failed to post to webhook with status: " + resp.Status + " body: " + string(b))
+			return notify.ClassifyResponse(resp, err)
 		}
 		return nil
 	})
@@ -135,7 +234,8 @@ func setupLoginService(url string, msgService *http.Service) *http.Service {
 	httpService.PostSend(func(req *stdhttp.Request, resp *stdhttp.Response) error {
 		if resp.StatusCode != stdhttp.StatusOK {
 			b, _ := io.ReadAll(resp.Body)
-			return errors.New("login failed with status: " + resp.Status + " body: " + string(b))
+			err := errors.New("login failed with status: " + resp.Status + " body: " + string(b))
+			return notify.ClassifyResponse(resp, err)
 		}
 
 		// get token from header
@@ -9,6 +9,8 @@ import (
 
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+
+	"github.com/nikoksr/notify"
 )
 
 const url = "https://host.mattermost.com"
@@ -71,6 +73,37 @@ func TestService_AddReceivers(t *testing.T) {
 	assert.Equal(service.channelIDs, hooksMap)
 }
 
+func TestService_Validate(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := New("https://mattermost.example.com")
+	service.AddReceivers("town-square")
+	assert.NoError(service.Validate())
+	assert.NoError(NewWithWebhook("https://mattermost.example.com/hooks/abc").Validate())
+
+	err := New("https://mattermost.example.com").Validate()
+	assert.ErrorIs(err, notify.ErrInvalidConfig)
+	assert.ErrorContains(err, "no channel set")
+
+	service.AddReceivers("")
+	assert.ErrorContains(service.Validate(), "channel ID is empty")
+}
+
+func TestService_Sanitize(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	sanitized := New("https://mattermost.example.com").Sanitize(notify.Message{
+		Subject: "**Alert**",
+		Body:    "@channel see [this](https://evil.example.com)",
+	})
+	assert.Equal(`\*\*Alert\*\*`, sanitized.Subject)
+	assert.Equal("@\u200bchannel see \\[this\\](https://evil.example.com)", sanitized.Body)
+}
+
 func TestService_Send(t *testing.T) {
 	t.Parallel()
 	assert := require.New(t)
@@ -142,3 +175,43 @@ func TestService_PostSend(t *testing.T) {
 	assert.True(mockClient.AssertCalled(t, "PostSend", mock.AnythingOfType("http.PostSendHookFn")))
 	mockClient.AssertExpectations(t)
 }
+
+func TestService_SendWebhook(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+
+	service := NewWithWebhook(url + "/hooks/xxx")
+	assert.NotNil(service)
+	service.UseMarkdown(true)
+
+	// Without receivers the message is posted to the webhook's default channel
+	mockClient := newMockHttpClient(t)
+	mockClient.
+		On("Send", context.TODO(), "", "#### fake-sub\n\nfake-msg").Return(nil)
+	service.messageClient = mockClient
+	err := service.Send(context.TODO(), "fake-sub", "fake-msg")
+	assert.Nil(err)
+	mockClient.AssertExpectations(t)
+
+	// With receivers the default channel gets overridden
+	mockClient = newMockHttpClient(t)
+	mockClient.
+		On("Send", context.TODO(), "town-square", "#### fake-sub\n\nfake-msg").Return(nil)
+	service.messageClient = mockClient
+	service.AddReceivers("town-square")
+	err = service.Send(context.TODO(), "fake-sub", "fake-msg")
+	assert.Nil(err)
+	mockClient.AssertExpectations(t)
+}
+
+func TestService_LoginWithToken(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+
+	service := New(url)
+	mockClient := newMockHttpClient(t)
+	mockClient.On("PreSend", mock.AnythingOfType("http.PreSendHookFn"))
+	service.messageClient = mockClient
+	service.LoginWithToken("fake-token")
+	assert.True(mockClient.AssertCalled(t, "PreSend", mock.AnythingOfType("http.PreSendHookFn")))
+}
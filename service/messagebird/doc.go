@@ -0,0 +1,34 @@
+/*
+Package messagebird provides a service for sending SMS via MessageBird.
+
+Usage:
+
+	package main
+
+	import (
+	    "context"
+	    "log"
+
+	    "github.com/nikoksr/notify"
+	    "github.com/nikoksr/notify/service/messagebird"
+	)
+
+	func main() {
+	    messageBirdService, err := messagebird.New("your-access-key", "Notify")
+	    if err != nil {
+	        log.Fatal(err)
+	    }
+
+	    // Send to these phone numbers.
+	    messageBirdService.AddReceivers("+31612345678", "+31687654321")
+
+	    // Tell our notifier to use the messagebird service.
+	    notify.UseServices(messageBirdService)
+
+	    // Send a test message.
+	    if err := notify.Send(context.Background(), "Subject/Title", "The actual message"); err != nil {
+	        log.Fatal(err)
+	    }
+	}
+*/
+package messagebird
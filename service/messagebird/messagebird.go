@@ -0,0 +1,169 @@
+package messagebird
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
+)
+
+const (
+	defaultBaseURL = "https://rest.messagebird.com"
+
+	// maxRecipients is the maximum number of recipients MessageBird accepts per message request.
+	maxRecipients = 50
+)
+
+// originatorPattern matches valid originators: a phone number of up to 17 digits or an alphanumeric string of up to
+// 11 characters.
+var originatorPattern = regexp.MustCompile(`^(\+?[0-9]{1,17}|[a-zA-Z0-9 ]{1,11})$`)
+
+// Service encapsulates the MessageBird client.
+type Service struct {
+	client     *http.Client
+	baseURL    string
+	accessKey  string
+	originator string
+	receivers  []string
+}
+
+// New returns a new instance of a MessageBird notification service. The access key can be found in the developer
+// section of the MessageBird dashboard. The originator is the sender of the messages, either a phone number or an
+// alphanumeric string of up to 11 characters.
+// For more information about the MessageBird SMS API:
+//
+//	-> https://developers.messagebird.com/api/sms-messaging/#send-outbound-sms
+func New(accessKey, originator string) (*Service, error) {
+	if !originatorPattern.MatchString(originator) {
+		return nil, fmt.Errorf("invalid originator %q: must be a phone number or up to 11 alphanumeric characters", originator)
+	}
+
+	return &Service{
+		client: &http.Client{
+			Transport: notify.NewRateLimitTransport(nil),
+			Timeout:   10 * time.Second,
+		},
+		baseURL:    defaultBaseURL,
+		accessKey:  accessKey,
+		originator: originator,
+		receivers:  []string{},
+	}, nil
+}
+
+// WithClient sets the http client to be used for sending requests. Calling this method is optional, the default client
+// will be used if this method is not called.
+func (s *Service) WithClient(client *http.Client) {
+	if client != nil {
+		s.client = client
+	}
+}
+
+// AddReceivers takes phone numbers in international format and adds them to the internal receiver list. The Send
+// method will send a given message to all those numbers.
+func (s *Service) AddReceivers(phoneNumbers ...string) {
+	s.receivers = append(s.receivers, phoneNumbers...)
+}
+
+type message struct {
+	Originator string   `json:"originator"`
+	Recipients []string `json:"recipients"`
+	Body       string   `json:"body"`
+}
+
+type errorResponse struct {
+	Errors []struct {
+		Code        int    `json:"code"`
+		Description string `json:"description"`
+	} `json:"errors"`
+}
+
+// Validate checks that access key and originator are set and that the receivers are phone numbers in international
+// format, see notify.Validator.
+func (s *Service) Validate() error {
+	var problems notify.ConfigErrors
+	problems.Require("access key", s.accessKey)
+	problems.Require("originator", s.originator)
+	for _, number := range s.receivers {
+		problems.Check("receiver", notify.ValidatePhoneNumber("+"+strings.TrimPrefix(number, "+")))
+	}
+
+	return problems.Err()
+}
+
+// ResolveContact returns the phone number of the contact, see notify.SendToContacts.
+func (s *Service) ResolveContact(contact notify.Contact) (string, bool) {
+	return contact.Phone, contact.Phone != ""
+}
+
+// Send takes a message subject and a message body and sends them to all previously set phone numbers. Subject and
+// message are joined by a newline. Recipients are sent in batches of up to 50 per request. The phone numbers can be
+// overridden per send, see notify.WithReceiversOverride.
+func (s *Service) Send(ctx context.Context, subject, msg string) error {
+	receivers := notify.ReceiversFromContext(ctx, s.receivers)
+	body := subject + "\n" + msg
+
+	for start := 0; start < len(receivers); start += maxRecipients {
+		end := start + maxRecipients
+		if end > len(receivers) {
+			end = len(receivers)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			if err := s.send(ctx, message{
+				Originator: s.originator,
+				Recipients: receivers[start:end],
+				Body:       body,
+			}); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) send(ctx context.Context, msg message) error {
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal message")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.baseURL+"/messages", bytes.NewReader(payload))
+	if err != nil {
+		return errors.Wrap(err, "failed to create request")
+	}
+	req.Header.Set("Authorization", "AccessKey "+s.accessKey)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "failed to send message")
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusCreated {
+		b, _ := io.ReadAll(resp.Body)
+
+		var errResp errorResponse
+		if json.Unmarshal(b, &errResp) == nil && len(errResp.Errors) > 0 {
+			return fmt.Errorf("failed to send message: %s (code %d)", errResp.Errors[0].Description, errResp.Errors[0].Code)
+		}
+
+		return notify.ClassifyResponse(resp, fmt.Errorf("failed to send message: status %d: %s", resp.StatusCode, string(b)))
+	}
+
+	return nil
+}
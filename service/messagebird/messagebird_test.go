@@ -0,0 +1,91 @@
+package messagebird
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nikoksr/notify"
+)
+
+func TestMessageBird_New(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	for _, originator := range []string{"Notify", "+31612345678", "31612345678"} {
+		_, err := New("key", originator)
+		assert.NoError(err, originator)
+	}
+	for _, originator := range []string{"", "VeryLongSender", "Not-valid"} {
+		_, err := New("key", originator)
+		assert.Error(err, originator)
+	}
+}
+
+func TestMessageBird_Validate(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service, err := New("key", "Notify")
+	assert.NoError(err)
+	service.AddReceivers("31612345678", "+31612345679")
+	assert.NoError(service.Validate())
+
+	service.accessKey = ""
+	service.AddReceivers("0612345678")
+	err = service.Validate()
+	assert.ErrorIs(err, notify.ErrInvalidConfig)
+	assert.ErrorContains(err, "access key is missing")
+	assert.ErrorContains(err, "receiver")
+}
+
+func TestMessageBird_Send(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	var got []message
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "AccessKey key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"errors":[{"code":2,"description":"Request not allowed (incorrect access_key)"}]}`))
+			return
+		}
+		var msg message
+		_ = json.NewDecoder(r.Body).Decode(&msg)
+		got = append(got, msg)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	service, err := New("key", "Notify")
+	assert.NoError(err)
+	service.baseURL = server.URL
+
+	// No receivers added
+	assert.NoError(service.Send(context.Background(), "subject", "message"))
+	assert.Empty(got)
+
+	for i := 0; i < 120; i++ {
+		service.AddReceivers(fmt.Sprintf("+31600000%03d", i))
+	}
+	assert.NoError(service.Send(context.Background(), "subject", "message"))
+	assert.Len(got, 3)
+	assert.Len(got[0].Recipients, 50)
+	assert.Len(got[1].Recipients, 50)
+	assert.Len(got[2].Recipients, 20)
+	assert.Equal("Notify", got[2].Originator)
+	assert.Equal("subject\nmessage", got[2].Body)
+
+	// Test error response
+	service.accessKey = "invalid"
+	err = service.Send(context.Background(), "subject", "message")
+	assert.ErrorContains(err, "incorrect access_key")
+}
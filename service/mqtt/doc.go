@@ -0,0 +1,39 @@
+/*
+Package mqtt provides a service for publishing notifications to MQTT topics.
+
+Every notification is published as JSON document with the fields "subject", "message" and "timestamp".
+
+Usage:
+
+	package main
+
+	import (
+	    "context"
+	    "log"
+
+	    "github.com/nikoksr/notify"
+	    "github.com/nikoksr/notify/service/mqtt"
+	)
+
+	func main() {
+	    mqttService := mqtt.New("ssl://broker.example.com:8883", "notify-client")
+	    mqttService.SetCredentials("username", "password")
+	    mqttService.SetRetained(true)
+	    if err := mqttService.SetQoS(1); err != nil {
+	        log.Fatal(err)
+	    }
+	    defer func() { _ = mqttService.Close() }()
+
+	    // Publish to these topics.
+	    mqttService.AddReceivers("home/alerts")
+
+	    // Tell our notifier to use the mqtt service.
+	    notify.UseServices(mqttService)
+
+	    // Send a test message.
+	    if err := notify.Send(context.Background(), "Subject/Title", "The actual message"); err != nil {
+	        log.Fatal(err)
+	    }
+	}
+*/
+package mqtt
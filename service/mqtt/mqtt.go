@@ -0,0 +1,200 @@
+package mqtt
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+	"github.com/eclipse/paho.mqtt.golang/packets"
+	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
+)
+
+// mqttClient abstracts the paho MQTT client for writing unit tests.
+//
+//go:generate mockery --name=mqttClient --output=. --case=underscore --inpackage
+type mqttClient interface {
+	Connect() mqtt.Token
+	Publish(topic string, qos byte, retained bool, payload interface{}) mqtt.Token
+	Disconnect(quiesce uint)
+}
+
+// Compile-time check to ensure that mqtt.Client implements the mqttClient interface.
+var _ mqttClient = mqtt.Client(nil)
+
+// Service encapsulates the MQTT client.
+type Service struct {
+	options  *mqtt.ClientOptions
+	qos      byte
+	retained bool
+	topics   []string
+
+	mu     sync.Mutex
+	client mqttClient
+}
+
+// payload is the JSON document published for every notification.
+type payload struct {
+	Subject   string    `json:"subject"`
+	Message   string    `json:"message"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// New returns a new instance of an MQTT notification service that publishes to the given broker, e.g.
+// "tcp://broker.example.com:1883", "ssl://broker.example.com:8883" or "ws://broker.example.com:8080/mqtt". The client
+// connects on the first Send and stays connected, reconnecting automatically, until Close is called.
+func New(brokerURL, clientID string) *Service {
+	options := mqtt.NewClientOptions().
+		AddBroker(brokerURL).
+		SetClientID(clientID).
+		SetConnectTimeout(10 * time.Second).
+		SetAutoReconnect(true)
+
+	return &Service{
+		options: options,
+		topics:  []string{},
+	}
+}
+
+// SetCredentials sets the username and password used to authenticate with the broker.
+func (s *Service) SetCredentials(username, password string) {
+	s.options.SetUsername(username).SetPassword(password)
+}
+
+// SetTLS sets the TLS configuration used for ssl:// and wss:// brokers, e.g. for client certificates.
+func (s *Service) SetTLS(cfg *tls.Config) {
+	s.options.SetTLSConfig(cfg)
+}
+
+// SetQoS sets the quality of service level (0, 1 or 2) of published messages. Defaults to 0.
+func (s *Service) SetQoS(qos byte) error {
+	if qos > 2 {
+		return errors.Errorf("invalid QoS level %d", qos)
+	}
+	s.qos = qos
+
+	return nil
+}
+
+// SetRetained sets whether the broker retains the last message on each topic for new subscribers.
+func (s *Service) SetRetained(retained bool) {
+	s.retained = retained
+}
+
+// AddReceivers takes topics and adds them to the internal receiver list. The Send method publishes to all of them.
+func (s *Service) AddReceivers(topics ...string) {
+	s.topics = append(s.topics, topics...)
+}
+
+// wait blocks until the token completes or the context is done.
+func wait(ctx context.Context, token mqtt.Token) error {
+	select {
+	case <-token.Done():
+		return token.Error()
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// connect returns the connected client, connecting it first if necessary.
+func (s *Service) connect(ctx context.Context) (mqttClient, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.client != nil {
+		return s.client, nil
+	}
+
+	client := mqtt.NewClient(s.options)
+	if err := wait(ctx, client.Connect()); err != nil {
+		return nil, err
+	}
+	s.client = client
+
+	return client, nil
+}
+
+// Validate checks that the broker URL parses, that the client ID is set and that at least one topic is set and none is
+// empty or contains wildcards, see notify.Validator.
+func (s *Service) Validate() error {
+	var problems notify.ConfigErrors
+	if len(s.options.Servers) == 0 {
+		problems.Addf("broker URL is missing or invalid")
+	}
+	for _, server := range s.options.Servers {
+		if server.Host == "" {
+			problems.Addf("broker URL has no host")
+		}
+	}
+	problems.Require("client ID", s.options.ClientID)
+	if len(s.topics) == 0 {
+		problems.Addf("no topic set")
+	}
+	for _, topic := range s.topics {
+		switch {
+		case topic == "":
+			problems.Addf("topic is empty")
+		case strings.ContainsAny(topic, "+#"):
+			problems.Addf("topic %q contains wildcards", topic)
+		}
+	}
+
+	return problems.Err()
+}
+
+// Close disconnects from the broker, waiting up to one second for in-flight messages. The next Send reconnects.
+func (s *Service) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.client != nil {
+		s.client.Disconnect(1000)
+		s.client = nil
+	}
+
+	return nil
+}
+
+// Send takes a message subject and a message body and publishes them as JSON document to all previously set topics.
+func (s *Service) Send(ctx context.Context, subject, message string) error {
+	if len(s.topics) == 0 {
+		return nil
+	}
+
+	data, err := json.Marshal(&payload{Subject: subject, Message: message, Timestamp: time.Now().UTC()})
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal payload")
+	}
+
+	client, err := s.connect(ctx)
+	if err != nil {
+		return classifyError(errors.Wrap(err, "failed to connect to broker"))
+	}
+
+	for _, topic := range s.topics {
+		if err = wait(ctx, client.Publish(topic, s.qos, s.retained, data)); err != nil {
+			return classifyError(errors.Wrapf(err, "failed to publish to topic %q", topic))
+		}
+	}
+
+	return nil
+}
+
+// classifyError classifies errors of the broker and the connection to it, see notify.ClassifyError.
+func classifyError(err error) error {
+	switch {
+	case errors.Is(err, packets.ErrorRefusedBadUsernameOrPassword), errors.Is(err, packets.ErrorRefusedNotAuthorised):
+		return notify.ClassifyError(err, notify.ErrAuthFailed)
+	case errors.Is(err, packets.ErrorRefusedServerUnavailable), errors.Is(err, mqtt.ErrNotConnected):
+		return notify.ClassifyError(err, notify.ErrTransient)
+	case errors.Is(err, packets.ErrorRefusedBadProtocolVersion), errors.Is(err, packets.ErrorRefusedIDRejected):
+		return notify.ClassifyError(err, notify.ErrPermanent)
+	default:
+		return notify.ClassifyNetworkError(err)
+	}
+}
@@ -0,0 +1,105 @@
+package mqtt
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nikoksr/notify"
+)
+
+// token is a completed mqtt.Token.
+type token struct {
+	err error
+}
+
+func (t *token) Wait() bool                     { return true }
+func (t *token) WaitTimeout(time.Duration) bool { return true }
+func (t *token) Error() error                   { return t.err }
+
+func (t *token) Done() <-chan struct{} {
+	done := make(chan struct{})
+	close(done)
+	return done
+}
+
+func TestMQTT_SetQoS(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := New("tcp://localhost:1883", "notify")
+	assert.NoError(service.SetQoS(2))
+	assert.Equal(byte(2), service.qos)
+	assert.Error(service.SetQoS(3))
+}
+
+func TestMQTT_Validate(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := New("tcp://broker.example.com:1883", "notify")
+	service.AddReceivers("alerts/ops")
+	assert.NoError(service.Validate())
+
+	service = New("", "")
+	service.AddReceivers("alerts/#")
+	err := service.Validate()
+	assert.ErrorIs(err, notify.ErrInvalidConfig)
+	assert.ErrorContains(err, "broker URL has no host")
+	assert.ErrorContains(err, "client ID is missing")
+	assert.ErrorContains(err, `topic "alerts/#" contains wildcards`)
+
+	assert.ErrorContains(New("tcp://broker.example.com:1883", "notify").Validate(), "no topic set")
+}
+
+func TestMQTT_Send(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+	ctx := context.Background()
+
+	service := New("tcp://localhost:1883", "notify")
+
+	// No receivers added
+	err := service.Send(ctx, "subject", "message")
+	assert.NoError(err)
+
+	// Test publishing successfully
+	var published payload
+	mockClient := newMockMqttClient(t)
+	mockClient.
+		On("Publish", "alerts", byte(1), true, mock.AnythingOfType("[]uint8")).
+		Run(func(args mock.Arguments) { _ = json.Unmarshal(args.Get(3).([]byte), &published) }).
+		Return(&token{})
+
+	service.client = mockClient
+	service.AddReceivers("alerts")
+	assert.NoError(service.SetQoS(1))
+	service.SetRetained(true)
+	err = service.Send(ctx, "subject", "message")
+	assert.NoError(err)
+	assert.Equal("subject", published.Subject)
+	assert.Equal("message", published.Message)
+
+	// Test publishing with error
+	mockClient = newMockMqttClient(t)
+	mockClient.
+		On("Publish", "alerts", byte(1), true, mock.Anything).
+		Return(&token{err: mqtt.ErrNotConnected})
+
+	service.client = mockClient
+	err = service.Send(ctx, "subject", "message")
+	assert.ErrorIs(err, notify.ErrTransient)
+
+	// Test closing
+	mockClient.On("Disconnect", uint(1000)).Return()
+	assert.NoError(service.Close())
+	assert.Nil(service.client)
+}
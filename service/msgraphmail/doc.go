@@ -0,0 +1,37 @@
+/*
+Package msgraphmail provides a service for sending emails through the Microsoft Graph sendMail API.
+
+Unlike SMTP, the Graph API keeps working in Microsoft 365 tenants that have disabled SMTP AUTH.
+
+Usage:
+
+	package main
+
+	import (
+	    "context"
+	    "log"
+
+	    "github.com/nikoksr/notify"
+	    "github.com/nikoksr/notify/service/msgraphmail"
+	)
+
+	func main() {
+	    // The app registration requires the Mail.Send application permission.
+	    mailService := msgraphmail.New("your-tenant-id", "your-client-id", "your-client-secret", "alerts@example.com")
+
+	    // Add email addresses.
+	    mailService.AddReceivers("oncall@example.com")
+
+	    // Send HTML instead of plain text.
+	    mailService.BodyFormat(true)
+
+	    // Tell our notifier to use the graph mail service.
+	    notify.UseServices(mailService)
+
+	    // Send a test message.
+	    if err := notify.Send(context.Background(), "Subject/Title", "<p>The actual message - Hello, you awesome gophers! :)</p>"); err != nil {
+	        log.Fatal(err)
+	    }
+	}
+*/
+package msgraphmail
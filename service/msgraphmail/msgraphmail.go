@@ -0,0 +1,219 @@
+package msgraphmail
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+
+	"github.com/nikoksr/notify"
+)
+
+const (
+	defaultGraphURL = "https://graph.microsoft.com/v1.0"
+	graphScope      = "https://graph.microsoft.com/.default"
+)
+
+// Service encapsulates the Microsoft Graph mail client.
+type Service struct {
+	client        *http.Client
+	graphURL      string
+	senderAddress string
+	receivers     []string
+	ccReceivers   []string
+	useHTML       bool
+	saveToSent    bool
+	oauthConfig   *clientcredentials.Config
+
+	mu          sync.Mutex
+	tokenSource oauth2.TokenSource
+}
+
+// New returns a new instance of a Microsoft Graph mail notification service. Tenant ID, client ID and client secret
+// belong to an app registration with the Mail.Send application permission. The sender address is the mailbox mails are
+// sent from; consider restricting the app to that mailbox with an application access policy.
+// For more information about sending mail with Microsoft Graph:
+//
+//	-> https://learn.microsoft.com/graph/api/user-sendmail
+func New(tenantID, clientID, clientSecret, senderAddress string) *Service {
+	return &Service{
+		client: &http.Client{
+			Transport: notify.NewRateLimitTransport(nil),
+			Timeout:   10 * time.Second,
+		},
+		graphURL:      defaultGraphURL,
+		senderAddress: senderAddress,
+		receivers:     []string{},
+		ccReceivers:   []string{},
+		saveToSent:    true,
+		oauthConfig: &clientcredentials.Config{
+			ClientID:     clientID,
+			ClientSecret: clientSecret,
+			TokenURL:     fmt.Sprintf("https://login.microsoftonline.com/%s/oauth2/v2.0/token", url.PathEscape(tenantID)),
+			Scopes:       []string{graphScope},
+		},
+	}
+}
+
+// WithClient sets the http client to be used for sending requests. Calling this method is optional, the default client
+// will be used if this method is not called.
+func (s *Service) WithClient(client *http.Client) {
+	if client != nil {
+		s.mu.Lock()
+		s.client = client
+		s.tokenSource = nil
+		s.mu.Unlock()
+	}
+}
+
+// AddReceivers takes email addresses and adds them to the internal address list. The Send method will send a given
+// message to all those addresses.
+func (s *Service) AddReceivers(addresses ...string) {
+	s.receivers = append(s.receivers, addresses...)
+}
+
+// AddCCReceivers takes email addresses and adds them to the carbon copy recipients of all messages.
+func (s *Service) AddCCReceivers(addresses ...string) {
+	s.ccReceivers = append(s.ccReceivers, addresses...)
+}
+
+// BodyFormat sets the format of the message body. Pass true to send the message as HTML instead of plain text.
+func (s *Service) BodyFormat(html bool) {
+	s.useHTML = html
+}
+
+// SaveToSentItems sets whether sent messages are saved in the sender's Sent Items folder. Defaults to true.
+func (s *Service) SaveToSentItems(save bool) {
+	s.saveToSent = save
+}
+
+// Validate checks that the client credentials are set and that the sender, receiver and carbon copy addresses parse,
+// see notify.Validator.
+func (s *Service) Validate() error {
+	var problems notify.ConfigErrors
+	problems.Require("client ID", s.oauthConfig.ClientID)
+	problems.Require("client secret", s.oauthConfig.ClientSecret)
+	problems.Check("Graph URL", notify.ValidateURL(s.graphURL))
+	problems.Check("sender", notify.ValidateMailAddress(s.senderAddress))
+	for _, address := range s.receivers {
+		problems.Check("receiver", notify.ValidateMailAddress(address))
+	}
+	for _, address := range s.ccReceivers {
+		problems.Check("CC receiver", notify.ValidateMailAddress(address))
+	}
+
+	return problems.Err()
+}
+
+// token returns a cached access token or requests a new one if there is none or it expired.
+func (s *Service) token() (*oauth2.Token, error) {
+	s.mu.Lock()
+	if s.tokenSource == nil {
+		// The token source outlives single requests, so it must not be bound to a request context.
+		ctx := context.WithValue(context.Background(), oauth2.HTTPClient, s.client)
+		s.tokenSource = s.oauthConfig.TokenSource(ctx)
+	}
+	tokenSource := s.tokenSource
+	s.mu.Unlock()
+
+	return tokenSource.Token()
+}
+
+type emailAddress struct {
+	Address string `json:"address"`
+}
+
+type recipient struct {
+	EmailAddress emailAddress `json:"emailAddress"`
+}
+
+type itemBody struct {
+	ContentType string `json:"contentType"`
+	Content     string `json:"content"`
+}
+
+type message struct {
+	Subject      string      `json:"subject"`
+	Body         itemBody    `json:"body"`
+	ToRecipients []recipient `json:"toRecipients"`
+	CCRecipients []recipient `json:"ccRecipients,omitempty"`
+}
+
+type sendMailRequest struct {
+	Message         message `json:"message"`
+	SaveToSentItems bool    `json:"saveToSentItems"`
+}
+
+func toRecipients(addresses []string) []recipient {
+	recipients := make([]recipient, 0, len(addresses))
+	for _, address := range addresses {
+		recipients = append(recipients, recipient{EmailAddress: emailAddress{Address: address}})
+	}
+
+	return recipients
+}
+
+// Send takes a message subject and a message body and sends them as a single email to all previously set email
+// addresses.
+func (s *Service) Send(ctx context.Context, subject, msg string) error {
+	if len(s.receivers) == 0 {
+		return nil
+	}
+
+	contentType := "Text"
+	if s.useHTML {
+		contentType = "HTML"
+	}
+
+	payload := sendMailRequest{
+		Message: message{
+			Subject:      subject,
+			Body:         itemBody{ContentType: contentType, Content: msg},
+			ToRecipients: toRecipients(s.receivers),
+		},
+		SaveToSentItems: s.saveToSent,
+	}
+	if len(s.ccReceivers) > 0 {
+		payload.Message.CCRecipients = toRecipients(s.ccReceivers)
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal message")
+	}
+
+	token, err := s.token()
+	if err != nil {
+		return errors.Wrap(err, "failed to get access token")
+	}
+
+	endpoint := fmt.Sprintf("%s/users/%s/sendMail", s.graphURL, url.PathEscape(s.senderAddress))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "failed to create request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	token.SetAuthHeader(req)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "failed to send mail via Microsoft Graph")
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusAccepted {
+		result, _ := io.ReadAll(resp.Body)
+		return notify.ClassifyResponse(resp, fmt.Errorf("microsoft graph returned status code %d: %s", resp.StatusCode, string(result)))
+	}
+
+	return nil
+}
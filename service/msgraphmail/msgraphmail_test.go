@@ -0,0 +1,136 @@
+package msgraphmail
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nikoksr/notify"
+)
+
+func TestMSGraphMail_New(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := New("tenant", "client", "secret", "alerts@example.com")
+	assert.NotNil(service)
+	assert.Equal("https://login.microsoftonline.com/tenant/oauth2/v2.0/token", service.oauthConfig.TokenURL)
+	assert.True(service.saveToSent)
+}
+
+func TestMSGraphMail_Validate(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := New("tenant", "client", "secret", "alerts@example.com")
+	service.AddReceivers("alice@example.com")
+	service.AddCCReceivers("Ops <ops@example.com>")
+	assert.NoError(service.Validate())
+
+	service = New("tenant", "", "", "alerts")
+	service.AddReceivers("alice")
+	service.AddCCReceivers("ops")
+	err := service.Validate()
+	assert.ErrorIs(err, notify.ErrInvalidConfig)
+	assert.ErrorContains(err, "client ID is missing")
+	assert.ErrorContains(err, "client secret is missing")
+	assert.ErrorContains(err, `sender: mail address "alerts"`)
+	assert.ErrorContains(err, `receiver: mail address "alice"`)
+	assert.ErrorContains(err, `CC receiver: mail address "ops"`)
+}
+
+func TestMSGraphMail_Send(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	var (
+		tokenRequests int
+		got           []sendMailRequest
+	)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		id, secret, _ := r.BasicAuth()
+		if r.PostForm.Get("client_id") != "" {
+			id, secret = r.PostForm.Get("client_id"), r.PostForm.Get("client_secret")
+		}
+		if id != "client" || secret != "secret" || r.PostForm.Get("scope") != graphScope {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error":"invalid_client"}`))
+			return
+		}
+		tokenRequests++
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token":"token","token_type":"Bearer","expires_in":3600}`))
+	})
+	mux.HandleFunc("/users/alerts@example.com/sendMail", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var req sendMailRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		got = append(got, req)
+		w.WriteHeader(http.StatusAccepted)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	service := New("tenant", "client", "secret", "alerts@example.com")
+	service.oauthConfig.TokenURL = server.URL + "/token"
+	service.graphURL = server.URL
+
+	// No receivers, no request
+	assert.NoError(service.Send(context.Background(), "subject", "message"))
+	assert.Zero(tokenRequests)
+
+	service.AddReceivers("a@example.com", "b@example.com")
+	service.AddCCReceivers("c@example.com")
+	assert.NoError(service.Send(context.Background(), "subject", "message"))
+
+	service.BodyFormat(true)
+	service.SaveToSentItems(false)
+	assert.NoError(service.Send(context.Background(), "subject", "<b>message</b>"))
+
+	assert.Equal(1, tokenRequests)
+	assert.Equal([]sendMailRequest{
+		{
+			Message: message{
+				Subject: "subject",
+				Body:    itemBody{ContentType: "Text", Content: "message"},
+				ToRecipients: []recipient{
+					{EmailAddress: emailAddress{Address: "a@example.com"}},
+					{EmailAddress: emailAddress{Address: "b@example.com"}},
+				},
+				CCRecipients: []recipient{{EmailAddress: emailAddress{Address: "c@example.com"}}},
+			},
+			SaveToSentItems: true,
+		},
+		{
+			Message: message{
+				Subject: "subject",
+				Body:    itemBody{ContentType: "HTML", Content: "<b>message</b>"},
+				ToRecipients: []recipient{
+					{EmailAddress: emailAddress{Address: "a@example.com"}},
+					{EmailAddress: emailAddress{Address: "b@example.com"}},
+				},
+				CCRecipients: []recipient{{EmailAddress: emailAddress{Address: "c@example.com"}}},
+			},
+			SaveToSentItems: false,
+		},
+	}, got)
+
+	// Test error response
+	service = New("tenant", "client", "invalid", "alerts@example.com")
+	service.oauthConfig.TokenURL = server.URL + "/token"
+	service.graphURL = server.URL
+	service.AddReceivers("a@example.com")
+	assert.Error(service.Send(context.Background(), "subject", "message"))
+}
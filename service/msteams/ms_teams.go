@@ -2,9 +2,14 @@ package msteams
 
 import (
 	"context"
+	"regexp"
+	"strconv"
+	"strings"
 
 	teams "github.com/atc0005/go-teams-notify/v2"
 	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
 )
 
 //go:generate mockery --name=teamsClient --output=. --case=underscore --inpackage
@@ -16,6 +21,12 @@ type teamsClient interface {
 // Compile-time check to ensure that teams.Client implements the teamsClient interface.
 var _ teamsClient = teams.NewClient()
 
+// Compile-time check to ensure that MSTeams implements the notify.MessageSender interface.
+var _ notify.MessageSender = MSTeams{}
+
+// Compile-time check to ensure that MSTeams implements the notify.Sanitizer interface.
+var _ notify.Sanitizer = MSTeams{}
+
 // MSTeams struct holds necessary data to communicate with the MSTeams API.
 type MSTeams struct {
 	client   teamsClient
@@ -52,15 +63,61 @@ func (m *MSTeams) AddReceivers(webHooks ...string) {
 	m.webHooks = append(m.webHooks, webHooks...)
 }
 
+// Validate checks that the webhook URLs are well-formed, see notify.Validator.
+func (m MSTeams) Validate() error {
+	var problems notify.ConfigErrors
+	for _, webHook := range m.webHooks {
+		problems.Check("webhook URL", notify.ValidateURL(webHook))
+	}
+
+	return problems.Err()
+}
+
 // Send accepts a subject and a message body and sends them to all previously specified channels. Message body supports
 // html as markup language.
 // For more information about telegram api token:
 //
 //	-> https://github.com/atc0005/go-teams-notify#example-basic
 func (m MSTeams) Send(ctx context.Context, subject, message string) error {
+	return m.SendMessage(ctx, notify.Message{Subject: subject, Body: message})
+}
+
+// cardSections renders a card as message card sections: one holding title, fields and image and one holding the
+// footer.
+func cardSections(card *notify.Card) []*teams.MessageCardSection {
+	section := teams.NewMessageCardSection()
+	section.ActivityTitle = card.Title
+	for _, field := range card.Fields {
+		section.Facts = append(section.Facts, teams.MessageCardSectionFact{Name: field.Name, Value: field.Value})
+	}
+	if card.ImageURL != "" {
+		section.Images = append(section.Images, &teams.MessageCardSectionImage{Image: card.ImageURL, Title: card.Title})
+	}
+	sections := []*teams.MessageCardSection{section}
+
+	if card.Footer != "" {
+		footer := teams.NewMessageCardSection()
+		footer.Text = card.Footer
+		footer.StartGroup = true
+		sections = append(sections, footer)
+	}
+
+	return sections
+}
+
+// SendMessage works like Send, but renders the card of the message as card sections colored by the accent color of
+// the card. Actions are appended as links.
+func (m MSTeams) SendMessage(ctx context.Context, message notify.Message) error {
+	card := message.Card
+	message.Card = nil
+
 	msgCard := teams.NewMessageCard()
-	msgCard.Title = subject
-	msgCard.Text = message
+	msgCard.Title = message.Subject
+	msgCard.Text = message.PlainText()
+	if card != nil {
+		msgCard.ThemeColor = card.Color
+		msgCard.Sections = cardSections(card)
+	}
 
 	for _, webHook := range m.webHooks {
 		select {
@@ -69,10 +126,50 @@ func (m MSTeams) Send(ctx context.Context, subject, message string) error {
 		default:
 			err := m.client.SendWithContext(ctx, webHook, msgCard)
 			if err != nil {
-				return errors.Wrapf(err, "failed to send message to Microsoft Teams via webhook '%s'", webHook)
+				err = errors.Wrapf(err, "failed to send message to Microsoft Teams via webhook '%s'", webHook)
+				return classifyError(err)
 			}
 		}
 	}
 
 	return nil
 }
+
+// textEscaper escapes HTML and the characters that start markdown formatting and links in message card texts.
+var textEscaper = strings.NewReplacer(
+	"&", "&amp;",
+	"<", "&lt;",
+	">", "&gt;",
+	`\`, `\\`,
+	"`", "\\`",
+	"*", `\*`,
+	"_", `\_`,
+	"~", `\~`,
+	"[", `\[`,
+	"]", `\]`,
+	"#", `\#`,
+)
+
+// Sanitize escapes the HTML and markdown of the message, so that user provided content can't format the message or
+// inject links, see notify.Sanitizer.
+func (m MSTeams) Sanitize(message notify.Message) notify.Message {
+	return notify.SanitizeMessage(message, func(text string) string {
+		return textEscaper.Replace(notify.StripControlChars(text))
+	})
+}
+
+// statusPattern extracts the HTTP status from the errors of the Teams client, which doesn't expose it otherwise.
+var statusPattern = regexp.MustCompile(`error on notification: (\d{3}) `)
+
+// classifyError classifies errors returned by Microsoft Teams, see notify.ClassifyError.
+func classifyError(err error) error {
+	if errors.Is(err, teams.ErrWebhookURLUnexpected) {
+		return notify.ClassifyError(err, notify.ErrInvalidReceiver)
+	}
+	if match := statusPattern.FindStringSubmatch(err.Error()); match != nil {
+		status, _ := strconv.Atoi(match[1])
+		return notify.ClassifyStatus(status, err)
+	}
+
+	return notify.ClassifyNetworkError(err)
+}
@@ -6,7 +6,10 @@ import (
 
 	teams "github.com/atc0005/go-teams-notify/v2"
 	"github.com/pkg/errors"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+
+	"github.com/nikoksr/notify"
 )
 
 func TestMSTeams_New(t *testing.T) {
@@ -39,6 +42,34 @@ func TestMSTeams_AddReceivers(t *testing.T) {
 	assert.Equal(service.webHooks, hooks)
 }
 
+func TestMSTeams_Validate(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := New()
+	service.AddReceivers("https://example.webhook.office.com/webhookb2/abc")
+	assert.NoError(service.Validate())
+
+	service.AddReceivers("example.webhook.office.com/webhookb2/abc")
+	err := service.Validate()
+	assert.ErrorIs(err, notify.ErrInvalidConfig)
+	assert.ErrorContains(err, "webhook URL")
+}
+
+func TestMSTeams_Sanitize(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	sanitized := New().Sanitize(notify.Message{
+		Subject: "**Alert**",
+		Body:    `<a href="https://evil.example.com">[docs](https://evil.example.com)</a>`,
+	})
+	assert.Equal(`\*\*Alert\*\*`, sanitized.Subject)
+	assert.Equal(`&lt;a href="https://evil.example.com"&gt;\[docs\](https://evil.example.com)&lt;/a&gt;`, sanitized.Body)
+}
+
 func TestMSTeams_Send(t *testing.T) {
 	t.Parallel()
 
@@ -84,3 +115,40 @@ func TestMSTeams_Send(t *testing.T) {
 	assert.Nil(err)
 	mockClient.AssertExpectations(t)
 }
+
+func TestMSTeams_SendMessageCard(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := New()
+	assert.NotNil(service)
+	service.AddReceivers("1234")
+
+	ctx := context.Background()
+	mockClient := newMockTeamsClient(t)
+	mockClient.
+		On("SendWithContext", ctx, "1234", mock.MatchedBy(func(card teams.MessageCard) bool {
+			return card.Title == "subject" &&
+				card.Text == "message" &&
+				card.ThemeColor == "#ff0000" &&
+				len(card.Sections) == 2 &&
+				card.Sections[0].ActivityTitle == "Disk usage high" &&
+				card.Sections[0].Facts[0] == teams.MessageCardSectionFact{Name: "Host", Value: "db-1"} &&
+				card.Sections[1].Text == "monitoring"
+		})).
+		Return(nil)
+	service.client = mockClient
+
+	err := service.SendMessage(ctx, notify.Message{
+		Subject: "subject",
+		Body:    "message",
+		Card: &notify.Card{
+			Title:  "Disk usage high",
+			Fields: []notify.CardField{{Name: "Host", Value: "db-1"}},
+			Color:  "#ff0000",
+			Footer: "monitoring",
+		},
+	})
+	assert.Nil(err)
+}
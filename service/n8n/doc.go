@@ -0,0 +1,35 @@
+/*
+Package n8n provides a service for triggering n8n workflows through Webhook nodes.
+
+Usage:
+
+	package main
+
+	import (
+	    "context"
+	    "log"
+
+	    "github.com/nikoksr/notify"
+	    "github.com/nikoksr/notify/service/n8n"
+	)
+
+	func main() {
+	    n8nService := n8n.New("https://n8n.example.com")
+
+	    // Call the Webhook nodes listening on these paths.
+	    n8nService.AddReceivers("alerts")
+	    n8nService.SetHeaderAuth("X-Api-Key", "your-secret")
+
+	    // Use the test URL while building the workflow.
+	    n8nService.UseTestURL(true)
+
+	    // Tell our notifier to use the n8n service.
+	    notify.UseServices(n8nService)
+
+	    // Send a test message.
+	    if err := notify.Send(context.Background(), "Subject/Title", "The actual message"); err != nil {
+	        log.Fatal(err)
+	    }
+	}
+*/
+package n8n
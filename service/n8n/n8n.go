@@ -0,0 +1,168 @@
+package n8n
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
+)
+
+// Compile-time check to ensure that Service implements the notify.JSONSender interface.
+var _ notify.JSONSender = new(Service)
+
+// Service encapsulates the n8n webhook client.
+type Service struct {
+	client      *http.Client
+	baseURL     string
+	test        bool
+	headerName  string
+	headerValue string
+	paths       []string
+	signer      *notify.Signer
+
+	now func() time.Time
+}
+
+// New returns a new instance of an n8n notification service for the n8n instance at the given base URL, e.g.
+// https://n8n.example.com.
+// For more information about the Webhook node:
+//
+//	-> https://docs.n8n.io/integrations/builtin/core-nodes/n8n-nodes-base.webhook/
+func New(baseURL string) *Service {
+	return &Service{
+		client: &http.Client{
+			Transport: notify.NewRateLimitTransport(nil),
+			Timeout:   10 * time.Second,
+		},
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		paths:   []string{},
+		now:     time.Now,
+	}
+}
+
+// WithClient sets the http client to be used for sending requests. Calling this method is optional, the default client
+// will be used if this method is not called.
+func (s *Service) WithClient(client *http.Client) {
+	if client != nil {
+		s.client = client
+	}
+}
+
+// AddReceivers takes the paths of Webhook nodes, e.g. "alerts" or a generated UUID, and adds them to the internal path
+// list. The Send method will call every one of those webhooks.
+func (s *Service) AddReceivers(paths ...string) {
+	for _, path := range paths {
+		s.paths = append(s.paths, strings.Trim(path, "/"))
+	}
+}
+
+// UseTestURL switches between the test and production URLs of the webhooks. Test URLs are only active while
+// "Listen for test event" is clicked in the workflow editor, production URLs only while the workflow is active.
+// Production URLs are used by default.
+func (s *Service) UseTestURL(test bool) {
+	s.test = test
+}
+
+// SetHeaderAuth sets the header sent with every request, for webhooks using header authentication.
+func (s *Service) SetHeaderAuth(name, value string) {
+	s.headerName = name
+	s.headerValue = value
+}
+
+// SetSigner makes the service sign every payload with the given signer, so that the receiver can verify that it was
+// sent by this service. See notify.Signer.
+func (s *Service) SetSigner(signer *notify.Signer) {
+	s.signer = signer
+}
+
+type payload struct {
+	Subject   string `json:"subject"`
+	Message   string `json:"message"`
+	Timestamp string `json:"timestamp"`
+}
+
+// Validate checks that the base URL is well-formed and that no webhook path is empty, see notify.Validator.
+func (s *Service) Validate() error {
+	var problems notify.ConfigErrors
+	problems.Check("base URL", notify.ValidateURL(s.baseURL))
+	for _, path := range s.paths {
+		if path == "" {
+			problems.Addf("webhook path is empty")
+		}
+	}
+	if s.headerName == "" && s.headerValue != "" {
+		problems.Addf("header auth value is set without header name")
+	}
+
+	return problems.Err()
+}
+
+// Send takes a message subject and a message body and posts them as JSON to all previously set webhooks.
+func (s *Service) Send(ctx context.Context, subject, message string) error {
+	return s.SendJSON(ctx, payload{
+		Subject:   subject,
+		Message:   message,
+		Timestamp: s.now().UTC().Format(time.RFC3339),
+	})
+}
+
+// SendJSON posts the JSON encoding of v to all previously set webhooks.
+func (s *Service) SendJSON(ctx context.Context, v any) error {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal payload")
+	}
+
+	prefix := "/webhook/"
+	if s.test {
+		prefix = "/webhook-test/"
+	}
+
+	for _, path := range s.paths {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			if err = s.post(ctx, s.baseURL+prefix+path, body); err != nil {
+				return errors.Wrapf(err, "failed to call webhook %q", path)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) post(ctx context.Context, url string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "create request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.signer != nil {
+		s.signer.SignRequest(req, body)
+	}
+	if s.headerName != "" {
+		req.Header.Set(s.headerName, s.headerValue)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "send request")
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		result, _ := io.ReadAll(resp.Body)
+		return notify.ClassifyResponse(resp, fmt.Errorf("n8n returned status code %d: %s", resp.StatusCode, string(result)))
+	}
+
+	return nil
+}
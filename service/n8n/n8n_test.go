@@ -0,0 +1,92 @@
+package n8n
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nikoksr/notify"
+)
+
+func TestN8n_Send(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	got := map[string]payload{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Api-Key") != "secret" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		var p payload
+		_ = json.NewDecoder(r.Body).Decode(&p)
+		got[r.URL.Path] = p
+		_, _ = w.Write([]byte(`{"message":"Workflow was started"}`))
+	}))
+	defer server.Close()
+
+	service := New(server.URL + "/")
+	service.now = func() time.Time { return time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC) }
+	service.AddReceivers("/alerts/")
+	service.SetHeaderAuth("X-Api-Key", "secret")
+
+	assert.NoError(service.Send(context.Background(), "subject", "message"))
+	service.UseTestURL(true)
+	assert.NoError(service.Send(context.Background(), "subject", "message"))
+
+	want := payload{Subject: "subject", Message: "message", Timestamp: "2023-01-02T03:04:05Z"}
+	assert.Equal(map[string]payload{
+		"/webhook/alerts":      want,
+		"/webhook-test/alerts": want,
+	}, got)
+
+	// Test error response
+	service.SetHeaderAuth("X-Api-Key", "invalid")
+	assert.Error(service.Send(context.Background(), "subject", "message"))
+}
+
+func TestN8n_SendJSON(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	var got map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&got)
+	}))
+	defer server.Close()
+
+	service := New(server.URL)
+	service.AddReceivers("alerts")
+
+	assert.NoError(service.SendJSON(context.Background(), map[string]any{"service": "api", "version": "1.2.3"}))
+	assert.Equal(map[string]any{"service": "api", "version": "1.2.3"}, got)
+
+	assert.Error(service.SendJSON(context.Background(), make(chan int)))
+}
+
+func TestN8n_Validate(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := New("https://n8n.example.com")
+	service.AddReceivers("alerts")
+	service.SetHeaderAuth("X-Api-Key", "secret")
+	assert.NoError(service.Validate())
+
+	service = New("n8n.example.com")
+	service.AddReceivers("")
+	service.SetHeaderAuth("", "secret")
+	err := service.Validate()
+	assert.ErrorIs(err, notify.ErrInvalidConfig)
+	assert.ErrorContains(err, "base URL")
+	assert.ErrorContains(err, "webhook path is empty")
+	assert.ErrorContains(err, "header auth value is set without header name")
+}
@@ -0,0 +1,37 @@
+/*
+Package nats provides a service for publishing notifications to NATS subjects, via core NATS or JetStream.
+
+Every notification is published as JSON document with the fields "subject", "message" and "timestamp".
+
+Usage:
+
+	package main
+
+	import (
+	    "context"
+	    "log"
+
+	    "github.com/nikoksr/notify"
+	    "github.com/nikoksr/notify/service/nats"
+	)
+
+	func main() {
+	    natsService := nats.New("nats://nats.example.com:4222")
+	    natsService.SetCredentials("notify.creds")
+	    natsService.UseJetStream(true)
+	    natsService.AddHeader("Source", "billing-api")
+	    defer func() { _ = natsService.Close() }()
+
+	    // Publish to these subjects.
+	    natsService.AddReceivers("alerts.billing")
+
+	    // Tell our notifier to use the nats service.
+	    notify.UseServices(natsService)
+
+	    // Send a test message.
+	    if err := notify.Send(context.Background(), "Subject/Title", "The actual message"); err != nil {
+	        log.Fatal(err)
+	    }
+	}
+*/
+package nats
@@ -0,0 +1,243 @@
+package nats
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
+)
+
+// Compile-time check to ensure that Service implements the notify.JSONSender interface.
+var _ notify.JSONSender = new(Service)
+
+// publisher abstracts publishing via core NATS or JetStream for writing unit tests.
+//
+//go:generate mockery --name=publisher --output=. --case=underscore --inpackage
+type publisher interface {
+	PublishMsg(ctx context.Context, msg *nats.Msg) error
+}
+
+// corePublisher publishes via core NATS. Messages are flushed to make sure the server received them.
+type corePublisher struct {
+	conn *nats.Conn
+}
+
+// PublishMsg implements publisher.
+func (p *corePublisher) PublishMsg(ctx context.Context, msg *nats.Msg) error {
+	if err := p.conn.PublishMsg(msg); err != nil {
+		return err
+	}
+
+	return p.conn.FlushWithContext(ctx)
+}
+
+// jetStreamPublisher publishes via JetStream and waits for the stream to acknowledge the message.
+type jetStreamPublisher struct {
+	js nats.JetStreamContext
+}
+
+// PublishMsg implements publisher.
+func (p *jetStreamPublisher) PublishMsg(ctx context.Context, msg *nats.Msg) error {
+	_, err := p.js.PublishMsg(msg, nats.Context(ctx))
+
+	return err
+}
+
+// Service encapsulates the NATS client.
+type Service struct {
+	serverURL string
+	options   []nats.Option
+	jetStream bool
+	subjects  []string
+	headers   nats.Header
+
+	mu        sync.Mutex
+	conn      *nats.Conn
+	publisher publisher
+}
+
+// payload is the JSON document published for every notification.
+type payload struct {
+	Subject   string    `json:"subject"`
+	Message   string    `json:"message"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// New returns a new instance of a NATS notification service that publishes to the given server, e.g.
+// "nats://nats.example.com:4222". Multiple servers of a cluster can be passed as comma separated list. The client
+// connects on the first Send and stays connected, reconnecting automatically, until Close is called.
+func New(serverURL string) *Service {
+	return &Service{
+		serverURL: serverURL,
+		options:   []nats.Option{nats.Name("notify")},
+		subjects:  []string{},
+		headers:   nats.Header{},
+	}
+}
+
+// WithOptions adds further options to the connection, e.g. nats.Secure for TLS or nats.RootCAs.
+func (s *Service) WithOptions(options ...nats.Option) {
+	s.options = append(s.options, options...)
+}
+
+// SetCredentials makes the client authenticate with the given credentials file, containing a user JWT and NKey seed.
+func (s *Service) SetCredentials(credentialsFile string) {
+	s.options = append(s.options, nats.UserCredentials(credentialsFile))
+}
+
+// SetNKey makes the client authenticate with the NKey seed stored in the given file.
+func (s *Service) SetNKey(seedFile string) error {
+	option, err := nats.NkeyOptionFromSeed(seedFile)
+	if err != nil {
+		return errors.Wrap(err, "failed to load nkey seed")
+	}
+	s.options = append(s.options, option)
+
+	return nil
+}
+
+// SetUserInfo makes the client authenticate with the given username and password.
+func (s *Service) SetUserInfo(username, password string) {
+	s.options = append(s.options, nats.UserInfo(username, password))
+}
+
+// UseJetStream makes the service publish via JetStream, waiting for every message to be acknowledged by the stream
+// bound to the subject. Defaults to core NATS.
+func (s *Service) UseJetStream(enabled bool) {
+	s.jetStream = enabled
+}
+
+// AddReceivers takes subjects and adds them to the internal receiver list. The Send method publishes to all of them.
+func (s *Service) AddReceivers(subjects ...string) {
+	s.subjects = append(s.subjects, subjects...)
+}
+
+// AddHeader adds a header that is sent with every message.
+func (s *Service) AddHeader(key, value string) {
+	s.headers.Add(key, value)
+}
+
+// connect returns the publisher, connecting to the server first if necessary.
+func (s *Service) connect() (publisher, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.publisher != nil {
+		return s.publisher, nil
+	}
+
+	conn, err := nats.Connect(s.serverURL, s.options...)
+	if err != nil {
+		return nil, err
+	}
+
+	if !s.jetStream {
+		s.conn, s.publisher = conn, &corePublisher{conn: conn}
+		return s.publisher, nil
+	}
+
+	js, err := conn.JetStream()
+	if err != nil {
+		conn.Close()
+		return nil, errors.Wrap(err, "create jetstream context")
+	}
+	s.conn, s.publisher = conn, &jetStreamPublisher{js: js}
+
+	return s.publisher, nil
+}
+
+// Validate checks that the server URL is set and that at least one subject is set and none is empty or contains
+// wildcards or whitespace, see notify.Validator.
+func (s *Service) Validate() error {
+	var problems notify.ConfigErrors
+	problems.Require("server URL", s.serverURL)
+	if len(s.subjects) == 0 {
+		problems.Addf("no subject set")
+	}
+	for _, subject := range s.subjects {
+		switch {
+		case subject == "":
+			problems.Addf("subject is empty")
+		case strings.ContainsAny(subject, "*> \t\r\n"):
+			problems.Addf("subject %q contains wildcards or whitespace", subject)
+		}
+	}
+
+	return problems.Err()
+}
+
+// Close drains and closes the connection to the server. The next Send reconnects.
+func (s *Service) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.publisher = nil
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Drain()
+	s.conn = nil
+
+	return err
+}
+
+// Send takes a message subject and a message body and publishes them as JSON document to all previously set subjects.
+func (s *Service) Send(ctx context.Context, subject, message string) error {
+	return s.SendJSON(ctx, &payload{Subject: subject, Message: message, Timestamp: time.Now().UTC()})
+}
+
+// SendJSON publishes the JSON encoding of v to all previously set subjects.
+func (s *Service) SendJSON(ctx context.Context, v any) error {
+	if len(s.subjects) == 0 {
+		return nil
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal payload")
+	}
+
+	p, err := s.connect()
+	if err != nil {
+		return classifyError(errors.Wrap(err, "failed to connect to server"))
+	}
+
+	for _, natsSubject := range s.subjects {
+		msg := &nats.Msg{Subject: natsSubject, Data: data}
+		if len(s.headers) > 0 {
+			msg.Header = s.headers
+		}
+
+		if err = p.PublishMsg(ctx, msg); err != nil {
+			return classifyError(errors.Wrapf(err, "failed to publish to subject %q", natsSubject))
+		}
+	}
+
+	return nil
+}
+
+// classifyError classifies errors of the server and the connection to it, see notify.ClassifyError.
+func classifyError(err error) error {
+	var apiErr *nats.APIError
+	switch {
+	case errors.As(err, &apiErr) && apiErr.Code != 0:
+		return notify.ClassifyStatus(apiErr.Code, err)
+	case errors.Is(err, nats.ErrAuthorization), errors.Is(err, nats.ErrAuthExpired), errors.Is(err, nats.ErrAuthRevoked):
+		return notify.ClassifyError(err, notify.ErrAuthFailed)
+	case errors.Is(err, nats.ErrBadSubject), errors.Is(err, nats.ErrNoStreamResponse):
+		return notify.ClassifyError(err, notify.ErrInvalidReceiver)
+	case errors.Is(err, nats.ErrMaxPayload):
+		return notify.ClassifyError(err, notify.ErrPermanent)
+	case errors.Is(err, nats.ErrNoServers), errors.Is(err, nats.ErrTimeout), errors.Is(err, nats.ErrConnectionClosed),
+		errors.Is(err, nats.ErrConnectionReconnecting), errors.Is(err, nats.ErrDisconnected):
+		return notify.ClassifyError(err, notify.ErrTransient)
+	default:
+		return notify.ClassifyNetworkError(err)
+	}
+}
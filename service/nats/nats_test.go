@@ -0,0 +1,115 @@
+package nats
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nikoksr/notify"
+)
+
+func TestNATS_Validate(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := New("nats://nats.example.com:4222")
+	service.AddReceivers("alerts.ops")
+	assert.NoError(service.Validate())
+
+	err := New("").Validate()
+	assert.ErrorIs(err, notify.ErrInvalidConfig)
+	assert.ErrorContains(err, "server URL is missing")
+	assert.ErrorContains(err, "no subject set")
+
+	service.AddReceivers("alerts.>", "")
+	err = service.Validate()
+	assert.ErrorContains(err, `subject "alerts.>" contains wildcards or whitespace`)
+	assert.ErrorContains(err, "subject is empty")
+}
+
+func TestNATS_Send(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+	ctx := context.Background()
+
+	service := New("nats://localhost:4222")
+
+	// No receivers added
+	err := service.Send(ctx, "subject", "message")
+	assert.NoError(err)
+
+	// Test publishing successfully
+	var published []*nats.Msg
+	mockPublisher := newMockPublisher(t)
+	mockPublisher.
+		On("PublishMsg", ctx, mock.AnythingOfType("*nats.Msg")).
+		Run(func(args mock.Arguments) { published = append(published, args.Get(1).(*nats.Msg)) }).
+		Return(nil)
+
+	service.publisher = mockPublisher
+	service.AddReceivers("alerts.critical", "alerts.all")
+	service.AddHeader("Source", "notify")
+	err = service.Send(ctx, "subject", "message")
+	assert.NoError(err)
+	assert.Len(published, 2)
+	assert.Equal("alerts.critical", published[0].Subject)
+	assert.Equal("notify", published[0].Header.Get("Source"))
+
+	var p payload
+	assert.NoError(json.Unmarshal(published[1].Data, &p))
+	assert.Equal("subject", p.Subject)
+	assert.Equal("message", p.Message)
+
+	// Test publishing with error
+	mockPublisher = newMockPublisher(t)
+	mockPublisher.
+		On("PublishMsg", ctx, mock.Anything).
+		Return(nats.ErrNoServers)
+
+	service.publisher = mockPublisher
+	err = service.Send(ctx, "subject", "message")
+	assert.ErrorIs(err, notify.ErrTransient)
+
+	assert.NoError(service.Close())
+	assert.Nil(service.publisher)
+}
+
+func TestNATS_SetNKey(t *testing.T) {
+	t.Parallel()
+
+	err := New("nats://localhost:4222").SetNKey("does-not-exist.nk")
+	require.Error(t, err)
+}
+
+func TestNATS_SendJSON(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+	ctx := context.Background()
+
+	var published []*nats.Msg
+	mockPublisher := newMockPublisher(t)
+	mockPublisher.
+		On("PublishMsg", ctx, mock.AnythingOfType("*nats.Msg")).
+		Run(func(args mock.Arguments) { published = append(published, args.Get(1).(*nats.Msg)) }).
+		Return(nil)
+
+	service := New("nats://localhost:4222")
+	service.publisher = mockPublisher
+	service.AddReceivers("events.deploy")
+
+	err := service.SendJSON(ctx, map[string]any{"service": "api", "version": "1.2.3"})
+	assert.NoError(err)
+	assert.Len(published, 1)
+	assert.JSONEq(`{"service":"api","version":"1.2.3"}`, string(published[0].Data))
+
+	// Test unserializable payload
+	err = service.SendJSON(ctx, make(chan int))
+	assert.Error(err)
+}
@@ -0,0 +1,32 @@
+/*
+Package newrelic provides a service for submitting notifications as custom events to New Relic.
+
+Usage:
+
+	package main
+
+	import (
+	    "context"
+	    "log"
+
+	    "github.com/nikoksr/notify"
+	    "github.com/nikoksr/notify/service/newrelic"
+	)
+
+	func main() {
+	    newRelicService := newrelic.New("your-account-id", "your-insert-key")
+
+	    // Events are queried via NRQL: SELECT * FROM Deployment
+	    newRelicService.SetEventType("Deployment")
+	    newRelicService.AddAttribute("service", "api")
+
+	    // Tell our notifier to use the new relic service.
+	    notify.UseServices(newRelicService)
+
+	    // Send a test message.
+	    if err := notify.Send(context.Background(), "Subject/Title", "The actual message"); err != nil {
+	        log.Fatal(err)
+	    }
+	}
+*/
+package newrelic
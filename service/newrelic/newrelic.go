@@ -0,0 +1,124 @@
+package newrelic
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
+)
+
+const (
+	usCollectorURL = "https://insights-collector.newrelic.com"
+	euCollectorURL = "https://insights-collector.eu01.nr-data.net"
+)
+
+// DefaultEventType is the event type used if none is set via SetEventType.
+const DefaultEventType = "Notification"
+
+// Service allow you to configure the New Relic service.
+type Service struct {
+	client       *http.Client
+	collectorURL string
+	accountID    string
+	insertKey    string
+
+	eventType  string
+	attributes map[string]any
+
+	now func() time.Time
+}
+
+func defaultHTTPClient() *http.Client {
+	return &http.Client{
+		Transport: notify.NewRateLimitTransport(nil),
+		Timeout:   10 * time.Second,
+	}
+}
+
+// New returns a new instance of a New Relic notification service, submitting custom events to the given account with
+// an Insights insert key, which can be created under API Keys.
+// For more information about the Event API:
+//
+//	-> https://docs.newrelic.com/docs/data-apis/ingest-apis/event-api/introduction-event-api/
+func New(accountID, insertKey string) *Service {
+	return &Service{
+		client:       defaultHTTPClient(),
+		collectorURL: usCollectorURL,
+		accountID:    accountID,
+		insertKey:    insertKey,
+		eventType:    DefaultEventType,
+		attributes:   map[string]any{},
+		now:          time.Now,
+	}
+}
+
+// WithClient sets the http client to be used for sending requests. Calling this method is optional, the default client
+// will be used if this method is not called.
+func (s *Service) WithClient(client *http.Client) {
+	if client != nil {
+		s.client = client
+	}
+}
+
+// UseEURegion submits events to the EU data center. Required for accounts hosted in the EU region.
+func (s *Service) UseEURegion() {
+	s.collectorURL = euCollectorURL
+}
+
+// SetEventType sets the type of the submitted events, which they are queried by with NRQL, e.g.
+// "SELECT * FROM Notification". Defaults to DefaultEventType.
+func (s *Service) SetEventType(eventType string) {
+	s.eventType = eventType
+}
+
+// AddAttribute adds an attribute to all submitted events, e.g. "service" or "severity". Values must be strings or
+// numbers.
+func (s *Service) AddAttribute(key string, value any) {
+	s.attributes[key] = value
+}
+
+// Send takes a message subject and a message body and submits them as custom event to New Relic, with "subject" and
+// "message" attributes.
+func (s *Service) Send(ctx context.Context, subject, message string) error {
+	event := make(map[string]any, len(s.attributes)+4)
+	for key, value := range s.attributes {
+		event[key] = value
+	}
+	event["eventType"] = s.eventType
+	event["timestamp"] = s.now().Unix()
+	event["subject"] = subject
+	event["message"] = message
+
+	body, err := json.Marshal([]map[string]any{event})
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal event")
+	}
+
+	url := fmt.Sprintf("%s/v1/accounts/%s/events", s.collectorURL, s.accountID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "failed to create request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Insert-Key", s.insertKey)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "failed to send event to New Relic")
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		result, _ := io.ReadAll(resp.Body)
+		return notify.ClassifyResponse(resp, fmt.Errorf("new relic returned status code %d: %s", resp.StatusCode, string(result)))
+	}
+
+	return nil
+}
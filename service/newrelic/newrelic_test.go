@@ -0,0 +1,56 @@
+package newrelic
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRelic_Send(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	var got []map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Insert-Key") != "key" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		if r.URL.Path != "/v1/accounts/123/events" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		_, _ = w.Write([]byte(`{"success":true,"uuid":"1"}`))
+	}))
+	defer server.Close()
+
+	service := New("123", "key")
+	assert.Equal(usCollectorURL, service.collectorURL)
+	service.UseEURegion()
+	assert.Equal(euCollectorURL, service.collectorURL)
+
+	service.collectorURL = server.URL
+	service.now = func() time.Time { return time.Unix(1672531200, 0) }
+	service.SetEventType("Deployment")
+	service.AddAttribute("service", "api")
+
+	assert.NoError(service.Send(context.Background(), "subject", "message"))
+	assert.Equal([]map[string]any{{
+		"eventType": "Deployment",
+		"timestamp": float64(1672531200),
+		"subject":   "subject",
+		"message":   "message",
+		"service":   "api",
+	}}, got)
+
+	// Test error response
+	service.insertKey = "invalid"
+	assert.Error(service.Send(context.Background(), "subject", "message"))
+}
@@ -0,0 +1,31 @@
+/*
+Package nextcloudtalk provides a service for sending messages to Nextcloud Talk conversations.
+
+Usage:
+
+	package main
+
+	import (
+	    "context"
+	    "log"
+
+	    "github.com/nikoksr/notify"
+	    "github.com/nikoksr/notify/service/nextcloudtalk"
+	)
+
+	func main() {
+	    talkService := nextcloudtalk.New("https://cloud.example.com", "notify-bot", "your-app-password")
+
+	    // Post to these conversations.
+	    talkService.AddReceivers("abc123")
+
+	    // Tell our notifier to use the nextcloud talk service.
+	    notify.UseServices(talkService)
+
+	    // Send a test message.
+	    if err := notify.Send(context.Background(), "Subject/Title", "The actual message"); err != nil {
+	        log.Fatal(err)
+	    }
+	}
+*/
+package nextcloudtalk
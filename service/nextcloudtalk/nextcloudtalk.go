@@ -0,0 +1,139 @@
+package nextcloudtalk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
+)
+
+// Service encapsulates the Nextcloud Talk client.
+type Service struct {
+	client      *http.Client
+	serverURL   string
+	username    string
+	appPassword string
+	silent      bool
+	roomTokens  []string
+}
+
+// New returns a new instance of a Nextcloud Talk notification service for the Nextcloud server at the given URL, e.g.
+// https://cloud.example.com. It's recommended to use a dedicated user with an app password, which can be created under
+// Personal settings > Security.
+// For more information about the Talk chat API:
+//
+//	-> https://nextcloud-talk.readthedocs.io/en/latest/chat/#sending-a-new-chat-message
+func New(serverURL, username, appPassword string) *Service {
+	return &Service{
+		client: &http.Client{
+			Transport: notify.NewRateLimitTransport(nil),
+			Timeout:   10 * time.Second,
+		},
+		serverURL:   strings.TrimSuffix(serverURL, "/"),
+		username:    username,
+		appPassword: appPassword,
+		roomTokens:  []string{},
+	}
+}
+
+// WithClient sets the http client to be used for sending requests. Calling this method is optional, the default client
+// will be used if this method is not called.
+func (s *Service) WithClient(client *http.Client) {
+	if client != nil {
+		s.client = client
+	}
+}
+
+// AddReceivers takes conversation tokens and adds them to the internal receiver list. The token is the last part of a
+// conversation's URL, e.g. "abc123" for https://cloud.example.com/call/abc123. The user must be a participant of the
+// conversations.
+func (s *Service) AddReceivers(roomTokens ...string) {
+	s.roomTokens = append(s.roomTokens, roomTokens...)
+}
+
+// SetSilent sends messages without triggering notifications for the participants.
+func (s *Service) SetSilent(silent bool) {
+	s.silent = silent
+}
+
+type chatMessage struct {
+	Message string `json:"message"`
+	Silent  bool   `json:"silent,omitempty"`
+}
+
+// Validate checks that the server URL is well-formed, that username and app password are set and that no conversation
+// token is empty, see notify.Validator.
+func (s *Service) Validate() error {
+	var problems notify.ConfigErrors
+	problems.Check("server URL", notify.ValidateURL(s.serverURL))
+	problems.Require("username", s.username)
+	problems.Require("app password", s.appPassword)
+	for _, roomToken := range s.roomTokens {
+		if roomToken == "" {
+			problems.Addf("conversation token is empty")
+		}
+	}
+
+	return problems.Err()
+}
+
+// Send takes a message subject and a message body and posts them to all previously set conversations. The subject is
+// formatted bold, Talk renders messages as markdown.
+func (s *Service) Send(ctx context.Context, subject, message string) error {
+	text := message
+	if subject != "" {
+		text = "**" + subject + "**\n\n" + message
+	}
+
+	body, err := json.Marshal(chatMessage{Message: text, Silent: s.silent})
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal message")
+	}
+
+	for _, roomToken := range s.roomTokens {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			if err = s.post(ctx, roomToken, body); err != nil {
+				return errors.Wrapf(err, "failed to send message to conversation %q", roomToken)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) post(ctx context.Context, roomToken string, body []byte) error {
+	endpoint := s.serverURL + "/ocs/v2.php/apps/spreed/api/v1/chat/" + url.PathEscape(roomToken)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "create request")
+	}
+	req.SetBasicAuth(s.username, s.appPassword)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("OCS-APIRequest", "true")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "send request")
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusCreated {
+		result, _ := io.ReadAll(resp.Body)
+		return notify.ClassifyResponse(resp, fmt.Errorf("nextcloud returned status code %d: %s", resp.StatusCode, string(result)))
+	}
+
+	return nil
+}
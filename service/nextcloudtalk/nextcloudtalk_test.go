@@ -0,0 +1,67 @@
+package nextcloudtalk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nikoksr/notify"
+)
+
+func TestNextcloudTalk_Send(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	got := map[string]chatMessage{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, ok := r.BasicAuth()
+		if !ok || user != "bot" || pass != "app-password" || r.Header.Get("OCS-APIRequest") != "true" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var msg chatMessage
+		_ = json.NewDecoder(r.Body).Decode(&msg)
+		got[r.URL.Path] = msg
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	service := New(server.URL+"/", "bot", "app-password")
+	service.AddReceivers("abc123", "def456")
+	service.SetSilent(true)
+
+	assert.NoError(service.Send(context.Background(), "subject", "message"))
+	want := chatMessage{Message: "**subject**\n\nmessage", Silent: true}
+	assert.Equal(map[string]chatMessage{
+		"/ocs/v2.php/apps/spreed/api/v1/chat/abc123": want,
+		"/ocs/v2.php/apps/spreed/api/v1/chat/def456": want,
+	}, got)
+
+	// Test error response
+	service.appPassword = "invalid"
+	assert.Error(service.Send(context.Background(), "subject", "message"))
+}
+
+func TestNextcloudTalk_Validate(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := New("https://cloud.example.com", "bot", "password")
+	service.AddReceivers("abc123")
+	assert.NoError(service.Validate())
+
+	service = New("cloud.example.com", "", "")
+	service.AddReceivers("")
+	err := service.Validate()
+	assert.ErrorIs(err, notify.ErrInvalidConfig)
+	assert.ErrorContains(err, "server URL")
+	assert.ErrorContains(err, "username is missing")
+	assert.ErrorContains(err, "app password is missing")
+	assert.ErrorContains(err, "conversation token is empty")
+}
@@ -0,0 +1,35 @@
+/*
+Package notion provides a service for appending messages to Notion pages and creating entries in Notion databases.
+
+Usage:
+
+	package main
+
+	import (
+	    "context"
+	    "log"
+
+	    "github.com/nikoksr/notify"
+	    "github.com/nikoksr/notify/service/notion"
+	)
+
+	func main() {
+	    notionService := notion.New("secret_xxx")
+
+	    // Append messages to this page...
+	    notionService.AddReceivers("b55c9c91-384d-452b-81db-d1ef79372b75")
+
+	    // ...and create entries in this database, whose title property is called "Incident".
+	    notionService.AddDatabaseReceivers("d9824bdc-8445-4327-be8b-5b47500af6ce")
+	    notionService.SetTitleProperty("Incident")
+
+	    // Tell our notifier to use the notion service.
+	    notify.UseServices(notionService)
+
+	    // Send a test message.
+	    if err := notify.Send(context.Background(), "Subject/Title", "The actual message"); err != nil {
+	        log.Fatal(err)
+	    }
+	}
+*/
+package notion
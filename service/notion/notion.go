@@ -0,0 +1,175 @@
+package notion
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
+)
+
+// DefaultAPIURL is the base URL of the Notion API.
+const DefaultAPIURL = "https://api.notion.com/v1"
+
+// apiVersion is the version of the Notion API the service is written against.
+const apiVersion = "2022-06-28"
+
+// DefaultTitleProperty is the name of the title property of databases created by Notion.
+const DefaultTitleProperty = "Name"
+
+// maxTextLength is the maximum length of a single rich text object.
+const maxTextLength = 2000
+
+// Service encapsulates the Notion client.
+type Service struct {
+	client        *http.Client
+	apiURL        string
+	token         string
+	pageIDs       []string
+	databaseIDs   []string
+	titleProperty string
+}
+
+// New returns a new instance of a Notion notification service, authenticated with the secret of an internal
+// integration. Pages and databases have to be shared with the integration before it can write to them.
+// For more information about integrations:
+//
+//	-> https://developers.notion.com/docs/create-a-notion-integration
+func New(token string) *Service {
+	return &Service{
+		client: &http.Client{
+			Transport: notify.NewRateLimitTransport(nil),
+			Timeout:   10 * time.Second,
+		},
+		apiURL:        DefaultAPIURL,
+		token:         token,
+		pageIDs:       []string{},
+		databaseIDs:   []string{},
+		titleProperty: DefaultTitleProperty,
+	}
+}
+
+// WithClient sets the http client to be used for sending requests. Calling this method is optional, the default client
+// will be used if this method is not called.
+func (s *Service) WithClient(client *http.Client) {
+	if client != nil {
+		s.client = client
+	}
+}
+
+// AddReceivers takes page IDs and adds them to the internal receiver list. The Send method appends the message to the
+// end of each of those pages.
+func (s *Service) AddReceivers(pageIDs ...string) {
+	s.pageIDs = append(s.pageIDs, pageIDs...)
+}
+
+// AddDatabaseReceivers takes database IDs and adds them to the internal receiver list. The Send method creates a new
+// entry in each of those databases, with the subject as title and the body as page content.
+func (s *Service) AddDatabaseReceivers(databaseIDs ...string) {
+	s.databaseIDs = append(s.databaseIDs, databaseIDs...)
+}
+
+// SetTitleProperty sets the name of the title property of the databases. Defaults to DefaultTitleProperty.
+func (s *Service) SetTitleProperty(name string) {
+	s.titleProperty = name
+}
+
+// richText returns rich text objects for the given text, split to respect the maximum text length.
+func richText(text string) []map[string]any {
+	runes := []rune(text)
+	texts := make([]map[string]any, 0, len(runes)/maxTextLength+1)
+	for len(runes) > 0 {
+		n := len(runes)
+		if n > maxTextLength {
+			n = maxTextLength
+		}
+		texts = append(texts, map[string]any{
+			"type": "text",
+			"text": map[string]string{"content": string(runes[:n])},
+		})
+		runes = runes[n:]
+	}
+
+	return texts
+}
+
+// block returns a block of the given type with the given text.
+func block(typ, text string) map[string]any {
+	return map[string]any{
+		"object": "block",
+		"type":   typ,
+		typ:      map[string]any{"rich_text": richText(text)},
+	}
+}
+
+func (s *Service) do(ctx context.Context, method, path string, payload any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return errors.Wrap(err, "marshal request")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, s.apiURL+path, bytes.NewReader(data))
+	if err != nil {
+		return errors.Wrap(err, "create request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+s.token)
+	req.Header.Set("Notion-Version", apiVersion)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "send request")
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return notify.ClassifyResponse(resp, fmt.Errorf("notion returned status code %d: %s", resp.StatusCode, string(body)))
+	}
+
+	return nil
+}
+
+// Send takes a message subject and a message body and appends them to all previously set pages and creates entries in
+// all previously set databases.
+func (s *Service) Send(ctx context.Context, subject, message string) error {
+	for _, pageID := range s.pageIDs {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			children := map[string]any{
+				"children": []map[string]any{block("heading_3", subject), block("paragraph", message)},
+			}
+			if err := s.do(ctx, http.MethodPatch, "/blocks/"+pageID+"/children", children); err != nil {
+				return errors.Wrapf(err, "failed to append to page %q", pageID)
+			}
+		}
+	}
+
+	for _, databaseID := range s.databaseIDs {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			page := map[string]any{
+				"parent": map[string]string{"database_id": databaseID},
+				"properties": map[string]any{
+					s.titleProperty: map[string]any{"title": richText(subject)},
+				},
+				"children": []map[string]any{block("paragraph", message)},
+			}
+			if err := s.do(ctx, http.MethodPost, "/pages", page); err != nil {
+				return errors.Wrapf(err, "failed to create entry in database %q", databaseID)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,68 @@
+package notion
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotion_RichText(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	texts := richText(strings.Repeat("ä", maxTextLength+1))
+	assert.Len(texts, 2)
+	assert.Equal("ä", texts[1]["text"].(map[string]string)["content"])
+
+	assert.Empty(richText(""))
+}
+
+func TestNotion_Send(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	got := map[string]map[string]any{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" || r.Header.Get("Notion-Version") != apiVersion {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		got[r.Method+" "+r.URL.Path] = body
+		_, _ = w.Write([]byte(`{"object":"list"}`))
+	}))
+	defer server.Close()
+
+	service := New("token")
+	service.apiURL = server.URL
+	service.AddReceivers("page")
+	service.AddDatabaseReceivers("database")
+	service.SetTitleProperty("Incident")
+
+	err := service.Send(context.Background(), "subject", "message")
+	assert.Nil(err)
+	assert.Len(got, 2)
+
+	children := got["PATCH /blocks/page/children"]["children"].([]any)
+	assert.Len(children, 2)
+	assert.Equal("heading_3", children[0].(map[string]any)["type"])
+
+	page := got["POST /pages"]
+	assert.Equal(map[string]any{"database_id": "database"}, page["parent"])
+	assert.Contains(page["properties"], "Incident")
+
+	// Test error response
+	service = New("invalid")
+	service.apiURL = server.URL
+	service.AddReceivers("page")
+	err = service.Send(context.Background(), "subject", "message")
+	assert.NotNil(err)
+}
@@ -0,0 +1,40 @@
+/*
+Package ntfy provides a service for publishing messages to ntfy.sh or self-hosted ntfy topics.
+
+Usage:
+
+	package main
+
+	import (
+	    "context"
+	    "log"
+
+	    "github.com/nikoksr/notify"
+	    "github.com/nikoksr/notify/service/ntfy"
+	)
+
+	func main() {
+	    // Create a ntfy service. Use ntfy.NewWithServer to publish to a self-hosted instance.
+	    ntfyService := ntfy.New()
+
+	    // Protected topics require an access token.
+	    ntfyService.AuthenticateWithToken("tk_your_access_token")
+
+	    // Configure how the messages should be displayed.
+	    ntfyService.SetPriority(ntfy.PriorityHigh)
+	    ntfyService.SetTags("warning", "server")
+	    ntfyService.SetClickURL("https://status.example.com")
+
+	    // Add the topics to publish to.
+	    ntfyService.AddReceivers("my-alerts")
+
+	    // Tell our notifier to use the ntfy service.
+	    notify.UseServices(ntfyService)
+
+	    // Send a test message.
+	    if err := notify.Send(context.Background(), "Subject/Title", "The actual message"); err != nil {
+	        log.Fatal(err)
+	    }
+	}
+*/
+package ntfy
@@ -0,0 +1,218 @@
+package ntfy
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
+)
+
+// DefaultServerURL is the default server to use for the ntfy service.
+const DefaultServerURL = "https://ntfy.sh"
+
+// Priority represents the priority of a ntfy message. See https://docs.ntfy.sh/publish/#message-priority.
+type Priority int
+
+// All priorities supported by ntfy.
+const (
+	PriorityMin     Priority = 1
+	PriorityLow     Priority = 2
+	PriorityDefault Priority = 3
+	PriorityHigh    Priority = 4
+	PriorityMax     Priority = 5
+)
+
+// Compile-time check to ensure that Service implements the notify.Validator interface.
+var _ notify.Validator = &Service{}
+
+// priorities maps the priorities of notify.WithPriority to ntfy priorities.
+var priorities = map[notify.Priority]Priority{
+	notify.PriorityLow:      PriorityLow,
+	notify.PriorityNormal:   PriorityDefault,
+	notify.PriorityHigh:     PriorityHigh,
+	notify.PriorityCritical: PriorityMax,
+}
+
+// Service allow you to configure the ntfy service.
+type Service struct {
+	client      *http.Client
+	serverURL   string
+	accessToken string
+	topics      []string
+
+	priority   Priority
+	tags       []string
+	clickURL   string
+	attachURL  string
+	attachName string
+}
+
+func defaultHTTPClient() *http.Client {
+	return &http.Client{
+		Transport: notify.NewRateLimitTransport(nil),
+		Timeout:   10 * time.Second,
+	}
+}
+
+// New returns a new instance of a ntfy notification service that publishes to the public ntfy.sh instance.
+func New() *Service {
+	return NewWithServer(DefaultServerURL)
+}
+
+// NewWithServer returns a new instance of a ntfy notification service that publishes to the given, usually
+// self-hosted, server.
+func NewWithServer(serverURL string) *Service {
+	if serverURL == "" {
+		serverURL = DefaultServerURL
+	}
+
+	return &Service{
+		client:    defaultHTTPClient(),
+		serverURL: strings.TrimSuffix(serverURL, "/"),
+		topics:    []string{},
+		priority:  PriorityDefault,
+	}
+}
+
+// WithClient sets the http client to be used for sending requests. Calling this method is optional, the default client
+// will be used if this method is not called.
+func (s *Service) WithClient(client *http.Client) {
+	if client != nil {
+		s.client = client
+	}
+}
+
+// AuthenticateWithToken sets an access token that is used to authenticate against protected topics.
+func (s *Service) AuthenticateWithToken(accessToken string) {
+	s.accessToken = accessToken
+}
+
+// AddReceivers takes ntfy topic names and adds them to the internal topic list. The Send method will publish a given
+// message to all of those topics.
+func (s *Service) AddReceivers(topics ...string) {
+	s.topics = append(s.topics, topics...)
+}
+
+// SetPriority sets the priority of all messages published by this service.
+func (s *Service) SetPriority(priority Priority) {
+	s.priority = priority
+}
+
+// SetTags sets the tags of all messages published by this service. Tags that match an emoji short code are rendered as
+// emojis by ntfy clients.
+func (s *Service) SetTags(tags ...string) {
+	s.tags = tags
+}
+
+// SetClickURL sets the URL that is opened when a notification is clicked.
+func (s *Service) SetClickURL(clickURL string) {
+	s.clickURL = clickURL
+}
+
+// SetAttachment sets an external file that gets attached to all messages. The filename is optional.
+func (s *Service) SetAttachment(attachURL, filename string) {
+	s.attachURL = attachURL
+	s.attachName = filename
+}
+
+// newRequest creates a publish request for the given topic.
+func (s *Service) newRequest(ctx context.Context, topic, subject, message string) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.serverURL+"/"+topic, strings.NewReader(message))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "text/plain; charset=utf-8")
+	if subject != "" {
+		req.Header.Set("Title", subject)
+	}
+	options := notify.SendOptionsFromContext(ctx)
+	priority := s.priority
+	if options.Priority != notify.PriorityDefault {
+		priority = priorities[options.Priority]
+	}
+	if priority != 0 {
+		req.Header.Set("Priority", strconv.Itoa(int(priority)))
+	}
+	if tags := append(append([]string(nil), s.tags...), options.Tags...); len(tags) > 0 {
+		req.Header.Set("Tags", strings.Join(tags, ","))
+	}
+	if s.clickURL != "" {
+		req.Header.Set("Click", s.clickURL)
+	}
+	if s.attachURL != "" {
+		req.Header.Set("Attach", s.attachURL)
+		if s.attachName != "" {
+			req.Header.Set("Filename", s.attachName)
+		}
+	}
+	if s.accessToken != "" {
+		req.Header.Set("Authorization", "Bearer "+s.accessToken)
+	}
+
+	return req, nil
+}
+
+func (s *Service) send(ctx context.Context, topic, subject, message string) error {
+	req, err := s.newRequest(ctx, topic, subject, message)
+	if err != nil {
+		return errors.Wrap(err, "create request")
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "send request")
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		result, _ := io.ReadAll(resp.Body)
+		return notify.ClassifyResponse(resp, fmt.Errorf("ntfy returned status code %d: %s", resp.StatusCode, string(result)))
+	}
+
+	return nil
+}
+
+// Validate checks that the server URL, click URL and attachment URL are well-formed and that the topics are valid
+// topic names, see notify.Validator.
+func (s *Service) Validate() error {
+	var problems notify.ConfigErrors
+	problems.Check("server URL", notify.ValidateURL(s.serverURL))
+	if s.clickURL != "" {
+		problems.Check("click URL", notify.ValidateURL(s.clickURL))
+	}
+	if s.attachURL != "" {
+		problems.Check("attachment URL", notify.ValidateURL(s.attachURL))
+	}
+	for _, topic := range s.topics {
+		if topic == "" || strings.Contains(topic, "/") {
+			problems.Addf("topic %q is not a valid topic name", topic)
+		}
+	}
+
+	return problems.Err()
+}
+
+// Send takes a message subject and a message body and publishes them to all previously set topics. Priority, tags and
+// topics can be customized per send, see notify.SendWithOptions.
+func (s *Service) Send(ctx context.Context, subject, message string) error {
+	for _, topic := range notify.ReceiversFromContext(ctx, s.topics) {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			if err := s.send(ctx, topic, subject, message); err != nil {
+				return errors.Wrapf(err, "failed to publish message to ntfy topic %q", topic)
+			}
+		}
+	}
+
+	return nil
+}
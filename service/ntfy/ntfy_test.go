@@ -0,0 +1,116 @@
+package ntfy
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nikoksr/notify"
+)
+
+func TestNtfy_New(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := New()
+	assert.NotNil(service)
+	assert.Equal(DefaultServerURL, service.serverURL)
+
+	service = NewWithServer("https://ntfy.example.com/")
+	assert.Equal("https://ntfy.example.com", service.serverURL)
+}
+
+func TestNtfy_AddReceivers(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := New()
+	service.AddReceivers("a")
+	service.AddReceivers("b", "c")
+	assert.Equal([]string{"a", "b", "c"}, service.topics)
+}
+
+func TestNtfy_Validate(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := New()
+	service.AddReceivers("alerts")
+	service.SetClickURL("https://status.example.com")
+	assert.NoError(service.Validate())
+
+	service = NewWithServer("ntfy.example.com")
+	service.AddReceivers("", "alerts/db")
+	service.SetClickURL("status.example.com")
+	service.SetAttachment("ftp://files.example.com/report.pdf", "report.pdf")
+	err := service.Validate()
+	assert.ErrorIs(err, notify.ErrInvalidConfig)
+	assert.ErrorContains(err, "server URL")
+	assert.ErrorContains(err, "click URL")
+	assert.ErrorContains(err, "attachment URL")
+	assert.ErrorContains(err, `topic "" is not a valid topic name`)
+	assert.ErrorContains(err, `topic "alerts/db" is not a valid topic name`)
+}
+
+func TestNtfy_Send(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	var (
+		gotHeader http.Header
+		gotPath   string
+		gotBody   string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/forbidden" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		gotHeader = r.Header
+		gotPath = r.URL.Path
+		body, _ := io.ReadAll(r.Body)
+		gotBody = string(body)
+	}))
+	defer server.Close()
+
+	service := NewWithServer(server.URL)
+	service.AuthenticateWithToken("token")
+	service.SetPriority(PriorityMax)
+	service.SetTags("warning", "skull")
+	service.SetClickURL("https://example.com")
+	service.AddReceivers("alerts")
+
+	err := service.Send(context.Background(), "subject", "message")
+	assert.Nil(err)
+	assert.Equal("message", gotBody)
+	assert.Equal("subject", gotHeader.Get("Title"))
+	assert.Equal("5", gotHeader.Get("Priority"))
+	assert.Equal("warning,skull", gotHeader.Get("Tags"))
+	assert.Equal("https://example.com", gotHeader.Get("Click"))
+	assert.Equal("Bearer token", gotHeader.Get("Authorization"))
+
+	// Test send options
+	ctx := notify.ContextWithSendOptions(context.Background(),
+		notify.WithPriority(notify.PriorityLow),
+		notify.WithTags("db"),
+		notify.WithReceiversOverride("db-alerts"),
+	)
+	err = service.Send(ctx, "subject", "message")
+	assert.Nil(err)
+	assert.Equal("/db-alerts", gotPath)
+	assert.Equal("2", gotHeader.Get("Priority"))
+	assert.Equal("warning,skull,db", gotHeader.Get("Tags"))
+
+	// Test error response
+	service.AddReceivers("forbidden")
+	err = service.Send(context.Background(), "subject", "message")
+	assert.NotNil(err)
+}
@@ -0,0 +1,33 @@
+/*
+Package onesignal provides a service for sending push notifications via OneSignal.
+
+Usage:
+
+	package main
+
+	import (
+	    "context"
+	    "log"
+
+	    "github.com/nikoksr/notify"
+	    "github.com/nikoksr/notify/service/onesignal"
+	)
+
+	func main() {
+	    oneSignalService := onesignal.New("your-app-id", "your-rest-api-key")
+
+	    // Target segments, users or devices.
+	    oneSignalService.AddSegments("Subscribed Users")
+	    oneSignalService.AddExternalUserIDs("user-42")
+	    oneSignalService.AddPlayerIDs("1dd608f2-c6a1-11e3-851d-000c2940e62c")
+
+	    // Tell our notifier to use the onesignal service.
+	    notify.UseServices(oneSignalService)
+
+	    // Send a test message.
+	    if err := notify.Send(context.Background(), "Subject/Title", "The actual message"); err != nil {
+	        log.Fatal(err)
+	    }
+	}
+*/
+package onesignal
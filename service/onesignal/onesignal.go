@@ -0,0 +1,167 @@
+package onesignal
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
+)
+
+const defaultAPIURL = "https://onesignal.com/api/v1/notifications"
+
+// Service encapsulates the OneSignal client.
+type Service struct {
+	client          *http.Client
+	apiURL          string
+	appID           string
+	restAPIKey      string
+	segments        []string
+	externalUserIDs []string
+	playerIDs       []string
+	url             string
+}
+
+// New returns a new instance of a OneSignal notification service. The app ID and REST API key can be found under
+// Settings > Keys & IDs of the app in the OneSignal dashboard.
+// For more information about the OneSignal API:
+//
+//	-> https://documentation.onesignal.com/reference/create-notification
+func New(appID, restAPIKey string) *Service {
+	return &Service{
+		client: &http.Client{
+			Transport: notify.NewRateLimitTransport(nil),
+			Timeout:   10 * time.Second,
+		},
+		apiURL:     defaultAPIURL,
+		appID:      appID,
+		restAPIKey: restAPIKey,
+	}
+}
+
+// WithClient sets the http client to be used for sending requests. Calling this method is optional, the default client
+// will be used if this method is not called.
+func (s *Service) WithClient(client *http.Client) {
+	if client != nil {
+		s.client = client
+	}
+}
+
+// AddSegments adds segments, e.g. "Subscribed Users", whose subscribers receive the notifications.
+func (s *Service) AddSegments(segments ...string) {
+	s.segments = append(s.segments, segments...)
+}
+
+// AddExternalUserIDs adds users identified by the external user ID set by your app.
+func (s *Service) AddExternalUserIDs(ids ...string) {
+	s.externalUserIDs = append(s.externalUserIDs, ids...)
+}
+
+// AddPlayerIDs adds devices identified by their OneSignal player (subscription) ID.
+func (s *Service) AddPlayerIDs(ids ...string) {
+	s.playerIDs = append(s.playerIDs, ids...)
+}
+
+// SetURL sets a URL that is opened when the notification is clicked.
+func (s *Service) SetURL(url string) {
+	s.url = url
+}
+
+type notification struct {
+	AppID                  string            `json:"app_id"`
+	Headings               map[string]string `json:"headings"`
+	Contents               map[string]string `json:"contents"`
+	URL                    string            `json:"url,omitempty"`
+	IncludedSegments       []string          `json:"included_segments,omitempty"`
+	IncludeExternalUserIDs []string          `json:"include_external_user_ids,omitempty"`
+	IncludePlayerIDs       []string          `json:"include_player_ids,omitempty"`
+}
+
+type response struct {
+	ID     string          `json:"id"`
+	Errors json.RawMessage `json:"errors"`
+}
+
+// Send takes a message subject and a message body and sends them as push notification to all previously set segments,
+// external user IDs and player IDs. OneSignal doesn't allow mixing targeting methods, so one notification is created
+// per method in use.
+func (s *Service) Send(ctx context.Context, subject, message string) error {
+	base := notification{
+		AppID:    s.appID,
+		Headings: map[string]string{"en": subject},
+		Contents: map[string]string{"en": message},
+		URL:      s.url,
+	}
+
+	var notifications []notification
+	if len(s.segments) > 0 {
+		n := base
+		n.IncludedSegments = s.segments
+		notifications = append(notifications, n)
+	}
+	if len(s.externalUserIDs) > 0 {
+		n := base
+		n.IncludeExternalUserIDs = s.externalUserIDs
+		notifications = append(notifications, n)
+	}
+	if len(s.playerIDs) > 0 {
+		n := base
+		n.IncludePlayerIDs = s.playerIDs
+		notifications = append(notifications, n)
+	}
+
+	for _, n := range notifications {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			if err := s.send(ctx, n); err != nil {
+				return errors.Wrap(err, "failed to send notification")
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) send(ctx context.Context, n notification) error {
+	body, err := json.Marshal(n)
+	if err != nil {
+		return errors.Wrap(err, "marshal notification")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.apiURL, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "create request")
+	}
+	req.Header.Set("Authorization", "Basic "+s.restAPIKey)
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "send request")
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	b, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return notify.ClassifyResponse(resp, fmt.Errorf("status %d: %s", resp.StatusCode, string(b)))
+	}
+
+	// OneSignal responds with 200 and an empty ID if none of the targeted recipients are subscribed.
+	var result response
+	if err = json.Unmarshal(b, &result); err != nil {
+		return errors.Wrap(err, "decode response")
+	}
+	if result.ID == "" {
+		return fmt.Errorf("no notification created: %s", string(result.Errors))
+	}
+
+	return nil
+}
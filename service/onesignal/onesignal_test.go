@@ -0,0 +1,75 @@
+package onesignal
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOneSignal_Send(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	var got []notification
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Basic key" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"errors":["Please include a case-sensitive header of Authorization"]}`))
+			return
+		}
+		var n notification
+		_ = json.NewDecoder(r.Body).Decode(&n)
+		got = append(got, n)
+		if len(n.IncludePlayerIDs) > 0 && n.IncludePlayerIDs[0] == "unsubscribed" {
+			_, _ = w.Write([]byte(`{"id":"","errors":["All included players are not subscribed"]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"b98881cc","recipients":1}`))
+	}))
+	defer server.Close()
+
+	service := New("app", "key")
+	service.apiURL = server.URL
+
+	// No receivers added
+	assert.NoError(service.Send(context.Background(), "subject", "message"))
+	assert.Empty(got)
+
+	service.AddSegments("Subscribed Users")
+	service.AddExternalUserIDs("user-1", "user-2")
+	service.SetURL("https://example.com")
+	assert.NoError(service.Send(context.Background(), "subject", "message"))
+	assert.Equal([]notification{
+		{
+			AppID:            "app",
+			Headings:         map[string]string{"en": "subject"},
+			Contents:         map[string]string{"en": "message"},
+			URL:              "https://example.com",
+			IncludedSegments: []string{"Subscribed Users"},
+		},
+		{
+			AppID:                  "app",
+			Headings:               map[string]string{"en": "subject"},
+			Contents:               map[string]string{"en": "message"},
+			URL:                    "https://example.com",
+			IncludeExternalUserIDs: []string{"user-1", "user-2"},
+		},
+	}, got)
+
+	// Test no subscribed recipients
+	service = New("app", "key")
+	service.apiURL = server.URL
+	service.AddPlayerIDs("unsubscribed")
+	assert.ErrorContains(service.Send(context.Background(), "subject", "message"), "not subscribed")
+
+	// Test error response
+	service = New("app", "invalid")
+	service.apiURL = server.URL
+	service.AddSegments("Subscribed Users")
+	assert.Error(service.Send(context.Background(), "subject", "message"))
+}
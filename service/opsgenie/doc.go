@@ -0,0 +1,41 @@
+/*
+Package opsgenie provides a service for creating alerts through the Opsgenie Alert API.
+
+Usage:
+
+	package main
+
+	import (
+	    "context"
+	    "log"
+
+	    "github.com/nikoksr/notify"
+	    "github.com/nikoksr/notify/service/opsgenie"
+	)
+
+	func main() {
+	    // Create an opsgenie service using the key of an API integration.
+	    opsgenieService := opsgenie.New("your-api-key")
+
+	    // Accounts hosted in the EU have to use the EU endpoint.
+	    // opsgenieService.WithAPIURL(opsgenie.EUAPIURL)
+
+	    // Route alerts to teams or other responders.
+	    opsgenieService.AddTeams("ops")
+	    opsgenieService.AddReceivers(opsgenie.Responder{Type: opsgenie.ResponderUser, Username: "jane@example.com"})
+
+	    // Configure priority, tags and an alias for deduplication.
+	    opsgenieService.SetPriority(opsgenie.PriorityP1)
+	    opsgenieService.SetTags("database", "production")
+	    opsgenieService.SetAlias("db-01/replication-lag")
+
+	    // Tell our notifier to use the opsgenie service.
+	    notify.UseServices(opsgenieService)
+
+	    // Create an alert.
+	    if err := notify.Send(context.Background(), "Replication lag", "db-01 is 10 minutes behind"); err != nil {
+	        log.Fatal(err)
+	    }
+	}
+*/
+package opsgenie
@@ -0,0 +1,229 @@
+package opsgenie
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
+)
+
+// API endpoints of the Opsgenie Alert API.
+const (
+	DefaultAPIURL = "https://api.opsgenie.com"
+	EUAPIURL      = "https://api.eu.opsgenie.com"
+)
+
+// maxMessageLength is the maximum length of an alert message accepted by Opsgenie.
+const maxMessageLength = 130
+
+// Priority represents the priority of an Opsgenie alert.
+type Priority string
+
+// All priorities supported by Opsgenie, P1 being the highest.
+const (
+	PriorityP1 Priority = "P1"
+	PriorityP2 Priority = "P2"
+	PriorityP3 Priority = "P3"
+	PriorityP4 Priority = "P4"
+	PriorityP5 Priority = "P5"
+)
+
+// priorities maps the priorities of notify.WithPriority to Opsgenie priorities.
+var priorities = map[notify.Priority]Priority{
+	notify.PriorityLow:      PriorityP5,
+	notify.PriorityNormal:   PriorityP3,
+	notify.PriorityHigh:     PriorityP2,
+	notify.PriorityCritical: PriorityP1,
+}
+
+// ResponderType represents the type of an alert responder.
+type ResponderType string
+
+// All responder types supported by Opsgenie.
+const (
+	ResponderTeam       ResponderType = "team"
+	ResponderUser       ResponderType = "user"
+	ResponderEscalation ResponderType = "escalation"
+	ResponderSchedule   ResponderType = "schedule"
+)
+
+// Responder is a team, user, escalation or schedule the alert gets routed to. Either the ID or the name (username for
+// users) has to be set.
+type Responder struct {
+	Type     ResponderType `json:"type"`
+	ID       string        `json:"id,omitempty"`
+	Name     string        `json:"name,omitempty"`
+	Username string        `json:"username,omitempty"`
+}
+
+// Service allow you to configure the Opsgenie service.
+type Service struct {
+	client     *http.Client
+	apiURL     string
+	apiKey     string
+	responders []Responder
+
+	priority Priority
+	tags     []string
+	alias    string
+}
+
+func defaultHTTPClient() *http.Client {
+	return &http.Client{
+		Transport: notify.NewRateLimitTransport(nil),
+		Timeout:   10 * time.Second,
+	}
+}
+
+// New returns a new instance of an Opsgenie notification service. The apiKey is the key of an API integration.
+// For more information about the Alert API:
+//
+//	-> https://docs.opsgenie.com/docs/alert-api
+func New(apiKey string) *Service {
+	return &Service{
+		client:     defaultHTTPClient(),
+		apiURL:     DefaultAPIURL,
+		apiKey:     apiKey,
+		responders: []Responder{},
+		priority:   PriorityP3,
+	}
+}
+
+// WithClient sets the http client to be used for sending requests. Calling this method is optional, the default client
+// will be used if this method is not called.
+func (s *Service) WithClient(client *http.Client) {
+	if client != nil {
+		s.client = client
+	}
+}
+
+// WithAPIURL sets the API URL to use, e.g. EUAPIURL for accounts hosted in the EU.
+func (s *Service) WithAPIURL(apiURL string) {
+	if apiURL != "" {
+		s.apiURL = apiURL
+	}
+}
+
+// AddReceivers takes responders and adds them to the internal responder list. All created alerts get routed to
+// those responders.
+func (s *Service) AddReceivers(responders ...Responder) {
+	s.responders = append(s.responders, responders...)
+}
+
+// AddTeams is a convenience method that adds the teams with the given names as responders.
+func (s *Service) AddTeams(names ...string) {
+	for _, name := range names {
+		s.AddReceivers(Responder{Type: ResponderTeam, Name: name})
+	}
+}
+
+// SetPriority sets the priority of alerts created by this service. The priority of a notification, see
+// notify.WithPriority, overrides it: low is P5, normal P3, high P2 and critical P1.
+func (s *Service) SetPriority(priority Priority) {
+	s.priority = priority
+}
+
+// SetTags sets the tags of all alerts created by this service.
+func (s *Service) SetTags(tags ...string) {
+	s.tags = tags
+}
+
+// SetAlias sets the alias of all alerts created by this service. Opsgenie deduplicates open alerts with the same
+// alias instead of creating new ones.
+func (s *Service) SetAlias(alias string) {
+	s.alias = alias
+}
+
+// alert is the request body expected by the create alert endpoint.
+type alert struct {
+	Message     string      `json:"message"`
+	Alias       string      `json:"alias,omitempty"`
+	Description string      `json:"description,omitempty"`
+	Responders  []Responder `json:"responders,omitempty"`
+	Tags        []string    `json:"tags,omitempty"`
+	Priority    Priority    `json:"priority,omitempty"`
+	Source      string      `json:"source,omitempty"`
+}
+
+// truncate shortens the given string to at most n runes.
+func truncate(str string, n int) string {
+	runes := []rune(str)
+	if len(runes) <= n {
+		return str
+	}
+
+	return string(runes[:n])
+}
+
+// Validate checks that the API key is set, that the API URL is well-formed and that every responder is identified by
+// ID, name or username, see notify.Validator.
+func (s *Service) Validate() error {
+	var problems notify.ConfigErrors
+	problems.Require("API key", s.apiKey)
+	problems.Check("API URL", notify.ValidateURL(s.apiURL))
+	for _, responder := range s.responders {
+		if responder.ID == "" && responder.Name == "" && responder.Username == "" {
+			problems.Addf("%s responder has neither ID, name nor username", responder.Type)
+		}
+	}
+
+	return problems.Err()
+}
+
+// Send takes a message subject and a message body and creates an alert from them. The subject is used as the alert
+// message, which is limited to 130 characters, and the body as the alert description.
+func (s *Service) Send(ctx context.Context, subject, message string) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+
+	if subject == "" {
+		subject = message
+	}
+	priority := s.priority
+	if mapped, ok := priorities[notify.SendOptionsFromContext(ctx).Priority]; ok {
+		priority = mapped
+	}
+
+	body, err := json.Marshal(&alert{
+		Message:     truncate(subject, maxMessageLength),
+		Alias:       s.alias,
+		Description: message,
+		Responders:  s.responders,
+		Tags:        s.tags,
+		Priority:    priority,
+		Source:      "notify",
+	})
+	if err != nil {
+		return errors.Wrap(err, "marshal alert")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.apiURL+"/v2/alerts", bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "create request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "GenieKey "+s.apiKey)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "send request")
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusAccepted {
+		result, _ := io.ReadAll(resp.Body)
+		return notify.ClassifyResponse(resp, fmt.Errorf("opsgenie returned status code %d: %s", resp.StatusCode, string(result)))
+	}
+
+	return nil
+}
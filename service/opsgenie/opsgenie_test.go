@@ -0,0 +1,102 @@
+package opsgenie
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nikoksr/notify"
+)
+
+func TestOpsgenie_New(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := New("key")
+	assert.NotNil(service)
+	assert.Equal(DefaultAPIURL, service.apiURL)
+
+	service.WithAPIURL(EUAPIURL)
+	assert.Equal(EUAPIURL, service.apiURL)
+}
+
+func TestOpsgenie_AddReceivers(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := New("key")
+	service.AddTeams("ops", "dev")
+	service.AddReceivers(Responder{Type: ResponderUser, Username: "jane"})
+	assert.Len(service.responders, 3)
+	assert.Equal(Responder{Type: ResponderTeam, Name: "ops"}, service.responders[0])
+}
+
+func TestOpsgenie_Validate(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := New("key")
+	service.AddTeams("ops")
+	service.AddReceivers(Responder{Type: ResponderUser, Username: "jane"})
+	assert.NoError(service.Validate())
+
+	service = New("")
+	service.WithAPIURL("api.opsgenie.com")
+	service.AddReceivers(Responder{Type: ResponderSchedule})
+	err := service.Validate()
+	assert.ErrorIs(err, notify.ErrInvalidConfig)
+	assert.ErrorContains(err, "API key is missing")
+	assert.ErrorContains(err, "API URL")
+	assert.ErrorContains(err, "schedule responder has neither ID, name nor username")
+}
+
+func TestOpsgenie_Send(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	var got alert
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "GenieKey key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	service := New("key")
+	service.WithAPIURL(server.URL)
+	service.AddTeams("ops")
+	service.SetPriority(PriorityP1)
+	service.SetTags("db")
+	service.SetAlias("alias")
+
+	err := service.Send(context.Background(), strings.Repeat("a", 200), "message")
+	assert.Nil(err)
+	assert.Len(got.Message, maxMessageLength)
+	assert.Equal("message", got.Description)
+	assert.Equal(PriorityP1, got.Priority)
+	assert.Equal("alias", got.Alias)
+	assert.Equal([]string{"db"}, got.Tags)
+
+	// The priority of the notification overrides the priority of the service.
+	ctx := notify.ContextWithSendOptions(context.Background(), notify.WithPriority(notify.PriorityHigh))
+	assert.Nil(service.Send(ctx, "subject", "message"))
+	assert.Equal(PriorityP2, got.Priority)
+
+	// Test error response
+	service = New("wrong")
+	service.WithAPIURL(server.URL)
+	err = service.Send(context.Background(), "subject", "message")
+	assert.NotNil(err)
+}
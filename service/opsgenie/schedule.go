@@ -0,0 +1,104 @@
+package opsgenie
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
+)
+
+// Compile-time check to ensure that Schedule implements the notify.Schedule interface.
+var _ notify.Schedule = (*Schedule)(nil)
+
+// Schedule resolves the people on call from an Opsgenie schedule, see notify.WithSchedule. The usernames of the people,
+// which are their mail addresses, are used as receivers.
+type Schedule struct {
+	client   *http.Client
+	apiURL   string
+	apiKey   string
+	schedule string
+}
+
+// NewSchedule returns a new schedule that queries the Opsgenie schedule with the given name. The apiKey is the key of
+// an API integration with read access.
+// For more information about the Who is On Call API:
+//
+//	-> https://docs.opsgenie.com/docs/who-is-on-call-api
+func NewSchedule(apiKey, scheduleName string) *Schedule {
+	return &Schedule{
+		client:   defaultHTTPClient(),
+		apiURL:   DefaultAPIURL,
+		apiKey:   apiKey,
+		schedule: scheduleName,
+	}
+}
+
+// WithClient sets the http client to be used for sending requests. Calling this method is optional, the default client
+// will be used if this method is not called.
+func (s *Schedule) WithClient(client *http.Client) {
+	if client != nil {
+		s.client = client
+	}
+}
+
+// WithAPIURL sets the API URL to use, e.g. EUAPIURL for accounts hosted in the EU.
+func (s *Schedule) WithAPIURL(apiURL string) {
+	if apiURL != "" {
+		s.apiURL = apiURL
+	}
+}
+
+// onCallsResponse is the response of the Who is On Call API with flat results.
+type onCallsResponse struct {
+	Data struct {
+		OnCallRecipients []string `json:"onCallRecipients"`
+	} `json:"data"`
+}
+
+// OnCall returns the people on call at the given time. If several people are on call, all of them are notified.
+func (s *Schedule) OnCall(ctx context.Context, at time.Time) (notify.OnCall, error) {
+	query := url.Values{
+		"scheduleIdentifierType": {"name"},
+		"flat":                   {"true"},
+		"date":                   {at.UTC().Format(time.RFC3339)},
+	}
+	endpoint := s.apiURL + "/v2/schedules/" + url.PathEscape(s.schedule) + "/on-calls?" + query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, http.NoBody)
+	if err != nil {
+		return notify.OnCall{}, errors.Wrap(err, "create request")
+	}
+	req.Header.Set("Authorization", "GenieKey "+s.apiKey)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return notify.OnCall{}, errors.Wrap(err, "send request")
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		result, _ := io.ReadAll(resp.Body)
+		return notify.OnCall{}, notify.ClassifyResponse(resp,
+			fmt.Errorf("opsgenie returned status code %d: %s", resp.StatusCode, string(result)))
+	}
+
+	var response onCallsResponse
+	if err = json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return notify.OnCall{}, errors.Wrap(err, "decode response")
+	}
+
+	recipients := response.Data.OnCallRecipients
+	if len(recipients) == 0 {
+		return notify.OnCall{}, errors.Errorf("nobody is on call on Opsgenie schedule '%s'", s.schedule)
+	}
+
+	return notify.OnCall{Name: strings.Join(recipients, ", "), Receivers: recipients}, nil
+}
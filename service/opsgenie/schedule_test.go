@@ -0,0 +1,48 @@
+package opsgenie
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nikoksr/notify"
+)
+
+func TestSchedule_OnCall(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("GenieKey key", r.Header.Get("Authorization"))
+		if r.URL.Path == "/v2/schedules/nobody/on-calls" {
+			_, _ = w.Write([]byte(`{"data": {"onCallRecipients": []}}`))
+			return
+		}
+		assert.Equal("/v2/schedules/ops team/on-calls", r.URL.Path)
+		assert.Equal("name", r.URL.Query().Get("scheduleIdentifierType"))
+		assert.Equal("2024-01-01T09:00:00Z", r.URL.Query().Get("date"))
+		_, _ = w.Write([]byte(`{"data": {"onCallRecipients": ["alice@example.com", "bob@example.com"]}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	at := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+
+	schedule := NewSchedule("key", "ops team")
+	schedule.WithAPIURL(server.URL)
+	onCall, err := schedule.OnCall(context.Background(), at)
+	assert.Nil(err)
+	assert.Equal(notify.OnCall{
+		Name:      "alice@example.com, bob@example.com",
+		Receivers: []string{"alice@example.com", "bob@example.com"},
+	}, onCall)
+
+	schedule = NewSchedule("key", "nobody")
+	schedule.WithAPIURL(server.URL)
+	_, err = schedule.OnCall(context.Background(), at)
+	assert.NotNil(err)
+}
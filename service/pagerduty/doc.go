@@ -0,0 +1,42 @@
+/*
+Package pagerduty provides a service for triggering, acknowledging and resolving PagerDuty alerts through the Events
+API v2.
+
+Usage:
+
+	package main
+
+	import (
+	    "context"
+	    "log"
+
+	    "github.com/nikoksr/notify"
+	    "github.com/nikoksr/notify/service/pagerduty"
+	)
+
+	func main() {
+	    // Create a pagerduty service. The source identifies the affected system.
+	    pagerdutyService := pagerduty.New("api-01.example.com")
+
+	    // Add the routing keys of your Events API v2 integrations.
+	    pagerdutyService.AddReceivers("your-routing-key")
+
+	    // Configure the severity and a dedup key, so repeated notifications are grouped into one alert.
+	    pagerdutyService.SetSeverity(pagerduty.SeverityCritical)
+	    pagerdutyService.SetDedupKey("api-01/disk-full")
+
+	    // Tell our notifier to use the pagerduty service.
+	    notify.UseServices(pagerdutyService)
+
+	    // Trigger an alert.
+	    if err := notify.Send(context.Background(), "Disk full", "/var is at 99%"); err != nil {
+	        log.Fatal(err)
+	    }
+
+	    // Resolve it once the problem is gone.
+	    if err := pagerdutyService.Resolve(context.Background(), "api-01/disk-full"); err != nil {
+	        log.Fatal(err)
+	    }
+	}
+*/
+package pagerduty
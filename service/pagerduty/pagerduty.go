@@ -0,0 +1,223 @@
+package pagerduty
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
+)
+
+// DefaultEventsURL is the default endpoint of PagerDuty's Events API v2.
+const DefaultEventsURL = "https://events.pagerduty.com/v2/enqueue"
+
+// Severity represents the perceived severity of the status the event is describing.
+type Severity string
+
+// All severities supported by PagerDuty.
+const (
+	SeverityCritical Severity = "critical"
+	SeverityError    Severity = "error"
+	SeverityWarning  Severity = "warning"
+	SeverityInfo     Severity = "info"
+)
+
+// severities maps the priorities of notify.WithPriority to PagerDuty severities.
+var severities = map[notify.Priority]Severity{
+	notify.PriorityLow:      SeverityInfo,
+	notify.PriorityNormal:   SeverityWarning,
+	notify.PriorityHigh:     SeverityError,
+	notify.PriorityCritical: SeverityCritical,
+}
+
+// EventAction represents the type of event that is sent to PagerDuty.
+type EventAction string
+
+// All event actions supported by PagerDuty.
+const (
+	EventActionTrigger     EventAction = "trigger"
+	EventActionAcknowledge EventAction = "acknowledge"
+	EventActionResolve     EventAction = "resolve"
+)
+
+// Service allow you to configure the PagerDuty service.
+type Service struct {
+	client      *http.Client
+	eventsURL   string
+	routingKeys []string
+
+	source   string
+	severity Severity
+	dedupKey string
+}
+
+func defaultHTTPClient() *http.Client {
+	return &http.Client{
+		Transport: notify.NewRateLimitTransport(nil),
+		Timeout:   10 * time.Second,
+	}
+}
+
+// New returns a new instance of a PagerDuty notification service. The source is the unique location of the affected
+// system, preferably a hostname or FQDN, and is shown on the incident.
+// For more information about the Events API v2:
+//
+//	-> https://developer.pagerduty.com/docs/events-api-v2/overview
+func New(source string) *Service {
+	if source == "" {
+		source = "notify"
+	}
+
+	return &Service{
+		client:      defaultHTTPClient(),
+		eventsURL:   DefaultEventsURL,
+		routingKeys: []string{},
+		source:      source,
+		severity:    SeverityError,
+	}
+}
+
+// WithClient sets the http client to be used for sending requests. Calling this method is optional, the default client
+// will be used if this method is not called.
+func (s *Service) WithClient(client *http.Client) {
+	if client != nil {
+		s.client = client
+	}
+}
+
+// AddReceivers takes PagerDuty integration routing keys and adds them to the internal routing key list. The Send
+// method will trigger an event for every one of those routing keys.
+func (s *Service) AddReceivers(routingKeys ...string) {
+	s.routingKeys = append(s.routingKeys, routingKeys...)
+}
+
+// SetSeverity sets the severity of events triggered by this service. The priority of a notification, see
+// notify.WithPriority, overrides it: low is info, normal warning, high error and critical critical.
+func (s *Service) SetSeverity(severity Severity) {
+	s.severity = severity
+}
+
+// SetDedupKey sets the deduplication key of all events triggered by this service. Events sharing a dedup key are
+// grouped into the same alert. If left empty, PagerDuty generates a new key for every event.
+func (s *Service) SetDedupKey(dedupKey string) {
+	s.dedupKey = dedupKey
+}
+
+// eventPayload holds the details of a triggered event.
+type eventPayload struct {
+	Summary       string         `json:"summary"`
+	Source        string         `json:"source"`
+	Severity      Severity       `json:"severity"`
+	CustomDetails map[string]any `json:"custom_details,omitempty"`
+}
+
+// event is the request body expected by the Events API v2.
+type event struct {
+	RoutingKey  string        `json:"routing_key"`
+	EventAction EventAction   `json:"event_action"`
+	DedupKey    string        `json:"dedup_key,omitempty"`
+	Payload     *eventPayload `json:"payload,omitempty"`
+}
+
+func (s *Service) send(ctx context.Context, e *event) error {
+	body, err := json.Marshal(e)
+	if err != nil {
+		return errors.Wrap(err, "marshal event")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.eventsURL, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "create request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "send request")
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusAccepted {
+		result, _ := io.ReadAll(resp.Body)
+		return notify.ClassifyResponse(resp, fmt.Errorf("pagerduty returned status code %d: %s", resp.StatusCode, string(result)))
+	}
+
+	return nil
+}
+
+// sendAll sends an event built by newEvent to every routing key.
+func (s *Service) sendAll(ctx context.Context, newEvent func(routingKey string) *event) error {
+	for _, routingKey := range s.routingKeys {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			if err := s.send(ctx, newEvent(routingKey)); err != nil {
+				return errors.Wrap(err, "failed to send event to PagerDuty")
+			}
+		}
+	}
+
+	return nil
+}
+
+// Validate checks that the events URL is well-formed and that the routing keys look like integration keys, see
+// notify.Validator.
+func (s *Service) Validate() error {
+	var problems notify.ConfigErrors
+	problems.Check("events URL", notify.ValidateURL(s.eventsURL))
+	for i, routingKey := range s.routingKeys {
+		if len(routingKey) != 32 {
+			problems.Addf("routing key %d must be 32 characters long", i+1)
+		}
+	}
+
+	return problems.Err()
+}
+
+// Send takes a message subject and a message body and triggers an event for every previously set routing key. The
+// subject is used as the event summary, the message is attached as custom detail.
+func (s *Service) Send(ctx context.Context, subject, message string) error {
+	summary := subject
+	if summary == "" {
+		summary = message
+	}
+	severity := s.severity
+	if mapped, ok := severities[notify.SendOptionsFromContext(ctx).Priority]; ok {
+		severity = mapped
+	}
+
+	return s.sendAll(ctx, func(routingKey string) *event {
+		return &event{
+			RoutingKey:  routingKey,
+			EventAction: EventActionTrigger,
+			DedupKey:    s.dedupKey,
+			Payload: &eventPayload{
+				Summary:       summary,
+				Source:        s.source,
+				Severity:      severity,
+				CustomDetails: map[string]any{"message": message},
+			},
+		}
+	})
+}
+
+// Acknowledge acknowledges the alert identified by the given dedup key for every previously set routing key.
+func (s *Service) Acknowledge(ctx context.Context, dedupKey string) error {
+	return s.sendAll(ctx, func(routingKey string) *event {
+		return &event{RoutingKey: routingKey, EventAction: EventActionAcknowledge, DedupKey: dedupKey}
+	})
+}
+
+// Resolve resolves the alert identified by the given dedup key for every previously set routing key.
+func (s *Service) Resolve(ctx context.Context, dedupKey string) error {
+	return s.sendAll(ctx, func(routingKey string) *event {
+		return &event{RoutingKey: routingKey, EventAction: EventActionResolve, DedupKey: dedupKey}
+	})
+}
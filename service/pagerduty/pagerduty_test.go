@@ -0,0 +1,100 @@
+package pagerduty
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nikoksr/notify"
+)
+
+func newTestServer(t *testing.T, events *[]event) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var e event
+		_ = json.NewDecoder(r.Body).Decode(&e)
+		if e.RoutingKey == "invalid" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		*events = append(*events, e)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestPagerDuty_New(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := New("")
+	assert.NotNil(service)
+	assert.Equal("notify", service.source)
+	assert.Equal(SeverityError, service.severity)
+}
+
+func TestPagerDuty_Send(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	var events []event
+	server := newTestServer(t, &events)
+
+	service := New("host")
+	service.eventsURL = server.URL
+	service.SetSeverity(SeverityCritical)
+	service.SetDedupKey("dedup")
+	service.AddReceivers("key")
+
+	err := service.Send(context.Background(), "subject", "message")
+	assert.Nil(err)
+	assert.Len(events, 1)
+	assert.Equal(EventActionTrigger, events[0].EventAction)
+	assert.Equal("dedup", events[0].DedupKey)
+	assert.Equal("subject", events[0].Payload.Summary)
+	assert.Equal(SeverityCritical, events[0].Payload.Severity)
+
+	err = service.Acknowledge(context.Background(), "dedup")
+	assert.Nil(err)
+	err = service.Resolve(context.Background(), "dedup")
+	assert.Nil(err)
+	assert.Len(events, 3)
+	assert.Equal(EventActionAcknowledge, events[1].EventAction)
+	assert.Equal(EventActionResolve, events[2].EventAction)
+	assert.Nil(events[2].Payload)
+
+	// The priority of the notification overrides the severity.
+	ctx := notify.ContextWithSendOptions(context.Background(), notify.WithPriority(notify.PriorityLow))
+	assert.Nil(service.Send(ctx, "subject", "message"))
+	assert.Equal(SeverityInfo, events[3].Payload.Severity)
+
+	// Test error response
+	service.AddReceivers("invalid")
+	err = service.Send(context.Background(), "subject", "message")
+	assert.NotNil(err)
+}
+
+func TestPagerDuty_Validate(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := New("notify")
+	service.AddReceivers("0123456789abcdef0123456789abcdef")
+	assert.NoError(service.Validate())
+
+	service.AddReceivers("too-short")
+	err := service.Validate()
+	assert.ErrorIs(err, notify.ErrInvalidConfig)
+	assert.ErrorContains(err, "routing key 2 must be 32 characters long")
+	assert.NotContains(err.Error(), "too-short")
+}
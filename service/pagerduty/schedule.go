@@ -0,0 +1,111 @@
+package pagerduty
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
+)
+
+// DefaultAPIURL is the default endpoint of PagerDuty's REST API.
+const DefaultAPIURL = "https://api.pagerduty.com"
+
+// Compile-time check to ensure that Schedule implements the notify.Schedule interface.
+var _ notify.Schedule = (*Schedule)(nil)
+
+// Schedule resolves the person on call from a PagerDuty schedule, see notify.WithSchedule. The mail address of the
+// person is used as receiver.
+type Schedule struct {
+	client     *http.Client
+	apiURL     string
+	apiKey     string
+	scheduleID string
+}
+
+// NewSchedule returns a new schedule that queries the PagerDuty schedule with the given ID. The apiKey is a REST API
+// key with read access.
+// For more information about the on-calls API:
+//
+//	-> https://developer.pagerduty.com/api-reference/3a6b910f11050-list-all-of-the-on-calls
+func NewSchedule(apiKey, scheduleID string) *Schedule {
+	return &Schedule{
+		client:     defaultHTTPClient(),
+		apiURL:     DefaultAPIURL,
+		apiKey:     apiKey,
+		scheduleID: scheduleID,
+	}
+}
+
+// WithClient sets the http client to be used for sending requests. Calling this method is optional, the default client
+// will be used if this method is not called.
+func (s *Schedule) WithClient(client *http.Client) {
+	if client != nil {
+		s.client = client
+	}
+}
+
+// onCallsResponse is the response of the on-calls API.
+type onCallsResponse struct {
+	OnCalls []struct {
+		EscalationLevel int `json:"escalation_level"`
+		User            struct {
+			Summary string `json:"summary"`
+			Email   string `json:"email"`
+		} `json:"user"`
+	} `json:"oncalls"`
+}
+
+// OnCall returns the user on call at the given time on the lowest escalation level of the schedule.
+func (s *Schedule) OnCall(ctx context.Context, at time.Time) (notify.OnCall, error) {
+	query := url.Values{
+		"schedule_ids[]": {s.scheduleID},
+		"include[]":      {"users"},
+		"since":          {at.UTC().Format(time.RFC3339)},
+		"until":          {at.Add(time.Minute).UTC().Format(time.RFC3339)},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.apiURL+"/oncalls?"+query.Encode(), http.NoBody)
+	if err != nil {
+		return notify.OnCall{}, errors.Wrap(err, "create request")
+	}
+	req.Header.Set("Accept", "application/vnd.pagerduty+json;version=2")
+	req.Header.Set("Authorization", "Token token="+s.apiKey)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return notify.OnCall{}, errors.Wrap(err, "send request")
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		result, _ := io.ReadAll(resp.Body)
+		return notify.OnCall{}, notify.ClassifyResponse(resp,
+			fmt.Errorf("pagerduty returned status code %d: %s", resp.StatusCode, string(result)))
+	}
+
+	var response onCallsResponse
+	if err = json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return notify.OnCall{}, errors.Wrap(err, "decode response")
+	}
+
+	found := -1
+	for i, onCall := range response.OnCalls {
+		if found < 0 || onCall.EscalationLevel < response.OnCalls[found].EscalationLevel {
+			found = i
+		}
+	}
+	if found < 0 {
+		return notify.OnCall{}, errors.Errorf("nobody is on call on PagerDuty schedule '%s'", s.scheduleID)
+	}
+
+	user := response.OnCalls[found].User
+
+	return notify.OnCall{Name: user.Summary, Receivers: []string{user.Email}}, nil
+}
@@ -0,0 +1,54 @@
+package pagerduty
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nikoksr/notify"
+)
+
+func TestSchedule_OnCall(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Token token=key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Query().Get("schedule_ids[]") == "empty" {
+			_, _ = w.Write([]byte(`{"oncalls": []}`))
+			return
+		}
+		assert.Equal("2024-01-01T09:00:00Z", r.URL.Query().Get("since"))
+		_, _ = w.Write([]byte(`{"oncalls": [
+			{"escalation_level": 2, "user": {"summary": "Bob", "email": "bob@example.com"}},
+			{"escalation_level": 1, "user": {"summary": "Alice", "email": "alice@example.com"}}
+		]}`))
+	}))
+	t.Cleanup(server.Close)
+
+	at := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+
+	schedule := NewSchedule("key", "PSCHED")
+	schedule.apiURL = server.URL
+	onCall, err := schedule.OnCall(context.Background(), at)
+	assert.Nil(err)
+	assert.Equal(notify.OnCall{Name: "Alice", Receivers: []string{"alice@example.com"}}, onCall)
+
+	schedule = NewSchedule("key", "empty")
+	schedule.apiURL = server.URL
+	_, err = schedule.OnCall(context.Background(), at)
+	assert.NotNil(err)
+
+	schedule = NewSchedule("wrong", "PSCHED")
+	schedule.apiURL = server.URL
+	_, err = schedule.OnCall(context.Background(), at)
+	assert.ErrorIs(err, notify.ErrAuthFailed)
+}
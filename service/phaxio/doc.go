@@ -0,0 +1,37 @@
+/*
+Package phaxio provides a service for faxing notifications through Phaxio.
+
+Notifications are rendered into a PDF document, which is then faxed to all receivers.
+
+Usage:
+
+	package main
+
+	import (
+	    "context"
+	    "log"
+
+	    "github.com/nikoksr/notify"
+	    "github.com/nikoksr/notify/service/phaxio"
+	)
+
+	func main() {
+	    phaxioService := phaxio.New("your-api-key", "your-api-secret")
+
+	    // Optionally set the sending number and the page header.
+	    phaxioService.SetCallerID("+15550000000")
+	    phaxioService.SetHeaderText("ACME Corp Incident Notice")
+
+	    // Add fax numbers in E.164 format.
+	    phaxioService.AddReceivers("+15551111111")
+
+	    // Tell our notifier to use the phaxio service.
+	    notify.UseServices(phaxioService)
+
+	    // Send a test message.
+	    if err := notify.Send(context.Background(), "Subject/Title", "The actual message - Hello, you awesome gophers! :)"); err != nil {
+	        log.Fatal(err)
+	    }
+	}
+*/
+package phaxio
@@ -0,0 +1,35 @@
+package phaxio
+
+import (
+	"bytes"
+
+	"github.com/go-pdf/fpdf"
+	"github.com/pkg/errors"
+)
+
+// renderPDF renders subject and message into a single, possibly multi-page, PDF document. The core fonts only support
+// the cp1252 charset; unsupported characters are replaced.
+func renderPDF(subject, message string) ([]byte, error) {
+	pdf := fpdf.New("P", "mm", "Letter", "")
+	pdf.SetMargins(20, 20, 20)
+	pdf.SetAutoPageBreak(true, 20)
+	pdf.AddPage()
+
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+
+	if subject != "" {
+		pdf.SetFont("Helvetica", "B", 16)
+		pdf.MultiCell(0, 8, tr(subject), "", "L", false)
+		pdf.Ln(6)
+	}
+
+	pdf.SetFont("Helvetica", "", 12)
+	pdf.MultiCell(0, 6, tr(message), "", "L", false)
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, errors.Wrap(err, "render PDF")
+	}
+
+	return buf.Bytes(), nil
+}
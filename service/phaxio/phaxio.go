@@ -0,0 +1,135 @@
+package phaxio
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
+)
+
+const defaultBaseURL = "https://api.phaxio.com/v2"
+
+// Service encapsulates the Phaxio fax client.
+type Service struct {
+	client    *http.Client
+	baseURL   string
+	apiKey    string
+	apiSecret string
+
+	callerID   string
+	headerText string
+	receivers  []string
+}
+
+// New returns a new instance of a Phaxio notification service. API key and secret can be found in the API keys
+// section of the Phaxio console.
+// For more information about sending faxes with Phaxio:
+//
+//	-> https://www.phaxio.com/docs/api/v2.1/faxes/create_and_send_fax
+func New(apiKey, apiSecret string) *Service {
+	return &Service{
+		client: &http.Client{
+			Transport: notify.NewRateLimitTransport(nil),
+			Timeout:   30 * time.Second,
+		},
+		baseURL:   defaultBaseURL,
+		apiKey:    apiKey,
+		apiSecret: apiSecret,
+		receivers: []string{},
+	}
+}
+
+// WithClient sets the http client to be used for sending requests. Calling this method is optional, the default client
+// will be used if this method is not called.
+func (s *Service) WithClient(client *http.Client) {
+	if client != nil {
+		s.client = client
+	}
+}
+
+// SetCallerID sets the Phaxio number faxes are sent from. If left empty, Phaxio picks one of the account's numbers.
+func (s *Service) SetCallerID(callerID string) {
+	s.callerID = callerID
+}
+
+// SetHeaderText sets the text printed in the header line of every fax page.
+func (s *Service) SetHeaderText(headerText string) {
+	s.headerText = headerText
+}
+
+// AddReceivers takes fax numbers in E.164 format and adds them to the internal receiver list. The Send method will fax
+// a given message to all those numbers.
+func (s *Service) AddReceivers(faxNumbers ...string) {
+	s.receivers = append(s.receivers, faxNumbers...)
+}
+
+type faxResponse struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+// Send takes a message subject and a message body, renders them into a PDF document and faxes it to all previously set
+// fax numbers in a single request.
+func (s *Service) Send(ctx context.Context, subject, message string) error {
+	if len(s.receivers) == 0 {
+		return nil
+	}
+
+	document, err := renderPDF(subject, message)
+	if err != nil {
+		return errors.Wrap(err, "failed to render fax document")
+	}
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	for _, to := range s.receivers {
+		_ = writer.WriteField("to[]", to)
+	}
+	if s.callerID != "" {
+		_ = writer.WriteField("caller_id", s.callerID)
+	}
+	if s.headerText != "" {
+		_ = writer.WriteField("header_text", s.headerText)
+	}
+	part, err := writer.CreateFormFile("file", "notification.pdf")
+	if err != nil {
+		return errors.Wrap(err, "failed to create file part")
+	}
+	if _, err = part.Write(document); err != nil {
+		return errors.Wrap(err, "failed to write file part")
+	}
+	if err = writer.Close(); err != nil {
+		return errors.Wrap(err, "failed to close multipart writer")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.baseURL+"/faxes", body)
+	if err != nil {
+		return errors.Wrap(err, "failed to create request")
+	}
+	req.SetBasicAuth(s.apiKey, s.apiSecret)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "failed to send fax via Phaxio")
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	var result faxResponse
+	if err = json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return errors.Wrapf(err, "failed to decode response with status code %d", resp.StatusCode)
+	}
+
+	if !result.Success {
+		return notify.ClassifyResponse(resp, fmt.Errorf("phaxio returned status code %d: %s", resp.StatusCode, result.Message))
+	}
+
+	return nil
+}
@@ -0,0 +1,63 @@
+package phaxio
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPhaxio_Send(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	var (
+		fields   map[string][]string
+		document []byte
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key, secret, ok := r.BasicAuth()
+		if !ok || key != "key" || secret != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"success":false,"message":"Invalid API credentials"}`))
+			return
+		}
+
+		_ = r.ParseMultipartForm(1 << 20)
+		fields = r.MultipartForm.Value
+		file, _, _ := r.FormFile("file")
+		document, _ = io.ReadAll(file)
+		_, _ = w.Write([]byte(`{"success":true,"message":"Fax queued for sending","data":{"id":1}}`))
+	}))
+	defer server.Close()
+
+	service := New("key", "secret")
+	service.baseURL = server.URL
+
+	// No receivers, no request
+	assert.NoError(service.Send(context.Background(), "subject", "message"))
+	assert.Nil(fields)
+
+	service.SetCallerID("+15550000000")
+	service.SetHeaderText("ACME Corp")
+	service.AddReceivers("+15551111111", "+15552222222")
+
+	assert.NoError(service.Send(context.Background(), "Incident notice", "Résumé of the incident"))
+	assert.Equal(map[string][]string{
+		"to[]":        {"+15551111111", "+15552222222"},
+		"caller_id":   {"+15550000000"},
+		"header_text": {"ACME Corp"},
+	}, fields)
+	assert.True(bytes.HasPrefix(document, []byte("%PDF-")))
+
+	// Test error response
+	service.apiSecret = "invalid"
+	err := service.Send(context.Background(), "subject", "message")
+	assert.Error(err)
+	assert.Contains(err.Error(), "Invalid API credentials")
+}
@@ -0,0 +1,59 @@
+package plivo
+
+import "strings"
+
+// Maximum text lengths accepted by Plivo, depending on the encoding required by the text. Longer texts are truncated
+// before sending, as Plivo would reject them otherwise.
+//
+//	-> https://www.plivo.com/docs/sms/concepts/encoding-and-concatenation
+const (
+	maxGSMLength     = 1600
+	maxUnicodeLength = 737
+)
+
+// gsmCharset contains the characters of the GSM 03.38 basic character set and its extension table.
+const gsmCharset = "@£$¥èéùìòÇ\nØø\rÅåΔ_ΦΓΛΩΠΨΣΘΞÆæßÉ !\"#¤%&'()*+,-./0123456789:;<=>?" +
+	"¡ABCDEFGHIJKLMNOPQRSTUVWXYZÄÖÑÜ§¿abcdefghijklmnopqrstuvwxyzäöñüà" +
+	"\f^{}\\[~]|€"
+
+// gsmReplacer replaces common unicode punctuation with the closest characters of the GSM character set.
+var gsmReplacer = strings.NewReplacer(
+	"‘", "'", "’", "'", "‚", "'", "′", "'",
+	"“", "\"", "”", "\"", "„", "\"", "″", "\"",
+	"–", "-", "—", "-", "‐", "-", "‑", "-",
+	"…", "...", "•", "*",
+	" ", " ", " ", " ", " ", " ",
+	"\t", " ",
+)
+
+// isGSM reports whether text can be sent using the GSM character set. Otherwise, Plivo sends it UCS-2 (unicode)
+// encoded, which fits fewer characters into a single SMS segment.
+func isGSM(text string) bool {
+	for _, r := range text {
+		if !strings.ContainsRune(gsmCharset, r) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// prepareText replaces unicode punctuation if transliterate is set and truncates text to the maximum length allowed
+// for its encoding.
+func prepareText(text string, transliterate bool) string {
+	if transliterate {
+		text = gsmReplacer.Replace(text)
+	}
+
+	limit := maxGSMLength
+	if !isGSM(text) {
+		limit = maxUnicodeLength
+	}
+
+	runes := []rune(text)
+	if len(runes) > limit {
+		return string(runes[:limit])
+	}
+
+	return text
+}
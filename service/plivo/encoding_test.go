@@ -0,0 +1,30 @@
+package plivo
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlivo_prepareText(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	assert.True(isGSM("Hello {world} €5 @ 10:00"))
+	assert.False(isGSM("Hello “world”"))
+	assert.False(isGSM("こんにちは"))
+
+	assert.Equal("Hello “world”", prepareText("Hello “world”", false))
+	assert.Equal("Hello \"world\"", prepareText("Hello “world”", true))
+
+	long := strings.Repeat("a", maxGSMLength+10)
+	assert.Len(prepareText(long, false), maxGSMLength)
+
+	long = strings.Repeat("ä", maxGSMLength+10)
+	assert.Equal(maxGSMLength, len([]rune(prepareText(long, false))))
+
+	long = strings.Repeat("こ", maxUnicodeLength+10)
+	assert.Equal(maxUnicodeLength, len([]rune(prepareText(long, false))))
+}
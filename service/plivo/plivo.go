@@ -4,9 +4,12 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"regexp"
 	"strings"
 
 	plivo "github.com/plivo/plivo-go/v7"
+
+	"github.com/nikoksr/notify"
 )
 
 // ClientOptions allow you to configure a Plivo SDK client.
@@ -25,8 +28,16 @@ type MessageOptions struct {
 	// Optional
 	CallbackURL    string // URL to which status update callbacks for the message should be sent
 	CallbackMethod string // The HTTP method to be used when calling CallbackURL - GET or POST(default)
+
+	// TransliterateUnicode replaces unicode punctuation, e.g. smart quotes and dashes, with their GSM equivalents.
+	// Messages containing unicode characters are sent UCS-2 encoded, which fits 70 instead of 160 characters into a
+	// single SMS segment.
+	TransliterateUnicode bool
 }
 
+// powerpackPattern matches Plivo Powerpack UUIDs, which may be used as source instead of a phone number.
+var powerpackPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
 // plivoMsgClient abstracts Plivo SDK for writing unit tests
 //
 //go:generate mockery --name=plivoMsgClient --output=. --case=underscore --inpackage
@@ -34,6 +45,9 @@ type plivoMsgClient interface {
 	Create(plivo.MessageCreateParams) (*plivo.MessageCreateResponseBody, error)
 }
 
+// Compile-time check to ensure that Service implements the notify.Limiter interface.
+var _ notify.Limiter = new(Service)
+
 // Service is a Plivo client
 type Service struct {
 	client       plivoMsgClient
@@ -72,25 +86,63 @@ func New(cOpts *ClientOptions, mOpts *MessageOptions) (*Service, error) {
 	}, nil
 }
 
-// AddReceivers adds the given destination phone numbers to the notifier.
+// AddReceivers adds the given destination phone numbers to the notifier. Numbers should be in E.164 format; a
+// leading "+" is optional.
 func (s *Service) AddReceivers(phoneNumbers ...string) {
 	s.destinations = append(s.destinations, phoneNumbers...)
 }
 
-// Send sends a SMS via Plivo to all previously added receivers.
+// Limits returns the maximum length of texts with Unicode characters, which contain subject and body. Texts of GSM
+// characters only may be twice as long, but the limit has to hold for both, so that Send doesn't cut off texts.
+func (s *Service) Limits() notify.Limits {
+	return notify.Limits{Total: maxUnicodeLength}
+}
+
+// Validate checks that the source is set and that the receivers are phone numbers in E.164 format, with or without
+// leading "+", see notify.Validator.
+func (s *Service) Validate() error {
+	var problems notify.ConfigErrors
+	problems.Require("source", s.mopts.Source)
+	for _, number := range s.destinations {
+		problems.Check("receiver", notify.ValidatePhoneNumber("+"+strings.TrimPrefix(number, "+")))
+	}
+
+	return problems.Err()
+}
+
+// ResolveContact returns the phone number of the contact, see notify.SendToContacts.
+func (s *Service) ResolveContact(contact notify.Contact) (string, bool) {
+	return contact.Phone, contact.Phone != ""
+}
+
+// Send sends a SMS via Plivo to all previously added receivers. Texts exceeding Plivo's length limit for their encoding
+// are truncated. The phone numbers can be overridden per send, see notify.WithReceiversOverride.
 func (s *Service) Send(ctx context.Context, subject, message string) error {
-	text := subject + "\n" + message
+	destinations := notify.ReceiversFromContext(ctx, s.destinations)
+	text := prepareText(subject+"\n"+message, s.mopts.TransliterateUnicode)
 
 	var dst string
-	switch len(s.destinations) {
+	switch len(destinations) {
 	case 0:
 		return fmt.Errorf("no receivers added")
 	case 1:
-		dst = s.destinations[0]
+		dst = destinations[0]
 	default:
 		// multiple destinations, use bulk message syntax
 		// see: https://www.plivo.com/docs/sms/api/message#bulk-messaging
-		dst = strings.Join(s.destinations, "<")
+		dst = strings.Join(destinations, "<")
+	}
+
+	params := plivo.MessageCreateParams{
+		Dst:    dst,
+		Text:   text,
+		URL:    s.mopts.CallbackURL,
+		Method: s.mopts.CallbackMethod,
+	}
+	if powerpackPattern.MatchString(s.mopts.Source) {
+		params.PowerpackUUID = s.mopts.Source
+	} else {
+		params.Src = s.mopts.Source
 	}
 
 	var err error
@@ -98,14 +150,28 @@ func (s *Service) Send(ctx context.Context, subject, message string) error {
 	case <-ctx.Done():
 		return ctx.Err()
 	default:
-		_, err = s.client.Create(plivo.MessageCreateParams{
-			Dst:    dst,
-			Text:   text,
-			Src:    s.mopts.Source,
-			URL:    s.mopts.CallbackURL,
-			Method: s.mopts.CallbackMethod,
-		})
+		_, err = s.client.Create(params)
+	}
+
+	return classifyError(err)
+}
+
+// classifyError classifies errors returned by Plivo, see notify.ClassifyError. The Plivo client returns the bodies of
+// error responses as errors, without their status, so the bodies are matched instead.
+func classifyError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	body := strings.ToLower(err.Error())
+	switch {
+	case strings.Contains(body, "authentication failed"), strings.Contains(body, "proper credentials"):
+		return notify.ClassifyError(err, notify.ErrAuthFailed)
+	case strings.Contains(body, "too many requests"):
+		return notify.ClassifyError(err, &notify.ErrRateLimited{})
+	case strings.Contains(body, "'dst'"):
+		return notify.ClassifyError(err, notify.ErrInvalidReceiver)
 	}
 
-	return err
+	return notify.ClassifyNetworkError(err)
 }
@@ -7,6 +7,8 @@ import (
 
 	"github.com/plivo/plivo-go/v7"
 	"github.com/stretchr/testify/require"
+
+	"github.com/nikoksr/notify"
 )
 
 func TestPlivo_New(t *testing.T) {
@@ -50,6 +52,22 @@ func TestPlivo_AddReceivers(t *testing.T) {
 	assert.Equal(svc.destinations, nums)
 }
 
+func TestPlivo_Validate(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	svc, err := New(&ClientOptions{}, &MessageOptions{Source: "12345"})
+	assert.Nil(err)
+	svc.AddReceivers("14155552671", "+14155552672")
+	assert.NoError(svc.Validate())
+
+	svc.AddReceivers("(415) 555-2673")
+	err = svc.Validate()
+	assert.ErrorIs(err, notify.ErrInvalidConfig)
+	assert.ErrorContains(err, "receiver")
+}
+
 func TestPlivo_Send(t *testing.T) {
 	t.Parallel()
 
@@ -83,4 +101,22 @@ func TestPlivo_Send(t *testing.T) {
 	err = svc.Send(ctx, "message", "test")
 	assert.Nil(err)
 	mockClient.AssertExpectations(t)
+
+	// test powerpack source and unicode transliteration
+	svc, err = New(&ClientOptions{}, &MessageOptions{
+		Source:               "d5b5e6b4-1a2b-4c3d-8e9f-0a1b2c3d4e5f",
+		TransliterateUnicode: true,
+	})
+	assert.Nil(err)
+	mockClient = new(mockPlivoMsgClient)
+	mockClient.On("Create", plivo.MessageCreateParams{
+		PowerpackUUID: "d5b5e6b4-1a2b-4c3d-8e9f-0a1b2c3d4e5f",
+		Dst:           "67890",
+		Text:          "\"message\"\ntest - it's...",
+	}).Return(nil, nil)
+	svc.client = mockClient
+	svc.AddReceivers("67890")
+	err = svc.Send(ctx, "“message”", "test – it’s…")
+	assert.Nil(err)
+	mockClient.AssertExpectations(t)
 }
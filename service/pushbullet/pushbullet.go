@@ -2,9 +2,13 @@ package pushbullet
 
 import (
 	"context"
+	"strconv"
+	"strings"
 
 	"github.com/cschomburg/go-pushbullet"
 	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
 )
 
 // Pushbullet struct holds necessary data to communicate with the Pushbullet API.
@@ -46,15 +50,46 @@ func (pb Pushbullet) Send(ctx context.Context, subject, message string) error {
 		default:
 			dev, err := pb.client.Device(deviceNickname)
 			if err != nil {
-				return errors.Wrapf(err, "failed to find Pushbullet device with nickname '%s'", deviceNickname)
+				err = errors.Wrapf(err, "failed to find Pushbullet device with nickname '%s'", deviceNickname)
+				return classifyError(err)
 			}
 
 			err = dev.PushNote(subject, message)
 			if err != nil {
-				return errors.Wrapf(err, "failed to send message to Pushbullet device with nickname '%s'", deviceNickname)
+				err = errors.Wrapf(err, "failed to send message to Pushbullet device with nickname '%s'", deviceNickname)
+				return classifyError(err)
 			}
 		}
 	}
 
 	return nil
 }
+
+// classifyError classifies errors returned by the Pushbullet API, see notify.ClassifyError. The client only exposes
+// the error message of failed requests, or their status if there is none.
+func classifyError(err error) error {
+	if errors.Is(err, pushbullet.ErrDeviceNotFound) {
+		return notify.ClassifyError(err, notify.ErrInvalidReceiver)
+	}
+
+	var respErr *pushbullet.ErrResponse
+	if errors.As(err, &respErr) {
+		msg := strings.ToLower(respErr.Message)
+		switch {
+		case respErr.Type == "server":
+			return notify.ClassifyError(err, notify.ErrTransient)
+		case strings.Contains(msg, "access token"):
+			return notify.ClassifyError(err, notify.ErrAuthFailed)
+		case strings.Contains(msg, "ratelimited"):
+			return notify.ClassifyError(err, &notify.ErrRateLimited{})
+		default:
+			return notify.ClassifyError(err, notify.ErrPermanent)
+		}
+	}
+
+	if status, convErr := strconv.Atoi(strings.SplitN(errors.Cause(err).Error(), " ", 2)[0]); convErr == nil {
+		return notify.ClassifyStatus(status, err)
+	}
+
+	return notify.ClassifyNetworkError(err)
+}
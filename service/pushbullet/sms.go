@@ -50,7 +50,7 @@ func (sms SMS) Send(ctx context.Context, subject, message string) error {
 	fullMessage := subject + "\n" + message // Treating subject as message title
 	user, err := sms.client.Me()
 	if err != nil {
-		return errors.Wrapf(err, "failed to find valid pushbullet user")
+		return classifyError(errors.Wrapf(err, "failed to find valid pushbullet user"))
 	}
 
 	for _, phoneNumber := range sms.phoneNumbers {
@@ -60,7 +60,7 @@ func (sms SMS) Send(ctx context.Context, subject, message string) error {
 		default:
 			err = sms.client.PushSMS(user.Iden, sms.deviceIdentifier, phoneNumber, fullMessage)
 			if err != nil {
-				return errors.Wrapf(err, "failed to send SMS message to %s via Pushbullet", phoneNumber)
+				return classifyError(errors.Wrapf(err, "failed to send SMS message to %s via Pushbullet", phoneNumber))
 			}
 		}
 	}
@@ -0,0 +1,35 @@
+/*
+Package pushdeer provides a service for sending push notifications via PushDeer.
+
+Usage:
+
+	package main
+
+	import (
+	    "context"
+	    "log"
+
+	    "github.com/nikoksr/notify"
+	    "github.com/nikoksr/notify/service/pushdeer"
+	)
+
+	func main() {
+	    pushDeerService := pushdeer.New()
+
+	    // Use a self-hosted server.
+	    pushDeerService.SetServerURL("https://pushdeer.example.com")
+
+	    // Send with these push keys.
+	    pushDeerService.AddReceivers("PDU1234T...")
+	    pushDeerService.SetMessageType(pushdeer.TypeText)
+
+	    // Tell our notifier to use the pushdeer service.
+	    notify.UseServices(pushDeerService)
+
+	    // Send a test message.
+	    if err := notify.Send(context.Background(), "Subject/Title", "The actual message"); err != nil {
+	        log.Fatal(err)
+	    }
+	}
+*/
+package pushdeer
@@ -0,0 +1,140 @@
+package pushdeer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
+)
+
+// DefaultServerURL is the URL of the official PushDeer server.
+const DefaultServerURL = "https://api2.pushdeer.com"
+
+// MessageType determines how a message is rendered.
+type MessageType string
+
+// Message types supported by PushDeer.
+const (
+	TypeText     MessageType = "text"
+	TypeMarkdown MessageType = "markdown"
+	TypeImage    MessageType = "image"
+)
+
+// Service encapsulates the PushDeer client.
+type Service struct {
+	client      *http.Client
+	serverURL   string
+	messageType MessageType
+	pushKeys    []string
+}
+
+// New returns a new instance of a PushDeer notification service using the official server.
+// For more information about the PushDeer API:
+//
+//	-> https://www.pushdeer.com/dev.html
+func New() *Service {
+	return &Service{
+		client: &http.Client{
+			Transport: notify.NewRateLimitTransport(nil),
+			Timeout:   10 * time.Second,
+		},
+		serverURL:   DefaultServerURL,
+		messageType: TypeMarkdown,
+		pushKeys:    []string{},
+	}
+}
+
+// WithClient sets the http client to be used for sending requests. Calling this method is optional, the default client
+// will be used if this method is not called.
+func (s *Service) WithClient(client *http.Client) {
+	if client != nil {
+		s.client = client
+	}
+}
+
+// SetServerURL sets the URL of a self-hosted server. Defaults to DefaultServerURL.
+func (s *Service) SetServerURL(serverURL string) {
+	s.serverURL = strings.TrimSuffix(serverURL, "/")
+}
+
+// AddReceivers takes push keys and adds them to the internal key list. The Send method will send a given message with
+// every one of those keys.
+func (s *Service) AddReceivers(pushKeys ...string) {
+	s.pushKeys = append(s.pushKeys, pushKeys...)
+}
+
+// SetMessageType sets the type of sent messages. Defaults to TypeMarkdown. With TypeImage, the message body must be
+// the URL of the image, and the subject is ignored.
+func (s *Service) SetMessageType(messageType MessageType) {
+	s.messageType = messageType
+}
+
+type response struct {
+	Code  int    `json:"code"`
+	Error string `json:"error"`
+}
+
+// Send takes a message subject and a message body and sends them with all previously set push keys. The subject is
+// shown as the title, the message as the description.
+func (s *Service) Send(ctx context.Context, subject, message string) error {
+	form := url.Values{"type": {string(s.messageType)}}
+	if s.messageType == TypeImage {
+		form.Set("text", message)
+	} else {
+		form.Set("text", subject)
+		form.Set("desp", message)
+	}
+
+	for _, pushKey := range s.pushKeys {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			form.Set("pushkey", pushKey)
+			if err := s.push(ctx, form); err != nil {
+				return errors.Wrap(err, "failed to send message")
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) push(ctx context.Context, form url.Values) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.serverURL+"/message/push",
+		strings.NewReader(form.Encode()))
+	if err != nil {
+		return errors.Wrap(err, "create request")
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "send request")
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	b, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return notify.ClassifyResponse(resp, fmt.Errorf("pushdeer returned status code %d: %s", resp.StatusCode, string(b)))
+	}
+
+	// PushDeer reports errors with status 200 and a non-zero code.
+	var result response
+	if err = json.Unmarshal(b, &result); err != nil {
+		return errors.Wrap(err, "decode response")
+	}
+	if result.Code != 0 {
+		return fmt.Errorf("pushdeer returned code %d: %s", result.Code, result.Error)
+	}
+
+	return nil
+}
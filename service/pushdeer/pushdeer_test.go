@@ -0,0 +1,47 @@
+package pushdeer
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPushDeer_Send(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	var got []url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		if r.PostForm.Get("pushkey") == "invalid" {
+			_, _ = w.Write([]byte(`{"code":80403,"error":"没有有效的设备"}`))
+			return
+		}
+		got = append(got, r.PostForm)
+		_, _ = w.Write([]byte(`{"code":0,"content":{"result":["{\"counts\":1}"]}}`))
+	}))
+	defer server.Close()
+
+	service := New()
+	assert.Equal(DefaultServerURL, service.serverURL)
+	service.SetServerURL(server.URL + "/")
+	service.AddReceivers("PDU1")
+
+	assert.NoError(service.Send(context.Background(), "subject", "message"))
+	service.SetMessageType(TypeImage)
+	assert.NoError(service.Send(context.Background(), "subject", "https://example.com/image.png"))
+
+	assert.Equal([]url.Values{
+		{"pushkey": {"PDU1"}, "type": {"markdown"}, "text": {"subject"}, "desp": {"message"}},
+		{"pushkey": {"PDU1"}, "type": {"image"}, "text": {"https://example.com/image.png"}},
+	}, got)
+
+	// Test error response
+	service.AddReceivers("invalid")
+	assert.Error(service.Send(context.Background(), "subject", "message"))
+}
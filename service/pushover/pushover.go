@@ -4,9 +4,12 @@ package pushover
 
 import (
 	"context"
+	"strings"
 
 	"github.com/gregdel/pushover"
 	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
 )
 
 //go:generate mockery --name=pushoverClient --output=. --case=underscore --inpackage
@@ -17,6 +20,9 @@ type pushoverClient interface {
 // Compile-time check to ensure that pushover.Pushover implements the pushoverClient interface.
 var _ pushoverClient = new(pushover.Pushover)
 
+// Compile-time check to ensure that Pushover implements the notify.Limiter interface.
+var _ notify.Limiter = Pushover{}
+
 // Pushover struct holds necessary data to communicate with the Pushover API.
 type Pushover struct {
 	client     pushoverClient
@@ -58,9 +64,42 @@ func (p Pushover) Send(ctx context.Context, subject, message string) error {
 				&p.recipients[i],
 			)
 			if err != nil {
-				return errors.Wrapf(err, "failed to send message to Pushover recipient '%s'", p.recipients[i])
+				return classifyError(errors.Wrapf(err, "failed to send message to Pushover recipient '%s'", p.recipients[i]))
 			}
 		}
 	}
 	return nil
 }
+
+// Limits returns the maximum lengths of title and message of Pushover notifications.
+func (p Pushover) Limits() notify.Limits {
+	return notify.Limits{Subject: 250, Body: 1024}
+}
+
+// classifyError classifies errors returned by the Pushover API, see notify.ClassifyError. Pushover only reports its
+// errors as messages, so these are matched.
+func classifyError(err error) error {
+	if errors.Is(err, pushover.ErrHTTPPushover) {
+		return notify.ClassifyError(err, notify.ErrTransient)
+	}
+	if errors.Is(err, pushover.ErrInvalidToken) {
+		return notify.ClassifyError(err, notify.ErrAuthFailed)
+	}
+
+	var apiErrs pushover.Errors
+	if !errors.As(err, &apiErrs) {
+		return notify.ClassifyNetworkError(err)
+	}
+
+	msg := strings.ToLower(apiErrs.Error())
+	switch {
+	case strings.Contains(msg, "token is invalid"):
+		return notify.ClassifyError(err, notify.ErrAuthFailed)
+	case strings.Contains(msg, "limit"):
+		return notify.ClassifyError(err, &notify.ErrRateLimited{})
+	case strings.Contains(msg, "user"), strings.Contains(msg, "device"):
+		return notify.ClassifyError(err, notify.ErrInvalidReceiver)
+	default:
+		return notify.ClassifyError(err, notify.ErrPermanent)
+	}
+}
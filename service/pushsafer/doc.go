@@ -0,0 +1,36 @@
+/*
+Package pushsafer provides a service for sending push notifications via Pushsafer.
+
+Usage:
+
+	package main
+
+	import (
+	    "context"
+	    "log"
+
+	    "github.com/nikoksr/notify"
+	    "github.com/nikoksr/notify/service/pushsafer"
+	)
+
+	func main() {
+	    pushsaferService := pushsafer.New("your-private-key")
+
+	    // Send to these devices and device groups.
+	    pushsaferService.AddReceivers("1234", "gs23")
+
+	    // Customize icon, sound and vibration.
+	    _ = pushsaferService.SetIcon(5)
+	    _ = pushsaferService.SetSound(8)
+	    _ = pushsaferService.SetVibration(2)
+
+	    // Tell our notifier to use the pushsafer service.
+	    notify.UseServices(pushsaferService)
+
+	    // Send a test message.
+	    if err := notify.Send(context.Background(), "Subject/Title", "The actual message"); err != nil {
+	        log.Fatal(err)
+	    }
+	}
+*/
+package pushsafer
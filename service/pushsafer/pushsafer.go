@@ -0,0 +1,161 @@
+package pushsafer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
+)
+
+const defaultAPIURL = "https://www.pushsafer.com/api"
+
+// AllDevices is a receiver targeting all devices of the account.
+const AllDevices = "a"
+
+// Service encapsulates the Pushsafer client.
+type Service struct {
+	client     *http.Client
+	apiURL     string
+	privateKey string
+	icon       string
+	sound      string
+	vibration  string
+	receivers  []string
+}
+
+// New returns a new instance of a Pushsafer notification service. The private or alias key can be found on the
+// Pushsafer dashboard.
+// For more information about the Pushsafer API:
+//
+//	-> https://www.pushsafer.com/en/pushapi
+func New(privateKey string) *Service {
+	return &Service{
+		client: &http.Client{
+			Transport: notify.NewRateLimitTransport(nil),
+			Timeout:   10 * time.Second,
+		},
+		apiURL:     defaultAPIURL,
+		privateKey: privateKey,
+		receivers:  []string{},
+	}
+}
+
+// WithClient sets the http client to be used for sending requests. Calling this method is optional, the default client
+// will be used if this method is not called.
+func (s *Service) WithClient(client *http.Client) {
+	if client != nil {
+		s.client = client
+	}
+}
+
+// AddReceivers takes device IDs, e.g. "1234", device group IDs, e.g. "gs23", or AllDevices and adds them to the
+// internal receiver list. The Send method will send a given message to all of them.
+func (s *Service) AddReceivers(ids ...string) {
+	s.receivers = append(s.receivers, ids...)
+}
+
+// SetIcon sets the icon shown with the notifications, a number from 1 to 181.
+func (s *Service) SetIcon(icon int) error {
+	if icon < 1 || icon > 181 {
+		return fmt.Errorf("invalid icon %d: must be between 1 and 181", icon)
+	}
+	s.icon = strconv.Itoa(icon)
+
+	return nil
+}
+
+// SetSound sets the sound played for the notifications, a number from 0 (silent) to 62.
+func (s *Service) SetSound(sound int) error {
+	if sound < 0 || sound > 62 {
+		return fmt.Errorf("invalid sound %d: must be between 0 and 62", sound)
+	}
+	s.sound = strconv.Itoa(sound)
+
+	return nil
+}
+
+// SetVibration sets how often the device vibrates for the notifications, from 1 to 3, or 0 to use the device default.
+func (s *Service) SetVibration(vibration int) error {
+	if vibration < 0 || vibration > 3 {
+		return fmt.Errorf("invalid vibration %d: must be between 0 and 3", vibration)
+	}
+	s.vibration = ""
+	if vibration > 0 {
+		s.vibration = strconv.Itoa(vibration)
+	}
+
+	return nil
+}
+
+type response struct {
+	Status  int    `json:"status"`
+	Success string `json:"success"`
+	Error   string `json:"error"`
+}
+
+// Send takes a message subject and a message body and sends them to all previously set devices and groups.
+func (s *Service) Send(ctx context.Context, subject, message string) error {
+	form := url.Values{
+		"k": {s.privateKey},
+		"t": {subject},
+		"m": {message},
+	}
+	if s.icon != "" {
+		form.Set("i", s.icon)
+	}
+	if s.sound != "" {
+		form.Set("s", s.sound)
+	}
+	if s.vibration != "" {
+		form.Set("v", s.vibration)
+	}
+
+	for _, receiver := range s.receivers {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			form.Set("d", receiver)
+			if err := s.push(ctx, form); err != nil {
+				return errors.Wrapf(err, "failed to send message to %q", receiver)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) push(ctx context.Context, form url.Values) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.apiURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return errors.Wrap(err, "create request")
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "send request")
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	b, _ := io.ReadAll(resp.Body)
+
+	var result response
+	if err = json.Unmarshal(b, &result); err != nil {
+		return notify.ClassifyResponse(resp, fmt.Errorf("pushsafer returned status code %d: %s", resp.StatusCode, string(b)))
+	}
+	if result.Status != 1 {
+		return fmt.Errorf("pushsafer returned an error: %s", result.Error)
+	}
+
+	return nil
+}
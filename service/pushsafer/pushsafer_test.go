@@ -0,0 +1,58 @@
+package pushsafer
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPushsafer_Send(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	var got []url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		if r.PostForm.Get("k") != "key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"status":0,"error":"invalid key"}`))
+			return
+		}
+		got = append(got, r.PostForm)
+		_, _ = w.Write([]byte(`{"status":1,"success":"message transmitted"}`))
+	}))
+	defer server.Close()
+
+	service := New("key")
+	service.apiURL = server.URL
+	service.AddReceivers("1234", "gs23")
+
+	assert.Error(service.SetIcon(0))
+	assert.Error(service.SetSound(63))
+	assert.Error(service.SetVibration(4))
+	assert.NoError(service.SetIcon(5))
+	assert.NoError(service.SetSound(0))
+	assert.NoError(service.SetVibration(2))
+
+	assert.NoError(service.Send(context.Background(), "subject", "message"))
+	assert.Len(got, 2)
+	assert.Equal(url.Values{
+		"k": {"key"},
+		"d": {"1234"},
+		"t": {"subject"},
+		"m": {"message"},
+		"i": {"5"},
+		"s": {"0"},
+		"v": {"2"},
+	}, got[0])
+	assert.Equal("gs23", got[1].Get("d"))
+
+	// Test error response
+	service.privateKey = "invalid"
+	assert.ErrorContains(service.Send(context.Background(), "subject", "message"), "invalid key")
+}
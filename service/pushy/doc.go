@@ -0,0 +1,32 @@
+/*
+Package pushy provides a service for sending push notifications via Pushy.
+
+Usage:
+
+	package main
+
+	import (
+	    "context"
+	    "log"
+
+	    "github.com/nikoksr/notify"
+	    "github.com/nikoksr/notify/service/pushy"
+	)
+
+	func main() {
+	    pushyService := pushy.New("your-secret-api-key")
+
+	    // Send to these devices and topics.
+	    pushyService.AddReceivers("a6345d0278adc55d3474f5")
+	    pushyService.AddTopics("news")
+
+	    // Tell our notifier to use the pushy service.
+	    notify.UseServices(pushyService)
+
+	    // Send a test message.
+	    if err := notify.Send(context.Background(), "Subject/Title", "The actual message"); err != nil {
+	        log.Fatal(err)
+	    }
+	}
+*/
+package pushy
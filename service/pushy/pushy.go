@@ -0,0 +1,162 @@
+package pushy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
+)
+
+const defaultAPIURL = "https://api.pushy.me/push"
+
+// Service encapsulates the Pushy client.
+type Service struct {
+	client       *http.Client
+	apiURL       string
+	apiKey       string
+	sound        string
+	deviceTokens []string
+	topics       []string
+}
+
+// New returns a new instance of a Pushy notification service. The secret API key can be found in the Pushy dashboard
+// under the app's API Authentication tab.
+// For more information about the Pushy API:
+//
+//	-> https://pushy.me/docs/api/send-notifications
+func New(apiKey string) *Service {
+	return &Service{
+		client: &http.Client{
+			Transport: notify.NewRateLimitTransport(nil),
+			Timeout:   10 * time.Second,
+		},
+		apiURL:       defaultAPIURL,
+		apiKey:       apiKey,
+		sound:        "ping.aiff",
+		deviceTokens: []string{},
+		topics:       []string{},
+	}
+}
+
+// WithClient sets the http client to be used for sending requests. Calling this method is optional, the default client
+// will be used if this method is not called.
+func (s *Service) WithClient(client *http.Client) {
+	if client != nil {
+		s.client = client
+	}
+}
+
+// SetSound sets the sound played on iOS when the notification is received. Defaults to "ping.aiff".
+func (s *Service) SetSound(sound string) {
+	s.sound = sound
+}
+
+// AddReceivers takes device tokens and adds them to the internal receiver list. The Send method will send a given
+// message to all those devices.
+func (s *Service) AddReceivers(deviceTokens ...string) {
+	s.deviceTokens = append(s.deviceTokens, deviceTokens...)
+}
+
+// AddTopics takes topic names, e.g. "news", and adds them to the internal topic list. The Send method will send a
+// given message to all devices subscribed to those topics.
+func (s *Service) AddTopics(topics ...string) {
+	for _, topic := range topics {
+		s.topics = append(s.topics, "/topics/"+strings.TrimPrefix(topic, "/topics/"))
+	}
+}
+
+type data struct {
+	Title   string `json:"title"`
+	Message string `json:"message"`
+}
+
+type notification struct {
+	Title string `json:"title"`
+	Body  string `json:"body"`
+	Sound string `json:"sound,omitempty"`
+}
+
+type pushRequest struct {
+	// To is either a list of device tokens or a single topic.
+	To           any          `json:"to"`
+	Data         data         `json:"data"`
+	Notification notification `json:"notification"`
+}
+
+type pushResponse struct {
+	Success bool   `json:"success"`
+	Code    string `json:"code"`
+	Error   string `json:"error"`
+}
+
+// Send takes a message subject and a message body and sends them to all previously set device tokens and topics. The
+// notification payload is displayed on iOS, while the data payload is passed to the app on Android, where the Pushy
+// SDK displays it.
+func (s *Service) Send(ctx context.Context, subject, message string) error {
+	var targets []any
+	if len(s.deviceTokens) > 0 {
+		targets = append(targets, s.deviceTokens)
+	}
+	for _, topic := range s.topics {
+		targets = append(targets, topic)
+	}
+
+	for _, to := range targets {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			req := pushRequest{
+				To:           to,
+				Data:         data{Title: subject, Message: message},
+				Notification: notification{Title: subject, Body: message, Sound: s.sound},
+			}
+			if err := s.push(ctx, req); err != nil {
+				return errors.Wrap(err, "failed to send push notification")
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) push(ctx context.Context, payload pushRequest) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return errors.Wrap(err, "marshal request")
+	}
+
+	endpoint := s.apiURL + "?api_key=" + url.QueryEscape(s.apiKey)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "create request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "send request")
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	b, _ := io.ReadAll(resp.Body)
+
+	var result pushResponse
+	if err = json.Unmarshal(b, &result); err != nil {
+		return notify.ClassifyResponse(resp, fmt.Errorf("status %d: %s", resp.StatusCode, string(b)))
+	}
+	if !result.Success {
+		return fmt.Errorf("%s (%s)", result.Error, result.Code)
+	}
+
+	return nil
+}
@@ -0,0 +1,52 @@
+package pushy
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPushy_Send(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	var got []map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("api_key") != "key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"code":"INVALID_API_KEY","error":"The API key you provided is invalid."}`))
+			return
+		}
+		var req map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		got = append(got, req)
+		_, _ = w.Write([]byte(`{"success":true,"id":"5ea9b214b47cad768a35f13a","info":{"devices":1}}`))
+	}))
+	defer server.Close()
+
+	service := New("key")
+	service.apiURL = server.URL
+
+	// No receivers added
+	assert.NoError(service.Send(context.Background(), "subject", "message"))
+	assert.Empty(got)
+
+	service.AddReceivers("a6345d0278adc55d3474f5", "b6345d0278adc55d3474f5")
+	service.AddTopics("news", "/topics/alerts")
+	assert.NoError(service.Send(context.Background(), "subject", "message"))
+	assert.Len(got, 3)
+	assert.Equal([]any{"a6345d0278adc55d3474f5", "b6345d0278adc55d3474f5"}, got[0]["to"])
+	assert.Equal("/topics/news", got[1]["to"])
+	assert.Equal("/topics/alerts", got[2]["to"])
+	assert.Equal(map[string]any{"title": "subject", "message": "message"}, got[0]["data"])
+	assert.Equal(map[string]any{"title": "subject", "body": "message", "sound": "ping.aiff"}, got[0]["notification"])
+
+	// Test error response
+	service.apiKey = "invalid"
+	assert.ErrorContains(service.Send(context.Background(), "subject", "message"), "INVALID_API_KEY")
+}
@@ -5,9 +5,13 @@ import (
 	"context"
 	"crypto/tls"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/vartanbeno/go-reddit/v2/reddit"
+
+	"github.com/nikoksr/notify"
 )
 
 //go:generate mockery --name=redditMessageClient --output=. --case=underscore --inpackage
@@ -20,10 +24,14 @@ var _ redditMessageClient = new(reddit.MessageService)
 
 // Reddit struct holds necessary data to communicate with the Reddit API.
 type Reddit struct {
-	client     redditMessageClient
-	recipients []string
+	client        redditMessageClient
+	recipients    []string
+	fromSubreddit string
 }
 
+// maxSubjectLength is the maximum length of a private message subject accepted by Reddit.
+const maxSubjectLength = 100
+
 // New returns a new instance of a Reddit notification service.
 // For more information on obtaining client credentials:
 //
@@ -32,9 +40,9 @@ func New(clientID, clientSecret, username, password string) (*Reddit, error) {
 	// Disable HTTP2 in http client
 	// Details: https://www.reddit.com/r/redditdev/comments/t8e8hc/getting_nothing_but_429_responses_when_using_go/i18yga2/
 	h := http.Client{
-		Transport: &http.Transport{
+		Transport: notify.NewRateLimitTransport(&http.Transport{
 			TLSNextProto: map[string]func(authority string, c *tls.Conn) http.RoundTripper{},
-		},
+		}),
 	}
 	rClient, err := reddit.NewClient(
 		reddit.Credentials{
@@ -64,6 +72,21 @@ func (r *Reddit) AddReceivers(recipients ...string) {
 	r.recipients = append(r.recipients, recipients...)
 }
 
+// AddSubredditReceivers takes subreddit names, with or without the "r/" prefix, and adds their modmail to the internal
+// recipient list. Messages sent to a subreddit are delivered to all of its moderators.
+func (r *Reddit) AddSubredditReceivers(subreddits ...string) {
+	for _, subreddit := range subreddits {
+		subreddit = strings.TrimPrefix(strings.TrimPrefix(subreddit, "/"), "r/")
+		r.recipients = append(r.recipients, "/r/"+subreddit)
+	}
+}
+
+// SendAsSubreddit makes all messages appear to come from the given subreddit instead of the authenticated user, which
+// must be a moderator of that subreddit with mail permissions. Use an empty string to send as the user again.
+func (r *Reddit) SendAsSubreddit(subreddit string) {
+	r.fromSubreddit = strings.TrimPrefix(strings.TrimPrefix(subreddit, "/"), "r/")
+}
+
 // Send takes a message subject and a message body and sends them to all previously set recipients.
 func (r *Reddit) Send(ctx context.Context, subject, message string) error {
 	for i := range r.recipients {
@@ -72,16 +95,46 @@ func (r *Reddit) Send(ctx context.Context, subject, message string) error {
 			return ctx.Err()
 		default:
 			m := reddit.SendMessageRequest{
-				To:      r.recipients[i],
-				Subject: subject,
-				Text:    message,
+				To:            r.recipients[i],
+				Subject:       subject,
+				Text:          message,
+				FromSubreddit: r.fromSubreddit,
+			}
+			if len(m.Subject) > maxSubjectLength {
+				m.Subject = m.Subject[:maxSubjectLength]
 			}
 
 			_, err := r.client.Send(ctx, &m)
 			if err != nil {
-				return errors.Wrapf(err, "failed to send message to Reddit recipient '%s'", r.recipients[i])
+				return classifyError(errors.Wrapf(err, "failed to send message to Reddit recipient '%s'", r.recipients[i]))
 			}
 		}
 	}
 	return nil
 }
+
+// classifyError classifies errors returned by the Reddit API, see notify.ClassifyError.
+func classifyError(err error) error {
+	var rateErr *reddit.RateLimitError
+	if errors.As(err, &rateErr) {
+		return notify.ClassifyError(err, &notify.ErrRateLimited{RetryAfter: time.Until(rateErr.Rate.Reset)})
+	}
+
+	// Reddit answers some invalid requests with 200 and a list of errors, e.g. for unknown recipients.
+	var jsonErr *reddit.JSONErrorResponse
+	if errors.As(err, &jsonErr) {
+		for _, apiErr := range jsonErr.JSON.Errors {
+			if apiErr.Label == "USER_DOESNT_EXIST" || apiErr.Label == "NOT_WHITELISTED_BY_USER_MESSAGE" {
+				return notify.ClassifyError(err, notify.ErrInvalidReceiver)
+			}
+		}
+		return notify.ClassifyError(err, notify.ErrPermanent)
+	}
+
+	var respErr *reddit.ErrorResponse
+	if errors.As(err, &respErr) && respErr.Response != nil {
+		return notify.ClassifyResponse(respErr.Response, err)
+	}
+
+	return notify.ClassifyNetworkError(err)
+}
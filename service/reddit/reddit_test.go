@@ -74,3 +74,34 @@ func TestReddit_Send(t *testing.T) {
 	assert.Nil(err)
 	mockClient.AssertExpectations(t)
 }
+
+func TestReddit_SendToSubreddit(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service, err := New("id", "secret", "user", "password")
+	assert.NotNil(service)
+	assert.NoError(err)
+
+	service.AddSubredditReceivers("r/golang", "/r/notify", "gophers")
+	assert.Equal([]string{"/r/golang", "/r/notify", "/r/gophers"}, service.recipients)
+	service.recipients = service.recipients[:1]
+
+	ctx := context.Background()
+	mockClient := newMockRedditMessageClient(t)
+	mockClient.
+		On("Send", ctx, &reddit.SendMessageRequest{
+			To:            "/r/golang",
+			Subject:       "subject",
+			Text:          "message",
+			FromSubreddit: "notify",
+		}).
+		Return(&reddit.Response{}, nil)
+
+	service.client = mockClient
+	service.SendAsSubreddit("r/notify")
+	err = service.Send(ctx, "subject", "message")
+	assert.Nil(err)
+	mockClient.AssertExpectations(t)
+}
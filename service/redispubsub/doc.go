@@ -0,0 +1,39 @@
+/*
+Package redispubsub provides a service for publishing notifications to Redis pub/sub channels and streams.
+
+Usage:
+
+	package main
+
+	import (
+	    "context"
+	    "log"
+
+	    "github.com/nikoksr/notify"
+	    "github.com/nikoksr/notify/service/redispubsub"
+	)
+
+	func main() {
+	    redisService, err := redispubsub.New("redis://localhost:6379/0")
+	    if err != nil {
+	        log.Fatal(err)
+	    }
+	    defer func() { _ = redisService.Close() }()
+
+	    // PUBLISH to these channels...
+	    redisService.AddReceivers("notifications")
+
+	    // ...and XADD to these streams, keeping roughly the last 10000 entries.
+	    redisService.AddStreams("notifications-log")
+	    redisService.SetStreamMaxLength(10000)
+
+	    // Tell our notifier to use the redis service.
+	    notify.UseServices(redisService)
+
+	    // Send a test message.
+	    if err := notify.Send(context.Background(), "Subject/Title", "The actual message"); err != nil {
+	        log.Fatal(err)
+	    }
+	}
+*/
+package redispubsub
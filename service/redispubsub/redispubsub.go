@@ -0,0 +1,156 @@
+package redispubsub
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
+)
+
+// Compile-time check to ensure that Service implements the notify.JSONSender interface.
+var _ notify.JSONSender = new(Service)
+
+// Service encapsulates the Redis client.
+type Service struct {
+	client    redis.UniversalClient
+	channels  []string
+	streams   []string
+	maxLength int64
+}
+
+// payload is the JSON document published for every notification.
+type payload struct {
+	Subject   string    `json:"subject"`
+	Message   string    `json:"message"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// New returns a new instance of a Redis notification service connecting to the server at the given URL, e.g.
+// "redis://:password@localhost:6379/0" or "rediss://..." for TLS.
+func New(url string) (*Service, error) {
+	options, err := redis.ParseURL(url)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse redis url")
+	}
+
+	return NewWithClient(redis.NewClient(options)), nil
+}
+
+// NewWithClient returns a new instance of a Redis notification service using the given client, e.g. a cluster or
+// sentinel client.
+func NewWithClient(client redis.UniversalClient) *Service {
+	return &Service{
+		client:   client,
+		channels: []string{},
+		streams:  []string{},
+	}
+}
+
+// AddReceivers takes channel names and adds them to the internal receiver list. The Send method PUBLISHes a JSON
+// document with the fields "subject", "message" and "timestamp" to each of them.
+func (s *Service) AddReceivers(channels ...string) {
+	s.channels = append(s.channels, channels...)
+}
+
+// AddStreams takes stream keys and adds them to the internal receiver list. The Send method XADDs an entry with the
+// fields "subject", "message" and "timestamp" to each of them. Unlike pub/sub, streams retain messages for consumers
+// that are offline.
+func (s *Service) AddStreams(streams ...string) {
+	s.streams = append(s.streams, streams...)
+}
+
+// SetStreamMaxLength caps the streams at approximately the given number of entries, trimming the oldest ones. Zero,
+// the default, disables trimming.
+func (s *Service) SetStreamMaxLength(maxLength int64) {
+	s.maxLength = maxLength
+}
+
+// Close closes the client.
+func (s *Service) Close() error {
+	return s.client.Close()
+}
+
+// Send takes a message subject and a message body and publishes them to all previously set channels and streams.
+func (s *Service) Send(ctx context.Context, subject, message string) error {
+	now := time.Now().UTC()
+
+	if len(s.channels) > 0 {
+		data, err := json.Marshal(&payload{Subject: subject, Message: message, Timestamp: now})
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal payload")
+		}
+		if err = s.publish(ctx, data); err != nil {
+			return err
+		}
+	}
+
+	return s.addToStreams(ctx, "subject", subject, "message", message, "timestamp", now.Format(time.RFC3339Nano))
+}
+
+// SendJSON publishes the JSON encoding of v to all previously set channels. It is added to all previously set streams
+// as entry with the fields "payload", holding the encoding, and "timestamp".
+func (s *Service) SendJSON(ctx context.Context, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal payload")
+	}
+	if err = s.publish(ctx, data); err != nil {
+		return err
+	}
+
+	return s.addToStreams(ctx, "payload", string(data), "timestamp", time.Now().UTC().Format(time.RFC3339Nano))
+}
+
+// publish publishes data to all channels.
+func (s *Service) publish(ctx context.Context, data []byte) error {
+	for _, channel := range s.channels {
+		if err := s.client.Publish(ctx, channel, data).Err(); err != nil {
+			return classifyError(errors.Wrapf(err, "failed to publish to channel %q", channel))
+		}
+	}
+
+	return nil
+}
+
+// addToStreams adds an entry with the given field value pairs to all streams.
+func (s *Service) addToStreams(ctx context.Context, values ...string) error {
+	for _, stream := range s.streams {
+		args := &redis.XAddArgs{
+			Stream: stream,
+			MaxLen: s.maxLength,
+			Approx: s.maxLength > 0,
+			Values: values,
+		}
+		if err := s.client.XAdd(ctx, args).Err(); err != nil {
+			return classifyError(errors.Wrapf(err, "failed to add to stream %q", stream))
+		}
+	}
+
+	return nil
+}
+
+// classifyError classifies errors of the server by the prefix of their message, and errors of the connection to it,
+// see notify.ClassifyError.
+func classifyError(err error) error {
+	var redisErr redis.Error
+	if !errors.As(err, &redisErr) {
+		return notify.ClassifyNetworkError(err)
+	}
+
+	prefix, _, _ := strings.Cut(redisErr.Error(), " ")
+	switch prefix {
+	case "NOAUTH", "WRONGPASS", "NOPERM":
+		return notify.ClassifyError(err, notify.ErrAuthFailed)
+	case "WRONGTYPE":
+		return notify.ClassifyError(err, notify.ErrInvalidReceiver)
+	case "LOADING", "BUSY", "TRYAGAIN", "CLUSTERDOWN", "MASTERDOWN", "READONLY", "OOM":
+		return notify.ClassifyError(err, notify.ErrTransient)
+	default:
+		return notify.ClassifyError(err, notify.ErrPermanent)
+	}
+}
@@ -0,0 +1,112 @@
+package redispubsub
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nikoksr/notify"
+)
+
+func TestRedisPubSub_New(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	_, err := New("invalid://localhost")
+	assert.Error(err)
+
+	service, err := New("redis://localhost:6379/0")
+	assert.NoError(err)
+	assert.NoError(service.Close())
+}
+
+func TestRedisPubSub_Send(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+	ctx := context.Background()
+
+	server := miniredis.RunT(t)
+	service, err := New("redis://" + server.Addr())
+	assert.NoError(err)
+	defer func() { _ = service.Close() }()
+
+	subscription := service.client.Subscribe(ctx, "alerts")
+	defer func() { _ = subscription.Close() }()
+	_, err = subscription.Receive(ctx)
+	assert.NoError(err)
+
+	service.AddReceivers("alerts")
+	service.AddStreams("alerts-stream")
+	service.SetStreamMaxLength(100)
+	err = service.Send(ctx, "subject", "message")
+	assert.NoError(err)
+
+	select {
+	case msg := <-subscription.Channel():
+		var p payload
+		assert.NoError(json.Unmarshal([]byte(msg.Payload), &p))
+		assert.Equal("subject", p.Subject)
+		assert.Equal("message", p.Message)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for message")
+	}
+
+	entries, err := service.client.XRange(ctx, "alerts-stream", "-", "+").Result()
+	assert.NoError(err)
+	assert.Len(entries, 1)
+	assert.Equal("subject", entries[0].Values["subject"])
+	assert.Equal("message", entries[0].Values["message"])
+
+	// Test error responses
+	assert.NoError(server.Set("not-a-stream", "value"))
+	service.AddStreams("not-a-stream")
+	err = service.Send(ctx, "subject", "message")
+	assert.ErrorIs(err, notify.ErrInvalidReceiver)
+
+	server.Close()
+	err = service.Send(ctx, "subject", "message")
+	assert.ErrorIs(err, notify.ErrTransient)
+}
+
+func TestRedisPubSub_SendJSON(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+	ctx := context.Background()
+
+	server := miniredis.RunT(t)
+	service, err := New("redis://" + server.Addr())
+	assert.NoError(err)
+	defer func() { _ = service.Close() }()
+
+	subscription := service.client.Subscribe(ctx, "deploys")
+	defer func() { _ = subscription.Close() }()
+	_, err = subscription.Receive(ctx)
+	assert.NoError(err)
+
+	service.AddReceivers("deploys")
+	service.AddStreams("deploys-stream")
+	err = service.SendJSON(ctx, map[string]any{"service": "api", "version": "1.2.3"})
+	assert.NoError(err)
+
+	select {
+	case msg := <-subscription.Channel():
+		assert.JSONEq(`{"service":"api","version":"1.2.3"}`, msg.Payload)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for message")
+	}
+
+	entries, err := service.client.XRange(ctx, "deploys-stream", "-", "+").Result()
+	assert.NoError(err)
+	assert.Len(entries, 1)
+	assert.JSONEq(`{"service":"api","version":"1.2.3"}`, entries[0].Values["payload"].(string))
+
+	err = service.SendJSON(ctx, make(chan int))
+	assert.Error(err)
+}
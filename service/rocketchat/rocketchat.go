@@ -3,15 +3,26 @@ package rocketchat
 import (
 	"context"
 	"net/url"
+	"strings"
 
 	"github.com/RocketChat/Rocket.Chat.Go.SDK/models"
 	"github.com/RocketChat/Rocket.Chat.Go.SDK/rest"
 	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
 )
 
+//go:generate mockery --name=rocketChatClient --output=. --case=underscore --inpackage
+type rocketChatClient interface {
+	PostMessage(msg *models.PostMessage) (*rest.MessageResponse, error)
+}
+
+// Compile-time check to ensure that rest.Client implements the rocketChatClient interface.
+var _ rocketChatClient = new(rest.Client)
+
 // RocketChat struct holds necessary data to communicate with the RocketChat API.
 type RocketChat struct {
-	client       *rest.Client
+	client       rocketChatClient
 	channelNames []string
 }
 
@@ -47,6 +58,41 @@ func (r *RocketChat) AddReceivers(channelNames ...string) {
 	r.channelNames = append(r.channelNames, channelNames...)
 }
 
+// AddDirectReceivers takes rocketchat usernames and adds them to the internal channel list. The Send method will send
+// a given message as direct message to those users. A leading '@' is optional.
+func (r *RocketChat) AddDirectReceivers(usernames ...string) {
+	for _, username := range usernames {
+		r.channelNames = append(r.channelNames, "@"+strings.TrimPrefix(username, "@"))
+	}
+}
+
+// Validate checks that at least one channel is set and that no channel name or username is empty, see
+// notify.Validator.
+func (r *RocketChat) Validate() error {
+	var problems notify.ConfigErrors
+	if len(r.channelNames) == 0 {
+		problems.Addf("no channel set")
+	}
+	for _, channelName := range r.channelNames {
+		if channelName == "" || channelName == "@" {
+			problems.Addf("channel name or username is empty")
+		}
+	}
+
+	return problems.Err()
+}
+
+// massMentions breaks the mentions of @all and @here with a zero width space, as they aren't markdown.
+var massMentions = strings.NewReplacer("@all", "@\u200ball", "@here", "@\u200bhere")
+
+// Sanitize escapes the markdown of the message, so that user provided content can neither format the message nor
+// mention everyone in the channel, see notify.Sanitizer.
+func (r *RocketChat) Sanitize(message notify.Message) notify.Message {
+	return notify.SanitizeMessage(message, func(text string) string {
+		return massMentions.Replace(notify.EscapeMarkdown(text))
+	})
+}
+
 // Send takes a message subject and a message body and sends them to all previously set channels.
 // user used for sending the message has to be a member of the channel.
 // https://docs.rocket.chat/api/rest-api/methods/chat/postmessage
@@ -64,9 +110,28 @@ func (r *RocketChat) Send(ctx context.Context, subject, message string) error {
 			}
 			_, err := r.client.PostMessage(&msg)
 			if err != nil {
-				return errors.Wrapf(err, "failed to send message to RocketChat channel '%s'", channelName)
+				return classifyError(errors.Wrapf(err, "failed to send message to RocketChat channel '%s'", channelName))
 			}
 		}
 	}
 	return nil
 }
+
+// classifyError classifies errors returned by the Rocket.Chat API, see notify.ClassifyError. The SDK doesn't expose
+// the HTTP status of failed requests, so the error codes and messages of the API are matched instead.
+func classifyError(err error) error {
+	msg := err.Error()
+	switch {
+	case strings.Contains(msg, "must be logged in"), strings.Contains(msg, "Request error: 401"):
+		return notify.ClassifyError(err, notify.ErrAuthFailed)
+	case strings.Contains(msg, "error-too-many-requests"), strings.Contains(msg, "Request error: 429"):
+		return notify.ClassifyError(err, &notify.ErrRateLimited{})
+	case strings.Contains(msg, "error-invalid-channel"), strings.Contains(msg, "error-room-not-found"),
+		strings.Contains(msg, "error-invalid-user"):
+		return notify.ClassifyError(err, notify.ErrInvalidReceiver)
+	case strings.Contains(msg, "Request error: 5"):
+		return notify.ClassifyError(err, notify.ErrTransient)
+	}
+
+	return notify.ClassifyNetworkError(err)
+}
@@ -0,0 +1,96 @@
+package rocketchat
+
+import (
+	"context"
+	"testing"
+
+	"github.com/RocketChat/Rocket.Chat.Go.SDK/models"
+	"github.com/RocketChat/Rocket.Chat.Go.SDK/rest"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nikoksr/notify"
+)
+
+func TestRocketChat_New(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service, err := New("localhost", "http", "user-id", "token")
+	assert.Nil(err)
+	assert.NotNil(service)
+}
+
+func TestRocketChat_AddReceivers(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service, _ := New("localhost", "http", "user-id", "token")
+	service.AddReceivers("general", "#alerts")
+	service.AddDirectReceivers("jane", "@john")
+	assert.Equal([]string{"general", "#alerts", "@jane", "@john"}, service.channelNames)
+}
+
+func TestRocketChat_Validate(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := &RocketChat{}
+	err := service.Validate()
+	assert.ErrorIs(err, notify.ErrInvalidConfig)
+	assert.ErrorContains(err, "no channel set")
+
+	service.AddReceivers("general")
+	service.AddDirectReceivers("jane")
+	assert.NoError(service.Validate())
+
+	service.AddDirectReceivers("")
+	assert.ErrorContains(service.Validate(), "channel name or username is empty")
+}
+
+func TestRocketChat_Sanitize(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	sanitized := (&RocketChat{}).Sanitize(notify.Message{Subject: "_Alert_", Body: "@here `rm -rf`"})
+	assert.Equal(`\_Alert\_`, sanitized.Subject)
+	assert.Equal("@\u200bhere \\`rm -rf\\`", sanitized.Body)
+}
+
+func TestRocketChat_Send(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service, _ := New("localhost", "http", "user-id", "token")
+	service.AddReceivers("general")
+	service.AddDirectReceivers("jane")
+
+	mockClient := newMockRocketChatClient(t)
+	mockClient.
+		On("PostMessage", &models.PostMessage{Channel: "general", Text: "subject\nmessage"}).
+		Return(&rest.MessageResponse{}, nil)
+	mockClient.
+		On("PostMessage", &models.PostMessage{Channel: "@jane", Text: "subject\nmessage"}).
+		Return(&rest.MessageResponse{}, nil)
+	service.client = mockClient
+
+	err := service.Send(context.Background(), "subject", "message")
+	assert.Nil(err)
+	mockClient.AssertExpectations(t)
+
+	// Test error response
+	mockClient = newMockRocketChatClient(t)
+	mockClient.
+		On("PostMessage", &models.PostMessage{Channel: "general", Text: "subject\nmessage"}).
+		Return(nil, errors.New("some error"))
+	service.client = mockClient
+
+	err = service.Send(context.Background(), "subject", "message")
+	assert.NotNil(err)
+	mockClient.AssertExpectations(t)
+}
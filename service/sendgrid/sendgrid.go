@@ -7,6 +7,8 @@ import (
 	"github.com/pkg/errors"
 	"github.com/sendgrid/sendgrid-go"
 	"github.com/sendgrid/sendgrid-go/helpers/mail"
+
+	"github.com/nikoksr/notify"
 )
 
 // SendGrid struct holds necessary data to communicate with the SendGrid API.
@@ -35,6 +37,17 @@ func (s *SendGrid) AddReceivers(addresses ...string) {
 	s.receiverAddresses = append(s.receiverAddresses, addresses...)
 }
 
+// Validate checks that the sender and receiver addresses parse, see notify.Validator.
+func (s SendGrid) Validate() error {
+	var problems notify.ConfigErrors
+	problems.Check("sender", notify.ValidateMailAddress(s.senderAddress))
+	for _, address := range s.receiverAddresses {
+		problems.Check("receiver", notify.ValidateMailAddress(address))
+	}
+
+	return problems.Err()
+}
+
 // Send takes a message subject and a message body and sends them to all previously set chats. Message body supports
 // html as markup language.
 func (s SendGrid) Send(ctx context.Context, subject, message string) error {
@@ -60,11 +73,11 @@ func (s SendGrid) Send(ctx context.Context, subject, message string) error {
 	default:
 		resp, err := s.client.Send(mailMessage)
 		if err != nil {
-			return errors.Wrap(err, "failed to send mail using SendGrid service")
+			return notify.ClassifyNetworkError(errors.Wrap(err, "failed to send mail using SendGrid service"))
 		}
 
 		if resp.StatusCode != http.StatusAccepted {
-			return errors.New("the SendGrid endpoint did not accept the message")
+			return notify.ClassifyStatus(resp.StatusCode, errors.New("the SendGrid endpoint did not accept the message"))
 		}
 	}
 
@@ -0,0 +1,26 @@
+package sendgrid
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nikoksr/notify"
+)
+
+func TestSendGrid_Validate(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := New("key", "alerts@example.com", "Alerts")
+	service.AddReceivers("alice@example.com")
+	assert.NoError(service.Validate())
+
+	service = New("key", "alerts", "Alerts")
+	service.AddReceivers("alice")
+	err := service.Validate()
+	assert.ErrorIs(err, notify.ErrInvalidConfig)
+	assert.ErrorContains(err, `sender: mail address "alerts"`)
+	assert.ErrorContains(err, `receiver: mail address "alice"`)
+}
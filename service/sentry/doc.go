@@ -0,0 +1,37 @@
+/*
+Package sentry provides a service for recording notifications as Sentry events.
+
+Usage:
+
+	package main
+
+	import (
+	    "context"
+	    "log"
+
+	    sentrygo "github.com/getsentry/sentry-go"
+
+	    "github.com/nikoksr/notify"
+	    "github.com/nikoksr/notify/service/sentry"
+	)
+
+	func main() {
+	    sentryService, err := sentry.New("https://public@o0.ingest.sentry.io/0")
+	    if err != nil {
+	        log.Fatal(err)
+	    }
+
+	    // Record notifications as warnings, tagged with the owning team.
+	    sentryService.SetLevel(sentrygo.LevelWarning)
+	    sentryService.AddTag("team", "ops")
+
+	    // Tell our notifier to use the sentry service.
+	    notify.UseServices(sentryService)
+
+	    // Send a test message.
+	    if err := notify.Send(context.Background(), "Subject/Title", "The actual message"); err != nil {
+	        log.Fatal(err)
+	    }
+	}
+*/
+package sentry
@@ -0,0 +1,97 @@
+package sentry
+
+import (
+	"context"
+	"time"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
+)
+
+// defaultFlushTimeout is the maximum time Send waits for an event to be delivered if the context has no deadline.
+const defaultFlushTimeout = 5 * time.Second
+
+// Service encapsulates the Sentry client.
+type Service struct {
+	client *sentry.Client
+	level  sentry.Level
+	tags   map[string]string
+}
+
+// New returns a new instance of a Sentry notification service for the project identified by the given DSN, which can
+// be found under Client Keys in the project settings. The service uses its own client, independent of the global
+// Sentry hub used for error tracking.
+func New(dsn string) (*Service, error) {
+	return NewWithOptions(sentry.ClientOptions{
+		Dsn:       dsn,
+		Transport: sentry.NewHTTPSyncTransport(),
+	})
+}
+
+// NewWithOptions returns a new instance of a Sentry notification service using a client created with the given
+// options, e.g. to set the environment or release of the events.
+func NewWithOptions(options sentry.ClientOptions) (*Service, error) {
+	client, err := sentry.NewClient(options)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create sentry client")
+	}
+
+	return &Service{
+		client: client,
+		level:  sentry.LevelInfo,
+		tags:   map[string]string{},
+	}, nil
+}
+
+// SetLevel sets the level of all events recorded by this service. Defaults to sentry.LevelInfo.
+func (s *Service) SetLevel(level sentry.Level) {
+	s.level = level
+}
+
+// AddTag adds a tag to all events recorded by this service. Tags are indexed by Sentry and can be used to search and
+// filter events.
+func (s *Service) AddTag(key, value string) {
+	s.tags[key] = value
+}
+
+// Send takes a message subject and a message body and records them as a message event in Sentry. Events are grouped
+// by subject.
+func (s *Service) Send(ctx context.Context, subject, message string) error {
+	event := sentry.NewEvent()
+	event.Level = s.level
+	event.Message = subject
+	if message != "" {
+		event.Message += "\n\n" + message
+	}
+	event.Fingerprint = []string{subject}
+	event.Contexts["notification"] = map[string]any{
+		"subject": subject,
+		"message": message,
+	}
+	for key, value := range s.tags {
+		event.Tags[key] = value
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+
+	if id := s.client.CaptureEvent(event, nil, nil); id == nil {
+		// Sentry drops events when its queue is full or the project is rate limited.
+		return notify.ClassifyError(errors.New("failed to send event to Sentry: event was dropped"), notify.ErrTransient)
+	}
+
+	timeout := defaultFlushTimeout
+	if deadline, ok := ctx.Deadline(); ok {
+		timeout = time.Until(deadline)
+	}
+	if !s.client.Flush(timeout) {
+		return notify.ClassifyError(errors.New("failed to send event to Sentry: timed out"), notify.ErrTransient)
+	}
+
+	return nil
+}
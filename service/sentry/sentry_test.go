@@ -0,0 +1,62 @@
+package sentry
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/stretchr/testify/require"
+)
+
+// transport records events instead of sending them.
+type transport struct {
+	events []*sentry.Event
+}
+
+func (t *transport) Flush(time.Duration) bool       { return true }
+func (t *transport) Configure(sentry.ClientOptions) {}
+func (t *transport) SendEvent(event *sentry.Event)  { t.events = append(t.events, event) }
+
+func TestSentry_New(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	_, err := New("invalid")
+	assert.Error(err)
+
+	service, err := New("https://public@sentry.example.com/1")
+	assert.NoError(err)
+	assert.Equal(sentry.LevelInfo, service.level)
+}
+
+func TestSentry_Send(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	tr := &transport{}
+	service, err := NewWithOptions(sentry.ClientOptions{
+		Dsn:       "https://public@sentry.example.com/1",
+		Transport: tr,
+	})
+	assert.NoError(err)
+
+	service.SetLevel(sentry.LevelWarning)
+	service.AddTag("team", "ops")
+	assert.NoError(service.Send(context.Background(), "subject", "message"))
+
+	assert.Len(tr.events, 1)
+	event := tr.events[0]
+	assert.Equal(sentry.LevelWarning, event.Level)
+	assert.Equal("subject\n\nmessage", event.Message)
+	assert.Equal([]string{"subject"}, event.Fingerprint)
+	assert.Equal("ops", event.Tags["team"])
+	assert.Equal(map[string]any{"subject": "subject", "message": "message"}, event.Contexts["notification"])
+
+	// Test canceled context
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.Error(service.Send(ctx, "subject", "message"))
+}
@@ -0,0 +1,31 @@
+/*
+Package serverchan provides a service for pushing messages to personal WeChat accounts via ServerChan.
+
+Usage:
+
+	package main
+
+	import (
+	    "context"
+	    "log"
+
+	    "github.com/nikoksr/notify"
+	    "github.com/nikoksr/notify/service/serverchan"
+	)
+
+	func main() {
+	    serverChanService := serverchan.New()
+
+	    // Send keys can be found on https://sct.ftqq.com/sendkey.
+	    serverChanService.AddReceivers("SCTxxxxxxxx")
+
+	    // Tell our notifier to use the serverchan service.
+	    notify.UseServices(serverChanService)
+
+	    // Send a test message. The message body may contain markdown.
+	    if err := notify.Send(context.Background(), "Subject/Title", "The actual message"); err != nil {
+	        log.Fatal(err)
+	    }
+	}
+*/
+package serverchan
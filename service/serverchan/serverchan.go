@@ -0,0 +1,139 @@
+package serverchan
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
+)
+
+// DefaultServerURL is the API endpoint of ServerChan Turbo.
+const DefaultServerURL = "https://sctapi.ftqq.com"
+
+// sc3KeyPattern matches ServerChan³ send keys, which embed the ID of the user's push server.
+var sc3KeyPattern = regexp.MustCompile(`^sctp(\d+)t`)
+
+// Service encapsulates the ServerChan client.
+type Service struct {
+	client    *http.Client
+	serverURL string
+	sendKeys  []string
+	channel   string
+}
+
+// New returns a new instance of a ServerChan notification service. Messages get pushed to the personal WeChat accounts
+// (or other channels) bound to the send keys added via AddReceivers.
+// For more information about ServerChan:
+//
+//	-> https://sct.ftqq.com
+func New() *Service {
+	return &Service{
+		client: &http.Client{
+			Transport: notify.NewRateLimitTransport(nil),
+			Timeout:   10 * time.Second,
+		},
+		serverURL: DefaultServerURL,
+		sendKeys:  []string{},
+	}
+}
+
+// WithClient sets the http client to be used for sending requests. Calling this method is optional, the default client
+// will be used if this method is not called.
+func (s *Service) WithClient(client *http.Client) {
+	if client != nil {
+		s.client = client
+	}
+}
+
+// AddReceivers takes send keys and adds them to the internal receiver list. Both ServerChan Turbo keys (SCT...) and
+// ServerChan³ keys (sctp...) are supported.
+func (s *Service) AddReceivers(sendKeys ...string) {
+	s.sendKeys = append(s.sendKeys, sendKeys...)
+}
+
+// SetChannel overrides the message channels configured on the website, e.g. "9|66" for the WeChat service account and
+// the official account test channel. Only supported by ServerChan Turbo.
+func (s *Service) SetChannel(channel string) {
+	s.channel = channel
+}
+
+// response is the body returned by both ServerChan APIs.
+type response struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// endpoint returns the URL messages for the given send key get posted to.
+func (s *Service) endpoint(sendKey string) string {
+	if m := sc3KeyPattern.FindStringSubmatch(sendKey); m != nil && s.serverURL == DefaultServerURL {
+		return "https://" + m[1] + ".push.ft07.com/send/" + sendKey + ".send"
+	}
+
+	return s.serverURL + "/" + sendKey + ".send"
+}
+
+func (s *Service) send(ctx context.Context, sendKey, subject, message string) error {
+	form := url.Values{
+		"title": {subject},
+		"desp":  {message},
+	}
+	if s.channel != "" {
+		form.Set("channel", s.channel)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint(sendKey), strings.NewReader(form.Encode()))
+	if err != nil {
+		return errors.Wrap(err, "create request")
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "send request")
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Wrap(err, "read response")
+	}
+	if resp.StatusCode != http.StatusOK {
+		return notify.ClassifyResponse(resp, fmt.Errorf("serverchan returned status code %d: %s", resp.StatusCode, string(body)))
+	}
+
+	var r response
+	if err = json.Unmarshal(body, &r); err != nil {
+		return errors.Wrap(err, "decode response")
+	}
+	if r.Code != 0 {
+		return fmt.Errorf("serverchan returned error code %d: %s", r.Code, r.Message)
+	}
+
+	return nil
+}
+
+// Send takes a message subject and a message body and sends them to all previously set send keys. The body may
+// contain markdown.
+func (s *Service) Send(ctx context.Context, subject, message string) error {
+	for _, sendKey := range s.sendKeys {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			if err := s.send(ctx, sendKey, subject, message); err != nil {
+				return errors.Wrap(err, "failed to send message to serverchan")
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,56 @@
+package serverchan
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestServerChan_Endpoint(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := New()
+	assert.Equal("https://sctapi.ftqq.com/SCTkey.send", service.endpoint("SCTkey"))
+	assert.Equal("https://1234.push.ft07.com/send/sctp1234tkey.send", service.endpoint("sctp1234tkey"))
+}
+
+func TestServerChan_Send(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/SCTkey.send" {
+			_, _ = w.Write([]byte(`{"code":40001,"message":"bad pushkey"}`))
+			return
+		}
+		if r.FormValue("title") != "subject" || r.FormValue("desp") != "message" || r.FormValue("channel") != "9" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		_, _ = w.Write([]byte(`{"code":0,"message":""}`))
+	}))
+	defer server.Close()
+
+	service := New()
+	service.serverURL = server.URL
+	service.SetChannel("9")
+
+	// No receivers added
+	err := service.Send(context.Background(), "subject", "message")
+	assert.Nil(err)
+
+	service.AddReceivers("SCTkey")
+	err = service.Send(context.Background(), "subject", "message")
+	assert.Nil(err)
+
+	// Test error response
+	service.AddReceivers("invalid")
+	err = service.Send(context.Background(), "subject", "message")
+	assert.NotNil(err)
+}
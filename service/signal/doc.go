@@ -0,0 +1,35 @@
+/*
+Package signal provides a service for sending messages through a signal-cli-rest-api instance.
+
+Signal does not offer an official bot API. This service relies on https://github.com/bbernhard/signal-cli-rest-api,
+which has to be running and linked to or registered with the sending phone number.
+
+Usage:
+
+	package main
+
+	import (
+	    "context"
+	    "log"
+
+	    "github.com/nikoksr/notify"
+	    "github.com/nikoksr/notify/service/signal"
+	)
+
+	func main() {
+	    // Create a signal service using the address of your signal-cli-rest-api instance and the sending number.
+	    signalService := signal.New("http://localhost:8080", "+491234567890")
+
+	    // Add phone numbers or group IDs to send messages to.
+	    signalService.AddReceivers("+499876543210", "group.c2lnbmFsLWdyb3VwLWlk")
+
+	    // Tell our notifier to use the signal service.
+	    notify.UseServices(signalService)
+
+	    // Send a test message.
+	    if err := notify.Send(context.Background(), "Subject/Title", "The actual message"); err != nil {
+	        log.Fatal(err)
+	    }
+	}
+*/
+package signal
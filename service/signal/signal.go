@@ -0,0 +1,135 @@
+package signal
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
+)
+
+// Service allow you to configure the Signal service.
+type Service struct {
+	client     *http.Client
+	baseURL    string
+	sender     string
+	recipients []string
+}
+
+func defaultHTTPClient() *http.Client {
+	return &http.Client{
+		Transport: notify.NewRateLimitTransport(nil),
+		Timeout:   30 * time.Second,
+	}
+}
+
+// New returns a new instance of a Signal notification service. The baseURL is the address of a signal-cli-rest-api
+// instance and sender is the phone number registered with it, in international format.
+// For more information about signal-cli-rest-api:
+//
+//	-> https://github.com/bbernhard/signal-cli-rest-api
+func New(baseURL, sender string) *Service {
+	return &Service{
+		client:     defaultHTTPClient(),
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		sender:     sender,
+		recipients: []string{},
+	}
+}
+
+// WithClient sets the http client to be used for sending requests. Calling this method is optional, the default client
+// will be used if this method is not called.
+func (s *Service) WithClient(client *http.Client) {
+	if client != nil {
+		s.client = client
+	}
+}
+
+// AddReceivers takes phone numbers in international format or group IDs (as returned by the /v1/groups endpoint,
+// e.g. "group.abc...") and adds them to the internal recipient list. The Send method will send a given message to all
+// of those recipients.
+func (s *Service) AddReceivers(recipients ...string) {
+	s.recipients = append(s.recipients, recipients...)
+}
+
+// sendRequest is the request body expected by the /v2/send endpoint.
+type sendRequest struct {
+	Message    string   `json:"message"`
+	Number     string   `json:"number"`
+	Recipients []string `json:"recipients"`
+}
+
+// Validate checks that the base URL is well-formed and that the sender and the recipients, except for groups, are
+// phone numbers in E.164 format, see notify.Validator.
+func (s *Service) Validate() error {
+	var problems notify.ConfigErrors
+	problems.Check("base URL", notify.ValidateURL(s.baseURL))
+	problems.Check("sender", notify.ValidatePhoneNumber(s.sender))
+	for _, recipient := range s.recipients {
+		if !strings.HasPrefix(recipient, "group.") {
+			problems.Check("recipient", notify.ValidatePhoneNumber(recipient))
+		}
+	}
+
+	return problems.Err()
+}
+
+// ResolveContact returns the phone number of the contact, see notify.SendToContacts.
+func (s *Service) ResolveContact(contact notify.Contact) (string, bool) {
+	return contact.Phone, contact.Phone != ""
+}
+
+// Send takes a message subject and a message body and sends them to all previously set recipients with a single
+// request. The recipients can be overridden per send, see notify.WithReceiversOverride.
+func (s *Service) Send(ctx context.Context, subject, message string) error {
+	recipients := notify.ReceiversFromContext(ctx, s.recipients)
+	if len(recipients) == 0 {
+		return nil
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+
+	text := message
+	if subject != "" {
+		text = subject + "\n" + message
+	}
+
+	payload, err := json.Marshal(&sendRequest{
+		Message:    text,
+		Number:     s.sender,
+		Recipients: recipients,
+	})
+	if err != nil {
+		return errors.Wrap(err, "marshal message")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.baseURL+"/v2/send", bytes.NewReader(payload))
+	if err != nil {
+		return errors.Wrap(err, "create request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "send request")
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		result, _ := io.ReadAll(resp.Body)
+		return notify.ClassifyResponse(resp, fmt.Errorf("signal returned status code %d: %s", resp.StatusCode, string(result)))
+	}
+
+	return nil
+}
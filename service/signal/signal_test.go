@@ -0,0 +1,78 @@
+package signal
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nikoksr/notify"
+)
+
+func TestSignal_New(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := New("http://localhost:8080/", "+491234567890")
+	assert.NotNil(service)
+	assert.Equal("http://localhost:8080", service.baseURL)
+
+	service.AddReceivers("+499876543210", "group.abc")
+	assert.Len(service.recipients, 2)
+}
+
+func TestSignal_Validate(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := New("http://localhost:8080", "+431212131491291")
+	service.AddReceivers("+4915112345678", "group.abc")
+	assert.NoError(service.Validate())
+
+	service = New("localhost:8080", "431212131491291")
+	service.AddReceivers("015112345678")
+	err := service.Validate()
+	assert.ErrorIs(err, notify.ErrInvalidConfig)
+	assert.ErrorContains(err, "base URL")
+	assert.ErrorContains(err, `sender: phone number "431212131491291" is not in E.164 format`)
+	assert.ErrorContains(err, `recipient: phone number "015112345678" is not in E.164 format`)
+}
+
+func TestSignal_Send(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	var got sendRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		if got.Number != "+491234567890" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	// No receivers added
+	service := New(server.URL, "+491234567890")
+	err := service.Send(context.Background(), "subject", "message")
+	assert.Nil(err)
+
+	service.AddReceivers("+499876543210", "group.abc")
+	err = service.Send(context.Background(), "subject", "message")
+	assert.Nil(err)
+	assert.Equal("subject\nmessage", got.Message)
+	assert.Equal([]string{"+499876543210", "group.abc"}, got.Recipients)
+
+	// Test error response
+	service = New(server.URL, "unknown")
+	service.AddReceivers("+499876543210")
+	err = service.Send(context.Background(), "subject", "message")
+	assert.NotNil(err)
+}
@@ -0,0 +1,34 @@
+/*
+Package simplepush provides a service for sending push notifications via SimplePush.
+
+Usage:
+
+	package main
+
+	import (
+	    "context"
+	    "log"
+
+	    "github.com/nikoksr/notify"
+	    "github.com/nikoksr/notify/service/simplepush"
+	)
+
+	func main() {
+	    simplePushService := simplepush.New()
+
+	    // Send to these keys.
+	    simplePushService.AddReceivers("HuxgBB")
+
+	    // Encrypt title and message with the password set in the app.
+	    simplePushService.SetEncryption("your-password", "")
+
+	    // Tell our notifier to use the simplepush service.
+	    notify.UseServices(simplePushService)
+
+	    // Send a test message.
+	    if err := notify.Send(context.Background(), "Subject/Title", "The actual message"); err != nil {
+	        log.Fatal(err)
+	    }
+	}
+*/
+package simplepush
@@ -0,0 +1,165 @@
+package simplepush
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha1" //nolint:gosec // SimplePush derives the encryption key with SHA-1.
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
+)
+
+const defaultAPIURL = "https://api.simplepush.io/send"
+
+// DefaultSalt is the salt used to derive the encryption key if none is given to SetEncryption. It's the default of
+// the SimplePush app.
+const DefaultSalt = "1789F0B8C4A051E5"
+
+// Service encapsulates the SimplePush client.
+type Service struct {
+	client *http.Client
+	apiURL string
+	event  string
+	keys   []string
+
+	// encryptionKey is only set if end-to-end encryption is enabled.
+	encryptionKey []byte
+}
+
+// New returns a new instance of a SimplePush notification service.
+// For more information about the SimplePush API:
+//
+//	-> https://simplepush.io/api
+func New() *Service {
+	return &Service{
+		client: &http.Client{
+			Transport: notify.NewRateLimitTransport(nil),
+			Timeout:   10 * time.Second,
+		},
+		apiURL: defaultAPIURL,
+		keys:   []string{},
+	}
+}
+
+// WithClient sets the http client to be used for sending requests. Calling this method is optional, the default client
+// will be used if this method is not called.
+func (s *Service) WithClient(client *http.Client) {
+	if client != nil {
+		s.client = client
+	}
+}
+
+// AddReceivers takes SimplePush keys and adds them to the internal key list. The Send method will send a given message
+// to every one of those keys.
+func (s *Service) AddReceivers(keys ...string) {
+	s.keys = append(s.keys, keys...)
+}
+
+// SetEvent sets the event of the notifications, which can be used to customize their ringtone and vibration pattern
+// in the app.
+func (s *Service) SetEvent(event string) {
+	s.event = event
+}
+
+// SetEncryption enables end-to-end encryption of title and message with the password and salt set in the app. If the
+// salt is empty, DefaultSalt is used.
+func (s *Service) SetEncryption(password, salt string) {
+	if salt == "" {
+		salt = DefaultSalt
+	}
+
+	sum := sha1.Sum([]byte(password + salt)) //nolint:gosec // See import.
+	s.encryptionKey = sum[:aes.BlockSize]
+}
+
+// encrypt encrypts text with AES-128-CBC and PKCS#7 padding and returns it URL-safe base64 encoded.
+func encrypt(block cipher.Block, iv []byte, text string) string {
+	padding := aes.BlockSize - len(text)%aes.BlockSize
+	plaintext := append([]byte(text), bytes.Repeat([]byte{byte(padding)}, padding)...)
+
+	ciphertext := make([]byte, len(plaintext))
+	cipher.NewCBCEncrypter(block, iv).CryptBlocks(ciphertext, plaintext)
+
+	return base64.URLEncoding.EncodeToString(ciphertext)
+}
+
+// Send takes a message subject and a message body and sends them to all previously set keys.
+func (s *Service) Send(ctx context.Context, subject, message string) error {
+	form := url.Values{
+		"title": {subject},
+		"msg":   {message},
+	}
+	if s.event != "" {
+		form.Set("event", s.event)
+	}
+
+	if s.encryptionKey != nil {
+		block, err := aes.NewCipher(s.encryptionKey)
+		if err != nil {
+			return errors.Wrap(err, "failed to create cipher")
+		}
+
+		iv := make([]byte, aes.BlockSize)
+		if _, err = io.ReadFull(rand.Reader, iv); err != nil {
+			return errors.Wrap(err, "failed to generate iv")
+		}
+
+		form.Set("encrypted", "true")
+		form.Set("iv", strings.ToUpper(hex.EncodeToString(iv)))
+		form.Set("title", encrypt(block, iv, subject))
+		form.Set("msg", encrypt(block, iv, message))
+	}
+
+	for _, key := range s.keys {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			form.Set("key", key)
+			if err := s.push(ctx, form); err != nil {
+				return errors.Wrap(err, "failed to send message")
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) push(ctx context.Context, form url.Values) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.apiURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return errors.Wrap(err, "create request")
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "send request")
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	b, _ := io.ReadAll(resp.Body)
+
+	var result struct {
+		Status  string `json:"status"`
+		Message string `json:"message"`
+	}
+	if resp.StatusCode != http.StatusOK || json.Unmarshal(b, &result) != nil || result.Status != "OK" {
+		return notify.ClassifyResponse(resp, fmt.Errorf("simplepush returned status code %d: %s", resp.StatusCode, string(b)))
+	}
+
+	return nil
+}
@@ -0,0 +1,74 @@
+package simplepush
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/base64"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func decrypt(t *testing.T, key []byte, ivHex, text string) string {
+	t.Helper()
+
+	block, err := aes.NewCipher(key)
+	require.NoError(t, err)
+	iv, err := hex.DecodeString(ivHex)
+	require.NoError(t, err)
+	ciphertext, err := base64.URLEncoding.DecodeString(text)
+	require.NoError(t, err)
+
+	plaintext := make([]byte, len(ciphertext))
+	cipher.NewCBCDecrypter(block, iv).CryptBlocks(plaintext, ciphertext)
+
+	return string(plaintext[:len(plaintext)-int(plaintext[len(plaintext)-1])])
+}
+
+func TestSimplePush_Send(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	var got []url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		if r.PostForm.Get("key") == "invalid" {
+			_, _ = w.Write([]byte(`{"status":"error","message":"Invalid key"}`))
+			return
+		}
+		got = append(got, r.PostForm)
+		_, _ = w.Write([]byte(`{"status":"OK"}`))
+	}))
+	defer server.Close()
+
+	service := New()
+	service.apiURL = server.URL
+	service.AddReceivers("HuxgBB")
+	service.SetEvent("alert")
+
+	assert.NoError(service.Send(context.Background(), "subject", "message"))
+	assert.Equal(url.Values{
+		"key":   {"HuxgBB"},
+		"title": {"subject"},
+		"msg":   {"message"},
+		"event": {"alert"},
+	}, got[0])
+
+	// Test encryption
+	service.SetEncryption("password", "")
+	assert.Len(service.encryptionKey, 16)
+	assert.NoError(service.Send(context.Background(), "subject", "a longer message spanning blocks"))
+	assert.Equal("true", got[1].Get("encrypted"))
+	assert.Equal("subject", decrypt(t, service.encryptionKey, got[1].Get("iv"), got[1].Get("title")))
+	assert.Equal("a longer message spanning blocks", decrypt(t, service.encryptionKey, got[1].Get("iv"), got[1].Get("msg")))
+
+	// Test error response
+	service.AddReceivers("invalid")
+	assert.Error(service.Send(context.Background(), "subject", "message"))
+}
@@ -0,0 +1,31 @@
+/*
+Package skype provides a service for sending messages to Skype conversations through the Bot Framework connector.
+
+Usage:
+
+	package main
+
+	import (
+	    "context"
+	    "log"
+
+	    "github.com/nikoksr/notify"
+	    "github.com/nikoksr/notify/service/skype"
+	)
+
+	func main() {
+	    skypeService := skype.New("your-app-id", "your-app-password")
+
+	    // Add conversations the bot is a member of.
+	    skypeService.AddReceivers("29:1abcdef", "19:abcdef@thread.skype")
+
+	    // Tell our notifier to use the skype service.
+	    notify.UseServices(skypeService)
+
+	    // Send a test message.
+	    if err := notify.Send(context.Background(), "Subject/Title", "The actual message - Hello, you awesome gophers! :)"); err != nil {
+	        log.Fatal(err)
+	    }
+	}
+*/
+package skype
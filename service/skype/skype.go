@@ -0,0 +1,159 @@
+package skype
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+
+	"github.com/nikoksr/notify"
+)
+
+const (
+	// DefaultServiceURL is the Bot Framework connector endpoint serving Skype conversations.
+	DefaultServiceURL = "https://smba.trafficmanager.net/apis"
+
+	defaultTokenURL = "https://login.microsoftonline.com/botframework.com/oauth2/v2.0/token"
+	botScope        = "https://api.botframework.com/.default"
+)
+
+// Service encapsulates the Bot Framework connector client.
+type Service struct {
+	client        *http.Client
+	serviceURL    string
+	conversations []string
+	oauthConfig   *clientcredentials.Config
+
+	mu          sync.Mutex
+	tokenSource oauth2.TokenSource
+}
+
+// New returns a new instance of a Skype notification service for a bot registered with the Azure Bot Service. App ID
+// and password are the credentials of the bot's app registration.
+// For more information about sending proactive messages through the Bot Connector API:
+//
+//	-> https://learn.microsoft.com/azure/bot-service/rest-api/bot-framework-rest-connector-send-and-receive-messages
+func New(appID, appPassword string) *Service {
+	return &Service{
+		client: &http.Client{
+			Transport: notify.NewRateLimitTransport(nil),
+			Timeout:   10 * time.Second,
+		},
+		serviceURL:    DefaultServiceURL,
+		conversations: []string{},
+		oauthConfig: &clientcredentials.Config{
+			ClientID:     appID,
+			ClientSecret: appPassword,
+			TokenURL:     defaultTokenURL,
+			Scopes:       []string{botScope},
+		},
+	}
+}
+
+// WithClient sets the http client to be used for sending requests. Calling this method is optional, the default client
+// will be used if this method is not called.
+func (s *Service) WithClient(client *http.Client) {
+	if client != nil {
+		s.mu.Lock()
+		s.client = client
+		s.tokenSource = nil
+		s.mu.Unlock()
+	}
+}
+
+// SetServiceURL sets the connector endpoint messages are sent to. The service URL is part of every activity the bot
+// receives. Defaults to DefaultServiceURL.
+func (s *Service) SetServiceURL(serviceURL string) {
+	s.serviceURL = strings.TrimSuffix(serviceURL, "/")
+}
+
+// AddReceivers takes Skype conversation IDs and adds them to the internal conversation list. The bot must have been
+// added to those conversations before. The Send method will send a given message to all those conversations.
+func (s *Service) AddReceivers(conversationIDs ...string) {
+	s.conversations = append(s.conversations, conversationIDs...)
+}
+
+// token returns a cached access token or requests a new one if there is none or it expired.
+func (s *Service) token() (*oauth2.Token, error) {
+	s.mu.Lock()
+	if s.tokenSource == nil {
+		// The token source outlives single requests, so it must not be bound to a request context.
+		ctx := context.WithValue(context.Background(), oauth2.HTTPClient, s.client)
+		s.tokenSource = s.oauthConfig.TokenSource(ctx)
+	}
+	tokenSource := s.tokenSource
+	s.mu.Unlock()
+
+	return tokenSource.Token()
+}
+
+type activity struct {
+	Type       string `json:"type"`
+	Text       string `json:"text"`
+	TextFormat string `json:"textFormat"`
+}
+
+// Send takes a message subject and a message body and sends them to all previously set conversations. The subject is
+// rendered in bold using markdown.
+func (s *Service) Send(ctx context.Context, subject, message string) error {
+	text := message
+	if subject != "" {
+		text = fmt.Sprintf("**%s**\n\n%s", subject, message)
+	}
+
+	body, err := json.Marshal(activity{Type: "message", Text: text, TextFormat: "markdown"})
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal activity")
+	}
+
+	for _, conversation := range s.conversations {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			if err = s.send(ctx, conversation, body); err != nil {
+				return errors.Wrapf(err, "failed to send message to Skype conversation '%s'", conversation)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) send(ctx context.Context, conversation string, body []byte) error {
+	token, err := s.token()
+	if err != nil {
+		return errors.Wrap(err, "get access token")
+	}
+
+	endpoint := fmt.Sprintf("%s/v3/conversations/%s/activities", s.serviceURL, url.PathEscape(conversation))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "create request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	token.SetAuthHeader(req)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "send request")
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		result, _ := io.ReadAll(resp.Body)
+		return notify.ClassifyResponse(resp, fmt.Errorf("skype returned status code %d: %s", resp.StatusCode, string(result)))
+	}
+
+	return nil
+}
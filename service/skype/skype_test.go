@@ -0,0 +1,70 @@
+package skype
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSkype_Send(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	var (
+		tokenRequests int
+		got           = map[string]activity{}
+	)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		id, secret, _ := r.BasicAuth()
+		if r.PostForm.Get("client_id") != "" {
+			id, secret = r.PostForm.Get("client_id"), r.PostForm.Get("client_secret")
+		}
+		if id != "app" || secret != "password" || r.PostForm.Get("scope") != botScope {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error":"invalid_client"}`))
+			return
+		}
+		tokenRequests++
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token":"token","token_type":"Bearer","expires_in":3600}`))
+	})
+	mux.HandleFunc("/v3/conversations/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var a activity
+		_ = json.NewDecoder(r.Body).Decode(&a)
+		got[r.URL.EscapedPath()] = a
+		w.WriteHeader(http.StatusCreated)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	service := New("app", "password")
+	service.oauthConfig.TokenURL = server.URL + "/token"
+	service.SetServiceURL(server.URL + "/")
+	service.AddReceivers("29:1abc", "19:group@thread.skype")
+
+	assert.NoError(service.Send(context.Background(), "subject", "message"))
+	assert.NoError(service.Send(context.Background(), "subject", "message"))
+	assert.Equal(1, tokenRequests)
+	assert.Equal(map[string]activity{
+		"/v3/conversations/29:1abc/activities":               {Type: "message", Text: "**subject**\n\nmessage", TextFormat: "markdown"},
+		"/v3/conversations/19:group@thread.skype/activities": {Type: "message", Text: "**subject**\n\nmessage", TextFormat: "markdown"},
+	}, got)
+
+	// Test error response
+	service = New("app", "invalid")
+	service.oauthConfig.TokenURL = server.URL + "/token"
+	service.SetServiceURL(server.URL)
+	service.AddReceivers("29:1abc")
+	assert.Error(service.Send(context.Background(), "subject", "message"))
+}
@@ -14,6 +14,57 @@ type mockSlackClient struct {
 	mock.Mock
 }
 
+// AuthTestContext provides a mock function with given fields: ctx
+func (_m *mockSlackClient) AuthTestContext(ctx context.Context) (*slack_goslack.AuthTestResponse, error) {
+	ret := _m.Called(ctx)
+
+	var r0 *slack_goslack.AuthTestResponse
+	if rf, ok := ret.Get(0).(func(context.Context) *slack_goslack.AuthTestResponse); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*slack_goslack.AuthTestResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteMessageContext provides a mock function with given fields: ctx, channelID, timestamp
+func (_m *mockSlackClient) DeleteMessageContext(ctx context.Context, channelID string, timestamp string) (string, string, error) {
+	ret := _m.Called(ctx, channelID, timestamp)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
+		r0 = rf(ctx, channelID, timestamp)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 string
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) string); ok {
+		r1 = rf(ctx, channelID, timestamp)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, string, string) error); ok {
+		r2 = rf(ctx, channelID, timestamp)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // PostMessageContext provides a mock function with given fields: ctx, channelID, options
 func (_m *mockSlackClient) PostMessageContext(ctx context.Context, channelID string, options ...slack_goslack.MsgOption) (string, string, error) {
 	_va := make([]interface{}, len(options))
@@ -49,6 +100,71 @@ func (_m *mockSlackClient) PostMessageContext(ctx context.Context, channelID str
 	return r0, r1, r2
 }
 
+// UpdateMessageContext provides a mock function with given fields: ctx, channelID, timestamp, options
+func (_m *mockSlackClient) UpdateMessageContext(ctx context.Context, channelID string, timestamp string, options ...slack_goslack.MsgOption) (string, string, string, error) {
+	_va := make([]interface{}, len(options))
+	for _i := range options {
+		_va[_i] = options[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, channelID, timestamp)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, ...slack_goslack.MsgOption) string); ok {
+		r0 = rf(ctx, channelID, timestamp, options...)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 string
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, ...slack_goslack.MsgOption) string); ok {
+		r1 = rf(ctx, channelID, timestamp, options...)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	var r2 string
+	if rf, ok := ret.Get(2).(func(context.Context, string, string, ...slack_goslack.MsgOption) string); ok {
+		r2 = rf(ctx, channelID, timestamp, options...)
+	} else {
+		r2 = ret.Get(2).(string)
+	}
+
+	var r3 error
+	if rf, ok := ret.Get(3).(func(context.Context, string, string, ...slack_goslack.MsgOption) error); ok {
+		r3 = rf(ctx, channelID, timestamp, options...)
+	} else {
+		r3 = ret.Error(3)
+	}
+
+	return r0, r1, r2, r3
+}
+
+// UploadFileContext provides a mock function with given fields: ctx, params
+func (_m *mockSlackClient) UploadFileContext(ctx context.Context, params slack_goslack.FileUploadParameters) (*slack_goslack.File, error) {
+	ret := _m.Called(ctx, params)
+
+	var r0 *slack_goslack.File
+	if rf, ok := ret.Get(0).(func(context.Context, slack_goslack.FileUploadParameters) *slack_goslack.File); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*slack_goslack.File)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, slack_goslack.FileUploadParameters) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type mockConstructorTestingTnewMockSlackClient interface {
 	mock.TestingT
 	Cleanup(func())
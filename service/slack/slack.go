@@ -1,15 +1,49 @@
 package slack
 
 import (
+	"bytes"
 	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/slack-go/slack"
+	"github.com/slack-go/slack/slackevents"
+	"github.com/slack-go/slack/socketmode"
+
+	"github.com/nikoksr/notify"
 )
 
 //go:generate mockery --name=slackClient --output=. --case=underscore --inpackage
 type slackClient interface {
+	AuthTestContext(ctx context.Context) (*slack.AuthTestResponse, error)
 	PostMessageContext(ctx context.Context, channelID string, options ...slack.MsgOption) (string, string, error)
+	UpdateMessageContext(ctx context.Context, channelID, timestamp string, options ...slack.MsgOption) (string, string, string, error)
+	DeleteMessageContext(ctx context.Context, channelID, timestamp string) (string, string, error)
+	UploadFileContext(ctx context.Context, params slack.FileUploadParameters) (*slack.File, error)
+}
+
+// Compile-time check to ensure that Slack implements the optional notify interfaces.
+var (
+	_ notify.MessageSender    = Slack{}
+	_ notify.AttachmentSender = Slack{}
+	_ notify.Limiter          = Slack{}
+	_ notify.Updater          = Slack{}
+	_ notify.Deleter          = Slack{}
+	_ notify.Sanitizer        = Slack{}
+	_ notify.Receiver         = Slack{}
+	_ notify.ContactResolver  = Slack{}
+	_ notify.Validator        = Slack{}
+	_ notify.Prober           = Slack{}
+)
+
+// uploadOptions configures the upload of message attachments. Slack accepts files of up to 1 GB.
+var uploadOptions = notify.UploadOptions{
+	MaxSize:    1 << 30,
+	Retries:    2,
+	RetryDelay: time.Second,
 }
 
 // Compile-time check to ensure that slack.Client implements the slackClient interface.
@@ -19,6 +53,8 @@ var _ slackClient = new(slack.Client)
 type Slack struct {
 	client     slackClient
 	channelIDs []string
+	threads    *notify.Threads
+	appToken   string
 }
 
 // New returns a new instance of a Slack notification service.
@@ -31,6 +67,7 @@ func New(apiToken string) *Slack {
 	s := &Slack{
 		client:     client,
 		channelIDs: []string{},
+		threads:    notify.NewThreads(),
 	}
 
 	return s
@@ -42,27 +79,349 @@ func (s *Slack) AddReceivers(channelIDs ...string) {
 	s.channelIDs = append(s.channelIDs, channelIDs...)
 }
 
+// SetAppToken sets the app-level token used to receive messages over Socket Mode, see Receive. The token starts with
+// xapp- and needs the connections:write scope.
+// For more information about Socket Mode:
+//
+//	-> https://api.slack.com/apis/connections/socket
+func (s *Slack) SetAppToken(token string) {
+	s.appToken = token
+}
+
+// Validate checks that no channel ID is empty and that the app token, if set, is an app-level token, see
+// notify.Validator.
+func (s Slack) Validate() error {
+	var problems notify.ConfigErrors
+	for _, channelID := range s.channelIDs {
+		if channelID == "" {
+			problems.Addf("channel ID is empty")
+		}
+	}
+	if s.appToken != "" && !strings.HasPrefix(s.appToken, "xapp-") {
+		problems.Addf("app token must start with xapp-")
+	}
+
+	return problems.Err()
+}
+
+// Probe checks that Slack accepts the API token by calling auth.test, see notify.Prober.
+func (s Slack) Probe(ctx context.Context) error {
+	if _, err := s.client.AuthTestContext(ctx); err != nil {
+		return classifyError(errors.Wrap(err, "auth test"))
+	}
+
+	return nil
+}
+
+// ResolveContact returns the chat ID of the contact for "slack", e.g. a user ID to send direct messages, see
+// notify.SendToContacts.
+func (s Slack) ResolveContact(contact notify.Contact) (string, bool) {
+	return contact.ChatID("slack")
+}
+
 // Send takes a message subject and a message body and sends them to all previously set channels.
 // you will need a slack app with the chat:write.public and chat:write permissions.
 // see https://api.slack.com/
+// The channel ID and timestamp of each posted message are recorded as receipt, see notify.ContextWithReceipts.
+// Messages sent with the same thread key, see notify.ContextWithThreadKey, are posted as replies to the first one.
 func (s Slack) Send(ctx context.Context, subject, message string) error {
-	fullMessage := subject + "\n" + message // Treating subject as message title
+	return s.SendMessage(ctx, notify.Message{Subject: subject, Body: message})
+}
 
-	for _, channelID := range s.channelIDs {
+// classifyError classifies errors returned by the Slack API, see notify.ClassifyError.
+func classifyError(err error) error {
+	var rateLimited *slack.RateLimitedError
+	if errors.As(err, &rateLimited) {
+		return notify.ClassifyError(err, &notify.ErrRateLimited{RetryAfter: rateLimited.RetryAfter})
+	}
+
+	var slackErr slack.SlackErrorResponse
+	if !errors.As(err, &slackErr) {
+		return err
+	}
+
+	switch slackErr.Err {
+	case "invalid_auth", "not_authed", "account_inactive", "token_revoked", "token_expired", "missing_scope":
+		return notify.ClassifyError(err, notify.ErrAuthFailed)
+	case "channel_not_found", "is_archived", "not_in_channel", "user_not_found":
+		return notify.ClassifyError(err, notify.ErrInvalidReceiver)
+	case "internal_error", "fatal_error", "service_unavailable", "request_timeout":
+		return notify.ClassifyError(err, notify.ErrTransient)
+	default:
+		return err
+	}
+}
+
+// cardAttachment renders a card as an attachment of blocks, colored by the accent color of the card.
+func cardAttachment(card *notify.Card) slack.Attachment {
+	var blocks []slack.Block
+	if card.Title != "" || len(card.Fields) > 0 {
+		var title *slack.TextBlockObject
+		if card.Title != "" {
+			title = slack.NewTextBlockObject(slack.MarkdownType, "*"+card.Title+"*", false, false)
+		}
+		var fields []*slack.TextBlockObject
+		for _, field := range card.Fields {
+			fields = append(fields,
+				slack.NewTextBlockObject(slack.MarkdownType, "*"+field.Name+"*\n"+field.Value, false, false))
+		}
+		blocks = append(blocks, slack.NewSectionBlock(title, fields, nil))
+	}
+	if card.ImageURL != "" {
+		blocks = append(blocks, slack.NewImageBlock(card.ImageURL, card.Title, "", nil))
+	}
+	if card.Footer != "" {
+		blocks = append(blocks,
+			slack.NewContextBlock("", slack.NewTextBlockObject(slack.MarkdownType, card.Footer, false, false)))
+	}
+
+	return slack.Attachment{
+		Color:    card.Color,
+		Fallback: card.PlainText(),
+		Blocks:   slack.Blocks{BlockSet: blocks},
+	}
+}
+
+// messageOptions returns the options that render the given message. Actions are rendered as link buttons below the
+// text and the card as colored attachment.
+func messageOptions(message notify.Message) []slack.MsgOption {
+	fullMessage := message.Subject + "\n" + message.Body // Treating subject as message title
+
+	options := []slack.MsgOption{slack.MsgOptionText(fullMessage, false)}
+	if message.Card != nil {
+		options = append(options, slack.MsgOptionAttachments(cardAttachment(message.Card)))
+	}
+	if len(message.Actions) == 0 {
+		return options
+	}
+
+	buttons := make([]slack.BlockElement, 0, len(message.Actions))
+	for i, action := range message.Actions {
+		button := slack.NewButtonBlockElement(
+			fmt.Sprintf("action_%d", i),
+			action.URL,
+			slack.NewTextBlockObject(slack.PlainTextType, action.Label, false, false),
+		)
+		button.URL = action.URL
+		buttons = append(buttons, button)
+	}
+
+	return append(options, slack.MsgOptionBlocks(
+		slack.NewSectionBlock(slack.NewTextBlockObject(slack.MarkdownType, fullMessage, false, false), nil, nil),
+		slack.NewActionBlock("", buttons...),
+	))
+}
+
+// uploadAttachments shares the attachments in the given channel, in the given thread if threadTS is not empty.
+func (s Slack) uploadAttachments(ctx context.Context, channelID, threadTS string, attachments []notify.Attachment) error {
+	_, err := notify.UploadAttachments(ctx, attachments, uploadOptions,
+		func(ctx context.Context, attachment notify.Attachment) (string, error) {
+			file, err := s.client.UploadFileContext(ctx, slack.FileUploadParameters{
+				Reader:          bytes.NewReader(attachment.Data),
+				Filename:        attachment.Name,
+				Title:           attachment.Name,
+				Channels:        []string{channelID},
+				ThreadTimestamp: threadTS,
+			})
+			if err != nil {
+				return "", classifyError(err)
+			}
+
+			return file.ID, nil
+		})
+
+	return err
+}
+
+// SupportsAttachments reports that Slack delivers the attachments of messages, see notify.AttachmentSender.
+func (s Slack) SupportsAttachments() bool {
+	return true
+}
+
+// SendMessage works like Send, but renders the actions of the message as link buttons and uploads its attachments to
+// the channels after the message.
+func (s Slack) SendMessage(ctx context.Context, message notify.Message) error {
+	threadKey, threaded := notify.ThreadKeyFromContext(ctx)
+	threaded = threaded && s.threads != nil
+
+	for _, channelID := range notify.ReceiversFromContext(ctx, s.channelIDs) {
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
 		default:
-			id, timestamp, err := s.client.PostMessageContext(
-				ctx,
-				channelID,
-				slack.MsgOptionText(fullMessage, false),
+			options := messageOptions(message)
+			var (
+				threadTS string
+				starting bool
 			)
+			if threaded {
+				root, ok, err := s.threads.Begin(ctx, channelID, threadKey)
+				if err != nil {
+					return err
+				}
+				if ok {
+					threadTS = root
+					options = append(options, slack.MsgOptionTS(threadTS))
+				}
+				starting = !ok
+			}
+
+			id, timestamp, err := s.client.PostMessageContext(ctx, channelID, options...)
 			if err != nil {
-				return errors.Wrapf(err, "failed to send message to Slack channel '%s' at time '%s'", id, timestamp)
+				if starting {
+					s.threads.Abort(channelID, threadKey)
+				}
+				return errors.Wrapf(classifyError(err), "failed to send message to Slack channel '%s' at time '%s'", id, timestamp)
+			}
+
+			if starting {
+				s.threads.SetRoot(channelID, threadKey, timestamp)
+			}
+
+			notify.RecordReceipt(ctx, notify.Receipt{Service: s, Receiver: id, MessageID: timestamp})
+
+			if err = s.uploadAttachments(ctx, channelID, threadTS, message.Attachments); err != nil {
+				return errors.Wrapf(err, "failed to upload attachments to Slack channel '%s'", channelID)
 			}
 		}
 	}
 
 	return nil
 }
+
+// Update takes a receipt recorded by Send and replaces the text of the posted message with the new subject and message.
+func (s Slack) Update(ctx context.Context, receipt notify.Receipt, subject, message string) error {
+	fullMessage := subject + "\n" + message // Treating subject as message title
+
+	_, _, _, err := s.client.UpdateMessageContext(
+		ctx,
+		receipt.Receiver,
+		receipt.MessageID,
+		slack.MsgOptionText(fullMessage, false),
+		slack.MsgOptionBlocks([]slack.Block{}...), // Slack keeps the blocks of messages sent with actions otherwise.
+	)
+	if err != nil {
+		return errors.Wrapf(classifyError(err), "failed to update message in Slack channel '%s' at time '%s'", receipt.Receiver, receipt.MessageID)
+	}
+
+	return nil
+}
+
+// Delete takes a receipt recorded by Send and deletes the posted message from its channel.
+func (s Slack) Delete(ctx context.Context, receipt notify.Receipt) error {
+	_, _, err := s.client.DeleteMessageContext(ctx, receipt.Receiver, receipt.MessageID)
+	if err != nil {
+		return errors.Wrapf(classifyError(err), "failed to delete message in Slack channel '%s' at time '%s'", receipt.Receiver, receipt.MessageID)
+	}
+
+	return nil
+}
+
+// parseTimestamp parses a Slack message timestamp, seconds since the epoch with microseconds as fraction.
+func parseTimestamp(ts string) time.Time {
+	seconds, fraction, _ := strings.Cut(ts, ".")
+	sec, err := strconv.ParseInt(seconds, 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+	usec, _ := strconv.ParseInt(fraction, 10, 64)
+
+	return time.Unix(sec, usec*int64(time.Microsecond))
+}
+
+// reply converts a Socket Mode event into a notify.Reply. It reports false for events other than messages posted by
+// users to the channels added with AddReceivers.
+func (s Slack) reply(event socketmode.Event) (notify.Reply, bool) {
+	apiEvent, ok := event.Data.(slackevents.EventsAPIEvent)
+	if !ok {
+		return notify.Reply{}, false
+	}
+	message, ok := apiEvent.InnerEvent.Data.(*slackevents.MessageEvent)
+	if !ok || message.BotID != "" || message.SubType != "" {
+		return notify.Reply{}, false
+	}
+
+	known := false
+	for _, channelID := range s.channelIDs {
+		known = known || channelID == message.Channel
+	}
+	if !known {
+		return notify.Reply{}, false
+	}
+
+	reply := notify.Reply{
+		Service:  s,
+		Sender:   message.User,
+		Receiver: message.Channel,
+		Text:     message.Text,
+		Time:     parseTimestamp(message.TimeStamp),
+	}
+	if message.ThreadTimeStamp != "" && message.ThreadTimeStamp != message.TimeStamp {
+		reply.InReplyTo = &notify.Receipt{Service: s, Receiver: message.Channel, MessageID: message.ThreadTimeStamp}
+	}
+
+	return reply, true
+}
+
+// Receive connects to Slack over Socket Mode and passes the messages users post to the channels added with
+// AddReceivers to the handler, until the context is done. Thread replies to notifications carry the receipt of the
+// notification. It requires an app-level token, see SetAppToken, and a subscription to the message.channels event;
+// without token it returns notify.ErrUnsupported.
+func (s Slack) Receive(ctx context.Context, handler notify.ReplyHandler) error {
+	if s.appToken == "" {
+		return errors.Wrap(notify.ErrUnsupported, "receiving from Slack requires an app-level token")
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	client := socketmode.New(slack.New("", slack.OptionAppLevelToken(s.appToken)))
+	errs := make(chan error, 1)
+	go func() {
+		errs <- client.RunContext(ctx)
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-errs:
+			if ctx.Err() != nil {
+				return nil
+			}
+			return errors.Wrap(err, "failed to receive from Slack")
+		case event := <-client.Events:
+			if event.Type != socketmode.EventTypeEventsAPI {
+				continue
+			}
+			if event.Request != nil {
+				client.Ack(*event.Request)
+			}
+
+			reply, ok := s.reply(event)
+			if !ok {
+				continue
+			}
+			if err := handler(ctx, reply); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// mrkdwnEscaper escapes the characters Slack uses for links and mentions, see
+// https://api.slack.com/reference/surfaces/formatting#escaping.
+var mrkdwnEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// Sanitize escapes the control characters of Slack's mrkdwn, so that user provided content can't inject links or
+// mention users, channels or @here.
+func (s Slack) Sanitize(message notify.Message) notify.Message {
+	return notify.SanitizeMessage(message, func(text string) string {
+		return mrkdwnEscaper.Replace(notify.StripControlChars(text))
+	})
+}
+
+// Limits returns the maximum length of Slack messages, which contain subject and body.
+func (s Slack) Limits() notify.Limits {
+	return notify.Limits{Total: 40000}
+}
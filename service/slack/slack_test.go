@@ -2,12 +2,19 @@ package slack
 
 import (
 	"context"
+	"net/url"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
+	"github.com/slack-go/slack"
+	"github.com/slack-go/slack/slackevents"
+	"github.com/slack-go/slack/socketmode"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+
+	"github.com/nikoksr/notify"
 )
 
 func TestSlack_New(t *testing.T) {
@@ -82,3 +89,364 @@ func TestSlack_Send(t *testing.T) {
 	assert.Nil(err)
 	mockClient.AssertExpectations(t)
 }
+
+func TestSlack_Update(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := New("")
+	assert.NotNil(service)
+	service.AddReceivers("1234")
+
+	ctx, receipts := notify.ContextWithReceipts(context.Background())
+
+	mockClient := newMockSlackClient(t)
+	mockClient.
+		On("PostMessageContext", ctx, "1234", mock.AnythingOfType("MsgOption")).
+		Return("C1234", "1700000000.000100", nil)
+	mockClient.
+		On("UpdateMessageContext", ctx, "C1234", "1700000000.000100", mock.AnythingOfType("MsgOption"), mock.AnythingOfType("MsgOption")).
+		Return("C1234", "1700000000.000100", "subject\nresolved", nil).
+		Once()
+	mockClient.
+		On("UpdateMessageContext", ctx, "C1234", "1700000000.000100", mock.AnythingOfType("MsgOption"), mock.AnythingOfType("MsgOption")).
+		Return("", "", "", errors.New("message_not_found"))
+	service.client = mockClient
+
+	err := service.Send(ctx, "subject", "message")
+	assert.Nil(err)
+	assert.Len(receipts.All(), 1)
+
+	receipt := receipts.All()[0]
+	assert.Equal("C1234", receipt.Receiver)
+	assert.Equal("1700000000.000100", receipt.MessageID)
+
+	err = notify.Update(ctx, receipt, "subject", "resolved")
+	assert.Nil(err)
+
+	// Test error response
+	err = service.Update(ctx, receipt, "subject", "resolved")
+	assert.NotNil(err)
+}
+
+func TestSlack_Delete(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := New("")
+	assert.NotNil(service)
+
+	ctx := context.Background()
+	receipt := notify.Receipt{Service: service, Receiver: "C1234", MessageID: "1700000000.000100"}
+
+	mockClient := newMockSlackClient(t)
+	mockClient.
+		On("DeleteMessageContext", ctx, "C1234", "1700000000.000100").
+		Return("C1234", "1700000000.000100", nil).
+		Once()
+	mockClient.
+		On("DeleteMessageContext", ctx, "C1234", "1700000000.000100").
+		Return("", "", errors.New("message_not_found"))
+	service.client = mockClient
+
+	err := service.Delete(ctx, receipt)
+	assert.Nil(err)
+
+	// Test error response
+	err = service.Delete(ctx, receipt)
+	assert.NotNil(err)
+}
+
+func TestSlack_SendThreaded(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := New("")
+	assert.NotNil(service)
+	service.AddReceivers("1234")
+
+	var threadTimestamps []string
+	captureThread := func(args mock.Arguments) {
+		var options []slack.MsgOption
+		for _, arg := range args[2:] {
+			options = append(options, arg.(slack.MsgOption))
+		}
+		_, values, err := slack.UnsafeApplyMsgOptions("", "1234", "", options...)
+		assert.Nil(err)
+		threadTimestamps = append(threadTimestamps, values.Get("thread_ts"))
+	}
+
+	ctx := notify.ContextWithThreadKey(context.Background(), "incident-42")
+
+	mockClient := newMockSlackClient(t)
+	mockClient.
+		On("PostMessageContext", ctx, "1234", mock.AnythingOfType("MsgOption")).
+		Run(captureThread).
+		Return("C1234", "1700000000.000100", nil).
+		Once()
+	mockClient.
+		On("PostMessageContext", ctx, "1234", mock.AnythingOfType("MsgOption"), mock.AnythingOfType("MsgOption")).
+		Run(captureThread).
+		Return("C1234", "1700000000.000200", nil).
+		Twice()
+	service.client = mockClient
+
+	for i := 0; i < 3; i++ {
+		err := service.Send(ctx, "subject", "message")
+		assert.Nil(err)
+	}
+
+	assert.Equal([]string{"", "1700000000.000100", "1700000000.000100"}, threadTimestamps)
+}
+
+func TestSlack_SendReceiversOverride(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := New("")
+	assert.NotNil(service)
+	service.AddReceivers("1234")
+
+	ctx := notify.ContextWithSendOptions(context.Background(), notify.WithReceiversOverride("5678"))
+	mockClient := newMockSlackClient(t)
+	mockClient.
+		On("PostMessageContext", ctx, "5678", mock.AnythingOfType("MsgOption")).
+		Return("C5678", "1700000000.000100", nil)
+	service.client = mockClient
+
+	err := service.Send(ctx, "subject", "message")
+	assert.Nil(err)
+}
+
+func TestSlack_classifyError(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	err := classifyError(slack.SlackErrorResponse{Err: "channel_not_found"})
+	assert.ErrorIs(err, notify.ErrInvalidReceiver)
+	assert.ErrorIs(err, notify.ErrPermanent)
+
+	err = classifyError(slack.SlackErrorResponse{Err: "invalid_auth"})
+	assert.ErrorIs(err, notify.ErrAuthFailed)
+
+	err = classifyError(&slack.RateLimitedError{RetryAfter: 30 * time.Second})
+	assert.ErrorIs(err, notify.ErrTransient)
+	retryAfter, ok := notify.RetryAfter(err)
+	assert.True(ok)
+	assert.Equal(30*time.Second, retryAfter)
+
+	err = errors.New("some error")
+	assert.Equal(err, classifyError(err))
+}
+
+func TestSlack_SendMessage(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := New("")
+	assert.NotNil(service)
+	service.AddReceivers("1234")
+
+	var values url.Values
+	ctx := context.Background()
+	mockClient := newMockSlackClient(t)
+	mockClient.
+		On("PostMessageContext", ctx, "1234", mock.AnythingOfType("MsgOption"), mock.AnythingOfType("MsgOption")).
+		Run(func(args mock.Arguments) {
+			var err error
+			_, values, err = slack.UnsafeApplyMsgOptions("", "1234", "",
+				args.Get(2).(slack.MsgOption), args.Get(3).(slack.MsgOption))
+			assert.Nil(err)
+		}).
+		Return("C1234", "1700000000.000100", nil)
+	service.client = mockClient
+
+	err := service.SendMessage(ctx, notify.Message{
+		Subject: "subject",
+		Body:    "message",
+		Actions: []notify.Action{{Label: "Acknowledge", URL: "https://example.com/ack"}},
+	})
+	assert.Nil(err)
+
+	assert.Equal("subject\nmessage", values.Get("text"))
+	assert.JSONEq(`[
+		{"type": "section", "text": {"type": "mrkdwn", "text": "subject\nmessage"}},
+		{"type": "actions", "elements": [{
+			"type": "button",
+			"action_id": "action_0",
+			"value": "https://example.com/ack",
+			"url": "https://example.com/ack",
+			"text": {"type": "plain_text", "text": "Acknowledge"}
+		}]}
+	]`, values.Get("blocks"))
+}
+
+func TestSlack_SendMessageCard(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := New("")
+	assert.NotNil(service)
+	service.AddReceivers("1234")
+
+	var values url.Values
+	ctx := context.Background()
+	mockClient := newMockSlackClient(t)
+	mockClient.
+		On("PostMessageContext", ctx, "1234", mock.AnythingOfType("MsgOption"), mock.AnythingOfType("MsgOption")).
+		Run(func(args mock.Arguments) {
+			var err error
+			_, values, err = slack.UnsafeApplyMsgOptions("", "1234", "",
+				args.Get(2).(slack.MsgOption), args.Get(3).(slack.MsgOption))
+			assert.Nil(err)
+		}).
+		Return("C1234", "1700000000.000100", nil)
+	service.client = mockClient
+
+	err := service.SendMessage(ctx, notify.Message{
+		Subject: "subject",
+		Body:    "message",
+		Card: &notify.Card{
+			Title:  "Disk usage high",
+			Fields: []notify.CardField{{Name: "Host", Value: "db-1", Inline: true}},
+			Color:  "#ff0000",
+			Footer: "monitoring",
+		},
+	})
+	assert.Nil(err)
+
+	assert.JSONEq(`[{
+		"color": "#ff0000",
+		"fallback": "Disk usage high\nHost: db-1\nmonitoring",
+		"blocks": [
+			{
+				"type": "section",
+				"text": {"type": "mrkdwn", "text": "*Disk usage high*"},
+				"fields": [{"type": "mrkdwn", "text": "*Host*\ndb-1"}]
+			},
+			{"type": "context", "elements": [{"type": "mrkdwn", "text": "monitoring"}]}
+		]
+	}]`, values.Get("attachments"))
+}
+
+func TestSlack_SendMessageAttachments(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := New("")
+	assert.NotNil(service)
+	service.AddReceivers("1234")
+
+	ctx := context.Background()
+	mockClient := newMockSlackClient(t)
+	mockClient.
+		On("PostMessageContext", ctx, "1234", mock.AnythingOfType("MsgOption")).
+		Return("C1234", "1700000000.000100", nil)
+	mockClient.
+		On("UploadFileContext", ctx, mock.MatchedBy(func(params slack.FileUploadParameters) bool {
+			return params.Filename == "report.csv" && params.Channels[0] == "1234" && params.ThreadTimestamp == ""
+		})).
+		Return(&slack.File{ID: "F1234"}, nil)
+	service.client = mockClient
+
+	err := service.SendMessage(ctx, notify.Message{
+		Subject:     "subject",
+		Body:        "message",
+		Attachments: []notify.Attachment{{Name: "report.csv", Data: []byte("a,b\n1,2\n")}},
+	})
+	assert.Nil(err)
+}
+
+func TestSlack_Sanitize(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	sanitized := New("").Sanitize(notify.Message{
+		Subject: "Deploy <!here>",
+		Body:    "see <https://evil.example|docs> & more",
+		Card:    &notify.Card{Title: "<@U123>"},
+	})
+	assert.Equal("Deploy &lt;!here&gt;", sanitized.Subject)
+	assert.Equal("see &lt;https://evil.example|docs&gt; &amp; more", sanitized.Body)
+	assert.Equal("&lt;@U123&gt;", sanitized.Card.Title)
+}
+
+func TestSlack_reply(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := New("")
+	service.AddReceivers("C123")
+
+	event := func(message *slackevents.MessageEvent) socketmode.Event {
+		return socketmode.Event{
+			Type: socketmode.EventTypeEventsAPI,
+			Data: slackevents.EventsAPIEvent{InnerEvent: slackevents.EventsAPIInnerEvent{Data: message}},
+		}
+	}
+
+	reply, ok := service.reply(event(&slackevents.MessageEvent{
+		User:            "U42",
+		Channel:         "C123",
+		Text:            "ACK",
+		TimeStamp:       "1700000000.000200",
+		ThreadTimeStamp: "1699999999.000100",
+	}))
+	assert.True(ok)
+	assert.Equal("U42", reply.Sender)
+	assert.Equal("C123", reply.Receiver)
+	assert.Equal("ACK", reply.Text)
+	assert.Equal(time.Unix(1700000000, 200000), reply.Time)
+	assert.Equal(&notify.Receipt{Service: *service, Receiver: "C123", MessageID: "1699999999.000100"}, reply.InReplyTo)
+
+	// Messages of bots, messages to other channels and other events are ignored.
+	_, ok = service.reply(event(&slackevents.MessageEvent{Channel: "C123", BotID: "B1", Text: "posted by bot"}))
+	assert.False(ok)
+	_, ok = service.reply(event(&slackevents.MessageEvent{Channel: "C999", Text: "elsewhere"}))
+	assert.False(ok)
+	_, ok = service.reply(socketmode.Event{Type: socketmode.EventTypeHello})
+	assert.False(ok)
+}
+
+func TestSlack_Validate(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := New("")
+	service.AddReceivers("C123")
+	assert.NoError(service.Validate())
+
+	service.AddReceivers("")
+	service.SetAppToken("xoxb-bot-token")
+	err := service.Validate()
+	assert.ErrorIs(err, notify.ErrInvalidConfig)
+	assert.ErrorContains(err, "channel ID is empty")
+	assert.ErrorContains(err, "app token must start with xapp-")
+}
+
+func TestSlack_Probe(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+	ctx := context.Background()
+
+	mockClient := newMockSlackClient(t)
+	mockClient.On("AuthTestContext", ctx).Return(&slack.AuthTestResponse{}, nil).Once()
+	mockClient.On("AuthTestContext", ctx).Return(nil, slack.SlackErrorResponse{Err: "invalid_auth"}).Once()
+
+	service := New("")
+	service.client = mockClient
+	assert.NoError(service.Probe(ctx))
+	assert.ErrorIs(service.Probe(ctx), notify.ErrAuthFailed)
+}
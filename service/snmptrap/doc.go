@@ -0,0 +1,38 @@
+/*
+Package snmptrap provides a service for sending notifications as SNMPv2c or SNMPv3 traps to network management systems.
+
+Subject and message are sent as string variable bindings; trap and variable OIDs are configurable.
+
+Usage:
+
+	package main
+
+	import (
+	    "context"
+	    "log"
+
+	    "github.com/nikoksr/notify"
+	    "github.com/nikoksr/notify/service/snmptrap"
+	)
+
+	func main() {
+	    trapService := snmptrap.NewV2c("public")
+
+	    // Use the OIDs of your enterprise MIB.
+	    trapService.SetTrapOID(".1.3.6.1.4.1.99999.0.1")
+	    trapService.SetSubjectOID(".1.3.6.1.4.1.99999.1.1")
+	    trapService.SetMessageOID(".1.3.6.1.4.1.99999.1.2")
+
+	    // Add SNMP managers.
+	    trapService.AddReceivers("nms.example.com:162")
+
+	    // Tell our notifier to use the snmp trap service.
+	    notify.UseServices(trapService)
+
+	    // Send a test message.
+	    if err := notify.Send(context.Background(), "Subject/Title", "The actual message - Hello, you awesome gophers! :)"); err != nil {
+	        log.Fatal(err)
+	    }
+	}
+*/
+package snmptrap
@@ -0,0 +1,177 @@
+package snmptrap
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/gosnmp/gosnmp"
+	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
+)
+
+const (
+	// DefaultPort is the standard port SNMP managers listen on for traps.
+	DefaultPort = 162
+
+	// DefaultTrapOID is the trap OID used unless set otherwise. It lies in the NET-SNMP playpen arc; configure OIDs of
+	// your own enterprise MIB for production use.
+	DefaultTrapOID = ".1.3.6.1.4.1.8072.9999.9999.1"
+	// DefaultSubjectOID is the OID of the variable binding carrying the subject unless set otherwise.
+	DefaultSubjectOID = DefaultTrapOID + ".1"
+	// DefaultMessageOID is the OID of the variable binding carrying the message unless set otherwise.
+	DefaultMessageOID = DefaultTrapOID + ".2"
+
+	snmpTrapOID = ".1.3.6.1.6.3.1.1.4.1.0"
+)
+
+// Service encapsulates the SNMP trap sender.
+type Service struct {
+	version            gosnmp.SnmpVersion
+	community          string
+	msgFlags           gosnmp.SnmpV3MsgFlags
+	securityParameters *gosnmp.UsmSecurityParameters
+
+	targets    []string
+	timeout    time.Duration
+	trapOID    string
+	subjectOID string
+	messageOID string
+	variables  []gosnmp.SnmpPDU
+}
+
+func newService(version gosnmp.SnmpVersion) *Service {
+	return &Service{
+		version:    version,
+		targets:    []string{},
+		timeout:    2 * time.Second,
+		trapOID:    DefaultTrapOID,
+		subjectOID: DefaultSubjectOID,
+		messageOID: DefaultMessageOID,
+		variables:  []gosnmp.SnmpPDU{},
+	}
+}
+
+// NewV2c returns a new instance of an SNMP trap notification service sending SNMPv2c traps with the given community.
+func NewV2c(community string) *Service {
+	s := newService(gosnmp.Version2c)
+	s.community = community
+
+	return s
+}
+
+// NewV3 returns a new instance of an SNMP trap notification service sending SNMPv3 traps using the user-based security
+// model. The security parameters must contain the user name, the protocols and passphrases required by the message
+// flags, and the engine ID of the sender as AuthoritativeEngineID.
+func NewV3(msgFlags gosnmp.SnmpV3MsgFlags, securityParameters *gosnmp.UsmSecurityParameters) *Service {
+	s := newService(gosnmp.Version3)
+	s.msgFlags = msgFlags
+	s.securityParameters = securityParameters
+
+	return s
+}
+
+// AddReceivers takes addresses of SNMP managers, either "host" or "host:port", and adds them to the internal target
+// list. Targets without port use DefaultPort. The Send method will send a trap to every one of those targets.
+func (s *Service) AddReceivers(targets ...string) {
+	s.targets = append(s.targets, targets...)
+}
+
+// SetTrapOID sets the snmpTrapOID of all traps, identifying the kind of notification. Defaults to DefaultTrapOID.
+func (s *Service) SetTrapOID(oid string) {
+	s.trapOID = oid
+}
+
+// SetSubjectOID sets the OID of the variable binding carrying the subject. Defaults to DefaultSubjectOID.
+func (s *Service) SetSubjectOID(oid string) {
+	s.subjectOID = oid
+}
+
+// SetMessageOID sets the OID of the variable binding carrying the message. Defaults to DefaultMessageOID.
+func (s *Service) SetMessageOID(oid string) {
+	s.messageOID = oid
+}
+
+// AddVariable adds a variable binding with the given OID and string value to all traps.
+func (s *Service) AddVariable(oid, value string) {
+	s.variables = append(s.variables, gosnmp.SnmpPDU{Name: oid, Type: gosnmp.OctetString, Value: value})
+}
+
+// SetTimeout sets the timeout of the connection to a target. Defaults to 2 seconds.
+func (s *Service) SetTimeout(timeout time.Duration) {
+	s.timeout = timeout
+}
+
+// newClient returns an SNMP client for the given target.
+func (s *Service) newClient(ctx context.Context, target string) (*gosnmp.GoSNMP, error) {
+	host, port := target, DefaultPort
+	if h, p, err := net.SplitHostPort(target); err == nil {
+		if port, err = strconv.Atoi(p); err != nil {
+			return nil, errors.Wrapf(err, "invalid port in '%s'", target)
+		}
+		host = h
+	}
+
+	client := &gosnmp.GoSNMP{
+		Context:   ctx,
+		Target:    host,
+		Port:      uint16(port),
+		Transport: "udp",
+		Version:   s.version,
+		Community: s.community,
+		Timeout:   s.timeout,
+		MaxOids:   gosnmp.MaxOids,
+	}
+	if s.version == gosnmp.Version3 {
+		client.MsgFlags = s.msgFlags
+		client.SecurityModel = gosnmp.UserSecurityModel
+		client.SecurityParameters = s.securityParameters.Copy()
+	}
+
+	return client, nil
+}
+
+// Send takes a message subject and a message body and sends them as variable bindings of a trap to all previously set
+// targets.
+func (s *Service) Send(ctx context.Context, subject, message string) error {
+	variables := make([]gosnmp.SnmpPDU, 0, len(s.variables)+3)
+	variables = append(variables,
+		gosnmp.SnmpPDU{Name: snmpTrapOID, Type: gosnmp.ObjectIdentifier, Value: s.trapOID},
+		gosnmp.SnmpPDU{Name: s.subjectOID, Type: gosnmp.OctetString, Value: subject},
+		gosnmp.SnmpPDU{Name: s.messageOID, Type: gosnmp.OctetString, Value: message},
+	)
+	variables = append(variables, s.variables...)
+
+	for _, target := range s.targets {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			if err := s.send(ctx, target, gosnmp.SnmpTrap{Variables: variables}); err != nil {
+				return notify.ClassifyNetworkError(errors.Wrapf(err, "failed to send SNMP trap to '%s'", target))
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) send(ctx context.Context, target string, trap gosnmp.SnmpTrap) error {
+	client, err := s.newClient(ctx, target)
+	if err != nil {
+		return err
+	}
+
+	if err = client.Connect(); err != nil {
+		return errors.Wrap(err, "connect")
+	}
+	defer func() { _ = client.Conn.Close() }()
+
+	if _, err = client.SendTrap(trap); err != nil {
+		return errors.Wrap(err, "send trap")
+	}
+
+	return nil
+}
@@ -0,0 +1,124 @@
+package snmptrap
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/gosnmp/gosnmp"
+	"github.com/stretchr/testify/require"
+)
+
+// receiveTrap listens for a single trap and decodes it with the given decoder.
+func receiveTrap(t *testing.T, decoder *gosnmp.GoSNMP) (string, <-chan *gosnmp.SnmpPacket) {
+	t.Helper()
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	packets := make(chan *gosnmp.SnmpPacket, 1)
+	go func() {
+		buf := make([]byte, 4096)
+		_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+		n, _, err := conn.ReadFrom(buf)
+		if err != nil {
+			close(packets)
+			return
+		}
+		packet, err := decoder.UnmarshalTrap(buf[:n], false)
+		if err != nil {
+			close(packets)
+			return
+		}
+		packets <- packet
+	}()
+
+	return conn.LocalAddr().String(), packets
+}
+
+func variables(packet *gosnmp.SnmpPacket) map[string]any {
+	vars := map[string]any{}
+	for _, v := range packet.Variables {
+		if b, ok := v.Value.([]byte); ok {
+			vars[v.Name] = string(b)
+			continue
+		}
+		vars[v.Name] = v.Value
+	}
+
+	return vars
+}
+
+func TestSNMPTrap_SendV2c(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	target, packets := receiveTrap(t, &gosnmp.GoSNMP{Version: gosnmp.Version2c})
+
+	service := NewV2c("public")
+	service.AddVariable(".1.3.6.1.4.1.8072.9999.9999.1.3", "critical")
+	service.AddReceivers(target)
+
+	assert.NoError(service.Send(context.Background(), "subject", "message"))
+
+	packet := <-packets
+	assert.NotNil(packet)
+	assert.Equal("public", packet.Community)
+	vars := variables(packet)
+	assert.Equal(DefaultTrapOID, vars[snmpTrapOID])
+	assert.Equal("subject", vars[DefaultSubjectOID])
+	assert.Equal("message", vars[DefaultMessageOID])
+	assert.Equal("critical", vars[".1.3.6.1.4.1.8072.9999.9999.1.3"])
+}
+
+func TestSNMPTrap_SendV3(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	newParams := func() *gosnmp.UsmSecurityParameters {
+		return &gosnmp.UsmSecurityParameters{
+			UserName:                 "notify",
+			AuthoritativeEngineID:    "\x80\x00\x1f\x88\x04notify",
+			AuthenticationProtocol:   gosnmp.SHA,
+			AuthenticationPassphrase: "authpassword",
+			PrivacyProtocol:          gosnmp.AES,
+			PrivacyPassphrase:        "privpassword",
+		}
+	}
+
+	target, packets := receiveTrap(t, &gosnmp.GoSNMP{
+		Version:            gosnmp.Version3,
+		SecurityModel:      gosnmp.UserSecurityModel,
+		MsgFlags:           gosnmp.AuthPriv,
+		SecurityParameters: newParams(),
+	})
+
+	service := NewV3(gosnmp.AuthPriv, newParams())
+	service.SetTrapOID(".1.3.6.1.4.1.99999.1")
+	service.SetSubjectOID(".1.3.6.1.4.1.99999.1.1")
+	service.SetMessageOID(".1.3.6.1.4.1.99999.1.2")
+	service.AddReceivers(target)
+
+	assert.NoError(service.Send(context.Background(), "subject", "message"))
+
+	packet := <-packets
+	assert.NotNil(packet)
+	vars := variables(packet)
+	assert.Equal(".1.3.6.1.4.1.99999.1", vars[snmpTrapOID])
+	assert.Equal("subject", vars[".1.3.6.1.4.1.99999.1.1"])
+	assert.Equal("message", vars[".1.3.6.1.4.1.99999.1.2"])
+}
+
+func TestSNMPTrap_SendErrors(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := NewV2c("public")
+	service.AddReceivers("127.0.0.1:invalid")
+	assert.Error(service.Send(context.Background(), "subject", "message"))
+}
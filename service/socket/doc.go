@@ -0,0 +1,37 @@
+/*
+Package socket provides a service for writing notifications as JSON payloads to TCP or UDP endpoints, e.g. to feed
+legacy log collectors.
+
+Payloads are either newline-delimited or prefixed with their length as 4 byte big-endian integer.
+
+Usage:
+
+	package main
+
+	import (
+	    "context"
+	    "log"
+
+	    "github.com/nikoksr/notify"
+	    "github.com/nikoksr/notify/service/socket"
+	)
+
+	func main() {
+	    socketService := socket.New("tcp")
+
+	    // Optionally use length-prefixed payloads.
+	    socketService.SetFraming(socket.LengthPrefix)
+
+	    // Add endpoints.
+	    socketService.AddReceivers("collector.example.com:5170")
+
+	    // Tell our notifier to use the socket service.
+	    notify.UseServices(socketService)
+
+	    // Send a test message.
+	    if err := notify.Send(context.Background(), "Subject/Title", "The actual message - Hello, you awesome gophers! :)"); err != nil {
+	        log.Fatal(err)
+	    }
+	}
+*/
+package socket
@@ -0,0 +1,134 @@
+package socket
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"net"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
+)
+
+// Framing defines how payloads are delimited on the wire.
+type Framing int
+
+const (
+	// Newline terminates every payload with a line feed.
+	Newline Framing = iota
+	// LengthPrefix precedes every payload with its length as 4 byte big-endian integer.
+	LengthPrefix
+)
+
+// Service encapsulates the TCP/UDP socket writer.
+type Service struct {
+	network   string
+	addresses []string
+	framing   Framing
+	timeout   time.Duration
+
+	dialer *net.Dialer
+	now    func() time.Time
+}
+
+// New returns a new instance of a socket notification service. The network is one of "tcp", "tcp4", "tcp6", "udp",
+// "udp4" or "udp6". Every notification is written as a JSON object on a new connection.
+func New(network string) *Service {
+	return &Service{
+		network:   network,
+		addresses: []string{},
+		framing:   Newline,
+		timeout:   10 * time.Second,
+		dialer:    &net.Dialer{},
+		now:       time.Now,
+	}
+}
+
+// AddReceivers takes addresses in "host:port" format and adds them to the internal address list. The Send method will
+// write the payload to every one of those addresses.
+func (s *Service) AddReceivers(addresses ...string) {
+	s.addresses = append(s.addresses, addresses...)
+}
+
+// SetFraming sets how payloads are delimited. Defaults to Newline.
+func (s *Service) SetFraming(framing Framing) {
+	s.framing = framing
+}
+
+// SetTimeout sets the timeout for connecting and writing to a single address. Defaults to 10 seconds.
+func (s *Service) SetTimeout(timeout time.Duration) {
+	s.timeout = timeout
+}
+
+// payload is the JSON object written for every notification.
+type payload struct {
+	Subject   string `json:"subject"`
+	Message   string `json:"message"`
+	Timestamp string `json:"timestamp"`
+}
+
+// frame encodes the notification and frames it according to the configured framing.
+func (s *Service) frame(subject, message string) ([]byte, error) {
+	data, err := json.Marshal(payload{
+		Subject:   subject,
+		Message:   message,
+		Timestamp: s.now().UTC().Format(time.RFC3339),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if s.framing == LengthPrefix {
+		framed := make([]byte, 4, 4+len(data))
+		binary.BigEndian.PutUint32(framed, uint32(len(data)))
+		return append(framed, data...), nil
+	}
+
+	return append(data, '\n'), nil
+}
+
+// Send takes a message subject and a message body and writes them as a single framed JSON payload to all previously
+// set addresses.
+func (s *Service) Send(ctx context.Context, subject, message string) error {
+	data, err := s.frame(subject, message)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode payload")
+	}
+
+	for _, address := range s.addresses {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			if err = s.write(ctx, address, data); err != nil {
+				return notify.ClassifyNetworkError(errors.Wrapf(err, "failed to write payload to '%s'", address))
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) write(ctx context.Context, address string, data []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	conn, err := s.dialer.DialContext(ctx, s.network, address)
+	if err != nil {
+		return errors.Wrap(err, "dial")
+	}
+	defer func() { _ = conn.Close() }()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetWriteDeadline(deadline)
+	}
+
+	// Write the payload in one go, so that datagram based networks send it as a single packet.
+	if _, err = conn.Write(data); err != nil {
+		return errors.Wrap(err, "write")
+	}
+
+	return nil
+}
@@ -0,0 +1,120 @@
+package socket
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const wantPayload = `{"subject":"subject","message":"line 1\nline 2","timestamp":"2023-01-02T03:04:05Z"}`
+
+func newTestService(network string) *Service {
+	s := New(network)
+	s.now = func() time.Time { return time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC) }
+
+	return s
+}
+
+// acceptOne accepts a single TCP connection and passes it to read.
+func acceptOne(t *testing.T, read func(conn net.Conn) string) (string, <-chan string) {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = listener.Close() })
+
+	got := make(chan string, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			close(got)
+			return
+		}
+		defer func() { _ = conn.Close() }()
+		got <- read(conn)
+	}()
+
+	return listener.Addr().String(), got
+}
+
+func TestSocket_SendTCPNewline(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	address, got := acceptOne(t, func(conn net.Conn) string {
+		line, _ := bufio.NewReader(conn).ReadString('\n')
+		return line
+	})
+
+	service := newTestService("tcp")
+	service.AddReceivers(address)
+
+	assert.NoError(service.Send(context.Background(), "subject", "line 1\nline 2"))
+	assert.Equal(wantPayload+"\n", <-got)
+}
+
+func TestSocket_SendTCPLengthPrefix(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	address, got := acceptOne(t, func(conn net.Conn) string {
+		var length uint32
+		_ = binary.Read(conn, binary.BigEndian, &length)
+		data := make([]byte, length)
+		_, _ = io.ReadFull(conn, data)
+		return string(data)
+	})
+
+	service := newTestService("tcp")
+	service.SetFraming(LengthPrefix)
+	service.AddReceivers(address)
+
+	assert.NoError(service.Send(context.Background(), "subject", "line 1\nline 2"))
+	assert.Equal(wantPayload, <-got)
+}
+
+func TestSocket_SendUDP(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	assert.NoError(err)
+	defer func() { _ = conn.Close() }()
+
+	service := newTestService("udp")
+	service.AddReceivers(conn.LocalAddr().String())
+
+	assert.NoError(service.Send(context.Background(), "subject", "line 1\nline 2"))
+
+	buf := make([]byte, 1024)
+	_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	n, _, err := conn.ReadFrom(buf)
+	assert.NoError(err)
+	assert.Equal(wantPayload+"\n", string(buf[:n]))
+}
+
+func TestSocket_SendErrors(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	// Reserve a port and close it again, so that connections are refused.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(err)
+	address := listener.Addr().String()
+	_ = listener.Close()
+
+	service := newTestService("tcp")
+	service.SetTimeout(time.Second)
+	service.AddReceivers(address)
+	assert.Error(service.Send(context.Background(), "subject", "message"))
+}
@@ -0,0 +1,31 @@
+/*
+Package splunkhec provides a service for sending notifications as events to a Splunk HTTP Event Collector.
+
+Usage:
+
+	package main
+
+	import (
+	    "context"
+	    "log"
+
+	    "github.com/nikoksr/notify"
+	    "github.com/nikoksr/notify/service/splunkhec"
+	)
+
+	func main() {
+	    splunkService := splunkhec.New("https://splunk.example.com:8088", "your-hec-token")
+
+	    splunkService.SetIndex("notifications")
+	    splunkService.SetSourceType("notify:event")
+
+	    // Tell our notifier to use the splunk service.
+	    notify.UseServices(splunkService)
+
+	    // Send a test message.
+	    if err := notify.Send(context.Background(), "Subject/Title", "The actual message"); err != nil {
+	        log.Fatal(err)
+	    }
+	}
+*/
+package splunkhec
@@ -0,0 +1,165 @@
+package splunkhec
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
+)
+
+// Service allow you to configure the Splunk HTTP Event Collector service.
+type Service struct {
+	client   *http.Client
+	eventURL string
+	token    string
+
+	source     string
+	sourceType string
+	index      string
+	host       string
+	fields     map[string]string
+
+	now func() time.Time
+}
+
+func defaultHTTPClient() *http.Client {
+	return &http.Client{
+		Transport: notify.NewRateLimitTransport(nil),
+		Timeout:   10 * time.Second,
+	}
+}
+
+// New returns a new instance of a Splunk HTTP Event Collector notification service. The URL is the base URL of the
+// collector, e.g. https://splunk.example.com:8088, and the token is an HEC token created under Settings > Data Inputs.
+// For more information about the HTTP Event Collector:
+//
+//	-> https://docs.splunk.com/Documentation/Splunk/latest/Data/UsetheHTTPEventCollector
+func New(url, token string) *Service {
+	return &Service{
+		client:     defaultHTTPClient(),
+		eventURL:   strings.TrimSuffix(url, "/") + "/services/collector/event",
+		token:      token,
+		source:     "notify",
+		sourceType: "_json",
+		fields:     map[string]string{},
+		now:        time.Now,
+	}
+}
+
+// WithClient sets the http client to be used for sending requests, e.g. one trusting the certificate of the
+// collector. Calling this method is optional, the default client will be used if this method is not called.
+func (s *Service) WithClient(client *http.Client) {
+	if client != nil {
+		s.client = client
+	}
+}
+
+// SetSource sets the source of all events. Defaults to "notify".
+func (s *Service) SetSource(source string) {
+	s.source = source
+}
+
+// SetSourceType sets the sourcetype of all events. Defaults to "_json".
+func (s *Service) SetSourceType(sourceType string) {
+	s.sourceType = sourceType
+}
+
+// SetIndex sets the index events are stored in. The token must be allowed to write to it. If left empty, the default
+// index of the token is used.
+func (s *Service) SetIndex(index string) {
+	s.index = index
+}
+
+// SetHost sets the host of all events. If left empty, the collector uses the address of the sender.
+func (s *Service) SetHost(host string) {
+	s.host = host
+}
+
+// AddField adds an indexed field to all events.
+func (s *Service) AddField(key, value string) {
+	s.fields[key] = value
+}
+
+// event is the request body expected by the event endpoint.
+type event struct {
+	Time       float64           `json:"time"`
+	Host       string            `json:"host,omitempty"`
+	Source     string            `json:"source,omitempty"`
+	SourceType string            `json:"sourcetype,omitempty"`
+	Index      string            `json:"index,omitempty"`
+	Fields     map[string]string `json:"fields,omitempty"`
+	Event      eventData         `json:"event"`
+}
+
+type eventData struct {
+	Subject string `json:"subject"`
+	Message string `json:"message"`
+}
+
+type response struct {
+	Text string `json:"text"`
+	Code int    `json:"code"`
+}
+
+// Validate checks that the collector URL is well-formed and that the token is set, see notify.Validator.
+func (s *Service) Validate() error {
+	var problems notify.ConfigErrors
+	problems.Check("collector URL", notify.ValidateURL(s.eventURL))
+	problems.Require("token", s.token)
+
+	return problems.Err()
+}
+
+// Send takes a message subject and a message body and sends them as event to the HTTP Event Collector.
+func (s *Service) Send(ctx context.Context, subject, message string) error {
+	e := event{
+		Time:       float64(s.now().UnixMilli()) / 1000,
+		Host:       s.host,
+		Source:     s.source,
+		SourceType: s.sourceType,
+		Index:      s.index,
+		Event:      eventData{Subject: subject, Message: message},
+	}
+	if len(s.fields) > 0 {
+		e.Fields = s.fields
+	}
+
+	body, err := json.Marshal(e)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal event")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.eventURL, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "failed to create request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Splunk "+s.token)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "failed to send event to Splunk")
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		b, _ := io.ReadAll(resp.Body)
+
+		var result response
+		if json.Unmarshal(b, &result) == nil && result.Text != "" {
+			return notify.ClassifyResponse(resp, fmt.Errorf("splunk returned status code %d: %s (code %d)", resp.StatusCode, result.Text, result.Code))
+		}
+
+		return notify.ClassifyResponse(resp, fmt.Errorf("splunk returned status code %d: %s", resp.StatusCode, string(b)))
+	}
+
+	return nil
+}
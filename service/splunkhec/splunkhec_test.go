@@ -0,0 +1,71 @@
+package splunkhec
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nikoksr/notify"
+)
+
+func TestSplunkHEC_Send(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	var got event
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Splunk token" {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"text":"Invalid token","code":4}`))
+			return
+		}
+		if r.URL.Path != "/services/collector/event" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		_, _ = w.Write([]byte(`{"text":"Success","code":0}`))
+	}))
+	defer server.Close()
+
+	service := New(server.URL+"/", "token")
+	service.now = func() time.Time { return time.UnixMilli(1672531200500) }
+	service.SetSourceType("notify:event")
+	service.SetIndex("alerts")
+	service.SetHost("api-01")
+	service.AddField("team", "ops")
+
+	assert.NoError(service.Send(context.Background(), "subject", "message"))
+	assert.Equal(event{
+		Time:       1672531200.5,
+		Host:       "api-01",
+		Source:     "notify",
+		SourceType: "notify:event",
+		Index:      "alerts",
+		Fields:     map[string]string{"team": "ops"},
+		Event:      eventData{Subject: "subject", Message: "message"},
+	}, got)
+
+	// Test error response
+	service.token = "invalid"
+	assert.ErrorContains(service.Send(context.Background(), "subject", "message"), "Invalid token")
+}
+
+func TestSplunkHEC_Validate(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	assert.NoError(New("https://splunk.example.com:8088", "token").Validate())
+
+	err := New("splunk.example.com:8088", "").Validate()
+	assert.ErrorIs(err, notify.ErrInvalidConfig)
+	assert.ErrorContains(err, "collector URL")
+	assert.ErrorContains(err, "token is missing")
+}
@@ -0,0 +1,40 @@
+/*
+Package squadcast provides a service for creating and resolving incidents in Squadcast.
+
+Usage:
+
+	package main
+
+	import (
+	    "context"
+	    "log"
+
+	    "github.com/nikoksr/notify"
+	    "github.com/nikoksr/notify/service/squadcast"
+	)
+
+	func main() {
+	    squadcastService := squadcast.New()
+
+	    // Trigger incidents through these Incident Webhook alert sources.
+	    squadcastService.AddReceivers("https://api.squadcast.com/v2/incidents/api/your-api-key")
+
+	    // Deduplicate events for the same problem, so they can be resolved later.
+	    squadcastService.SetEventID("api-01/disk-full")
+	    squadcastService.AddTag("severity", "critical")
+
+	    // Tell our notifier to use the squadcast service.
+	    notify.UseServices(squadcastService)
+
+	    // Send a test message.
+	    if err := notify.Send(context.Background(), "Disk full", "api-01 has less than 1% disk space left"); err != nil {
+	        log.Fatal(err)
+	    }
+
+	    // Resolve it once the problem is gone.
+	    if err := squadcastService.Resolve(context.Background(), "api-01/disk-full"); err != nil {
+	        log.Fatal(err)
+	    }
+	}
+*/
+package squadcast
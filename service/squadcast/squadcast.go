@@ -0,0 +1,152 @@
+package squadcast
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
+)
+
+// Status represents the type of event that is sent to Squadcast.
+type Status string
+
+// All statuses supported by the Incident Webhook API.
+const (
+	StatusTrigger Status = "trigger"
+	StatusResolve Status = "resolve"
+)
+
+// Service allow you to configure the Squadcast service.
+type Service struct {
+	client      *http.Client
+	webhookURLs []string
+
+	eventID string
+	tags    map[string]string
+}
+
+func defaultHTTPClient() *http.Client {
+	return &http.Client{
+		Transport: notify.NewRateLimitTransport(nil),
+		Timeout:   10 * time.Second,
+	}
+}
+
+// New returns a new instance of a Squadcast notification service. Receivers are the webhook URLs of "Incident
+// Webhook" alert sources, e.g. https://api.squadcast.com/v2/incidents/api/<api-key>, which can be found on the
+// service's Alert Sources page.
+// For more information about the Incident Webhook API:
+//
+//	-> https://support.squadcast.com/integrations/incident-webhook-incident-webhook-api
+func New() *Service {
+	return &Service{
+		client:      defaultHTTPClient(),
+		webhookURLs: []string{},
+		tags:        map[string]string{},
+	}
+}
+
+// WithClient sets the http client to be used for sending requests. Calling this method is optional, the default client
+// will be used if this method is not called.
+func (s *Service) WithClient(client *http.Client) {
+	if client != nil {
+		s.client = client
+	}
+}
+
+// AddReceivers takes Incident Webhook URLs and adds them to the internal URL list. The Send method will trigger an
+// incident for every one of those URLs.
+func (s *Service) AddReceivers(webhookURLs ...string) {
+	s.webhookURLs = append(s.webhookURLs, webhookURLs...)
+}
+
+// SetEventID sets the event ID of all incidents triggered by this service. Events sharing an ID are deduplicated into
+// the same incident and can be resolved via Resolve. If left empty, every event creates a new incident.
+func (s *Service) SetEventID(eventID string) {
+	s.eventID = eventID
+}
+
+// AddTag adds a tag that is attached to all incidents triggered by this service. Tags can be used in routing and
+// deduplication rules.
+func (s *Service) AddTag(key, value string) {
+	s.tags[key] = value
+}
+
+// event is the request body expected by the Incident Webhook API.
+type event struct {
+	Message     string            `json:"message,omitempty"`
+	Description string            `json:"description,omitempty"`
+	Tags        map[string]string `json:"tags,omitempty"`
+	Status      Status            `json:"status"`
+	EventID     string            `json:"event_id,omitempty"`
+}
+
+func (s *Service) send(ctx context.Context, webhookURL string, e *event) error {
+	body, err := json.Marshal(e)
+	if err != nil {
+		return errors.Wrap(err, "marshal event")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "create request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "send request")
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		result, _ := io.ReadAll(resp.Body)
+		return notify.ClassifyResponse(resp, fmt.Errorf("squadcast returned status code %d: %s", resp.StatusCode, string(result)))
+	}
+
+	return nil
+}
+
+// sendAll sends the event to every webhook URL.
+func (s *Service) sendAll(ctx context.Context, e *event) error {
+	for _, webhookURL := range s.webhookURLs {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			if err := s.send(ctx, webhookURL, e); err != nil {
+				return errors.Wrap(err, "failed to send event to Squadcast")
+			}
+		}
+	}
+
+	return nil
+}
+
+// Send takes a message subject and a message body and triggers an incident for every previously set webhook URL. The
+// subject is used as the incident message, the message as its description.
+func (s *Service) Send(ctx context.Context, subject, message string) error {
+	e := &event{
+		Message:     subject,
+		Description: message,
+		Status:      StatusTrigger,
+		EventID:     s.eventID,
+	}
+	if len(s.tags) > 0 {
+		e.Tags = s.tags
+	}
+
+	return s.sendAll(ctx, e)
+}
+
+// Resolve resolves the incident identified by the given event ID for every previously set webhook URL.
+func (s *Service) Resolve(ctx context.Context, eventID string) error {
+	return s.sendAll(ctx, &event{Status: StatusResolve, EventID: eventID})
+}
@@ -0,0 +1,52 @@
+package squadcast
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSquadcast_Send(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	var events []event
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/incidents/api/key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var e event
+		_ = json.NewDecoder(r.Body).Decode(&e)
+		events = append(events, e)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	service := New()
+	service.AddReceivers(server.URL + "/v2/incidents/api/key")
+	service.SetEventID("api-01/disk-full")
+	service.AddTag("severity", "critical")
+
+	assert.NoError(service.Send(context.Background(), "subject", "message"))
+	assert.NoError(service.Resolve(context.Background(), "api-01/disk-full"))
+	assert.Equal([]event{
+		{
+			Message:     "subject",
+			Description: "message",
+			Tags:        map[string]string{"severity": "critical"},
+			Status:      StatusTrigger,
+			EventID:     "api-01/disk-full",
+		},
+		{Status: StatusResolve, EventID: "api-01/disk-full"},
+	}, events)
+
+	// Test error response
+	service.AddReceivers(server.URL + "/v2/incidents/api/invalid")
+	assert.Error(service.Send(context.Background(), "subject", "message"))
+}
@@ -0,0 +1,40 @@
+/*
+Package statuspage provides a service for creating and updating incidents on Atlassian Statuspage.
+
+Usage:
+
+	package main
+
+	import (
+	    "context"
+	    "log"
+
+	    "github.com/nikoksr/notify"
+	    "github.com/nikoksr/notify/service/statuspage"
+	)
+
+	func main() {
+	    statuspageService := statuspage.New("your-api-key", "your-page-id")
+
+	    // Mark the affected component and notify subscribers.
+	    statuspageService.SetComponentStatus("your-component-id", statuspage.ComponentMajorOutage)
+	    statuspageService.NotifySubscribers(true)
+
+	    // Tell our notifier to use the statuspage service.
+	    notify.UseServices(statuspageService)
+
+	    // Create an incident.
+	    if err := notify.Send(context.Background(), "API outage", "We are investigating elevated error rates."); err != nil {
+	        log.Fatal(err)
+	    }
+
+	    // Later on, resolve it.
+	    statuspageService.SetIncidentID("the-incident-id")
+	    statuspageService.SetIncidentStatus(statuspage.IncidentResolved)
+	    statuspageService.SetComponentStatus("your-component-id", statuspage.ComponentOperational)
+	    if err := notify.Send(context.Background(), "API outage", "The issue has been resolved."); err != nil {
+	        log.Fatal(err)
+	    }
+	}
+*/
+package statuspage
@@ -0,0 +1,192 @@
+package statuspage
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
+)
+
+const defaultAPIURL = "https://api.statuspage.io/v1"
+
+// IncidentStatus represents the status of an incident.
+type IncidentStatus string
+
+// Incident statuses supported by Statuspage for realtime incidents.
+const (
+	IncidentInvestigating IncidentStatus = "investigating"
+	IncidentIdentified    IncidentStatus = "identified"
+	IncidentMonitoring    IncidentStatus = "monitoring"
+	IncidentResolved      IncidentStatus = "resolved"
+)
+
+// ComponentStatus represents the status of a component.
+type ComponentStatus string
+
+// Component statuses supported by Statuspage.
+const (
+	ComponentOperational         ComponentStatus = "operational"
+	ComponentDegradedPerformance ComponentStatus = "degraded_performance"
+	ComponentPartialOutage       ComponentStatus = "partial_outage"
+	ComponentMajorOutage         ComponentStatus = "major_outage"
+	ComponentUnderMaintenance    ComponentStatus = "under_maintenance"
+)
+
+// Service allow you to configure the Statuspage service.
+type Service struct {
+	client *http.Client
+	apiURL string
+	apiKey string
+	pageID string
+
+	incidentID string
+	status     IncidentStatus
+	components map[string]ComponentStatus
+	notify     bool
+}
+
+func defaultHTTPClient() *http.Client {
+	return &http.Client{
+		Transport: notify.NewRateLimitTransport(nil),
+		Timeout:   10 * time.Second,
+	}
+}
+
+// New returns a new instance of a Statuspage notification service for the page with the given ID. The API key can be
+// created under the user's API info.
+// For more information about the Statuspage API:
+//
+//	-> https://developer.statuspage.io/#tag/incidents
+func New(apiKey, pageID string) *Service {
+	return &Service{
+		client:     defaultHTTPClient(),
+		apiURL:     defaultAPIURL,
+		apiKey:     apiKey,
+		pageID:     pageID,
+		status:     IncidentInvestigating,
+		components: map[string]ComponentStatus{},
+	}
+}
+
+// WithClient sets the http client to be used for sending requests. Calling this method is optional, the default client
+// will be used if this method is not called.
+func (s *Service) WithClient(client *http.Client) {
+	if client != nil {
+		s.client = client
+	}
+}
+
+// SetIncidentID sets the ID of an existing incident. If set, Send posts an update to that incident instead of creating
+// a new one.
+func (s *Service) SetIncidentID(incidentID string) {
+	s.incidentID = incidentID
+}
+
+// SetIncidentStatus sets the status of created or updated incidents. Defaults to IncidentInvestigating.
+func (s *Service) SetIncidentStatus(status IncidentStatus) {
+	s.status = status
+}
+
+// SetComponentStatus sets the status the component with the given ID is changed to along with the incident.
+func (s *Service) SetComponentStatus(componentID string, status ComponentStatus) {
+	s.components[componentID] = status
+}
+
+// NotifySubscribers sends notifications to the page's subscribers for incident creations and updates.
+func (s *Service) NotifySubscribers(notify bool) {
+	s.notify = notify
+}
+
+// incident holds the fields of an incident that are created or updated.
+type incident struct {
+	Name                string                     `json:"name,omitempty"`
+	Status              IncidentStatus             `json:"status"`
+	Body                string                     `json:"body"`
+	ComponentIDs        []string                   `json:"component_ids,omitempty"`
+	Components          map[string]ComponentStatus `json:"components,omitempty"`
+	DeliverNotification bool                       `json:"deliver_notifications"`
+}
+
+type incidentRequest struct {
+	Incident incident `json:"incident"`
+}
+
+// Validate checks that the API key and page ID are set, that the API URL is well-formed and that no component ID is
+// empty, see notify.Validator.
+func (s *Service) Validate() error {
+	var problems notify.ConfigErrors
+	problems.Require("API key", s.apiKey)
+	problems.Require("page ID", s.pageID)
+	problems.Check("API URL", notify.ValidateURL(s.apiURL))
+	if _, ok := s.components[""]; ok {
+		problems.Addf("component ID is empty")
+	}
+
+	return problems.Err()
+}
+
+// Send takes a message subject and a message body and creates an incident named after the subject with the message as
+// its first update. If an incident ID is set, the message is posted as update to that incident instead.
+func (s *Service) Send(ctx context.Context, subject, message string) error {
+	inc := incident{
+		Status:              s.status,
+		Body:                message,
+		DeliverNotification: s.notify,
+	}
+	if len(s.components) > 0 {
+		inc.Components = s.components
+		for id := range s.components {
+			inc.ComponentIDs = append(inc.ComponentIDs, id)
+		}
+	}
+
+	method := http.MethodPost
+	endpoint := fmt.Sprintf("%s/pages/%s/incidents", s.apiURL, url.PathEscape(s.pageID))
+	if s.incidentID != "" {
+		method = http.MethodPatch
+		endpoint += "/" + url.PathEscape(s.incidentID)
+	} else {
+		inc.Name = subject
+	}
+
+	if err := s.do(ctx, method, endpoint, incidentRequest{Incident: inc}); err != nil {
+		return errors.Wrap(err, "failed to send incident to Statuspage")
+	}
+
+	return nil
+}
+
+func (s *Service) do(ctx context.Context, method, endpoint string, payload incidentRequest) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return errors.Wrap(err, "marshal incident")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "create request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "OAuth "+s.apiKey)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "send request")
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		result, _ := io.ReadAll(resp.Body)
+		return notify.ClassifyResponse(resp, fmt.Errorf("statuspage returned status code %d: %s", resp.StatusCode, string(result)))
+	}
+
+	return nil
+}
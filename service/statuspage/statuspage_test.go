@@ -0,0 +1,98 @@
+package statuspage
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nikoksr/notify"
+)
+
+func TestStatuspage_Validate(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := New("key", "page")
+	service.SetComponentStatus("api", ComponentMajorOutage)
+	assert.NoError(service.Validate())
+
+	service = New("", "")
+	service.SetComponentStatus("", ComponentMajorOutage)
+	err := service.Validate()
+	assert.ErrorIs(err, notify.ErrInvalidConfig)
+	assert.ErrorContains(err, "API key is missing")
+	assert.ErrorContains(err, "page ID is missing")
+	assert.ErrorContains(err, "component ID is empty")
+}
+
+func TestStatuspage_Send(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	type request struct {
+		method string
+		path   string
+		body   incidentRequest
+	}
+	var got []request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "OAuth key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var body incidentRequest
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		got = append(got, request{method: r.Method, path: r.URL.Path, body: body})
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":"incident"}`))
+	}))
+	defer server.Close()
+
+	service := New("key", "page")
+	service.apiURL = server.URL
+	service.SetComponentStatus("api", ComponentMajorOutage)
+	service.NotifySubscribers(true)
+
+	assert.NoError(service.Send(context.Background(), "API outage", "We are investigating."))
+
+	service.SetIncidentID("incident")
+	service.SetIncidentStatus(IncidentResolved)
+	service.SetComponentStatus("api", ComponentOperational)
+	assert.NoError(service.Send(context.Background(), "API outage", "The issue is resolved."))
+
+	assert.Equal([]request{
+		{
+			method: http.MethodPost,
+			path:   "/pages/page/incidents",
+			body: incidentRequest{Incident: incident{
+				Name:                "API outage",
+				Status:              IncidentInvestigating,
+				Body:                "We are investigating.",
+				ComponentIDs:        []string{"api"},
+				Components:          map[string]ComponentStatus{"api": ComponentMajorOutage},
+				DeliverNotification: true,
+			}},
+		},
+		{
+			method: http.MethodPatch,
+			path:   "/pages/page/incidents/incident",
+			body: incidentRequest{Incident: incident{
+				Status:              IncidentResolved,
+				Body:                "The issue is resolved.",
+				ComponentIDs:        []string{"api"},
+				Components:          map[string]ComponentStatus{"api": ComponentOperational},
+				DeliverNotification: true,
+			}},
+		},
+	}, got)
+
+	// Test error response
+	service.apiKey = "invalid"
+	assert.Error(service.Send(context.Background(), "subject", "message"))
+}
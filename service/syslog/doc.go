@@ -21,7 +21,18 @@ Usage:
 	        log.Fatalf("syslog.New() failed: %v", err)
 	    }
 
-	    notify.UseServices(syslogSvc)
+	    // Or emit RFC 5424 messages to a remote collector via TLS.
+	    remoteSvc, err := syslog.NewRFC5424(syslog.Config{
+	        Network:  "tls",
+	        Address:  "logs.example.com:6514",
+	        Facility: sl.LOG_LOCAL0,
+	        Severity: sl.LOG_ERR,
+	    })
+	    if err != nil {
+	        log.Fatalf("syslog.NewRFC5424() failed: %v", err)
+	    }
+
+	    notify.UseServices(syslogSvc, remoteSvc)
 
 	    err = notify.Send(context.Background(), "TEST", "Hello, World!")
 	    if err != nil {
@@ -0,0 +1,214 @@
+package syslog
+
+import (
+	"crypto/tls"
+	"fmt"
+	"log/syslog"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Config configures a writer that emits RFC 5424 formatted messages.
+type Config struct {
+	// Network is one of "udp", "tcp", "tls", "unix" or "unixgram". If empty, the local syslog daemon is used.
+	Network string
+	// Address is the address of the remote collector, e.g. "logs.example.com:6514", or the path of the unix socket.
+	Address string
+	// TLSConfig is used if Network is "tls". May be nil.
+	TLSConfig *tls.Config
+	// Facility is the facility of all messages, e.g. syslog.LOG_LOCAL0. Defaults to syslog.LOG_USER.
+	Facility syslog.Priority
+	// Severity is the severity of all messages, e.g. syslog.LOG_ERR. Defaults to syslog.LOG_NOTICE, so use
+	// Service.SetSeverity for syslog.LOG_EMERG.
+	Severity syslog.Priority
+	// Hostname is reported as HOSTNAME. Defaults to the hostname of the machine.
+	Hostname string
+	// AppName is reported as APP-NAME. Defaults to "notify".
+	AppName string
+}
+
+// localSockets are the usual locations of the local syslog daemon's socket.
+var localSockets = []string{"/dev/log", "/var/run/syslog", "/var/run/log"}
+
+// rfc5424Writer writes RFC 5424 formatted messages to a local or remote syslog collector. Messages sent via stream
+// transports are framed using octet counting, as described in RFC 6587.
+type rfc5424Writer struct {
+	cfg Config
+
+	mu       sync.Mutex
+	conn     net.Conn
+	severity syslog.Priority
+}
+
+// newRFC5424Writer returns a new writer for the given config and connects it.
+func newRFC5424Writer(cfg Config) (*rfc5424Writer, error) {
+	if cfg.Facility < 0 || cfg.Facility > syslog.LOG_LOCAL7 || cfg.Facility&7 != 0 {
+		return nil, errors.New("invalid syslog facility")
+	}
+	if cfg.Facility == 0 {
+		cfg.Facility = syslog.LOG_USER
+	}
+	if cfg.Severity == 0 {
+		cfg.Severity = syslog.LOG_NOTICE
+	}
+	if cfg.Hostname == "" {
+		cfg.Hostname, _ = os.Hostname()
+	}
+	if cfg.AppName == "" {
+		cfg.AppName = "notify"
+	}
+
+	w := &rfc5424Writer{cfg: cfg}
+	if err := w.setSeverity(cfg.Severity); err != nil {
+		return nil, err
+	}
+	if err := w.connect(); err != nil {
+		return nil, err
+	}
+
+	return w, nil
+}
+
+// setSeverity sets the severity of future messages.
+func (w *rfc5424Writer) setSeverity(severity syslog.Priority) error {
+	if severity < syslog.LOG_EMERG || severity > syslog.LOG_DEBUG {
+		return errors.New("invalid syslog severity")
+	}
+
+	w.mu.Lock()
+	w.severity = severity
+	w.mu.Unlock()
+
+	return nil
+}
+
+// connect establishes the connection to the collector. Must be called with the lock held or before the writer is used.
+func (w *rfc5424Writer) connect() (err error) {
+	switch w.cfg.Network {
+	case "":
+		for _, path := range localSockets {
+			for _, network := range []string{"unixgram", "unix"} {
+				if w.conn, err = net.Dial(network, path); err == nil {
+					return nil
+				}
+			}
+		}
+		return errors.New("unix syslog delivery error")
+	case "tls":
+		w.conn, err = tls.Dial("tcp", w.cfg.Address, w.cfg.TLSConfig)
+	default:
+		w.conn, err = net.Dial(w.cfg.Network, w.cfg.Address)
+	}
+
+	return err
+}
+
+// isStream reports whether messages need to be framed.
+func (w *rfc5424Writer) isStream() bool {
+	switch w.conn.(type) {
+	case *net.TCPConn, *tls.Conn:
+		return true
+	case *net.UnixConn:
+		return w.conn.LocalAddr().Network() == "unix"
+	}
+
+	return false
+}
+
+// format returns the message formatted as RFC 5424 syslog message with the given severity, without structured data.
+func (w *rfc5424Writer) format(msg string, severity syslog.Priority, now time.Time) string {
+	return fmt.Sprintf("<%d>1 %s %s %s %d - - %s",
+		w.cfg.Facility|severity,
+		now.Format("2006-01-02T15:04:05.000000Z07:00"),
+		headerField(w.cfg.Hostname, 255),
+		headerField(w.cfg.AppName, 48),
+		os.Getpid(),
+		msg,
+	)
+}
+
+// headerField returns the value as valid header field: at most n printable ASCII characters without spaces, or the
+// NILVALUE if empty.
+func headerField(value string, n int) string {
+	value = strings.Map(func(r rune) rune {
+		if r <= ' ' || r > '~' {
+			return -1
+		}
+		return r
+	}, value)
+	if value == "" {
+		return "-"
+	}
+	if len(value) > n {
+		value = value[:n]
+	}
+
+	return value
+}
+
+// write writes the message over the current connection.
+func (w *rfc5424Writer) write(msg string) error {
+	if w.isStream() {
+		msg = strconv.Itoa(len(msg)) + " " + msg
+	}
+	_, err := w.conn.Write([]byte(msg))
+
+	return err
+}
+
+// Write implements io.Writer. If writing fails, the writer reconnects once and retries.
+func (w *rfc5424Writer) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.send(p, w.severity)
+}
+
+// writeSeverity works like Write, but with the given severity instead of the configured one.
+func (w *rfc5424Writer) writeSeverity(p []byte, severity syslog.Priority) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.send(p, severity)
+}
+
+// send formats and writes the message with the given severity. Must be called with the lock held.
+func (w *rfc5424Writer) send(p []byte, severity syslog.Priority) (int, error) {
+	msg := w.format(string(p), severity, time.Now())
+	if w.conn != nil {
+		if err := w.write(msg); err == nil {
+			return len(p), nil
+		}
+		_ = w.conn.Close()
+		w.conn = nil
+	}
+
+	if err := w.connect(); err != nil {
+		return 0, err
+	}
+	if err := w.write(msg); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+// Close implements io.Closer.
+func (w *rfc5424Writer) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.conn == nil {
+		return nil
+	}
+	err := w.conn.Close()
+	w.conn = nil
+
+	return err
+}
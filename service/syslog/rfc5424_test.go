@@ -0,0 +1,121 @@
+package syslog
+
+import (
+	"bufio"
+	"context"
+	"log/syslog"
+	"net"
+	"os"
+	"regexp"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nikoksr/notify"
+)
+
+func TestSyslog_RFC5424Format(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	w := &rfc5424Writer{
+		cfg:      Config{Facility: syslog.LOG_LOCAL0, Hostname: "my host", AppName: ""},
+		severity: syslog.LOG_ERR,
+	}
+	now := time.Date(2003, 10, 11, 22, 14, 15, 3000, time.UTC)
+	expected := "<131>1 2003-10-11T22:14:15.000003Z myhost - " + strconv.Itoa(os.Getpid()) + " - - subject: message"
+	assert.Equal(expected, w.format("subject: message", w.severity, now))
+}
+
+func TestSyslog_NewRFC5424(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	_, err := NewRFC5424(Config{Network: "udp", Address: "localhost:514", Facility: 3})
+	assert.Error(err)
+
+	_, err = NewRFC5424(Config{Network: "udp", Address: "localhost:514", Severity: 8})
+	assert.Error(err)
+
+	// SetSeverity is not supported by log/syslog writers.
+	svc := &Service{writer: new(mockSyslogWriter)}
+	assert.Error(svc.SetSeverity(syslog.LOG_ERR))
+}
+
+func TestSyslog_SendRFC5424UDP(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	assert.NoError(err)
+	defer func() { _ = conn.Close() }()
+
+	svc, err := NewRFC5424(Config{
+		Network:  "udp",
+		Address:  conn.LocalAddr().String(),
+		Facility: syslog.LOG_DAEMON,
+		Hostname: "host",
+		AppName:  "app",
+	})
+	assert.NoError(err)
+	defer func() { _ = svc.Close() }()
+
+	assert.NoError(svc.SetSeverity(syslog.LOG_WARNING))
+	assert.NoError(svc.Send(context.Background(), "subject", "message"))
+
+	buf := make([]byte, 1024)
+	_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	n, _, err := conn.ReadFrom(buf)
+	assert.NoError(err)
+	assert.Regexp(regexp.MustCompile(`^<28>1 \S+ host app \d+ - - subject: message$`), string(buf[:n]))
+
+	// The priority of the notification overrides the severity.
+	ctx := notify.ContextWithSendOptions(context.Background(), notify.WithPriority(notify.PriorityCritical))
+	assert.NoError(svc.Send(ctx, "subject", "message"))
+	n, _, err = conn.ReadFrom(buf)
+	assert.NoError(err)
+	assert.Regexp(regexp.MustCompile(`^<26>1 \S+ host app \d+ - - subject: message$`), string(buf[:n]))
+}
+
+func TestSyslog_SendRFC5424TCP(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(err)
+	defer func() { _ = listener.Close() }()
+
+	received := make(chan string, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer func() { _ = conn.Close() }()
+
+		r := bufio.NewReader(conn)
+		length, _ := r.ReadString(' ')
+		n, _ := strconv.Atoi(length[:len(length)-1])
+		msg := make([]byte, n)
+		_, _ = r.Read(msg)
+		received <- string(msg)
+	}()
+
+	svc, err := NewRFC5424(Config{Network: "tcp", Address: listener.Addr().String()})
+	assert.NoError(err)
+	defer func() { _ = svc.Close() }()
+
+	assert.NoError(svc.Send(context.Background(), "subject", "message"))
+	select {
+	case msg := <-received:
+		assert.Regexp(regexp.MustCompile(`^<13>1 \S+ \S+ notify \d+ - - subject: message$`), msg)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for message")
+	}
+}
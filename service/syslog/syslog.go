@@ -7,6 +7,8 @@ import (
 	"strings"
 
 	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
 )
 
 // mockSyslogWriter abstracts log/syslog for writing unit tests.
@@ -16,6 +18,14 @@ type syslogWriter interface {
 	io.WriteCloser
 }
 
+// severities maps the priorities of notify.WithPriority to syslog severities.
+var severities = map[notify.Priority]syslog.Priority{
+	notify.PriorityLow:      syslog.LOG_INFO,
+	notify.PriorityNormal:   syslog.LOG_NOTICE,
+	notify.PriorityHigh:     syslog.LOG_WARNING,
+	notify.PriorityCritical: syslog.LOG_CRIT,
+}
+
 // Service encapsulates a syslog daemon writer.
 type Service struct {
 	writer syslogWriter
@@ -53,21 +63,75 @@ func NewFromDial(network, raddr string, priority syslog.Priority, tag string) (*
 	return dial(network, raddr, priority, tag)
 }
 
+// NewRFC5424 returns a new instance of a Service notification service that emits RFC 5424 formatted messages to the
+// local syslog daemon or a remote collector via UDP, TCP or TLS, as configured.
+func NewRFC5424(cfg Config) (*Service, error) {
+	writer, err := newRFC5424Writer(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Service{writer: writer}, nil
+}
+
+// SetSeverity sets the severity of future messages, e.g. syslog.LOG_WARNING. Only supported by services created with
+// NewRFC5424, as log/syslog fixes the severity on creation.
+func (s *Service) SetSeverity(severity syslog.Priority) error {
+	writer, ok := s.writer.(*rfc5424Writer)
+	if !ok {
+		return errors.New("setting the severity requires a service created with NewRFC5424")
+	}
+
+	return writer.setSeverity(severity)
+}
+
 // Close the underlying syslog writer.
 func (s *Service) Close() error {
 	return s.writer.Close()
 }
 
-// Send takes a message subject and a message body and sends them to all previously set channels.
-// user used for sending the message has to be a member of the channel.
+// write writes the message with the severity mapped from the given priority, or the configured severity for
+// notify.PriorityDefault.
+func (s *Service) write(msg string, priority notify.Priority) error {
+	severity, ok := severities[priority]
+	if !ok {
+		_, err := s.writer.Write([]byte(msg))
+		return err
+	}
+
+	switch writer := s.writer.(type) {
+	case *rfc5424Writer:
+		_, err := writer.writeSeverity([]byte(msg), severity)
+		return err
+	case *syslog.Writer:
+		switch severity {
+		case syslog.LOG_INFO:
+			return writer.Info(msg)
+		case syslog.LOG_NOTICE:
+			return writer.Notice(msg)
+		case syslog.LOG_WARNING:
+			return writer.Warning(msg)
+		case syslog.LOG_CRIT:
+			return writer.Crit(msg)
+		}
+	}
+
+	_, err := s.writer.Write([]byte(msg))
+
+	return err
+}
+
+// Send takes a message subject and a message body and writes them to the syslog. The priority of the notification,
+// see notify.WithPriority, sets the severity of the message: low is LOG_INFO, normal LOG_NOTICE, high LOG_WARNING and
+// critical LOG_CRIT. Without priority, the severity the service was created with is used.
 func (s *Service) Send(ctx context.Context, subject, message string) error {
 	select {
 	case <-ctx.Done():
 		return ctx.Err()
 	default:
-		_, err := s.writer.Write([]byte(subject + ": " + message))
+		err := s.write(subject+": "+message, notify.SendOptionsFromContext(ctx).Priority)
 		if err != nil {
-			return errors.Wrap(err, "failed to write message to syslog")
+			return notify.ClassifyNetworkError(errors.Wrap(err, "failed to write message to syslog"))
 		}
 	}
 
@@ -2,9 +2,15 @@ package telegram
 
 import (
 	"context"
+	"html"
+	"strconv"
+	"strings"
+	"time"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
 	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
 )
 
 const (
@@ -14,10 +20,35 @@ const (
 
 var parseMode = ModeHTML // HTML is the default mode.
 
+// uploadOptions configures the upload of message attachments. Bots can send files of up to 50 MB.
+var uploadOptions = notify.UploadOptions{
+	MaxSize:    50 << 20,
+	Retries:    2,
+	RetryDelay: time.Second,
+}
+
+// Compile-time check to ensure that Telegram implements the optional notify interfaces.
+var (
+	_ notify.MessageSender    = Telegram{}
+	_ notify.AttachmentSender = Telegram{}
+	_ notify.Limiter          = Telegram{}
+	_ notify.Validator        = Telegram{}
+	_ notify.Updater          = Telegram{}
+	_ notify.Deleter          = Telegram{}
+	_ notify.Sanitizer        = Telegram{}
+	_ notify.Receiver         = Telegram{}
+	_ notify.ContactResolver  = Telegram{}
+)
+
+// pollTimeout is the number of seconds a poll for updates waits for new messages. It bounds the time Receive takes to
+// return once its context is done.
+const pollTimeout = 10
+
 // Telegram struct holds necessary data to communicate with the Telegram API.
 type Telegram struct {
 	client  *tgbotapi.BotAPI
 	chatIDs []int64
+	threads *notify.Threads
 }
 
 // New returns a new instance of a Telegram notification service.
@@ -33,6 +64,7 @@ func New(apiToken string) (*Telegram, error) {
 	t := &Telegram{
 		client:  client,
 		chatIDs: []int64{},
+		threads: notify.NewThreads(),
 	}
 
 	return t, nil
@@ -60,26 +92,317 @@ func (t *Telegram) AddReceivers(chatIDs ...int64) {
 	t.chatIDs = append(t.chatIDs, chatIDs...)
 }
 
+// Validate checks that the service has a bot client and that no chat ID is zero, see notify.Validator. The token of
+// the bot is already verified by New.
+func (t Telegram) Validate() error {
+	var problems notify.ConfigErrors
+	if t.client == nil {
+		problems.Addf("bot client is missing")
+	}
+	for _, chatID := range t.chatIDs {
+		if chatID == 0 {
+			problems.Addf("chat ID is zero")
+		}
+	}
+
+	return problems.Err()
+}
+
+// ResolveContact returns the chat ID of the contact for "telegram", e.g. the ID of the private chat with the contact,
+// see notify.SendToContacts.
+func (t Telegram) ResolveContact(contact notify.Contact) (string, bool) {
+	return contact.ChatID("telegram")
+}
+
 // Send takes a message subject and a message body and sends them to all previously set chats. Message body supports
-// html as markup language.
+// html as markup language. The chat ID and message ID of each sent message are recorded as receipt, see
+// notify.ContextWithReceipts. Messages sent with the same thread key, see notify.ContextWithThreadKey, are sent as
+// replies to the first one.
 func (t Telegram) Send(ctx context.Context, subject, message string) error {
-	fullMessage := subject + "\n" + message // Treating subject as message title
+	return t.SendMessage(ctx, notify.Message{Subject: subject, Body: message})
+}
+
+// inlineKeyboard returns a keyboard with one URL button per action.
+func inlineKeyboard(actions []notify.Action) tgbotapi.InlineKeyboardMarkup {
+	rows := make([][]tgbotapi.InlineKeyboardButton, 0, len(actions))
+	for _, action := range actions {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonURL(action.Label, action.URL)))
+	}
+
+	return tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
+
+// uploadAttachments sends the attachments as documents in reply to the message with the given ID.
+func (t Telegram) uploadAttachments(ctx context.Context, chatID int64, messageID int, attachments []notify.Attachment) error {
+	_, err := notify.UploadAttachments(ctx, attachments, uploadOptions,
+		func(_ context.Context, attachment notify.Attachment) (string, error) {
+			document := tgbotapi.NewDocumentUpload(chatID, tgbotapi.FileBytes{Name: attachment.Name, Bytes: attachment.Data})
+			document.MimeType = attachment.ContentType
+			document.ReplyToMessageID = messageID
+
+			sent, err := t.client.Send(document)
+			if err != nil {
+				return "", classifyError(err)
+			}
+			if sent.Document == nil {
+				return "", nil
+			}
+
+			return sent.Document.FileID, nil
+		})
+
+	return err
+}
+
+// receivers returns the chat IDs to send to, the receivers overridden for the send if any, see
+// notify.WithReceiversOverride, or the configured ones.
+func (t Telegram) receivers(ctx context.Context) ([]int64, error) {
+	override := notify.SendOptionsFromContext(ctx).Receivers
+	if override == nil {
+		return t.chatIDs, nil
+	}
+
+	chatIDs := make([]int64, 0, len(override))
+	for _, receiver := range override {
+		chatID, err := strconv.ParseInt(receiver, 10, 64)
+		if err != nil {
+			return nil, notify.ClassifyError(errors.Wrapf(err, "invalid Telegram chat ID %q", receiver),
+				notify.ErrInvalidReceiver)
+		}
+		chatIDs = append(chatIDs, chatID)
+	}
+
+	return chatIDs, nil
+}
+
+// SupportsAttachments reports that Telegram delivers the attachments of messages, see notify.AttachmentSender.
+func (t Telegram) SupportsAttachments() bool {
+	return true
+}
+
+// SendMessage works like Send, but renders the actions of the message as an inline keyboard of URL buttons and sends
+// its attachments as documents in reply to the message.
+func (t Telegram) SendMessage(ctx context.Context, message notify.Message) error {
+	fullMessage := message.Subject + "\n" + message.Body // Treating subject as message title
 
 	msg := tgbotapi.NewMessage(0, fullMessage)
 	msg.ParseMode = parseMode
+	if len(message.Actions) > 0 {
+		msg.ReplyMarkup = inlineKeyboard(message.Actions)
+	}
 
-	for _, chatID := range t.chatIDs {
+	chatIDs, err := t.receivers(ctx)
+	if err != nil {
+		return err
+	}
+
+	threadKey, threaded := notify.ThreadKeyFromContext(ctx)
+	threaded = threaded && t.threads != nil
+
+	for _, chatID := range chatIDs {
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
 		default:
+			receiver := strconv.FormatInt(chatID, 10)
+
 			msg.ChatID = chatID
-			_, err := t.client.Send(msg)
+			msg.ReplyToMessageID = 0
+			starting := false
+			if threaded {
+				root, ok, err := t.threads.Begin(ctx, receiver, threadKey)
+				if err != nil {
+					return err
+				}
+				if ok {
+					msg.ReplyToMessageID, _ = strconv.Atoi(root)
+				}
+				starting = !ok
+			}
+
+			sent, err := t.client.Send(msg)
 			if err != nil {
-				return errors.Wrapf(err, "failed to send message to Telegram chat '%d'", chatID)
+				if starting {
+					t.threads.Abort(receiver, threadKey)
+				}
+				return classifyError(errors.Wrapf(err, "failed to send message to Telegram chat '%d'", chatID))
+			}
+
+			if starting {
+				t.threads.SetRoot(receiver, threadKey, strconv.Itoa(sent.MessageID))
+			}
+
+			notify.RecordReceipt(ctx, notify.Receipt{
+				Service:   t,
+				Receiver:  receiver,
+				MessageID: strconv.Itoa(sent.MessageID),
+			})
+
+			if err = t.uploadAttachments(ctx, chatID, sent.MessageID, message.Attachments); err != nil {
+				return classifyError(errors.Wrapf(err, "failed to upload attachments to Telegram chat '%d'", chatID))
 			}
 		}
 	}
 
 	return nil
 }
+
+// classifyError classifies errors returned by the Telegram Bot API by their description, which starts with the HTTP
+// status text, see notify.ClassifyError.
+func classifyError(err error) error {
+	var apiErr tgbotapi.Error
+	if !errors.As(err, &apiErr) {
+		return notify.ClassifyNetworkError(err)
+	}
+
+	description := strings.ToLower(apiErr.Message)
+	switch {
+	case apiErr.RetryAfter > 0, strings.HasPrefix(description, "too many requests"):
+		retryAfter := time.Duration(apiErr.RetryAfter) * time.Second
+		return notify.ClassifyError(err, &notify.ErrRateLimited{RetryAfter: retryAfter})
+	case strings.HasPrefix(description, "unauthorized"):
+		return notify.ClassifyError(err, notify.ErrAuthFailed)
+	case apiErr.MigrateToChatID != 0, strings.HasPrefix(description, "forbidden"),
+		strings.Contains(description, "chat not found"):
+		// The bot was blocked or removed from the chat, or the group was upgraded to a supergroup.
+		return notify.ClassifyError(err, notify.ErrInvalidReceiver)
+	case strings.HasPrefix(description, "bad request"):
+		return notify.ClassifyError(err, notify.ErrPermanent)
+	case strings.HasPrefix(description, "internal server error"), strings.HasPrefix(description, "bad gateway"):
+		return notify.ClassifyError(err, notify.ErrTransient)
+	default:
+		return err
+	}
+}
+
+// parseReceipt extracts the chat ID and message ID from a receipt recorded by Send.
+func parseReceipt(receipt notify.Receipt) (chatID int64, messageID int, err error) {
+	chatID, err = strconv.ParseInt(receipt.Receiver, 10, 64)
+	if err != nil {
+		return 0, 0, errors.Wrapf(err, "invalid Telegram chat ID '%s'", receipt.Receiver)
+	}
+
+	messageID, err = strconv.Atoi(receipt.MessageID)
+	if err != nil {
+		return 0, 0, errors.Wrapf(err, "invalid Telegram message ID '%s'", receipt.MessageID)
+	}
+
+	return chatID, messageID, nil
+}
+
+// Update takes a receipt recorded by Send and replaces the text of the sent message with the new subject and message.
+func (t Telegram) Update(_ context.Context, receipt notify.Receipt, subject, message string) error {
+	chatID, messageID, err := parseReceipt(receipt)
+	if err != nil {
+		return err
+	}
+
+	edit := tgbotapi.NewEditMessageText(chatID, messageID, subject+"\n"+message)
+	edit.ParseMode = parseMode
+
+	if _, err = t.client.Send(edit); err != nil {
+		return classifyError(errors.Wrapf(err, "failed to update message '%d' in Telegram chat '%d'", messageID, chatID))
+	}
+
+	return nil
+}
+
+// Delete takes a receipt recorded by Send and deletes the sent message from its chat. Bots can only delete messages
+// that were sent less than 48 hours ago.
+func (t Telegram) Delete(_ context.Context, receipt notify.Receipt) error {
+	chatID, messageID, err := parseReceipt(receipt)
+	if err != nil {
+		return err
+	}
+
+	if _, err = t.client.DeleteMessage(tgbotapi.NewDeleteMessage(chatID, messageID)); err != nil {
+		return classifyError(errors.Wrapf(err, "failed to delete message '%d' in Telegram chat '%d'", messageID, chatID))
+	}
+
+	return nil
+}
+
+// reply converts a message received by the bot into a notify.Reply.
+func (t Telegram) reply(message *tgbotapi.Message) notify.Reply {
+	receiver := strconv.FormatInt(message.Chat.ID, 10)
+	reply := notify.Reply{
+		Service:  t,
+		Receiver: receiver,
+		Text:     message.Text,
+		Time:     message.Time(),
+	}
+	if message.From != nil {
+		reply.Sender = strconv.Itoa(message.From.ID)
+	}
+	if message.ReplyToMessage != nil {
+		reply.InReplyTo = &notify.Receipt{
+			Service:   t,
+			Receiver:  receiver,
+			MessageID: strconv.Itoa(message.ReplyToMessage.MessageID),
+		}
+	}
+
+	return reply
+}
+
+// Receive polls the bot for new messages and passes those posted to the chats added with AddReceivers to the handler,
+// until the context is done. Replies to notifications carry the receipt of the notification. Receiving doesn't work
+// for bots with a webhook set.
+func (t Telegram) Receive(ctx context.Context, handler notify.ReplyHandler) error {
+	chats := make(map[int64]bool, len(t.chatIDs))
+	for _, chatID := range t.chatIDs {
+		chats[chatID] = true
+	}
+
+	config := tgbotapi.NewUpdate(0)
+	config.Timeout = pollTimeout
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		default:
+		}
+
+		updates, err := t.client.GetUpdates(config)
+		if err != nil {
+			return errors.Wrap(err, "failed to get updates from Telegram")
+		}
+
+		for _, update := range updates {
+			config.Offset = update.UpdateID + 1
+
+			message := update.Message
+			if message == nil {
+				message = update.ChannelPost
+			}
+			if message == nil || message.Chat == nil || !chats[message.Chat.ID] {
+				continue
+			}
+
+			if err = handler(ctx, t.reply(message)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// markdownEscaper escapes the characters that start formatting in Telegram's legacy markdown mode.
+var markdownEscaper = strings.NewReplacer("_", "\\_", "*", "\\*", "`", "\\`", "[", "\\[")
+
+// Sanitize escapes the message for the parse mode set with SetParseMode, so that user provided content can't format
+// the message or inject links.
+func (t Telegram) Sanitize(message notify.Message) notify.Message {
+	escape := html.EscapeString
+	if parseMode == ModeMarkdown {
+		escape = markdownEscaper.Replace
+	}
+
+	return notify.SanitizeMessage(message, func(s string) string {
+		return escape(notify.StripControlChars(s))
+	})
+}
+
+// Limits returns the maximum length of Telegram messages, which contain subject and body.
+func (t Telegram) Limits() notify.Limits {
+	return notify.Limits{Total: 4096}
+}
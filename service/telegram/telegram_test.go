@@ -0,0 +1,40 @@
+package telegram
+
+import (
+	"testing"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nikoksr/notify"
+)
+
+func TestTelegram_Validate(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := &Telegram{client: &tgbotapi.BotAPI{Token: "token"}}
+	service.AddReceivers(-1001234567890, 42)
+	assert.NoError(service.Validate())
+
+	service = &Telegram{}
+	service.AddReceivers(0)
+	err := service.Validate()
+	assert.ErrorIs(err, notify.ErrInvalidConfig)
+	assert.ErrorContains(err, "bot client is missing")
+	assert.ErrorContains(err, "chat ID is zero")
+}
+
+func TestTelegram_ResolveContact(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	receiver, ok := Telegram{}.ResolveContact(notify.Contact{ChatIDs: map[string]string{"telegram": "42"}})
+	assert.True(ok)
+	assert.Equal("42", receiver)
+
+	_, ok = Telegram{}.ResolveContact(notify.Contact{Email: "jane@example.com"})
+	assert.False(ok)
+}
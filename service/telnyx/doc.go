@@ -0,0 +1,37 @@
+/*
+Package telnyx provides a service for sending SMS and MMS via Telnyx.
+
+Usage:
+
+	package main
+
+	import (
+	    "context"
+	    "log"
+
+	    "github.com/nikoksr/notify"
+	    "github.com/nikoksr/notify/service/telnyx"
+	)
+
+	func main() {
+	    telnyxService := telnyx.New("your-api-key")
+
+	    // Send from a fixed number...
+	    telnyxService.SetFrom("+15551234567")
+
+	    // ...or let Telnyx pick one from the number pool of a messaging profile.
+	    // telnyxService.SetMessagingProfileID("your-messaging-profile-id")
+
+	    // Send to these phone numbers.
+	    telnyxService.AddReceivers("+15557654321")
+
+	    // Tell our notifier to use the telnyx service.
+	    notify.UseServices(telnyxService)
+
+	    // Send a test message.
+	    if err := notify.Send(context.Background(), "Subject/Title", "The actual message"); err != nil {
+	        log.Fatal(err)
+	    }
+	}
+*/
+package telnyx
@@ -0,0 +1,200 @@
+package telnyx
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
+)
+
+const defaultBaseURL = "https://api.telnyx.com/v2"
+
+// Service encapsulates the Telnyx client.
+type Service struct {
+	client             *http.Client
+	baseURL            string
+	apiKey             string
+	from               string
+	messagingProfileID string
+	mediaURLs          []string
+	receivers          []string
+}
+
+// New returns a new instance of a Telnyx notification service. The API key can be created in the Telnyx Mission
+// Control Portal. Either a sender number must be set via SetFrom, or a messaging profile with a number pool via
+// SetMessagingProfileID.
+// For more information about the Telnyx messaging API:
+//
+//	-> https://developers.telnyx.com/api/messaging/send-message
+func New(apiKey string) *Service {
+	return &Service{
+		client: &http.Client{
+			Transport: notify.NewRateLimitTransport(nil),
+			Timeout:   10 * time.Second,
+		},
+		baseURL:   defaultBaseURL,
+		apiKey:    apiKey,
+		receivers: []string{},
+	}
+}
+
+// WithClient sets the http client to be used for sending requests. Calling this method is optional, the default client
+// will be used if this method is not called.
+func (s *Service) WithClient(client *http.Client) {
+	if client != nil {
+		s.client = client
+	}
+}
+
+// SetFrom sets the sending phone number in E.164 format, a short code or an alphanumeric sender ID.
+func (s *Service) SetFrom(from string) {
+	s.from = from
+}
+
+// SetMessagingProfileID sets the messaging profile to send messages with. If no sender is set via SetFrom, messages
+// are sent using the number pool of the profile, letting Telnyx choose the sending number.
+func (s *Service) SetMessagingProfileID(id string) {
+	s.messagingProfileID = id
+}
+
+// AddMediaURLs adds URLs of media files to attach to messages, turning them into MMS.
+func (s *Service) AddMediaURLs(urls ...string) {
+	s.mediaURLs = append(s.mediaURLs, urls...)
+}
+
+// AddReceivers takes phone numbers in E.164 format and adds them to the internal receiver list. The Send method will
+// send a given message to all those numbers.
+func (s *Service) AddReceivers(phoneNumbers ...string) {
+	s.receivers = append(s.receivers, phoneNumbers...)
+}
+
+type messageRequest struct {
+	From               string   `json:"from,omitempty"`
+	MessagingProfileID string   `json:"messaging_profile_id,omitempty"`
+	To                 string   `json:"to"`
+	Text               string   `json:"text"`
+	Subject            string   `json:"subject,omitempty"`
+	MediaURLs          []string `json:"media_urls,omitempty"`
+}
+
+type errorResponse struct {
+	Errors []struct {
+		Code   string `json:"code"`
+		Title  string `json:"title"`
+		Detail string `json:"detail"`
+	} `json:"errors"`
+}
+
+// Validate checks that the API key is set, that there is a sender or a messaging profile, that the sender, if it is a
+// phone number, and the receiver phone numbers are in E.164 format and that the media URLs are well-formed, see
+// notify.Validator.
+func (s *Service) Validate() error {
+	var problems notify.ConfigErrors
+	problems.Require("API key", s.apiKey)
+	if s.from == "" && s.messagingProfileID == "" {
+		problems.Addf("sender is missing, see SetFrom and SetMessagingProfileID")
+	}
+	if strings.HasPrefix(s.from, "+") {
+		problems.Check("sender", notify.ValidatePhoneNumber(s.from))
+	}
+	for _, number := range s.receivers {
+		problems.Check("receiver", notify.ValidatePhoneNumber(number))
+	}
+	for _, mediaURL := range s.mediaURLs {
+		problems.Check("media URL", notify.ValidateURL(mediaURL))
+	}
+
+	return problems.Err()
+}
+
+// ResolveContact returns the phone number of the contact, see notify.SendToContacts.
+func (s *Service) ResolveContact(contact notify.Contact) (string, bool) {
+	return contact.Phone, contact.Phone != ""
+}
+
+// Send takes a message subject and a message body and sends them to all previously set phone numbers. For SMS, subject
+// and message are joined by a newline; MMS carry the subject separately. The phone numbers can be overridden per send,
+// see notify.WithReceiversOverride.
+func (s *Service) Send(ctx context.Context, subject, message string) error {
+	receivers := notify.ReceiversFromContext(ctx, s.receivers)
+	if len(receivers) == 0 {
+		return nil
+	}
+	if s.from == "" && s.messagingProfileID == "" {
+		return errors.New("either a sender or a messaging profile ID must be set")
+	}
+
+	path := "/messages"
+	if s.from == "" {
+		path = "/messages/number_pool"
+	}
+
+	for _, receiver := range receivers {
+		req := messageRequest{
+			From:               s.from,
+			MessagingProfileID: s.messagingProfileID,
+			To:                 receiver,
+		}
+		if len(s.mediaURLs) > 0 {
+			req.Subject = subject
+			req.Text = message
+			req.MediaURLs = s.mediaURLs
+		} else {
+			req.Text = subject + "\n" + message
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			if err := s.post(ctx, path, req); err != nil {
+				return errors.Wrapf(err, "failed to send message to %q", receiver)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) post(ctx context.Context, path string, payload messageRequest) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return errors.Wrap(err, "marshal request")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.baseURL+path, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "create request")
+	}
+	req.Header.Set("Authorization", "Bearer "+s.apiKey)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "send request")
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		b, _ := io.ReadAll(resp.Body)
+
+		var errResp errorResponse
+		if json.Unmarshal(b, &errResp) == nil && len(errResp.Errors) > 0 {
+			e := errResp.Errors[0]
+			return fmt.Errorf("%s (code %s): %s", e.Title, e.Code, e.Detail)
+		}
+
+		return notify.ClassifyResponse(resp, fmt.Errorf("status %d: %s", resp.StatusCode, string(b)))
+	}
+
+	return nil
+}
@@ -0,0 +1,95 @@
+package telnyx
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nikoksr/notify"
+)
+
+func TestTelnyx_Send(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	got := map[string][]messageRequest{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"errors":[{"code":"10009","title":"Authentication failed","detail":"Invalid API key"}]}`))
+			return
+		}
+		var req messageRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		got[r.URL.Path] = append(got[r.URL.Path], req)
+		_, _ = w.Write([]byte(`{"data":{"id":"1"}}`))
+	}))
+	defer server.Close()
+
+	service := New("key")
+	service.baseURL = server.URL
+
+	// No receivers added
+	assert.NoError(service.Send(context.Background(), "subject", "message"))
+
+	// Neither sender nor messaging profile set
+	service.AddReceivers("+15557654321", "+15557654322")
+	assert.Error(service.Send(context.Background(), "subject", "message"))
+
+	// Number pool
+	service.SetMessagingProfileID("profile")
+	assert.NoError(service.Send(context.Background(), "subject", "message"))
+	assert.Equal([]messageRequest{
+		{MessagingProfileID: "profile", To: "+15557654321", Text: "subject\nmessage"},
+		{MessagingProfileID: "profile", To: "+15557654322", Text: "subject\nmessage"},
+	}, got["/messages/number_pool"])
+
+	// MMS from a fixed number
+	service.SetFrom("+15551234567")
+	service.AddMediaURLs("https://example.com/image.png")
+	assert.NoError(service.Send(context.Background(), "subject", "message"))
+	assert.Len(got["/messages"], 2)
+	assert.Equal(messageRequest{
+		From:               "+15551234567",
+		MessagingProfileID: "profile",
+		To:                 "+15557654321",
+		Text:               "message",
+		Subject:            "subject",
+		MediaURLs:          []string{"https://example.com/image.png"},
+	}, got["/messages"][0])
+
+	// Test error response
+	service.apiKey = "invalid"
+	err := service.Send(context.Background(), "subject", "message")
+	assert.ErrorContains(err, "Invalid API key")
+}
+
+func TestTelnyx_Validate(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := New("key")
+	service.SetMessagingProfileID("profile")
+	service.AddReceivers("+13125550001")
+	assert.NoError(service.Validate())
+
+	service = New("")
+	service.AddReceivers("3125550001")
+	err := service.Validate()
+	assert.ErrorIs(err, notify.ErrInvalidConfig)
+	assert.ErrorContains(err, "API key is missing")
+	assert.ErrorContains(err, "sender is missing")
+	assert.ErrorContains(err, `receiver: phone number "3125550001" is not in E.164 format`)
+
+	service.SetFrom("+1 312")
+	service.AddMediaURLs("example.com/image.png")
+	err = service.Validate()
+	assert.ErrorContains(err, `sender: phone number "+1 312" is not in E.164 format`)
+	assert.ErrorContains(err, "media URL")
+}
@@ -0,0 +1,34 @@
+/*
+Package textbelt provides a service for sending SMS messages through Textbelt or a self-hosted Textbelt server.
+
+Usage:
+
+	package main
+
+	import (
+	    "context"
+	    "log"
+
+	    "github.com/nikoksr/notify"
+	    "github.com/nikoksr/notify/service/textbelt"
+	)
+
+	func main() {
+	    textbeltService := textbelt.New("your-api-key")
+
+	    // Optionally use a self-hosted server.
+	    textbeltService.SetServerURL("http://localhost:9090")
+
+	    // Add phone numbers.
+	    textbeltService.AddReceivers("5555555555")
+
+	    // Tell our notifier to use the textbelt service.
+	    notify.UseServices(textbeltService)
+
+	    // Send a test message.
+	    if err := notify.Send(context.Background(), "Subject/Title", "The actual message - Hello, you awesome gophers! :)"); err != nil {
+	        log.Fatal(err)
+	    }
+	}
+*/
+package textbelt
@@ -0,0 +1,155 @@
+package textbelt
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
+)
+
+// DefaultServerURL is the URL of the hosted Textbelt service.
+const DefaultServerURL = "https://textbelt.com"
+
+// Service encapsulates the Textbelt client.
+type Service struct {
+	client    *http.Client
+	serverURL string
+	key       string
+	receivers []string
+}
+
+// New returns a new instance of a Textbelt notification service. The key is a purchased Textbelt API key; use "textbelt"
+// to send one free message per day for testing. Self-hosted servers may not require a key at all.
+// For more information about Textbelt:
+//
+//	-> https://docs.textbelt.com/
+func New(key string) *Service {
+	return &Service{
+		client: &http.Client{
+			Transport: notify.NewRateLimitTransport(nil),
+			Timeout:   10 * time.Second,
+		},
+		serverURL: DefaultServerURL,
+		key:       key,
+		receivers: []string{},
+	}
+}
+
+// WithClient sets the http client to be used for sending requests. Calling this method is optional, the default client
+// will be used if this method is not called.
+func (s *Service) WithClient(client *http.Client) {
+	if client != nil {
+		s.client = client
+	}
+}
+
+// SetServerURL sets the URL of a self-hosted Textbelt server. Defaults to DefaultServerURL.
+func (s *Service) SetServerURL(serverURL string) {
+	s.serverURL = strings.TrimSuffix(serverURL, "/")
+}
+
+// AddReceivers takes phone numbers and adds them to the internal receiver list. The Send method will send a given
+// message to all those numbers.
+func (s *Service) AddReceivers(phoneNumbers ...string) {
+	s.receivers = append(s.receivers, phoneNumbers...)
+}
+
+type textResponse struct {
+	Success        bool   `json:"success"`
+	Error          string `json:"error"`
+	QuotaRemaining int    `json:"quotaRemaining"`
+}
+
+// Validate checks that the server URL is well-formed, see notify.Validator. The key and phone numbers aren't checked,
+// as self-hosted servers may not require a key and accept phone numbers in their own formats.
+func (s *Service) Validate() error {
+	var problems notify.ConfigErrors
+	problems.Check("server URL", notify.ValidateURL(s.serverURL))
+
+	return problems.Err()
+}
+
+// ResolveContact returns the phone number of the contact, see notify.SendToContacts.
+func (s *Service) ResolveContact(contact notify.Contact) (string, bool) {
+	return contact.Phone, contact.Phone != ""
+}
+
+// Send takes a message subject and a message body and sends them to all previously set phone numbers. Subject and
+// message are joined by a newline. The phone numbers can be overridden per send, see notify.WithReceiversOverride.
+func (s *Service) Send(ctx context.Context, subject, message string) error {
+	receivers := notify.ReceiversFromContext(ctx, s.receivers)
+	text := subject + "\n" + message
+
+	for _, phone := range receivers {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			if err := s.send(ctx, phone, text); err != nil {
+				return errors.Wrapf(err, "failed to send SMS to '%s' via Textbelt", phone)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) send(ctx context.Context, phone, text string) error {
+	form := url.Values{}
+	form.Set("phone", phone)
+	form.Set("message", text)
+	if s.key != "" {
+		form.Set("key", s.key)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.serverURL+"/text", strings.NewReader(form.Encode()))
+	if err != nil {
+		return errors.Wrap(err, "create request")
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return notify.ClassifyNetworkError(errors.Wrap(err, "send request"))
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	var result textResponse
+	if err = json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		err = errors.Wrapf(err, "decode response with status code %d", resp.StatusCode)
+		return notify.ClassifyResponse(resp, err)
+	}
+
+	if !result.Success {
+		return classifyError(resp.StatusCode, fmt.Errorf("textbelt returned error: %s", result.Error))
+	}
+
+	return nil
+}
+
+// classifyError classifies an error reported by Textbelt, see notify.ClassifyError. Textbelt answers most failed sends
+// with 200 and an error message, so the message is matched if the status doesn't tell.
+func classifyError(statusCode int, err error) error {
+	if statusCode >= http.StatusBadRequest {
+		return notify.ClassifyStatus(statusCode, err)
+	}
+
+	msg := strings.ToLower(err.Error())
+	switch {
+	case strings.Contains(msg, "phone"):
+		return notify.ClassifyError(err, notify.ErrInvalidReceiver)
+	case strings.Contains(msg, "per day"), strings.Contains(msg, "too many"):
+		return notify.ClassifyError(err, &notify.ErrRateLimited{})
+	case strings.Contains(msg, "key"):
+		return notify.ClassifyError(err, notify.ErrAuthFailed)
+	default:
+		return notify.ClassifyError(err, notify.ErrPermanent)
+	}
+}
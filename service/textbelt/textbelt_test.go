@@ -0,0 +1,69 @@
+package textbelt
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nikoksr/notify"
+)
+
+func TestTextbelt_Send(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	var got []url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		if r.URL.Path != "/text" || r.PostForm.Get("phone") == "invalid" {
+			_, _ = w.Write([]byte(`{"success":false,"error":"Invalid phone number"}`))
+			return
+		}
+		got = append(got, r.PostForm)
+		_, _ = w.Write([]byte(`{"success":true,"textId":"1","quotaRemaining":40}`))
+	}))
+	defer server.Close()
+
+	service := New("key")
+	service.SetServerURL(server.URL + "/")
+	service.AddReceivers("5555555555")
+
+	assert.NoError(service.Send(context.Background(), "subject", "message"))
+	assert.Equal([]url.Values{{
+		"phone":   {"5555555555"},
+		"message": {"subject\nmessage"},
+		"key":     {"key"},
+	}}, got)
+
+	// Test self-hosted server without key
+	got = nil
+	service.key = ""
+	assert.NoError(service.Send(context.Background(), "subject", "message"))
+	assert.Len(got, 1)
+	assert.NotContains(got[0], "key")
+
+	// Test error response
+	service.AddReceivers("invalid")
+	err := service.Send(context.Background(), "subject", "message")
+	assert.Error(err)
+	assert.Contains(err.Error(), "Invalid phone number")
+}
+
+func TestTextbelt_Validate(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := New("textbelt")
+	assert.NoError(service.Validate())
+
+	service.SetServerURL("textbelt.example.com")
+	err := service.Validate()
+	assert.ErrorIs(err, notify.ErrInvalidConfig)
+	assert.ErrorContains(err, "server URL")
+}
@@ -5,6 +5,8 @@ import (
 	"strings"
 
 	textMagic "github.com/textmagic/textmagic-rest-go-v2/v2"
+
+	"github.com/nikoksr/notify"
 )
 
 // Service allow you to configure a TextMagic SDK client.
@@ -33,18 +35,41 @@ func (s *Service) AddReceivers(phoneNumbers ...string) {
 	s.phoneNumbers = append(s.phoneNumbers, phoneNumbers...)
 }
 
-// Send sends a SMS via TextMagic to all previously added receivers.
+// Validate checks that username and API key are set and that the receivers are phone numbers in international format,
+// see notify.Validator.
+func (s *Service) Validate() error {
+	var problems notify.ConfigErrors
+	problems.Require("username", s.userName)
+	problems.Require("API key", s.apiKey)
+	for _, number := range s.phoneNumbers {
+		problems.Check("receiver", notify.ValidatePhoneNumber("+"+strings.TrimPrefix(number, "+")))
+	}
+
+	return problems.Err()
+}
+
+// ResolveContact returns the phone number of the contact, see notify.SendToContacts.
+func (s *Service) ResolveContact(contact notify.Contact) (string, bool) {
+	return contact.Phone, contact.Phone != ""
+}
+
+// Send sends a SMS via TextMagic to all previously added receivers. The phone numbers can be overridden per send, see
+// notify.WithReceiversOverride.
 func (s *Service) Send(ctx context.Context, subject, message string) error {
+	phoneNumbers := notify.ReceiversFromContext(ctx, s.phoneNumbers)
 	auth := context.WithValue(ctx, textMagic.ContextBasicAuth, textMagic.BasicAuth{
 		UserName: s.userName,
 		Password: s.apiKey,
 	})
 
 	text := subject + "\n" + message
-	_, _, err := s.client.TextMagicApi.SendMessage(auth, textMagic.SendMessageInputObject{
+	_, resp, err := s.client.TextMagicApi.SendMessage(auth, textMagic.SendMessageInputObject{
 		Text:   text,
-		Phones: strings.Join(s.phoneNumbers, ","),
+		Phones: strings.Join(phoneNumbers, ","),
 	})
+	if err != nil && resp != nil {
+		return notify.ClassifyResponse(resp, err)
+	}
 
-	return err
+	return notify.ClassifyNetworkError(err)
 }
@@ -0,0 +1,27 @@
+package textmagic
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nikoksr/notify"
+)
+
+func TestTextMagic_Validate(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := New("user", "key")
+	service.AddReceivers("447860021130", "+447860021131")
+	assert.NoError(service.Validate())
+
+	service = New("", "")
+	service.AddReceivers("07860 021130")
+	err := service.Validate()
+	assert.ErrorIs(err, notify.ErrInvalidConfig)
+	assert.ErrorContains(err, "username is missing")
+	assert.ErrorContains(err, "API key is missing")
+	assert.ErrorContains(err, "receiver")
+}
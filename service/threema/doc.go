@@ -0,0 +1,35 @@
+/*
+Package threema provides a service for sending messages via the Threema Gateway.
+
+Usage:
+
+	package main
+
+	import (
+	    "context"
+	    "log"
+
+	    "github.com/nikoksr/notify"
+	    "github.com/nikoksr/notify/service/threema"
+	)
+
+	func main() {
+	    // Basic mode, messages are encrypted by the Threema servers.
+	    threemaService := threema.NewBasic("*NOTIFY1", "your-secret")
+
+	    // End-to-end mode, messages are encrypted locally.
+	    // threemaService, err := threema.NewE2E("*NOTIFY1", "your-secret", "your-private-key")
+
+	    // Send to these Threema IDs.
+	    threemaService.AddReceivers("ECHOECHO")
+
+	    // Tell our notifier to use the threema service.
+	    notify.UseServices(threemaService)
+
+	    // Send a test message.
+	    if err := notify.Send(context.Background(), "Subject/Title", "The actual message"); err != nil {
+	        log.Fatal(err)
+	    }
+	}
+*/
+package threema
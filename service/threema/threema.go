@@ -0,0 +1,256 @@
+package threema
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/nacl/box"
+
+	"github.com/nikoksr/notify"
+)
+
+const defaultBaseURL = "https://msgapi.threema.ch"
+
+// Service encapsulates the Threema Gateway client.
+type Service struct {
+	client    *http.Client
+	baseURL   string
+	gatewayID string
+	secret    string
+	receivers []string
+
+	// privateKey is only set in end-to-end mode.
+	privateKey *[32]byte
+
+	mu         sync.Mutex
+	publicKeys map[string]*[32]byte
+}
+
+// NewBasic returns a new instance of a Threema notification service using a Basic mode gateway ID, e.g. "*NOTIFY1".
+// In Basic mode, messages are encrypted by the Threema servers. The gateway ID and its secret can be requested at
+// https://gateway.threema.ch.
+// For more information about the Gateway API:
+//
+//	-> https://gateway.threema.ch/en/developer/api
+func NewBasic(gatewayID, secret string) *Service {
+	return &Service{
+		client: &http.Client{
+			Transport: notify.NewRateLimitTransport(nil),
+			Timeout:   10 * time.Second,
+		},
+		baseURL:    defaultBaseURL,
+		gatewayID:  gatewayID,
+		secret:     secret,
+		receivers:  []string{},
+		publicKeys: map[string]*[32]byte{},
+	}
+}
+
+// NewE2E returns a new instance of a Threema notification service using an End-to-End mode gateway ID. Messages are
+// encrypted locally with the hex encoded private key generated for the gateway ID, so that not even the Threema
+// servers can read them.
+func NewE2E(gatewayID, secret, privateKey string) (*Service, error) {
+	key, err := hex.DecodeString(strings.TrimPrefix(privateKey, "private:"))
+	if err != nil || len(key) != 32 {
+		return nil, errors.New("invalid private key: must be 32 hex encoded bytes")
+	}
+
+	s := NewBasic(gatewayID, secret)
+	s.privateKey = new([32]byte)
+	copy(s.privateKey[:], key)
+
+	return s, nil
+}
+
+// WithClient sets the http client to be used for sending requests. Calling this method is optional, the default client
+// will be used if this method is not called.
+func (s *Service) WithClient(client *http.Client) {
+	if client != nil {
+		s.client = client
+	}
+}
+
+// AddReceivers takes 8-character Threema IDs and adds them to the internal receiver list. The Send method will send a
+// given message to all those IDs.
+func (s *Service) AddReceivers(threemaIDs ...string) {
+	for _, id := range threemaIDs {
+		s.receivers = append(s.receivers, strings.ToUpper(id))
+	}
+}
+
+// Send takes a message subject and a message body and sends them to all previously set Threema IDs. Subject and
+// message are joined by a newline.
+func (s *Service) Send(ctx context.Context, subject, message string) error {
+	text := subject + "\n" + message
+
+	for _, receiver := range s.receivers {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			var err error
+			if s.privateKey == nil {
+				err = s.sendSimple(ctx, receiver, text)
+			} else {
+				err = s.sendE2E(ctx, receiver, text)
+			}
+			if err != nil {
+				return errors.Wrapf(err, "failed to send message to %q", receiver)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) sendSimple(ctx context.Context, to, text string) error {
+	form := url.Values{
+		"from":   {s.gatewayID},
+		"to":     {to},
+		"text":   {text},
+		"secret": {s.secret},
+	}
+	_, err := s.do(ctx, http.MethodPost, "/send_simple", form)
+
+	return err
+}
+
+func (s *Service) sendE2E(ctx context.Context, to, text string) error {
+	publicKey, err := s.publicKey(ctx, to)
+	if err != nil {
+		return errors.Wrap(err, "fetch public key")
+	}
+
+	plaintext, err := padMessage(text)
+	if err != nil {
+		return err
+	}
+
+	var nonce [24]byte
+	if _, err = io.ReadFull(rand.Reader, nonce[:]); err != nil {
+		return errors.Wrap(err, "generate nonce")
+	}
+	sealed := box.Seal(nil, plaintext, &nonce, publicKey, s.privateKey)
+
+	form := url.Values{
+		"from":   {s.gatewayID},
+		"to":     {to},
+		"nonce":  {hex.EncodeToString(nonce[:])},
+		"box":    {hex.EncodeToString(sealed)},
+		"secret": {s.secret},
+	}
+	_, err = s.do(ctx, http.MethodPost, "/send_e2e", form)
+
+	return err
+}
+
+// publicKey returns the public key of the given Threema ID, looking it up once.
+func (s *Service) publicKey(ctx context.Context, threemaID string) (*[32]byte, error) {
+	s.mu.Lock()
+	key, ok := s.publicKeys[threemaID]
+	s.mu.Unlock()
+	if ok {
+		return key, nil
+	}
+
+	query := url.Values{"from": {s.gatewayID}, "secret": {s.secret}}
+	body, err := s.do(ctx, http.MethodGet, "/pubkeys/"+url.PathEscape(threemaID)+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	decoded, err := hex.DecodeString(strings.TrimSpace(string(body)))
+	if err != nil || len(decoded) != 32 {
+		return nil, errors.New("invalid public key")
+	}
+	key = new([32]byte)
+	copy(key[:], decoded)
+
+	s.mu.Lock()
+	s.publicKeys[threemaID] = key
+	s.mu.Unlock()
+
+	return key, nil
+}
+
+// padMessage encodes text as Threema text message: the message type 0x01 followed by the text and PKCS#7 padding of
+// random length, so that the length of the ciphertext doesn't reveal the length of the text.
+func padMessage(text string) ([]byte, error) {
+	var b [1]byte
+	if _, err := io.ReadFull(rand.Reader, b[:]); err != nil {
+		return nil, errors.Wrap(err, "generate padding")
+	}
+
+	padding := int(b[0])
+	if padding == 0 {
+		padding = 1
+	}
+	// Messages must be at least 32 bytes long, including the padding.
+	if length := 1 + len(text); length+padding < 32 {
+		padding = 32 - length
+	}
+
+	msg := make([]byte, 0, 1+len(text)+padding)
+	msg = append(msg, 0x01)
+	msg = append(msg, text...)
+	for i := 0; i < padding; i++ {
+		msg = append(msg, byte(padding))
+	}
+
+	return msg, nil
+}
+
+func (s *Service) do(ctx context.Context, method, path string, form url.Values) ([]byte, error) {
+	var body io.Reader
+	if form != nil {
+		body = strings.NewReader(form.Encode())
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, s.baseURL+path, body)
+	if err != nil {
+		return nil, errors.Wrap(err, "create request")
+	}
+	if form != nil {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "send request")
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	result, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return nil, notify.ClassifyResponse(resp, fmt.Errorf("threema returned status code %d: %s", resp.StatusCode, statusText(resp.StatusCode)))
+	}
+
+	return result, nil
+}
+
+// statusText describes the status codes returned by the Gateway API.
+func statusText(code int) string {
+	switch code {
+	case http.StatusBadRequest:
+		return "invalid recipient or wrong gateway mode"
+	case http.StatusUnauthorized:
+		return "invalid gateway ID or secret"
+	case http.StatusPaymentRequired:
+		return "no credits remaining"
+	case http.StatusNotFound:
+		return "recipient not found"
+	case http.StatusRequestEntityTooLarge:
+		return "message too long"
+	default:
+		return http.StatusText(code)
+	}
+}
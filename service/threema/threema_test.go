@@ -0,0 +1,112 @@
+package threema
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/nacl/box"
+)
+
+func TestThreema_NewE2E(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	_, err := NewE2E("*NOTIFY1", "secret", "invalid")
+	assert.Error(err)
+
+	service, err := NewE2E("*NOTIFY1", "secret", "private:"+hex.EncodeToString(make([]byte, 32)))
+	assert.NoError(err)
+	assert.NotNil(service.privateKey)
+}
+
+func TestThreema_SendBasic(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	var got []map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		if r.URL.Path != "/send_simple" || r.PostForm.Get("secret") != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		got = append(got, map[string]string{
+			"from": r.PostForm.Get("from"),
+			"to":   r.PostForm.Get("to"),
+			"text": r.PostForm.Get("text"),
+		})
+		_, _ = w.Write([]byte("0a1b2c3d4e5f6071"))
+	}))
+	defer server.Close()
+
+	service := NewBasic("*NOTIFY1", "secret")
+	service.baseURL = server.URL
+	service.AddReceivers("echoecho")
+
+	assert.NoError(service.Send(context.Background(), "subject", "message"))
+	assert.Equal([]map[string]string{{"from": "*NOTIFY1", "to": "ECHOECHO", "text": "subject\nmessage"}}, got)
+
+	// Test error response
+	service.secret = "invalid"
+	assert.ErrorContains(service.Send(context.Background(), "subject", "message"), "invalid gateway ID or secret")
+}
+
+func TestThreema_SendE2E(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	gatewayPublic, gatewayPrivate, err := box.GenerateKey(rand.Reader)
+	assert.NoError(err)
+	recipientPublic, recipientPrivate, err := box.GenerateKey(rand.Reader)
+	assert.NoError(err)
+
+	lookups := 0
+	var texts []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/pubkeys/ECHOECHO":
+			lookups++
+			_, _ = w.Write([]byte(hex.EncodeToString(recipientPublic[:])))
+		case "/send_e2e":
+			_ = r.ParseForm()
+			var nonce [24]byte
+			n, _ := hex.DecodeString(r.PostForm.Get("nonce"))
+			copy(nonce[:], n)
+			sealed, _ := hex.DecodeString(r.PostForm.Get("box"))
+
+			plaintext, ok := box.Open(nil, sealed, &nonce, gatewayPublic, recipientPrivate)
+			if !ok || plaintext[0] != 0x01 {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			padding := int(plaintext[len(plaintext)-1])
+			texts = append(texts, string(plaintext[1:len(plaintext)-padding]))
+			_, _ = w.Write([]byte("0a1b2c3d4e5f6071"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	service, err := NewE2E("*NOTIFY1", "secret", hex.EncodeToString(gatewayPrivate[:]))
+	assert.NoError(err)
+	service.baseURL = server.URL
+	service.AddReceivers("ECHOECHO")
+
+	assert.NoError(service.Send(context.Background(), "subject", "message"))
+	assert.NoError(service.Send(context.Background(), "a", "b"))
+	assert.Equal([]string{"subject\nmessage", "a\nb"}, texts)
+	assert.Equal(1, lookups)
+
+	// Test unknown recipient
+	service.AddReceivers("UNKNOWN1")
+	assert.ErrorContains(service.Send(context.Background(), "subject", "message"), "recipient not found")
+}
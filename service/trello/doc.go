@@ -0,0 +1,32 @@
+/*
+Package trello provides a service for creating cards on Trello lists.
+
+Usage:
+
+	package main
+
+	import (
+	    "context"
+	    "log"
+
+	    "github.com/nikoksr/notify"
+	    "github.com/nikoksr/notify/service/trello"
+	)
+
+	func main() {
+	    trelloService := trello.New("your-api-key", "your-token")
+
+	    // Create cards on this list, labeled "alert" and "backend".
+	    trelloService.AddReceivers("5abbe4b7ddc1b351ef961414")
+	    trelloService.SetTags("alert", "backend")
+
+	    // Tell our notifier to use the trello service.
+	    notify.UseServices(trelloService)
+
+	    // Send a test message.
+	    if err := notify.Send(context.Background(), "Subject/Title", "The actual message"); err != nil {
+	        log.Fatal(err)
+	    }
+	}
+*/
+package trello
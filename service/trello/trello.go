@@ -0,0 +1,173 @@
+package trello
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
+)
+
+// DefaultAPIURL is the base URL of the Trello REST API.
+const DefaultAPIURL = "https://api.trello.com/1"
+
+// Service encapsulates the Trello client.
+type Service struct {
+	client  *http.Client
+	apiURL  string
+	apiKey  string
+	token   string
+	listIDs []string
+	tags    []string
+}
+
+// New returns a new instance of a Trello notification service. The API key and token can be generated on the Power-Up
+// admin portal.
+// For more information about API keys and tokens:
+//
+//	-> https://developer.atlassian.com/cloud/trello/guides/rest-api/api-introduction/
+func New(apiKey, token string) *Service {
+	return &Service{
+		client: &http.Client{
+			Transport: notify.NewRateLimitTransport(nil),
+			Timeout:   10 * time.Second,
+		},
+		apiURL:  DefaultAPIURL,
+		apiKey:  apiKey,
+		token:   token,
+		listIDs: []string{},
+	}
+}
+
+// WithClient sets the http client to be used for sending requests. Calling this method is optional, the default client
+// will be used if this method is not called.
+func (s *Service) WithClient(client *http.Client) {
+	if client != nil {
+		s.client = client
+	}
+}
+
+// AddReceivers takes list IDs and adds them to the internal receiver list. The Send method creates a new card on each
+// of those lists.
+func (s *Service) AddReceivers(listIDs ...string) {
+	s.listIDs = append(s.listIDs, listIDs...)
+}
+
+// SetTags sets the tags of created cards. Every tag is applied as label with the same name; labels that don't exist on
+// the board yet get created.
+func (s *Service) SetTags(tags ...string) {
+	s.tags = tags
+}
+
+type label struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// call sends a request to the given API path and decodes the response into out, if not nil.
+func (s *Service) call(ctx context.Context, method, path string, params url.Values, out any) error {
+	if params == nil {
+		params = url.Values{}
+	}
+	params.Set("key", s.apiKey)
+	params.Set("token", s.token)
+
+	req, err := http.NewRequestWithContext(ctx, method, s.apiURL+path+"?"+params.Encode(), http.NoBody)
+	if err != nil {
+		return errors.Wrap(err, "create request")
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "send request")
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Wrap(err, "read response")
+	}
+	if resp.StatusCode != http.StatusOK {
+		return notify.ClassifyResponse(resp, fmt.Errorf("trello returned status code %d: %s", resp.StatusCode, string(body)))
+	}
+
+	if out != nil {
+		return errors.Wrap(json.Unmarshal(body, out), "decode response")
+	}
+
+	return nil
+}
+
+// labelIDs returns the IDs of the labels matching the tags on the board the list belongs to, creating missing labels.
+func (s *Service) labelIDs(ctx context.Context, listID string) ([]string, error) {
+	var list struct {
+		IDBoard string `json:"idBoard"`
+	}
+	if err := s.call(ctx, http.MethodGet, "/lists/"+listID, url.Values{"fields": {"idBoard"}}, &list); err != nil {
+		return nil, errors.Wrap(err, "get board of list")
+	}
+
+	var labels []label
+	if err := s.call(ctx, http.MethodGet, "/boards/"+list.IDBoard+"/labels", url.Values{"fields": {"name"}}, &labels); err != nil {
+		return nil, errors.Wrap(err, "get labels of board")
+	}
+
+	ids := make([]string, 0, len(s.tags))
+	for _, tag := range s.tags {
+		id := ""
+		for _, l := range labels {
+			if strings.EqualFold(l.Name, tag) {
+				id = l.ID
+				break
+			}
+		}
+
+		if id == "" {
+			var created label
+			params := url.Values{"name": {tag}, "color": {"null"}, "idBoard": {list.IDBoard}}
+			if err := s.call(ctx, http.MethodPost, "/labels", params, &created); err != nil {
+				return nil, errors.Wrapf(err, "create label %q", tag)
+			}
+			labels = append(labels, created)
+			id = created.ID
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// Send takes a message subject and a message body and creates a card with the subject as name and the body as
+// description on all previously set lists.
+func (s *Service) Send(ctx context.Context, subject, message string) error {
+	for _, listID := range s.listIDs {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			params := url.Values{"idList": {listID}, "name": {subject}, "desc": {message}}
+			if len(s.tags) > 0 {
+				ids, err := s.labelIDs(ctx, listID)
+				if err != nil {
+					return errors.Wrapf(err, "failed to resolve labels for list %q", listID)
+				}
+				params.Set("idLabels", strings.Join(ids, ","))
+			}
+
+			if err := s.call(ctx, http.MethodPost, "/cards", params, nil); err != nil {
+				return errors.Wrapf(err, "failed to create card on list %q", listID)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package trello
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrello_Send(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	var card, createdLabel url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("key") != "key" || query.Get("token") != "token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/lists/list":
+			_, _ = w.Write([]byte(`{"id":"list","idBoard":"board"}`))
+		case "/boards/board/labels":
+			_, _ = w.Write([]byte(`[{"id":"l1","name":"Alert"},{"id":"l2","name":"other"}]`))
+		case "/labels":
+			createdLabel = query
+			_, _ = w.Write([]byte(`{"id":"l3","name":"backend"}`))
+		case "/cards":
+			card = query
+			_, _ = w.Write([]byte(`{"id":"card"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	service := New("key", "token")
+	service.apiURL = server.URL
+	service.AddReceivers("list")
+
+	err := service.Send(context.Background(), "subject", "message")
+	assert.Nil(err)
+	assert.Equal("list", card.Get("idList"))
+	assert.Equal("subject", card.Get("name"))
+	assert.Equal("message", card.Get("desc"))
+	assert.Empty(card.Get("idLabels"))
+
+	service.SetTags("alert", "backend")
+	err = service.Send(context.Background(), "subject", "message")
+	assert.Nil(err)
+	assert.Equal("l1,l3", card.Get("idLabels"))
+	assert.Equal("backend", createdLabel.Get("name"))
+	assert.Equal("board", createdLabel.Get("idBoard"))
+
+	// Test error response
+	service.AddReceivers("unknown")
+	err = service.Send(context.Background(), "subject", "message")
+	assert.NotNil(err)
+}
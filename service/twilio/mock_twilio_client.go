@@ -3,6 +3,7 @@
 package twilio
 
 import (
+	context "context"
 	url "net/url"
 
 	twilio_go "github.com/kevinburke/twilio-go"
@@ -14,6 +15,29 @@ type mockTwilioClient struct {
 	mock.Mock
 }
 
+// Get provides a mock function with given fields: ctx, sid
+func (_m *mockTwilioClient) Get(ctx context.Context, sid string) (*twilio_go.Message, error) {
+	ret := _m.Called(ctx, sid)
+
+	var r0 *twilio_go.Message
+	if rf, ok := ret.Get(0).(func(context.Context, string) *twilio_go.Message); ok {
+		r0 = rf(ctx, sid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*twilio_go.Message)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, sid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SendMessage provides a mock function with given fields: from, to, body, mediaURLs
 func (_m *mockTwilioClient) SendMessage(from string, to string, body string, mediaURLs []*url.URL) (*twilio_go.Message, error) {
 	ret := _m.Called(from, to, body, mediaURLs)
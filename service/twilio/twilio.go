@@ -3,9 +3,22 @@ package twilio
 import (
 	"context"
 	"net/url"
+	"strings"
 
+	"github.com/kevinburke/rest/resterror"
 	"github.com/kevinburke/twilio-go"
 	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
+)
+
+// Compile-time check that Service satisfies the notify.StatusQuerier, notify.Limiter, notify.ContactResolver and
+// notify.Validator interfaces.
+var (
+	_ notify.StatusQuerier   = &Service{}
+	_ notify.Limiter         = &Service{}
+	_ notify.ContactResolver = &Service{}
+	_ notify.Validator       = &Service{}
 )
 
 // Compile-time check that twilio.MessageService satisfies twilioClient interface.
@@ -16,6 +29,7 @@ var _ twilioClient = &twilio.MessageService{}
 //go:generate mockery --name=twilioClient --output=. --case=underscore --inpackage
 type twilioClient interface {
 	SendMessage(from, to, body string, mediaURLs []*url.URL) (*twilio.Message, error)
+	Get(ctx context.Context, sid string) (*twilio.Message, error)
 }
 
 // Service encapsulates the Twilio Message Service client along with internal state for storing recipient phone numbers.
@@ -44,22 +58,95 @@ func (s *Service) AddReceivers(phoneNumbers ...string) {
 	s.toPhoneNumbers = append(s.toPhoneNumbers, phoneNumbers...)
 }
 
-// Send takes a message subject and a message body and sends them to all previously set phone numbers.
+// Validate checks that the sender and receiver phone numbers are in E.164 format, see notify.Validator. Senders may
+// also be alphanumeric sender IDs.
+func (s *Service) Validate() error {
+	var problems notify.ConfigErrors
+	problems.Require("sender", s.fromPhoneNumber)
+	if strings.HasPrefix(s.fromPhoneNumber, "+") {
+		problems.Check("sender", notify.ValidatePhoneNumber(s.fromPhoneNumber))
+	}
+	for _, number := range s.toPhoneNumbers {
+		problems.Check("receiver", notify.ValidatePhoneNumber(number))
+	}
+
+	return problems.Err()
+}
+
+// ResolveContact returns the phone number of the contact, see notify.SendToContacts.
+func (s *Service) ResolveContact(contact notify.Contact) (string, bool) {
+	return contact.Phone, contact.Phone != ""
+}
+
+// Send takes a message subject and a message body and sends them to all previously set phone numbers. The SID of each
+// sent message is recorded as receipt, see notify.ContextWithReceipts. The phone numbers can be overridden per send,
+// see notify.WithReceiversOverride.
 func (s *Service) Send(ctx context.Context, subject, message string) error {
 	body := subject + "\n" + message
 
-	for _, toPhoneNumber := range s.toPhoneNumbers {
+	for _, toPhoneNumber := range notify.ReceiversFromContext(ctx, s.toPhoneNumbers) {
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
 		default:
-
-			_, err := s.client.SendMessage(s.fromPhoneNumber, toPhoneNumber, body, []*url.URL{})
+			msg, err := s.client.SendMessage(s.fromPhoneNumber, toPhoneNumber, body, []*url.URL{})
 			if err != nil {
-				return errors.Wrapf(err, "failed to send message to phone number '%s' using Twilio", toPhoneNumber)
+				return classifyError(errors.Wrapf(err, "failed to send message to phone number '%s' using Twilio", toPhoneNumber))
 			}
+
+			notify.RecordReceipt(ctx, notify.Receipt{Service: s, Receiver: toPhoneNumber, MessageID: msg.Sid})
 		}
 	}
 
 	return nil
 }
+
+// Status looks up the message with the given SID and maps its Twilio status to a notify.DeliveryStatus.
+func (s *Service) Status(ctx context.Context, messageID string) (notify.DeliveryStatus, error) {
+	msg, err := s.client.Get(ctx, messageID)
+	if err != nil {
+		err = errors.Wrapf(err, "failed to get message '%s' from Twilio", messageID)
+		return notify.DeliveryStatusUnknown, classifyError(err)
+	}
+
+	switch msg.Status {
+	case twilio.StatusAccepted, twilio.StatusQueued, twilio.StatusSending, twilio.Status("scheduled"):
+		return notify.DeliveryStatusPending, nil
+	case twilio.StatusSent:
+		return notify.DeliveryStatusSent, nil
+	case twilio.StatusDelivered, twilio.StatusRead:
+		return notify.DeliveryStatusDelivered, nil
+	case twilio.StatusUndelivered:
+		return notify.DeliveryStatusBounced, nil
+	case twilio.StatusFailed, twilio.StatusCanceled:
+		return notify.DeliveryStatusFailed, nil
+	default:
+		return notify.DeliveryStatusUnknown, nil
+	}
+}
+
+// Limits returns the maximum length of Twilio SMS, which contain subject and body. Longer texts are rejected by Twilio.
+func (s *Service) Limits() notify.Limits {
+	return notify.Limits{Total: 1600}
+}
+
+// classifyError classifies errors returned by the Twilio API by their Twilio error code and HTTP status, see
+// notify.ClassifyError.
+func classifyError(err error) error {
+	var restErr *resterror.Error
+	if !errors.As(err, &restErr) {
+		return notify.ClassifyNetworkError(err)
+	}
+
+	switch restErr.ID {
+	case "20003":
+		return notify.ClassifyError(err, notify.ErrAuthFailed)
+	case "20429":
+		return notify.ClassifyError(err, &notify.ErrRateLimited{})
+	// Invalid, unreachable or unsubscribed phone numbers and phone numbers that can't receive SMS.
+	case "21211", "21214", "21610", "21612", "21614":
+		return notify.ClassifyError(err, notify.ErrInvalidReceiver)
+	default:
+		return notify.ClassifyStatus(restErr.Status, err)
+	}
+}
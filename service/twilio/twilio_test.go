@@ -7,8 +7,11 @@ import (
 	"net/url"
 	testing "testing"
 
+	"github.com/kevinburke/rest/resterror"
 	twilio "github.com/kevinburke/twilio-go"
 	"github.com/stretchr/testify/require"
+
+	"github.com/nikoksr/notify"
 )
 
 func TestTwilio_New(t *testing.T) {
@@ -35,6 +38,31 @@ func TestTwilio_AddReceivers(t *testing.T) {
 	assert.Equal(svc.toPhoneNumbers, toPhoneNumbers)
 }
 
+func TestTwilio_Validate(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service, err := New("sid", "token", "+4915112345678")
+	assert.NoError(err)
+	service.AddReceivers("+4915187654321")
+	assert.NoError(service.Validate())
+
+	// Alphanumeric sender IDs are allowed.
+	service.fromPhoneNumber = "Notify"
+	assert.NoError(service.Validate())
+
+	service.fromPhoneNumber = "+49 151"
+	service.AddReceivers("015187654321")
+	err = service.Validate()
+	assert.ErrorIs(err, notify.ErrInvalidConfig)
+	assert.ErrorContains(err, `sender: phone number "+49 151" is not in E.164 format`)
+	assert.ErrorContains(err, `receiver: phone number "015187654321" is not in E.164 format`)
+
+	service.fromPhoneNumber = ""
+	assert.ErrorContains(service.Validate(), "sender is missing")
+}
+
 func TestTwilio_Send(t *testing.T) {
 	t.Parallel()
 
@@ -76,6 +104,14 @@ func TestTwilio_Send(t *testing.T) {
 	assert.Equal(
 		fmt.Sprintf("failed to send message to phone number '%s' using Twilio: %s", mockPhoneNumber, mockError.Error()),
 		err.Error())
+
+	// test twilio client send rejecting the phone number
+	mockClient = newMockTwilioClient(t)
+	mockClient.On("SendMessage", svc.fromPhoneNumber, mockPhoneNumber, mockBody, []*url.URL{}).
+		Return(nil, &resterror.Error{Title: "The 'To' number is not a valid phone number.", ID: "21211", Status: 400})
+	svc.client = mockClient
+	err = svc.Send(context.Background(), "subject", "message")
+	assert.ErrorIs(err, notify.ErrInvalidReceiver)
 	mockClient.AssertExpectations(t)
 
 	// test twilio client send multiple receivers
@@ -98,3 +134,66 @@ func TestTwilio_Send(t *testing.T) {
 	assert.Nil(err)
 	mockClient.AssertExpectations(t)
 }
+
+func TestTwilio_SendRecordsReceipts(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	svc := &Service{
+		fromPhoneNumber: "my_phone_number",
+		toPhoneNumbers:  []string{"recipient_phone_number"},
+	}
+
+	mockClient := newMockTwilioClient(t)
+	mockClient.On("SendMessage",
+		svc.fromPhoneNumber,
+		"recipient_phone_number",
+		"subject\nmessage",
+		[]*url.URL{}).Return(&twilio.Message{Sid: "SM123"}, nil)
+	svc.client = mockClient
+
+	ctx, receipts := notify.ContextWithReceipts(context.Background())
+	err := svc.Send(ctx, "subject", "message")
+	assert.Nil(err)
+	assert.Equal([]notify.Receipt{{Service: svc, Receiver: "recipient_phone_number", MessageID: "SM123"}}, receipts.All())
+}
+
+func TestTwilio_Status(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	tests := []struct {
+		status twilio.Status
+		want   notify.DeliveryStatus
+	}{
+		{twilio.StatusQueued, notify.DeliveryStatusPending},
+		{twilio.StatusSending, notify.DeliveryStatusPending},
+		{twilio.StatusSent, notify.DeliveryStatusSent},
+		{twilio.StatusDelivered, notify.DeliveryStatusDelivered},
+		{twilio.StatusUndelivered, notify.DeliveryStatusBounced},
+		{twilio.StatusFailed, notify.DeliveryStatusFailed},
+		{twilio.StatusReceived, notify.DeliveryStatusUnknown},
+	}
+
+	ctx := context.Background()
+	for _, tt := range tests {
+		mockClient := newMockTwilioClient(t)
+		mockClient.On("Get", ctx, "SM123").Return(&twilio.Message{Sid: "SM123", Status: tt.status}, nil)
+		svc := &Service{client: mockClient}
+
+		status, err := svc.Status(ctx, "SM123")
+		assert.Nil(err)
+		assert.Equal(tt.want, status, tt.status)
+	}
+
+	// test twilio client get returning error
+	mockClient := newMockTwilioClient(t)
+	mockClient.On("Get", ctx, "SM123").Return(nil, errors.New("some error"))
+	svc := &Service{client: mockClient}
+
+	status, err := svc.Status(ctx, "SM123")
+	assert.NotNil(err)
+	assert.Equal(notify.DeliveryStatusUnknown, status)
+}
@@ -0,0 +1,41 @@
+/*
+Package twiliovoice provides a service for calling phone numbers and reading notifications using Twilio Voice.
+
+Calls that are not answered are retried, which makes this service a good fit for waking up on-call engineers.
+
+Usage:
+
+	package main
+
+	import (
+	    "context"
+	    "log"
+	    "time"
+
+	    "github.com/nikoksr/notify"
+	    "github.com/nikoksr/notify/service/twiliovoice"
+	)
+
+	func main() {
+	    voiceService := twiliovoice.New("your-account-sid", "your-auth-token", "+15550000000")
+
+	    // Optionally pick a voice and the retry behavior.
+	    voiceService.SetVoice("Polly.Joanna", "en-US")
+	    voiceService.SetRetry(5, 2*time.Minute)
+
+	    // Add phone numbers to call.
+	    voiceService.AddReceivers("+15551111111")
+
+	    // Tell our notifier to use the Twilio Voice service.
+	    notify.UseServices(voiceService)
+
+	    // Calls take a while, so give them enough time.
+	    ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+	    defer cancel()
+
+	    if err := notify.Send(ctx, "Database outage", "The primary database is not responding."); err != nil {
+	        log.Fatal(err)
+	    }
+	}
+*/
+package twiliovoice
@@ -0,0 +1,227 @@
+package twiliovoice
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/kevinburke/rest/resterror"
+	"github.com/kevinburke/twilio-go"
+	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
+)
+
+// Compile-time check that twilio.CallService satisfies callClient interface.
+var _ callClient = &twilio.CallService{}
+
+// callClient abstracts twilio-go CallService for writing unit tests
+//
+//go:generate mockery --name=callClient --output=. --case=underscore --inpackage
+type callClient interface {
+	Create(ctx context.Context, data url.Values) (*twilio.Call, error)
+	Get(ctx context.Context, sid string) (*twilio.Call, error)
+}
+
+// Service encapsulates the Twilio Call Service client along with internal state for storing recipient phone numbers.
+type Service struct {
+	client callClient
+
+	fromPhoneNumber string
+	toPhoneNumbers  []string
+
+	voice       string
+	language    string
+	repeat      int
+	ringTimeout time.Duration
+
+	maxAttempts  int
+	retryDelay   time.Duration
+	pollInterval time.Duration
+}
+
+// New returns a new instance of a Twilio Voice notification service. The from phone number must be a voice capable
+// Twilio number or a verified caller ID.
+func New(accountSID, authToken, fromPhoneNumber string) *Service {
+	client := twilio.NewClient(accountSID, authToken, nil)
+
+	return &Service{
+		client:          client.Calls,
+		fromPhoneNumber: fromPhoneNumber,
+		toPhoneNumbers:  []string{},
+		repeat:          2,
+		ringTimeout:     30 * time.Second,
+		maxAttempts:     3,
+		retryDelay:      time.Minute,
+		pollInterval:    5 * time.Second,
+	}
+}
+
+// AddReceivers takes strings of recipient phone numbers and appends them to the internal phone numbers slice.
+// The Send method will call all those phone numbers.
+func (s *Service) AddReceivers(phoneNumbers ...string) {
+	s.toPhoneNumbers = append(s.toPhoneNumbers, phoneNumbers...)
+}
+
+// SetVoice sets the text-to-speech voice and language, e.g. "Polly.Joanna" and "en-US". Empty values select Twilio's
+// defaults.
+// For a list of available voices:
+//
+//	-> https://www.twilio.com/docs/voice/twiml/say/text-speech
+func (s *Service) SetVoice(voice, language string) {
+	s.voice = voice
+	s.language = language
+}
+
+// SetRepeat sets how often the message is read to the callee. Defaults to 2.
+func (s *Service) SetRepeat(repeat int) {
+	s.repeat = repeat
+}
+
+// SetRingTimeout sets how long a phone rings before the call counts as not answered. Defaults to 30 seconds.
+func (s *Service) SetRingTimeout(timeout time.Duration) {
+	s.ringTimeout = timeout
+}
+
+// SetRetry sets how often a phone number is called when the call is not answered or the line is busy, and how long to
+// wait between attempts. Defaults to 3 attempts, one minute apart.
+func (s *Service) SetRetry(maxAttempts int, delay time.Duration) {
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+	s.maxAttempts = maxAttempts
+	s.retryDelay = delay
+}
+
+// wait blocks for the given duration or until the context is done.
+func wait(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// call places a single call and waits for it to end. It returns the final status of the call.
+func (s *Service) call(ctx context.Context, to, twiml string) (twilio.Status, error) {
+	data := url.Values{}
+	data.Set("From", s.fromPhoneNumber)
+	data.Set("To", to)
+	data.Set("Twiml", twiml)
+	data.Set("Timeout", strconv.Itoa(int(s.ringTimeout.Seconds())))
+
+	call, err := s.client.Create(ctx, data)
+	if err != nil {
+		return "", classifyError(errors.Wrap(err, "create call"))
+	}
+
+	for !call.Ended() {
+		if err = wait(ctx, s.pollInterval); err != nil {
+			return "", err
+		}
+		if call, err = s.client.Get(ctx, call.Sid); err != nil {
+			return "", classifyError(errors.Wrap(err, "get call status"))
+		}
+	}
+
+	return call.Status, nil
+}
+
+// callWithRetry calls the given phone number until the call is answered or the maximum number of attempts is reached.
+func (s *Service) callWithRetry(ctx context.Context, to, twiml string) error {
+	var status twilio.Status
+	for attempt := 1; attempt <= s.maxAttempts; attempt++ {
+		if attempt > 1 {
+			if err := wait(ctx, s.retryDelay); err != nil {
+				return err
+			}
+		}
+
+		var err error
+		if status, err = s.call(ctx, to, twiml); err != nil {
+			return err
+		}
+
+		switch status {
+		case twilio.StatusCompleted:
+			return nil
+		case twilio.StatusNoAnswer, twilio.StatusBusy:
+			continue
+		default:
+			return fmt.Errorf("call ended with status %s", status)
+		}
+	}
+
+	// The callee may answer later.
+	return notify.ClassifyError(fmt.Errorf("call not answered after %d attempts, last status %s", s.maxAttempts, status),
+		notify.ErrTransient)
+}
+
+// Validate checks that the caller and callee phone numbers are in E.164 format, see notify.Validator.
+func (s *Service) Validate() error {
+	var problems notify.ConfigErrors
+	problems.Check("sender", notify.ValidatePhoneNumber(s.fromPhoneNumber))
+	for _, number := range s.toPhoneNumbers {
+		problems.Check("receiver", notify.ValidatePhoneNumber(number))
+	}
+
+	return problems.Err()
+}
+
+// ResolveContact returns the phone number of the contact, see notify.SendToContacts.
+func (s *Service) ResolveContact(contact notify.Contact) (string, bool) {
+	return contact.Phone, contact.Phone != ""
+}
+
+// Send takes a message subject and a message body and reads them to all previously set phone numbers. Calls that are
+// not answered or hit a busy line are retried. Send blocks until every call has ended, so callers should pass a
+// context with a generous deadline. The locale of the notification, see notify.ContextWithLocale, overrides the
+// configured language. The phone numbers can be overridden per send, see notify.WithReceiversOverride.
+func (s *Service) Send(ctx context.Context, subject, message string) error {
+	receivers := notify.ReceiversFromContext(ctx, s.toPhoneNumbers)
+	locale, _ := notify.LocaleFromContext(ctx)
+	twiml, err := s.buildTwiML(subject, message, locale)
+	if err != nil {
+		return errors.Wrap(err, "failed to build TwiML")
+	}
+
+	for _, toPhoneNumber := range receivers {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			if err = s.callWithRetry(ctx, toPhoneNumber, twiml); err != nil {
+				return errors.Wrapf(err, "failed to call phone number '%s' using Twilio", toPhoneNumber)
+			}
+		}
+	}
+
+	return nil
+}
+
+// classifyError classifies errors returned by the Twilio API by their Twilio error code and HTTP status, see
+// notify.ClassifyError.
+func classifyError(err error) error {
+	var restErr *resterror.Error
+	if !errors.As(err, &restErr) {
+		return notify.ClassifyNetworkError(err)
+	}
+
+	switch restErr.ID {
+	case "20003":
+		return notify.ClassifyError(err, notify.ErrAuthFailed)
+	case "20429":
+		return notify.ClassifyError(err, &notify.ErrRateLimited{})
+	// Invalid or unreachable phone numbers and phone numbers in regions calls aren't enabled for.
+	case "21211", "21214", "21215", "21217":
+		return notify.ClassifyError(err, notify.ErrInvalidReceiver)
+	default:
+		return notify.ClassifyStatus(restErr.Status, err)
+	}
+}
@@ -0,0 +1,127 @@
+package twiliovoice
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	twilio "github.com/kevinburke/twilio-go"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nikoksr/notify"
+)
+
+func newTestService(client callClient) *Service {
+	s := New("sid", "token", "+15550000000")
+	s.client = client
+	s.SetRetry(3, 0)
+	s.pollInterval = 0
+
+	return s
+}
+
+func TestTwilioVoice_New(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := New("", "", "")
+	assert.NotNil(service)
+	assert.Equal(3, service.maxAttempts)
+}
+
+func TestTwilioVoice_Validate(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := New("sid", "token", "+4915112345678")
+	service.AddReceivers("+4915187654321")
+	assert.NoError(service.Validate())
+
+	service = New("sid", "token", "")
+	service.AddReceivers("015187654321")
+	err := service.Validate()
+	assert.ErrorIs(err, notify.ErrInvalidConfig)
+	assert.ErrorContains(err, "sender")
+	assert.ErrorContains(err, `receiver: phone number "015187654321" is not in E.164 format`)
+}
+
+func TestTwilioVoice_Send(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	wantData := url.Values{
+		"From":    {"+15550000000"},
+		"To":      {"+15551111111"},
+		"Twiml":   {`<Response><Say voice="Polly.Joanna" language="en-US" loop="2">Outage &amp; more. Database is down</Say></Response>`},
+		"Timeout": {"30"},
+	}
+
+	client := newMockCallClient(t)
+	// First attempt is not answered, second one is.
+	client.On("Create", mock.Anything, wantData).Return(&twilio.Call{Sid: "CA1", Status: twilio.StatusQueued}, nil).Once()
+	client.On("Get", mock.Anything, "CA1").Return(&twilio.Call{Sid: "CA1", Status: twilio.StatusRinging}, nil).Once()
+	client.On("Get", mock.Anything, "CA1").Return(&twilio.Call{Sid: "CA1", Status: twilio.StatusNoAnswer}, nil).Once()
+	client.On("Create", mock.Anything, wantData).Return(&twilio.Call{Sid: "CA2", Status: twilio.StatusQueued}, nil).Once()
+	client.On("Get", mock.Anything, "CA2").Return(&twilio.Call{Sid: "CA2", Status: twilio.StatusCompleted}, nil).Once()
+
+	service := newTestService(client)
+	service.SetVoice("Polly.Joanna", "en-US")
+	service.AddReceivers("+15551111111")
+
+	assert.NoError(service.Send(context.Background(), "Outage & more", "Database is down"))
+
+	// The phone numbers can be overridden per send.
+	wantData.Set("To", "+15552222222")
+	client.On("Create", mock.Anything, wantData).Return(&twilio.Call{Sid: "CA3", Status: twilio.StatusQueued}, nil).Once()
+	client.On("Get", mock.Anything, "CA3").Return(&twilio.Call{Sid: "CA3", Status: twilio.StatusCompleted}, nil).Once()
+
+	ctx := notify.ContextWithSendOptions(context.Background(), notify.WithReceiversOverride("+15552222222"))
+	assert.NoError(service.Send(ctx, "Outage & more", "Database is down"))
+}
+
+func TestTwilioVoice_SendErrors(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	// Never answered
+	client := newMockCallClient(t)
+	client.On("Create", mock.Anything, mock.Anything).Return(&twilio.Call{Sid: "CA1", Status: twilio.StatusBusy}, nil).Times(3)
+
+	service := newTestService(client)
+	service.AddReceivers("+15551111111")
+	err := service.Send(context.Background(), "subject", "message")
+	assert.ErrorIs(err, notify.ErrTransient)
+	assert.Contains(err.Error(), "not answered after 3 attempts")
+
+	// Failed calls are not retried
+	client = newMockCallClient(t)
+	client.On("Create", mock.Anything, mock.Anything).Return(&twilio.Call{Sid: "CA1", Status: twilio.StatusFailed}, nil).Once()
+
+	service = newTestService(client)
+	service.AddReceivers("+15551111111")
+	err = service.Send(context.Background(), "subject", "message")
+	assert.Error(err)
+	assert.Contains(err.Error(), "status failed")
+}
+
+func TestTwilioVoice_buildTwiML(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := New("", "", "")
+	service.SetVoice("", "en-US")
+
+	twiml, err := service.buildTwiML("", "Database is down", "")
+	assert.NoError(err)
+	assert.Equal(`<Response><Say language="en-US" loop="2">Database is down</Say></Response>`, twiml)
+
+	twiml, err = service.buildTwiML("", "Datenbank ist ausgefallen", "de-DE")
+	assert.NoError(err)
+	assert.Equal(`<Response><Say language="de-DE" loop="2">Datenbank ist ausgefallen</Say></Response>`, twiml)
+}
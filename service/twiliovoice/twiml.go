@@ -0,0 +1,46 @@
+package twiliovoice
+
+import (
+	"encoding/xml"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// say is the TwiML verb that reads text to the callee.
+type say struct {
+	Voice    string `xml:"voice,attr,omitempty"`
+	Language string `xml:"language,attr,omitempty"`
+	Loop     int    `xml:"loop,attr,omitempty"`
+	Text     string `xml:",chardata"`
+}
+
+type response struct {
+	XMLName xml.Name `xml:"Response"`
+	Say     say      `xml:"Say"`
+}
+
+// buildTwiML returns the TwiML document that reads subject and message to the callee in the given language, the
+// configured one if empty.
+func (s *Service) buildTwiML(subject, message, language string) (string, error) {
+	if language == "" {
+		language = s.language
+	}
+
+	text := strings.TrimSpace(subject + ". " + message)
+	if subject == "" {
+		text = message
+	}
+
+	doc, err := xml.Marshal(response{Say: say{
+		Voice:    s.voice,
+		Language: language,
+		Loop:     s.repeat,
+		Text:     text,
+	}})
+	if err != nil {
+		return "", errors.Wrap(err, "marshal TwiML")
+	}
+
+	return string(doc), nil
+}
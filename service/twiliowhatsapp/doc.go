@@ -0,0 +1,32 @@
+/*
+Package twiliowhatsapp provides message notification integration for WhatsApp through Twilio.
+
+Usage:
+
+	package main
+
+	import (
+		"context"
+		"log"
+
+		"github.com/nikoksr/notify"
+		"github.com/nikoksr/notify/service/twiliowhatsapp"
+	)
+
+	func main() {
+		whatsAppSvc := twiliowhatsapp.New("account_sid", "auth_token", "whatsapp:+14155238886")
+
+		whatsAppSvc.AddReceivers("+15551111111")
+
+		notifier := notify.New()
+		notifier.UseServices(whatsAppSvc)
+
+		err := notifier.Send(context.Background(), "subject", "message")
+		if err != nil {
+			log.Fatalf("notifier.Send() failed: %s", err.Error())
+		}
+
+		log.Println("notification sent")
+	}
+*/
+package twiliowhatsapp
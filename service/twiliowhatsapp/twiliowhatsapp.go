@@ -0,0 +1,139 @@
+package twiliowhatsapp
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/kevinburke/rest/resterror"
+	"github.com/kevinburke/twilio-go"
+	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
+)
+
+// channelPrefix marks phone numbers as WhatsApp addresses for the Twilio Messages API.
+const channelPrefix = "whatsapp:"
+
+// Compile-time check that twilio.MessageService satisfies twilioClient interface.
+var _ twilioClient = &twilio.MessageService{}
+
+// twilioClient abstracts twilio-go MessageService for writing unit tests
+//
+//go:generate mockery --name=twilioClient --output=. --case=underscore --inpackage
+type twilioClient interface {
+	SendMessage(from, to, body string, mediaURLs []*url.URL) (*twilio.Message, error)
+}
+
+// Service encapsulates the Twilio Message Service client along with internal state for storing recipient WhatsApp
+// addresses.
+type Service struct {
+	client twilioClient
+
+	fromPhoneNumber string
+	toPhoneNumbers  []string
+	mediaURLs       []*url.URL
+}
+
+// whatsAppAddress prefixes the phone number with "whatsapp:", unless it already is a WhatsApp address.
+func whatsAppAddress(phoneNumber string) string {
+	if strings.HasPrefix(phoneNumber, channelPrefix) {
+		return phoneNumber
+	}
+
+	return channelPrefix + phoneNumber
+}
+
+// New returns a new instance of a Twilio WhatsApp notification service. The from phone number is a WhatsApp enabled
+// Twilio number or the Twilio Sandbox number, either as plain E.164 number or in "whatsapp:+..." format.
+// For more information about WhatsApp on Twilio:
+//
+//	-> https://www.twilio.com/docs/whatsapp/api
+func New(accountSID, authToken, fromPhoneNumber string) *Service {
+	client := twilio.NewClient(accountSID, authToken, nil)
+
+	return &Service{
+		client:          client.Messages,
+		fromPhoneNumber: whatsAppAddress(fromPhoneNumber),
+		toPhoneNumbers:  []string{},
+		mediaURLs:       []*url.URL{},
+	}
+}
+
+// AddReceivers takes recipient phone numbers, either as plain E.164 numbers or in "whatsapp:+..." format, and appends
+// them to the internal phone numbers slice. The Send method will send a given message to all those phone numbers.
+func (s *Service) AddReceivers(phoneNumbers ...string) {
+	for _, phoneNumber := range phoneNumbers {
+		s.toPhoneNumbers = append(s.toPhoneNumbers, whatsAppAddress(phoneNumber))
+	}
+}
+
+// AddMediaURLs takes publicly accessible URLs of images, audio or documents and attaches them to all messages.
+func (s *Service) AddMediaURLs(mediaURLs ...*url.URL) {
+	s.mediaURLs = append(s.mediaURLs, mediaURLs...)
+}
+
+// Validate checks that the sender and receiver phone numbers are in E.164 format, see notify.Validator.
+func (s *Service) Validate() error {
+	var problems notify.ConfigErrors
+	problems.Check("sender", notify.ValidatePhoneNumber(strings.TrimPrefix(s.fromPhoneNumber, channelPrefix)))
+	for _, number := range s.toPhoneNumbers {
+		problems.Check("receiver", notify.ValidatePhoneNumber(strings.TrimPrefix(number, channelPrefix)))
+	}
+
+	return problems.Err()
+}
+
+// ResolveContact returns the phone number of the contact, see notify.SendToContacts.
+func (s *Service) ResolveContact(contact notify.Contact) (string, bool) {
+	return contact.Phone, contact.Phone != ""
+}
+
+// Send takes a message subject and a message body and sends them to all previously set phone numbers. The subject is
+// rendered in bold using WhatsApp formatting. The phone numbers can be overridden per send, see
+// notify.WithReceiversOverride.
+//
+// Outside of the 24 hour customer service window, WhatsApp only delivers messages matching an approved template.
+func (s *Service) Send(ctx context.Context, subject, message string) error {
+	receivers := notify.ReceiversFromContext(ctx, s.toPhoneNumbers)
+	body := message
+	if subject != "" {
+		body = "*" + subject + "*\n" + message
+	}
+
+	for _, toPhoneNumber := range receivers {
+		toPhoneNumber = whatsAppAddress(toPhoneNumber)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			_, err := s.client.SendMessage(s.fromPhoneNumber, toPhoneNumber, body, s.mediaURLs)
+			if err != nil {
+				return classifyError(errors.Wrapf(err, "failed to send WhatsApp message to '%s' using Twilio", toPhoneNumber))
+			}
+		}
+	}
+
+	return nil
+}
+
+// classifyError classifies errors returned by the Twilio API by their Twilio error code and HTTP status, see
+// notify.ClassifyError.
+func classifyError(err error) error {
+	var restErr *resterror.Error
+	if !errors.As(err, &restErr) {
+		return notify.ClassifyNetworkError(err)
+	}
+
+	switch restErr.ID {
+	case "20003":
+		return notify.ClassifyError(err, notify.ErrAuthFailed)
+	case "20429":
+		return notify.ClassifyError(err, &notify.ErrRateLimited{})
+	// Invalid or unsubscribed phone numbers and phone numbers without WhatsApp.
+	case "21211", "21610", "63003":
+		return notify.ClassifyError(err, notify.ErrInvalidReceiver)
+	default:
+		return notify.ClassifyStatus(restErr.Status, err)
+	}
+}
@@ -0,0 +1,67 @@
+package twiliowhatsapp
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/kevinburke/rest/resterror"
+	twilio "github.com/kevinburke/twilio-go"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nikoksr/notify"
+)
+
+func TestTwilioWhatsApp_New(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := New("sid", "token", "+14155238886")
+	assert.NotNil(service)
+	assert.Equal("whatsapp:+14155238886", service.fromPhoneNumber)
+
+	service = New("sid", "token", "whatsapp:+14155238886")
+	assert.Equal("whatsapp:+14155238886", service.fromPhoneNumber)
+}
+
+func TestTwilioWhatsApp_Validate(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := New("sid", "token", "whatsapp:+14155238886")
+	service.AddReceivers("+4915112345678")
+	assert.NoError(service.Validate())
+
+	service = New("sid", "token", "14155238886")
+	service.AddReceivers("whatsapp:015112345678")
+	err := service.Validate()
+	assert.ErrorIs(err, notify.ErrInvalidConfig)
+	assert.ErrorContains(err, `sender: phone number "14155238886" is not in E.164 format`)
+	assert.ErrorContains(err, `receiver: phone number "015112345678" is not in E.164 format`)
+}
+
+func TestTwilioWhatsApp_Send(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	mediaURL, _ := url.Parse("https://example.com/graph.png")
+
+	client := newMockTwilioClient(t)
+	client.On("SendMessage", "whatsapp:+14155238886", "whatsapp:+15551111111", "*subject*\nmessage",
+		[]*url.URL{mediaURL}).Return(&twilio.Message{}, nil).Once()
+	client.On("SendMessage", "whatsapp:+14155238886", "whatsapp:+15552222222", "*subject*\nmessage",
+		[]*url.URL{mediaURL}).Return(nil, &resterror.Error{Title: "unverified number", ID: "63003", Status: 400}).Once()
+
+	service := New("sid", "token", "+14155238886")
+	service.client = client
+	service.AddMediaURLs(mediaURL)
+	service.AddReceivers("+15551111111", "whatsapp:+15552222222")
+	assert.Equal([]string{"whatsapp:+15551111111", "whatsapp:+15552222222"}, service.toPhoneNumbers)
+
+	err := service.Send(context.Background(), "subject", "message")
+	assert.ErrorIs(err, notify.ErrInvalidReceiver)
+	assert.Contains(err.Error(), "whatsapp:+15552222222")
+}
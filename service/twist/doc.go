@@ -0,0 +1,34 @@
+/*
+Package twist provides a service for sending messages to Twist threads and channels.
+
+Usage:
+
+	package main
+
+	import (
+	    "context"
+	    "log"
+
+	    "github.com/nikoksr/notify"
+	    "github.com/nikoksr/notify/service/twist"
+	)
+
+	func main() {
+	    twistService := twist.New("your-integration-token")
+
+	    // Comment on existing threads.
+	    twistService.AddReceivers(123456)
+
+	    // Start new threads in channels.
+	    twistService.AddChannels(654321)
+
+	    // Tell our notifier to use the twist service.
+	    notify.UseServices(twistService)
+
+	    // Send a test message.
+	    if err := notify.Send(context.Background(), "Subject/Title", "The actual message - Hello, you awesome gophers! :)"); err != nil {
+	        log.Fatal(err)
+	    }
+	}
+*/
+package twist
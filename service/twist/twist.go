@@ -0,0 +1,141 @@
+package twist
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
+)
+
+const defaultAPIURL = "https://api.twist.com/api/v3"
+
+// Service encapsulates the Twist API client.
+type Service struct {
+	client     *http.Client
+	apiURL     string
+	token      string
+	threadIDs  []int
+	channelIDs []int
+}
+
+// New returns a new instance of a Twist notification service. The token is the OAuth token of a Twist integration
+// that has been installed into the workspace.
+// For more information about the Twist API:
+//
+//	-> https://developer.twist.com/v3/
+func New(token string) *Service {
+	return &Service{
+		client: &http.Client{
+			Transport: notify.NewRateLimitTransport(nil),
+			Timeout:   10 * time.Second,
+		},
+		apiURL:     defaultAPIURL,
+		token:      token,
+		threadIDs:  []int{},
+		channelIDs: []int{},
+	}
+}
+
+// WithClient sets the http client to be used for sending requests. Calling this method is optional, the default client
+// will be used if this method is not called.
+func (s *Service) WithClient(client *http.Client) {
+	if client != nil {
+		s.client = client
+	}
+}
+
+// AddReceivers takes Twist thread IDs and adds them to the internal thread list. The Send method will post a comment
+// to every one of those threads.
+func (s *Service) AddReceivers(threadIDs ...int) {
+	s.threadIDs = append(s.threadIDs, threadIDs...)
+}
+
+// AddChannels takes Twist channel IDs and adds them to the internal channel list. The Send method will start a new
+// thread in every one of those channels.
+func (s *Service) AddChannels(channelIDs ...int) {
+	s.channelIDs = append(s.channelIDs, channelIDs...)
+}
+
+type addCommentRequest struct {
+	ThreadID int    `json:"thread_id"`
+	Content  string `json:"content"`
+}
+
+type addThreadRequest struct {
+	ChannelID int    `json:"channel_id"`
+	Title     string `json:"title"`
+	Content   string `json:"content"`
+}
+
+// Send takes a message subject and a message body and sends them to all previously set threads and channels. Comments
+// in threads contain the subject in bold followed by the message; new threads in channels use the subject as title.
+func (s *Service) Send(ctx context.Context, subject, message string) error {
+	content := message
+	if subject != "" {
+		content = fmt.Sprintf("**%s**\n\n%s", subject, message)
+	}
+
+	for _, threadID := range s.threadIDs {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			payload := addCommentRequest{ThreadID: threadID, Content: content}
+			if err := s.post(ctx, "/comments/add", payload); err != nil {
+				return errors.Wrapf(err, "failed to send message to Twist thread %d", threadID)
+			}
+		}
+	}
+
+	title := subject
+	if title == "" {
+		title = message
+	}
+	for _, channelID := range s.channelIDs {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			payload := addThreadRequest{ChannelID: channelID, Title: title, Content: message}
+			if err := s.post(ctx, "/threads/add", payload); err != nil {
+				return errors.Wrapf(err, "failed to send message to Twist channel %d", channelID)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) post(ctx context.Context, path string, payload any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return errors.Wrap(err, "marshal payload")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.apiURL+path, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "create request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+s.token)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "send request")
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		result, _ := io.ReadAll(resp.Body)
+		return notify.ClassifyResponse(resp, fmt.Errorf("twist returned status code %d: %s", resp.StatusCode, string(result)))
+	}
+
+	return nil
+}
@@ -0,0 +1,50 @@
+package twist
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTwist_Send(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	got := map[string][]map[string]any{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		got[r.URL.Path] = append(got[r.URL.Path], body)
+		_, _ = w.Write([]byte(`{"id":1}`))
+	}))
+	defer server.Close()
+
+	service := New("token")
+	service.apiURL = server.URL
+	service.AddReceivers(1, 2)
+	service.AddChannels(3)
+
+	assert.NoError(service.Send(context.Background(), "subject", "message"))
+	assert.Equal(map[string][]map[string]any{
+		"/comments/add": {
+			{"thread_id": float64(1), "content": "**subject**\n\nmessage"},
+			{"thread_id": float64(2), "content": "**subject**\n\nmessage"},
+		},
+		"/threads/add": {
+			{"channel_id": float64(3), "title": "subject", "content": "message"},
+		},
+	}, got)
+
+	// Test error response
+	service.token = "invalid"
+	assert.Error(service.Send(context.Background(), "subject", "message"))
+}
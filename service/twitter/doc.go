@@ -0,0 +1,43 @@
+/*
+Package twitter provides a service for sending direct messages via the X (Twitter) API v2.
+
+Usage:
+
+	package main
+
+	import (
+	    "context"
+	    "log"
+
+	    "github.com/nikoksr/notify"
+	    "github.com/nikoksr/notify/service/twitter"
+	)
+
+	func main() {
+	    // Authenticate with OAuth 1.0a user credentials...
+	    twitterService, err := twitter.New(twitter.Credentials{
+	        ConsumerKey:       "your-consumer-key",
+	        ConsumerSecret:    "your-consumer-secret",
+	        AccessToken:       "your-access-token",
+	        AccessTokenSecret: "your-access-token-secret",
+	    })
+	    if err != nil {
+	        log.Fatal(err)
+	    }
+
+	    // ...or with an OAuth 2.0 user access token.
+	    // twitterService, err := twitter.NewWithOAuth2("your-oauth2-access-token")
+
+	    // Add the numeric IDs of the users that should receive direct messages.
+	    twitterService.AddReceivers("1234567890")
+
+	    // Tell our notifier to use the twitter service.
+	    notify.UseServices(twitterService)
+
+	    // Send a test message.
+	    if err := notify.Send(context.Background(), "Subject/Title", "The actual message"); err != nil {
+	        log.Fatal(err)
+	    }
+	}
+*/
+package twitter
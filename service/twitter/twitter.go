@@ -1,16 +1,27 @@
 package twitter
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
 
 	"github.com/dghubble/oauth1"
-	"github.com/drswork/go-twitter/twitter"
 	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
 )
 
+// DefaultAPIURL is the base URL of the X (Twitter) API v2.
+const DefaultAPIURL = "https://api.twitter.com/2"
+
 // Twitter struct holds necessary data to communicate with the Twitter API
 type Twitter struct {
-	client     *twitter.Client
+	client     *http.Client
+	apiURL     string
 	twitterIDs []string
 }
 
@@ -33,34 +44,63 @@ type Credentials struct {
 	AccessTokenSecret string
 }
 
-// New returns a new instance of a Twitter service.
+// bearerTransport adds an OAuth 2.0 bearer token to all requests.
+type bearerTransport struct {
+	token string
+	base  http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (b *bearerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+b.token)
+
+	return b.base.RoundTrip(req)
+}
+
+// New returns a new instance of a Twitter service authenticated with OAuth 1.0a user credentials. The credentials are
+// verified before the service is returned.
 // For more information about Twitter access token:
 //
 //	-> https://developer.twitter.com/en/docs/authentication/oauth-1-0a/obtaining-user-access-tokens
 func New(credentials Credentials) (*Twitter, error) {
 	config := oauth1.NewConfig(credentials.ConsumerKey, credentials.ConsumerSecret)
 	token := oauth1.NewToken(credentials.AccessToken, credentials.AccessTokenSecret)
-	httpClient := config.Client(oauth1.NoContext, token)
-	client := twitter.NewClient(httpClient)
+	client := config.Client(oauth1.NoContext, token)
+	client.Transport = notify.NewRateLimitTransport(client.Transport)
 
-	// Verify Credentials
-	verifyParams := &twitter.AccountVerifyParams{
-		SkipStatus:   twitter.Bool(true),
-		IncludeEmail: twitter.Bool(true),
-	}
+	return newWithClient(context.Background(), client, DefaultAPIURL)
+}
 
-	// we can retrieve the user and verify if the credentials
-	// we have used successfully allow us to log in!
-	_, _, err := client.Accounts.VerifyCredentials(verifyParams)
-	if err != nil {
-		return nil, err
+// NewWithOAuth2 returns a new instance of a Twitter service authenticated with an OAuth 2.0 user access token, which
+// requires the dm.write, dm.read, tweet.read and users.read scopes. The token is verified before the service is
+// returned. Refreshing the token is up to the caller.
+// For more information about OAuth 2.0 user access tokens:
+//
+//	-> https://developer.twitter.com/en/docs/authentication/oauth-2-0/authorization-code
+func NewWithOAuth2(accessToken string) (*Twitter, error) {
+	client := &http.Client{
+		Transport: notify.NewRateLimitTransport(&bearerTransport{token: accessToken, base: http.DefaultTransport}),
 	}
 
+	return newWithClient(context.Background(), client, DefaultAPIURL)
+}
+
+func newWithClient(ctx context.Context, client *http.Client, apiURL string) (*Twitter, error) {
+	client.Timeout = 10 * time.Second
+
 	t := &Twitter{
 		client:     client,
+		apiURL:     apiURL,
 		twitterIDs: []string{},
 	}
 
+	// We can retrieve the authenticated user and verify if the credentials
+	// we have used successfully allow us to log in!
+	if err := t.do(ctx, http.MethodGet, "/users/me", nil); err != nil {
+		return nil, errors.Wrap(err, "verify credentials")
+	}
+
 	return t, nil
 }
 
@@ -69,11 +109,44 @@ func (t *Twitter) AddReceivers(twitterIDs ...string) {
 	t.twitterIDs = append(t.twitterIDs, twitterIDs...)
 }
 
+// do sends a request to the given API path and checks the response status.
+func (t *Twitter) do(ctx context.Context, method, path string, payload any) error {
+	var body io.Reader = http.NoBody
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return errors.Wrap(err, "marshal request")
+		}
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, t.apiURL+path, body)
+	if err != nil {
+		return errors.Wrap(err, "create request")
+	}
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "send request")
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		result, _ := io.ReadAll(resp.Body)
+		return notify.ClassifyResponse(resp, fmt.Errorf("twitter returned status code %d: %s", resp.StatusCode, string(result)))
+	}
+
+	return nil
+}
+
 // Send takes a message subject and a message body and sends them to all previously set twitterIDs as a DM.
-// See https://developer.twitter.com/en/docs/twitter-api/v1/direct-messages/sending-and-receiving/api-reference/new-event
+// See https://developer.twitter.com/en/docs/twitter-api/direct-messages/manage/api-reference/post-dm_conversations-with-participant_id-messages
 func (t Twitter) Send(ctx context.Context, subject, message string) error {
-	directMessageData := &twitter.DirectMessageData{
-		Text: subject + "\n" + message,
+	directMessage := map[string]string{
+		"text": subject + "\n" + message,
 	}
 
 	for _, twitterID := range t.twitterIDs {
@@ -81,22 +154,7 @@ func (t Twitter) Send(ctx context.Context, subject, message string) error {
 		case <-ctx.Done():
 			return ctx.Err()
 		default:
-			directMessageTarget := &twitter.DirectMessageTarget{
-				RecipientID: twitterID,
-			}
-			directMessageEvent := &twitter.DirectMessageEvent{
-				Type: "message_create",
-				Message: &twitter.DirectMessageEventMessage{
-					Target: directMessageTarget,
-					Data:   directMessageData,
-				},
-			}
-
-			directMessageParams := &twitter.DirectMessageEventsNewParams{
-				Event: directMessageEvent,
-			}
-
-			_, _, err := t.client.DirectMessages.EventsNew(directMessageParams)
+			err := t.do(ctx, http.MethodPost, "/dm_conversations/with/"+twitterID+"/messages", directMessage)
 			if err != nil {
 				return errors.Wrapf(err, "failed to send direct message to twitter ID '%s'", twitterID)
 			}
@@ -0,0 +1,69 @@
+package twitter
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nikoksr/notify"
+)
+
+func TestTwitter_Send(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	var got []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/users/me":
+			_, _ = w.Write([]byte(`{"data":{"id":"1"}}`))
+		case "/dm_conversations/with/42/messages":
+			var body map[string]string
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			got = append(got, body["text"])
+			w.WriteHeader(http.StatusCreated)
+		case "/dm_conversations/with/44/messages":
+			w.Header().Set("Retry-After", "60")
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: &bearerTransport{token: "token", base: http.DefaultTransport}}
+	service, err := newWithClient(context.Background(), client, server.URL)
+	assert.Nil(err)
+
+	service.AddReceivers("42")
+	err = service.Send(context.Background(), "subject", "message")
+	assert.Nil(err)
+	assert.Equal([]string{"subject\nmessage"}, got)
+
+	// Test error response
+	service.AddReceivers("43")
+	err = service.Send(context.Background(), "subject", "message")
+	assert.NotNil(err)
+
+	// Test rate limit response
+	service.twitterIDs = []string{"44"}
+	err = service.Send(context.Background(), "subject", "message")
+	retryAfter, ok := notify.RetryAfter(err)
+	assert.True(ok)
+	assert.Equal(time.Minute, retryAfter)
+
+	// Test invalid credentials
+	client = &http.Client{Transport: &bearerTransport{token: "invalid", base: http.DefaultTransport}}
+	_, err = newWithClient(context.Background(), client, server.URL)
+	assert.NotNil(err)
+}
@@ -0,0 +1,33 @@
+/*
+Package unixsock provides a service for writing notifications as JSON lines to local Unix domain sockets or named
+pipes, so that sidecar processes can consume them without a network hop.
+
+Usage:
+
+	package main
+
+	import (
+	    "context"
+	    "log"
+
+	    "github.com/nikoksr/notify"
+	    "github.com/nikoksr/notify/service/unixsock"
+	)
+
+	func main() {
+	    sockService := unixsock.New()
+
+	    // Add sockets and named pipes.
+	    sockService.AddReceivers("/run/sidecar/notify.sock")
+	    sockService.AddNamedPipes("/run/sidecar/notify.fifo")
+
+	    // Tell our notifier to use the unix socket service.
+	    notify.UseServices(sockService)
+
+	    // Send a test message.
+	    if err := notify.Send(context.Background(), "Subject/Title", "The actual message - Hello, you awesome gophers! :)"); err != nil {
+	        log.Fatal(err)
+	    }
+	}
+*/
+package unixsock
@@ -0,0 +1,40 @@
+//go:build !windows
+
+package unixsock
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnixSock_SendNamedPipe(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	pipePath := filepath.Join(t.TempDir(), "notify.fifo")
+	assert.NoError(syscall.Mkfifo(pipePath, 0o600))
+
+	service := newTestService()
+	service.AddNamedPipes(pipePath)
+
+	// Without reader
+	assert.Error(service.Send(context.Background(), "subject", "message"))
+
+	reader, err := os.OpenFile(pipePath, os.O_RDONLY|syscall.O_NONBLOCK, 0)
+	assert.NoError(err)
+	defer func() { _ = reader.Close() }()
+
+	assert.NoError(service.Send(context.Background(), "subject", "message"))
+
+	got := make([]byte, len(wantPayload))
+	_, err = io.ReadFull(reader, got)
+	assert.NoError(err)
+	assert.Equal(wantPayload, string(got))
+}
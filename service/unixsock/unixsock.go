@@ -0,0 +1,146 @@
+package unixsock
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"os"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
+)
+
+// Service encapsulates the Unix domain socket and named pipe writer.
+type Service struct {
+	sockets     []string
+	pipes       []string
+	useDatagram bool
+	timeout     time.Duration
+
+	dialer *net.Dialer
+	now    func() time.Time
+}
+
+// New returns a new instance of a Unix socket notification service. Every notification is written as a single line of
+// JSON.
+func New() *Service {
+	return &Service{
+		sockets: []string{},
+		pipes:   []string{},
+		timeout: 5 * time.Second,
+		dialer:  &net.Dialer{},
+		now:     time.Now,
+	}
+}
+
+// AddReceivers takes paths of Unix domain sockets and adds them to the internal socket list. The Send method will
+// write the payload to every one of those sockets.
+func (s *Service) AddReceivers(socketPaths ...string) {
+	s.sockets = append(s.sockets, socketPaths...)
+}
+
+// AddNamedPipes takes paths of named pipes (FIFOs) and adds them to the internal pipe list. The Send method will write
+// the payload to every one of those pipes. Writing fails if no process has the pipe opened for reading.
+func (s *Service) AddNamedPipes(pipePaths ...string) {
+	s.pipes = append(s.pipes, pipePaths...)
+}
+
+// UseDatagram sets whether sockets are of type SOCK_DGRAM ("unixgram") instead of SOCK_STREAM ("unix").
+func (s *Service) UseDatagram(datagram bool) {
+	s.useDatagram = datagram
+}
+
+// SetTimeout sets the timeout for connecting and writing to a single socket. Defaults to 5 seconds.
+func (s *Service) SetTimeout(timeout time.Duration) {
+	s.timeout = timeout
+}
+
+// payload is the JSON object written for every notification.
+type payload struct {
+	Subject   string `json:"subject"`
+	Message   string `json:"message"`
+	Timestamp string `json:"timestamp"`
+}
+
+// Send takes a message subject and a message body and writes them as a single JSON line to all previously set sockets
+// and named pipes.
+func (s *Service) Send(ctx context.Context, subject, message string) error {
+	data, err := json.Marshal(payload{
+		Subject:   subject,
+		Message:   message,
+		Timestamp: s.now().UTC().Format(time.RFC3339),
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to encode payload")
+	}
+	data = append(data, '\n')
+
+	for _, socketPath := range s.sockets {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			if err = s.writeSocket(ctx, socketPath, data); err != nil {
+				return notify.ClassifyNetworkError(errors.Wrapf(err, "failed to write payload to socket '%s'", socketPath))
+			}
+		}
+	}
+
+	for _, pipePath := range s.pipes {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			if err = writePipe(pipePath, data); err != nil {
+				return errors.Wrapf(err, "failed to write payload to named pipe '%s'", pipePath)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) writeSocket(ctx context.Context, socketPath string, data []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	network := "unix"
+	if s.useDatagram {
+		network = "unixgram"
+	}
+
+	conn, err := s.dialer.DialContext(ctx, network, socketPath)
+	if err != nil {
+		return errors.Wrap(err, "dial")
+	}
+	defer func() { _ = conn.Close() }()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetWriteDeadline(deadline)
+	}
+
+	if _, err = conn.Write(data); err != nil {
+		return errors.Wrap(err, "write")
+	}
+
+	return nil
+}
+
+// writePipe writes data to the named pipe. The pipe is opened non-blocking, so that a missing reader results in an
+// error instead of blocking forever. Payloads smaller than PIPE_BUF are written atomically.
+func writePipe(pipePath string, data []byte) error {
+	pipe, err := os.OpenFile(pipePath, os.O_WRONLY|os.O_APPEND|syscall.O_NONBLOCK, 0)
+	if err != nil {
+		return errors.Wrap(err, "open")
+	}
+	defer func() { _ = pipe.Close() }()
+
+	if _, err = pipe.Write(data); err != nil {
+		return errors.Wrap(err, "write")
+	}
+
+	return nil
+}
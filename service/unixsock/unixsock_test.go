@@ -0,0 +1,87 @@
+package unixsock
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const wantPayload = `{"subject":"subject","message":"message","timestamp":"2023-01-02T03:04:05Z"}` + "\n"
+
+func newTestService() *Service {
+	s := New()
+	s.now = func() time.Time { return time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC) }
+
+	return s
+}
+
+func TestUnixSock_SendStream(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	socketPath := filepath.Join(t.TempDir(), "notify.sock")
+	listener, err := net.Listen("unix", socketPath)
+	assert.NoError(err)
+	defer func() { _ = listener.Close() }()
+
+	got := make(chan string, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			close(got)
+			return
+		}
+		defer func() { _ = conn.Close() }()
+		line, _ := bufio.NewReader(conn).ReadString('\n')
+		got <- line
+	}()
+
+	service := newTestService()
+	service.AddReceivers(socketPath)
+
+	assert.NoError(service.Send(context.Background(), "subject", "message"))
+	assert.Equal(wantPayload, <-got)
+}
+
+func TestUnixSock_SendDatagram(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	socketPath := filepath.Join(t.TempDir(), "notify.sock")
+	conn, err := net.ListenPacket("unixgram", socketPath)
+	assert.NoError(err)
+	defer func() { _ = conn.Close() }()
+
+	service := newTestService()
+	service.UseDatagram(true)
+	service.AddReceivers(socketPath)
+
+	assert.NoError(service.Send(context.Background(), "subject", "message"))
+
+	buf := make([]byte, 1024)
+	_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	n, _, err := conn.ReadFrom(buf)
+	assert.NoError(err)
+	assert.Equal(wantPayload, string(buf[:n]))
+}
+
+func TestUnixSock_SendErrors(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := newTestService()
+	service.AddReceivers(filepath.Join(t.TempDir(), "missing.sock"))
+	assert.Error(service.Send(context.Background(), "subject", "message"))
+
+	service = newTestService()
+	service.AddNamedPipes(filepath.Join(t.TempDir(), "missing.fifo"))
+	assert.Error(service.Send(context.Background(), "subject", "message"))
+}
@@ -12,6 +12,43 @@ type mockViberClient struct {
 	mock.Mock
 }
 
+// NewTextMessage provides a mock function with given fields: msg
+func (_m *mockViberClient) NewTextMessage(msg string) *mileusnaviber.TextMessage {
+	ret := _m.Called(msg)
+
+	var r0 *mileusnaviber.TextMessage
+	if rf, ok := ret.Get(0).(func(string) *mileusnaviber.TextMessage); ok {
+		r0 = rf(msg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*mileusnaviber.TextMessage)
+		}
+	}
+
+	return r0
+}
+
+// SendMessage provides a mock function with given fields: to, m
+func (_m *mockViberClient) SendMessage(to string, m mileusnaviber.Message) (uint64, error) {
+	ret := _m.Called(to, m)
+
+	var r0 uint64
+	if rf, ok := ret.Get(0).(func(string, mileusnaviber.Message) uint64); ok {
+		r0 = rf(to, m)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, mileusnaviber.Message) error); ok {
+		r1 = rf(to, m)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SendTextMessage provides a mock function with given fields: receiver, msg
 func (_m *mockViberClient) SendTextMessage(receiver string, msg string) (uint64, error) {
 	ret := _m.Called(receiver, msg)
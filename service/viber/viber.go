@@ -5,12 +5,16 @@ import (
 
 	vb "github.com/mileusna/viber"
 	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
 )
 
 //go:generate mockery --name=viberClient --output=. --case=underscore --inpackage
 type viberClient interface {
 	SetWebhook(url string, eventTypes []string) (vb.WebhookResp, error)
 	SendTextMessage(receiver, msg string) (uint64, error)
+	NewTextMessage(msg string) *vb.TextMessage
+	SendMessage(to string, m vb.Message) (uint64, error)
 }
 
 // Compile-time check to ensure that vb.Viber implements the viberClient interface.
@@ -20,6 +24,7 @@ var _ viberClient = new(vb.Viber)
 type Viber struct {
 	Client            viberClient
 	SubscribedUserIDs []string
+	trackingData      string
 }
 
 // New returns a new instance of Viber notification service
@@ -41,6 +46,24 @@ func (v *Viber) SetWebhook(webhookURL string) error {
 	return err
 }
 
+// SetTrackingData sets data that is attached to every message sent. Viber sends it back to your webhook along with
+// the user's reply, which allows you to track the conversation.
+func (v *Viber) SetTrackingData(trackingData string) {
+	v.trackingData = trackingData
+}
+
+// sendTextMessage sends a text message to a single user, attaching the tracking data if any was set.
+func (v *Viber) sendTextMessage(receiver, msg string) (uint64, error) {
+	if v.trackingData == "" {
+		return v.Client.SendTextMessage(receiver, msg)
+	}
+
+	textMessage := v.Client.NewTextMessage(msg)
+	textMessage.TrackingData = v.trackingData
+
+	return v.Client.SendMessage(receiver, textMessage)
+}
+
 // Send takes a message subject and a message body and sends them to all previously set userIds
 func (v *Viber) Send(ctx context.Context, subject, message string) error {
 	fullMessage := subject + "\n" + message // Treating subject as message title
@@ -50,12 +73,34 @@ func (v *Viber) Send(ctx context.Context, subject, message string) error {
 		case <-ctx.Done():
 			return ctx.Err()
 		default:
-			_, err := v.Client.SendTextMessage(subscribedUserID, fullMessage)
+			_, err := v.sendTextMessage(subscribedUserID, fullMessage)
 			if err != nil {
-				return errors.Wrapf(err, "failed to send message to User ID '%s'", subscribedUserID)
+				return classifyError(errors.Wrapf(err, "failed to send message to User ID '%s'", subscribedUserID))
 			}
 		}
 	}
 
 	return nil
 }
+
+// classifyError classifies errors returned by the Viber API by their status, see notify.ClassifyError and
+// https://developers.viber.com/docs/api/rest-bot-api/#error-codes.
+func classifyError(err error) error {
+	var apiErr vb.Error
+	if !errors.As(err, &apiErr) {
+		return notify.ClassifyNetworkError(err)
+	}
+
+	switch apiErr.Status {
+	case 2, 7, 8, 9:
+		// Invalid auth token, or the account is blocked, not found or suspended.
+		return notify.ClassifyError(err, notify.ErrAuthFailed)
+	case 5, 6, 11:
+		// The receiver isn't registered or subscribed, or has no suitable device.
+		return notify.ClassifyError(err, notify.ErrInvalidReceiver)
+	case 12:
+		return notify.ClassifyError(err, &notify.ErrRateLimited{})
+	default:
+		return notify.ClassifyError(err, notify.ErrPermanent)
+	}
+}
@@ -101,3 +101,24 @@ func TestViber_Send(t *testing.T) {
 	assert.Nil(err)
 	viberMock.AssertExpectations(t)
 }
+
+func TestViber_SendWithTrackingData(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+	viber := New("appkey", "senderName", "senderAvatar")
+	viber.AddReceivers("receiver1")
+	viber.SetTrackingData("incident-42")
+
+	viberMock := newMockViberClient(t)
+	viberMock.
+		On("NewTextMessage", "subject\nmessage").
+		Return(&vb.TextMessage{Text: "subject\nmessage"})
+	viberMock.
+		On("SendMessage", "receiver1", &vb.TextMessage{Text: "subject\nmessage", TrackingData: "incident-42"}).
+		Return(uint64(0), nil)
+
+	viber.Client = viberMock
+	err := viber.Send(context.Background(), "subject", "message")
+	assert.Nil(err)
+	viberMock.AssertExpectations(t)
+}
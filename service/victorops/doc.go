@@ -0,0 +1,37 @@
+/*
+Package victorops provides a service for sending alerts to Splunk On-Call (formerly VictorOps) through its REST
+integration.
+
+Usage:
+
+	package main
+
+	import (
+	    "context"
+	    "log"
+
+	    "github.com/nikoksr/notify"
+	    "github.com/nikoksr/notify/service/victorops"
+	)
+
+	func main() {
+	    // Create a victorops service using the key of your REST integration.
+	    victoropsService := victorops.New("your-api-key")
+
+	    // Add the routing keys the alerts should be sent to.
+	    victoropsService.AddReceivers("ops-team")
+
+	    // Configure the behavior of the alert. Alerts with the same entity ID belong to the same incident.
+	    victoropsService.SetMessageType(victorops.MessageTypeWarning)
+	    victoropsService.SetEntityID("api-01/latency")
+
+	    // Tell our notifier to use the victorops service.
+	    notify.UseServices(victoropsService)
+
+	    // Send an alert.
+	    if err := notify.Send(context.Background(), "High latency", "p99 latency is above 2s"); err != nil {
+	        log.Fatal(err)
+	    }
+	}
+*/
+package victorops
@@ -0,0 +1,189 @@
+package victorops
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
+)
+
+// DefaultBaseURL is the default base URL of the Splunk On-Call REST integration endpoint.
+const DefaultBaseURL = "https://alert.victorops.com/integrations/generic/20131114/alert"
+
+// MessageType represents the behavior of an alert sent to Splunk On-Call.
+type MessageType string
+
+// All message types supported by the REST integration.
+const (
+	MessageTypeCritical        MessageType = "CRITICAL"
+	MessageTypeWarning         MessageType = "WARNING"
+	MessageTypeInfo            MessageType = "INFO"
+	MessageTypeAcknowledgement MessageType = "ACKNOWLEDGEMENT"
+	MessageTypeRecovery        MessageType = "RECOVERY"
+)
+
+// messageTypes maps the priorities of notify.WithPriority to Splunk On-Call message types.
+var messageTypes = map[notify.Priority]MessageType{
+	notify.PriorityLow:      MessageTypeInfo,
+	notify.PriorityNormal:   MessageTypeWarning,
+	notify.PriorityHigh:     MessageTypeCritical,
+	notify.PriorityCritical: MessageTypeCritical,
+}
+
+// Service allow you to configure the Splunk On-Call (formerly VictorOps) service.
+type Service struct {
+	client      *http.Client
+	baseURL     string
+	apiKey      string
+	routingKeys []string
+
+	messageType MessageType
+	entityID    string
+}
+
+func defaultHTTPClient() *http.Client {
+	return &http.Client{
+		Transport: notify.NewRateLimitTransport(nil),
+		Timeout:   10 * time.Second,
+	}
+}
+
+// New returns a new instance of a Splunk On-Call notification service. The apiKey is the key of your REST
+// integration.
+// For more information about the REST integration:
+//
+//	-> https://help.victorops.com/knowledge-base/rest-endpoint-integration-guide/
+func New(apiKey string) *Service {
+	return &Service{
+		client:      defaultHTTPClient(),
+		baseURL:     DefaultBaseURL,
+		apiKey:      apiKey,
+		routingKeys: []string{},
+		messageType: MessageTypeCritical,
+	}
+}
+
+// WithClient sets the http client to be used for sending requests. Calling this method is optional, the default client
+// will be used if this method is not called.
+func (s *Service) WithClient(client *http.Client) {
+	if client != nil {
+		s.client = client
+	}
+}
+
+// AddReceivers takes routing keys and adds them to the internal routing key list. The Send method will send an alert
+// to every one of those routing keys.
+func (s *Service) AddReceivers(routingKeys ...string) {
+	s.routingKeys = append(s.routingKeys, routingKeys...)
+}
+
+// SetMessageType sets the message type of alerts sent by this service. Use MessageTypeRecovery together with the
+// entity ID of an open incident to resolve it. The priority of a notification, see notify.WithPriority, overrides the
+// types critical, warning and info: low is info, normal warning, high and critical critical. Acknowledgements and
+// recoveries are kept.
+func (s *Service) SetMessageType(messageType MessageType) {
+	s.messageType = messageType
+}
+
+// SetEntityID sets the entity ID of all alerts sent by this service. Alerts sharing the same entity ID belong to the
+// same incident. If left empty, the subject is used as entity ID.
+func (s *Service) SetEntityID(entityID string) {
+	s.entityID = entityID
+}
+
+// Validate checks that the API key is set, that the base URL is well-formed and that at least one routing key is set
+// and none is empty, see notify.Validator.
+func (s *Service) Validate() error {
+	var problems notify.ConfigErrors
+	problems.Require("API key", s.apiKey)
+	problems.Check("base URL", notify.ValidateURL(s.baseURL))
+	if len(s.routingKeys) == 0 {
+		problems.Addf("no routing key set")
+	}
+	for _, routingKey := range s.routingKeys {
+		if routingKey == "" {
+			problems.Addf("routing key is empty")
+		}
+	}
+
+	return problems.Err()
+}
+
+// alert is the request body expected by the REST integration.
+type alert struct {
+	MessageType       MessageType `json:"message_type"`
+	EntityID          string      `json:"entity_id,omitempty"`
+	EntityDisplayName string      `json:"entity_display_name,omitempty"`
+	StateMessage      string      `json:"state_message,omitempty"`
+	MonitoringTool    string      `json:"monitoring_tool,omitempty"`
+}
+
+func (s *Service) send(ctx context.Context, routingKey string, body []byte) error {
+	endpoint := strings.TrimSuffix(s.baseURL, "/") + "/" + url.PathEscape(s.apiKey) + "/" + url.PathEscape(routingKey)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "create request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "send request")
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		result, _ := io.ReadAll(resp.Body)
+		return notify.ClassifyResponse(resp, fmt.Errorf("splunk on-call returned status code %d: %s", resp.StatusCode, string(result)))
+	}
+
+	return nil
+}
+
+// Send takes a message subject and a message body and sends them as alert to all previously set routing keys.
+func (s *Service) Send(ctx context.Context, subject, message string) error {
+	entityID := s.entityID
+	if entityID == "" {
+		entityID = subject
+	}
+	messageType := s.messageType
+	if messageType != MessageTypeAcknowledgement && messageType != MessageTypeRecovery {
+		if mapped, ok := messageTypes[notify.SendOptionsFromContext(ctx).Priority]; ok {
+			messageType = mapped
+		}
+	}
+
+	body, err := json.Marshal(&alert{
+		MessageType:       messageType,
+		EntityID:          entityID,
+		EntityDisplayName: subject,
+		StateMessage:      message,
+		MonitoringTool:    "notify",
+	})
+	if err != nil {
+		return errors.Wrap(err, "marshal alert")
+	}
+
+	for _, routingKey := range s.routingKeys {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			if err = s.send(ctx, routingKey, body); err != nil {
+				return errors.Wrapf(err, "failed to send alert to Splunk On-Call routing key %q", routingKey)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,90 @@
+package victorops
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nikoksr/notify"
+)
+
+func TestVictorOps_New(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := New("key")
+	assert.NotNil(service)
+	assert.Equal(MessageTypeCritical, service.messageType)
+
+	service.AddReceivers("a", "b")
+	assert.Equal([]string{"a", "b"}, service.routingKeys)
+}
+
+func TestVictorOps_Validate(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := New("key")
+	service.AddReceivers("ops")
+	assert.NoError(service.Validate())
+
+	err := New("").Validate()
+	assert.ErrorIs(err, notify.ErrInvalidConfig)
+	assert.ErrorContains(err, "API key is missing")
+	assert.ErrorContains(err, "no routing key set")
+
+	service = New("key")
+	service.AddReceivers("")
+	assert.ErrorContains(service.Validate(), "routing key is empty")
+}
+
+func TestVictorOps_Send(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	var (
+		gotPath  string
+		gotAlert alert
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/key/invalid" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		gotPath = r.URL.Path
+		_ = json.NewDecoder(r.Body).Decode(&gotAlert)
+	}))
+	defer server.Close()
+
+	service := New("key")
+	service.baseURL = server.URL
+	service.SetMessageType(MessageTypeRecovery)
+	service.AddReceivers("ops")
+
+	err := service.Send(context.Background(), "subject", "message")
+	assert.Nil(err)
+	assert.Equal("/key/ops", gotPath)
+	assert.Equal(MessageTypeRecovery, gotAlert.MessageType)
+	assert.Equal("subject", gotAlert.EntityID)
+	assert.Equal("message", gotAlert.StateMessage)
+
+	// The priority of the notification overrides alert types, but not recoveries.
+	ctx := notify.ContextWithSendOptions(context.Background(), notify.WithPriority(notify.PriorityLow))
+	assert.Nil(service.Send(ctx, "subject", "message"))
+	assert.Equal(MessageTypeRecovery, gotAlert.MessageType)
+	service.SetMessageType(MessageTypeCritical)
+	assert.Nil(service.Send(ctx, "subject", "message"))
+	assert.Equal(MessageTypeInfo, gotAlert.MessageType)
+
+	// Test error response
+	service.AddReceivers("invalid")
+	err = service.Send(context.Background(), "subject", "message")
+	assert.NotNil(err)
+}
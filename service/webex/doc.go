@@ -0,0 +1,33 @@
+/*
+Package webex provides a service for sending messages to Webex rooms and people.
+
+Usage:
+
+	package main
+
+	import (
+	    "context"
+	    "log"
+
+	    "github.com/nikoksr/notify"
+	    "github.com/nikoksr/notify/service/webex"
+	)
+
+	func main() {
+	    webexService := webex.New("your-bot-token")
+
+	    // Send to these rooms and people.
+	    webexService.AddReceivers("Y2lzY29zcGFyazovL3VzL1JPT00v...")
+	    webexService.AddPersonReceivers("jane.doe@example.com")
+	    webexService.UseMarkdown(true)
+
+	    // Tell our notifier to use the webex service.
+	    notify.UseServices(webexService)
+
+	    // Send a test message.
+	    if err := notify.Send(context.Background(), "Subject/Title", "The actual message"); err != nil {
+	        log.Fatal(err)
+	    }
+	}
+*/
+package webex
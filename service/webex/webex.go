@@ -0,0 +1,177 @@
+package webex
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
+)
+
+const defaultAPIURL = "https://webexapis.com/v1/messages"
+
+// Service encapsulates the Webex client.
+type Service struct {
+	client      *http.Client
+	apiURL      string
+	token       string
+	useMarkdown bool
+	roomIDs     []string
+	people      []string
+}
+
+// New returns a new instance of a Webex notification service. The token is the access token of a bot, which can be
+// created at https://developer.webex.com/my-apps/new/bot.
+// For more information about the messages API:
+//
+//	-> https://developer.webex.com/docs/api/v1/messages/create-a-message
+func New(token string) *Service {
+	return &Service{
+		client: &http.Client{
+			Transport: notify.NewRateLimitTransport(nil),
+			Timeout:   10 * time.Second,
+		},
+		apiURL:  defaultAPIURL,
+		token:   token,
+		roomIDs: []string{},
+		people:  []string{},
+	}
+}
+
+// WithClient sets the http client to be used for sending requests. Calling this method is optional, the default client
+// will be used if this method is not called.
+func (s *Service) WithClient(client *http.Client) {
+	if client != nil {
+		s.client = client
+	}
+}
+
+// AddReceivers takes room IDs and adds them to the internal receiver list. The bot must be a member of the rooms.
+func (s *Service) AddReceivers(roomIDs ...string) {
+	s.roomIDs = append(s.roomIDs, roomIDs...)
+}
+
+// AddPersonReceivers takes email addresses of people and adds them to the internal receiver list. Every one of them
+// receives the messages in a direct conversation with the bot.
+func (s *Service) AddPersonReceivers(emails ...string) {
+	s.people = append(s.people, emails...)
+}
+
+// UseMarkdown sends the message as markdown, with the subject formatted bold. Messages are sent as plain text by
+// default.
+func (s *Service) UseMarkdown(markdown bool) {
+	s.useMarkdown = markdown
+}
+
+type webexMessage struct {
+	RoomID        string `json:"roomId,omitempty"`
+	ToPersonEmail string `json:"toPersonEmail,omitempty"`
+	Text          string `json:"text,omitempty"`
+	Markdown      string `json:"markdown,omitempty"`
+}
+
+// Validate checks that the token is set, that at least one room or person is set and that the email addresses of the
+// people are well-formed, see notify.Validator.
+func (s *Service) Validate() error {
+	var problems notify.ConfigErrors
+	problems.Require("token", s.token)
+	if len(s.roomIDs) == 0 && len(s.people) == 0 {
+		problems.Addf("no room or person set")
+	}
+	for _, roomID := range s.roomIDs {
+		if roomID == "" {
+			problems.Addf("room ID is empty")
+		}
+	}
+	for _, person := range s.people {
+		problems.Check("person", notify.ValidateMailAddress(person))
+	}
+
+	return problems.Err()
+}
+
+// Sanitize escapes the markdown of the message if the service sends markdown, so that user provided content can neither
+// format the message nor mention people, see notify.Sanitizer. Plain text messages only have control characters
+// removed.
+func (s *Service) Sanitize(message notify.Message) notify.Message {
+	if s.useMarkdown {
+		return notify.SanitizeMessage(message, notify.EscapeMarkdown)
+	}
+
+	return notify.SanitizeMessage(message, notify.StripControlChars)
+}
+
+// Send takes a message subject and a message body and sends them to all previously set rooms and people.
+func (s *Service) Send(ctx context.Context, subject, message string) error {
+	// Clients without markdown support display the text instead.
+	content := webexMessage{Text: subject + "\n\n" + message}
+	if s.useMarkdown {
+		content.Markdown = "**" + subject + "**\n\n" + message
+	}
+
+	var messages []webexMessage
+	for _, roomID := range s.roomIDs {
+		msg := content
+		msg.RoomID = roomID
+		messages = append(messages, msg)
+	}
+	for _, email := range s.people {
+		msg := content
+		msg.ToPersonEmail = email
+		messages = append(messages, msg)
+	}
+
+	for _, msg := range messages {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			if err := s.post(ctx, msg); err != nil {
+				return errors.Wrapf(err, "failed to send message to %q", msg.RoomID+msg.ToPersonEmail)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) post(ctx context.Context, msg webexMessage) error {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return errors.Wrap(err, "marshal message")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.apiURL, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "create request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+s.token)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "send request")
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		b, _ := io.ReadAll(resp.Body)
+
+		var errResp struct {
+			Message string `json:"message"`
+		}
+		if json.Unmarshal(b, &errResp) == nil && errResp.Message != "" {
+			return notify.ClassifyResponse(resp, fmt.Errorf("webex returned status code %d: %s", resp.StatusCode, errResp.Message))
+		}
+
+		return notify.ClassifyResponse(resp, fmt.Errorf("webex returned status code %d: %s", resp.StatusCode, string(b)))
+	}
+
+	return nil
+}
@@ -0,0 +1,88 @@
+package webex
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nikoksr/notify"
+)
+
+func TestWebex_Validate(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := New("token")
+	service.AddReceivers("room")
+	service.AddPersonReceivers("jane@example.com")
+	assert.NoError(service.Validate())
+
+	err := New("").Validate()
+	assert.ErrorIs(err, notify.ErrInvalidConfig)
+	assert.ErrorContains(err, "token is missing")
+	assert.ErrorContains(err, "no room or person set")
+
+	service.AddReceivers("")
+	service.AddPersonReceivers("jane")
+	err = service.Validate()
+	assert.ErrorContains(err, "room ID is empty")
+	assert.ErrorContains(err, "person: mail address")
+}
+
+func TestWebex_Sanitize(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := New("token")
+	message := notify.Message{Body: "<@all> **down**"}
+	assert.Equal("<@all> **down**", service.Sanitize(message).Body)
+
+	service.UseMarkdown(true)
+	assert.Equal(`\<@all\> \*\*down\*\*`, service.Sanitize(message).Body)
+}
+
+func TestWebex_Send(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	var got []webexMessage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"message":"The request requires a valid access token set in the Authorization request header."}`))
+			return
+		}
+		var msg webexMessage
+		_ = json.NewDecoder(r.Body).Decode(&msg)
+		got = append(got, msg)
+		_, _ = w.Write([]byte(`{"id":"1"}`))
+	}))
+	defer server.Close()
+
+	service := New("token")
+	service.apiURL = server.URL
+	service.AddReceivers("room")
+	service.AddPersonReceivers("jane@example.com")
+
+	assert.NoError(service.Send(context.Background(), "subject", "message"))
+	service.UseMarkdown(true)
+	assert.NoError(service.Send(context.Background(), "subject", "message"))
+
+	assert.Equal([]webexMessage{
+		{RoomID: "room", Text: "subject\n\nmessage"},
+		{ToPersonEmail: "jane@example.com", Text: "subject\n\nmessage"},
+		{RoomID: "room", Text: "subject\n\nmessage", Markdown: "**subject**\n\nmessage"},
+		{ToPersonEmail: "jane@example.com", Text: "subject\n\nmessage", Markdown: "**subject**\n\nmessage"},
+	}, got)
+
+	// Test error response
+	service.token = "invalid"
+	assert.ErrorContains(service.Send(context.Background(), "subject", "message"), "valid access token")
+}
@@ -9,6 +9,8 @@ import (
 
 	"github.com/SherClockHolmes/webpush-go"
 	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
 )
 
 type (
@@ -140,7 +142,8 @@ func payloadFromContext(ctx context.Context, subject, message string) ([]byte, e
 func (s *Service) send(ctx context.Context, message []byte, subscription *Subscription, options *Options) error {
 	res, err := webpush.SendNotificationWithContext(ctx, message, subscription, options)
 	if err != nil {
-		return errors.Wrapf(err, "failed to send messagePayload to webpush subscription %s", subscription.Endpoint)
+		err = errors.Wrapf(err, "failed to send messagePayload to webpush subscription %s", subscription.Endpoint)
+		return notify.ClassifyNetworkError(err)
 	}
 	defer res.Body.Close()
 
@@ -162,7 +165,8 @@ func (s *Service) send(ctx context.Context, message []byte, subscription *Subscr
 		baseErr = fmt.Errorf("%w: %s", baseErr, body)
 	}
 
-	return baseErr
+	// Push services answer 404 and 410 for expired subscriptions, which are invalid receivers.
+	return notify.ClassifyResponse(res, baseErr)
 }
 
 // Send sends a message to all the webpush subscriptions that have been added to the Service. The subject and message
@@ -14,6 +14,8 @@ import (
 	"github.com/silenceper/wechat/v2/officialaccount/config"
 	"github.com/silenceper/wechat/v2/officialaccount/message"
 	"github.com/silenceper/wechat/v2/util"
+
+	"github.com/nikoksr/notify"
 )
 
 type verificationCallbackFunc func(r *http.Request, verified bool)
@@ -169,10 +171,35 @@ func (s *Service) Send(ctx context.Context, subject, content string) error {
 			text := fmt.Sprintf("%s\n%s", subject, content)
 			err := s.messageManager.Send(message.NewCustomerTextMessage(userID, text))
 			if err != nil {
-				return errors.Wrapf(err, "failed to send message to WeChat user '%s'", userID)
+				return classifyError(errors.Wrapf(err, "failed to send message to WeChat user '%s'", userID))
 			}
 		}
 	}
 
 	return nil
 }
+
+// classifyError classifies errors returned by the WeChat API by their error code, see notify.ClassifyError and
+// https://developers.weixin.qq.com/doc/offiaccount/en/Getting_Started/Global_Return_Code.html.
+func classifyError(err error) error {
+	var apiErr *util.CommonError
+	if !errors.As(err, &apiErr) {
+		return notify.ClassifyNetworkError(err)
+	}
+
+	switch apiErr.ErrCode {
+	case -1:
+		// The system is busy.
+		return notify.ClassifyError(err, notify.ErrTransient)
+	case 40001, 40014, 42001:
+		// Invalid or expired access token.
+		return notify.ClassifyError(err, notify.ErrAuthFailed)
+	case 45009, 45047:
+		return notify.ClassifyError(err, &notify.ErrRateLimited{})
+	case 40003, 45015:
+		// Unknown user, or the user hasn't interacted with the account in the last 48 hours.
+		return notify.ClassifyError(err, notify.ErrInvalidReceiver)
+	default:
+		return notify.ClassifyError(err, notify.ErrPermanent)
+	}
+}
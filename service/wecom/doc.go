@@ -0,0 +1,36 @@
+/*
+Package wecom provides a service for sending messages to WeCom (WeChat Work) group robots and application users.
+
+Usage:
+
+	package main
+
+	import (
+	    "context"
+	    "log"
+
+	    "github.com/nikoksr/notify"
+	    "github.com/nikoksr/notify/service/wecom"
+	)
+
+	func main() {
+	    // Post to a group robot, identified by the key of its webhook URL. Receivers are mentioned in the group.
+	    robotService := wecom.NewWithWebhook("your-webhook-key")
+	    robotService.AddReceivers("zhangsan")
+
+	    // Or send application messages to users and departments. Access tokens are requested and cached automatically.
+	    appService := wecom.NewWithApp("your-corp-id", "your-app-secret", 1000002)
+	    appService.AddReceivers("zhangsan", "lisi")
+	    appService.AddParties("2")
+	    appService.UseMarkdown(true)
+
+	    // Tell our notifier to use the wecom services.
+	    notify.UseServices(robotService, appService)
+
+	    // Send a test message.
+	    if err := notify.Send(context.Background(), "Subject/Title", "The actual message"); err != nil {
+	        log.Fatal(err)
+	    }
+	}
+*/
+package wecom
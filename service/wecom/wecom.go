@@ -0,0 +1,323 @@
+package wecom
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
+)
+
+// DefaultAPIURL is the base URL of the WeCom server API.
+const DefaultAPIURL = "https://qyapi.weixin.qq.com/cgi-bin"
+
+// Error codes returned by the API if the access token is invalid or expired.
+const (
+	errCodeInvalidToken = 40014
+	errCodeExpiredToken = 42001
+)
+
+// tokenExpiryMargin is subtracted from the token lifetime to avoid using tokens that are about to expire.
+const tokenExpiryMargin = 5 * time.Minute
+
+// Service encapsulates the WeCom client. It either posts to a group robot webhook or sends application messages.
+type Service struct {
+	client   *http.Client
+	apiURL   string
+	markdown bool
+
+	// Group robot
+	webhookKey string
+	mentioned  []string
+
+	// Application messages
+	corpID     string
+	corpSecret string
+	agentID    int64
+	users      []string
+	parties    []string
+
+	mu          sync.Mutex
+	accessToken string
+	expiresAt   time.Time
+}
+
+func defaultHTTPClient() *http.Client {
+	return &http.Client{
+		Transport: notify.NewRateLimitTransport(nil),
+		Timeout:   10 * time.Second,
+	}
+}
+
+// NewWithWebhook returns a new instance of a WeCom notification service that posts to the group robot identified by the
+// given webhook key, which is the "key" query parameter of the robot's webhook URL.
+// For more information about group robots:
+//
+//	-> https://developer.work.weixin.qq.com/document/path/91770
+func NewWithWebhook(key string) *Service {
+	return &Service{
+		client:     defaultHTTPClient(),
+		apiURL:     DefaultAPIURL,
+		webhookKey: key,
+	}
+}
+
+// NewWithApp returns a new instance of a WeCom notification service that sends application messages on behalf of the
+// application identified by agentID. Access tokens are requested using the corp ID and the application's secret and are
+// cached until they expire.
+// For more information about application messages:
+//
+//	-> https://developer.work.weixin.qq.com/document/path/90236
+func NewWithApp(corpID, corpSecret string, agentID int64) *Service {
+	return &Service{
+		client:     defaultHTTPClient(),
+		apiURL:     DefaultAPIURL,
+		corpID:     corpID,
+		corpSecret: corpSecret,
+		agentID:    agentID,
+		users:      []string{},
+		parties:    []string{},
+	}
+}
+
+// WithClient sets the http client to be used for sending requests. Calling this method is optional, the default client
+// will be used if this method is not called.
+func (s *Service) WithClient(client *http.Client) {
+	if client != nil {
+		s.client = client
+	}
+}
+
+// AddReceivers takes user IDs and adds them to the internal receiver list. In application mode, the message is sent to
+// all of those users; "@all" sends it to everyone in the application's visible range. In group robot mode, the users
+// get mentioned in the group instead.
+func (s *Service) AddReceivers(userIDs ...string) {
+	if s.webhookKey != "" {
+		s.mentioned = append(s.mentioned, userIDs...)
+		return
+	}
+	s.users = append(s.users, userIDs...)
+}
+
+// AddParties takes department IDs and adds them to the internal party list. Only used in application mode.
+func (s *Service) AddParties(partyIDs ...string) {
+	s.parties = append(s.parties, partyIDs...)
+}
+
+// UseMarkdown tells the service to send markdown messages, with the subject rendered as heading.
+func (s *Service) UseMarkdown(enabled bool) {
+	s.markdown = enabled
+}
+
+type (
+	textContent struct {
+		Content       string   `json:"content"`
+		MentionedList []string `json:"mentioned_list,omitempty"`
+	}
+
+	markdownContent struct {
+		Content string `json:"content"`
+	}
+
+	// message is the request body shared by robot and application messages.
+	message struct {
+		ToUser   string           `json:"touser,omitempty"`
+		ToParty  string           `json:"toparty,omitempty"`
+		AgentID  int64            `json:"agentid,omitempty"`
+		MsgType  string           `json:"msgtype"`
+		Text     *textContent     `json:"text,omitempty"`
+		Markdown *markdownContent `json:"markdown,omitempty"`
+	}
+
+	// response is the common part of all API responses.
+	response struct {
+		ErrCode     int    `json:"errcode"`
+		ErrMsg      string `json:"errmsg"`
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int64  `json:"expires_in"`
+	}
+)
+
+// newMessage builds the message for the given subject and body.
+func (s *Service) newMessage(subject, body string) *message {
+	msg := &message{MsgType: "text"}
+
+	if s.markdown {
+		msg.MsgType = "markdown"
+		content := "### " + subject + "\n" + body
+		for _, userID := range s.mentioned {
+			content += "\n<@" + userID + ">"
+		}
+		msg.Markdown = &markdownContent{Content: content}
+	} else {
+		msg.Text = &textContent{Content: subject + "\n" + body, MentionedList: s.mentioned}
+	}
+
+	if s.webhookKey == "" {
+		msg.ToUser = strings.Join(s.users, "|")
+		msg.ToParty = strings.Join(s.parties, "|")
+		msg.AgentID = s.agentID
+	}
+
+	return msg
+}
+
+// do sends the given request and decodes the response, returning an error for non-zero error codes.
+func (s *Service) do(req *http.Request) (*response, error) {
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "send request")
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "read response")
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, notify.ClassifyResponse(resp, fmt.Errorf("wecom returned status code %d: %s", resp.StatusCode, string(body)))
+	}
+
+	r := new(response)
+	if err = json.Unmarshal(body, r); err != nil {
+		return nil, errors.Wrap(err, "decode response")
+	}
+
+	return r, nil
+}
+
+// token returns a cached access token or requests a new one if there is none or it expired.
+func (s *Service) token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.accessToken != "" && time.Now().Before(s.expiresAt) {
+		return s.accessToken, nil
+	}
+
+	query := url.Values{"corpid": {s.corpID}, "corpsecret": {s.corpSecret}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.apiURL+"/gettoken?"+query.Encode(), http.NoBody)
+	if err != nil {
+		return "", errors.Wrap(err, "create request")
+	}
+
+	r, err := s.do(req)
+	if err != nil {
+		return "", err
+	}
+	if r.ErrCode != 0 {
+		return "", fmt.Errorf("wecom returned error code %d: %s", r.ErrCode, r.ErrMsg)
+	}
+
+	s.accessToken = r.AccessToken
+	s.expiresAt = time.Now().Add(time.Duration(r.ExpiresIn)*time.Second - tokenExpiryMargin)
+
+	return s.accessToken, nil
+}
+
+// invalidateToken drops the cached access token, forcing a new one to be requested.
+func (s *Service) invalidateToken() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.accessToken = ""
+}
+
+func (s *Service) post(ctx context.Context, endpoint string, payload []byte) (*response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return nil, errors.Wrap(err, "create request")
+	}
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+
+	return s.do(req)
+}
+
+// sendAppMessage sends an application message, retrying once with a fresh token if the cached one was rejected.
+func (s *Service) sendAppMessage(ctx context.Context, payload []byte) error {
+	for attempt := 0; ; attempt++ {
+		token, err := s.token(ctx)
+		if err != nil {
+			return errors.Wrap(err, "get access token")
+		}
+
+		r, err := s.post(ctx, s.apiURL+"/message/send?access_token="+url.QueryEscape(token), payload)
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case r.ErrCode == 0:
+			return nil
+		case (r.ErrCode == errCodeInvalidToken || r.ErrCode == errCodeExpiredToken) && attempt == 0:
+			s.invalidateToken()
+		default:
+			return fmt.Errorf("wecom returned error code %d: %s", r.ErrCode, r.ErrMsg)
+		}
+	}
+}
+
+// Validate checks that the webhook key or the application's credentials are set and, in application mode, that at
+// least one user or party is set, see notify.Validator.
+func (s *Service) Validate() error {
+	var problems notify.ConfigErrors
+	problems.Check("API URL", notify.ValidateURL(s.apiURL))
+	if s.webhookKey != "" {
+		return problems.Err()
+	}
+
+	problems.Require("corp ID", s.corpID)
+	problems.Require("corp secret", s.corpSecret)
+	if s.agentID <= 0 {
+		problems.Addf("agent ID must be positive")
+	}
+	if len(s.users) == 0 && len(s.parties) == 0 {
+		problems.Addf("no user or party set")
+	}
+	for _, user := range s.users {
+		if user == "" {
+			problems.Addf("user ID is empty")
+		}
+	}
+
+	return problems.Err()
+}
+
+// Send takes a message subject and a message body and sends them to the group robot or to all previously set users
+// and parties.
+func (s *Service) Send(ctx context.Context, subject, body string) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+
+	if s.webhookKey == "" && len(s.users) == 0 && len(s.parties) == 0 {
+		return nil
+	}
+
+	payload, err := json.Marshal(s.newMessage(subject, body))
+	if err != nil {
+		return errors.Wrap(err, "marshal message")
+	}
+
+	if s.webhookKey == "" {
+		return errors.Wrap(s.sendAppMessage(ctx, payload), "failed to send application message")
+	}
+
+	r, err := s.post(ctx, s.apiURL+"/webhook/send?key="+url.QueryEscape(s.webhookKey), payload)
+	if err == nil && r.ErrCode != 0 {
+		err = fmt.Errorf("wecom returned error code %d: %s", r.ErrCode, r.ErrMsg)
+	}
+
+	return errors.Wrap(err, "failed to send message to group robot")
+}
@@ -0,0 +1,142 @@
+package wecom
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nikoksr/notify"
+)
+
+func TestWeCom_New(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	robot := NewWithWebhook("key")
+	robot.AddReceivers("zhangsan")
+	assert.Equal([]string{"zhangsan"}, robot.mentioned)
+	assert.Empty(robot.users)
+
+	app := NewWithApp("corp", "secret", 1)
+	app.AddReceivers("zhangsan", "lisi")
+	app.AddParties("2")
+	assert.Equal([]string{"zhangsan", "lisi"}, app.users)
+	assert.Equal([]string{"2"}, app.parties)
+}
+
+func TestWeCom_Validate(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	assert.NoError(NewWithWebhook("key").Validate())
+	service := NewWithApp("corp", "secret", 1000002)
+	service.AddReceivers("jane")
+	assert.NoError(service.Validate())
+
+	err := NewWithApp("", "", 0).Validate()
+	assert.ErrorIs(err, notify.ErrInvalidConfig)
+	assert.ErrorContains(err, "corp ID is missing")
+	assert.ErrorContains(err, "corp secret is missing")
+	assert.ErrorContains(err, "agent ID must be positive")
+	assert.ErrorContains(err, "no user or party set")
+
+	service.AddReceivers("")
+	assert.ErrorContains(service.Validate(), "user ID is empty")
+}
+
+func TestWeCom_SendWebhook(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	var got message
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("key") != "key" {
+			_, _ = w.Write([]byte(`{"errcode":93000,"errmsg":"invalid webhook url"}`))
+			return
+		}
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		_, _ = w.Write([]byte(`{"errcode":0,"errmsg":"ok"}`))
+	}))
+	defer server.Close()
+
+	service := NewWithWebhook("key")
+	service.apiURL = server.URL
+	service.AddReceivers("zhangsan")
+
+	err := service.Send(context.Background(), "subject", "body")
+	assert.Nil(err)
+	assert.Equal("text", got.MsgType)
+	assert.Equal("subject\nbody", got.Text.Content)
+	assert.Equal([]string{"zhangsan"}, got.Text.MentionedList)
+	assert.Zero(got.AgentID)
+
+	service.UseMarkdown(true)
+	err = service.Send(context.Background(), "subject", "body")
+	assert.Nil(err)
+	assert.Equal("### subject\nbody\n<@zhangsan>", got.Markdown.Content)
+
+	// Test error response
+	service = NewWithWebhook("wrong")
+	service.apiURL = server.URL
+	err = service.Send(context.Background(), "subject", "body")
+	assert.NotNil(err)
+}
+
+func TestWeCom_SendApp(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	var (
+		tokenRequests int32
+		got           message
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/gettoken":
+			n := atomic.AddInt32(&tokenRequests, 1)
+			if n == 1 {
+				_, _ = w.Write([]byte(`{"errcode":0,"access_token":"stale","expires_in":7200}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"errcode":0,"access_token":"fresh","expires_in":7200}`))
+		case "/message/send":
+			if r.URL.Query().Get("access_token") != "fresh" {
+				_, _ = w.Write([]byte(`{"errcode":42001,"errmsg":"access_token expired"}`))
+				return
+			}
+			_ = json.NewDecoder(r.Body).Decode(&got)
+			_, _ = w.Write([]byte(`{"errcode":0,"errmsg":"ok"}`))
+		}
+	}))
+	defer server.Close()
+
+	service := NewWithApp("corp", "secret", 1000002)
+	service.apiURL = server.URL
+
+	// No receivers added
+	err := service.Send(context.Background(), "subject", "body")
+	assert.Nil(err)
+	assert.Zero(atomic.LoadInt32(&tokenRequests))
+
+	// The stale token gets rejected, refreshed and the message resent
+	service.AddReceivers("zhangsan", "lisi")
+	err = service.Send(context.Background(), "subject", "body")
+	assert.Nil(err)
+	assert.Equal("zhangsan|lisi", got.ToUser)
+	assert.Equal(int64(1000002), got.AgentID)
+	assert.EqualValues(2, atomic.LoadInt32(&tokenRequests))
+
+	// The fresh token is cached
+	err = service.Send(context.Background(), "subject", "body")
+	assert.Nil(err)
+	assert.EqualValues(2, atomic.LoadInt32(&tokenRequests))
+}
@@ -1,8 +1,36 @@
 /*
-Package whatsapp is currently a [ NO-OP ] service! We're sorry for the inconveniences. We're already working on a fix.
+Package whatsapp provides a service for sending messages through Meta's WhatsApp Business Cloud API.
 
-Please read this [1] for more. Thanks!
+Usage:
 
-[1]:https://github.com/nikoksr/notify/issues/274
+	package main
+
+	import (
+	    "context"
+	    "log"
+
+	    "github.com/nikoksr/notify"
+	    "github.com/nikoksr/notify/service/whatsapp"
+	)
+
+	func main() {
+	    // Create a whatsapp service using the ID of your business phone number and an access token.
+	    whatsappService := whatsapp.New("your-phone-number-id", "your-access-token")
+
+	    // Add the phone numbers to send messages to.
+	    whatsappService.AddReceivers("+491234567890")
+
+	    // Free-form messages are only delivered within 24 hours after the recipient's last message. Use an approved
+	    // template whose body has two parameters, {{1}} for the subject and {{2}} for the message.
+	    whatsappService.UseTemplate("incident_alert", "en_US")
+
+	    // Tell our notifier to use the whatsapp service.
+	    notify.UseServices(whatsappService)
+
+	    // Send a test message.
+	    if err := notify.Send(context.Background(), "Subject/Title", "The actual message"); err != nil {
+	        log.Fatal(err)
+	    }
+	}
 */
 package whatsapp
@@ -1,25 +1,227 @@
 package whatsapp
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
 )
 
-// Service encapsulates the WhatsApp client along with internal state for storing contacts.
-type Service struct{}
+// DefaultGraphURL is the default base URL of Meta's Graph API, including the API version.
+const DefaultGraphURL = "https://graph.facebook.com/v17.0"
+
+// Service encapsulates the WhatsApp Cloud API client along with internal state for storing recipients.
+type Service struct {
+	client        *http.Client
+	graphURL      string
+	phoneNumberID string
+	accessToken   string
+	recipients    []string
+
+	templateName     string
+	templateLanguage string
+}
+
+func defaultHTTPClient() *http.Client {
+	return &http.Client{
+		Transport: notify.NewRateLimitTransport(nil),
+		Timeout:   10 * time.Second,
+	}
+}
+
+// New returns a new instance of a WhatsApp notification service. The phoneNumberID is the ID of the business phone
+// number messages are sent from and accessToken is a system user or temporary access token of your Meta app.
+// For more information about the WhatsApp Cloud API:
+//
+//	-> https://developers.facebook.com/docs/whatsapp/cloud-api/get-started
+//
+// New no longer returns an error and the QR code and session logins were removed, see the package README for how to
+// upgrade.
+func New(phoneNumberID, accessToken string) *Service {
+	return &Service{
+		client:        defaultHTTPClient(),
+		graphURL:      DefaultGraphURL,
+		phoneNumberID: phoneNumberID,
+		accessToken:   accessToken,
+		recipients:    []string{},
+	}
+}
+
+// WithClient sets the http client to be used for sending requests. Calling this method is optional, the default client
+// will be used if this method is not called.
+func (s *Service) WithClient(client *http.Client) {
+	if client != nil {
+		s.client = client
+	}
+}
+
+// AddReceivers takes phone numbers in international format and adds them to the internal recipient list. The Send
+// method will send a given message to all of those recipients.
+func (s *Service) AddReceivers(phoneNumbers ...string) {
+	for _, phoneNumber := range phoneNumbers {
+		s.recipients = append(s.recipients, strings.TrimPrefix(phoneNumber, "+"))
+	}
+}
+
+// UseTemplate tells the service to send template messages instead of free-form text messages. WhatsApp only accepts
+// free-form messages within 24 hours after the recipient last messaged you, so business-initiated notifications have
+// to use an approved template. The template's body is expected to have two parameters, {{1}} is set to the subject
+// and {{2}} to the message.
+func (s *Service) UseTemplate(name, languageCode string) {
+	s.templateName = name
+	s.templateLanguage = languageCode
+}
+
+type (
+	textObject struct {
+		Body       string `json:"body"`
+		PreviewURL bool   `json:"preview_url"`
+	}
+
+	templateParameter struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	}
+
+	templateComponent struct {
+		Type       string              `json:"type"`
+		Parameters []templateParameter `json:"parameters"`
+	}
+
+	templateLanguage struct {
+		Code string `json:"code"`
+	}
+
+	templateObject struct {
+		Name       string              `json:"name"`
+		Language   templateLanguage    `json:"language"`
+		Components []templateComponent `json:"components,omitempty"`
+	}
+
+	// message is the request body expected by the messages endpoint.
+	message struct {
+		MessagingProduct string          `json:"messaging_product"`
+		RecipientType    string          `json:"recipient_type"`
+		To               string          `json:"to"`
+		Type             string          `json:"type"`
+		Text             *textObject     `json:"text,omitempty"`
+		Template         *templateObject `json:"template,omitempty"`
+	}
+)
+
+// newMessage builds the message for the given recipient based on the service's settings. Template messages use the
+// given locale as language if set, converted to WhatsApp's format, e.g. "en-US" to "en_US".
+func (s *Service) newMessage(to, subject, body, locale string) *message {
+	msg := &message{
+		MessagingProduct: "whatsapp",
+		RecipientType:    "individual",
+		To:               to,
+	}
+
+	if s.templateName == "" {
+		text := body
+		if subject != "" {
+			text = "*" + subject + "*\n" + body
+		}
+		msg.Type = "text"
+		msg.Text = &textObject{Body: text}
+
+		return msg
+	}
+
+	language := s.templateLanguage
+	if locale != "" {
+		language = strings.ReplaceAll(locale, "-", "_")
+	}
+
+	msg.Type = "template"
+	msg.Template = &templateObject{
+		Name:     s.templateName,
+		Language: templateLanguage{Code: language},
+		Components: []templateComponent{{
+			Type: "body",
+			Parameters: []templateParameter{
+				{Type: "text", Text: subject},
+				{Type: "text", Text: body},
+			},
+		}},
+	}
+
+	return msg
+}
+
+func (s *Service) send(ctx context.Context, msg *message) error {
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return errors.Wrap(err, "marshal message")
+	}
+
+	endpoint := s.graphURL + "/" + s.phoneNumberID + "/messages"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return errors.Wrap(err, "create request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+s.accessToken)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "send request")
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		result, _ := io.ReadAll(resp.Body)
+		return notify.ClassifyResponse(resp, fmt.Errorf("whatsapp returned status code %d: %s", resp.StatusCode, string(result)))
+	}
+
+	return nil
+}
 
-// New returns a new instance of a WhatsApp notification service.
-func New() (*Service, error) { return &Service{}, nil }
+// Validate checks that phone number ID and access token are set, that the Graph API URL is well-formed and that the
+// recipients are phone numbers in international format, see notify.Validator.
+func (s *Service) Validate() error {
+	var problems notify.ConfigErrors
+	problems.Require("phone number ID", s.phoneNumberID)
+	problems.Require("access token", s.accessToken)
+	problems.Check("Graph API URL", notify.ValidateURL(s.graphURL))
+	for _, number := range s.recipients {
+		problems.Check("recipient", notify.ValidatePhoneNumber("+"+strings.TrimPrefix(number, "+")))
+	}
 
-// LoginWithSessionCredentials provides helper for authentication using whatsapp.Session credentials.
-func (s *Service) LoginWithSessionCredentials(_, _, _, _ string, _, _ []byte) error { return nil }
+	return problems.Err()
+}
 
-// LoginWithQRCode provides helper for authentication using QR code on terminal.
-// Refer: https://github.com/Rhymen/go-whatsapp#login for more information.
-func (s *Service) LoginWithQRCode() error { return nil }
+// ResolveContact returns the phone number of the contact, see notify.SendToContacts.
+func (s *Service) ResolveContact(contact notify.Contact) (string, bool) {
+	return contact.Phone, contact.Phone != ""
+}
 
-// AddReceivers takes WhatsApp contacts and adds them to the internal contacts list. The Send method will send
-// a given message to all those contacts.
-func (s *Service) AddReceivers(_ ...string) {}
+// Send takes a message subject and a message body and sends them to all previously set recipients. Template messages
+// are sent in the locale of the notification, see notify.ContextWithLocale, if set. The phone numbers can be overridden
+// per send, see notify.WithReceiversOverride.
+func (s *Service) Send(ctx context.Context, subject, body string) error {
+	recipients := notify.ReceiversFromContext(ctx, s.recipients)
+	locale, _ := notify.LocaleFromContext(ctx)
+	for _, recipient := range recipients {
+		recipient = strings.TrimPrefix(recipient, "+")
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			if err := s.send(ctx, s.newMessage(recipient, subject, body, locale)); err != nil {
+				return errors.Wrapf(err, "failed to send message to WhatsApp recipient %q", recipient)
+			}
+		}
+	}
 
-// Send takes a message subject and a message body and sends them to all previously set contacts.
-func (s *Service) Send(_ context.Context, _, _ string) error { return nil }
+	return nil
+}
@@ -0,0 +1,91 @@
+package whatsapp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nikoksr/notify"
+)
+
+func TestWhatsApp_New(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := New("phone-number-id", "token")
+	assert.NotNil(service)
+
+	service.AddReceivers("+491234567890", "15550001111")
+	assert.Equal([]string{"491234567890", "15550001111"}, service.recipients)
+}
+
+func TestWhatsApp_Validate(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := New("106540352242922", "token")
+	service.AddReceivers("4915112345678")
+	assert.NoError(service.Validate())
+
+	service = New("", "")
+	service.AddReceivers("0151 12345678")
+	err := service.Validate()
+	assert.ErrorIs(err, notify.ErrInvalidConfig)
+	assert.ErrorContains(err, "phone number ID is missing")
+	assert.ErrorContains(err, "access token is missing")
+	assert.ErrorContains(err, "recipient")
+}
+
+func TestWhatsApp_Send(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	var (
+		gotPath string
+		got     message
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		gotPath = r.URL.Path
+		_ = json.NewDecoder(r.Body).Decode(&got)
+	}))
+	defer server.Close()
+
+	service := New("12345", "token")
+	service.graphURL = server.URL
+	service.AddReceivers("+491234567890")
+
+	err := service.Send(context.Background(), "subject", "message")
+	assert.Nil(err)
+	assert.Equal("/12345/messages", gotPath)
+	assert.Equal("text", got.Type)
+	assert.Equal("*subject*\nmessage", got.Text.Body)
+
+	service.UseTemplate("alert", "en_US")
+	err = service.Send(context.Background(), "subject", "message")
+	assert.Nil(err)
+	assert.Equal("template", got.Type)
+	assert.Equal("alert", got.Template.Name)
+	assert.Equal("en_US", got.Template.Language.Code)
+	assert.Equal("subject", got.Template.Components[0].Parameters[0].Text)
+	assert.Equal("message", got.Template.Components[0].Parameters[1].Text)
+
+	err = service.Send(notify.ContextWithLocale(context.Background(), "de-DE"), "subject", "message")
+	assert.Nil(err)
+	assert.Equal("de_DE", got.Template.Language.Code)
+
+	// Test error response
+	service.accessToken = "wrong"
+	err = service.Send(context.Background(), "subject", "message")
+	assert.NotNil(err)
+}
@@ -0,0 +1,32 @@
+/*
+Package wirepusher provides a service for sending push notifications to Android devices via WirePusher.
+
+Usage:
+
+	package main
+
+	import (
+	    "context"
+	    "log"
+
+	    "github.com/nikoksr/notify"
+	    "github.com/nikoksr/notify/service/wirepusher"
+	)
+
+	func main() {
+	    wirePusherService := wirepusher.New()
+
+	    // Send to these devices.
+	    wirePusherService.AddReceivers("your-device-id")
+	    wirePusherService.SetType("alert")
+
+	    // Tell our notifier to use the wirepusher service.
+	    notify.UseServices(wirePusherService)
+
+	    // Send a test message.
+	    if err := notify.Send(context.Background(), "Subject/Title", "The actual message"); err != nil {
+	        log.Fatal(err)
+	    }
+	}
+*/
+package wirepusher
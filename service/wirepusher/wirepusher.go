@@ -0,0 +1,123 @@
+package wirepusher
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
+)
+
+const defaultAPIURL = "https://wirepusher.com/send"
+
+// Service encapsulates the WirePusher client.
+type Service struct {
+	client      *http.Client
+	apiURL      string
+	messageType string
+	action      string
+	imageURL    string
+	deviceIDs   []string
+}
+
+// New returns a new instance of a WirePusher notification service. WirePusher doesn't depend on Google services, so
+// it also works on Android devices without them.
+// For more information about the WirePusher API:
+//
+//	-> https://wirepusher.com/#api
+func New() *Service {
+	return &Service{
+		client: &http.Client{
+			Transport: notify.NewRateLimitTransport(nil),
+			Timeout:   10 * time.Second,
+		},
+		apiURL:    defaultAPIURL,
+		deviceIDs: []string{},
+	}
+}
+
+// WithClient sets the http client to be used for sending requests. Calling this method is optional, the default client
+// will be used if this method is not called.
+func (s *Service) WithClient(client *http.Client) {
+	if client != nil {
+		s.client = client
+	}
+}
+
+// AddReceivers takes device IDs, shown in the WirePusher app, and adds them to the internal receiver list. The Send
+// method will send a given message to all those devices.
+func (s *Service) AddReceivers(deviceIDs ...string) {
+	s.deviceIDs = append(s.deviceIDs, deviceIDs...)
+}
+
+// SetType sets the message type of the notifications. Types are configured in the app and determine their sound,
+// vibration and LED color, e.g. "alert".
+func (s *Service) SetType(messageType string) {
+	s.messageType = messageType
+}
+
+// SetAction sets a URL opened when the notification is tapped.
+func (s *Service) SetAction(action string) {
+	s.action = action
+}
+
+// SetImageURL sets the URL of an image shown with the notifications.
+func (s *Service) SetImageURL(imageURL string) {
+	s.imageURL = imageURL
+}
+
+// Send takes a message subject and a message body and sends them to all previously set devices.
+func (s *Service) Send(ctx context.Context, subject, message string) error {
+	query := url.Values{
+		"title":   {subject},
+		"message": {message},
+	}
+	if s.messageType != "" {
+		query.Set("type", s.messageType)
+	}
+	if s.action != "" {
+		query.Set("action", s.action)
+	}
+	if s.imageURL != "" {
+		query.Set("image_url", s.imageURL)
+	}
+
+	for _, deviceID := range s.deviceIDs {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			query.Set("id", deviceID)
+			if err := s.push(ctx, query); err != nil {
+				return errors.Wrapf(err, "failed to send message to device %q", deviceID)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) push(ctx context.Context, query url.Values) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.apiURL+"?"+query.Encode(), http.NoBody)
+	if err != nil {
+		return errors.Wrap(err, "create request")
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "send request")
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		result, _ := io.ReadAll(resp.Body)
+		return notify.ClassifyResponse(resp, fmt.Errorf("wirepusher returned status code %d: %s", resp.StatusCode, string(result)))
+	}
+
+	return nil
+}
@@ -0,0 +1,51 @@
+package wirepusher
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWirePusher_Send(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	var got []url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("id") == "invalid" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("Invalid device ID"))
+			return
+		}
+		got = append(got, r.URL.Query())
+	}))
+	defer server.Close()
+
+	service := New()
+	service.apiURL = server.URL
+	service.AddReceivers("abc", "def")
+	service.SetType("alert")
+	service.SetAction("https://example.com")
+	service.SetImageURL("https://example.com/image.png")
+
+	assert.NoError(service.Send(context.Background(), "subject", "message"))
+	assert.Len(got, 2)
+	assert.Equal(url.Values{
+		"id":        {"abc"},
+		"title":     {"subject"},
+		"message":   {"message"},
+		"type":      {"alert"},
+		"action":    {"https://example.com"},
+		"image_url": {"https://example.com/image.png"},
+	}, got[0])
+	assert.Equal("def", got[1].Get("id"))
+
+	// Test error response
+	service.AddReceivers("invalid")
+	assert.ErrorContains(service.Send(context.Background(), "subject", "message"), "Invalid device ID")
+}
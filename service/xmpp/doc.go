@@ -0,0 +1,40 @@
+/*
+Package xmpp provides a service for sending chat messages to XMPP (Jabber) users and multi-user chat rooms.
+
+The service ships its own minimal client. It establishes a new connection for every call to Send, authenticates using
+SASL PLAIN and closes the connection once all messages were sent. Connections are secured using STARTTLS by default.
+
+Usage:
+
+	package main
+
+	import (
+	    "context"
+	    "log"
+
+	    "github.com/nikoksr/notify"
+	    "github.com/nikoksr/notify/service/xmpp"
+	)
+
+	func main() {
+	    // Create a xmpp service. Leaving the server address empty connects to the JID's domain on port 5222.
+	    xmppService := xmpp.New("notify@example.com", "password", "")
+
+	    // Optionally use direct TLS instead of STARTTLS, e.g. on port 5223.
+	    // xmppService.SetTLS(&tls.Config{ServerName: "example.com"})
+
+	    // Send chat messages to users and group chat messages to rooms.
+	    xmppService.AddReceivers("jane@example.com")
+	    xmppService.AddRooms("ops@conference.example.com")
+	    xmppService.SetNickname("alerts")
+
+	    // Tell our notifier to use the xmpp service.
+	    notify.UseServices(xmppService)
+
+	    // Send a test message.
+	    if err := notify.Send(context.Background(), "Subject/Title", "The actual message"); err != nil {
+	        log.Fatal(err)
+	    }
+	}
+*/
+package xmpp
@@ -0,0 +1,306 @@
+package xmpp
+
+import (
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
+)
+
+// XML namespaces used by the stream negotiation.
+const (
+	nsStream  = "http://etherx.jabber.org/streams"
+	nsClient  = "jabber:client"
+	nsTLS     = "urn:ietf:params:xml:ns:xmpp-tls"
+	nsSASL    = "urn:ietf:params:xml:ns:xmpp-sasl"
+	nsBind    = "urn:ietf:params:xml:ns:xmpp-bind"
+	nsSession = "urn:ietf:params:xml:ns:xmpp-session"
+	nsMUC     = "http://jabber.org/protocol/muc"
+)
+
+// features holds the stream features announced by the server that are relevant to us.
+type features struct {
+	XMLName    xml.Name  `xml:"features"`
+	StartTLS   *struct{} `xml:"starttls"`
+	Mechanisms struct {
+		Mechanism []string `xml:"mechanism"`
+	} `xml:"mechanisms"`
+	Bind    *struct{} `xml:"bind"`
+	Session *struct {
+		Optional *struct{} `xml:"optional"`
+	} `xml:"session"`
+}
+
+// stanzaError is the error element of a stanza of type "error".
+type stanzaError struct {
+	Type      string `xml:"type,attr"`
+	Condition struct {
+		XMLName xml.Name
+	} `xml:",any"`
+}
+
+// session is a single, short-lived client-to-server stream.
+type session struct {
+	conn   net.Conn
+	dec    *xml.Decoder
+	domain string
+	nextID int
+}
+
+// newSession wraps the given connection and opens the initial stream.
+func newSession(conn net.Conn, domain string) (*session, error) {
+	s := &session{conn: conn, domain: domain}
+	if err := s.openStream(); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// write sends the given raw XML to the server.
+func (s *session) write(format string, args ...any) error {
+	_, err := fmt.Fprintf(s.conn, format, args...)
+	return err
+}
+
+// escape returns the XML escaped form of the given string.
+func escape(str string) string {
+	var b strings.Builder
+	_ = xml.EscapeText(&b, []byte(str))
+	return b.String()
+}
+
+// id returns a new unique stanza ID.
+func (s *session) id() string {
+	s.nextID++
+	return fmt.Sprintf("notify-%d", s.nextID)
+}
+
+// nextStart returns the next start element of the stream.
+func (s *session) nextStart() (xml.StartElement, error) {
+	for {
+		token, err := s.dec.Token()
+		if err != nil {
+			return xml.StartElement{}, err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			return t, nil
+		case xml.EndElement:
+			if t.Name.Space == nsStream && t.Name.Local == "stream" {
+				return xml.StartElement{}, io.EOF
+			}
+		}
+	}
+}
+
+// openStream (re)starts the XML stream and returns once the server's stream header was received. A new decoder is
+// used for every stream, as required after STARTTLS and SASL negotiation.
+func (s *session) openStream() error {
+	s.dec = xml.NewDecoder(s.conn)
+
+	err := s.write(
+		"<?xml version='1.0'?><stream:stream to='%s' xmlns='%s' xmlns:stream='%s' version='1.0'>",
+		escape(s.domain), nsClient, nsStream,
+	)
+	if err != nil {
+		return errors.Wrap(err, "open stream")
+	}
+
+	start, err := s.nextStart()
+	if err != nil {
+		return errors.Wrap(err, "read stream header")
+	}
+	if start.Name.Space != nsStream || start.Name.Local != "stream" {
+		return errors.Errorf("expected stream header, got <%s>", start.Name.Local)
+	}
+
+	return nil
+}
+
+// readFeatures reads the stream features announced after a stream (re)start.
+func (s *session) readFeatures() (*features, error) {
+	start, err := s.nextStart()
+	if err != nil {
+		return nil, errors.Wrap(err, "read stream features")
+	}
+
+	f := new(features)
+	if err = s.dec.DecodeElement(f, &start); err != nil {
+		return nil, errors.Wrap(err, "decode stream features")
+	}
+
+	return f, nil
+}
+
+// startTLS upgrades the connection using STARTTLS and restarts the stream.
+func (s *session) startTLS(config *tls.Config) error {
+	if err := s.write("<starttls xmlns='%s'/>", nsTLS); err != nil {
+		return err
+	}
+
+	start, err := s.nextStart()
+	if err != nil {
+		return err
+	}
+	if start.Name.Local != "proceed" {
+		return errors.New("server refused to start tls")
+	}
+
+	tlsConn := tls.Client(s.conn, config)
+	if err = tlsConn.Handshake(); err != nil {
+		return errors.Wrap(err, "tls handshake")
+	}
+	s.conn = tlsConn
+
+	return s.openStream()
+}
+
+// authenticate authenticates the user using SASL PLAIN and restarts the stream.
+func (s *session) authenticate(f *features, username, password string) error {
+	supported := false
+	for _, mechanism := range f.Mechanisms.Mechanism {
+		if mechanism == "PLAIN" {
+			supported = true
+			break
+		}
+	}
+	if !supported {
+		return errors.Errorf("server does not support SASL PLAIN, offered mechanisms: %v", f.Mechanisms.Mechanism)
+	}
+
+	credentials := base64.StdEncoding.EncodeToString([]byte("\x00" + username + "\x00" + password))
+	if err := s.write("<auth xmlns='%s' mechanism='PLAIN'>%s</auth>", nsSASL, credentials); err != nil {
+		return err
+	}
+
+	start, err := s.nextStart()
+	if err != nil {
+		return err
+	}
+	if start.Name.Local != "success" {
+		return notify.ClassifyError(errors.New("authentication failed"), notify.ErrAuthFailed)
+	}
+	if err = s.dec.Skip(); err != nil {
+		return err
+	}
+
+	return s.openStream()
+}
+
+// iqResult waits for the result of the iq with the given ID.
+func (s *session) iqResult(id string) error {
+	for {
+		start, err := s.nextStart()
+		if err != nil {
+			return err
+		}
+
+		var iq struct {
+			ID    string       `xml:"id,attr"`
+			Type  string       `xml:"type,attr"`
+			Error *stanzaError `xml:"error"`
+		}
+		if err = s.dec.DecodeElement(&iq, &start); err != nil {
+			return err
+		}
+		if start.Name.Local != "iq" || iq.ID != id {
+			continue
+		}
+		if iq.Type == "error" {
+			if iq.Error != nil {
+				return errors.Errorf("iq error: %s", iq.Error.Condition.XMLName.Local)
+			}
+			return errors.New("iq error")
+		}
+
+		return nil
+	}
+}
+
+// bind binds the given resource to the stream and, if required by the server, establishes a session.
+func (s *session) bind(f *features, resource string) error {
+	id := s.id()
+	err := s.write(
+		"<iq type='set' id='%s'><bind xmlns='%s'><resource>%s</resource></bind></iq>", id, nsBind, escape(resource),
+	)
+	if err != nil {
+		return err
+	}
+	if err = s.iqResult(id); err != nil {
+		return errors.Wrap(err, "bind resource")
+	}
+
+	// Session establishment is deprecated but still required by some older servers.
+	if f.Session != nil && f.Session.Optional == nil {
+		id = s.id()
+		if err = s.write("<iq type='set' id='%s'><session xmlns='%s'/></iq>", id, nsSession); err != nil {
+			return err
+		}
+		if err = s.iqResult(id); err != nil {
+			return errors.Wrap(err, "establish session")
+		}
+	}
+
+	return nil
+}
+
+// joinRoom joins the given multi-user chat room and waits until the server confirmed our presence in it.
+func (s *session) joinRoom(room, nickname string) error {
+	occupant := room + "/" + nickname
+	err := s.write(
+		"<presence to='%s'><x xmlns='%s'><history maxstanzas='0'/></x></presence>", escape(occupant), nsMUC,
+	)
+	if err != nil {
+		return err
+	}
+
+	for {
+		start, err := s.nextStart()
+		if err != nil {
+			return err
+		}
+
+		var presence struct {
+			From  string       `xml:"from,attr"`
+			Type  string       `xml:"type,attr"`
+			Error *stanzaError `xml:"error"`
+		}
+		if err = s.dec.DecodeElement(&presence, &start); err != nil {
+			return err
+		}
+		if start.Name.Local != "presence" || !strings.EqualFold(presence.From, occupant) {
+			continue
+		}
+		if presence.Type == "error" {
+			if presence.Error != nil {
+				err = errors.Errorf("join room: %s", presence.Error.Condition.XMLName.Local)
+				return notify.ClassifyError(err, notify.ErrInvalidReceiver)
+			}
+			return notify.ClassifyError(errors.New("join room failed"), notify.ErrInvalidReceiver)
+		}
+
+		return nil
+	}
+}
+
+// sendMessage sends a message stanza of the given type.
+func (s *session) sendMessage(to, messageType, body string) error {
+	return s.write(
+		"<message to='%s' type='%s' id='%s'><body>%s</body></message>",
+		escape(to), messageType, s.id(), escape(body),
+	)
+}
+
+// close ends the stream and closes the underlying connection.
+func (s *session) close() error {
+	_ = s.write("</stream:stream>")
+	return s.conn.Close()
+}
@@ -0,0 +1,275 @@
+package xmpp
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
+)
+
+// Default ports used if the server address doesn't contain a port.
+const (
+	defaultPort    = "5222"
+	defaultTLSPort = "5223"
+)
+
+// tlsMode describes how the connection to the server is secured.
+type tlsMode int
+
+const (
+	// startTLS upgrades a plain connection using STARTTLS. This is the default.
+	startTLS tlsMode = iota
+	// directTLS connects using TLS right away, usually on port 5223.
+	directTLS
+	// noTLS never encrypts the connection. Only use this for local testing.
+	noTLS
+)
+
+// XMPP struct holds necessary data to send messages over XMPP.
+type XMPP struct {
+	jid        string
+	password   string
+	serverAddr string
+	resource   string
+	nickname   string
+	receivers  []string
+	rooms      []string
+	tlsMode    tlsMode
+	tlsConfig  *tls.Config
+	dialer     net.Dialer
+}
+
+// New returns a new instance of a XMPP notification service. The jid is the bare JID of the sending account, e.g.
+// notify@example.com. The serverAddr is the host:port of the server and may be left empty, in which case the domain
+// part of the JID and the default client port are used.
+func New(jid, password, serverAddr string) *XMPP {
+	return &XMPP{
+		jid:        jid,
+		password:   password,
+		serverAddr: serverAddr,
+		resource:   "notify",
+		nickname:   "notify",
+		receivers:  []string{},
+		rooms:      []string{},
+		tlsMode:    startTLS,
+	}
+}
+
+// AddReceivers takes bare JIDs and adds them to the internal receiver list. The Send method will send a given message
+// as chat message to all of those receivers.
+func (x *XMPP) AddReceivers(jids ...string) {
+	x.receivers = append(x.receivers, jids...)
+}
+
+// AddRooms takes multi-user chat room JIDs, e.g. ops@conference.example.com, and adds them to the internal room list.
+// The Send method will join all of those rooms and send a given message as group chat message to them.
+func (x *XMPP) AddRooms(roomJIDs ...string) {
+	x.rooms = append(x.rooms, roomJIDs...)
+}
+
+// SetNickname sets the nickname used when joining rooms.
+func (x *XMPP) SetNickname(nickname string) {
+	x.nickname = nickname
+}
+
+// SetTLS can be used to connect to the server over direct TLS with an optional TLS config.
+func (x *XMPP) SetTLS(tlsConfig *tls.Config) {
+	x.tlsMode = directTLS
+	x.tlsConfig = tlsConfig
+}
+
+// SetStartTLS can be used to upgrade the connection using STARTTLS with an optional TLS config. This is the default
+// and the service refuses to authenticate if the server does not offer STARTTLS.
+func (x *XMPP) SetStartTLS(tlsConfig *tls.Config) {
+	x.tlsMode = startTLS
+	x.tlsConfig = tlsConfig
+}
+
+// UnSetTLS can be used to connect without tls. Credentials are sent in plain text, so only use this for local testing.
+func (x *XMPP) UnSetTLS() {
+	x.tlsMode = noTLS
+	x.tlsConfig = nil
+}
+
+// domain returns the domain part of the service's JID.
+func (x *XMPP) domain() string {
+	domain := x.jid
+	if i := strings.IndexByte(domain, '@'); i >= 0 {
+		domain = domain[i+1:]
+	}
+	if i := strings.IndexByte(domain, '/'); i >= 0 {
+		domain = domain[:i]
+	}
+
+	return domain
+}
+
+// username returns the local part of the service's JID.
+func (x *XMPP) username() string {
+	if i := strings.IndexByte(x.jid, '@'); i >= 0 {
+		return x.jid[:i]
+	}
+
+	return x.jid
+}
+
+// address returns the address of the server to connect to.
+func (x *XMPP) address() string {
+	if x.serverAddr != "" {
+		if _, _, err := net.SplitHostPort(x.serverAddr); err == nil {
+			return x.serverAddr
+		}
+		return net.JoinHostPort(x.serverAddr, x.port())
+	}
+
+	return net.JoinHostPort(x.domain(), x.port())
+}
+
+func (x *XMPP) port() string {
+	if x.tlsMode == directTLS {
+		return defaultTLSPort
+	}
+
+	return defaultPort
+}
+
+// config returns the TLS config to use, defaulting the server name to the JID's domain.
+func (x *XMPP) config() *tls.Config {
+	config := &tls.Config{MinVersion: tls.VersionTLS12}
+	if x.tlsConfig != nil {
+		config = x.tlsConfig.Clone()
+	}
+	if config.ServerName == "" {
+		config.ServerName = x.domain()
+	}
+
+	return config
+}
+
+// connect dials the server and negotiates an authenticated, bound stream.
+func (x *XMPP) connect(ctx context.Context) (*session, error) {
+	conn, err := x.dialer.DialContext(ctx, "tcp", x.address())
+	if err != nil {
+		return nil, errors.Wrap(err, "dial server")
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	if x.tlsMode == directTLS {
+		tlsConn := tls.Client(conn, x.config())
+		if err = tlsConn.HandshakeContext(ctx); err != nil {
+			_ = conn.Close()
+			return nil, errors.Wrap(err, "tls handshake")
+		}
+		conn = tlsConn
+	}
+
+	s, err := newSession(conn, x.domain())
+	if err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+
+	if err = x.negotiate(s); err != nil {
+		_ = s.close()
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// negotiate runs the stream negotiation: STARTTLS, authentication and resource binding.
+func (x *XMPP) negotiate(s *session) error {
+	f, err := s.readFeatures()
+	if err != nil {
+		return err
+	}
+
+	if x.tlsMode == startTLS {
+		if f.StartTLS == nil {
+			return errors.New("server does not support STARTTLS")
+		}
+		if err = s.startTLS(x.config()); err != nil {
+			return errors.Wrap(err, "starttls")
+		}
+		if f, err = s.readFeatures(); err != nil {
+			return err
+		}
+	}
+
+	if err = s.authenticate(f, x.username(), x.password); err != nil {
+		return errors.Wrap(err, "authenticate")
+	}
+	if f, err = s.readFeatures(); err != nil {
+		return err
+	}
+
+	return s.bind(f, x.resource)
+}
+
+// Validate checks that the JID is a bare JID, that the password is set and that at least one receiver or room is set
+// and all of them are bare JIDs, see notify.Validator.
+func (x *XMPP) Validate() error {
+	var problems notify.ConfigErrors
+	if x.username() == x.jid || x.username() == "" || x.domain() == "" {
+		problems.Addf("JID %q must be in the format user@domain", x.jid)
+	}
+	problems.Require("password", x.password)
+	if len(x.receivers) == 0 && len(x.rooms) == 0 {
+		problems.Addf("no receiver or room set")
+	}
+	for _, jid := range append(append([]string{}, x.receivers...), x.rooms...) {
+		if !strings.Contains(jid, "@") {
+			problems.Addf("receiver %q is not a bare JID", jid)
+		}
+	}
+
+	return problems.Err()
+}
+
+// Send takes a message subject and a message body and sends them to all previously set receivers and rooms. A new
+// connection is established for every call.
+func (x *XMPP) Send(ctx context.Context, subject, message string) error {
+	if len(x.receivers) == 0 && len(x.rooms) == 0 {
+		return nil
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+
+	s, err := x.connect(ctx)
+	if err != nil {
+		return notify.ClassifyNetworkError(errors.Wrap(err, "failed to connect to xmpp server"))
+	}
+	defer func() { _ = s.close() }()
+
+	body := message
+	if subject != "" {
+		body = subject + "\n" + message
+	}
+
+	for _, receiver := range x.receivers {
+		if err = s.sendMessage(receiver, "chat", body); err != nil {
+			return notify.ClassifyNetworkError(errors.Wrapf(err, "failed to send message to %q", receiver))
+		}
+	}
+
+	for _, room := range x.rooms {
+		if err = s.joinRoom(room, x.nickname); err != nil {
+			return notify.ClassifyNetworkError(errors.Wrapf(err, "failed to join room %q", room))
+		}
+		if err = s.sendMessage(room, "groupchat", body); err != nil {
+			return notify.ClassifyNetworkError(errors.Wrapf(err, "failed to send message to room %q", room))
+		}
+	}
+
+	return nil
+}
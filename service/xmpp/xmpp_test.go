@@ -0,0 +1,197 @@
+package xmpp
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nikoksr/notify"
+)
+
+// fakeServer is a minimal XMPP server that accepts a single client using SASL PLAIN without TLS.
+type fakeServer struct {
+	listener net.Listener
+	password string
+
+	mu       sync.Mutex
+	messages map[string]string
+}
+
+func newFakeServer(t *testing.T, password string) *fakeServer {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = listener.Close() })
+
+	s := &fakeServer{listener: listener, password: password, messages: map[string]string{}}
+	go s.serve()
+
+	return s
+}
+
+func (s *fakeServer) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *fakeServer) handle(conn net.Conn) {
+	defer func() { _ = conn.Close() }()
+
+	authenticated := false
+	dec := xml.NewDecoder(conn)
+	for {
+		token, err := dec.Token()
+		if err != nil {
+			return
+		}
+
+		switch t := token.(type) {
+		case xml.EndElement:
+			if t.Name.Local == "stream" {
+				return
+			}
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "stream":
+				_, _ = fmt.Fprintf(conn, "<?xml version='1.0'?><stream:stream xmlns='%s' xmlns:stream='%s' "+
+					"from='example.com' id='1' version='1.0'>", nsClient, nsStream)
+				if authenticated {
+					_, _ = fmt.Fprintf(conn, "<stream:features><bind xmlns='%s'/></stream:features>", nsBind)
+				} else {
+					_, _ = fmt.Fprintf(conn, "<stream:features><mechanisms xmlns='%s'>"+
+						"<mechanism>PLAIN</mechanism></mechanisms></stream:features>", nsSASL)
+				}
+			case "auth":
+				var auth struct {
+					Value string `xml:",chardata"`
+				}
+				_ = dec.DecodeElement(&auth, &t)
+				credentials, _ := base64.StdEncoding.DecodeString(auth.Value)
+				if string(credentials) != "\x00notify\x00"+s.password {
+					_, _ = fmt.Fprintf(conn, "<failure xmlns='%s'><not-authorized/></failure>", nsSASL)
+					return
+				}
+				_, _ = fmt.Fprintf(conn, "<success xmlns='%s'/>", nsSASL)
+				authenticated = true
+				dec = xml.NewDecoder(conn)
+			case "iq":
+				var iq struct {
+					ID string `xml:"id,attr"`
+				}
+				_ = dec.DecodeElement(&iq, &t)
+				_, _ = fmt.Fprintf(conn, "<iq type='result' id='%s'/>", iq.ID)
+			case "presence":
+				var presence struct {
+					To string `xml:"to,attr"`
+				}
+				_ = dec.DecodeElement(&presence, &t)
+				_, _ = fmt.Fprintf(conn, "<presence from='%s'/>", presence.To)
+			case "message":
+				var message struct {
+					To   string `xml:"to,attr"`
+					Type string `xml:"type,attr"`
+					Body string `xml:"body"`
+				}
+				_ = dec.DecodeElement(&message, &t)
+				s.mu.Lock()
+				s.messages[message.Type+":"+message.To] = message.Body
+				s.mu.Unlock()
+			}
+		}
+	}
+}
+
+func TestXMPP_New(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := New("notify@example.com", "password", "")
+	assert.NotNil(service)
+	assert.Equal("example.com", service.domain())
+	assert.Equal("notify", service.username())
+	assert.Equal("example.com:5222", service.address())
+
+	service.SetTLS(nil)
+	assert.Equal("example.com:5223", service.address())
+
+	service = New("notify@example.com", "password", "xmpp.example.com:5269")
+	assert.Equal("xmpp.example.com:5269", service.address())
+}
+
+func TestXMPP_Validate(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := New("notify@example.com", "password", "")
+	service.AddReceivers("jane@example.com")
+	service.AddRooms("ops@conference.example.com")
+	assert.NoError(service.Validate())
+
+	service = New("example.com", "", "")
+	err := service.Validate()
+	assert.ErrorIs(err, notify.ErrInvalidConfig)
+	assert.ErrorContains(err, `JID "example.com" must be in the format user@domain`)
+	assert.ErrorContains(err, "password is missing")
+	assert.ErrorContains(err, "no receiver or room set")
+
+	service.AddRooms("ops")
+	assert.ErrorContains(service.Validate(), `receiver "ops" is not a bare JID`)
+}
+
+func TestXMPP_Send(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	server := newFakeServer(t, "password")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// No receivers added
+	service := New("notify@example.com", "password", server.listener.Addr().String())
+	service.UnSetTLS()
+	err := service.Send(ctx, "subject", "message")
+	assert.Nil(err)
+
+	service.AddReceivers("jane@example.com")
+	service.AddRooms("ops@conference.example.com")
+	err = service.Send(ctx, "subject", "<message>")
+	assert.Nil(err)
+
+	assert.Eventually(func() bool {
+		server.mu.Lock()
+		defer server.mu.Unlock()
+		return len(server.messages) == 2
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal("subject\n<message>", server.messages["chat:jane@example.com"])
+	assert.Equal("subject\n<message>", server.messages["groupchat:ops@conference.example.com"])
+
+	// Test authentication failure
+	service = New("notify@example.com", "wrong", server.listener.Addr().String())
+	service.UnSetTLS()
+	service.AddReceivers("jane@example.com")
+	err = service.Send(ctx, "subject", "message")
+	assert.NotNil(err)
+
+	// Test missing STARTTLS support
+	service = New("notify@example.com", "password", server.listener.Addr().String())
+	service.AddReceivers("jane@example.com")
+	err = service.Send(ctx, "subject", "message")
+	assert.NotNil(err)
+}
@@ -0,0 +1,34 @@
+/*
+Package zapier provides a service for sending notifications to Zapier Catch Hooks.
+
+Usage:
+
+	package main
+
+	import (
+	    "context"
+	    "log"
+
+	    "github.com/nikoksr/notify"
+	    "github.com/nikoksr/notify/service/zapier"
+	)
+
+	func main() {
+	    zapierService := zapier.New()
+
+	    // Post to these Catch Hooks.
+	    zapierService.AddReceivers("https://hooks.zapier.com/hooks/catch/123/abc/")
+
+	    // Additional fields show up in the Zap editor.
+	    zapierService.AddField("severity", "high")
+
+	    // Tell our notifier to use the zapier service.
+	    notify.UseServices(zapierService)
+
+	    // Send a test message.
+	    if err := notify.Send(context.Background(), "Subject/Title", "The actual message"); err != nil {
+	        log.Fatal(err)
+	    }
+	}
+*/
+package zapier
@@ -0,0 +1,164 @@
+package zapier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
+)
+
+// Compile-time check to ensure that Service implements the notify.JSONSender interface.
+var _ notify.JSONSender = new(Service)
+
+// Service encapsulates the Zapier webhook client.
+type Service struct {
+	client   *http.Client
+	hookURLs []string
+	fields   map[string]any
+	signer   *notify.Signer
+
+	now func() time.Time
+}
+
+// New returns a new instance of a Zapier notification service. Receivers are the URLs of "Catch Hook" triggers of
+// "Webhooks by Zapier", e.g. https://hooks.zapier.com/hooks/catch/123/abc/.
+func New() *Service {
+	return &Service{
+		client: &http.Client{
+			Transport: notify.NewRateLimitTransport(nil),
+			Timeout:   10 * time.Second,
+		},
+		hookURLs: []string{},
+		fields:   map[string]any{},
+		now:      time.Now,
+	}
+}
+
+// WithClient sets the http client to be used for sending requests. Calling this method is optional, the default client
+// will be used if this method is not called.
+func (s *Service) WithClient(client *http.Client) {
+	if client != nil {
+		s.client = client
+	}
+}
+
+// AddReceivers takes Catch Hook URLs and adds them to the internal URL list. The Send method will post to every one of
+// those URLs.
+func (s *Service) AddReceivers(hookURLs ...string) {
+	s.hookURLs = append(s.hookURLs, hookURLs...)
+}
+
+// SetSigner makes the service sign every payload with the given signer, so that the receiver can verify that it was
+// sent by this service. See notify.Signer.
+func (s *Service) SetSigner(signer *notify.Signer) {
+	s.signer = signer
+}
+
+// AddField adds a field to all payloads. Nested maps are flattened, so that every value shows up as a separate field
+// in the Zap editor; e.g. "host" with {"name": "api-01"} becomes "host__name".
+func (s *Service) AddField(key string, value any) {
+	s.fields[key] = value
+}
+
+// flatten copies value into payload under key, recursively flattening nested maps with keys joined by "__".
+func flatten(payload map[string]any, key string, value any) {
+	switch v := value.(type) {
+	case map[string]any:
+		for k, nested := range v {
+			flatten(payload, key+"__"+k, nested)
+		}
+	case map[string]string:
+		for k, nested := range v {
+			payload[key+"__"+k] = nested
+		}
+	default:
+		payload[key] = value
+	}
+}
+
+// Validate checks that the hook URLs are well-formed, see notify.Validator.
+func (s *Service) Validate() error {
+	var problems notify.ConfigErrors
+	for _, hookURL := range s.hookURLs {
+		problems.Check("hook URL", notify.ValidateURL(hookURL))
+	}
+
+	return problems.Err()
+}
+
+// Send takes a message subject and a message body and posts them as flat JSON object with "subject", "message" and
+// "timestamp" fields, along with all previously added fields, to every previously set Catch Hook URL.
+func (s *Service) Send(ctx context.Context, subject, message string) error {
+	return s.SendJSON(ctx, map[string]any{
+		"subject":   subject,
+		"message":   message,
+		"timestamp": s.now().UTC().Format(time.RFC3339),
+	})
+}
+
+// SendJSON posts the JSON encoding of v to every previously set Catch Hook URL. If v is encoded as JSON object, it is
+// flattened and merged with all previously added fields like for Send; other values are posted as they are.
+func (s *Service) SendJSON(ctx context.Context, v any) error {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal payload")
+	}
+
+	var object map[string]any
+	if json.Unmarshal(body, &object) == nil && object != nil {
+		payload := make(map[string]any, len(s.fields)+len(object))
+		for key, value := range s.fields {
+			flatten(payload, key, value)
+		}
+		for key, value := range object {
+			flatten(payload, key, value)
+		}
+		if body, err = json.Marshal(payload); err != nil {
+			return errors.Wrap(err, "failed to marshal payload")
+		}
+	}
+
+	for _, hookURL := range s.hookURLs {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			if err = s.post(ctx, hookURL, body); err != nil {
+				return errors.Wrap(err, "failed to send payload to Zapier")
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) post(ctx context.Context, hookURL string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hookURL, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "create request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.signer != nil {
+		s.signer.SignRequest(req, body)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "send request")
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		result, _ := io.ReadAll(resp.Body)
+		return notify.ClassifyResponse(resp, fmt.Errorf("zapier returned status code %d: %s", resp.StatusCode, string(result)))
+	}
+
+	return nil
+}
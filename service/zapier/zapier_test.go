@@ -0,0 +1,119 @@
+package zapier
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nikoksr/notify"
+)
+
+func TestZapier_Send(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	var got map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/hooks/catch/123/abc/" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		_, _ = w.Write([]byte(`{"status":"success"}`))
+	}))
+	defer server.Close()
+
+	service := New()
+	service.now = func() time.Time { return time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC) }
+	service.AddReceivers(server.URL + "/hooks/catch/123/abc/")
+	service.AddField("severity", "high")
+	service.AddField("host", map[string]any{
+		"name":   "api-01",
+		"region": map[string]string{"code": "eu-west-1"},
+	})
+
+	assert.NoError(service.Send(context.Background(), "subject", "message"))
+	assert.Equal(map[string]any{
+		"subject":            "subject",
+		"message":            "message",
+		"timestamp":          "2023-01-02T03:04:05Z",
+		"severity":           "high",
+		"host__name":         "api-01",
+		"host__region__code": "eu-west-1",
+	}, got)
+
+	// Test error response
+	service.AddReceivers(server.URL + "/invalid")
+	assert.Error(service.Send(context.Background(), "subject", "message"))
+}
+
+func TestZapier_SendJSON(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	var got any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&got)
+	}))
+	defer server.Close()
+
+	service := New()
+	service.AddReceivers(server.URL)
+	service.AddField("severity", "high")
+
+	payload := map[string]any{"service": "api", "deploy": map[string]any{"version": "1.2.3"}}
+	assert.NoError(service.SendJSON(context.Background(), payload))
+	assert.Equal(map[string]any{"service": "api", "deploy__version": "1.2.3", "severity": "high"}, got)
+
+	assert.NoError(service.SendJSON(context.Background(), []string{"api", "web"}))
+	assert.Equal([]any{"api", "web"}, got)
+
+	assert.Error(service.SendJSON(context.Background(), make(chan int)))
+}
+
+func TestZapier_Validate(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := New()
+	service.AddReceivers("https://hooks.zapier.com/hooks/catch/123/abc/")
+	assert.NoError(service.Validate())
+
+	service.AddReceivers("hooks.zapier.com/hooks/catch/123/abc/")
+	err := service.Validate()
+	assert.ErrorIs(err, notify.ErrInvalidConfig)
+	assert.ErrorContains(err, "hook URL")
+}
+
+func TestZapier_SetSigner(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	signer := notify.NewSigner("secret")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if err := signer.VerifyRequest(r, body); err != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"status":"success"}`))
+	}))
+	defer server.Close()
+
+	service := New()
+	service.AddReceivers(server.URL)
+	assert.Error(service.Send(context.Background(), "subject", "message"))
+
+	service.SetSigner(signer)
+	assert.NoError(service.Send(context.Background(), "subject", "message"))
+}
@@ -0,0 +1,38 @@
+/*
+Package zendesk provides a service for creating and updating Zendesk tickets.
+
+Usage:
+
+	package main
+
+	import (
+	    "context"
+	    "log"
+
+	    "github.com/nikoksr/notify"
+	    "github.com/nikoksr/notify/service/zendesk"
+	)
+
+	func main() {
+	    zendeskService := zendesk.New("your-subdomain", "agent@example.com", "your-api-token")
+
+	    // Set priority and tags of the tickets.
+	    zendeskService.SetPriority(zendesk.PriorityHigh)
+	    zendeskService.AddTags("notify", "outage")
+
+	    // Tell our notifier to use the zendesk service.
+	    notify.UseServices(zendeskService)
+
+	    // Create a ticket.
+	    if err := notify.Send(context.Background(), "Billing outage", "Invoices are not generated."); err != nil {
+	        log.Fatal(err)
+	    }
+
+	    // Later on, add a comment to it.
+	    zendeskService.SetTicketID(35436)
+	    if err := notify.Send(context.Background(), "Billing outage", "Invoices are generated again."); err != nil {
+	        log.Fatal(err)
+	    }
+	}
+*/
+package zendesk
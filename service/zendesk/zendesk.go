@@ -0,0 +1,193 @@
+package zendesk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
+)
+
+// Priority represents the urgency of a ticket.
+type Priority string
+
+// All ticket priorities supported by Zendesk.
+const (
+	PriorityUrgent Priority = "urgent"
+	PriorityHigh   Priority = "high"
+	PriorityNormal Priority = "normal"
+	PriorityLow    Priority = "low"
+)
+
+// priorities maps the priorities of notify.WithPriority to Zendesk priorities.
+var priorities = map[notify.Priority]Priority{
+	notify.PriorityLow:      PriorityLow,
+	notify.PriorityNormal:   PriorityNormal,
+	notify.PriorityHigh:     PriorityHigh,
+	notify.PriorityCritical: PriorityUrgent,
+}
+
+// Service allow you to configure the Zendesk service.
+type Service struct {
+	client   *http.Client
+	apiURL   string
+	email    string
+	apiToken string
+
+	ticketID  int64
+	priority  Priority
+	tags      []string
+	requester *requester
+}
+
+// New returns a new instance of a Zendesk notification service for the given subdomain, e.g. "acme" for
+// acme.zendesk.com. Requests are authenticated with the email address of an agent and an API token.
+// For more information about the Tickets API:
+//
+//	-> https://developer.zendesk.com/api-reference/ticketing/tickets/tickets/
+func New(subdomain, email, apiToken string) *Service {
+	return &Service{
+		client: &http.Client{
+			Transport: notify.NewRateLimitTransport(nil),
+			Timeout:   10 * time.Second,
+		},
+		apiURL:   fmt.Sprintf("https://%s.zendesk.com/api/v2", subdomain),
+		email:    email,
+		apiToken: apiToken,
+		tags:     []string{},
+	}
+}
+
+// WithClient sets the http client to be used for sending requests. Calling this method is optional, the default client
+// will be used if this method is not called.
+func (s *Service) WithClient(client *http.Client) {
+	if client != nil {
+		s.client = client
+	}
+}
+
+// SetTicketID sets the ID of an existing ticket. If set, Send adds a comment to that ticket instead of creating a new
+// one.
+func (s *Service) SetTicketID(ticketID int64) {
+	s.ticketID = ticketID
+}
+
+// SetPriority sets the priority of created or updated tickets. If left empty, Zendesk's default applies to created
+// tickets and updated tickets keep their priority. The priority of a notification, see notify.WithPriority, overrides
+// it: low is low, normal normal, high high and critical urgent.
+func (s *Service) SetPriority(priority Priority) {
+	s.priority = priority
+}
+
+// AddTags adds tags to created or updated tickets. Tags replace the tags of updated tickets.
+func (s *Service) AddTags(tags ...string) {
+	s.tags = append(s.tags, tags...)
+}
+
+// SetRequester sets the end user created tickets are requested by. Zendesk creates the user if no user with the email
+// address exists. If not set, the authenticated agent is the requester.
+func (s *Service) SetRequester(name, email string) {
+	s.requester = &requester{Name: name, Email: email}
+}
+
+type requester struct {
+	Name  string `json:"name,omitempty"`
+	Email string `json:"email"`
+}
+
+type comment struct {
+	Body string `json:"body"`
+}
+
+type ticket struct {
+	Subject   string     `json:"subject,omitempty"`
+	Comment   comment    `json:"comment"`
+	Priority  Priority   `json:"priority,omitempty"`
+	Tags      []string   `json:"tags,omitempty"`
+	Requester *requester `json:"requester,omitempty"`
+}
+
+type ticketRequest struct {
+	Ticket ticket `json:"ticket"`
+}
+
+// Validate checks that the subdomain and API token are set and that the email addresses of the agent and the
+// requester are well-formed, see notify.Validator.
+func (s *Service) Validate() error {
+	var problems notify.ConfigErrors
+	if strings.HasPrefix(s.apiURL, "https://.") {
+		problems.Addf("subdomain is missing")
+	}
+	problems.Check("email", notify.ValidateMailAddress(s.email))
+	problems.Require("API token", s.apiToken)
+	if s.requester != nil {
+		problems.Check("requester", notify.ValidateMailAddress(s.requester.Email))
+	}
+
+	return problems.Err()
+}
+
+// Send takes a message subject and a message body and creates a ticket with the subject as title and the message as
+// description. If a ticket ID is set, the message is added as comment to that ticket instead.
+func (s *Service) Send(ctx context.Context, subject, message string) error {
+	t := ticket{
+		Comment:  comment{Body: message},
+		Priority: s.priority,
+	}
+	if mapped, ok := priorities[notify.SendOptionsFromContext(ctx).Priority]; ok {
+		t.Priority = mapped
+	}
+	if len(s.tags) > 0 {
+		t.Tags = s.tags
+	}
+
+	method := http.MethodPost
+	endpoint := s.apiURL + "/tickets.json"
+	if s.ticketID != 0 {
+		method = http.MethodPut
+		endpoint = fmt.Sprintf("%s/tickets/%d.json", s.apiURL, s.ticketID)
+	} else {
+		t.Subject = subject
+		t.Requester = s.requester
+	}
+
+	if err := s.do(ctx, method, endpoint, ticketRequest{Ticket: t}); err != nil {
+		return errors.Wrap(err, "failed to send ticket to Zendesk")
+	}
+
+	return nil
+}
+
+func (s *Service) do(ctx context.Context, method, endpoint string, payload ticketRequest) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return errors.Wrap(err, "marshal ticket")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "create request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.SetBasicAuth(s.email+"/token", s.apiToken)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "send request")
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		result, _ := io.ReadAll(resp.Body)
+		return notify.ClassifyResponse(resp, fmt.Errorf("zendesk returned status code %d: %s", resp.StatusCode, string(result)))
+	}
+
+	return nil
+}
@@ -0,0 +1,115 @@
+package zendesk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nikoksr/notify"
+)
+
+func TestZendesk_New(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := New("acme", "agent@example.com", "token")
+	assert.NotNil(service)
+	assert.Equal("https://acme.zendesk.com/api/v2", service.apiURL)
+}
+
+func TestZendesk_Validate(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := New("acme", "agent@example.com", "token")
+	service.SetRequester("Jane Doe", "jane@example.com")
+	assert.NoError(service.Validate())
+
+	service = New("", "agent", "")
+	service.SetRequester("Jane Doe", "")
+	err := service.Validate()
+	assert.ErrorIs(err, notify.ErrInvalidConfig)
+	assert.ErrorContains(err, "subdomain is missing")
+	assert.ErrorContains(err, "email: mail address")
+	assert.ErrorContains(err, "API token is missing")
+	assert.ErrorContains(err, "requester: mail address")
+}
+
+func TestZendesk_Send(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	type request struct {
+		method string
+		path   string
+		body   ticketRequest
+	}
+	var got []request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, token, ok := r.BasicAuth()
+		if !ok || user != "agent@example.com/token" || token != "token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error":"Couldn't authenticate you"}`))
+			return
+		}
+		var body ticketRequest
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		got = append(got, request{method: r.Method, path: r.URL.Path, body: body})
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusCreated)
+		}
+		_, _ = w.Write([]byte(`{"ticket":{"id":35436}}`))
+	}))
+	defer server.Close()
+
+	service := New("acme", "agent@example.com", "token")
+	service.apiURL = server.URL
+	service.SetPriority(PriorityUrgent)
+	service.AddTags("outage", "billing")
+	service.SetRequester("Jane Doe", "jane@example.com")
+
+	assert.NoError(service.Send(context.Background(), "Billing outage", "Invoices are not generated."))
+
+	service.SetTicketID(35436)
+	service.SetPriority(PriorityNormal)
+	assert.NoError(service.Send(context.Background(), "Billing outage", "Invoices are generated again."))
+
+	assert.Equal([]request{
+		{
+			method: http.MethodPost,
+			path:   "/tickets.json",
+			body: ticketRequest{Ticket: ticket{
+				Subject:   "Billing outage",
+				Comment:   comment{Body: "Invoices are not generated."},
+				Priority:  PriorityUrgent,
+				Tags:      []string{"outage", "billing"},
+				Requester: &requester{Name: "Jane Doe", Email: "jane@example.com"},
+			}},
+		},
+		{
+			method: http.MethodPut,
+			path:   "/tickets/35436.json",
+			body: ticketRequest{Ticket: ticket{
+				Comment:  comment{Body: "Invoices are generated again."},
+				Priority: PriorityNormal,
+				Tags:     []string{"outage", "billing"},
+			}},
+		},
+	}, got)
+
+	// The priority of the notification overrides the priority of the service.
+	ctx := notify.ContextWithSendOptions(context.Background(), notify.WithPriority(notify.PriorityCritical))
+	assert.NoError(service.Send(ctx, "Billing outage", "Invoices fail again."))
+	assert.Equal(PriorityUrgent, got[2].body.Ticket.Priority)
+
+	// Test error response
+	service.apiToken = "invalid"
+	assert.Error(service.Send(context.Background(), "subject", "message"))
+}
@@ -0,0 +1,31 @@
+/*
+Package zoomchat provides a service for sending Zoom Team Chat messages as chatbot.
+
+Usage:
+
+	package main
+
+	import (
+	    "context"
+	    "log"
+
+	    "github.com/nikoksr/notify"
+	    "github.com/nikoksr/notify/service/zoomchat"
+	)
+
+	func main() {
+	    zoomService := zoomchat.New("your-client-id", "your-client-secret", "your-bot-jid", "your-account-id")
+
+	    // Send to these channels and users.
+	    zoomService.AddReceivers("abc123@conference.xmpp.zoom.us")
+
+	    // Tell our notifier to use the zoom chat service.
+	    notify.UseServices(zoomService)
+
+	    // Send a test message.
+	    if err := notify.Send(context.Background(), "Subject/Title", "The actual message"); err != nil {
+	        log.Fatal(err)
+	    }
+	}
+*/
+package zoomchat
@@ -0,0 +1,203 @@
+package zoomchat
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
+)
+
+const (
+	defaultOAuthURL = "https://zoom.us/oauth/token"
+	defaultAPIURL   = "https://api.zoom.us/v2"
+)
+
+// tokenExpiryMargin is subtracted from the token lifetime to avoid using tokens that are about to expire.
+const tokenExpiryMargin = 5 * time.Minute
+
+// Service encapsulates the Zoom Team Chat chatbot client.
+type Service struct {
+	client       *http.Client
+	oauthURL     string
+	apiURL       string
+	clientID     string
+	clientSecret string
+	robotJID     string
+	accountID    string
+	useMarkdown  bool
+	receivers    []string
+
+	mu          sync.Mutex
+	accessToken string
+	expiresAt   time.Time
+}
+
+// New returns a new instance of a Zoom Team Chat notification service for a chatbot app. The client ID and secret are
+// used to request access tokens via the client credentials grant. The bot JID can be found on the Features page of the
+// app, the account ID is the one of the account the app is installed on.
+// For more information about sending chatbot messages:
+//
+//	-> https://developers.zoom.us/docs/team-chat-apps/send-chatbot-messages/
+func New(clientID, clientSecret, robotJID, accountID string) *Service {
+	return &Service{
+		client: &http.Client{
+			Transport: notify.NewRateLimitTransport(nil),
+			Timeout:   10 * time.Second,
+		},
+		oauthURL:     defaultOAuthURL,
+		apiURL:       defaultAPIURL,
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		robotJID:     robotJID,
+		accountID:    accountID,
+		receivers:    []string{},
+	}
+}
+
+// WithClient sets the http client to be used for sending requests. Calling this method is optional, the default client
+// will be used if this method is not called.
+func (s *Service) WithClient(client *http.Client) {
+	if client != nil {
+		s.client = client
+	}
+}
+
+// AddReceivers takes JIDs of channels, e.g. "abc@conference.xmpp.zoom.us", or users, e.g. "xyz@xmpp.zoom.us", and adds
+// them to the internal receiver list. The Send method will send a given message to all those JIDs.
+func (s *Service) AddReceivers(jids ...string) {
+	s.receivers = append(s.receivers, jids...)
+}
+
+// UseMarkdown enables markdown in the message body.
+func (s *Service) UseMarkdown(markdown bool) {
+	s.useMarkdown = markdown
+}
+
+// token returns a cached access token or requests a new one if there is none or it expired.
+func (s *Service) token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.accessToken != "" && time.Now().Before(s.expiresAt) {
+		return s.accessToken, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.oauthURL+"?grant_type=client_credentials", http.NoBody)
+	if err != nil {
+		return "", errors.Wrap(err, "create request")
+	}
+	req.SetBasicAuth(s.clientID, s.clientSecret)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return "", errors.Wrap(err, "send request")
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	b, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return "", notify.ClassifyResponse(resp, fmt.Errorf("zoom returned status code %d: %s", resp.StatusCode, string(b)))
+	}
+
+	var r struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int64  `json:"expires_in"`
+	}
+	if err = json.Unmarshal(b, &r); err != nil {
+		return "", errors.Wrap(err, "decode response")
+	}
+
+	s.accessToken = r.AccessToken
+	s.expiresAt = time.Now().Add(time.Duration(r.ExpiresIn)*time.Second - tokenExpiryMargin)
+
+	return s.accessToken, nil
+}
+
+type contentHead struct {
+	Text string `json:"text"`
+}
+
+type contentBody struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+type content struct {
+	Head contentHead   `json:"head"`
+	Body []contentBody `json:"body"`
+}
+
+type chatMessage struct {
+	RobotJID          string  `json:"robot_jid"`
+	ToJID             string  `json:"to_jid"`
+	AccountID         string  `json:"account_id"`
+	Content           content `json:"content"`
+	IsMarkdownSupport bool    `json:"is_markdown_support,omitempty"`
+}
+
+// Send takes a message subject and a message body and sends them to all previously set JIDs. The subject is shown as
+// the message head.
+func (s *Service) Send(ctx context.Context, subject, message string) error {
+	for _, jid := range s.receivers {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			msg := chatMessage{
+				RobotJID:  s.robotJID,
+				ToJID:     jid,
+				AccountID: s.accountID,
+				Content: content{
+					Head: contentHead{Text: subject},
+					Body: []contentBody{{Type: "message", Text: message}},
+				},
+				IsMarkdownSupport: s.useMarkdown,
+			}
+			if err := s.post(ctx, msg); err != nil {
+				return errors.Wrapf(err, "failed to send message to %q", jid)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) post(ctx context.Context, msg chatMessage) error {
+	token, err := s.token(ctx)
+	if err != nil {
+		return errors.Wrap(err, "get access token")
+	}
+
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return errors.Wrap(err, "marshal message")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.apiURL+"/im/chat/messages", bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "create request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "send request")
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		result, _ := io.ReadAll(resp.Body)
+		return notify.ClassifyResponse(resp, fmt.Errorf("zoom returned status code %d: %s", resp.StatusCode, string(result)))
+	}
+
+	return nil
+}
@@ -0,0 +1,70 @@
+package zoomchat
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestZoomChat_Send(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	tokenRequests := 0
+	var got []chatMessage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/oauth/token":
+			id, secret, ok := r.BasicAuth()
+			if !ok || id != "id" || secret != "secret" || r.URL.Query().Get("grant_type") != "client_credentials" {
+				w.WriteHeader(http.StatusUnauthorized)
+				_, _ = w.Write([]byte(`{"reason":"Invalid client_id or client_secret","error":"invalid_client"}`))
+				return
+			}
+			tokenRequests++
+			_, _ = w.Write([]byte(`{"access_token":"token","token_type":"bearer","expires_in":3599}`))
+		case "/v2/im/chat/messages":
+			if r.Header.Get("Authorization") != "Bearer token" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			var msg chatMessage
+			_ = json.NewDecoder(r.Body).Decode(&msg)
+			got = append(got, msg)
+			_, _ = w.Write([]byte(`{"message_id":"1","robot_jid":"bot@xmpp.zoom.us"}`))
+		}
+	}))
+	defer server.Close()
+
+	service := New("id", "secret", "bot@xmpp.zoom.us", "account")
+	service.oauthURL = server.URL + "/oauth/token"
+	service.apiURL = server.URL + "/v2"
+	service.AddReceivers("channel@conference.xmpp.zoom.us", "user@xmpp.zoom.us")
+	service.UseMarkdown(true)
+
+	assert.NoError(service.Send(context.Background(), "subject", "message"))
+	assert.Equal(1, tokenRequests)
+	assert.Len(got, 2)
+	assert.Equal(chatMessage{
+		RobotJID:  "bot@xmpp.zoom.us",
+		ToJID:     "channel@conference.xmpp.zoom.us",
+		AccountID: "account",
+		Content: content{
+			Head: contentHead{Text: "subject"},
+			Body: []contentBody{{Type: "message", Text: "message"}},
+		},
+		IsMarkdownSupport: true,
+	}, got[0])
+
+	// Test invalid credentials
+	service = New("id", "invalid", "bot@xmpp.zoom.us", "account")
+	service.oauthURL = server.URL + "/oauth/token"
+	service.apiURL = server.URL + "/v2"
+	service.AddReceivers("channel@conference.xmpp.zoom.us")
+	assert.ErrorContains(service.Send(context.Background(), "subject", "message"), "invalid_client")
+}
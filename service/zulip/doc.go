@@ -0,0 +1,35 @@
+/*
+Package zulip provides a service for sending stream and private messages to Zulip.
+
+Usage:
+
+	package main
+
+	import (
+	    "context"
+	    "log"
+
+	    "github.com/nikoksr/notify"
+	    "github.com/nikoksr/notify/service/zulip"
+	)
+
+	func main() {
+	    // Create a zulip service using your organization's URL and the credentials of a bot.
+	    zulipService := zulip.New("https://example.zulipchat.com", "notify-bot@example.zulipchat.com", "your-api-key")
+
+	    // Send messages to streams; the subject is used as topic.
+	    zulipService.AddReceivers("alerts")
+
+	    // Send private messages to users.
+	    zulipService.AddPrivateReceivers("jane@example.com")
+
+	    // Tell our notifier to use the zulip service.
+	    notify.UseServices(zulipService)
+
+	    // Send a test message.
+	    if err := notify.Send(context.Background(), "Deployment", "Version 1.2.3 is live"); err != nil {
+	        log.Fatal(err)
+	    }
+	}
+*/
+package zulip
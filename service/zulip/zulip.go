@@ -0,0 +1,200 @@
+package zulip
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/nikoksr/notify"
+)
+
+// maxTopicLength is the maximum length of a topic name accepted by Zulip.
+const maxTopicLength = 60
+
+// defaultTopic is used for stream messages without a subject, since Zulip requires a topic for those.
+const defaultTopic = "notify"
+
+// Service allow you to configure the Zulip service.
+type Service struct {
+	client   *http.Client
+	siteURL  string
+	botEmail string
+	apiKey   string
+	streams  []string
+	users    []string
+}
+
+func defaultHTTPClient() *http.Client {
+	return &http.Client{
+		Transport: notify.NewRateLimitTransport(nil),
+		Timeout:   10 * time.Second,
+	}
+}
+
+// New returns a new instance of a Zulip notification service. The siteURL is the URL of your organization, e.g.
+// https://example.zulipchat.com, botEmail and apiKey are the credentials of the bot sending the messages.
+// For more information about Zulip bots:
+//
+//	-> https://zulip.com/help/add-a-bot-or-integration
+func New(siteURL, botEmail, apiKey string) *Service {
+	return &Service{
+		client:   defaultHTTPClient(),
+		siteURL:  strings.TrimSuffix(siteURL, "/"),
+		botEmail: botEmail,
+		apiKey:   apiKey,
+		streams:  []string{},
+		users:    []string{},
+	}
+}
+
+// WithClient sets the http client to be used for sending requests. Calling this method is optional, the default client
+// will be used if this method is not called.
+func (s *Service) WithClient(client *http.Client) {
+	if client != nil {
+		s.client = client
+	}
+}
+
+// AddReceivers takes stream names and adds them to the internal stream list. The Send method will send a given
+// message to all of those streams, using the subject as topic.
+func (s *Service) AddReceivers(streams ...string) {
+	s.streams = append(s.streams, streams...)
+}
+
+// AddPrivateReceivers takes user emails and adds them to the internal user list. The Send method will send a given
+// message as private message to every one of those users.
+func (s *Service) AddPrivateReceivers(emails ...string) {
+	s.users = append(s.users, emails...)
+}
+
+// topic derives a valid topic name from the given subject.
+func topic(subject string) string {
+	subject = strings.TrimSpace(subject)
+	if subject == "" {
+		return defaultTopic
+	}
+
+	runes := []rune(subject)
+	if len(runes) > maxTopicLength {
+		return string(runes[:maxTopicLength-1]) + "…"
+	}
+
+	return subject
+}
+
+// response is the relevant part of the body returned by the Zulip API.
+type response struct {
+	Result string `json:"result"`
+	Msg    string `json:"msg"`
+}
+
+func (s *Service) send(ctx context.Context, form url.Values) error {
+	req, err := http.NewRequestWithContext(
+		ctx, http.MethodPost, s.siteURL+"/api/v1/messages", strings.NewReader(form.Encode()),
+	)
+	if err != nil {
+		return errors.Wrap(err, "create request")
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(s.botEmail, s.apiKey)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "send request")
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Wrap(err, "read response")
+	}
+
+	var result response
+	_ = json.Unmarshal(body, &result)
+	if resp.StatusCode != http.StatusOK || result.Result != "success" {
+		return notify.ClassifyResponse(resp, fmt.Errorf("zulip returned status code %d: %s", resp.StatusCode, string(body)))
+	}
+
+	return nil
+}
+
+// Validate checks that the site URL is well-formed, that the bot's credentials are set, that at least one stream or
+// user is set and that the user email addresses are well-formed, see notify.Validator.
+func (s *Service) Validate() error {
+	var problems notify.ConfigErrors
+	problems.Check("site URL", notify.ValidateURL(s.siteURL))
+	problems.Check("bot email", notify.ValidateMailAddress(s.botEmail))
+	problems.Require("API key", s.apiKey)
+	if len(s.streams) == 0 && len(s.users) == 0 {
+		problems.Addf("no stream or user set")
+	}
+	for _, stream := range s.streams {
+		if stream == "" {
+			problems.Addf("stream name is empty")
+		}
+	}
+	for _, user := range s.users {
+		problems.Check("user", notify.ValidateMailAddress(user))
+	}
+
+	return problems.Err()
+}
+
+// Sanitize escapes the markdown of the message, so that user provided content can neither format the message nor
+// mention everyone in the stream, see notify.Sanitizer. The subject is escaped as well, as it is rendered bold in
+// private messages.
+func (s *Service) Sanitize(message notify.Message) notify.Message {
+	return notify.SanitizeMessage(message, notify.EscapeMarkdown)
+}
+
+// Send takes a message subject and a message body and sends them to all previously set streams and users. Stream
+// messages use the subject as topic, private messages include the subject as bold first line.
+func (s *Service) Send(ctx context.Context, subject, message string) error {
+	for _, stream := range s.streams {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			form := url.Values{
+				"type":    {"stream"},
+				"to":      {stream},
+				"topic":   {topic(subject)},
+				"content": {message},
+			}
+			if err := s.send(ctx, form); err != nil {
+				return errors.Wrapf(err, "failed to send message to Zulip stream %q", stream)
+			}
+		}
+	}
+
+	content := message
+	if subject != "" {
+		content = "**" + subject + "**\n" + message
+	}
+
+	for _, user := range s.users {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			to, _ := json.Marshal([]string{user})
+			form := url.Values{
+				"type":    {"private"},
+				"to":      {string(to)},
+				"content": {content},
+			}
+			if err := s.send(ctx, form); err != nil {
+				return errors.Wrapf(err, "failed to send private message to Zulip user %q", user)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,117 @@
+package zulip
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nikoksr/notify"
+)
+
+func TestZulip_New(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := New("https://example.zulipchat.com/", "bot@example.com", "key")
+	assert.NotNil(service)
+	assert.Equal("https://example.zulipchat.com", service.siteURL)
+
+	service.AddReceivers("alerts")
+	service.AddPrivateReceivers("jane@example.com")
+	assert.Equal([]string{"alerts"}, service.streams)
+	assert.Equal([]string{"jane@example.com"}, service.users)
+}
+
+func TestZulip_Topic(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	assert.Equal(defaultTopic, topic(" "))
+	assert.Equal("subject", topic("subject"))
+	assert.Len([]rune(topic(strings.Repeat("a", 100))), maxTopicLength)
+}
+
+func TestZulip_Validate(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	service := New("https://example.zulipchat.com", "bot@example.zulipchat.com", "key")
+	service.AddReceivers("ops")
+	service.AddPrivateReceivers("jane@example.com")
+	assert.NoError(service.Validate())
+
+	err := New("example.zulipchat.com", "bot", "").Validate()
+	assert.ErrorIs(err, notify.ErrInvalidConfig)
+	assert.ErrorContains(err, "site URL")
+	assert.ErrorContains(err, "bot email")
+	assert.ErrorContains(err, "API key is missing")
+	assert.ErrorContains(err, "no stream or user set")
+
+	service.AddReceivers("")
+	service.AddPrivateReceivers("jane")
+	err = service.Validate()
+	assert.ErrorContains(err, "stream name is empty")
+	assert.ErrorContains(err, "user: mail address")
+}
+
+func TestZulip_Sanitize(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	sanitized := New("https://example.zulipchat.com", "bot@example.zulipchat.com", "key").Sanitize(notify.Message{
+		Subject: "Deploy",
+		Body:    "@**all** see [this](https://evil.example.com)",
+	})
+	assert.Equal("Deploy", sanitized.Subject)
+	assert.Equal(`@\*\*all\*\* see \[this\](https://evil.example.com)`, sanitized.Body)
+}
+
+func TestZulip_Send(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	var forms []url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, _ := r.BasicAuth()
+		if user != "bot@example.com" || pass != "key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"result":"error","msg":"Invalid API key"}`))
+			return
+		}
+		_ = r.ParseForm()
+		forms = append(forms, r.PostForm)
+		_, _ = w.Write([]byte(`{"result":"success","msg":"","id":42}`))
+	}))
+	defer server.Close()
+
+	service := New(server.URL, "bot@example.com", "key")
+	service.AddReceivers("alerts")
+	service.AddPrivateReceivers("jane@example.com")
+
+	err := service.Send(context.Background(), "subject", "message")
+	assert.Nil(err)
+	assert.Len(forms, 2)
+	assert.Equal("stream", forms[0].Get("type"))
+	assert.Equal("alerts", forms[0].Get("to"))
+	assert.Equal("subject", forms[0].Get("topic"))
+	assert.Equal("message", forms[0].Get("content"))
+	assert.Equal("private", forms[1].Get("type"))
+	assert.Equal(`["jane@example.com"]`, forms[1].Get("to"))
+	assert.Equal("**subject**\nmessage", forms[1].Get("content"))
+
+	// Test error response
+	service = New(server.URL, "bot@example.com", "wrong")
+	service.AddReceivers("alerts")
+	err = service.Send(context.Background(), "subject", "message")
+	assert.NotNil(err)
+}
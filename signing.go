@@ -0,0 +1,108 @@
+package notify
+
+import (
+	"crypto/hmac"
+	"crypto/sha1" //nolint:gosec // SHA-1 is only offered for receivers that require it, HMAC-SHA1 is still sound.
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"hash"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// DefaultSignatureHeader is the header Signer sets by default.
+const DefaultSignatureHeader = "X-Notify-Signature"
+
+// ErrInvalidSignature signals that the signature of a payload is missing or doesn't match.
+var ErrInvalidSignature = errors.New("invalid signature")
+
+// SignatureAlgorithm is the hash function used to sign payloads.
+type SignatureAlgorithm string
+
+// All signature algorithms supported by Signer.
+const (
+	SignatureSHA1   SignatureAlgorithm = "sha1"
+	SignatureSHA256 SignatureAlgorithm = "sha256"
+	SignatureSHA512 SignatureAlgorithm = "sha512"
+)
+
+// hashes maps the signature algorithms to their hash functions.
+var hashes = map[SignatureAlgorithm]func() hash.Hash{
+	SignatureSHA1:   sha1.New,
+	SignatureSHA256: sha256.New,
+	SignatureSHA512: sha512.New,
+}
+
+// Signer signs webhook payloads with an HMAC of a shared secret, so that receivers can verify that payloads are
+// authentic, and verifies the signatures of received payloads. Signatures are sent in a header as the algorithm and
+// the hex encoded HMAC, e.g. "sha256=5d7...", the format GitHub uses for its webhooks.
+type Signer struct {
+	secret    []byte
+	header    string
+	algorithm SignatureAlgorithm
+}
+
+// NewSigner returns a new Signer with the given secret. It signs with HMAC-SHA256 in the DefaultSignatureHeader.
+func NewSigner(secret string) *Signer {
+	return &Signer{
+		secret:    []byte(secret),
+		header:    DefaultSignatureHeader,
+		algorithm: SignatureSHA256,
+	}
+}
+
+// SetHeader sets the name of the header that carries the signature, e.g. "X-Hub-Signature-256" to verify GitHub
+// webhooks.
+func (s *Signer) SetHeader(name string) {
+	if name != "" {
+		s.header = name
+	}
+}
+
+// SetAlgorithm sets the hash function used to sign payloads. Unsupported algorithms are ignored.
+func (s *Signer) SetAlgorithm(algorithm SignatureAlgorithm) {
+	if _, ok := hashes[algorithm]; ok {
+		s.algorithm = algorithm
+	}
+}
+
+// Header returns the name of the header that carries the signature.
+func (s *Signer) Header() string {
+	return s.header
+}
+
+// Sign returns the signature of the payload, e.g. "sha256=5d7...".
+func (s *Signer) Sign(payload []byte) string {
+	mac := hmac.New(hashes[s.algorithm], s.secret)
+	_, _ = mac.Write(payload)
+
+	return string(s.algorithm) + "=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// SignRequest sets the signature of the payload, which must be the body of the request, in the signature header of the
+// request.
+func (s *Signer) SignRequest(req *http.Request, payload []byte) {
+	req.Header.Set(s.header, s.Sign(payload))
+}
+
+// Verify reports whether the signature matches the payload. The comparison takes constant time.
+func (s *Signer) Verify(payload []byte, signature string) bool {
+	return hmac.Equal([]byte(strings.TrimSpace(signature)), []byte(s.Sign(payload)))
+}
+
+// VerifyRequest verifies the signature header of a request with the given payload, which must be the body of the
+// request. It returns ErrInvalidSignature if the header is missing or doesn't match.
+func (s *Signer) VerifyRequest(r *http.Request, payload []byte) error {
+	signature := r.Header.Get(s.header)
+	if signature == "" {
+		return errors.Wrapf(ErrInvalidSignature, "missing %s header", s.header)
+	}
+	if !s.Verify(payload, signature) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
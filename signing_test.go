@@ -0,0 +1,64 @@
+package notify
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSigner(t *testing.T) {
+	t.Parallel()
+
+	// Example from the GitHub documentation on validating webhook deliveries.
+	signer := NewSigner("It's a Secret to Everybody")
+	payload := []byte("Hello, World!")
+
+	want := "sha256=757107ea0eb2509fc211221cce984b8a37570b6d7586c22c46f4379c8b043e17"
+	if got := signer.Sign(payload); got != want {
+		t.Errorf("Sign() = %q, want %q", got, want)
+	}
+	if !signer.Verify(payload, want) {
+		t.Error("Verify() rejected a valid signature")
+	}
+	if signer.Verify([]byte("Hello, World?"), want) {
+		t.Error("Verify() accepted the signature of another payload")
+	}
+	if NewSigner("other secret").Verify(payload, want) {
+		t.Error("Verify() accepted the signature of another secret")
+	}
+
+	signer.SetAlgorithm("md5")
+	if got := signer.Sign(payload); got != want {
+		t.Errorf("Sign() after setting an unsupported algorithm = %q, want %q", got, want)
+	}
+	signer.SetAlgorithm(SignatureSHA512)
+	if got := signer.Sign(payload); !strings.HasPrefix(got, "sha512=") || len(got) != len("sha512=")+128 {
+		t.Errorf("Sign() with SHA-512 = %q, want sha512= and 128 hex digits", got)
+	}
+}
+
+func TestSigner_Request(t *testing.T) {
+	t.Parallel()
+
+	signer := NewSigner("secret")
+	signer.SetHeader("X-Hub-Signature-256")
+	payload := []byte(`{"subject":"hi"}`)
+
+	r := httptest.NewRequest(http.MethodPost, "/", nil)
+	if err := signer.VerifyRequest(r, payload); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("VerifyRequest() of unsigned request returned %v, want ErrInvalidSignature", err)
+	}
+
+	signer.SignRequest(r, payload)
+	if got := r.Header.Get("X-Hub-Signature-256"); got != signer.Sign(payload) {
+		t.Errorf("SignRequest() set header %q, want %q", got, signer.Sign(payload))
+	}
+	if err := signer.VerifyRequest(r, payload); err != nil {
+		t.Errorf("VerifyRequest() of signed request returned error: %v", err)
+	}
+	if err := signer.VerifyRequest(r, []byte(`{"subject":"bye"}`)); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("VerifyRequest() of tampered payload returned %v, want ErrInvalidSignature", err)
+	}
+}
@@ -0,0 +1,53 @@
+package notify
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+// DeliveryStatus describes how far a sent message got on its way to the receiver.
+type DeliveryStatus string
+
+// All delivery statuses a StatusQuerier may report.
+const (
+	DeliveryStatusUnknown   DeliveryStatus = "unknown"
+	DeliveryStatusPending   DeliveryStatus = "pending"
+	DeliveryStatusSent      DeliveryStatus = "sent"
+	DeliveryStatusDelivered DeliveryStatus = "delivered"
+	DeliveryStatusBounced   DeliveryStatus = "bounced"
+	DeliveryStatusFailed    DeliveryStatus = "failed"
+)
+
+// StatusQuerier is implemented by services that can look up the delivery status of a message they sent.
+//
+// The Status function takes the message ID of a Receipt recorded by the service.
+type StatusQuerier interface {
+	Status(ctx context.Context, messageID string) (DeliveryStatus, error)
+}
+
+// status asks the service that sent the message identified by the given receipt for its delivery status.
+func (n *Notify) status(ctx context.Context, receipt Receipt) (DeliveryStatus, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	querier, ok := receipt.Service.(StatusQuerier)
+	if !ok {
+		return DeliveryStatusUnknown, errors.Wrap(ErrUnsupported, "query delivery status")
+	}
+
+	return querier.Status(ctx, receipt.MessageID)
+}
+
+// Status asks the service that sent the message identified by the given receipt for its delivery status. It returns
+// ErrUnsupported if the service does not implement StatusQuerier.
+func (n *Notify) Status(ctx context.Context, receipt Receipt) (DeliveryStatus, error) {
+	return n.status(ctx, receipt)
+}
+
+// Status asks the service that sent the message identified by the given receipt for its delivery status. It returns
+// ErrUnsupported if the service does not implement StatusQuerier.
+func Status(ctx context.Context, receipt Receipt) (DeliveryStatus, error) {
+	return std.Status(ctx, receipt)
+}
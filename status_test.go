@@ -0,0 +1,49 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type statusService struct {
+	status DeliveryStatus
+}
+
+func (s *statusService) Send(context.Context, string, string) error {
+	return nil
+}
+
+func (s *statusService) Status(_ context.Context, messageID string) (DeliveryStatus, error) {
+	if messageID == "" {
+		return DeliveryStatusUnknown, errors.New("missing message ID")
+	}
+
+	return s.status, nil
+}
+
+func TestNotifyStatus(t *testing.T) {
+	t.Parallel()
+
+	n := New()
+	ctx := context.Background()
+
+	service := &statusService{status: DeliveryStatusDelivered}
+	status, err := n.Status(ctx, Receipt{Service: service, MessageID: "id"})
+	if err != nil {
+		t.Fatalf("Status() returned error: %v", err)
+	}
+	if status != DeliveryStatusDelivered {
+		t.Errorf("Status() returned %q, want %q", status, DeliveryStatusDelivered)
+	}
+
+	if _, err = n.Status(ctx, Receipt{Service: service}); err == nil {
+		t.Error("Status() of failing query returned no error")
+	}
+
+	// Services that don't implement StatusQuerier are reported as unsupported.
+	_, err = Status(ctx, Receipt{Service: newFailingService(), MessageID: "id"})
+	if !errors.Is(err, ErrUnsupported) {
+		t.Errorf("Status() of unsupported service returned %v, want ErrUnsupported", err)
+	}
+}
@@ -0,0 +1,252 @@
+package notify
+
+import (
+	"context"
+	"math/rand"
+	"sort"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ErrUnknownTemplate signals that no template or template version with the given name is registered.
+var ErrUnknownTemplate = errors.New("unknown template")
+
+// TemplateRef identifies the version of a template a notification was rendered from.
+type TemplateRef struct {
+	Name    string
+	Version string
+}
+
+// templateVersion is a parsed version of a template.
+type templateVersion struct {
+	subject *template.Template
+	body    *template.Template
+}
+
+// templateVariant is a version of a template that is rendered with the given weight.
+type templateVariant struct {
+	version string
+	weight  int
+}
+
+// versionedTemplate holds all versions of a template.
+type versionedTemplate struct {
+	versions map[string]templateVersion
+	latest   string
+	variants []templateVariant
+}
+
+// Templates holds versioned text/template templates for notifications. Every template can have several versions, e.g.
+// to roll back wording changes, and weighted variants, to experiment with wording: the version that is rendered is
+// picked at random according to the weights of the variants, see SetVariants, and recorded in the audit log, see
+// WithAudit and SendTemplate. It is safe for concurrent use.
+type Templates struct {
+	mu        sync.RWMutex
+	templates map[string]*versionedTemplate
+	catalog   *Catalog
+
+	randMu sync.Mutex
+	random func(n int) int
+}
+
+// NewTemplates returns an empty set of templates.
+func NewTemplates() *Templates {
+	rnd := rand.New(rand.NewSource(time.Now().UnixNano())) //nolint:gosec // Picking variants needs no secure randomness.
+
+	return &Templates{
+		templates: make(map[string]*versionedTemplate),
+		random:    rnd.Intn,
+	}
+}
+
+// Add parses the given subject and body templates and adds them as version of the template with the given name,
+// replacing the version of the same name, if any. The templates have access to TemplateFuncs and to the translate,
+// number and localTime functions, see RenderLocalized. Unless variants are set, the version added last is rendered.
+func (t *Templates) Add(name, version, subject, body string) error {
+	funcs := parseFuncs()
+	subjectTmpl, err := template.New(name + "/subject").Funcs(funcs).Parse(subject)
+	if err != nil {
+		return errors.Wrapf(err, "parse subject template %q version %q", name, version)
+	}
+	bodyTmpl, err := template.New(name + "/body").Funcs(funcs).Parse(body)
+	if err != nil {
+		return errors.Wrapf(err, "parse body template %q version %q", name, version)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	tmpl, ok := t.templates[name]
+	if !ok {
+		tmpl = &versionedTemplate{versions: make(map[string]templateVersion)}
+		t.templates[name] = tmpl
+	}
+	tmpl.versions[version] = templateVersion{subject: subjectTmpl, body: bodyTmpl}
+	tmpl.latest = version
+
+	return nil
+}
+
+// SetVariants sets the versions of the template with the given name that are rendered and their weights, e.g.
+// {"v1": 9, "v2": 1} renders v2 for about one in ten notifications. Versions with a weight of zero or less are never
+// rendered. Without variants, the version added last is rendered.
+func (t *Templates) SetVariants(name string, weights map[string]int) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	tmpl, ok := t.templates[name]
+	if !ok {
+		return errors.Wrapf(ErrUnknownTemplate, "template %q", name)
+	}
+
+	variants := make([]templateVariant, 0, len(weights))
+	for version, weight := range weights {
+		if _, ok = tmpl.versions[version]; !ok {
+			return errors.Wrapf(ErrUnknownTemplate, "template %q version %q", name, version)
+		}
+		if weight > 0 {
+			variants = append(variants, templateVariant{version: version, weight: weight})
+		}
+	}
+	// Sort the variants, so that the same random number always picks the same version.
+	sort.Slice(variants, func(i, j int) bool { return variants[i].version < variants[j].version })
+	tmpl.variants = variants
+
+	return nil
+}
+
+// pick returns the version of the template to render.
+func (t *Templates) pick(tmpl *versionedTemplate) string {
+	total := 0
+	for _, variant := range tmpl.variants {
+		total += variant.weight
+	}
+	if total == 0 {
+		return tmpl.latest
+	}
+
+	t.randMu.Lock()
+	n := t.random(total)
+	t.randMu.Unlock()
+
+	for _, variant := range tmpl.variants {
+		if n < variant.weight {
+			return variant.version
+		}
+		n -= variant.weight
+	}
+
+	return tmpl.latest
+}
+
+// Render renders a version of the template with the given name with data, picked according to the variants of the
+// template, and returns the rendered message and the version. The translate function returns its keys untranslated,
+// see RenderLocalized.
+func (t *Templates) Render(name string, data any) (Message, TemplateRef, error) {
+	return t.RenderLocalized(name, data, "", nil)
+}
+
+// render renders a version of the template with the given name with data, with the given functions replacing those
+// the template was parsed with.
+func (t *Templates) render(name string, data any, funcs template.FuncMap) (Message, TemplateRef, error) {
+	t.mu.RLock()
+	tmpl, ok := t.templates[name]
+	var version string
+	var parsed templateVersion
+	if ok {
+		version = t.pick(tmpl)
+		parsed = tmpl.versions[version]
+	}
+	t.mu.RUnlock()
+
+	ref := TemplateRef{Name: name, Version: version}
+	if !ok {
+		return Message{}, ref, errors.Wrapf(ErrUnknownTemplate, "template %q", name)
+	}
+
+	// Clone the templates, so that renders for different receivers don't share functions.
+	subjectTmpl, err := parsed.subject.Clone()
+	if err != nil {
+		return Message{}, ref, errors.Wrap(err, "clone subject template")
+	}
+	bodyTmpl, err := parsed.body.Clone()
+	if err != nil {
+		return Message{}, ref, errors.Wrap(err, "clone body template")
+	}
+
+	b := getBuffer()
+	defer putBuffer(b)
+
+	if err = subjectTmpl.Funcs(funcs).Execute(b, data); err != nil {
+		return Message{}, ref, errors.Wrap(err, "render subject")
+	}
+	subject := b.String()
+	b.Reset()
+	if err = bodyTmpl.Funcs(funcs).Execute(b, data); err != nil {
+		return Message{}, ref, errors.Wrap(err, "render body")
+	}
+
+	return Message{Subject: subject, Body: b.String()}, ref, nil
+}
+
+// WithTemplates returns an Option that sets the templates SendTemplate renders.
+func WithTemplates(templates *Templates) Option {
+	return func(n *Notify) {
+		if n != nil {
+			n.templates = templates
+		}
+	}
+}
+
+// templateKey is the context key of the template a notification was rendered from.
+type templateKey struct{}
+
+// TemplateFromContext returns the template version a notification sent by SendTemplate was rendered from and whether
+// there is one.
+func TemplateFromContext(ctx context.Context) (TemplateRef, bool) {
+	ref, ok := ctx.Value(templateKey{}).(TemplateRef)
+	return ref, ok
+}
+
+// sendTemplate renders the template with the given name and sends the result.
+func (n *Notify) sendTemplate(ctx context.Context, name string, data any) error {
+	if n.Disabled {
+		return nil
+	}
+	if n.templates == nil {
+		return errors.Wrapf(ErrUnknownTemplate, "template %q", name)
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	locale, _ := LocaleFromContext(ctx)
+	var location *time.Location
+	if contact, ok := ContactFromContext(ctx); ok {
+		location = contact.TimeZone
+	}
+
+	message, ref, err := n.templates.RenderLocalized(name, data, locale, location)
+	if err != nil {
+		return newSendError(err)
+	}
+
+	return n.sendMessage(context.WithValue(ctx, templateKey{}, ref), message)
+}
+
+// SendTemplate renders the template with the given name with data, see WithTemplates, and sends the result to all
+// services like SendMessage. The rendered version of the template is recorded in the audit log, see WithAudit. It is
+// rendered in the locale of the context, see ContextWithLocale; use SendTemplateToContacts to render for each receiver.
+func (n *Notify) SendTemplate(ctx context.Context, name string, data any) error {
+	return n.sendTemplate(ctx, name, data)
+}
+
+// SendTemplate renders the template with the given name with data, see WithTemplates, and sends the result to all
+// services like SendMessage. The rendered version of the template is recorded in the audit log, see WithAudit. It is
+// rendered in the locale of the context, see ContextWithLocale; use SendTemplateToContacts to render for each receiver.
+func SendTemplate(ctx context.Context, name string, data any) error {
+	return std.SendTemplate(ctx, name, data)
+}
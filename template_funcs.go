@@ -0,0 +1,162 @@
+package notify
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// TemplateFuncs returns helpers for templates that render notifications:
+//
+//	humanizeDuration  formats a time.Duration or a number of seconds as e.g. "2d 3h" or "4m 5s"
+//	humanizeBytes     formats a byte count as e.g. "1.5 MiB"
+//	formatTime        formats a time.Time with a layout in a time zone: {{ .Time | formatTime "15:04" "Europe/Berlin" }}
+//	severityEmoji     returns an emoji for a severity such as "critical", "warning" or "resolved"
+//	truncate          shortens a string to a number of characters: {{ .Description | truncate 100 }}
+//
+// The returned map can be passed to text/template directly and to html/template after converting it to
+// html/template.FuncMap.
+func TemplateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"humanizeDuration": humanizeDuration,
+		"humanizeBytes":    humanizeBytes,
+		"formatTime":       formatTime,
+		"severityEmoji":    severityEmoji,
+		"truncate": func(limit int, s string) string {
+			return truncate(s, limit)
+		},
+	}
+}
+
+// humanizeDuration formats a time.Duration, or any number as seconds, see formatDuration. Numbers are accepted so
+// that durations decoded from JSON, e.g. by JSONTemplate, can be formatted.
+func humanizeDuration(d any) (string, error) {
+	if duration, ok := d.(time.Duration); ok {
+		return formatDuration(duration), nil
+	}
+
+	seconds, err := toFloat(d)
+	if err != nil {
+		return "", errors.Wrap(err, "humanizeDuration")
+	}
+
+	return formatDuration(time.Duration(seconds * float64(time.Second))), nil
+}
+
+// formatDuration formats d with its two most significant units, e.g. "2d 3h", "4m 5s" or "800ms".
+func formatDuration(d time.Duration) string {
+	if d < 0 {
+		return "-" + formatDuration(-d)
+	}
+	if d < time.Second {
+		return d.Round(time.Millisecond).String()
+	}
+
+	units := []struct {
+		suffix string
+		size   time.Duration
+	}{
+		{"d", 24 * time.Hour},
+		{"h", time.Hour},
+		{"m", time.Minute},
+		{"s", time.Second},
+	}
+
+	parts := make([]string, 0, 2)
+	for _, unit := range units {
+		if d < unit.size && len(parts) == 0 {
+			continue
+		}
+
+		value := d / unit.size
+		d -= value * unit.size
+		if value > 0 {
+			parts = append(parts, fmt.Sprintf("%d%s", value, unit.suffix))
+		}
+		if len(parts) == 2 || len(parts) == 1 && value == 0 {
+			break
+		}
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// humanizeBytes formats a byte count using binary units, e.g. "512 B" or "1.5 MiB". It accepts all number types.
+func humanizeBytes(size any) (string, error) {
+	n, err := toFloat(size)
+	if err != nil {
+		return "", errors.Wrap(err, "humanizeBytes")
+	}
+
+	const unit = 1024
+	if n < unit && n > -unit {
+		return fmt.Sprintf("%.0f B", n), nil
+	}
+
+	exp := 0
+	for n >= unit*unit || n <= -unit*unit {
+		n /= unit
+		exp++
+	}
+
+	return fmt.Sprintf("%.1f %ciB", n/unit, "KMGTPE"[exp]), nil
+}
+
+// toFloat converts integers, floats and json.Number, the numbers templates get to see, to float64.
+func toFloat(v any) (float64, error) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), nil
+	case int32:
+		return float64(n), nil
+	case int64:
+		return float64(n), nil
+	case uint:
+		return float64(n), nil
+	case uint32:
+		return float64(n), nil
+	case uint64:
+		return float64(n), nil
+	case float32:
+		return float64(n), nil
+	case float64:
+		return n, nil
+	case json.Number:
+		f, err := n.Float64()
+		return f, errors.Wrapf(err, "invalid number %q", n)
+	default:
+		return 0, errors.Errorf("unsupported type %T", v)
+	}
+}
+
+// formatTime formats t with the given layout in the time zone with the given IANA name, e.g. "Europe/Berlin".
+func formatTime(layout, timeZone string, t time.Time) (string, error) {
+	location, err := time.LoadLocation(timeZone)
+	if err != nil {
+		return "", errors.Wrap(err, "formatTime")
+	}
+
+	return t.In(location).Format(layout), nil
+}
+
+// severityEmoji returns an emoji for common severity and state names. Unknown severities yield an empty string.
+func severityEmoji(severity string) string {
+	switch strings.ToLower(severity) {
+	case "critical", "fatal", "emergency", "p1":
+		return "🚨"
+	case "error", "high", "major", "p2":
+		return "🔴"
+	case "warning", "warn", "medium", "minor", "p3":
+		return "⚠️"
+	case "info", "low", "notice", "p4", "p5":
+		return "ℹ️"
+	case "ok", "resolved", "success":
+		return "✅"
+	default:
+		return ""
+	}
+}
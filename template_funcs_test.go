@@ -0,0 +1,106 @@
+package notify
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"text/template"
+	"time"
+)
+
+func TestHumanizeDuration(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		d    any
+		want string
+	}{
+		{800 * time.Millisecond, "800ms"},
+		{45 * time.Second, "45s"},
+		{4*time.Minute + 5*time.Second, "4m 5s"},
+		{2*time.Hour + 30*time.Second, "2h"},
+		{51*time.Hour + 10*time.Minute, "2d 3h"},
+		{-90 * time.Second, "-1m 30s"},
+		{245, "4m 5s"},
+		{int64(7200), "2h"},
+		{0.8, "800ms"},
+		{json.Number("90"), "1m 30s"},
+	}
+
+	for _, tt := range tests {
+		got, err := humanizeDuration(tt.d)
+		if err != nil {
+			t.Fatalf("humanizeDuration(%v) returned error: %v", tt.d, err)
+		}
+		if got != tt.want {
+			t.Errorf("humanizeDuration(%v) returned %q, want %q", tt.d, got, tt.want)
+		}
+	}
+
+	if _, err := humanizeDuration("90s"); err == nil {
+		t.Error("humanizeDuration() of string returned no error")
+	}
+}
+
+func TestHumanizeBytes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		size any
+		want string
+	}{
+		{512, "512 B"},
+		{int64(1536), "1.5 KiB"},
+		{uint64(5 << 30), "5.0 GiB"},
+		{float64(1536), "1.5 KiB"},
+		{json.Number("1048576"), "1.0 MiB"},
+	}
+
+	for _, tt := range tests {
+		got, err := humanizeBytes(tt.size)
+		if err != nil {
+			t.Fatalf("humanizeBytes(%v) returned error: %v", tt.size, err)
+		}
+		if got != tt.want {
+			t.Errorf("humanizeBytes(%v) returned %q, want %q", tt.size, got, tt.want)
+		}
+	}
+
+	if _, err := humanizeBytes("1024"); err == nil {
+		t.Error("humanizeBytes() of string returned no error")
+	}
+	if _, err := humanizeBytes(json.Number("many")); err == nil {
+		t.Error("humanizeBytes() of invalid number returned no error")
+	}
+}
+
+func TestTemplateFuncs(t *testing.T) {
+	t.Parallel()
+
+	tmpl := template.Must(template.New("alert").Funcs(TemplateFuncs()).Parse(
+		`{{ severityEmoji .Severity }} {{ .Name | truncate 10 }} since {{ .Since | formatTime "15:04" "UTC" }} ` +
+			`({{ humanizeDuration .Duration }}, {{ humanizeBytes .Size }})`,
+	))
+
+	var b strings.Builder
+	err := tmpl.Execute(&b, map[string]any{
+		"Severity": "Critical",
+		"Name":     "DiskAlmostFull",
+		"Since":    time.Date(2023, 10, 1, 14, 30, 0, 0, time.FixedZone("CEST", 2*60*60)),
+		"Duration": 90 * time.Minute,
+		"Size":     2048,
+	})
+	if err != nil {
+		t.Fatalf("Execute() returned error: %v", err)
+	}
+
+	want := "🚨 DiskAlmos… since 12:30 (1h 30m, 2.0 KiB)"
+	if got := b.String(); got != want {
+		t.Errorf("Execute() rendered %q, want %q", got, want)
+	}
+
+	if err = template.Must(template.New("").Funcs(TemplateFuncs()).Parse(`{{ formatTime "15:04" "Nowhere/Void" .}}`)).
+		Execute(&b, time.Now()); err == nil {
+		t.Error("formatTime with unknown time zone returned no error")
+	}
+}
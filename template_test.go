@@ -0,0 +1,96 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestTemplates(t *testing.T) {
+	t.Parallel()
+
+	templates := NewTemplates()
+	if err := templates.Add("disk", "v1", "Disk full on {{ .Host }}", "{{ .Used | humanizeBytes }} used"); err != nil {
+		t.Fatalf("Add() returned error: %v", err)
+	}
+	if err := templates.Add("disk", "v2", "{{ .Host }} is out of disk space", "Free some space"); err != nil {
+		t.Fatalf("Add() returned error: %v", err)
+	}
+	if err := templates.Add("broken", "v1", "{{ .Host", ""); err == nil {
+		t.Error("Add() with invalid template returned no error")
+	}
+
+	data := struct {
+		Host string
+		Used int64
+	}{Host: "db-1", Used: 1 << 30}
+
+	// Without variants, the version added last is rendered.
+	message, ref, err := templates.Render("disk", data)
+	if err != nil {
+		t.Fatalf("Render() returned error: %v", err)
+	}
+	want := Message{Subject: "db-1 is out of disk space", Body: "Free some space"}
+	if diff := cmp.Diff(want, message); diff != "" {
+		t.Errorf("Render() returned unexpected message:\n%s", diff)
+	}
+	if wantRef := (TemplateRef{Name: "disk", Version: "v2"}); ref != wantRef {
+		t.Errorf("Render() rendered %+v, want %+v", ref, wantRef)
+	}
+
+	if err = templates.SetVariants("disk", map[string]int{"v1": 3, "v2": 1, "v3": 1}); !errors.Is(err, ErrUnknownTemplate) {
+		t.Errorf("SetVariants() with unknown version returned %v, want ErrUnknownTemplate", err)
+	}
+	if err = templates.SetVariants("disk", map[string]int{"v1": 3, "v2": 1}); err != nil {
+		t.Fatalf("SetVariants() returned error: %v", err)
+	}
+
+	var versions []string
+	for n := 0; n < 4; n++ {
+		n := n
+		templates.random = func(int) int { return n }
+		message, ref, _ = templates.Render("disk", data)
+		versions = append(versions, ref.Version)
+	}
+	if diff := cmp.Diff([]string{"v1", "v1", "v1", "v2"}, versions); diff != "" {
+		t.Errorf("Render() picked unexpected versions:\n%s", diff)
+	}
+	if diff := cmp.Diff(want, message); diff != "" {
+		t.Errorf("Render() returned unexpected message:\n%s", diff)
+	}
+
+	if _, _, err = templates.Render("unknown", data); !errors.Is(err, ErrUnknownTemplate) {
+		t.Errorf("Render() of unknown template returned %v, want ErrUnknownTemplate", err)
+	}
+}
+
+func TestNotifySendTemplate(t *testing.T) {
+	t.Parallel()
+
+	templates := NewTemplates()
+	if err := templates.Add("deploy", "v1", "Deployed {{ .version }}", "All good"); err != nil {
+		t.Fatalf("Add() returned error: %v", err)
+	}
+
+	service := &nativeService{}
+	store := &memoryAuditStore{}
+	n := NewWithServices(service)
+
+	if err := n.SendTemplate(context.Background(), "deploy", nil); !errors.Is(err, ErrUnknownTemplate) {
+		t.Errorf("SendTemplate() without templates returned %v, want ErrUnknownTemplate", err)
+	}
+
+	n.WithOptions(WithTemplates(templates), WithAudit(store))
+	if err := n.SendTemplate(context.Background(), "deploy", map[string]string{"version": "1.2.3"}); err != nil {
+		t.Fatalf("SendTemplate() returned error: %v", err)
+	}
+
+	if diff := cmp.Diff([]Message{{Subject: "Deployed 1.2.3", Body: "All good"}}, service.messages); diff != "" {
+		t.Errorf("SendTemplate() sent unexpected messages:\n%s", diff)
+	}
+	if len(store.records) != 1 || store.records[0].Template != "deploy" || store.records[0].TemplateVersion != "v1" {
+		t.Errorf("SendTemplate() recorded unexpected audit records: %+v", store.records)
+	}
+}
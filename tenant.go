@@ -0,0 +1,209 @@
+package notify
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ErrUnknownTenant signals that no tenant with the given ID is registered.
+var ErrUnknownTenant = errors.New("unknown tenant")
+
+// ErrQuotaExceeded signals that a tenant sent as many notifications as its quota allows in the current period. It is a
+// transient error, sending again in the next period succeeds.
+var ErrQuotaExceeded error = &classError{msg: "tenant quota exceeded", class: ErrTransient}
+
+// Quota limits the notifications of a tenant. Zero values mean no limit.
+type Quota struct {
+	// Messages is the maximum number of notifications per Period. Splitting a notification that exceeds the limits of
+	// a service, see OverflowSplit, counts as one notification.
+	Messages int
+	// Period is the length of the period Messages applies to. It defaults to an hour.
+	Period time.Duration
+	// Concurrent is the maximum number of notifications in flight at the same time. Further notifications wait until
+	// one finished or their context is done.
+	Concurrent int
+}
+
+// tenant is a Notify registered in a Registry, together with the state of its quota.
+type tenant struct {
+	notify *Notify
+	quota  Quota
+
+	mu          sync.Mutex
+	periodStart time.Time
+	sent        int
+	inFlight    chan struct{}
+}
+
+// Registry manages independent sets of services keyed by tenant ID, so that a single process can send notifications
+// on behalf of many customers. Every tenant has its own Notify, with its own services and options, and its own Quota,
+// so a tenant can neither reach the services of another tenant nor exhaust the capacity of the process. It is safe for
+// concurrent use.
+type Registry struct {
+	mu      sync.RWMutex
+	tenants map[string]*tenant
+
+	now func() time.Time
+}
+
+// NewRegistry returns an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		tenants: make(map[string]*tenant),
+		now:     time.Now,
+	}
+}
+
+// tenantKey is the context key of the ID of the tenant a notification is sent for.
+type tenantKey struct{}
+
+// TenantFromContext returns the ID of the tenant a notification is sent for by a Registry and whether there is one.
+// Services and audit stores can use it to separate the data of tenants.
+func TenantFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(tenantKey{}).(string)
+	return id, ok
+}
+
+// Register adds a tenant with the given Notify and quota. It replaces the tenant previously registered with the same
+// ID, if any, and resets its quota. The Notify must not be shared between tenants.
+func (r *Registry) Register(id string, n *Notify, quota Quota) {
+	if quota.Period <= 0 {
+		quota.Period = time.Hour
+	}
+
+	t := &tenant{notify: n, quota: quota}
+	if quota.Concurrent > 0 {
+		t.inFlight = make(chan struct{}, quota.Concurrent)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.tenants[id] = t
+}
+
+// Remove removes the tenant with the given ID. Notifications in flight are not canceled.
+func (r *Registry) Remove(id string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.tenants, id)
+}
+
+// Tenants returns the IDs of all registered tenants in ascending order.
+func (r *Registry) Tenants() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	ids := make([]string, 0, len(r.tenants))
+	for id := range r.tenants {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	return ids
+}
+
+// tenant returns the tenant with the given ID or ErrUnknownTenant.
+func (r *Registry) tenant(id string) (*tenant, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	t, ok := r.tenants[id]
+	if !ok {
+		return nil, errors.Wrapf(ErrUnknownTenant, "tenant %q", id)
+	}
+
+	return t, nil
+}
+
+// Notify returns the Notify of the tenant with the given ID, e.g. to use features the Registry doesn't wrap. Sends
+// through it bypass the quota of the tenant.
+func (r *Registry) Notify(id string) (*Notify, error) {
+	t, err := r.tenant(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.notify, nil
+}
+
+// Usage returns how many notifications the tenant with the given ID sent in the current period of its quota.
+func (r *Registry) Usage(id string) (int, error) {
+	t, err := r.tenant(id)
+	if err != nil {
+		return 0, err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if r.now().Sub(t.periodStart) >= t.quota.Period {
+		return 0, nil
+	}
+
+	return t.sent, nil
+}
+
+// take counts a notification against the quota of the tenant. It returns ErrQuotaExceeded if the quota is used up.
+func (t *tenant) take(now time.Time) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if now.Sub(t.periodStart) >= t.quota.Period {
+		t.periodStart = now
+		t.sent = 0
+	}
+	if t.quota.Messages > 0 && t.sent >= t.quota.Messages {
+		return ErrQuotaExceeded
+	}
+	t.sent++
+
+	return nil
+}
+
+// send calls fn with the Notify of the tenant with the given ID once its quota allows it.
+func (r *Registry) send(ctx context.Context, id string, fn func(ctx context.Context, n *Notify) error) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	t, err := r.tenant(id)
+	if err != nil {
+		return err
+	}
+	if err = t.take(r.now()); err != nil {
+		return errors.Wrapf(err, "tenant %q", id)
+	}
+
+	if t.inFlight != nil {
+		select {
+		case t.inFlight <- struct{}{}:
+			defer func() { <-t.inFlight }()
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	return fn(context.WithValue(ctx, tenantKey{}, id), t.notify)
+}
+
+// Send sends a notification through the services of the tenant with the given ID. It returns ErrUnknownTenant if the
+// tenant isn't registered and ErrQuotaExceeded if its quota is used up.
+func (r *Registry) Send(ctx context.Context, id, subject, message string) error {
+	return r.send(ctx, id, func(ctx context.Context, n *Notify) error {
+		return n.Send(ctx, subject, message)
+	})
+}
+
+// SendMessage sends a structured message through the services of the tenant with the given ID. It returns
+// ErrUnknownTenant if the tenant isn't registered and ErrQuotaExceeded if its quota is used up.
+func (r *Registry) SendMessage(ctx context.Context, id string, message Message) error {
+	return r.send(ctx, id, func(ctx context.Context, n *Notify) error {
+		return n.SendMessage(ctx, message)
+	})
+}
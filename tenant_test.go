@@ -0,0 +1,99 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// tenantService records the tenants it is called for.
+type tenantService struct {
+	tenants []string
+}
+
+func (s *tenantService) Send(ctx context.Context, _, _ string) error {
+	id, _ := TenantFromContext(ctx)
+	s.tenants = append(s.tenants, id)
+
+	return nil
+}
+
+func TestRegistry(t *testing.T) {
+	t.Parallel()
+
+	acme, globex := &tenantService{}, &tenantService{}
+	now := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+
+	r := NewRegistry()
+	r.now = func() time.Time { return now }
+	r.Register("acme", NewWithServices(acme), Quota{Messages: 2})
+	r.Register("globex", NewWithServices(globex), Quota{})
+
+	if diff := cmp.Diff([]string{"acme", "globex"}, r.Tenants()); diff != "" {
+		t.Errorf("Tenants() returned unexpected IDs:\n%s", diff)
+	}
+
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		if err := r.Send(ctx, "acme", "subject", "message"); err != nil {
+			t.Fatalf("Send() returned error: %v", err)
+		}
+	}
+	if err := r.Send(ctx, "acme", "subject", "message"); !errors.Is(err, ErrQuotaExceeded) ||
+		!errors.Is(err, ErrTransient) {
+		t.Errorf("Send() beyond quota returned %v, want ErrQuotaExceeded", err)
+	}
+	if err := r.SendMessage(ctx, "globex", Message{Subject: "subject"}); err != nil {
+		t.Errorf("SendMessage() of other tenant returned error: %v", err)
+	}
+	if usage, _ := r.Usage("acme"); usage != 2 {
+		t.Errorf("Usage() = %d, want 2", usage)
+	}
+
+	now = now.Add(time.Hour)
+	if usage, _ := r.Usage("acme"); usage != 0 {
+		t.Errorf("Usage() in next period = %d, want 0", usage)
+	}
+	if err := r.Send(ctx, "acme", "subject", "message"); err != nil {
+		t.Errorf("Send() in next period returned error: %v", err)
+	}
+
+	if diff := cmp.Diff([]string{"acme", "acme", "acme"}, acme.tenants); diff != "" {
+		t.Errorf("services of tenant acme were called for unexpected tenants:\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"globex"}, globex.tenants); diff != "" {
+		t.Errorf("services of tenant globex were called for unexpected tenants:\n%s", diff)
+	}
+
+	r.Remove("globex")
+	if err := r.Send(ctx, "globex", "subject", "message"); !errors.Is(err, ErrUnknownTenant) {
+		t.Errorf("Send() to removed tenant returned %v, want ErrUnknownTenant", err)
+	}
+	if _, err := r.Notify("globex"); !errors.Is(err, ErrUnknownTenant) {
+		t.Errorf("Notify() of removed tenant returned %v, want ErrUnknownTenant", err)
+	}
+}
+
+func TestRegistry_Concurrent(t *testing.T) {
+	t.Parallel()
+
+	r := NewRegistry()
+	r.Register("acme", New(), Quota{Concurrent: 1})
+
+	t1, _ := r.tenant("acme")
+	t1.inFlight <- struct{}{}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := r.Send(ctx, "acme", "subject", "message"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Send() beyond concurrency limit returned %v, want context.DeadlineExceeded", err)
+	}
+
+	<-t1.inFlight
+	if err := r.Send(context.Background(), "acme", "subject", "message"); err != nil {
+		t.Errorf("Send() returned error: %v", err)
+	}
+}
@@ -0,0 +1,178 @@
+package notify
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type threadKeyKey struct{}
+
+// ContextWithThreadKey returns a copy of ctx that carries the given thread key. Services that support threads group all
+// messages sent with the same key, e.g. chat services post them as replies to the first one and mail sets In-Reply-To
+// and References headers. Services without thread support ignore the key.
+func ContextWithThreadKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, threadKeyKey{}, key)
+}
+
+// ThreadKeyFromContext returns the thread key carried by ctx. It returns false if ctx carries no or an empty key.
+func ThreadKeyFromContext(ctx context.Context) (string, bool) {
+	if ctx == nil {
+		return "", false
+	}
+	key, ok := ctx.Value(threadKeyKey{}).(string)
+
+	return key, ok && key != ""
+}
+
+// DefaultThreadMaxAge is the time Threads keeps the root of a thread after its last message, see Threads.SetMaxAge.
+const DefaultThreadMaxAge = 24 * time.Hour
+
+// Threads remembers the message that started each thread, per receiver, so services can post later messages as
+// replies to it. Threads are only kept in memory and forgotten after some time without messages, see SetMaxAge; the
+// next message of a forgotten thread starts a new one. It is safe for concurrent use.
+type Threads struct {
+	mu        sync.Mutex
+	roots     map[threadRoot]*threadEntry
+	maxAge    time.Duration
+	nextSweep time.Time
+}
+
+type threadRoot struct {
+	receiver string
+	key      string
+}
+
+type threadEntry struct {
+	messageID string
+	lastUsed  time.Time
+	pending   chan struct{} // Closed once the root is sent, nil afterwards.
+}
+
+// NewThreads returns an empty Threads.
+func NewThreads() *Threads {
+	return &Threads{roots: make(map[threadRoot]*threadEntry), maxAge: DefaultThreadMaxAge}
+}
+
+// SetMaxAge sets the time a thread is kept after its last message, zero for DefaultThreadMaxAge.
+func (t *Threads) SetMaxAge(maxAge time.Duration) {
+	if maxAge <= 0 {
+		maxAge = DefaultThreadMaxAge
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.maxAge = maxAge
+}
+
+// entry returns the live entry of the given thread, if any. It must be called with the lock held.
+func (t *Threads) entry(root threadRoot, now time.Time) (*threadEntry, bool) {
+	if now.After(t.nextSweep) {
+		for r, e := range t.roots {
+			if e.pending == nil && now.Sub(e.lastUsed) > t.maxAge {
+				delete(t.roots, r)
+			}
+		}
+		t.nextSweep = now.Add(t.maxAge / 2)
+	}
+
+	e, ok := t.roots[root]
+	if ok && e.pending == nil && now.Sub(e.lastUsed) > t.maxAge {
+		delete(t.roots, root)
+		return nil, false
+	}
+
+	return e, ok
+}
+
+// Root returns the ID of the message that started the thread with the given key at the given receiver.
+func (t *Threads) Root(receiver, key string) (string, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	e, ok := t.entry(threadRoot{receiver: receiver, key: key}, time.Now())
+	if !ok || e.pending != nil {
+		return "", false
+	}
+
+	return e.messageID, true
+}
+
+// Begin returns the ID of the message that started the thread with the given key at the given receiver. If the thread
+// has no root yet, Begin reserves it for the caller and returns false: the caller must send the message that starts
+// the thread and record its ID with SetRoot, or call Abort if sending fails. Until then, concurrent calls for the same
+// thread wait, so that only one message starts it. Begin returns the error of the context if it is done first.
+func (t *Threads) Begin(ctx context.Context, receiver, key string) (string, bool, error) {
+	root := threadRoot{receiver: receiver, key: key}
+	for {
+		t.mu.Lock()
+		now := time.Now()
+		e, ok := t.entry(root, now)
+		switch {
+		case !ok:
+			t.roots[root] = &threadEntry{pending: make(chan struct{})}
+			t.mu.Unlock()
+
+			return "", false, nil
+		case e.pending == nil:
+			e.lastUsed = now
+			t.mu.Unlock()
+
+			return e.messageID, true, nil
+		}
+		pending := e.pending
+		t.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return "", false, ctx.Err()
+		case <-pending:
+		}
+	}
+}
+
+// SetRoot records the ID of the message that started the thread with the given key at the given receiver. It does
+// nothing if the thread already has a root.
+func (t *Threads) SetRoot(receiver, key, messageID string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	root := threadRoot{receiver: receiver, key: key}
+	now := time.Now()
+	e, ok := t.entry(root, now)
+	switch {
+	case !ok:
+		t.roots[root] = &threadEntry{messageID: messageID, lastUsed: now}
+	case e.pending != nil:
+		e.messageID, e.lastUsed = messageID, now
+		close(e.pending)
+		e.pending = nil
+	}
+}
+
+// Abort releases the thread with the given key at the given receiver reserved by Begin, after sending its first
+// message failed. The next call to Begin reserves it again.
+func (t *Threads) Abort(receiver, key string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	root := threadRoot{receiver: receiver, key: key}
+	if e, ok := t.roots[root]; ok && e.pending != nil {
+		close(e.pending)
+		delete(t.roots, root)
+	}
+}
+
+// Forget forgets the thread with the given key at all receivers, e.g. once the incident it is about is resolved. The
+// next message with the key starts a new thread. Threads whose first message is being sent are kept.
+func (t *Threads) Forget(key string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for root, e := range t.roots {
+		if root.key == key && e.pending == nil {
+			delete(t.roots, root)
+		}
+	}
+}
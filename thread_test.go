@@ -0,0 +1,122 @@
+package notify
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+func TestThreadKeyFromContext(t *testing.T) {
+	t.Parallel()
+
+	if _, ok := ThreadKeyFromContext(context.Background()); ok {
+		t.Error("ThreadKeyFromContext() of empty context returned a key")
+	}
+	//nolint:staticcheck
+	if _, ok := ThreadKeyFromContext(nil); ok {
+		t.Error("ThreadKeyFromContext() of nil context returned a key")
+	}
+	if _, ok := ThreadKeyFromContext(ContextWithThreadKey(context.Background(), "")); ok {
+		t.Error("ThreadKeyFromContext() returned an empty key")
+	}
+
+	key, ok := ThreadKeyFromContext(ContextWithThreadKey(context.Background(), "incident-42"))
+	if !ok || key != "incident-42" {
+		t.Errorf("ThreadKeyFromContext() returned %q, %v, want %q, true", key, ok, "incident-42")
+	}
+}
+
+func TestThreads(t *testing.T) {
+	t.Parallel()
+
+	threads := NewThreads()
+	if _, ok := threads.Root("channel", "incident-42"); ok {
+		t.Fatal("Root() of empty Threads returned a root")
+	}
+
+	threads.SetRoot("channel", "incident-42", "first")
+	threads.SetRoot("channel", "incident-42", "second")
+	threads.SetRoot("other-channel", "incident-42", "other")
+
+	if root, _ := threads.Root("channel", "incident-42"); root != "first" {
+		t.Errorf("Root() returned %q, want %q", root, "first")
+	}
+	if root, _ := threads.Root("other-channel", "incident-42"); root != "other" {
+		t.Errorf("Root() returned %q, want %q", root, "other")
+	}
+	if _, ok := threads.Root("channel", "incident-43"); ok {
+		t.Error("Root() of unknown key returned a root")
+	}
+}
+
+func TestThreads_Begin(t *testing.T) {
+	t.Parallel()
+
+	threads := NewThreads()
+	if _, ok, err := threads.Begin(context.Background(), "channel", "incident-42"); ok || err != nil {
+		t.Fatalf("Begin() of new thread returned %v, %v, want false, nil", ok, err)
+	}
+
+	// Concurrent messages wait until the first one started the thread.
+	roots := make(chan string)
+	go func() {
+		root, _, _ := threads.Begin(context.Background(), "channel", "incident-42")
+		roots <- root
+	}()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, _, err := threads.Begin(ctx, "channel", "incident-42"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Begin() of reserved thread returned %v, want context.DeadlineExceeded", err)
+	}
+	threads.SetRoot("channel", "incident-42", "first")
+	if root := <-roots; root != "first" {
+		t.Errorf("Begin() returned %q, want %q", root, "first")
+	}
+
+	// Failed first messages release the thread.
+	if _, ok, _ := threads.Begin(context.Background(), "channel", "incident-43"); ok {
+		t.Fatal("Begin() of new thread returned a root")
+	}
+	threads.Abort("channel", "incident-43")
+	if _, ok, _ := threads.Begin(context.Background(), "channel", "incident-43"); ok {
+		t.Error("Begin() of aborted thread returned a root")
+	}
+}
+
+func TestThreads_Forget(t *testing.T) {
+	t.Parallel()
+
+	threads := NewThreads()
+	threads.SetRoot("channel", "incident-42", "first")
+	threads.SetRoot("other-channel", "incident-42", "other")
+	threads.SetRoot("channel", "incident-43", "second")
+
+	threads.Forget("incident-42")
+	if _, ok := threads.Root("channel", "incident-42"); ok {
+		t.Error("Root() of forgotten thread returned a root")
+	}
+	if _, ok := threads.Root("other-channel", "incident-42"); ok {
+		t.Error("Root() of forgotten thread returned a root")
+	}
+	if _, ok := threads.Root("channel", "incident-43"); !ok {
+		t.Error("Forget() forgot other thread")
+	}
+}
+
+func TestThreads_SetMaxAge(t *testing.T) {
+	t.Parallel()
+
+	threads := NewThreads()
+	threads.SetMaxAge(time.Millisecond)
+	threads.SetRoot("channel", "incident-42", "first")
+	time.Sleep(5 * time.Millisecond)
+
+	if _, ok := threads.Root("channel", "incident-42"); ok {
+		t.Error("Root() of expired thread returned a root")
+	}
+	if len(threads.roots) != 0 {
+		t.Errorf("Threads kept %d expired roots", len(threads.roots))
+	}
+}
@@ -0,0 +1,45 @@
+package notify
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+// Updater is implemented by services that can edit a message after it was sent.
+//
+// The Update function replaces subject and message of the message identified by a Receipt the service recorded.
+//
+//	E.g. for slack.Slack it edits the posted message in place instead of posting a new one.
+type Updater interface {
+	Update(ctx context.Context, receipt Receipt, subject, message string) error
+}
+
+// update asks the service that sent the message identified by the given receipt to replace its subject and message.
+func (n *Notify) update(ctx context.Context, receipt Receipt, subject, message string) error {
+	if n.Disabled {
+		return nil
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	updater, ok := receipt.Service.(Updater)
+	if !ok {
+		return errors.Wrap(ErrUnsupported, "update notification")
+	}
+
+	return errors.Wrap(updater.Update(ctx, receipt, subject, message), "update notification")
+}
+
+// Update asks the service that sent the message identified by the given receipt to replace its subject and message.
+// It returns ErrUnsupported if the service does not implement Updater.
+func (n *Notify) Update(ctx context.Context, receipt Receipt, subject, message string) error {
+	return n.update(ctx, receipt, subject, message)
+}
+
+// Update asks the service that sent the message identified by the given receipt to replace its subject and message.
+// It returns ErrUnsupported if the service does not implement Updater.
+func Update(ctx context.Context, receipt Receipt, subject, message string) error {
+	return std.Update(ctx, receipt, subject, message)
+}
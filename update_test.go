@@ -0,0 +1,55 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type updateService struct {
+	updated map[string]string
+}
+
+func (s *updateService) Send(context.Context, string, string) error {
+	return nil
+}
+
+func (s *updateService) Update(_ context.Context, receipt Receipt, subject, message string) error {
+	if receipt.MessageID == "" {
+		return errors.New("missing message ID")
+	}
+	s.updated[receipt.MessageID] = subject + "\n" + message
+
+	return nil
+}
+
+func TestNotifyUpdate(t *testing.T) {
+	t.Parallel()
+
+	n := New()
+	ctx := context.Background()
+
+	service := &updateService{updated: make(map[string]string)}
+	if err := n.Update(ctx, Receipt{Service: service, MessageID: "id"}, "subject", "message"); err != nil {
+		t.Fatalf("Update() returned error: %v", err)
+	}
+	if got := service.updated["id"]; got != "subject\nmessage" {
+		t.Errorf("Update() updated message to %q, want %q", got, "subject\nmessage")
+	}
+
+	if err := n.Update(ctx, Receipt{Service: service}, "subject", "message"); err == nil {
+		t.Error("Update() of failing service returned no error")
+	}
+
+	// Services that don't implement Updater are reported as unsupported.
+	err := n.Update(ctx, Receipt{Service: newFailingService(), MessageID: "id"}, "subject", "message")
+	if !errors.Is(err, ErrUnsupported) {
+		t.Errorf("Update() of unsupported service returned %v, want ErrUnsupported", err)
+	}
+
+	// After disabling the Notifier, Update() should return silently.
+	n.WithOptions(Disable)
+	if err = n.Update(ctx, Receipt{Service: service}, "subject", "message"); err != nil {
+		t.Errorf("Update() of disabled Notifier returned error: %v", err)
+	}
+}
@@ -1,10 +1,6 @@
 package notify
 
-import (
-	"testing"
-
-	"github.com/nikoksr/notify/service/mail"
-)
+import "testing"
 
 func TestUseServices(t *testing.T) {
 	t.Parallel()
@@ -17,15 +13,15 @@ func TestUseServices(t *testing.T) {
 		t.Fatalf("Expected len(n.notifiers) == 0, got %d", len(n.notifiers))
 	}
 
-	n.UseServices(mail.New("", ""))
+	n.UseServices(newFailingService())
 
 	if len(n.notifiers) != 1 {
 		t.Errorf("Expected len(n.notifiers) == 1, got %d", len(n.notifiers))
 	}
 
 	n.UseServices(
-		mail.New("", ""),
-		mail.New("", ""),
+		newFailingService(),
+		newFailingService(),
 	)
 
 	if len(n.notifiers) != 3 {
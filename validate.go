@@ -0,0 +1,229 @@
+package notify
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ErrInvalidConfig signals that a service is misconfigured, e.g. because a credential is missing or an address doesn't
+// parse. It is a permanent error.
+var ErrInvalidConfig error = &classError{msg: "invalid configuration", class: ErrPermanent}
+
+// Validator is implemented by services that can check their configuration without sending anything.
+//
+// The Validate function returns an ErrInvalidConfig describing all problems it found, or nil.
+//
+//	E.g. for mail.Mail it checks that the sender and receiver addresses parse.
+//
+// It is implemented by most services: they check that credentials are set, that URLs, addresses and phone numbers
+// parse and that receivers aren't empty. Services that don't implement it are skipped by Validate and reported as
+// skipped by ValidateCommand.
+type Validator interface {
+	Validate() error
+}
+
+// Prober is implemented by services that can check that their provider is reachable and accepts their credentials,
+// without sending a notification.
+//
+//	E.g. for slack.Slack it calls auth.test.
+type Prober interface {
+	Probe(ctx context.Context) error
+}
+
+// ConfigErrors collects the problems found while validating the configuration of a service, see Validator.
+type ConfigErrors struct {
+	problems []string
+}
+
+// Addf adds a problem.
+func (e *ConfigErrors) Addf(format string, args ...any) {
+	e.problems = append(e.problems, fmt.Sprintf(format, args...))
+}
+
+// Check adds err as problem of the named setting, if it is not nil.
+func (e *ConfigErrors) Check(setting string, err error) {
+	if err != nil {
+		e.Addf("%s: %v", setting, err)
+	}
+}
+
+// Require adds a problem if the value of the named setting is empty.
+func (e *ConfigErrors) Require(setting, value string) {
+	if value == "" {
+		e.Addf("%s is missing", setting)
+	}
+}
+
+// Err returns an ErrInvalidConfig describing all problems, or nil if there are none.
+func (e *ConfigErrors) Err() error {
+	if len(e.problems) == 0 {
+		return nil
+	}
+
+	return errors.Wrap(ErrInvalidConfig, strings.Join(e.problems, "; "))
+}
+
+// ValidateURL checks that raw is an absolute http or https URL.
+func ValidateURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return errors.Errorf("URL %q must use http or https", raw)
+	}
+	if u.Host == "" {
+		return errors.Errorf("URL %q has no host", raw)
+	}
+
+	return nil
+}
+
+// ValidateMailAddress checks that address is an RFC 5322 address, e.g. "ops@example.com" or "Ops <ops@example.com>".
+func ValidateMailAddress(address string) error {
+	if _, err := mail.ParseAddress(address); err != nil {
+		return errors.Wrapf(err, "mail address %q", address)
+	}
+
+	return nil
+}
+
+// e164 matches phone numbers in E.164 format.
+var e164 = regexp.MustCompile(`^\+[1-9][0-9]{1,14}$`)
+
+// ValidatePhoneNumber checks that number is a phone number in E.164 format, e.g. "+4915112345678".
+func ValidatePhoneNumber(number string) error {
+	if !e164.MatchString(number) {
+		return errors.Errorf("phone number %q is not in E.164 format", number)
+	}
+
+	return nil
+}
+
+// validate checks the configuration of all services that implement Validator and, if probe is set, reaches out to
+// the providers of all services that implement Prober.
+func (n *Notify) validate(ctx context.Context, probe bool) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	var problems []string
+	for _, service := range n.notifiers {
+		var err error
+		if validator, ok := service.(Validator); ok {
+			err = validator.Validate()
+		}
+		if prober, ok := service.(Prober); ok && probe && err == nil {
+			err = prober.Probe(ctx)
+		}
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%T: %v", service, err))
+		}
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+
+	return errors.Wrap(ErrInvalidConfig, strings.Join(problems, "\n"))
+}
+
+// Validate checks the configuration of all services that implement Validator, so that misconfiguration is caught at
+// startup instead of on the first alert. It returns an ErrInvalidConfig listing the problems of all services, or nil.
+// Services that don't implement Validator are skipped.
+func (n *Notify) Validate(ctx context.Context) error {
+	return n.validate(ctx, false)
+}
+
+// Validate checks the configuration of all services that implement Validator, so that misconfiguration is caught at
+// startup instead of on the first alert. It returns an ErrInvalidConfig listing the problems of all services, or nil.
+// Services that don't implement Validator are skipped.
+func Validate(ctx context.Context) error {
+	return std.Validate(ctx)
+}
+
+// Probe works like Validate, but additionally checks that the providers of all valid services that implement Prober
+// are reachable and accept the credentials. Probing sends no notifications, but may count against rate limits.
+func (n *Notify) Probe(ctx context.Context) error {
+	return n.validate(ctx, true)
+}
+
+// Probe works like Validate, but additionally checks that the providers of all valid services that implement Prober
+// are reachable and accept the credentials. Probing sends no notifications, but may count against rate limits.
+func Probe(ctx context.Context) error {
+	return std.Probe(ctx)
+}
+
+// DefaultProbeTimeout is the default time ValidateCommand waits for providers to respond when probing.
+const DefaultProbeTimeout = 30 * time.Second
+
+// ValidateCommand implements a "notify validate" command for applications that build their Notify with a LoadFunc, e.g.
+// from a config file, so that the configuration can be checked before it is deployed:
+//
+//	if len(os.Args) > 2 && os.Args[1] == "notify" && os.Args[2] == "validate" {
+//		os.Exit(notify.ValidateCommand(ctx, loadNotify, os.Args[3:], os.Stdout))
+//	}
+//
+// It loads the Notify and validates it, see Validate. With the -probe flag, it additionally probes the providers, see
+// Probe, for at most the duration given by -timeout. Problems, and services that were skipped because they don't
+// implement Validator, are reported to out. It returns the exit code of the command: 0 if the configuration is valid,
+// 1 if it isn't or can't be loaded and 2 for invalid arguments.
+func ValidateCommand(ctx context.Context, load LoadFunc, args []string, out io.Writer) int {
+	flags := flag.NewFlagSet("validate", flag.ContinueOnError)
+	flags.SetOutput(out)
+	probe := flags.Bool("probe", false, "check that providers are reachable and accept the credentials")
+	timeout := flags.Duration("timeout", DefaultProbeTimeout, "time to wait for providers when probing")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() > 0 {
+		_, _ = fmt.Fprintf(out, "unexpected arguments: %s\n", strings.Join(flags.Args(), " "))
+		flags.Usage()
+		return 2
+	}
+
+	n, err := load()
+	if err != nil {
+		_, _ = fmt.Fprintf(out, "failed to load configuration: %v\n", err)
+		return 1
+	}
+
+	if *probe {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, *timeout)
+		defer cancel()
+
+		err = n.Probe(ctx)
+	} else {
+		err = n.Validate(ctx)
+	}
+	if err != nil {
+		_, _ = fmt.Fprintln(out, err)
+		return 1
+	}
+
+	var validated int
+	var skipped []string
+	for _, service := range n.notifiers {
+		if _, ok := service.(Validator); ok {
+			validated++
+		} else {
+			skipped = append(skipped, fmt.Sprintf("%T", service))
+		}
+	}
+	_, _ = fmt.Fprintf(out, "configuration of %d services is valid\n", validated)
+	if len(skipped) > 0 {
+		_, _ = fmt.Fprintf(out, "skipped %d services that can't be validated: %s\n", len(skipped),
+			strings.Join(skipped, ", "))
+	}
+
+	return 0
+}
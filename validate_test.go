@@ -0,0 +1,173 @@
+package notify
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+// validatingService validates and probes with the configured errors and records probes.
+type validatingService struct {
+	validateErr error
+	probeErr    error
+	probes      int
+}
+
+func (s *validatingService) Send(context.Context, string, string) error {
+	return nil
+}
+
+func (s *validatingService) Validate() error {
+	return s.validateErr
+}
+
+func (s *validatingService) Probe(context.Context) error {
+	s.probes++
+	return s.probeErr
+}
+
+func TestConfigErrors(t *testing.T) {
+	t.Parallel()
+
+	var problems ConfigErrors
+	if err := problems.Err(); err != nil {
+		t.Errorf("Err() = %v, want nil", err)
+	}
+
+	problems.Require("token", "secret")
+	problems.Check("url", nil)
+	if err := problems.Err(); err != nil {
+		t.Errorf("Err() = %v, want nil", err)
+	}
+
+	problems.Require("token", "")
+	problems.Check("url", errors.New("no host"))
+	problems.Addf("%d receivers", 0)
+
+	err := problems.Err()
+	if !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("Err() = %v, want ErrInvalidConfig", err)
+	}
+	if !errors.Is(err, ErrPermanent) {
+		t.Errorf("Err() = %v, want permanent error", err)
+	}
+	want := "token is missing; url: no host; 0 receivers: invalid configuration"
+	if err.Error() != want {
+		t.Errorf("Err() = %q, want %q", err.Error(), want)
+	}
+}
+
+func TestValidateHelpers(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		err     error
+		wantErr bool
+	}{
+		{name: "URL", err: ValidateURL("https://example.com/hook")},
+		{name: "URL without scheme", err: ValidateURL("example.com/hook"), wantErr: true},
+		{name: "URL with other scheme", err: ValidateURL("ftp://example.com"), wantErr: true},
+		{name: "URL without host", err: ValidateURL("https:///hook"), wantErr: true},
+		{name: "mail address", err: ValidateMailAddress("ops@example.com")},
+		{name: "mail address with name", err: ValidateMailAddress("Ops <ops@example.com>")},
+		{name: "invalid mail address", err: ValidateMailAddress("ops"), wantErr: true},
+		{name: "phone number", err: ValidatePhoneNumber("+4915112345678")},
+		{name: "phone number without plus", err: ValidatePhoneNumber("4915112345678"), wantErr: true},
+		{name: "phone number with spaces", err: ValidatePhoneNumber("+49 151 12345678"), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		if (tt.err != nil) != tt.wantErr {
+			t.Errorf("%s: error = %v, wantErr %v", tt.name, tt.err, tt.wantErr)
+		}
+	}
+}
+
+func TestNotify_Validate(t *testing.T) {
+	t.Parallel()
+
+	valid := &validatingService{}
+	invalid := &validatingService{validateErr: errors.New("token is missing")}
+	unreachable := &validatingService{probeErr: errors.New("connection refused")}
+
+	n := New()
+	n.UseServices(valid, &recordingService{}, unreachable)
+	if err := n.Validate(context.Background()); err != nil {
+		t.Errorf("Validate() = %v, want nil", err)
+	}
+	if valid.probes != 0 || unreachable.probes != 0 {
+		t.Errorf("Validate() probed services")
+	}
+
+	err := n.Probe(context.Background())
+	if !errors.Is(err, ErrInvalidConfig) {
+		t.Fatalf("Probe() = %v, want ErrInvalidConfig", err)
+	}
+	if !strings.Contains(err.Error(), "connection refused") {
+		t.Errorf("Probe() = %v, want probe error", err)
+	}
+
+	// Invalid services aren't probed, and the problems of all services are reported.
+	n.UseServices(invalid)
+	err = n.Probe(context.Background())
+	if !strings.Contains(err.Error(), "token is missing") || !strings.Contains(err.Error(), "connection refused") {
+		t.Errorf("Probe() = %v, want errors of all services", err)
+	}
+	if invalid.probes != 0 {
+		t.Errorf("Probe() probed invalid service")
+	}
+}
+
+func TestValidateCommand(t *testing.T) {
+	t.Parallel()
+
+	unreachable := &validatingService{probeErr: errors.New("connection refused")}
+	load := func() (*Notify, error) {
+		n := New()
+		n.UseServices(&validatingService{}, unreachable)
+		return n, nil
+	}
+	loadUnvalidated := func() (*Notify, error) {
+		n := New()
+		n.UseServices(&validatingService{}, &recordingService{})
+		return n, nil
+	}
+
+	tests := []struct {
+		name     string
+		load     LoadFunc
+		args     []string
+		wantCode int
+		wantOut  string
+	}{
+		{name: "valid", load: load, wantCode: 0, wantOut: "configuration of 2 services is valid"},
+		{
+			name:     "skipped",
+			load:     loadUnvalidated,
+			wantCode: 0,
+			wantOut:  "skipped 1 services that can't be validated: *notify.recordingService",
+		},
+		{name: "probe", load: load, args: []string{"-probe", "-timeout", "1s"}, wantCode: 1, wantOut: "connection refused"},
+		{
+			name:     "load error",
+			load:     func() (*Notify, error) { return nil, errors.New("no such file") },
+			wantCode: 1,
+			wantOut:  "failed to load configuration: no such file",
+		},
+		{name: "unknown flag", load: load, args: []string{"-verbose"}, wantCode: 2, wantOut: "-probe"},
+		{name: "unexpected argument", load: load, args: []string{"now"}, wantCode: 2, wantOut: "unexpected arguments: now"},
+	}
+
+	for _, tt := range tests {
+		var out strings.Builder
+		if code := ValidateCommand(context.Background(), tt.load, tt.args, &out); code != tt.wantCode {
+			t.Errorf("%s: ValidateCommand() = %d, want %d", tt.name, code, tt.wantCode)
+		}
+		if !strings.Contains(out.String(), tt.wantOut) {
+			t.Errorf("%s: ValidateCommand() wrote %q, want %q", tt.name, out.String(), tt.wantOut)
+		}
+	}
+}